@@ -4,8 +4,11 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
+
+	"iksoon_account_backend/utils"
 )
 
 // Config 애플리케이션 설정 구조체
@@ -21,6 +24,38 @@ type Config struct {
 
 	// 기타 설정
 	MaxConnections int `env:"MAX_CONNECTIONS"`
+
+	// 정기 거래 자동 생성 주기 (분, 0이면 비활성화)
+	RecurringIntervalMinutes int `env:"RECURRING_INTERVAL_MINUTES"`
+
+	// 인증 설정 (AUTH_ENABLED=true 이면 로그인 없이 API 호출 불가)
+	AuthEnabled     bool `env:"AUTH_ENABLED"`
+	SessionTTLHours int  `env:"SESSION_TTL_HOURS"`
+
+	// CORS 허용 Origin (기본값은 개발용 프론트엔드 주소, '*' 는 모든 Origin 허용이므로 사용 비권장)
+	CorsAllowedOrigin string `env:"CORS_ALLOWED_ORIGIN"`
+
+	// X-Real-IP 헤더를 믿을 프록시 주소 (쉼표로 구분한 IP/CIDR, 비어 있으면 헤더를 무시하고 접속 주소 사용)
+	TrustedProxies string `env:"TRUSTED_PROXIES"`
+
+	// 백업 설정 (BACKUP_DIR 이 비어있으면 DB 파일 옆의 backups 디렉토리 사용)
+	BackupDir           string `env:"BACKUP_DIR"`
+	BackupIntervalHours int    `env:"BACKUP_INTERVAL_HOURS"` // 자동 백업 주기 (시간, 0이면 비활성화)
+	BackupRetention     int    `env:"BACKUP_RETENTION"`      // 보관할 자동 백업 개수
+
+	// 첨부파일 설정 (ATTACHMENT_DIR 이 비어있으면 DB 파일 옆의 attachments 디렉토리 사용)
+	AttachmentDir       string `env:"ATTACHMENT_DIR"`
+	AttachmentMaxSizeMB int    `env:"ATTACHMENT_MAX_SIZE_MB"` // 첨부파일 1개 최대 크기 (MB)
+
+	// 알림 설정 (기준치 알림 확인 및 발송 대기열 처리 주기, 0이면 비활성화)
+	NotificationIntervalSeconds int `env:"NOTIFICATION_INTERVAL_SECONDS"`
+
+	// 이메일 알림용 SMTP 설정 (SMTP_HOST 가 비어있으면 이메일 채널 발송 실패로 기록)
+	SMTPHost     string `env:"SMTP_HOST"`
+	SMTPPort     string `env:"SMTP_PORT"`
+	SMTPUsername string `env:"SMTP_USERNAME"`
+	SMTPPassword string `env:"SMTP_PASSWORD"`
+	SMTPFrom     string `env:"SMTP_FROM"`
 }
 
 var (
@@ -37,6 +72,20 @@ func GetConfig() *Config {
 			DBPath:         "./data/account_app.db",
 			LogLevel:       "INFO",
 			MaxConnections: 100,
+
+			RecurringIntervalMinutes: 60,
+
+			AuthEnabled:       false,
+			SessionTTLHours:   720,
+			CorsAllowedOrigin: "http://localhost:3000",
+
+			BackupIntervalHours: 24,
+			BackupRetention:     7,
+
+			AttachmentMaxSizeMB: 10,
+
+			NotificationIntervalSeconds: 60,
+			SMTPPort:                    "587",
 		}
 		instance.loadFromEnvFile()
 		instance.loadFromEnvironment()
@@ -106,6 +155,84 @@ func (c *Config) loadFromEnvironment() {
 	if logLevel := os.Getenv("LOG_LEVEL"); logLevel != "" {
 		c.LogLevel = logLevel
 	}
+
+	if interval := os.Getenv("RECURRING_INTERVAL_MINUTES"); interval != "" {
+		if minutes, err := strconv.Atoi(interval); err == nil {
+			c.RecurringIntervalMinutes = minutes
+		}
+	}
+
+	if authEnabled := os.Getenv("AUTH_ENABLED"); authEnabled != "" {
+		if enabled, err := strconv.ParseBool(authEnabled); err == nil {
+			c.AuthEnabled = enabled
+		}
+	}
+
+	if ttl := os.Getenv("SESSION_TTL_HOURS"); ttl != "" {
+		if hours, err := strconv.Atoi(ttl); err == nil {
+			c.SessionTTLHours = hours
+		}
+	}
+
+	if origin := os.Getenv("CORS_ALLOWED_ORIGIN"); origin != "" {
+		c.CorsAllowedOrigin = origin
+	}
+
+	if proxies := os.Getenv("TRUSTED_PROXIES"); proxies != "" {
+		c.TrustedProxies = proxies
+	}
+
+	if backupDir := os.Getenv("BACKUP_DIR"); backupDir != "" {
+		c.BackupDir = backupDir
+	}
+
+	if interval := os.Getenv("BACKUP_INTERVAL_HOURS"); interval != "" {
+		if hours, err := strconv.Atoi(interval); err == nil {
+			c.BackupIntervalHours = hours
+		}
+	}
+
+	if retention := os.Getenv("BACKUP_RETENTION"); retention != "" {
+		if count, err := strconv.Atoi(retention); err == nil {
+			c.BackupRetention = count
+		}
+	}
+
+	if attachmentDir := os.Getenv("ATTACHMENT_DIR"); attachmentDir != "" {
+		c.AttachmentDir = attachmentDir
+	}
+
+	if maxSize := os.Getenv("ATTACHMENT_MAX_SIZE_MB"); maxSize != "" {
+		if mb, err := strconv.Atoi(maxSize); err == nil {
+			c.AttachmentMaxSizeMB = mb
+		}
+	}
+
+	if interval := os.Getenv("NOTIFICATION_INTERVAL_SECONDS"); interval != "" {
+		if seconds, err := strconv.Atoi(interval); err == nil {
+			c.NotificationIntervalSeconds = seconds
+		}
+	}
+
+	if host := os.Getenv("SMTP_HOST"); host != "" {
+		c.SMTPHost = host
+	}
+
+	if port := os.Getenv("SMTP_PORT"); port != "" {
+		c.SMTPPort = port
+	}
+
+	if username := os.Getenv("SMTP_USERNAME"); username != "" {
+		c.SMTPUsername = username
+	}
+
+	if password := os.Getenv("SMTP_PASSWORD"); password != "" {
+		c.SMTPPassword = password
+	}
+
+	if from := os.Getenv("SMTP_FROM"); from != "" {
+		c.SMTPFrom = from
+	}
 }
 
 // GetDBPath DB 파일 경로 반환 (디렉토리 자동 생성)
@@ -123,6 +250,45 @@ func (c *Config) GetDBPath() string {
 	return dbPath
 }
 
+// GetBackupDir 백업 디렉토리 경로 반환 (미설정 시 DB 파일 옆의 backups 디렉토리)
+func (c *Config) GetBackupDir() string {
+	backupDir := c.BackupDir
+	if backupDir == "" {
+		backupDir = "backups"
+		if strings.Contains(c.DBPath, "/") {
+			backupDir = c.DBPath[:strings.LastIndex(c.DBPath, "/")] + "/backups"
+		}
+	}
+
+	if err := os.MkdirAll(backupDir, 0755); err != nil {
+		fmt.Printf("백업 디렉토리 생성 실패: %v\n", err)
+	}
+
+	return backupDir
+}
+
+// GetAttachmentDir 첨부파일 디렉토리 경로 반환 (미설정 시 DB 파일 옆의 attachments 디렉토리)
+func (c *Config) GetAttachmentDir() string {
+	attachmentDir := c.AttachmentDir
+	if attachmentDir == "" {
+		attachmentDir = "attachments"
+		if strings.Contains(c.DBPath, "/") {
+			attachmentDir = c.DBPath[:strings.LastIndex(c.DBPath, "/")] + "/attachments"
+		}
+	}
+
+	if err := os.MkdirAll(attachmentDir, 0755); err != nil {
+		fmt.Printf("첨부파일 디렉토리 생성 실패: %v\n", err)
+	}
+
+	return attachmentDir
+}
+
+// GetAttachmentMaxSize 첨부파일 1개 최대 크기 (바이트)
+func (c *Config) GetAttachmentMaxSize() int64 {
+	return int64(c.AttachmentMaxSizeMB) << 20
+}
+
 // Validate 설정값 유효성 검사
 func (c *Config) Validate() error {
 	if c.Port == "" {
@@ -147,6 +313,34 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("유효하지 않은 LOG_LEVEL: %s (사용 가능: %v)", c.LogLevel, validLogLevels)
 	}
 
+	if c.RecurringIntervalMinutes < 0 {
+		return fmt.Errorf("RECURRING_INTERVAL_MINUTES는 0 이상이어야 합니다")
+	}
+
+	if c.SessionTTLHours <= 0 {
+		return fmt.Errorf("SESSION_TTL_HOURS는 1 이상이어야 합니다")
+	}
+
+	if _, err := utils.ParseTrustedProxies(c.TrustedProxies); err != nil {
+		return fmt.Errorf("TRUSTED_PROXIES 설정 오류: %v", err)
+	}
+
+	if c.BackupIntervalHours < 0 {
+		return fmt.Errorf("BACKUP_INTERVAL_HOURS는 0 이상이어야 합니다")
+	}
+
+	if c.BackupRetention < 1 {
+		return fmt.Errorf("BACKUP_RETENTION은 1 이상이어야 합니다")
+	}
+
+	if c.AttachmentMaxSizeMB < 1 {
+		return fmt.Errorf("ATTACHMENT_MAX_SIZE_MB는 1 이상이어야 합니다")
+	}
+
+	if c.NotificationIntervalSeconds < 0 {
+		return fmt.Errorf("NOTIFICATION_INTERVAL_SECONDS는 0 이상이어야 합니다")
+	}
+
 	return nil
 }
 
@@ -157,5 +351,29 @@ func (c *Config) PrintConfig() {
 	fmt.Printf("DB Path: %s\n", c.DBPath)
 	fmt.Printf("Log Level: %s\n", c.LogLevel)
 	fmt.Printf("Max Connections: %d\n", c.MaxConnections)
+	fmt.Printf("Recurring Interval: %d분\n", c.RecurringIntervalMinutes)
+	fmt.Printf("Auth Enabled: %v (세션 %d시간)\n", c.AuthEnabled, c.SessionTTLHours)
+	fmt.Printf("CORS Allowed Origin: %s\n", c.CorsAllowedOrigin)
+	fmt.Printf("Trusted Proxies: %s\n", c.TrustedProxies)
+	fmt.Printf("Backup: %s (%d시간 간격, %d개 보관)\n", c.GetBackupDir(), c.BackupIntervalHours, c.BackupRetention)
+	fmt.Printf("Attachments: %s (최대 %dMB)\n", c.GetAttachmentDir(), c.AttachmentMaxSizeMB)
+	fmt.Printf("Notifications: %d초 간격, SMTP=%s:%s\n", c.NotificationIntervalSeconds, c.SMTPHost, c.SMTPPort)
 	fmt.Println("========================")
 }
+
+// GetSMTPConfig 이메일 알림 발송용 SMTP 설정 반환
+func (c *Config) GetSMTPConfig() utils.SMTPConfig {
+	return utils.SMTPConfig{
+		Host:     c.SMTPHost,
+		Port:     c.SMTPPort,
+		Username: c.SMTPUsername,
+		Password: c.SMTPPassword,
+		From:     c.SMTPFrom,
+	}
+}
+
+// GetTrustedProxies X-Real-IP 헤더를 믿을 프록시 주소 목록 반환 (Validate 에서 형식 검사)
+func (c *Config) GetTrustedProxies() utils.TrustedProxies {
+	proxies, _ := utils.ParseTrustedProxies(c.TrustedProxies)
+	return proxies
+}
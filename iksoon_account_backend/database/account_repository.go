@@ -0,0 +1,574 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// createAccountTables 계좌/잔액 대조 테이블 생성 및 결제수단/입금경로에 계좌 연결 컬럼 추가
+// account_id 는 DROP COLUMN 으로 되돌릴 수 있도록 외래키 없이 추가하고 연결 시 가계부 범위를 검사
+func createAccountTables(exec sqlExecutor) error {
+	createAccountTable := `
+    CREATE TABLE IF NOT EXISTS accounts (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        ledger_id INTEGER NOT NULL,
+        name VARCHAR(100) NOT NULL,
+        type VARCHAR(10) NOT NULL DEFAULT 'bank' CHECK (type IN ('bank', 'card', 'cash', 'other')),
+        opening_balance INT NOT NULL DEFAULT 0,
+        opening_date TEXT NOT NULL,
+        created_at TEXT DEFAULT CURRENT_TIMESTAMP,
+        updated_at TEXT DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (ledger_id) REFERENCES ledgers(id) ON DELETE CASCADE,
+        UNIQUE(ledger_id, name)
+    );`
+
+	if _, err := exec.Exec(createAccountTable); err != nil {
+		return fmt.Errorf("계좌 테이블 생성 오류: %v", err)
+	}
+
+	createReconciliationTable := `
+    CREATE TABLE IF NOT EXISTS account_reconciliations (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        account_id INTEGER NOT NULL,
+        date TEXT NOT NULL,
+        statement_balance INT NOT NULL,
+        computed_balance INT NOT NULL,
+        difference INT NOT NULL,
+        memo TEXT DEFAULT '',
+        created_at TEXT DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE
+    );`
+
+	if _, err := exec.Exec(createReconciliationTable); err != nil {
+		return fmt.Errorf("잔액 대조 테이블 생성 오류: %v", err)
+	}
+
+	indexes := []string{
+		`CREATE INDEX IF NOT EXISTS idx_accounts_ledger ON accounts(ledger_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_account_reconciliations_account ON account_reconciliations(account_id, date)`,
+	}
+	for _, index := range indexes {
+		if _, err := exec.Exec(index); err != nil {
+			return fmt.Errorf("계좌 인덱스 생성 오류: %v", err)
+		}
+	}
+
+	if _, err := addColumnIfNotExists(exec, "payment_methods", "account_id", "INTEGER NULL"); err != nil {
+		return err
+	}
+	_, err := addColumnIfNotExists(exec, "deposit_paths", "account_id", "INTEGER NULL")
+	return err
+}
+
+// dropAccountTables 계좌 연결 컬럼과 계좌/잔액 대조 테이블 제거
+func dropAccountTables(exec sqlExecutor) error {
+	steps := []string{
+		`ALTER TABLE payment_methods DROP COLUMN account_id`,
+		`ALTER TABLE deposit_paths DROP COLUMN account_id`,
+	}
+	for _, step := range steps {
+		if _, err := exec.Exec(step); err != nil {
+			return fmt.Errorf("계좌 연결 컬럼 제거 오류: %v", err)
+		}
+	}
+	return dropTables("account_reconciliations", "accounts")(exec)
+}
+
+// accountSelectQuery 계좌 조회 공통 쿼리
+const accountSelectQuery = `
+    SELECT id, ledger_id, name, type, opening_balance, opening_date, created_at, updated_at
+    FROM accounts`
+
+// scanAccount 계좌 행 스캔
+func scanAccount(scanner interface{ Scan(...interface{}) error }) (*models.Account, error) {
+	var account models.Account
+	var createdAt, updatedAt string
+
+	err := scanner.Scan(&account.ID, &account.LedgerID, &account.Name, &account.Type,
+		&account.OpeningBalance, &account.OpeningDate, &createdAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	// 시간 파싱
+	if account.CreatedAt, err = time.Parse("2006-01-02 15:04:05", createdAt); err != nil {
+		account.CreatedAt = time.Now()
+	}
+	if account.UpdatedAt, err = time.Parse("2006-01-02 15:04:05", updatedAt); err != nil {
+		account.UpdatedAt = time.Now()
+	}
+
+	return &account, nil
+}
+
+// GetAccounts 가계부의 계좌 목록 조회 (연결된 결제수단/입금경로와 현재 잔액 포함)
+func (db *DB) GetAccounts(ledgerID int) ([]models.Account, error) {
+	rows, err := db.Conn.Query(accountSelectQuery+` WHERE ledger_id = ? ORDER BY name ASC`, ledgerID)
+	if err != nil {
+		return nil, fmt.Errorf("계좌 목록 조회 오류: %v", err)
+	}
+
+	accounts := []models.Account{}
+	for rows.Next() {
+		account, err := scanAccount(rows)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("계좌 데이터 읽기 오류: %v", err)
+		}
+		accounts = append(accounts, *account)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("계좌 목록 조회 오류: %v", err)
+	}
+
+	today := utils.FormatDateKST(utils.GetCurrentKST())
+	for i := range accounts {
+		if err := db.fillAccountDetails(&accounts[i], today); err != nil {
+			return nil, err
+		}
+	}
+	return accounts, nil
+}
+
+// GetAccountByID ID로 계좌 조회 (연결된 결제수단/입금경로와 현재 잔액 포함)
+func (db *DB) GetAccountByID(ledgerID int, id int) (*models.Account, error) {
+	account, err := db.getAccount(ledgerID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.fillAccountDetails(account, utils.FormatDateKST(utils.GetCurrentKST())); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// getAccount 계좌 기본 정보만 조회
+func (db *DB) getAccount(ledgerID int, id int) (*models.Account, error) {
+	account, err := scanAccount(db.Conn.QueryRow(accountSelectQuery+` WHERE id = ? AND ledger_id = ?`, id, ledgerID))
+	if err == sql.ErrNoRows {
+		return nil, apiErrors.ErrBankAccountNotFound.WithMessage("계좌를 찾을 수 없습니다")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("계좌 조회 오류: %v", err)
+	}
+	return account, nil
+}
+
+// fillAccountDetails 연결된 결제수단/입금경로 ID 와 기준일 잔액 채우기
+func (db *DB) fillAccountDetails(account *models.Account, date string) error {
+	var err error
+	if account.PaymentMethodIDs, err = queryIDs(db.Conn, `SELECT id FROM payment_methods WHERE account_id = ? ORDER BY id`, account.ID); err != nil {
+		return fmt.Errorf("계좌 연결 결제수단 조회 오류: %v", err)
+	}
+	if account.DepositPathIDs, err = queryIDs(db.Conn, `SELECT id FROM deposit_paths WHERE account_id = ? ORDER BY id`, account.ID); err != nil {
+		return fmt.Errorf("계좌 연결 입금경로 조회 오류: %v", err)
+	}
+
+	account.Balance = account.OpeningBalance
+	if date < account.OpeningDate {
+		return nil
+	}
+	totals, err := accountTotals(db.Conn, account, account.OpeningDate, date)
+	if err != nil {
+		return err
+	}
+	account.Balance += netMovement(totals)
+	return nil
+}
+
+// queryIDs 정수 ID 목록 조회
+func queryIDs(exec sqlExecutor, query string, args ...interface{}) ([]int, error) {
+	rows, err := exec.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// CreateAccount 계좌 생성
+func (db *DB) CreateAccount(ledgerID int, req models.AccountRequest) (int64, error) {
+	if err := normalizeAccountRequest(&req); err != nil {
+		return 0, err
+	}
+
+	query := `
+		INSERT INTO accounts (ledger_id, name, type, opening_balance, opening_date, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+
+	result, err := db.Conn.Exec(query, ledgerID, req.Name, req.Type, req.OpeningBalance, req.OpeningDate)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return 0, apiErrors.ErrAlreadyExists.WithMessage("이미 존재하는 계좌 이름입니다")
+		}
+		return 0, fmt.Errorf("계좌 생성 오류: %v", err)
+	}
+
+	return result.LastInsertId()
+}
+
+// UpdateAccount 계좌 수정 (개시 잔액/개시일을 바꾸면 잔액이 다시 계산됨)
+func (db *DB) UpdateAccount(ledgerID int, id int, req models.AccountRequest) error {
+	if err := normalizeAccountRequest(&req); err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE accounts
+		SET name = ?, type = ?, opening_balance = ?, opening_date = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND ledger_id = ?`
+
+	result, err := db.Conn.Exec(query, req.Name, req.Type, req.OpeningBalance, req.OpeningDate, id, ledgerID)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return apiErrors.ErrAlreadyExists.WithMessage("이미 존재하는 계좌 이름입니다")
+		}
+		return fmt.Errorf("계좌 수정 오류: %v", err)
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return apiErrors.ErrBankAccountNotFound.WithMessage("계좌를 찾을 수 없습니다")
+	}
+	return nil
+}
+
+// DeleteAccount 계좌 삭제 (결제수단/입금경로 연결 해제, 잔액 대조 기록 함께 삭제 - 거래 데이터는 유지, 이체 내역이 있으면 거부)
+func (db *DB) DeleteAccount(ledgerID int, id int) error {
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	if err := ensureLedgerRow(tx, "accounts", id, ledgerID); err != nil {
+		return err
+	}
+
+	// 이체 내역은 두 계좌의 잔액에 모두 반영되므로 계좌만 지울 수 없음
+	transferCount, err := countAccountTransfers(tx, id)
+	if err != nil {
+		return err
+	}
+	if transferCount > 0 {
+		return apiErrors.ErrInvalidBankAccountData.WithMessage(fmt.Sprintf("이체 내역이 있는 계좌는 삭제할 수 없습니다 (이체 %d건)", transferCount))
+	}
+
+	steps := []string{
+		`UPDATE payment_methods SET account_id = NULL, updated_at = CURRENT_TIMESTAMP WHERE account_id = ?`,
+		`UPDATE deposit_paths SET account_id = NULL, updated_at = CURRENT_TIMESTAMP WHERE account_id = ?`,
+		`DELETE FROM account_reconciliations WHERE account_id = ?`,
+		`DELETE FROM accounts WHERE id = ?`,
+	}
+	for _, step := range steps {
+		if _, err := tx.Exec(step, id); err != nil {
+			return fmt.Errorf("계좌 삭제 오류: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("트랜잭션 커밋 오류: %v", err)
+	}
+	return nil
+}
+
+// LinkAccount 결제수단 또는 입금경로를 계좌에 연결 (account_id 가 nil 이면 연결 해제)
+func (db *DB) LinkAccount(ledgerID int, req models.AccountLinkRequest) error {
+	if (req.PaymentMethodID == nil) == (req.DepositPathID == nil) {
+		return apiErrors.ErrInvalidBankAccountData.WithMessage("payment_method_id 또는 deposit_path_id 중 하나만 지정해야 합니다")
+	}
+	if req.AccountID != nil {
+		if err := ensureLedgerRow(db.Conn, "accounts", *req.AccountID, ledgerID); err != nil {
+			return err
+		}
+	}
+
+	table, id := "payment_methods", req.PaymentMethodID
+	if req.DepositPathID != nil {
+		table, id = "deposit_paths", req.DepositPathID
+	}
+	if err := ensureLedgerRow(db.Conn, table, *id, ledgerID); err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`UPDATE %s SET account_id = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND ledger_id = ?`, table)
+	if _, err := db.Conn.Exec(query, req.AccountID, *id, ledgerID); err != nil {
+		return fmt.Errorf("계좌 연결 오류: %v", err)
+	}
+	return nil
+}
+
+// GetAccountBalance 기준일(해당 일자 거래 포함)까지의 계좌 잔액 조회
+func (db *DB) GetAccountBalance(ledgerID int, id int, date string) (*models.AccountBalance, error) {
+	account, err := db.getAccount(ledgerID, id)
+	if err != nil {
+		return nil, err
+	}
+	if date < account.OpeningDate {
+		return nil, apiErrors.ErrInvalidDateRange.WithMessage(fmt.Sprintf("기준일은 계좌 개시일(%s) 이후여야 합니다", account.OpeningDate))
+	}
+
+	totals, err := accountTotals(db.Conn, account, account.OpeningDate, date)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AccountBalance{
+		AccountID:        account.ID,
+		AccountName:      account.Name,
+		OpeningBalance:   account.OpeningBalance,
+		OpeningDate:      account.OpeningDate,
+		Date:             date,
+		TotalIncome:      totals.Income,
+		TotalExpense:     totals.Expense,
+		TotalTransferIn:  totals.TransferIn,
+		TotalTransferOut: totals.TransferOut,
+		Balance:          account.OpeningBalance + netMovement(totals),
+	}, nil
+}
+
+// GetAccountBalanceHistory 기간 내 거래가 있었던 날짜별 잔액 추이 조회
+// 시작일이 개시일보다 이르면 개시일부터 계산
+func (db *DB) GetAccountBalanceHistory(ledgerID int, id int, startDate, endDate string) (*models.AccountBalanceHistory, error) {
+	account, err := db.getAccount(ledgerID, id)
+	if err != nil {
+		return nil, err
+	}
+	if startDate == "" || startDate < account.OpeningDate {
+		startDate = account.OpeningDate
+	}
+	if endDate < startDate {
+		return nil, apiErrors.ErrInvalidDateRange.WithMessage(fmt.Sprintf("종료일은 시작일(%s) 이후여야 합니다", startDate))
+	}
+
+	// 시작일 이전까지의 잔액
+	startBalance := account.OpeningBalance
+	if startDate > account.OpeningDate {
+		totals, err := accountTotals(db.Conn, account, account.OpeningDate, previousDate(startDate))
+		if err != nil {
+			return nil, err
+		}
+		startBalance += netMovement(totals)
+	}
+
+	points, err := accountDailyMovements(db.Conn, account, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	balance := startBalance
+	for i := range points {
+		balance += netMovement(points[i])
+		points[i].Balance = balance
+	}
+
+	return &models.AccountBalanceHistory{
+		AccountID:    account.ID,
+		AccountName:  account.Name,
+		StartDate:    startDate,
+		EndDate:      endDate,
+		StartBalance: startBalance,
+		EndBalance:   balance,
+		Points:       points,
+	}, nil
+}
+
+// ReconcileAccount 실제 잔액을 입력받아 계산 잔액과 비교하고 대조 기록 저장
+func (db *DB) ReconcileAccount(ledgerID int, req models.AccountReconciliationRequest) (*models.AccountReconciliation, error) {
+	if _, err := time.Parse("2006-01-02", req.Date); err != nil {
+		return nil, apiErrors.ErrInvalidBankAccountData.WithMessage("date는 YYYY-MM-DD 형식이어야 합니다")
+	}
+
+	balance, err := db.GetAccountBalance(ledgerID, req.AccountID, req.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO account_reconciliations (account_id, date, statement_balance, computed_balance, difference, memo, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)`
+
+	result, err := db.Conn.Exec(query, req.AccountID, req.Date, req.StatementBalance,
+		balance.Balance, req.StatementBalance-balance.Balance, strings.TrimSpace(req.Memo))
+	if err != nil {
+		return nil, fmt.Errorf("잔액 대조 기록 저장 오류: %v", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("잔액 대조 기록 ID 조회 오류: %v", err)
+	}
+
+	reconciliation, err := scanAccountReconciliation(db.Conn.QueryRow(reconciliationSelectQuery+` WHERE id = ?`, id))
+	if err != nil {
+		return nil, fmt.Errorf("잔액 대조 기록 조회 오류: %v", err)
+	}
+	return reconciliation, nil
+}
+
+// GetAccountReconciliations 계좌의 잔액 대조 기록 조회 (최신순)
+func (db *DB) GetAccountReconciliations(ledgerID int, accountID int) ([]models.AccountReconciliation, error) {
+	if err := ensureLedgerRow(db.Conn, "accounts", accountID, ledgerID); err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Conn.Query(reconciliationSelectQuery+` WHERE account_id = ? ORDER BY date DESC, id DESC`, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("잔액 대조 기록 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	reconciliations := []models.AccountReconciliation{}
+	for rows.Next() {
+		reconciliation, err := scanAccountReconciliation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("잔액 대조 기록 읽기 오류: %v", err)
+		}
+		reconciliations = append(reconciliations, *reconciliation)
+	}
+
+	return reconciliations, rows.Err()
+}
+
+// reconciliationSelectQuery 잔액 대조 기록 조회 공통 쿼리
+const reconciliationSelectQuery = `
+    SELECT id, account_id, date, statement_balance, computed_balance, difference, COALESCE(memo, ''), created_at
+    FROM account_reconciliations`
+
+// scanAccountReconciliation 잔액 대조 기록 행 스캔
+func scanAccountReconciliation(scanner interface{ Scan(...interface{}) error }) (*models.AccountReconciliation, error) {
+	var reconciliation models.AccountReconciliation
+	var createdAt string
+
+	err := scanner.Scan(&reconciliation.ID, &reconciliation.AccountID, &reconciliation.Date, &reconciliation.StatementBalance,
+		&reconciliation.ComputedBalance, &reconciliation.Difference, &reconciliation.Memo, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	// 시간 파싱
+	if reconciliation.CreatedAt, err = time.Parse("2006-01-02 15:04:05", createdAt); err != nil {
+		reconciliation.CreatedAt = time.Now()
+	}
+
+	return &reconciliation, nil
+}
+
+// accountMovementQuery 계좌에 연결된 입금/지출 거래와 계좌 간 이체 (하위 결제수단은 연결이 없으면 상위 결제수단의 계좌를 따름)
+// 인자: 계좌 ID, 가계부 ID, 시작일, 종료일 (입금, 지출, 이체 입금, 이체 출금 순서로 네 번)
+const accountMovementQuery = `
+    SELECT DATE(ia.date) AS day, ia.money AS income, 0 AS expense, 0 AS transfer_in, 0 AS transfer_out
+    FROM in_account_data ia
+    JOIN deposit_paths dp ON ia.deposit_path_id = dp.id
+    WHERE dp.account_id = ? AND ia.ledger_id = ? AND DATE(ia.date) >= ? AND DATE(ia.date) <= ?
+    UNION ALL
+    SELECT DATE(oa.date) AS day, 0 AS income, oa.money AS expense, 0 AS transfer_in, 0 AS transfer_out
+    FROM out_account_data oa
+    JOIN payment_methods pm ON oa.payment_method_id = pm.id
+    LEFT JOIN payment_methods parent ON pm.parent_id = parent.id
+    WHERE COALESCE(pm.account_id, parent.account_id) = ? AND oa.ledger_id = ? AND DATE(oa.date) >= ? AND DATE(oa.date) <= ?
+    UNION ALL
+    SELECT DATE(t.date) AS day, 0 AS income, 0 AS expense, t.money AS transfer_in, 0 AS transfer_out
+    FROM transfers t
+    WHERE t.to_account_id = ? AND t.ledger_id = ? AND DATE(t.date) >= ? AND DATE(t.date) <= ?
+    UNION ALL
+    SELECT DATE(t.date) AS day, 0 AS income, 0 AS expense, 0 AS transfer_in, t.money AS transfer_out
+    FROM transfers t
+    WHERE t.from_account_id = ? AND t.ledger_id = ? AND DATE(t.date) >= ? AND DATE(t.date) <= ?`
+
+// accountMovementArgs 계좌 거래 쿼리 인자 생성
+func accountMovementArgs(account *models.Account, startDate, endDate string) []interface{} {
+	args := make([]interface{}, 0, 16)
+	for i := 0; i < 4; i++ {
+		args = append(args, account.ID, account.LedgerID, startDate, endDate)
+	}
+	return args
+}
+
+// accountTotals 기간 내 계좌 입금/지출/이체 합계
+func accountTotals(exec sqlExecutor, account *models.Account, startDate, endDate string) (models.AccountBalancePoint, error) {
+	query := `SELECT COALESCE(SUM(income), 0), COALESCE(SUM(expense), 0), COALESCE(SUM(transfer_in), 0), COALESCE(SUM(transfer_out), 0)
+    FROM (` + accountMovementQuery + `)`
+
+	var totals models.AccountBalancePoint
+	err := exec.QueryRow(query, accountMovementArgs(account, startDate, endDate)...).Scan(&totals.Income, &totals.Expense, &totals.TransferIn, &totals.TransferOut)
+	if err != nil {
+		return totals, fmt.Errorf("계좌 잔액 계산 오류: %v", err)
+	}
+	return totals, nil
+}
+
+// netMovement 입금/지출/이체 합계의 순 증감액
+func netMovement(point models.AccountBalancePoint) int {
+	return point.Income - point.Expense + point.TransferIn - point.TransferOut
+}
+
+// accountDailyMovements 기간 내 날짜별 계좌 입금/지출/이체 합계 (거래가 있는 날짜만, 날짜 오름차순)
+func accountDailyMovements(exec sqlExecutor, account *models.Account, startDate, endDate string) ([]models.AccountBalancePoint, error) {
+	query := `SELECT day, SUM(income), SUM(expense), SUM(transfer_in), SUM(transfer_out)
+    FROM (` + accountMovementQuery + `) GROUP BY day ORDER BY day ASC`
+
+	rows, err := exec.Query(query, accountMovementArgs(account, startDate, endDate)...)
+	if err != nil {
+		return nil, fmt.Errorf("계좌 잔액 추이 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	points := []models.AccountBalancePoint{}
+	for rows.Next() {
+		var point models.AccountBalancePoint
+		if err := rows.Scan(&point.Date, &point.Income, &point.Expense, &point.TransferIn, &point.TransferOut); err != nil {
+			return nil, fmt.Errorf("계좌 잔액 추이 읽기 오류: %v", err)
+		}
+		points = append(points, point)
+	}
+	return points, rows.Err()
+}
+
+// normalizeAccountRequest 계좌 요청 기본값 보정 및 유효성 검사
+func normalizeAccountRequest(req *models.AccountRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Type == "" {
+		req.Type = models.AccountTypeBank
+	}
+	if req.OpeningDate == "" {
+		req.OpeningDate = utils.FormatDateKST(utils.GetCurrentKST())
+	}
+
+	if req.Name == "" {
+		return apiErrors.ErrMissingRequired.WithMessage("계좌 이름은 필수입니다")
+	}
+	switch req.Type {
+	case models.AccountTypeBank, models.AccountTypeCard, models.AccountTypeCash, models.AccountTypeOther:
+	default:
+		return apiErrors.ErrInvalidBankAccountData.WithMessage("type은 'bank', 'card', 'cash', 'other' 중 하나여야 합니다")
+	}
+	if _, err := time.Parse("2006-01-02", req.OpeningDate); err != nil {
+		return apiErrors.ErrInvalidBankAccountData.WithMessage("opening_date는 YYYY-MM-DD 형식이어야 합니다")
+	}
+	return nil
+}
+
+// previousDate YYYY-MM-DD 날짜의 전날
+func previousDate(date string) string {
+	parsed, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+	return parsed.AddDate(0, 0, -1).Format("2006-01-02")
+}
@@ -0,0 +1,381 @@
+package database
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+
+	"github.com/google/uuid"
+)
+
+// maxAttachmentsPerTransaction 거래 1건에 첨부할 수 있는 최대 파일 수
+const maxAttachmentsPerTransaction = 10
+
+// attachmentExtensions 저장 파일 확장자 (허용 MIME 타입별)
+var attachmentExtensions = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+	"image/heic":      ".heic",
+	"application/pdf": ".pdf",
+}
+
+// createAttachmentTable 첨부파일 메타데이터 테이블 생성
+// 파일 자체는 첨부파일 디렉토리에 <가계부ID>/<UUID><확장자> 로 저장되며, 거래 쪽 외래키 없이 account_type 으로 구분하고 삭제 시 직접 정리
+func createAttachmentTable(exec sqlExecutor) error {
+	createTable := `
+    CREATE TABLE IF NOT EXISTS attachments (
+        uuid TEXT PRIMARY KEY,
+        ledger_id INTEGER NOT NULL,
+        account_type VARCHAR(3) NOT NULL CHECK (account_type IN ('out', 'in')),
+        account_uuid TEXT NOT NULL,
+        file_name TEXT NOT NULL,
+        stored_name TEXT NOT NULL,
+        content_type VARCHAR(100) NOT NULL,
+        size INTEGER NOT NULL,
+        sha256 VARCHAR(64) NOT NULL,
+        created_at TEXT DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (ledger_id) REFERENCES ledgers(id) ON DELETE CASCADE
+    );`
+
+	if _, err := exec.Exec(createTable); err != nil {
+		return fmt.Errorf("첨부파일 테이블 생성 오류: %v", err)
+	}
+
+	_, err := exec.Exec(`CREATE INDEX IF NOT EXISTS idx_attachments_account ON attachments(account_type, account_uuid)`)
+	if err != nil {
+		return fmt.Errorf("첨부파일 인덱스 생성 오류: %v", err)
+	}
+	return nil
+}
+
+// attachmentSelectQuery 첨부파일 조회 공통 쿼리
+const attachmentSelectQuery = `
+    SELECT uuid, account_type, account_uuid, file_name, content_type, size, sha256, stored_name, created_at
+    FROM attachments`
+
+// scanAttachment 첨부파일 행 스캔
+func scanAttachment(scanner interface{ Scan(...interface{}) error }) (*models.Attachment, error) {
+	var attachment models.Attachment
+	err := scanner.Scan(&attachment.UUID, &attachment.AccountType, &attachment.AccountUUID, &attachment.FileName,
+		&attachment.ContentType, &attachment.Size, &attachment.SHA256, &attachment.StoredName, &attachment.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// GetAttachments 수입/지출의 첨부파일 목록 조회 (등록순)
+func (db *DB) GetAttachments(ledgerID int, accountType, accountUUID string) ([]models.Attachment, error) {
+	if _, ok := transactionTables[accountType]; !ok {
+		return nil, apiErrors.ErrInvalidAttachment.WithMessage("type은 'out' 또는 'in'이어야 합니다")
+	}
+	if err := ensureTransactionExists(db.Conn, ledgerID, accountType, accountUUID); err != nil {
+		return nil, err
+	}
+
+	return queryAttachments(db.Conn, attachmentSelectQuery+`
+    WHERE ledger_id = ? AND account_type = ? AND account_uuid = ?
+    ORDER BY created_at ASC, rowid ASC`, ledgerID, accountType, accountUUID)
+}
+
+// GetAttachmentsByAccounts 여러 수입/지출의 첨부파일 조회 (내보내기용, SQLite 변수 개수 제한을 피해 나누어 조회)
+func (db *DB) GetAttachmentsByAccounts(ledgerID int, accountType string, accountUUIDs []string) ([]models.Attachment, error) {
+	const chunkSize = 500
+	attachments := []models.Attachment{}
+
+	for start := 0; start < len(accountUUIDs); start += chunkSize {
+		end := start + chunkSize
+		if end > len(accountUUIDs) {
+			end = len(accountUUIDs)
+		}
+		chunk := accountUUIDs[start:end]
+
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(chunk)), ", ")
+		args := []interface{}{ledgerID, accountType}
+		for _, accountUUID := range chunk {
+			args = append(args, accountUUID)
+		}
+
+		items, err := queryAttachments(db.Conn, attachmentSelectQuery+`
+        WHERE ledger_id = ? AND account_type = ? AND account_uuid IN (`+placeholders+`)
+        ORDER BY account_uuid, created_at ASC, rowid ASC`, args...)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, items...)
+	}
+	return attachments, nil
+}
+
+// GetAttachmentByUUID UUID로 첨부파일 조회
+func (db *DB) GetAttachmentByUUID(ledgerID int, uuidStr string) (*models.Attachment, error) {
+	attachment, err := scanAttachment(db.Conn.QueryRow(attachmentSelectQuery+` WHERE uuid = ? AND ledger_id = ?`, uuidStr, ledgerID))
+	if err == sql.ErrNoRows {
+		return nil, apiErrors.ErrAttachmentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("첨부파일 조회 오류: %v", err)
+	}
+	return attachment, nil
+}
+
+// SaveAttachment 수입/지출에 첨부파일 저장 (contentType 은 허용된 MIME 타입이어야 함)
+// 파일을 먼저 디스크에 기록한 뒤 메타데이터를 등록하므로, 등록된 첨부파일은 항상 파일이 존재함
+func (db *DB) SaveAttachment(ledgerID int, accountType, accountUUID, fileName, contentType string, data []byte) (*models.Attachment, error) {
+	if db.AttachmentDir == "" {
+		return nil, fmt.Errorf("첨부파일 디렉토리가 설정되지 않았습니다")
+	}
+	if _, ok := transactionTables[accountType]; !ok {
+		return nil, apiErrors.ErrInvalidAttachment.WithMessage("type은 'out' 또는 'in'이어야 합니다")
+	}
+	extension, ok := attachmentExtensions[contentType]
+	if !ok {
+		return nil, apiErrors.ErrUnsupportedAttachmentType
+	}
+	if err := ensureTransactionExists(db.Conn, ledgerID, accountType, accountUUID); err != nil {
+		return nil, err
+	}
+
+	var count int
+	err := db.Conn.QueryRow(`SELECT COUNT(*) FROM attachments WHERE account_type = ? AND account_uuid = ?`, accountType, accountUUID).Scan(&count)
+	if err != nil {
+		return nil, fmt.Errorf("첨부파일 개수 조회 오류: %v", err)
+	}
+	if count >= maxAttachmentsPerTransaction {
+		return nil, apiErrors.ErrInvalidAttachment.WithMessage(fmt.Sprintf("거래 1건에는 첨부파일을 %d개까지 등록할 수 있습니다", maxAttachmentsPerTransaction))
+	}
+
+	checksum := sha256.Sum256(data)
+	attachment := &models.Attachment{
+		UUID:        uuid.New().String(),
+		AccountType: accountType,
+		AccountUUID: accountUUID,
+		FileName:    sanitizeAttachmentName(fileName, extension),
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		SHA256:      hex.EncodeToString(checksum[:]),
+	}
+	attachment.StoredName = fmt.Sprintf("%d/%s%s", ledgerID, attachment.UUID, extension)
+
+	path := db.attachmentPath(attachment.StoredName)
+	if err := writeFileAtomic(path, data); err != nil {
+		return nil, fmt.Errorf("첨부파일 저장 오류: %v", err)
+	}
+
+	_, err = db.Conn.Exec(`
+        INSERT INTO attachments (uuid, ledger_id, account_type, account_uuid, file_name, stored_name, content_type, size, sha256, created_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)`,
+		attachment.UUID, ledgerID, accountType, accountUUID, attachment.FileName, attachment.StoredName,
+		attachment.ContentType, attachment.Size, attachment.SHA256)
+	if err != nil {
+		os.Remove(path)
+		return nil, fmt.Errorf("첨부파일 등록 오류: %v", err)
+	}
+
+	return db.GetAttachmentByUUID(ledgerID, attachment.UUID)
+}
+
+// DeleteAttachment 첨부파일 삭제 (메타데이터와 파일)
+func (db *DB) DeleteAttachment(ledgerID int, uuidStr string) error {
+	attachment, err := db.GetAttachmentByUUID(ledgerID, uuidStr)
+	if err != nil {
+		return err
+	}
+
+	if _, err := db.Conn.Exec(`DELETE FROM attachments WHERE uuid = ? AND ledger_id = ?`, uuidStr, ledgerID); err != nil {
+		return fmt.Errorf("첨부파일 삭제 오류: %v", err)
+	}
+	db.removeAttachmentFiles([]string{attachment.StoredName})
+	return nil
+}
+
+// AttachmentFilePath 첨부파일의 실제 파일 경로 (파일이 없으면 not found)
+func (db *DB) AttachmentFilePath(attachment models.Attachment) (string, error) {
+	path := db.attachmentPath(attachment.StoredName)
+	if db.AttachmentDir == "" || !fileExists(path) {
+		utils.Warning("첨부파일 파일 누락: UUID=%s, 경로=%s", attachment.UUID, path)
+		return "", apiErrors.ErrAttachmentNotFound.WithMessage("첨부파일 원본을 찾을 수 없습니다")
+	}
+	return path, nil
+}
+
+// attachmentPath 저장 경로를 첨부파일 디렉토리 기준 실제 경로로 변환
+func (db *DB) attachmentPath(storedName string) string {
+	return filepath.Join(db.AttachmentDir, filepath.FromSlash(storedName))
+}
+
+// queryAttachments 첨부파일 목록 조회
+func queryAttachments(exec sqlExecutor, query string, args ...interface{}) ([]models.Attachment, error) {
+	rows, err := exec.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("첨부파일 목록 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	attachments := []models.Attachment{}
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("첨부파일 데이터 읽기 오류: %v", err)
+		}
+		attachments = append(attachments, *attachment)
+	}
+	return attachments, rows.Err()
+}
+
+// deleteTransactionAttachments 수입/지출의 첨부파일 메타데이터 삭제 후 지울 파일 목록 반환
+// 파일은 트랜잭션 커밋 후 removeAttachmentFiles 로 삭제
+func deleteTransactionAttachments(exec sqlExecutor, accountType, accountUUID string) ([]string, error) {
+	return deleteAttachmentsWhere(exec, `account_type = ? AND account_uuid = ?`, accountType, accountUUID)
+}
+
+// deleteInstallmentAttachments 할부의 모든 회차 지출에 붙은 첨부파일 메타데이터 삭제 후 지울 파일 목록 반환
+func deleteInstallmentAttachments(exec sqlExecutor, ledgerID int, installmentID string) ([]string, error) {
+	return deleteAttachmentsWhere(exec, `account_type = 'out' AND account_uuid IN (SELECT uuid FROM out_account_data WHERE installment_id = ? AND ledger_id = ?)`,
+		installmentID, ledgerID)
+}
+
+// installmentAttachmentSeqs 할부 회차들에 붙어 있는 첨부파일의 회차 번호 (첨부파일 UUID → 회차, 회차 재생성 시 유지용)
+func installmentAttachmentSeqs(exec sqlExecutor, ledgerID int, installmentID string) (map[string]int, error) {
+	rows, err := exec.Query(`
+        SELECT a.uuid, oa.installment_seq FROM attachments a
+        JOIN out_account_data oa ON a.account_uuid = oa.uuid AND a.account_type = 'out'
+        WHERE oa.installment_id = ? AND oa.ledger_id = ?`, installmentID, ledgerID)
+	if err != nil {
+		return nil, fmt.Errorf("할부 회차 첨부파일 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	seqs := make(map[string]int)
+	for rows.Next() {
+		var attachmentUUID string
+		var seq int
+		if err := rows.Scan(&attachmentUUID, &seq); err != nil {
+			return nil, fmt.Errorf("할부 회차 첨부파일 읽기 오류: %v", err)
+		}
+		seqs[attachmentUUID] = seq
+	}
+	return seqs, rows.Err()
+}
+
+// reattachInstallmentAttachments 재생성된 할부 회차에 첨부파일 다시 연결 (개월 수가 줄어 없어진 회차의 첨부파일은 마지막 회차로)
+func reattachInstallmentAttachments(exec sqlExecutor, ledgerID int, installmentID string, seqs map[string]int, months int) error {
+	for attachmentUUID, seq := range seqs {
+		if seq > months {
+			seq = months
+		}
+		_, err := exec.Exec(`
+            UPDATE attachments SET account_uuid = (
+                SELECT uuid FROM out_account_data WHERE installment_id = ? AND ledger_id = ? AND installment_seq = ?
+            ) WHERE uuid = ?`, installmentID, ledgerID, seq, attachmentUUID)
+		if err != nil {
+			return fmt.Errorf("할부 회차 첨부파일 연결 오류: %v", err)
+		}
+	}
+	return nil
+}
+
+// deleteAttachmentsWhere 조건에 맞는 첨부파일 메타데이터 삭제 후 저장 경로 목록 반환
+func deleteAttachmentsWhere(exec sqlExecutor, condition string, args ...interface{}) ([]string, error) {
+	rows, err := exec.Query(`SELECT stored_name FROM attachments WHERE `+condition, args...)
+	if err != nil {
+		return nil, fmt.Errorf("첨부파일 조회 오류: %v", err)
+	}
+	var storedNames []string
+	for rows.Next() {
+		var storedName string
+		if err := rows.Scan(&storedName); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("첨부파일 읽기 오류: %v", err)
+		}
+		storedNames = append(storedNames, storedName)
+	}
+	rows.Close()
+	if len(storedNames) == 0 {
+		return nil, nil
+	}
+
+	if _, err := exec.Exec(`DELETE FROM attachments WHERE `+condition, args...); err != nil {
+		return nil, fmt.Errorf("첨부파일 삭제 오류: %v", err)
+	}
+	return storedNames, nil
+}
+
+// removeAttachmentFiles 메타데이터가 삭제된 첨부파일의 파일 삭제 (실패해도 거래 삭제는 유지하고 경고만 기록)
+func (db *DB) removeAttachmentFiles(storedNames []string) {
+	if len(storedNames) == 0 || db.AttachmentDir == "" {
+		return
+	}
+
+	// 백업 스냅샷 생성 중에는 파일을 지우지 않음 (스냅샷 DB에 남은 첨부파일의 파일 보존)
+	db.attachmentMu.RLock()
+	defer db.attachmentMu.RUnlock()
+
+	for _, storedName := range storedNames {
+		if err := os.Remove(db.attachmentPath(storedName)); err != nil && !os.IsNotExist(err) {
+			utils.Warning("첨부파일 파일 삭제 실패: %s (%v)", storedName, err)
+		}
+	}
+	utils.Debug("첨부파일 파일 삭제: %d개", len(storedNames))
+}
+
+// sanitizeAttachmentName 다운로드 시 사용할 파일 이름 정리 (경로/제어 문자 제거, 비어있으면 기본 이름)
+func sanitizeAttachmentName(name, extension string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == "/" {
+		name = "attachment" + extension
+	}
+	if runes := []rune(name); len(runes) > 200 {
+		name = string(runes[len(runes)-200:])
+	}
+	return name
+}
+
+// writeFileAtomic 임시 파일에 기록한 뒤 이름을 바꿔 미완성 파일이 남지 않도록 저장
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	temp := path + ".tmp"
+	file, err := os.OpenFile(temp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		os.Remove(temp)
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		os.Remove(temp)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(temp)
+		return err
+	}
+	if err := os.Rename(temp, path); err != nil {
+		os.Remove(temp)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// GetUserCredentialsByName 로그인용 사용자 조회 (비밀번호 해시 포함, 해시가 없으면 빈 문자열)
+func (db *DB) GetUserCredentialsByName(name string) (*models.User, string, error) {
+	query := `
+		SELECT id, name, COALESCE(email, ''), is_active, is_admin, COALESCE(password_hash, '')
+		FROM users
+		WHERE name = ? AND is_active = 1`
+
+	var user models.User
+	var passwordHash string
+	err := db.Conn.QueryRow(query, name).Scan(
+		&user.ID, &user.Name, &user.Email, &user.IsActive, &user.IsAdmin, &passwordHash,
+	)
+	if err == sql.ErrNoRows {
+		return nil, "", apiErrors.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("사용자 인증 정보 조회 오류: %v", err)
+	}
+
+	user.HasPassword = passwordHash != ""
+	return &user, passwordHash, nil
+}
+
+// GetUserPasswordHash 사용자 ID로 비밀번호 해시 조회 (미설정 시 빈 문자열)
+func (db *DB) GetUserPasswordHash(userID int) (string, error) {
+	var passwordHash string
+	err := db.Conn.QueryRow(`SELECT COALESCE(password_hash, '') FROM users WHERE id = ?`, userID).Scan(&passwordHash)
+	if err == sql.ErrNoRows {
+		return "", apiErrors.ErrUserNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("비밀번호 해시 조회 오류: %v", err)
+	}
+	return passwordHash, nil
+}
+
+// SetUserPassword 사용자 비밀번호 해시 저장 (기존 세션은 모두 만료 처리)
+func (db *DB) SetUserPassword(userID int, passwordHash string) error {
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`UPDATE users SET password_hash = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND is_active = 1`, passwordHash, userID)
+	if err != nil {
+		return fmt.Errorf("비밀번호 저장 오류: %v", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return apiErrors.ErrUserNotFound
+	}
+
+	if _, err := tx.Exec(`DELETE FROM sessions WHERE user_id = ?`, userID); err != nil {
+		return fmt.Errorf("기존 세션 삭제 오류: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("트랜잭션 커밋 오류: %v", err)
+	}
+	return nil
+}
+
+// CountUsersWithPassword 비밀번호가 설정된 사용자 수 조회 (초기 설정 여부 판단용)
+func (db *DB) CountUsersWithPassword() (int, error) {
+	var count int
+	err := db.Conn.QueryRow(`SELECT COUNT(*) FROM users WHERE password_hash IS NOT NULL AND is_active = 1`).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("비밀번호 설정 사용자 수 조회 오류: %v", err)
+	}
+	return count, nil
+}
+
+// CreateSession 세션 생성 (토큰 해시와 만료 시각 저장)
+func (db *DB) CreateSession(tokenHash string, userID int, expiresAt time.Time) error {
+	_, err := db.Conn.Exec(`
+		INSERT INTO sessions (token_hash, user_id, expires_at, created_at)
+		VALUES (?, ?, ?, ?)`,
+		tokenHash, userID, utils.FormatDateTimeKST(expiresAt), utils.FormatDateTimeKST(utils.GetCurrentKST()))
+	if err != nil {
+		return fmt.Errorf("세션 생성 오류: %v", err)
+	}
+	return nil
+}
+
+// GetSessionUser 유효한 세션의 사용자 조회 (만료되었거나 없으면 ErrUnauthorized)
+func (db *DB) GetSessionUser(tokenHash string) (*models.User, error) {
+	query := `
+		SELECT u.id, u.name, COALESCE(u.email, ''), u.is_active, u.is_admin, u.password_hash IS NOT NULL
+		FROM sessions s
+		JOIN users u ON s.user_id = u.id
+		WHERE s.token_hash = ? AND s.expires_at > ? AND u.is_active = 1`
+
+	var user models.User
+	err := db.Conn.QueryRow(query, tokenHash, utils.FormatDateTimeKST(utils.GetCurrentKST())).Scan(
+		&user.ID, &user.Name, &user.Email, &user.IsActive, &user.IsAdmin, &user.HasPassword,
+	)
+	if err == sql.ErrNoRows {
+		return nil, apiErrors.ErrUnauthorized.WithMessage("세션이 만료되었거나 유효하지 않습니다")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("세션 조회 오류: %v", err)
+	}
+	return &user, nil
+}
+
+// DeleteSession 세션 삭제 (로그아웃)
+func (db *DB) DeleteSession(tokenHash string) error {
+	if _, err := db.Conn.Exec(`DELETE FROM sessions WHERE token_hash = ?`, tokenHash); err != nil {
+		return fmt.Errorf("세션 삭제 오류: %v", err)
+	}
+	return nil
+}
+
+// DeleteExpiredSessions 만료된 세션 정리
+func (db *DB) DeleteExpiredSessions() (int64, error) {
+	result, err := db.Conn.Exec(`DELETE FROM sessions WHERE expires_at <= ?`, utils.FormatDateTimeKST(utils.GetCurrentKST()))
+	if err != nil {
+		return 0, fmt.Errorf("만료 세션 정리 오류: %v", err)
+	}
+	return result.RowsAffected()
+}
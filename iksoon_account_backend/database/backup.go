@@ -0,0 +1,461 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// backupNamePattern 백업 파일 이름 형식: <DB이름>_<종류>_<YYYYMMDD_HHMMSS>[_N].db
+var backupNamePattern = regexp.MustCompile(`_(auto|manual|pre_restore)_(\d{8}_\d{6})(_\d+)?\.db$`)
+
+// AcquireConn 요청/백그라운드 작업이 DB 연결을 사용하는 동안 잡는 공유 잠금 (반환된 함수로 해제)
+// 복원 중 Conn 교체와 겹치지 않도록 보장
+func (db *DB) AcquireConn() func() {
+	db.connMu.RLock()
+	return db.connMu.RUnlock
+}
+
+// AcquireExclusive 복원처럼 Conn 을 교체하는 작업용 배타 잠금 (진행 중인 요청이 끝날 때까지 대기)
+func (db *DB) AcquireExclusive() func() {
+	db.connMu.Lock()
+	return db.connMu.Unlock
+}
+
+// CreateBackup VACUUM INTO 로 현재 DB의 일관된 스냅샷을 백업 디렉토리에 생성
+// 서버 실행 중에도 안전하며, 임시 파일에 먼저 쓰고 이름을 바꿔 미완성 백업이 목록에 보이지 않도록 함
+// 첨부파일은 <백업 이름>_attachments 디렉토리에 하드 링크(불가능하면 복사)로 함께 보관
+// 호출자는 AcquireConn 또는 AcquireExclusive 잠금을 잡고 있어야 함
+func (db *DB) CreateBackup(dir, kind string) (*models.BackupInfo, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("백업 디렉토리 생성 오류: %v", err)
+	}
+
+	base := strings.TrimSuffix(filepath.Base(db.Path), filepath.Ext(db.Path))
+	stamp := utils.GetCurrentKST().Format("20060102_150405")
+	name := fmt.Sprintf("%s_%s_%s.db", base, kind, stamp)
+	for i := 2; fileExists(filepath.Join(dir, name)); i++ {
+		name = fmt.Sprintf("%s_%s_%s_%d.db", base, kind, stamp, i)
+	}
+
+	target := filepath.Join(dir, name)
+	temp := target + ".tmp"
+	os.Remove(temp)
+
+	// 스냅샷 DB와 첨부파일 스냅샷 사이에 첨부파일이 삭제되지 않도록 잠금
+	db.attachmentMu.Lock()
+	defer db.attachmentMu.Unlock()
+
+	if _, err := db.Conn.Exec("VACUUM INTO ?", temp); err != nil {
+		os.Remove(temp)
+		return nil, fmt.Errorf("백업 생성 오류: %v", err)
+	}
+	if err := os.Rename(temp, target); err != nil {
+		os.Remove(temp)
+		return nil, fmt.Errorf("백업 파일 저장 오류: %v", err)
+	}
+
+	if db.AttachmentDir != "" && fileExists(db.AttachmentDir) {
+		snapshot := attachmentSnapshotDir(dir, name)
+		if _, err := linkTree(db.AttachmentDir, snapshot); err != nil {
+			os.Remove(target)
+			os.RemoveAll(snapshot)
+			return nil, fmt.Errorf("첨부파일 백업 오류: %v", err)
+		}
+	}
+
+	info, err := backupInfo(dir, name)
+	if err != nil {
+		return nil, err
+	}
+	utils.Info("데이터베이스 백업 생성: %s (%d bytes, 첨부파일 %d개)", name, info.Size, info.Attachments)
+	return info, nil
+}
+
+// ListBackups 백업 디렉토리의 백업 목록 조회 (최신순)
+func (db *DB) ListBackups(dir string) ([]models.BackupInfo, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return []models.BackupInfo{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("백업 디렉토리 조회 오류: %v", err)
+	}
+
+	backups := []models.BackupInfo{}
+	for _, entry := range entries {
+		if entry.IsDir() || !backupNamePattern.MatchString(entry.Name()) {
+			continue
+		}
+		info, err := backupInfo(dir, entry.Name())
+		if err != nil {
+			utils.Warning("백업 파일 정보 조회 실패: %s (%v)", entry.Name(), err)
+			continue
+		}
+		backups = append(backups, *info)
+	}
+
+	// 이름에 포함된 생성 시각 기준 최신순
+	sort.Slice(backups, func(i, j int) bool {
+		return backupStamp(backups[i].Name) > backupStamp(backups[j].Name)
+	})
+	return backups, nil
+}
+
+// PruneBackups 자동 백업을 최신 keep 개만 남기고 삭제 (삭제한 개수 반환)
+// 수동 백업과 복원 직전 스냅샷은 삭제하지 않음
+func (db *DB) PruneBackups(dir string, keep int) (int, error) {
+	backups, err := db.ListBackups(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	kept, removed := 0, 0
+	for _, backup := range backups {
+		if backup.Kind != models.BackupKindAuto {
+			continue
+		}
+		kept++
+		if kept <= keep {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, backup.Name)); err != nil {
+			return removed, fmt.Errorf("오래된 백업 삭제 오류 (%s): %v", backup.Name, err)
+		}
+		if err := os.RemoveAll(attachmentSnapshotDir(dir, backup.Name)); err != nil {
+			utils.Warning("오래된 백업의 첨부파일 삭제 실패: %s (%v)", backup.Name, err)
+		}
+		utils.Info("보관 기간이 지난 백업 삭제: %s", backup.Name)
+		removed++
+	}
+	return removed, nil
+}
+
+// BackupFilePath 백업 이름을 검증하고 실제 파일 경로 반환
+func (db *DB) BackupFilePath(dir, name string) (string, error) {
+	if name == "" || filepath.Base(name) != name || !backupNamePattern.MatchString(name) {
+		return "", apiErrors.ErrInvalidRequest.WithMessage("백업 이름이 올바르지 않습니다")
+	}
+
+	path := filepath.Join(dir, name)
+	if !fileExists(path) {
+		return "", apiErrors.ErrBackupNotFound
+	}
+	return path, nil
+}
+
+// BackupAttachmentDir 백업에 딸린 첨부파일 스냅샷 디렉토리 (첨부파일 없이 만든 백업이면 빈 문자열)
+func (db *DB) BackupAttachmentDir(dir, name string) string {
+	snapshot := attachmentSnapshotDir(dir, name)
+	if !fileExists(snapshot) {
+		return ""
+	}
+	return snapshot
+}
+
+// DeleteBackup 백업 파일 삭제
+func (db *DB) DeleteBackup(dir, name string) error {
+	path, err := db.BackupFilePath(dir, name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("백업 삭제 오류: %v", err)
+	}
+	if err := os.RemoveAll(attachmentSnapshotDir(dir, name)); err != nil {
+		utils.Warning("백업의 첨부파일 삭제 실패: %s (%v)", name, err)
+	}
+	utils.Info("데이터베이스 백업 삭제: %s", name)
+	return nil
+}
+
+// VerifyBackup 복원하기 전에 백업 이름과 파일 무결성/호환성 확인 (배타 잠금 없이 호출 가능)
+func (db *DB) VerifyBackup(dir, name string) error {
+	source, err := db.BackupFilePath(dir, name)
+	if err != nil {
+		return err
+	}
+	return verifyBackupFile(source, db.latestMigrationVersion())
+}
+
+// RestoreBackup 백업으로 현재 DB를 교체
+// 1) 백업 무결성/호환성 검사 2) 현재 DB 스냅샷 생성 3) 연결을 닫고 파일 교체 후 새 연결로 Conn 교체 4) 마이그레이션 적용 5) 첨부파일 교체
+// 교체 이후 단계가 실패하면 복원 직전 스냅샷으로 되돌림
+// 첨부파일 스냅샷이 없는 예전 백업은 현재 첨부파일 디렉토리를 그대로 둠
+// 호출자는 AcquireExclusive 잠금을 잡고 있어야 함
+func (db *DB) RestoreBackup(dir, name string) (*models.BackupRestoreResult, error) {
+	source, err := db.BackupFilePath(dir, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyBackupFile(source, db.latestMigrationVersion()); err != nil {
+		return nil, err
+	}
+
+	// 백업 디렉토리가 다른 디스크일 수 있으므로 DB 옆에 복사한 뒤 다시 검사하고 rename 으로 교체
+	staging := db.Path + ".restore"
+	if err := copyFile(source, staging); err != nil {
+		os.Remove(staging)
+		return nil, fmt.Errorf("복원 파일 준비 오류: %v", err)
+	}
+	defer os.Remove(staging)
+	if err := verifyBackupFile(staging, db.latestMigrationVersion()); err != nil {
+		return nil, err
+	}
+
+	// 첨부파일도 DB 교체 전에 첨부파일 디렉토리 옆에 미리 준비
+	attachmentStaging := ""
+	attachmentsRestored := 0
+	if snapshot := db.BackupAttachmentDir(dir, name); snapshot != "" && db.AttachmentDir != "" {
+		attachmentStaging = db.AttachmentDir + ".restore"
+		os.RemoveAll(attachmentStaging)
+		defer os.RemoveAll(attachmentStaging)
+		if attachmentsRestored, err = linkTree(snapshot, attachmentStaging); err != nil {
+			return nil, fmt.Errorf("첨부파일 복원 준비 오류: %v", err)
+		}
+	}
+
+	safety, err := db.CreateBackup(dir, models.BackupKindPreRestore)
+	if err != nil {
+		return nil, fmt.Errorf("복원 직전 스냅샷 생성 실패: %v", err)
+	}
+
+	if err := db.replaceDatabaseFile(staging); err != nil {
+		return nil, db.recoverFromSnapshot(filepath.Join(dir, safety.Name), err)
+	}
+
+	applied, err := db.Migrate()
+	if err != nil {
+		return nil, db.recoverFromSnapshot(filepath.Join(dir, safety.Name), err)
+	}
+	if err := db.EnsureSearchIndex(); err != nil {
+		return nil, db.recoverFromSnapshot(filepath.Join(dir, safety.Name), err)
+	}
+
+	if attachmentStaging != "" {
+		if err := db.replaceAttachmentDir(attachmentStaging); err != nil {
+			return nil, db.recoverFromSnapshot(filepath.Join(dir, safety.Name), err)
+		}
+	}
+
+	utils.Info("데이터베이스 복원 완료: %s (복원 직전 스냅샷: %s, 마이그레이션 %d개 적용, 첨부파일 %d개)", name, safety.Name, applied, attachmentsRestored)
+	return &models.BackupRestoreResult{Restored: name, SafetyBackup: safety.Name, MigrationsApplied: applied, AttachmentsRestored: attachmentsRestored}, nil
+}
+
+// replaceDatabaseFile 현재 연결을 닫고 DB 파일을 교체한 뒤 새 연결로 Conn 교체
+func (db *DB) replaceDatabaseFile(source string) error {
+	if err := db.Conn.Close(); err != nil {
+		utils.Warning("기존 데이터베이스 연결 종료 경고: %v", err)
+	}
+
+	// 남아있는 WAL 이 새 파일에 적용되지 않도록 반드시 제거
+	for _, sidecar := range []string{db.Path + "-wal", db.Path + "-shm"} {
+		if err := os.Remove(sidecar); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("WAL 파일 제거 오류: %v", err)
+		}
+	}
+	if err := os.Rename(source, db.Path); err != nil {
+		return fmt.Errorf("데이터베이스 파일 교체 오류: %v", err)
+	}
+
+	conn, err := openConn(db.Path)
+	if err != nil {
+		return fmt.Errorf("복원된 데이터베이스 연결 오류: %v", err)
+	}
+	db.Conn = conn
+	return nil
+}
+
+// replaceAttachmentDir 준비된 첨부파일 디렉토리로 현재 첨부파일 디렉토리 교체 (실패하면 기존 디렉토리를 되돌림)
+func (db *DB) replaceAttachmentDir(source string) error {
+	db.attachmentMu.Lock()
+	defer db.attachmentMu.Unlock()
+
+	old := db.AttachmentDir + ".old"
+	os.RemoveAll(old)
+	if err := os.Rename(db.AttachmentDir, old); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("첨부파일 디렉토리 교체 오류: %v", err)
+	}
+	if err := os.Rename(source, db.AttachmentDir); err != nil {
+		os.Rename(old, db.AttachmentDir)
+		return fmt.Errorf("첨부파일 디렉토리 교체 오류: %v", err)
+	}
+	if err := os.RemoveAll(old); err != nil {
+		utils.Warning("이전 첨부파일 디렉토리 삭제 실패: %s (%v)", old, err)
+	}
+	return nil
+}
+
+// recoverFromSnapshot 복원 실패 시 복원 직전 스냅샷으로 되돌리고 원래 오류를 반환
+func (db *DB) recoverFromSnapshot(snapshot string, cause error) error {
+	utils.Error("데이터베이스 복원 실패, 복원 직전 상태로 되돌립니다: %v", cause)
+
+	staging := db.Path + ".recover"
+	defer os.Remove(staging)
+	if err := copyFile(snapshot, staging); err != nil {
+		utils.Error("복원 직전 스냅샷 복사 실패: %v", err)
+		return fmt.Errorf("복원 실패 후 되돌리기도 실패했습니다 (스냅샷: %s): %v", filepath.Base(snapshot), cause)
+	}
+	if err := db.replaceDatabaseFile(staging); err != nil {
+		utils.Error("복원 직전 스냅샷 적용 실패: %v", err)
+		return fmt.Errorf("복원 실패 후 되돌리기도 실패했습니다 (스냅샷: %s): %v", filepath.Base(snapshot), cause)
+	}
+	return fmt.Errorf("복원 실패 (복원 직전 상태로 되돌림): %v", cause)
+}
+
+// latestMigrationVersion 현재 서버가 알고 있는 최신 마이그레이션 버전
+func (db *DB) latestMigrationVersion() int {
+	migrations := db.schemaMigrations()
+	return migrations[len(migrations)-1].version
+}
+
+// verifyBackupFile 백업 파일이 손상되지 않았고 현재 서버에서 열 수 있는 가계부 DB인지 확인
+func verifyBackupFile(path string, latestVersion int) error {
+	conn, err := sql.Open("sqlite3", "file:"+path+"?mode=ro")
+	if err != nil {
+		return apiErrors.ErrInvalidBackup.WithDetails(err.Error())
+	}
+	defer conn.Close()
+
+	var result string
+	if err := conn.QueryRow("PRAGMA integrity_check").Scan(&result); err != nil {
+		return apiErrors.ErrInvalidBackup.WithDetails(err.Error())
+	}
+	if result != "ok" {
+		return apiErrors.ErrInvalidBackup.WithDetails("무결성 검사 실패: " + result)
+	}
+
+	if exists, err := tableExists(conn, "users"); err != nil || !exists {
+		return apiErrors.ErrInvalidBackup.WithDetails("가계부 데이터베이스가 아닙니다")
+	}
+
+	// 더 최신 서버에서 만든 백업은 현재 코드와 스키마가 맞지 않을 수 있음
+	if exists, err := tableExists(conn, "schema_migrations"); err == nil && exists {
+		var version int
+		if err := conn.QueryRow("SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&version); err != nil {
+			return apiErrors.ErrInvalidBackup.WithDetails(err.Error())
+		}
+		if version > latestVersion {
+			return apiErrors.ErrInvalidBackup.WithDetails(fmt.Sprintf("더 최신 버전(마이그레이션 %d)에서 만든 백업입니다", version))
+		}
+	}
+	return nil
+}
+
+// backupInfo 백업 파일 정보 조회
+func backupInfo(dir, name string) (*models.BackupInfo, error) {
+	stat, err := os.Stat(filepath.Join(dir, name))
+	if err != nil {
+		return nil, err
+	}
+
+	kind := ""
+	if match := backupNamePattern.FindStringSubmatch(name); match != nil {
+		kind = match[1]
+	}
+	return &models.BackupInfo{
+		Name:        name,
+		Kind:        kind,
+		Size:        stat.Size(),
+		Attachments: countFiles(attachmentSnapshotDir(dir, name)),
+		CreatedAt:   utils.FormatDateTimeKST(stat.ModTime()),
+	}, nil
+}
+
+// attachmentSnapshotDir 백업 파일에 딸린 첨부파일 스냅샷 디렉토리 경로
+func attachmentSnapshotDir(dir, name string) string {
+	return filepath.Join(dir, strings.TrimSuffix(name, ".db")+"_attachments")
+}
+
+// linkTree source 디렉토리의 파일을 target 에 같은 구조로 하드 링크 (다른 디스크라 링크할 수 없으면 복사, 파일 수 반환)
+// 첨부파일은 저장 후 수정되지 않으므로 링크로 공유해도 스냅샷이 바뀌지 않음
+func linkTree(source, target string) (int, error) {
+	count := 0
+	err := filepath.Walk(source, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || strings.HasSuffix(path, ".tmp") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(source, path)
+		if err != nil {
+			return err
+		}
+		dest := filepath.Join(target, rel)
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return err
+		}
+		if err := os.Link(path, dest); err != nil {
+			if err := copyFile(path, dest); err != nil {
+				return err
+			}
+		}
+		count++
+		return nil
+	})
+	if err != nil {
+		return count, err
+	}
+	// 첨부파일이 하나도 없어도 스냅샷이 있었음을 알 수 있도록 디렉토리는 만들어 둠
+	return count, os.MkdirAll(target, 0755)
+}
+
+// countFiles 디렉토리 아래 파일 수 (디렉토리가 없으면 0)
+func countFiles(dir string) int {
+	count := 0
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			count++
+		}
+		return nil
+	})
+	return count
+}
+
+// backupStamp 백업 이름의 생성 시각 부분 (정렬용)
+func backupStamp(name string) string {
+	if match := backupNamePattern.FindStringSubmatch(name); match != nil {
+		return match[2] + match[3]
+	}
+	return ""
+}
+
+// copyFile 파일 복사 (디스크에 기록 완료까지 확인)
+func copyFile(source, target string) error {
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// fileExists 파일 존재 여부 확인
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
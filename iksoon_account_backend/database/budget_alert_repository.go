@@ -0,0 +1,259 @@
+package database
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// budgetAlertSelectQuery 기준치 알림 임계값 조회 공통 쿼리 (기준치 카테고리/사용자, 마지막으로 알림이 발생한 기간 포함)
+const budgetAlertSelectQuery = `
+    SELECT t.id, t.budget_id, b.category_id, COALESCE(c.name, ''), b.user_name, t.period, t.percent,
+           COALESCE((SELECT MAX(e.period_key) FROM budget_alert_events e WHERE e.threshold_id = t.id), ''),
+           t.created_at
+    FROM budget_alert_thresholds t
+    JOIN category_budgets b ON t.budget_id = b.id
+    LEFT JOIN categories c ON b.category_id = c.id`
+
+// GetBudgetAlertThresholds 가계부의 기준치 알림 임계값 목록 조회 (budgetID 가 0 이면 전체)
+func (db *DB) GetBudgetAlertThresholds(ledgerID, budgetID int) ([]models.BudgetAlertThreshold, error) {
+	query := budgetAlertSelectQuery + ` WHERE b.ledger_id = ?`
+	args := []interface{}{ledgerID}
+	if budgetID > 0 {
+		query += ` AND t.budget_id = ?`
+		args = append(args, budgetID)
+	}
+	query += ` ORDER BY t.budget_id ASC, t.period ASC, t.percent ASC`
+
+	rows, err := db.Conn.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("기준치 알림 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	thresholds := []models.BudgetAlertThreshold{}
+	for rows.Next() {
+		var threshold models.BudgetAlertThreshold
+		if err := rows.Scan(&threshold.ID, &threshold.BudgetID, &threshold.CategoryID, &threshold.CategoryName, &threshold.UserName,
+			&threshold.Period, &threshold.Percent, &threshold.LastFiredPeriod, &threshold.CreatedAt); err != nil {
+			return nil, fmt.Errorf("기준치 알림 데이터 읽기 오류: %v", err)
+		}
+		thresholds = append(thresholds, threshold)
+	}
+	return thresholds, rows.Err()
+}
+
+// CreateBudgetAlertThreshold 기준치 알림 임계값 생성 (같은 기준치/기간/사용률이 있으면 409)
+func (db *DB) CreateBudgetAlertThreshold(ledgerID int, req models.BudgetAlertThresholdRequest) (int64, error) {
+	req.Period = strings.ToLower(strings.TrimSpace(req.Period))
+	if req.Period == "" {
+		req.Period = models.BudgetAlertMonthly
+	}
+	if req.Period != models.BudgetAlertMonthly && req.Period != models.BudgetAlertYearly {
+		return 0, apiErrors.ErrInvalidNotification.WithMessage("period는 monthly 또는 yearly여야 합니다")
+	}
+	if req.Percent <= 0 || req.Percent > 1000 {
+		return 0, apiErrors.ErrInvalidNotification.WithMessage("percent는 1 이상 1000 이하여야 합니다")
+	}
+	if err := ensureCategoryBudget(db.Conn, ledgerID, req.BudgetID); err != nil {
+		return 0, err
+	}
+
+	result, err := db.Conn.Exec(`INSERT INTO budget_alert_thresholds (budget_id, period, percent) VALUES (?, ?, ?)`,
+		req.BudgetID, req.Period, req.Percent)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return 0, apiErrors.ErrAlreadyExists.WithMessage("같은 기준치 알림이 이미 있습니다")
+		}
+		return 0, fmt.Errorf("기준치 알림 생성 오류: %v", err)
+	}
+	return result.LastInsertId()
+}
+
+// DeleteBudgetAlertThreshold 기준치 알림 임계값과 발생 이력 삭제
+func (db *DB) DeleteBudgetAlertThreshold(ledgerID, id int) error {
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+        DELETE FROM budget_alert_thresholds
+        WHERE id = ? AND budget_id IN (SELECT id FROM category_budgets WHERE ledger_id = ?)`, id, ledgerID)
+	if err != nil {
+		return fmt.Errorf("기준치 알림 삭제 오류: %v", err)
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return apiErrors.ErrNotificationNotFound.WithMessage("기준치 알림을 찾을 수 없습니다")
+	}
+	if _, err := tx.Exec(`DELETE FROM budget_alert_events WHERE threshold_id = ?`, id); err != nil {
+		return fmt.Errorf("기준치 알림 이력 삭제 오류: %v", err)
+	}
+	return tx.Commit()
+}
+
+// deleteBudgetAlerts 기준치의 알림 임계값과 발생 이력 삭제 (기준치 삭제 시 사용)
+func deleteBudgetAlerts(exec sqlExecutor, budgetID int) error {
+	if _, err := exec.Exec(`
+        DELETE FROM budget_alert_events
+        WHERE threshold_id IN (SELECT id FROM budget_alert_thresholds WHERE budget_id = ?)`, budgetID); err != nil {
+		return fmt.Errorf("기준치 알림 이력 삭제 오류: %v", err)
+	}
+	if _, err := exec.Exec(`DELETE FROM budget_alert_thresholds WHERE budget_id = ?`, budgetID); err != nil {
+		return fmt.Errorf("기준치 알림 삭제 오류: %v", err)
+	}
+	return nil
+}
+
+// budgetAlertCandidate 이번 기간에 아직 알림이 발생하지 않은 임계값
+type budgetAlertCandidate struct {
+	thresholdID  int
+	budgetID     int
+	ledgerID     int
+	categoryID   int
+	categoryName string
+	userName     string
+	period       string
+	percent      int
+}
+
+// EvaluateBudgetAlerts 모든 가계부의 기준치 사용률을 now(KST) 가 속한 달/연도 기준으로 확인해 임계값에 도달한 알림 발생
+// 임계값마다 기간당 한 번만 발생하며, 한 번에 여러 임계값을 넘으면 가장 높은 임계값 하나로 채널별 알림을 등록
+// 반환값은 발송 대기열에 추가한 알림 수
+func (db *DB) EvaluateBudgetAlerts(now time.Time) (int, error) {
+	monthKey, yearKey := now.Format("2006-01"), now.Format("2006")
+
+	rows, err := db.Conn.Query(`
+        SELECT t.id, t.budget_id, b.ledger_id, b.category_id, COALESCE(c.name, ''), b.user_name, t.period, t.percent
+        FROM budget_alert_thresholds t
+        JOIN category_budgets b ON t.budget_id = b.id
+        LEFT JOIN categories c ON b.category_id = c.id
+        WHERE NOT EXISTS (
+            SELECT 1 FROM budget_alert_events e
+            WHERE e.threshold_id = t.id AND e.period_key = CASE t.period WHEN 'yearly' THEN ? ELSE ? END
+        )
+        ORDER BY t.budget_id ASC, t.period ASC, t.percent ASC`, yearKey, monthKey)
+	if err != nil {
+		return 0, fmt.Errorf("기준치 알림 대상 조회 오류: %v", err)
+	}
+	var candidates []budgetAlertCandidate
+	for rows.Next() {
+		var candidate budgetAlertCandidate
+		if err := rows.Scan(&candidate.thresholdID, &candidate.budgetID, &candidate.ledgerID, &candidate.categoryID,
+			&candidate.categoryName, &candidate.userName, &candidate.period, &candidate.percent); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("기준치 알림 대상 읽기 오류: %v", err)
+		}
+		candidates = append(candidates, candidate)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("기준치 알림 대상 조회 오류: %v", err)
+	}
+
+	queued := 0
+	for start := 0; start < len(candidates); {
+		end := start
+		for end < len(candidates) && candidates[end].budgetID == candidates[start].budgetID {
+			end++
+		}
+		count, err := db.fireBudgetAlerts(candidates[start:end], now, monthKey, yearKey)
+		if err != nil {
+			return queued, err
+		}
+		queued += count
+		start = end
+	}
+	return queued, nil
+}
+
+// fireBudgetAlerts 한 기준치의 임계값들을 사용률과 비교해 도달한 임계값의 발생 이력과 채널별 알림 등록
+func (db *DB) fireBudgetAlerts(candidates []budgetAlertCandidate, now time.Time, monthKey, yearKey string) (int, error) {
+	first := candidates[0]
+	usage, err := db.GetBudgetUsage(first.ledgerID, first.categoryID, first.userName, now)
+	if err != nil {
+		return 0, err
+	}
+	if usage == nil {
+		return 0, nil
+	}
+
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	queued := 0
+	for _, period := range []string{models.BudgetAlertMonthly, models.BudgetAlertYearly} {
+		percent, used, budget, over := usage.MonthlyPercent, usage.MonthlyUsed, usage.AvailableAmount, usage.IsMonthlyOver
+		periodKey, periodLabel := monthKey, "월"
+		if period == models.BudgetAlertYearly {
+			percent, used, budget, over = usage.YearlyPercent, usage.YearlyUsed, usage.YearlyBudget, usage.IsYearlyOver
+			periodKey, periodLabel = yearKey, "연"
+		}
+		// 이월된 초과 지출로 사용 가능 금액이 없으면 사용률 대신 초과 여부로 판단
+		if over && percent < 100 {
+			percent = 100
+		}
+
+		var reached *budgetAlertCandidate
+		for i := range candidates {
+			if candidates[i].period != period || percent < float64(candidates[i].percent) {
+				continue
+			}
+			if _, err := tx.Exec(`INSERT OR IGNORE INTO budget_alert_events (threshold_id, period_key, percent, fired_at) VALUES (?, ?, ?, ?)`,
+				candidates[i].thresholdID, periodKey, percent, utils.FormatDateTimeKST(now)); err != nil {
+				return 0, fmt.Errorf("기준치 알림 이력 저장 오류: %v", err)
+			}
+			reached = &candidates[i]
+		}
+		if reached == nil {
+			continue
+		}
+
+		target := "전체"
+		if reached.userName != "" {
+			target = reached.userName
+		}
+		subject := fmt.Sprintf("[기준치 알림] %s %s 기준치 %d%% 도달", reached.categoryName, periodLabel, reached.percent)
+		message := fmt.Sprintf("%s (%s) %s %s 기준치 %s원 중 %s원 사용 (%.1f%%)",
+			reached.categoryName, target, periodKey, periodLabel, formatWon(budget), formatWon(used), percent)
+
+		result, err := tx.Exec(`
+            INSERT INTO notification_deliveries (ledger_id, channel_id, threshold_id, period_key, subject, message, next_attempt_at, created_at)
+            SELECT ?, id, ?, ?, ?, ?, ?, ? FROM notification_channels WHERE ledger_id = ? AND enabled = 1`,
+			reached.ledgerID, reached.thresholdID, periodKey, subject, message,
+			utils.FormatDateTimeKST(now), utils.FormatDateTimeKST(now), reached.ledgerID)
+		if err != nil {
+			return 0, fmt.Errorf("알림 등록 오류: %v", err)
+		}
+		count, _ := result.RowsAffected()
+		queued += int(count)
+		utils.Info("기준치 알림 발생: 기준치 ID=%d, %s, 채널 %d개", reached.budgetID, subject, count)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("기준치 알림 저장 오류: %v", err)
+	}
+	return queued, nil
+}
+
+// formatWon 금액을 천 단위 쉼표로 구분한 문자열로 변환
+func formatWon(amount int) string {
+	digits := strconv.Itoa(amount)
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+	for i := len(digits) - 3; i > 0; i -= 3 {
+		digits = digits[:i] + "," + digits[i:]
+	}
+	return sign + digits
+}
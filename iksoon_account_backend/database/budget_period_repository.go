@@ -0,0 +1,367 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// maxBudgetReportMonths 월별 기준치 보고서의 최대 조회 개월 수
+const maxBudgetReportMonths = 120
+
+// createBudgetPeriodTable 기준치 적용 기간 테이블 생성
+// 기존 기준치는 생성된 달부터 현재 금액이 적용된 것으로 옮겨 둠
+func createBudgetPeriodTable(exec sqlExecutor) error {
+	steps := []string{
+		`CREATE TABLE IF NOT EXISTS category_budget_periods (
+            budget_id INTEGER NOT NULL,
+            effective_from TEXT NOT NULL,
+            monthly_budget INTEGER NOT NULL DEFAULT 0 CHECK (monthly_budget >= 0),
+            yearly_budget INTEGER NOT NULL DEFAULT 0 CHECK (yearly_budget >= 0),
+            created_at TEXT DEFAULT CURRENT_TIMESTAMP,
+            updated_at TEXT DEFAULT CURRENT_TIMESTAMP,
+            PRIMARY KEY (budget_id, effective_from),
+            FOREIGN KEY (budget_id) REFERENCES category_budgets(id) ON DELETE CASCADE
+        )`,
+		`INSERT OR IGNORE INTO category_budget_periods (budget_id, effective_from, monthly_budget, yearly_budget)
+         SELECT id, COALESCE(strftime('%Y-%m', created_at), strftime('%Y-%m', 'now', '+9 hours')), monthly_budget, yearly_budget
+         FROM category_budgets`,
+	}
+	for _, step := range steps {
+		if _, err := exec.Exec(step); err != nil {
+			return fmt.Errorf("기준치 적용 기간 테이블 생성 오류: %v", err)
+		}
+	}
+	return nil
+}
+
+// GetBudgetPeriods 기준치의 적용 기간 이력 조회 (오래된 기간 먼저)
+func (db *DB) GetBudgetPeriods(ledgerID, budgetID int) ([]models.BudgetPeriod, error) {
+	if err := ensureCategoryBudget(db.Conn, ledgerID, budgetID); err != nil {
+		return nil, err
+	}
+	return queryBudgetPeriods(db.Conn, budgetID)
+}
+
+// SetBudgetPeriod 특정 월부터 적용할 기준치 설정 (같은 월의 기간이 있으면 덮어씀, 이전 달 기준치는 유지)
+func (db *DB) SetBudgetPeriod(ledgerID, budgetID int, req models.BudgetPeriodRequest) error {
+	month, err := parseBudgetMonth(req.EffectiveFrom)
+	if err != nil {
+		return err
+	}
+	if (req.MonthlyBudget != nil && *req.MonthlyBudget < 0) || (req.YearlyBudget != nil && *req.YearlyBudget < 0) {
+		return apiErrors.ErrInvalidBudgetPeriod.WithMessage("기준치는 0 이상이어야 합니다")
+	}
+	if req.MonthlyBudget == nil && req.YearlyBudget == nil {
+		return apiErrors.ErrInvalidBudgetPeriod.WithMessage("monthly_budget 또는 yearly_budget 중 하나는 지정해야 합니다")
+	}
+
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	if err := ensureCategoryBudget(tx, ledgerID, budgetID); err != nil {
+		return err
+	}
+	if err := setBudgetPeriod(tx, budgetID, month, req.MonthlyBudget, req.YearlyBudget); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// DeleteBudgetPeriod 기준치 적용 기간 삭제 (해당 기간의 달은 이전 기간의 기준치를 따름, 마지막 남은 기간은 삭제 불가)
+func (db *DB) DeleteBudgetPeriod(ledgerID, budgetID int, effectiveFrom string) error {
+	month, err := parseBudgetMonth(effectiveFrom)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	if err := ensureCategoryBudget(tx, ledgerID, budgetID); err != nil {
+		return err
+	}
+
+	var count int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM category_budget_periods WHERE budget_id = ?`, budgetID).Scan(&count); err != nil {
+		return fmt.Errorf("기준치 적용 기간 확인 오류: %v", err)
+	}
+	if count <= 1 {
+		return apiErrors.ErrInvalidBudgetPeriod.WithMessage("마지막 남은 적용 기간은 삭제할 수 없습니다. 기준치 자체를 삭제해주세요")
+	}
+
+	result, err := tx.Exec(`DELETE FROM category_budget_periods WHERE budget_id = ? AND effective_from = ?`, budgetID, month)
+	if err != nil {
+		return fmt.Errorf("기준치 적용 기간 삭제 오류: %v", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return apiErrors.ErrBudgetNotFound.WithMessage(fmt.Sprintf("%s부터 적용되는 기준치가 없습니다", month))
+	}
+	if err := syncCategoryBudgetAmounts(tx, budgetID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetBudgetMonthlyReport 기준치별 월별 기준치 대비 실제 지출 표 조회 (각 달은 그 달에 적용된 기준치로 평가)
+// user/category_id 필터는 기준치 목록 조회와 같고, startMonth/endMonth 를 생략하면 이번 달까지 최근 12개월
+func (db *DB) GetBudgetMonthlyReport(ledgerID int, userName string, categoryID *int, startMonth, endMonth string) ([]models.BudgetMonthlyReport, error) {
+	months, err := budgetReportMonths(startMonth, endMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	budgets, err := db.GetCategoryBudgets(ledgerID, userName, categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := []models.BudgetMonthlyReport{}
+	for _, budget := range budgets {
+		periods, err := queryBudgetPeriods(db.Conn, budget.ID)
+		if err != nil {
+			return nil, err
+		}
+		used, err := monthlyCategoryUsage(db.Conn, ledgerID, budget.CategoryID, budget.UserName, months[0], months[len(months)-1])
+		if err != nil {
+			return nil, err
+		}
+		carries, err := budgetRolloverCarries(db.Conn, ledgerID, budget, months[len(months)-1])
+		if err != nil {
+			return nil, err
+		}
+
+		report := models.BudgetMonthlyReport{
+			BudgetID:     budget.ID,
+			CategoryID:   budget.CategoryID,
+			CategoryName: budget.CategoryName,
+			UserName:     budget.UserName,
+			Months:       make([]models.BudgetMonth, 0, len(months)),
+		}
+		for _, month := range months {
+			row := models.BudgetMonth{Month: month, Used: used[month]}
+			if period := budgetPeriodIn(periods, month); period != nil {
+				row.HasBudget = true
+				row.MonthlyBudget = period.MonthlyBudget
+				row.Carried = carries[month]
+				row.Available = period.MonthlyBudget + row.Carried
+				row.Remaining = row.Available - row.Used
+				if row.Available > 0 {
+					row.Percent = math.Round(float64(row.Used)/float64(row.Available)*1000) / 10
+				}
+				if row.Available > 0 || row.Carried != 0 {
+					row.IsOver = row.Used > row.Available
+				}
+				report.TotalBudget += period.MonthlyBudget
+			}
+			if row.IsOver {
+				report.OverCount++
+			}
+			report.TotalUsed += row.Used
+			report.Months = append(report.Months, row)
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// ensureCategoryBudget 기준치가 가계부에 있는지 확인
+func ensureCategoryBudget(exec sqlExecutor, ledgerID, budgetID int) error {
+	var exists int
+	err := exec.QueryRow(`SELECT 1 FROM category_budgets WHERE id = ? AND ledger_id = ?`, budgetID, ledgerID).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return apiErrors.ErrBudgetNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("기준치 확인 오류: %v", err)
+	}
+	return nil
+}
+
+// queryBudgetPeriods 기준치의 적용 기간 목록 조회 (effective_from 오름차순)
+func queryBudgetPeriods(exec sqlExecutor, budgetID int) ([]models.BudgetPeriod, error) {
+	rows, err := exec.Query(`
+        SELECT budget_id, effective_from, monthly_budget, yearly_budget, created_at, updated_at
+        FROM category_budget_periods WHERE budget_id = ? ORDER BY effective_from ASC`, budgetID)
+	if err != nil {
+		return nil, fmt.Errorf("기준치 적용 기간 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	periods := []models.BudgetPeriod{}
+	for rows.Next() {
+		var period models.BudgetPeriod
+		if err := rows.Scan(&period.BudgetID, &period.EffectiveFrom, &period.MonthlyBudget, &period.YearlyBudget, &period.CreatedAt, &period.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("기준치 적용 기간 읽기 오류: %v", err)
+		}
+		periods = append(periods, period)
+	}
+	return periods, rows.Err()
+}
+
+// budgetPeriodIn 오름차순 적용 기간 목록에서 해당 월(YYYY-MM)에 적용되는 기간 반환 (없으면 nil)
+func budgetPeriodIn(periods []models.BudgetPeriod, month string) *models.BudgetPeriod {
+	var applied *models.BudgetPeriod
+	for i := range periods {
+		if periods[i].EffectiveFrom > month {
+			break
+		}
+		applied = &periods[i]
+	}
+	return applied
+}
+
+// budgetPeriodAt 해당 월(YYYY-MM)에 적용되는 기준치 조회 (적용 기간이 없으면 found=false)
+func budgetPeriodAt(exec sqlExecutor, budgetID int, month string) (monthly, yearly int, found bool, err error) {
+	err = exec.QueryRow(`
+        SELECT monthly_budget, yearly_budget FROM category_budget_periods
+        WHERE budget_id = ? AND effective_from <= ?
+        ORDER BY effective_from DESC LIMIT 1`, budgetID, month).Scan(&monthly, &yearly)
+	if err == sql.ErrNoRows {
+		return 0, 0, false, nil
+	}
+	if err != nil {
+		return 0, 0, false, fmt.Errorf("적용 기준치 조회 오류: %v", err)
+	}
+	return monthly, yearly, true, nil
+}
+
+// setBudgetPeriod 해당 월부터 적용할 기준치 저장 (nil 인 금액은 그 월에 적용 중이던 값 유지) 후 현재 기준치 동기화
+func setBudgetPeriod(exec sqlExecutor, budgetID int, month string, monthlyBudget, yearlyBudget *int) error {
+	monthly, yearly, _, err := budgetPeriodAt(exec, budgetID, month)
+	if err != nil {
+		return err
+	}
+	if monthlyBudget != nil {
+		monthly = *monthlyBudget
+	}
+	if yearlyBudget != nil {
+		yearly = *yearlyBudget
+	}
+
+	_, err = exec.Exec(`
+        INSERT INTO category_budget_periods (budget_id, effective_from, monthly_budget, yearly_budget)
+        VALUES (?, ?, ?, ?)
+        ON CONFLICT(budget_id, effective_from) DO UPDATE SET
+            monthly_budget = excluded.monthly_budget, yearly_budget = excluded.yearly_budget, updated_at = CURRENT_TIMESTAMP`,
+		budgetID, month, monthly, yearly)
+	if err != nil {
+		return fmt.Errorf("기준치 적용 기간 저장 오류: %v", err)
+	}
+	return syncCategoryBudgetAmounts(exec, budgetID)
+}
+
+// syncCategoryBudgetAmounts 기준치 목록에 보이는 금액을 이번 달(KST)에 적용되는 기준치로 맞춤
+// 이번 달에 적용되는 기간이 없으면(모두 미래 기간) 가장 먼저 시작하는 기간의 금액 사용
+func syncCategoryBudgetAmounts(exec sqlExecutor, budgetID int) error {
+	monthly, yearly, found, err := budgetPeriodAt(exec, budgetID, utils.GetCurrentKST().Format("2006-01"))
+	if err != nil {
+		return err
+	}
+	if !found {
+		err = exec.QueryRow(`
+            SELECT monthly_budget, yearly_budget FROM category_budget_periods
+            WHERE budget_id = ? ORDER BY effective_from ASC LIMIT 1`, budgetID).Scan(&monthly, &yearly)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("적용 기준치 조회 오류: %v", err)
+		}
+	}
+
+	_, err = exec.Exec(`UPDATE category_budgets SET monthly_budget = ?, yearly_budget = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`,
+		monthly, yearly, budgetID)
+	if err != nil {
+		return fmt.Errorf("현재 기준치 동기화 오류: %v", err)
+	}
+	return nil
+}
+
+// monthlyCategoryUsage 카테고리의 월별 지출 합계 조회 (userName 이 비어 있으면 모든 사용자, 분할 지출은 해당 카테고리 항목 금액만)
+func monthlyCategoryUsage(exec sqlExecutor, ledgerID, categoryID int, userName, startMonth, endMonth string) (map[string]int, error) {
+	start, _ := utils.ParseDateInLayoutsKST(startMonth, "2006-01")
+	end, _ := utils.ParseDateInLayoutsKST(endMonth, "2006-01")
+
+	query := `
+        SELECT strftime('%Y-%m', date), COALESCE(SUM(money), 0) FROM out_account_line_items
+        WHERE ledger_id = ? AND category_id = ? AND date >= ? AND date <= ?`
+	args := []interface{}{ledgerID, categoryID, start.Format("2006-01-02 15:04:05"), utils.EndOfMonthKST(end).Format("2006-01-02 15:04:05")}
+	if userName != "" {
+		query += ` AND user = ?`
+		args = append(args, userName)
+	}
+	query += ` GROUP BY strftime('%Y-%m', date)`
+
+	rows, err := exec.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("월별 사용량 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	used := map[string]int{}
+	for rows.Next() {
+		var month string
+		var amount int
+		if err := rows.Scan(&month, &amount); err != nil {
+			return nil, fmt.Errorf("월별 사용량 읽기 오류: %v", err)
+		}
+		used[month] = amount
+	}
+	return used, rows.Err()
+}
+
+// parseBudgetMonth 기준치 적용 월 파싱 (YYYY-MM 또는 YYYY-MM-DD, 비어 있으면 이번 달)
+func parseBudgetMonth(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return utils.GetCurrentKST().Format("2006-01"), nil
+	}
+	parsed, err := utils.ParseDateInLayoutsKST(value, "2006-01", "2006-01-02")
+	if err != nil {
+		return "", apiErrors.ErrInvalidBudgetPeriod.WithMessage("effective_from은 YYYY-MM 형식이어야 합니다")
+	}
+	return parsed.Format("2006-01"), nil
+}
+
+// budgetReportMonths 보고서 기간의 월 목록(YYYY-MM) 생성
+func budgetReportMonths(startMonth, endMonth string) ([]string, error) {
+	end, err := parseBudgetMonth(endMonth)
+	if err != nil {
+		return nil, err
+	}
+	endTime, _ := time.Parse("2006-01", end)
+
+	startTime := endTime.AddDate(0, -11, 0)
+	if strings.TrimSpace(startMonth) != "" {
+		start, err := parseBudgetMonth(startMonth)
+		if err != nil {
+			return nil, err
+		}
+		startTime, _ = time.Parse("2006-01", start)
+	}
+	if startTime.After(endTime) {
+		return nil, apiErrors.ErrInvalidBudgetPeriod.WithMessage("시작 월은 종료 월보다 이후일 수 없습니다")
+	}
+
+	months := []string{}
+	for current := startTime; !current.After(endTime); current = current.AddDate(0, 1, 0) {
+		if len(months) == maxBudgetReportMonths {
+			return nil, apiErrors.ErrInvalidBudgetPeriod.WithMessage(fmt.Sprintf("최대 %d개월까지 조회할 수 있습니다", maxBudgetReportMonths))
+		}
+		months = append(months, current.Format("2006-01"))
+	}
+	return months, nil
+}
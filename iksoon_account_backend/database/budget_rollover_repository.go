@@ -0,0 +1,134 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+)
+
+// addBudgetRolloverColumns 카테고리 기준치에 이월 모드 컬럼 추가
+func addBudgetRolloverColumns(exec sqlExecutor) error {
+	columns := []struct{ name, definition string }{
+		{"rollover_enabled", "INTEGER NOT NULL DEFAULT 0"},
+		{"rollover_cap", "INTEGER NULL"},
+		{"rollover_from", "TEXT NULL"},
+	}
+	for _, column := range columns {
+		if _, err := addColumnIfNotExists(exec, "category_budgets", column.name, column.definition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropBudgetRolloverColumns 카테고리 기준치의 이월 모드 컬럼 제거
+func dropBudgetRolloverColumns(exec sqlExecutor) error {
+	steps := []string{
+		`ALTER TABLE category_budgets DROP COLUMN rollover_from`,
+		`ALTER TABLE category_budgets DROP COLUMN rollover_cap`,
+		`ALTER TABLE category_budgets DROP COLUMN rollover_enabled`,
+	}
+	for _, step := range steps {
+		if _, err := exec.Exec(step); err != nil {
+			return fmt.Errorf("기준치 이월 컬럼 제거 오류: %v", err)
+		}
+	}
+	return nil
+}
+
+// SetBudgetRollover 기준치 이월 모드 설정 (from 월부터 남은 금액/초과 금액을 다음 달로 누적, cap 은 이월 금액 절댓값 상한)
+func (db *DB) SetBudgetRollover(ledgerID, budgetID int, req models.BudgetRolloverRequest) error {
+	if err := ensureCategoryBudget(db.Conn, ledgerID, budgetID); err != nil {
+		return err
+	}
+
+	if !req.Enabled {
+		if _, err := db.Conn.Exec(`
+            UPDATE category_budgets SET rollover_enabled = 0, rollover_cap = NULL, rollover_from = NULL, updated_at = CURRENT_TIMESTAMP
+            WHERE id = ? AND ledger_id = ?`, budgetID, ledgerID); err != nil {
+			return fmt.Errorf("기준치 이월 해제 오류: %v", err)
+		}
+		return nil
+	}
+
+	if req.Cap != nil && *req.Cap < 0 {
+		return apiErrors.ErrInvalidBudgetRollover.WithMessage("이월 상한은 0 이상이어야 합니다")
+	}
+	from := strings.TrimSpace(req.From)
+	month, err := parseBudgetMonth(from)
+	if err != nil {
+		return apiErrors.ErrInvalidBudgetRollover.WithMessage("from은 YYYY-MM 형식이어야 합니다")
+	}
+
+	var rolloverCap interface{}
+	if req.Cap != nil {
+		rolloverCap = *req.Cap
+	}
+	if _, err := db.Conn.Exec(`
+        UPDATE category_budgets SET rollover_enabled = 1, rollover_cap = ?, rollover_from = ?, updated_at = CURRENT_TIMESTAMP
+        WHERE id = ? AND ledger_id = ?`, rolloverCap, month, budgetID, ledgerID); err != nil {
+		return fmt.Errorf("기준치 이월 설정 오류: %v", err)
+	}
+	return nil
+}
+
+// scanBudgetRollover 조회한 이월 컬럼 값을 기준치에 반영
+func scanBudgetRollover(budget *models.CategoryBudget, enabled int, rolloverCap sql.NullInt64, rolloverFrom sql.NullString) {
+	budget.RolloverEnabled = enabled != 0
+	budget.RolloverCap = nil
+	if rolloverCap.Valid {
+		value := int(rolloverCap.Int64)
+		budget.RolloverCap = &value
+	}
+	budget.RolloverFrom = rolloverFrom.String
+}
+
+// budgetRolloverCarries 이월 시작 월부터 endMonth 까지 각 달 시작 시점의 이월 금액 계산 (YYYY-MM → 금액)
+// 적용 기준치가 없는 달은 이월 금액을 그대로 넘기며, 상한이 있으면 매달 -상한 ~ +상한 으로 제한
+func budgetRolloverCarries(exec sqlExecutor, ledgerID int, budget models.CategoryBudget, endMonth string) (map[string]int, error) {
+	carries := map[string]int{}
+	if !budget.RolloverEnabled || budget.RolloverFrom == "" || budget.RolloverFrom > endMonth {
+		return carries, nil
+	}
+
+	periods, err := queryBudgetPeriods(exec, budget.ID)
+	if err != nil {
+		return nil, err
+	}
+	used, err := monthlyCategoryUsage(exec, ledgerID, budget.CategoryID, budget.UserName, budget.RolloverFrom, endMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := time.Parse("2006-01", budget.RolloverFrom)
+	if err != nil {
+		return nil, fmt.Errorf("이월 시작 월 파싱 오류: %v", err)
+	}
+	carry := 0
+	for month := current.Format("2006-01"); month <= endMonth; month = current.Format("2006-01") {
+		carries[month] = carry
+		if period := budgetPeriodIn(periods, month); period != nil {
+			carry += period.MonthlyBudget - used[month]
+			if budget.RolloverCap != nil {
+				carry = clampRollover(carry, *budget.RolloverCap)
+			}
+		}
+		current = current.AddDate(0, 1, 0)
+	}
+	return carries, nil
+}
+
+// clampRollover 이월 금액을 -상한 ~ +상한 범위로 제한
+func clampRollover(carry, rolloverCap int) int {
+	if carry > rolloverCap {
+		return rolloverCap
+	}
+	if carry < -rolloverCap {
+		return -rolloverCap
+	}
+	return carry
+}
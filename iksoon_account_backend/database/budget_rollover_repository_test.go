@@ -0,0 +1,98 @@
+package database
+
+import (
+	"testing"
+
+	"iksoon_account_backend/models"
+)
+
+func TestBudgetRolloverCarries(t *testing.T) {
+	db := newTestDB(t)
+	categoryID := createTestCategory(t, db, "테스트 식비", "out")
+	methodID := createTestPaymentMethod(t, db, "테스트 현금")
+
+	// 2월부터 월 10만원, 4월부터 월 5만원 (1월은 적용 기준치 없음)
+	budgetID, err := db.CreateCategoryBudget(DefaultLedgerID, categoryID, "", 100000, 0, "2024-02")
+	if err != nil {
+		t.Fatalf("기준치 생성 실패: %v", err)
+	}
+	reduced := 50000
+	if err := db.SetBudgetPeriod(DefaultLedgerID, int(budgetID), models.BudgetPeriodRequest{EffectiveFrom: "2024-04", MonthlyBudget: &reduced}); err != nil {
+		t.Fatalf("기준치 기간 설정 실패: %v", err)
+	}
+	for date, money := range map[string]int{"2024-01-10": 80000, "2024-02-10": 70000, "2024-03-10": 100000, "2024-03-20": 50000, "2024-04-10": 20000} {
+		insertTestOutAccount(t, db, date, money, categoryID, methodID)
+	}
+
+	intPtr := func(v int) *int { return &v }
+	tests := []struct {
+		name    string
+		enabled bool
+		from    string
+		cap     *int
+		want    map[string]int
+	}{
+		{
+			// 2월 +3만, 3월 -5만, 4월 +3만, 5월 +5만 (1월은 기준치가 없어 이월 없음)
+			name: "상한 없음", enabled: true, from: "2024-01",
+			want: map[string]int{"2024-01": 0, "2024-02": 0, "2024-03": 30000, "2024-04": -20000, "2024-05": 10000, "2024-06": 60000},
+		},
+		{
+			name: "상한 2만5천", enabled: true, from: "2024-01", cap: intPtr(25000),
+			want: map[string]int{"2024-01": 0, "2024-02": 0, "2024-03": 25000, "2024-04": -25000, "2024-05": 5000, "2024-06": 25000},
+		},
+		{
+			name: "상한 0이면 이월 없음", enabled: true, from: "2024-02", cap: intPtr(0),
+			want: map[string]int{"2024-02": 0, "2024-03": 0, "2024-04": 0},
+		},
+		{
+			name: "중간 월부터 시작", enabled: true, from: "2024-03",
+			want: map[string]int{"2024-03": 0, "2024-04": -50000, "2024-05": -20000},
+		},
+		{
+			name: "연도 경계", enabled: true, from: "2024-11",
+			want: map[string]int{"2024-11": 0, "2024-12": 50000, "2025-01": 100000, "2025-02": 150000},
+		},
+		{name: "이월 꺼짐", enabled: false, from: "2024-01", want: map[string]int{"2024-04": 0}},
+		{name: "시작 월이 조회 월 이후", enabled: true, from: "2024-07", want: map[string]int{"2024-06": 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := db.SetBudgetRollover(DefaultLedgerID, int(budgetID), models.BudgetRolloverRequest{Enabled: tt.enabled, From: tt.from, Cap: tt.cap}); err != nil {
+				t.Fatalf("SetBudgetRollover() error = %v", err)
+			}
+			for month, wantCarry := range tt.want {
+				usage, err := db.GetBudgetUsage(DefaultLedgerID, categoryID, "", mustParseKST(t, month+"-15 12:00:00"))
+				if err != nil {
+					t.Fatalf("%s GetBudgetUsage() error = %v", month, err)
+				}
+				if usage == nil {
+					// 적용되는 기준치가 없는 달
+					if wantCarry != 0 {
+						t.Errorf("%s 사용량 없음, want 이월 %d", month, wantCarry)
+					}
+					continue
+				}
+				if usage.RolloverEnabled != tt.enabled || usage.CarriedAmount != wantCarry {
+					t.Errorf("%s 이월 = %d (사용 %v), want %d (사용 %v)", month, usage.CarriedAmount, usage.RolloverEnabled, wantCarry, tt.enabled)
+				}
+				if usage.AvailableAmount != usage.MonthlyBudget+usage.CarriedAmount {
+					t.Errorf("%s 사용 가능 금액 = %d, want 기준치 %d + 이월 %d", month, usage.AvailableAmount, usage.MonthlyBudget, usage.CarriedAmount)
+				}
+			}
+		})
+	}
+
+	// 기준치 사용량은 이월 금액을 더한 사용 가능 금액 기준으로 계산
+	if err := db.SetBudgetRollover(DefaultLedgerID, int(budgetID), models.BudgetRolloverRequest{Enabled: true, From: "2024-01"}); err != nil {
+		t.Fatalf("SetBudgetRollover() error = %v", err)
+	}
+	usage, err := db.GetBudgetUsage(DefaultLedgerID, categoryID, "", mustParseKST(t, "2024-04-15 12:00:00"))
+	if err != nil {
+		t.Fatalf("GetBudgetUsage() error = %v", err)
+	}
+	if usage.CarriedAmount != -20000 || usage.AvailableAmount != 30000 || usage.MonthlyUsed != 20000 || usage.MonthlyRemaining != 10000 || usage.IsMonthlyOver {
+		t.Errorf("4월 사용량 = 이월 %d, 사용 가능 %d, 사용 %d, 남음 %d, 초과 %v; want -20000, 30000, 20000, 10000, false",
+			usage.CarriedAmount, usage.AvailableAmount, usage.MonthlyUsed, usage.MonthlyRemaining, usage.IsMonthlyOver)
+	}
+}
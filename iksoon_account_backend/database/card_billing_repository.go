@@ -0,0 +1,257 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+)
+
+// addCardBillingColumns 결제수단에 카드 명세서 마감일/결제일 컬럼 추가
+func addCardBillingColumns(exec sqlExecutor) error {
+	if _, err := addColumnIfNotExists(exec, "payment_methods", "statement_closing_day", "INTEGER NULL"); err != nil {
+		return err
+	}
+	_, err := addColumnIfNotExists(exec, "payment_methods", "payment_due_day", "INTEGER NULL")
+	return err
+}
+
+// dropCardBillingColumns 카드 명세서 마감일/결제일 컬럼 제거
+func dropCardBillingColumns(exec sqlExecutor) error {
+	steps := []string{
+		`ALTER TABLE payment_methods DROP COLUMN statement_closing_day`,
+		`ALTER TABLE payment_methods DROP COLUMN payment_due_day`,
+	}
+	for _, step := range steps {
+		if _, err := exec.Exec(step); err != nil {
+			return fmt.Errorf("카드 명세서 컬럼 제거 오류: %v", err)
+		}
+	}
+	return nil
+}
+
+// SetCardBilling 결제수단의 명세서 마감일/결제일 설정 (두 값 모두 nil 이면 해제)
+func (db *DB) SetCardBilling(ledgerID int, id int, req models.CardBillingRequest) error {
+	if (req.StatementClosingDay == nil) != (req.PaymentDueDay == nil) {
+		return apiErrors.ErrInvalidPaymentMethodData.WithMessage("명세서 마감일과 결제일은 함께 설정해야 합니다")
+	}
+	if req.StatementClosingDay != nil {
+		if *req.StatementClosingDay < 1 || *req.StatementClosingDay > 31 || *req.PaymentDueDay < 1 || *req.PaymentDueDay > 31 {
+			return apiErrors.ErrInvalidPaymentMethodData.WithMessage("명세서 마감일과 결제일은 1~31 사이여야 합니다")
+		}
+	}
+
+	query := `
+		UPDATE payment_methods
+		SET statement_closing_day = ?, payment_due_day = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND ledger_id = ? AND is_active = 1`
+
+	result, err := db.Conn.Exec(query, req.StatementClosingDay, req.PaymentDueDay, id, ledgerID)
+	if err != nil {
+		return fmt.Errorf("카드 명세서 주기 설정 오류: %v", err)
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return apiErrors.ErrPaymentMethodNotFound
+	}
+	return nil
+}
+
+// billingCard 명세서 주기가 설정된 결제수단과 그 주기를 따르는 결제수단 목록
+type billingCard struct {
+	id          int
+	name        string
+	closingDay  int
+	dueDay      int
+	memberIDs   []int // 자신 + 설정이 없는 하위 결제수단
+	periodStart time.Time
+	periodEnd   time.Time
+	dueDate     time.Time
+}
+
+// GetCardStatements 결제월(dueMonth)에 결제되는 카드별 명세서 조회
+// paymentMethodID 가 0 이 아니면 해당 카드의 명세서만 조회
+func (db *DB) GetCardStatements(ledgerID int, dueMonth time.Time, paymentMethodID int) (*models.CardStatementSummary, error) {
+	cards, err := loadBillingCards(db.Conn, ledgerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if paymentMethodID != 0 {
+		var selected []*billingCard
+		for _, card := range cards {
+			if card.id == paymentMethodID {
+				selected = append(selected, card)
+			}
+		}
+		if len(selected) == 0 {
+			if err := ensureLedgerRow(db.Conn, "payment_methods", paymentMethodID, ledgerID); err != nil {
+				return nil, err
+			}
+			return nil, apiErrors.ErrInvalidPaymentMethodData.WithMessage("명세서 주기가 설정되지 않은 결제수단입니다")
+		}
+		cards = selected
+	}
+
+	summary := &models.CardStatementSummary{
+		DueMonth:   dueMonth.Format("2006-01"),
+		Statements: []models.CardStatement{},
+	}
+	for _, card := range cards {
+		card.periodStart, card.periodEnd, card.dueDate = cardStatementPeriod(card.closingDay, card.dueDay, dueMonth)
+
+		expenses, err := cardStatementExpenses(db.Conn, ledgerID, card)
+		if err != nil {
+			return nil, err
+		}
+
+		statement := models.CardStatement{
+			PaymentMethodID:     card.id,
+			PaymentMethodName:   card.name,
+			StatementClosingDay: card.closingDay,
+			PaymentDueDay:       card.dueDay,
+			PeriodStart:         card.periodStart.Format("2006-01-02"),
+			PeriodEnd:           card.periodEnd.Format("2006-01-02"),
+			DueDate:             card.dueDate.Format("2006-01-02"),
+			Count:               len(expenses),
+			Expenses:            expenses,
+		}
+		for _, expense := range expenses {
+			statement.TotalAmount += expense.Money
+		}
+
+		summary.TotalAmount += statement.TotalAmount
+		summary.Statements = append(summary.Statements, statement)
+	}
+
+	sort.SliceStable(summary.Statements, func(i, j int) bool {
+		if summary.Statements[i].DueDate != summary.Statements[j].DueDate {
+			return summary.Statements[i].DueDate < summary.Statements[j].DueDate
+		}
+		return summary.Statements[i].PaymentMethodName < summary.Statements[j].PaymentMethodName
+	})
+
+	return summary, nil
+}
+
+// loadBillingCards 명세서 주기가 설정된 활성 결제수단과 주기를 상속하는 하위 결제수단 조회
+func loadBillingCards(exec sqlExecutor, ledgerID int) ([]*billingCard, error) {
+	query := `
+		SELECT id, name, parent_id, is_active, statement_closing_day, payment_due_day
+		FROM payment_methods
+		WHERE ledger_id = ?
+		ORDER BY id`
+
+	rows, err := exec.Query(query, ledgerID)
+	if err != nil {
+		return nil, fmt.Errorf("카드 명세서 주기 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	type methodRow struct {
+		id         int
+		parentID   *int
+		hasBilling bool
+	}
+
+	var methods []methodRow
+	cardsByID := make(map[int]*billingCard)
+	var cards []*billingCard
+	for rows.Next() {
+		var id int
+		var name string
+		var parentID, closingDay, dueDay *int
+		var isActive bool
+		if err := rows.Scan(&id, &name, &parentID, &isActive, &closingDay, &dueDay); err != nil {
+			return nil, fmt.Errorf("카드 명세서 주기 읽기 오류: %v", err)
+		}
+
+		hasBilling := closingDay != nil && dueDay != nil
+		methods = append(methods, methodRow{id: id, parentID: parentID, hasBilling: hasBilling})
+		if hasBilling && isActive {
+			card := &billingCard{id: id, name: name, closingDay: *closingDay, dueDay: *dueDay, memberIDs: []int{id}}
+			cardsByID[id] = card
+			cards = append(cards, card)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("카드 명세서 주기 조회 오류: %v", err)
+	}
+
+	// 자신의 설정이 없는 하위 결제수단은 상위 카드의 명세서에 포함
+	for _, method := range methods {
+		if method.hasBilling || method.parentID == nil {
+			continue
+		}
+		if parent, ok := cardsByID[*method.parentID]; ok {
+			parent.memberIDs = append(parent.memberIDs, method.id)
+		}
+	}
+
+	return cards, nil
+}
+
+// cardStatementPeriod 결제월의 명세서 이용 기간과 결제일 계산
+// 결제일이 마감일보다 뒤면 같은 달 마감분, 아니면 전월 마감분을 결제 (예: 마감 말일·결제 14일 → 전월 1일 ~ 전월 말일)
+func cardStatementPeriod(closingDay, dueDay int, dueMonth time.Time) (time.Time, time.Time, time.Time) {
+	loc := dueMonth.Location()
+	dueDate := clampDayOfMonth(dueMonth.Year(), dueMonth.Month(), dueDay, loc)
+
+	closingMonth := time.Date(dueMonth.Year(), dueMonth.Month(), 1, 0, 0, 0, 0, loc)
+	if dueDay <= closingDay {
+		closingMonth = closingMonth.AddDate(0, -1, 0)
+	}
+	periodEnd := clampDayOfMonth(closingMonth.Year(), closingMonth.Month(), closingDay, loc)
+
+	previousMonth := closingMonth.AddDate(0, -1, 0)
+	periodStart := clampDayOfMonth(previousMonth.Year(), previousMonth.Month(), closingDay, loc).AddDate(0, 0, 1)
+
+	return periodStart, periodEnd, dueDate
+}
+
+// cardStatementExpenses 명세서 이용 기간 내 카드(및 하위 결제수단) 지출 조회 (날짜 오름차순)
+func cardStatementExpenses(exec sqlExecutor, ledgerID int, card *billingCard) ([]models.OutAccount, error) {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(card.memberIDs)), ", ")
+	query := `
+    SELECT oa.uuid, oa.date, oa.user, oa.money, oa.category_id, oa.keyword_id, oa.payment_method_id, oa.memo, oa.installment_id, oa.installment_seq, oa.created_at, oa.updated_at,
+           COALESCE(c.name, '') as category_name,
+           COALESCE(k.name, '') as keyword_name,
+           COALESCE(pm.name, '') as payment_method_name
+    FROM out_account_data oa
+    LEFT JOIN categories c ON oa.category_id = c.id
+    LEFT JOIN keywords k ON oa.keyword_id = k.id
+    LEFT JOIN payment_methods pm ON oa.payment_method_id = pm.id
+    WHERE oa.ledger_id = ? AND oa.payment_method_id IN (` + placeholders + `)
+      AND DATE(oa.date) >= ? AND DATE(oa.date) <= ?
+    ORDER BY oa.date ASC, oa.created_at ASC`
+
+	args := []interface{}{ledgerID}
+	for _, id := range card.memberIDs {
+		args = append(args, id)
+	}
+	args = append(args, card.periodStart.Format("2006-01-02"), card.periodEnd.Format("2006-01-02"))
+
+	rows, err := exec.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("카드 명세서 지출 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	expenses := []models.OutAccount{}
+	for rows.Next() {
+		var expense models.OutAccount
+		err := rows.Scan(
+			&expense.UUID, &expense.Date, &expense.User, &expense.Money, &expense.CategoryID,
+			&expense.KeywordID, &expense.PaymentMethodID, &expense.Memo, &expense.InstallmentID, &expense.InstallmentSeq, &expense.CreatedAt, &expense.UpdatedAt,
+			&expense.CategoryName, &expense.KeywordName, &expense.PaymentMethodName,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("카드 명세서 지출 읽기 오류: %v", err)
+		}
+		expenses = append(expenses, expense)
+	}
+	return expenses, rows.Err()
+}
@@ -0,0 +1,129 @@
+package database
+
+import (
+	"errors"
+	"testing"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+)
+
+// setTestCardBilling 결제수단에 명세서 마감일/결제일 설정
+func setTestCardBilling(t *testing.T, db *DB, id, closingDay, dueDay int) {
+	t.Helper()
+
+	if err := db.SetCardBilling(DefaultLedgerID, id, models.CardBillingRequest{StatementClosingDay: &closingDay, PaymentDueDay: &dueDay}); err != nil {
+		t.Fatalf("SetCardBilling() error = %v", err)
+	}
+}
+
+func TestGetCardStatements(t *testing.T) {
+	db := newTestDB(t)
+	categoryID := createTestCategory(t, db, "테스트 쇼핑", "out")
+	credit := createTestPaymentMethod(t, db, "테스트 신용카드")
+	family, err := db.CreatePaymentMethod(DefaultLedgerID, "테스트 가족카드", &credit)
+	if err != nil {
+		t.Fatalf("하위 결제수단 생성 실패: %v", err)
+	}
+	check := createTestPaymentMethod(t, db, "테스트 체크카드")
+	cash := createTestPaymentMethod(t, db, "테스트 현금")
+
+	// 신용카드: 15일 마감·25일 결제 → 3월 결제분은 2/16 ~ 3/15 (마감일 밤 사용분 포함, 하위 결제수단 포함)
+	// 체크카드: 말일 마감·14일 결제 → 3월 결제분은 2월 한 달 (윤년 2/29 포함)
+	setTestCardBilling(t, db, credit, 15, 25)
+	setTestCardBilling(t, db, check, 31, 14)
+
+	insertTestOutAccount(t, db, "2024-02-15", 10000, categoryID, credit)
+	creditFirst := insertTestOutAccount(t, db, "2024-02-16", 20000, categoryID, credit)
+	familyUse := insertTestOutAccount(t, db, "2024-03-01", 5000, categoryID, int(family))
+	creditLast := insertTestOutAccount(t, db, "2024-03-15 23:30:00", 3000, categoryID, credit)
+	insertTestOutAccount(t, db, "2024-03-16", 9000, categoryID, credit)
+	insertTestOutAccount(t, db, "2024-03-02", 7000, categoryID, cash)
+	checkUse := insertTestOutAccount(t, db, "2024-02-29", 4000, categoryID, check)
+	insertTestOutAccount(t, db, "2024-03-01", 6000, categoryID, check)
+
+	summary, err := db.GetCardStatements(DefaultLedgerID, mustParseKST(t, "2024-03-01"), 0)
+	if err != nil {
+		t.Fatalf("GetCardStatements() error = %v", err)
+	}
+	if summary.DueMonth != "2024-03" || summary.TotalAmount != 32000 || len(summary.Statements) != 2 {
+		t.Fatalf("명세서 요약 = %s 총 %d원 %d장, want 2024-03 총 32000원 2장", summary.DueMonth, summary.TotalAmount, len(summary.Statements))
+	}
+
+	// 결제일 순으로 정렬
+	tests := []struct {
+		statement models.CardStatement
+		id        int
+		period    [3]string
+		total     int
+		uuids     []string
+	}{
+		{summary.Statements[0], check, [3]string{"2024-02-01", "2024-02-29", "2024-03-14"}, 4000, []string{checkUse}},
+		{summary.Statements[1], credit, [3]string{"2024-02-16", "2024-03-15", "2024-03-25"}, 28000, []string{creditFirst, familyUse, creditLast}},
+	}
+	for _, tt := range tests {
+		statement := tt.statement
+		if statement.PaymentMethodID != tt.id {
+			t.Errorf("명세서 결제수단 = %d, want %d", statement.PaymentMethodID, tt.id)
+			continue
+		}
+		if got := [3]string{statement.PeriodStart, statement.PeriodEnd, statement.DueDate}; got != tt.period {
+			t.Errorf("%s 이용 기간/결제일 = %v, want %v", statement.PaymentMethodName, got, tt.period)
+		}
+		if statement.TotalAmount != tt.total || statement.Count != len(tt.uuids) {
+			t.Errorf("%s 합계 = %d원 %d건, want %d원 %d건", statement.PaymentMethodName, statement.TotalAmount, statement.Count, tt.total, len(tt.uuids))
+		}
+		var uuids []string
+		for _, expense := range statement.Expenses {
+			uuids = append(uuids, expense.UUID)
+		}
+		if len(uuids) != len(tt.uuids) {
+			t.Errorf("%s 지출 = %v, want %v", statement.PaymentMethodName, uuids, tt.uuids)
+			continue
+		}
+		for i := range uuids {
+			if uuids[i] != tt.uuids[i] {
+				t.Errorf("%s 지출 = %v, want %v (날짜 오름차순)", statement.PaymentMethodName, uuids, tt.uuids)
+				break
+			}
+		}
+	}
+
+	// 카드 지정 조회, 명세서 주기가 없는 결제수단은 오류
+	single, err := db.GetCardStatements(DefaultLedgerID, mustParseKST(t, "2024-04-01"), credit)
+	if err != nil {
+		t.Fatalf("GetCardStatements(credit) error = %v", err)
+	}
+	if len(single.Statements) != 1 || single.TotalAmount != 9000 {
+		t.Errorf("4월 신용카드 명세서 = %d장 %d원, want 1장 9000원", len(single.Statements), single.TotalAmount)
+	}
+	_, err = db.GetCardStatements(DefaultLedgerID, mustParseKST(t, "2024-03-01"), cash)
+	var apiErr apiErrors.ErrorCode
+	if !errors.As(err, &apiErr) || apiErr.Code != apiErrors.ErrInvalidPaymentMethodData.Code {
+		t.Errorf("명세서 주기가 없는 결제수단 error = %v, want %s", err, apiErrors.ErrInvalidPaymentMethodData.Code)
+	}
+}
+
+func TestCardStatementPeriodContinuity(t *testing.T) {
+	// 연속된 결제월의 이용 기간은 빈 날짜나 겹치는 날짜 없이 이어지고 결제일은 마감 이후여야 함 (윤년 포함 2년)
+	// (마감 28일·결제 31일처럼 2월에 결제일이 말일로 당겨지면 마감일과 결제일이 같을 수 있음)
+	first := mustParseKST(t, "2023-01-01")
+	for _, closingDay := range []int{1, 14, 15, 28, 29, 30, 31} {
+		for _, dueDay := range []int{1, 10, 14, 25, 31} {
+			_, previousEnd, _ := cardStatementPeriod(closingDay, dueDay, first)
+			for i := 1; i < 24; i++ {
+				dueMonth := first.AddDate(0, i, 0)
+				start, end, due := cardStatementPeriod(closingDay, dueDay, dueMonth)
+				if want := previousEnd.AddDate(0, 0, 1); !start.Equal(want) {
+					t.Fatalf("마감 %d일·결제 %d일 %s: 시작일 %s, want %s", closingDay, dueDay, dueMonth.Format("2006-01"),
+						start.Format("2006-01-02"), want.Format("2006-01-02"))
+				}
+				if end.Before(start) || due.Before(end) {
+					t.Fatalf("마감 %d일·결제 %d일 %s: 기간 %s ~ %s, 결제일 %s", closingDay, dueDay, dueMonth.Format("2006-01"),
+						start.Format("2006-01-02"), end.Format("2006-01-02"), due.Format("2006-01-02"))
+				}
+				previousEnd = end
+			}
+		}
+	}
+}
@@ -0,0 +1,343 @@
+package database
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// compiledRule 평가용 자동 분류 규칙 (정규화한 패턴과 컴파일한 정규식 포함)
+type compiledRule struct {
+	models.CategorizationRule
+	pattern string
+	regex   *regexp.Regexp
+}
+
+// ruleCandidate 규칙을 다시 적용할 기존 거래
+type ruleCandidate struct {
+	uuid        string
+	date        string
+	user        string
+	money       int
+	categoryID  int
+	keywordID   *int
+	keywordName string
+	methodID    int
+	memo        string
+}
+
+// loadCategorizationRules 사용 중인 자동 분류 규칙을 평가 순서(우선순위, ID)대로 조회
+// 지정한 카테고리/결제수단/입금경로가 비활성화된 규칙은 제외하며, ruleID 가 0 이 아니면 그 규칙만 조회
+func loadCategorizationRules(exec sqlExecutor, ledgerID int, accountType string, ruleID int) ([]compiledRule, error) {
+	query := categorizationRuleSelectQuery + `
+    WHERE r.ledger_id = ? AND r.type = ? AND r.is_active = 1
+      AND (r.category_id IS NULL OR c.is_active = 1)
+      AND (r.payment_method_id IS NULL OR pm.is_active = 1)
+      AND (r.deposit_path_id IS NULL OR dp.is_active = 1)`
+	args := []interface{}{ledgerID, accountType}
+	if ruleID != 0 {
+		query += ` AND r.id = ?`
+		args = append(args, ruleID)
+	}
+	query += ` ORDER BY r.priority ASC, r.id ASC`
+
+	rules, err := queryCategorizationRules(exec, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	compiled := make([]compiledRule, 0, len(rules))
+	for _, rule := range rules {
+		c := compiledRule{CategorizationRule: rule, pattern: normalizeMatchText(rule.Pattern)}
+		if rule.MatchType == models.RuleMatchRegex {
+			if c.regex, err = regexp.Compile("(?i)" + rule.Pattern); err != nil {
+				utils.Warning("자동 분류 규칙 정규식 오류로 건너뜀: ID=%d, 패턴=%s, %v", rule.ID, rule.Pattern, err)
+				continue
+			}
+		}
+		compiled = append(compiled, c)
+	}
+	return compiled, nil
+}
+
+// normalizeMatchText 비교용 문자열 정규화 (공백 제거, 영문 소문자)
+func normalizeMatchText(value string) string {
+	return strings.ToLower(strings.Join(strings.Fields(value), ""))
+}
+
+// matches 거래가 규칙의 모든 조건(사용자, 금액 범위, 메모/가맹점명 패턴)을 만족하는지 확인
+func (r compiledRule) matches(subject models.RuleSubject) bool {
+	if r.User != "" && !strings.EqualFold(strings.TrimSpace(subject.User), r.User) {
+		return false
+	}
+	if r.MinAmount != nil && subject.Money < *r.MinAmount {
+		return false
+	}
+	if r.MaxAmount != nil && subject.Money > *r.MaxAmount {
+		return false
+	}
+	if r.Pattern == "" {
+		return true
+	}
+
+	for _, text := range []string{subject.Memo, subject.Merchant} {
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		if r.regex != nil {
+			if r.regex.MatchString(text) {
+				return true
+			}
+			continue
+		}
+
+		normalized := normalizeMatchText(text)
+		switch r.MatchType {
+		case models.RuleMatchEquals:
+			if normalized == r.pattern {
+				return true
+			}
+		case models.RuleMatchStartsWith:
+			if strings.HasPrefix(normalized, r.pattern) {
+				return true
+			}
+		default:
+			if strings.Contains(normalized, r.pattern) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// evaluateCategorizationRules 규칙을 우선순위 순서로 평가해 항목별 분류 결과 생성
+// 각 항목(카테고리+키워드, 결제수단, 입금경로)은 그 항목을 지정한 첫 번째 일치 규칙의 값을 사용
+func evaluateCategorizationRules(rules []compiledRule, subject models.RuleSubject) models.RuleResult {
+	result := models.RuleResult{MatchedRules: []models.RuleMatchRef{}}
+	for _, rule := range rules {
+		if !rule.matches(subject) {
+			continue
+		}
+
+		ref := models.RuleMatchRef{ID: rule.ID, Name: rule.Name, Priority: rule.Priority, Fields: []string{}}
+		if rule.CategoryID != nil && result.CategoryID == nil {
+			result.CategoryID, result.CategoryName, result.KeywordName = rule.CategoryID, rule.CategoryName, rule.KeywordName
+			ref.Fields = append(ref.Fields, "category")
+		}
+		if rule.PaymentMethodID != nil && result.PaymentMethodID == nil {
+			result.PaymentMethodID, result.PaymentMethodName = rule.PaymentMethodID, rule.PaymentMethodName
+			ref.Fields = append(ref.Fields, "payment_method")
+		}
+		if rule.DepositPathID != nil && result.DepositPathID == nil {
+			result.DepositPathID, result.DepositPathName = rule.DepositPathID, rule.DepositPathName
+			ref.Fields = append(ref.Fields, "deposit_path")
+		}
+
+		result.Matched = true
+		result.MatchedRules = append(result.MatchedRules, ref)
+	}
+	return result
+}
+
+// MatchCategorizationRules 거래 정보에 자동 분류 규칙을 적용한 결과 조회 (저장하지 않음)
+func (db *DB) MatchCategorizationRules(ledgerID int, subject models.RuleSubject) (*models.RuleResult, error) {
+	if subject.Type != models.AccountTypeOut && subject.Type != models.AccountTypeIn {
+		return nil, apiErrors.ErrInvalidCategorizationRule.WithMessage("type은 'out' 또는 'in'이어야 합니다")
+	}
+
+	rules, err := loadCategorizationRules(db.Conn, ledgerID, subject.Type, 0)
+	if err != nil {
+		return nil, err
+	}
+	result := evaluateCategorizationRules(rules, subject)
+	return &result, nil
+}
+
+// ApplyCategorizationRules 기간 내 기존 거래에 자동 분류 규칙을 다시 적용
+// 규칙이 지정한 항목은 기존 값을 덮어쓰고, 카테고리가 바뀌면서 규칙에 키워드가 없으면 기존 키워드를 비움
+// 분할 지출과 할부 회차는 항목/할부 단위로 관리되므로 제외하며, dry_run 이면 변경 내용만 계산
+func (db *DB) ApplyCategorizationRules(ledgerID int, req models.RuleApplyRequest) (*models.RuleApplyResult, error) {
+	for _, date := range []string{req.StartDate, req.EndDate} {
+		if _, err := utils.ParseDateInLayoutsKST(date, "2006-01-02"); err != nil {
+			return nil, apiErrors.ErrInvalidDateRange.WithMessage("start_date와 end_date는 YYYY-MM-DD 형식이어야 합니다")
+		}
+	}
+	if req.EndDate < req.StartDate {
+		return nil, apiErrors.ErrInvalidDateRange.WithMessage("end_date는 start_date 이후여야 합니다")
+	}
+	if req.Type != "" && req.Type != models.AccountTypeOut && req.Type != models.AccountTypeIn {
+		return nil, apiErrors.ErrInvalidCategorizationRule.WithMessage("type은 'out' 또는 'in'이어야 합니다")
+	}
+
+	types := []string{models.AccountTypeOut, models.AccountTypeIn}
+	ruleID := 0
+	if req.RuleID != nil {
+		rule, err := db.GetCategorizationRuleByID(ledgerID, *req.RuleID)
+		if err != nil {
+			return nil, err
+		}
+		if req.Type != "" && req.Type != rule.Type {
+			return nil, apiErrors.ErrInvalidCategorizationRule.WithMessage("규칙의 유형과 type이 다릅니다")
+		}
+		ruleID, types = rule.ID, []string{rule.Type}
+	} else if req.Type != "" {
+		types = []string{req.Type}
+	}
+
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	result := &models.RuleApplyResult{DryRun: req.DryRun, Changes: []models.RuleChange{}}
+	for _, accountType := range types {
+		rules, err := loadCategorizationRules(tx, ledgerID, accountType, ruleID)
+		if err != nil {
+			return nil, err
+		}
+		if len(rules) == 0 {
+			continue
+		}
+
+		candidates, err := queryRuleCandidates(tx, ledgerID, accountType, req.StartDate, req.EndDate)
+		if err != nil {
+			return nil, err
+		}
+		result.Scanned += len(candidates)
+
+		for _, candidate := range candidates {
+			match := evaluateCategorizationRules(rules, models.RuleSubject{
+				Type: accountType, Memo: candidate.memo, User: candidate.user, Money: candidate.money,
+			})
+			if !match.Matched {
+				continue
+			}
+			result.Matched++
+
+			change := buildRuleChange(accountType, candidate, match)
+			if change == nil {
+				continue
+			}
+			result.Changed++
+			result.Changes = append(result.Changes, *change)
+
+			if !req.DryRun {
+				if err := applyRuleChange(tx, ledgerID, candidate, *change); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	if req.DryRun {
+		return result, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("트랜잭션 커밋 오류: %v", err)
+	}
+	return result, nil
+}
+
+// queryRuleCandidates 규칙을 다시 적용할 기간 내 거래 조회 (분할 지출, 할부 회차 제외)
+func queryRuleCandidates(exec sqlExecutor, ledgerID int, accountType, startDate, endDate string) ([]ruleCandidate, error) {
+	query := `
+    SELECT oa.uuid, oa.date, oa.user, oa.money, oa.category_id, oa.keyword_id, COALESCE(k.name, ''), oa.payment_method_id, COALESCE(oa.memo, '')
+    FROM out_account_data oa
+    LEFT JOIN keywords k ON oa.keyword_id = k.id
+    WHERE oa.ledger_id = ? AND DATE(oa.date) >= ? AND DATE(oa.date) <= ?
+      AND oa.installment_id IS NULL
+      AND NOT EXISTS (SELECT 1 FROM out_account_splits s WHERE s.out_account_uuid = oa.uuid)
+    ORDER BY oa.date ASC, oa.uuid ASC`
+	if accountType == models.AccountTypeIn {
+		query = `
+    SELECT ia.uuid, ia.date, ia.user, ia.money, ia.category_id, ia.keyword_id, COALESCE(k.name, ''), ia.deposit_path_id, COALESCE(ia.memo, '')
+    FROM in_account_data ia
+    LEFT JOIN keywords k ON ia.keyword_id = k.id
+    WHERE ia.ledger_id = ? AND DATE(ia.date) >= ? AND DATE(ia.date) <= ?
+    ORDER BY ia.date ASC, ia.uuid ASC`
+	}
+
+	rows, err := exec.Query(query, ledgerID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("규칙 적용 대상 거래 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	var candidates []ruleCandidate
+	for rows.Next() {
+		var c ruleCandidate
+		var keywordID *int
+		if err := rows.Scan(&c.uuid, &c.date, &c.user, &c.money, &c.categoryID, &keywordID, &c.keywordName, &c.methodID, &c.memo); err != nil {
+			return nil, fmt.Errorf("규칙 적용 대상 거래 읽기 오류: %v", err)
+		}
+		c.keywordID = keywordID
+		candidates = append(candidates, c)
+	}
+	return candidates, rows.Err()
+}
+
+// buildRuleChange 규칙 적용 결과로 바뀌는 값 계산 (바뀌는 값이 없으면 nil)
+func buildRuleChange(accountType string, candidate ruleCandidate, match models.RuleResult) *models.RuleChange {
+	change := models.RuleChange{
+		Type: accountType, UUID: candidate.uuid, Date: candidate.date, Money: candidate.money, Memo: candidate.memo,
+		OldCategoryID: candidate.categoryID, NewCategoryID: candidate.categoryID,
+		OldKeywordName: candidate.keywordName, NewKeywordName: candidate.keywordName,
+		OldMethodID: candidate.methodID, NewMethodID: candidate.methodID,
+		RuleIDs: make([]int, 0, len(match.MatchedRules)),
+	}
+	for _, ref := range match.MatchedRules {
+		if len(ref.Fields) > 0 {
+			change.RuleIDs = append(change.RuleIDs, ref.ID)
+		}
+	}
+
+	if match.CategoryID != nil {
+		change.NewCategoryID = *match.CategoryID
+		if match.KeywordName != "" {
+			change.NewKeywordName = match.KeywordName
+		} else if change.NewCategoryID != candidate.categoryID {
+			change.NewKeywordName = ""
+		}
+	}
+	if accountType == models.AccountTypeOut && match.PaymentMethodID != nil {
+		change.NewMethodID = *match.PaymentMethodID
+	}
+	if accountType == models.AccountTypeIn && match.DepositPathID != nil {
+		change.NewMethodID = *match.DepositPathID
+	}
+
+	if change.NewCategoryID == change.OldCategoryID && change.NewKeywordName == change.OldKeywordName && change.NewMethodID == change.OldMethodID {
+		return nil
+	}
+	return &change
+}
+
+// applyRuleChange 규칙 적용 결과를 거래에 저장 (새 키워드는 카테고리 아래에 생성)
+func applyRuleChange(exec sqlExecutor, ledgerID int, candidate ruleCandidate, change models.RuleChange) error {
+	keywordID := candidate.keywordID
+	switch {
+	case change.NewKeywordName == "":
+		keywordID = nil
+	case change.NewKeywordName != change.OldKeywordName || change.NewCategoryID != change.OldCategoryID:
+		id, err := upsertKeyword(exec, ledgerID, change.NewCategoryID, change.NewKeywordName)
+		if err != nil {
+			return err
+		}
+		kid := int(id)
+		keywordID = &kid
+	}
+
+	query := `UPDATE out_account_data SET category_id = ?, keyword_id = ?, payment_method_id = ?, updated_at = CURRENT_TIMESTAMP WHERE uuid = ? AND ledger_id = ?`
+	if change.Type == models.AccountTypeIn {
+		query = `UPDATE in_account_data SET category_id = ?, keyword_id = ?, deposit_path_id = ?, updated_at = CURRENT_TIMESTAMP WHERE uuid = ? AND ledger_id = ?`
+	}
+	if _, err := exec.Exec(query, change.NewCategoryID, keywordID, change.NewMethodID, change.UUID, ledgerID); err != nil {
+		return fmt.Errorf("규칙 적용 거래 수정 오류: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,294 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+)
+
+// createCategorizationRuleTable 거래 자동 분류 규칙 테이블 생성
+// 키워드는 적용 시점에 카테고리 아래에 만들어지도록 이름으로 보관
+func createCategorizationRuleTable(exec sqlExecutor) error {
+	steps := []string{
+		`CREATE TABLE IF NOT EXISTS categorization_rules (
+            id INTEGER PRIMARY KEY AUTOINCREMENT,
+            ledger_id INTEGER NOT NULL,
+            name VARCHAR(100) NOT NULL,
+            type VARCHAR(10) NOT NULL CHECK (type IN ('out', 'in')),
+            priority INTEGER NOT NULL DEFAULT 0,
+            is_active INTEGER NOT NULL DEFAULT 1,
+            pattern TEXT NOT NULL DEFAULT '',
+            match_type VARCHAR(20) NOT NULL DEFAULT 'contains' CHECK (match_type IN ('contains', 'equals', 'starts_with', 'regex')),
+            min_amount INTEGER NULL,
+            max_amount INTEGER NULL,
+            user VARCHAR(50) NOT NULL DEFAULT '',
+            category_id INTEGER NULL,
+            keyword_name VARCHAR(100) NOT NULL DEFAULT '',
+            payment_method_id INTEGER NULL,
+            deposit_path_id INTEGER NULL,
+            created_at TEXT DEFAULT CURRENT_TIMESTAMP,
+            updated_at TEXT DEFAULT CURRENT_TIMESTAMP,
+            FOREIGN KEY (ledger_id) REFERENCES ledgers(id) ON DELETE CASCADE,
+            FOREIGN KEY (category_id) REFERENCES categories(id),
+            FOREIGN KEY (payment_method_id) REFERENCES payment_methods(id),
+            FOREIGN KEY (deposit_path_id) REFERENCES deposit_paths(id),
+            UNIQUE(ledger_id, name)
+        )`,
+		`CREATE INDEX IF NOT EXISTS idx_categorization_rules_priority ON categorization_rules(ledger_id, type, priority)`,
+	}
+	for _, step := range steps {
+		if _, err := exec.Exec(step); err != nil {
+			return fmt.Errorf("자동 분류 규칙 테이블 생성 오류: %v", err)
+		}
+	}
+	return nil
+}
+
+// categorizationRuleSelectQuery 자동 분류 규칙 조회 공통 쿼리 (카테고리/결제수단/입금경로 이름 포함)
+const categorizationRuleSelectQuery = `
+    SELECT r.id, r.name, r.type, r.priority, r.is_active, r.pattern, r.match_type, r.min_amount, r.max_amount, r.user,
+           r.category_id, COALESCE(c.name, ''), r.keyword_name,
+           r.payment_method_id, COALESCE(pm.name, ''), r.deposit_path_id, COALESCE(dp.name, ''),
+           r.created_at, r.updated_at
+    FROM categorization_rules r
+    LEFT JOIN categories c ON r.category_id = c.id
+    LEFT JOIN payment_methods pm ON r.payment_method_id = pm.id
+    LEFT JOIN deposit_paths dp ON r.deposit_path_id = dp.id`
+
+// GetCategorizationRules 자동 분류 규칙 목록 조회 (accountType 이 비어 있으면 전체, 평가 순서대로)
+func (db *DB) GetCategorizationRules(ledgerID int, accountType string) ([]models.CategorizationRule, error) {
+	query := categorizationRuleSelectQuery + ` WHERE r.ledger_id = ?`
+	args := []interface{}{ledgerID}
+	if accountType != "" {
+		query += ` AND r.type = ?`
+		args = append(args, accountType)
+	}
+	query += ` ORDER BY r.type DESC, r.priority ASC, r.id ASC`
+
+	return queryCategorizationRules(db.Conn, query, args...)
+}
+
+// GetCategorizationRuleByID ID로 자동 분류 규칙 조회
+func (db *DB) GetCategorizationRuleByID(ledgerID, id int) (*models.CategorizationRule, error) {
+	rule, err := scanCategorizationRule(db.Conn.QueryRow(categorizationRuleSelectQuery+` WHERE r.id = ? AND r.ledger_id = ?`, id, ledgerID))
+	if err == sql.ErrNoRows {
+		return nil, apiErrors.ErrCategorizationRuleNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("자동 분류 규칙 조회 오류: %v", err)
+	}
+	return rule, nil
+}
+
+// CreateCategorizationRule 자동 분류 규칙 생성 (우선순위를 생략하면 같은 유형 규칙의 가장 마지막, 같은 이름이 있으면 409)
+func (db *DB) CreateCategorizationRule(ledgerID int, req models.CategorizationRuleRequest) (int64, error) {
+	if err := validateCategorizationRule(db.Conn, ledgerID, &req); err != nil {
+		return 0, err
+	}
+
+	priority := 0
+	if req.Priority != nil {
+		priority = *req.Priority
+	} else if err := db.Conn.QueryRow(`
+        SELECT COALESCE(MAX(priority), 0) + 10 FROM categorization_rules WHERE ledger_id = ? AND type = ?`,
+		ledgerID, req.Type).Scan(&priority); err != nil {
+		return 0, fmt.Errorf("자동 분류 규칙 우선순위 조회 오류: %v", err)
+	}
+	isActive := req.IsActive == nil || *req.IsActive
+
+	result, err := db.Conn.Exec(`
+        INSERT INTO categorization_rules (ledger_id, name, type, priority, is_active, pattern, match_type, min_amount, max_amount, user,
+                                          category_id, keyword_name, payment_method_id, deposit_path_id)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		ledgerID, req.Name, req.Type, priority, isActive, req.Pattern, req.MatchType, req.MinAmount, req.MaxAmount, req.User,
+		req.CategoryID, req.KeywordName, req.PaymentMethodID, req.DepositPathID)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return 0, apiErrors.ErrAlreadyExists.WithMessage("같은 이름의 자동 분류 규칙이 이미 있습니다")
+		}
+		return 0, fmt.Errorf("자동 분류 규칙 생성 오류: %v", err)
+	}
+	return result.LastInsertId()
+}
+
+// UpdateCategorizationRule 자동 분류 규칙 수정 (priority, is_active 를 생략하면 기존 값 유지)
+func (db *DB) UpdateCategorizationRule(ledgerID, id int, req models.CategorizationRuleRequest) error {
+	existing, err := db.GetCategorizationRuleByID(ledgerID, id)
+	if err != nil {
+		return err
+	}
+	if err := validateCategorizationRule(db.Conn, ledgerID, &req); err != nil {
+		return err
+	}
+
+	priority, isActive := existing.Priority, existing.IsActive
+	if req.Priority != nil {
+		priority = *req.Priority
+	}
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	_, err = db.Conn.Exec(`
+        UPDATE categorization_rules
+        SET name = ?, type = ?, priority = ?, is_active = ?, pattern = ?, match_type = ?, min_amount = ?, max_amount = ?, user = ?,
+            category_id = ?, keyword_name = ?, payment_method_id = ?, deposit_path_id = ?, updated_at = CURRENT_TIMESTAMP
+        WHERE id = ? AND ledger_id = ?`,
+		req.Name, req.Type, priority, isActive, req.Pattern, req.MatchType, req.MinAmount, req.MaxAmount, req.User,
+		req.CategoryID, req.KeywordName, req.PaymentMethodID, req.DepositPathID, id, ledgerID)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return apiErrors.ErrAlreadyExists.WithMessage("같은 이름의 자동 분류 규칙이 이미 있습니다")
+		}
+		return fmt.Errorf("자동 분류 규칙 수정 오류: %v", err)
+	}
+	return nil
+}
+
+// DeleteCategorizationRule 자동 분류 규칙 삭제 (이미 분류된 거래는 그대로 유지)
+func (db *DB) DeleteCategorizationRule(ledgerID, id int) error {
+	result, err := db.Conn.Exec(`DELETE FROM categorization_rules WHERE id = ? AND ledger_id = ?`, id, ledgerID)
+	if err != nil {
+		return fmt.Errorf("자동 분류 규칙 삭제 오류: %v", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return apiErrors.ErrCategorizationRuleNotFound
+	}
+	return nil
+}
+
+// queryCategorizationRules 자동 분류 규칙 목록 조회 실행
+func queryCategorizationRules(exec sqlExecutor, query string, args ...interface{}) ([]models.CategorizationRule, error) {
+	rows, err := exec.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("자동 분류 규칙 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	rules := []models.CategorizationRule{}
+	for rows.Next() {
+		rule, err := scanCategorizationRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("자동 분류 규칙 데이터 읽기 오류: %v", err)
+		}
+		rules = append(rules, *rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("자동 분류 규칙 조회 오류: %v", err)
+	}
+	return rules, nil
+}
+
+// scanCategorizationRule 자동 분류 규칙 행 스캔
+func scanCategorizationRule(scanner interface{ Scan(...interface{}) error }) (*models.CategorizationRule, error) {
+	var rule models.CategorizationRule
+	var minAmount, maxAmount, categoryID, paymentMethodID, depositPathID sql.NullInt64
+	err := scanner.Scan(&rule.ID, &rule.Name, &rule.Type, &rule.Priority, &rule.IsActive, &rule.Pattern, &rule.MatchType,
+		&minAmount, &maxAmount, &rule.User,
+		&categoryID, &rule.CategoryName, &rule.KeywordName,
+		&paymentMethodID, &rule.PaymentMethodName, &depositPathID, &rule.DepositPathName,
+		&rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	rule.MinAmount = nullableInt(minAmount)
+	rule.MaxAmount = nullableInt(maxAmount)
+	rule.CategoryID = nullableInt(categoryID)
+	rule.PaymentMethodID = nullableInt(paymentMethodID)
+	rule.DepositPathID = nullableInt(depositPathID)
+	return &rule, nil
+}
+
+// nullableInt NULL 허용 정수 컬럼 값을 포인터로 변환
+func nullableInt(value sql.NullInt64) *int {
+	if !value.Valid {
+		return nil
+	}
+	v := int(value.Int64)
+	return &v
+}
+
+// validateCategorizationRule 자동 분류 규칙 요청 검증 및 정규화
+// 조건(메모 패턴/금액/사용자)과 지정 항목(카테고리/결제수단/입금경로)이 각각 하나 이상 있어야 하고, 참조 항목은 같은 가계부의 사용 중인 항목이어야 함
+func validateCategorizationRule(exec sqlExecutor, ledgerID int, req *models.CategorizationRuleRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	req.Pattern = strings.TrimSpace(req.Pattern)
+	req.User = strings.TrimSpace(req.User)
+	req.KeywordName = strings.TrimSpace(req.KeywordName)
+	if req.MatchType == "" {
+		req.MatchType = models.RuleMatchContains
+	}
+
+	if req.Name == "" {
+		return apiErrors.ErrMissingRequired.WithMessage("규칙 이름은 필수입니다")
+	}
+	if req.Type != models.AccountTypeOut && req.Type != models.AccountTypeIn {
+		return apiErrors.ErrInvalidCategorizationRule.WithMessage("type은 'out' 또는 'in'이어야 합니다")
+	}
+
+	switch req.MatchType {
+	case models.RuleMatchContains, models.RuleMatchEquals, models.RuleMatchStartsWith:
+	case models.RuleMatchRegex:
+		if req.Pattern == "" {
+			return apiErrors.ErrInvalidCategorizationRule.WithMessage("정규식 규칙은 pattern이 필요합니다")
+		}
+		if _, err := regexp.Compile("(?i)" + req.Pattern); err != nil {
+			return apiErrors.ErrInvalidCategorizationRule.WithMessage(fmt.Sprintf("정규식이 올바르지 않습니다: %v", err))
+		}
+	default:
+		return apiErrors.ErrInvalidCategorizationRule.WithMessage("match_type은 'contains', 'equals', 'starts_with', 'regex' 중 하나여야 합니다")
+	}
+
+	if (req.MinAmount != nil && *req.MinAmount < 0) || (req.MaxAmount != nil && *req.MaxAmount < 0) {
+		return apiErrors.ErrInvalidCategorizationRule.WithMessage("금액 조건은 0 이상이어야 합니다")
+	}
+	if req.MinAmount != nil && req.MaxAmount != nil && *req.MinAmount > *req.MaxAmount {
+		return apiErrors.ErrInvalidCategorizationRule.WithMessage("min_amount는 max_amount보다 클 수 없습니다")
+	}
+	if req.Pattern == "" && req.MinAmount == nil && req.MaxAmount == nil && req.User == "" {
+		return apiErrors.ErrInvalidCategorizationRule.WithMessage("pattern, min_amount, max_amount, user 중 하나 이상의 조건이 필요합니다")
+	}
+
+	if req.Type == models.AccountTypeOut && req.DepositPathID != nil {
+		return apiErrors.ErrInvalidCategorizationRule.WithMessage("지출 규칙에는 입금경로를 지정할 수 없습니다")
+	}
+	if req.Type == models.AccountTypeIn && req.PaymentMethodID != nil {
+		return apiErrors.ErrInvalidCategorizationRule.WithMessage("수입 규칙에는 결제수단을 지정할 수 없습니다")
+	}
+	if req.CategoryID == nil && req.PaymentMethodID == nil && req.DepositPathID == nil {
+		return apiErrors.ErrInvalidCategorizationRule.WithMessage("category_id, payment_method_id, deposit_path_id 중 하나 이상을 지정해야 합니다")
+	}
+	if req.KeywordName != "" && req.CategoryID == nil {
+		return apiErrors.ErrInvalidCategorizationRule.WithMessage("키워드는 카테고리와 함께 지정해야 합니다")
+	}
+
+	references := []struct {
+		id       *int
+		query    string
+		args     []interface{}
+		notFound string
+	}{
+		{req.CategoryID, `SELECT 1 FROM categories WHERE id = ? AND ledger_id = ? AND type = ? AND is_active = 1`, []interface{}{ledgerID, req.Type}, "카테고리를 찾을 수 없습니다"},
+		{req.PaymentMethodID, `SELECT 1 FROM payment_methods WHERE id = ? AND ledger_id = ? AND is_active = 1`, []interface{}{ledgerID}, "결제수단을 찾을 수 없습니다"},
+		{req.DepositPathID, `SELECT 1 FROM deposit_paths WHERE id = ? AND ledger_id = ? AND is_active = 1`, []interface{}{ledgerID}, "입금경로를 찾을 수 없습니다"},
+	}
+	for _, ref := range references {
+		if ref.id == nil {
+			continue
+		}
+		var exists int
+		err := exec.QueryRow(ref.query, append([]interface{}{*ref.id}, ref.args...)...).Scan(&exists)
+		if err == sql.ErrNoRows {
+			return apiErrors.ErrInvalidCategorizationRule.WithMessage(fmt.Sprintf("%s (ID: %d)", ref.notFound, *ref.id))
+		}
+		if err != nil {
+			return fmt.Errorf("자동 분류 규칙 참조 확인 오류: %v", err)
+		}
+	}
+	return nil
+}
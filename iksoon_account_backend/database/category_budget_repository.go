@@ -1,6 +1,7 @@
 package database
 
 import (
+	"database/sql"
 	"fmt"
 	"time"
 
@@ -8,7 +9,7 @@ import (
 )
 
 // GetCategoryBudgets 카테고리 기준치 목록 조회
-func (db *DB) GetCategoryBudgets(userName string, categoryID *int) ([]models.CategoryBudget, error) {
+func (db *DB) GetCategoryBudgets(ledgerID int, userName string, categoryID *int) ([]models.CategoryBudget, error) {
 	var query string
 	var args []interface{}
 
@@ -17,42 +18,48 @@ func (db *DB) GetCategoryBudgets(userName string, categoryID *int) ([]models.Cat
 		query = `
 			SELECT cb.id, cb.category_id, c.name, cb.user_name, 
 			       cb.monthly_budget, cb.yearly_budget,
+			       cb.rollover_enabled, cb.rollover_cap, cb.rollover_from,
 			       cb.created_at, cb.updated_at
 			FROM category_budgets cb
 			LEFT JOIN categories c ON cb.category_id = c.id
-			WHERE cb.user_name = ? AND cb.category_id = ?`
-		args = append(args, userName, *categoryID)
+			WHERE cb.ledger_id = ? AND cb.user_name = ? AND cb.category_id = ?`
+		args = append(args, ledgerID, userName, *categoryID)
 	} else if categoryID != nil {
 		// 특정 카테고리의 모든 기준치 조회 (사용자 구분 없음)
 		query = `
 			SELECT cb.id, cb.category_id, c.name, cb.user_name, 
 			       cb.monthly_budget, cb.yearly_budget,
+			       cb.rollover_enabled, cb.rollover_cap, cb.rollover_from,
 			       cb.created_at, cb.updated_at
 			FROM category_budgets cb
 			LEFT JOIN categories c ON cb.category_id = c.id
-			WHERE cb.category_id = ?
+			WHERE cb.ledger_id = ? AND cb.category_id = ?
 			ORDER BY cb.user_name ASC`
-		args = append(args, *categoryID)
+		args = append(args, ledgerID, *categoryID)
 	} else if userName != "" {
 		// 특정 사용자의 모든 기준치 조회
 		query = `
 			SELECT cb.id, cb.category_id, c.name, cb.user_name, 
 			       cb.monthly_budget, cb.yearly_budget,
+			       cb.rollover_enabled, cb.rollover_cap, cb.rollover_from,
 			       cb.created_at, cb.updated_at
 			FROM category_budgets cb
 			LEFT JOIN categories c ON cb.category_id = c.id
-			WHERE cb.user_name = ?
+			WHERE cb.ledger_id = ? AND cb.user_name = ?
 			ORDER BY c.name ASC`
-		args = append(args, userName)
+		args = append(args, ledgerID, userName)
 	} else {
 		// 모든 기준치 조회
 		query = `
 			SELECT cb.id, cb.category_id, c.name, cb.user_name, 
 			       cb.monthly_budget, cb.yearly_budget,
+			       cb.rollover_enabled, cb.rollover_cap, cb.rollover_from,
 			       cb.created_at, cb.updated_at
 			FROM category_budgets cb
 			LEFT JOIN categories c ON cb.category_id = c.id
+			WHERE cb.ledger_id = ?
 			ORDER BY cb.user_name ASC, c.name ASC`
+		args = append(args, ledgerID)
 	}
 
 	rows, err := db.Conn.Query(query, args...)
@@ -65,13 +72,18 @@ func (db *DB) GetCategoryBudgets(userName string, categoryID *int) ([]models.Cat
 	for rows.Next() {
 		var budget models.CategoryBudget
 		var createdAt, updatedAt string
+		var rolloverEnabled int
+		var rolloverCap sql.NullInt64
+		var rolloverFrom sql.NullString
 
 		err := rows.Scan(&budget.ID, &budget.CategoryID, &budget.CategoryName,
 			&budget.UserName, &budget.MonthlyBudget, &budget.YearlyBudget,
+			&rolloverEnabled, &rolloverCap, &rolloverFrom,
 			&createdAt, &updatedAt)
 		if err != nil {
 			return nil, fmt.Errorf("카테고리 기준치 데이터 읽기 오류: %v", err)
 		}
+		scanBudgetRollover(&budget, rolloverEnabled, rolloverCap, rolloverFrom)
 
 		// 시간 파싱
 		if budget.CreatedAt, err = time.Parse("2006-01-02 15:04:05", createdAt); err != nil {
@@ -87,16 +99,26 @@ func (db *DB) GetCategoryBudgets(userName string, categoryID *int) ([]models.Cat
 	return budgets, nil
 }
 
-// CreateCategoryBudget 카테고리 기준치 생성
-func (db *DB) CreateCategoryBudget(categoryID int, userName string, monthlyBudget, yearlyBudget int) (int64, error) {
+// CreateCategoryBudget 카테고리 기준치 생성 (effectiveFrom 월부터 적용, 비어 있으면 이번 달부터)
+func (db *DB) CreateCategoryBudget(ledgerID int, categoryID int, userName string, monthlyBudget, yearlyBudget int, effectiveFrom string) (int64, error) {
 	// 사용자명이 없는 경우 빈 문자열로 처리
 	if userName == "" {
 		userName = ""
 	}
 
+	month, err := parseBudgetMonth(effectiveFrom)
+	if err != nil {
+		return 0, err
+	}
+
+	// 다른 가계부의 카테고리에는 기준치를 설정할 수 없음
+	if err := ensureLedgerRow(db.Conn, "categories", categoryID, ledgerID); err != nil {
+		return 0, err
+	}
+
 	// 중복 확인
 	var count int
-	err := db.Conn.QueryRow(`
+	err = db.Conn.QueryRow(`
 		SELECT COUNT(*) FROM category_budgets 
 		WHERE category_id = ? AND user_name = ?`,
 		categoryID, userName).Scan(&count)
@@ -109,10 +131,16 @@ func (db *DB) CreateCategoryBudget(categoryID int, userName string, monthlyBudge
 	}
 
 	query := `
-		INSERT INTO category_budgets (category_id, user_name, monthly_budget, yearly_budget, created_at, updated_at) 
-		VALUES (?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+		INSERT INTO category_budgets (ledger_id, category_id, user_name, monthly_budget, yearly_budget, created_at, updated_at) 
+		VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
 
-	result, err := db.Conn.Exec(query, categoryID, userName, monthlyBudget, yearlyBudget)
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(query, ledgerID, categoryID, userName, monthlyBudget, yearlyBudget)
 	if err != nil {
 		return 0, fmt.Errorf("기준치 생성 오류: %v", err)
 	}
@@ -122,98 +150,88 @@ func (db *DB) CreateCategoryBudget(categoryID int, userName string, monthlyBudge
 		return 0, fmt.Errorf("기준치 ID 조회 오류: %v", err)
 	}
 
+	// 적용 기간 기록 (목록의 금액은 이번 달 기준치로 동기화)
+	if err := setBudgetPeriod(tx, int(id), month, &monthlyBudget, &yearlyBudget); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("기준치 생성 커밋 오류: %v", err)
+	}
+
 	return id, nil
 }
 
-// UpdateCategoryBudget 카테고리 기준치 수정
-func (db *DB) UpdateCategoryBudget(id int, monthlyBudget, yearlyBudget int) error {
-	query := `
-		UPDATE category_budgets 
-		SET monthly_budget = ?, yearly_budget = ?, updated_at = CURRENT_TIMESTAMP 
-		WHERE id = ?`
-
-	result, err := db.Conn.Exec(query, monthlyBudget, yearlyBudget, id)
+// UpdateCategoryBudget 카테고리 기준치 수정 (effectiveFrom 월부터 적용, 이전 달은 그때 기준치 유지)
+func (db *DB) UpdateCategoryBudget(ledgerID int, id int, monthlyBudget, yearlyBudget int, effectiveFrom string) error {
+	month, err := parseBudgetMonth(effectiveFrom)
 	if err != nil {
-		return fmt.Errorf("기준치 수정 오류: %v", err)
+		return err
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	tx, err := db.Conn.Begin()
 	if err != nil {
-		return fmt.Errorf("기준치 수정 결과 확인 오류: %v", err)
+		return fmt.Errorf("트랜잭션 시작 오류: %v", err)
 	}
+	defer tx.Rollback()
 
-	if rowsAffected == 0 {
+	if err := ensureCategoryBudget(tx, ledgerID, id); err != nil {
 		return fmt.Errorf("수정할 기준치를 찾을 수 없습니다")
 	}
+	if err := setBudgetPeriod(tx, id, month, &monthlyBudget, &yearlyBudget); err != nil {
+		return fmt.Errorf("기준치 수정 오류: %v", err)
+	}
 
-	return nil
+	return tx.Commit()
 }
 
-// UpdateMonthlyBudget 월별 기준치만 수정
-func (db *DB) UpdateMonthlyBudget(categoryID int, userName string, monthlyBudget int) error {
-	// 사용자명이 없는 경우 빈 문자열로 처리
-	if userName == "" {
-		userName = ""
-	}
+// UpdateMonthlyBudget 월별 기준치만 수정 (effectiveFrom 월부터 적용, 연 기준치는 그 달에 적용 중이던 값 유지)
+func (db *DB) UpdateMonthlyBudget(ledgerID int, categoryID int, userName string, monthlyBudget int, effectiveFrom string) error {
+	return db.updateBudgetAmount(ledgerID, categoryID, userName, &monthlyBudget, nil, effectiveFrom)
+}
 
-	query := `
-		UPDATE category_budgets
-		SET monthly_budget = ?, updated_at = CURRENT_TIMESTAMP
-		WHERE category_id = ? AND user_name = ?`
-	args := []interface{}{monthlyBudget, categoryID, userName}
+// UpdateYearlyBudget 연별 기준치만 수정 (effectiveFrom 월부터 적용, 월 기준치는 그 달에 적용 중이던 값 유지)
+func (db *DB) UpdateYearlyBudget(ledgerID int, categoryID int, userName string, yearlyBudget int, effectiveFrom string) error {
+	return db.updateBudgetAmount(ledgerID, categoryID, userName, nil, &yearlyBudget, effectiveFrom)
+}
 
-	result, err := db.Conn.Exec(query, args...)
+// updateBudgetAmount 카테고리/사용자의 기준치 중 지정한 금액만 적용 기간으로 저장
+func (db *DB) updateBudgetAmount(ledgerID int, categoryID int, userName string, monthlyBudget, yearlyBudget *int, effectiveFrom string) error {
+	month, err := parseBudgetMonth(effectiveFrom)
 	if err != nil {
-		return fmt.Errorf("월별 기준치 수정 오류: %v", err)
+		return err
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	tx, err := db.Conn.Begin()
 	if err != nil {
-		return fmt.Errorf("월별 기준치 수정 결과 확인 오류: %v", err)
+		return fmt.Errorf("트랜잭션 시작 오류: %v", err)
 	}
+	defer tx.Rollback()
 
-	if rowsAffected == 0 {
+	var budgetID int
+	err = tx.QueryRow(`SELECT id FROM category_budgets WHERE ledger_id = ? AND category_id = ? AND user_name = ?`,
+		ledgerID, categoryID, userName).Scan(&budgetID)
+	if err == sql.ErrNoRows {
 		return fmt.Errorf("수정할 기준치를 찾을 수 없습니다")
 	}
-
-	return nil
-}
-
-// UpdateYearlyBudget 연별 기준치만 수정
-func (db *DB) UpdateYearlyBudget(categoryID int, userName string, yearlyBudget int) error {
-	// 사용자명이 없는 경우 빈 문자열로 처리
-	if userName == "" {
-		userName = ""
-	}
-
-	query := `
-		UPDATE category_budgets
-		SET yearly_budget = ?, updated_at = CURRENT_TIMESTAMP
-		WHERE category_id = ? AND user_name = ?`
-	args := []interface{}{yearlyBudget, categoryID, userName}
-
-	result, err := db.Conn.Exec(query, args...)
 	if err != nil {
-		return fmt.Errorf("연별 기준치 수정 오류: %v", err)
+		return fmt.Errorf("기준치 조회 오류: %v", err)
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("연별 기준치 수정 결과 확인 오류: %v", err)
-	}
-
-	if rowsAffected == 0 {
-		return fmt.Errorf("수정할 기준치를 찾을 수 없습니다")
+	if err := setBudgetPeriod(tx, budgetID, month, monthlyBudget, yearlyBudget); err != nil {
+		return fmt.Errorf("기준치 수정 오류: %v", err)
 	}
-
-	return nil
+	return tx.Commit()
 }
 
-// DeleteCategoryBudget 카테고리 기준치 삭제 (물리적 삭제)
-func (db *DB) DeleteCategoryBudget(id int) error {
-	query := `DELETE FROM category_budgets WHERE id = ?`
+// DeleteCategoryBudget 카테고리 기준치 삭제 (물리적 삭제, 적용 기간 이력 포함)
+func (db *DB) DeleteCategoryBudget(ledgerID int, id int) error {
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
 
-	result, err := db.Conn.Exec(query, id)
+	result, err := tx.Exec(`DELETE FROM category_budgets WHERE id = ? AND ledger_id = ?`, id, ledgerID)
 	if err != nil {
 		return fmt.Errorf("기준치 삭제 오류: %v", err)
 	}
@@ -227,27 +245,40 @@ func (db *DB) DeleteCategoryBudget(id int) error {
 		return fmt.Errorf("삭제할 기준치를 찾을 수 없습니다")
 	}
 
-	return nil
+	if _, err := tx.Exec(`DELETE FROM category_budget_periods WHERE budget_id = ?`, id); err != nil {
+		return fmt.Errorf("기준치 적용 기간 삭제 오류: %v", err)
+	}
+	if err := deleteBudgetAlerts(tx, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
-// GetBudgetUsage 카테고리별 기준치 사용량 계산
-func (db *DB) GetBudgetUsage(categoryID int, userName string, currentDate time.Time) (*models.BudgetUsage, error) {
+// GetBudgetUsage 카테고리별 기준치 사용량 계산 (분할 지출은 해당 카테고리 항목 금액만 반영)
+// 기준치는 currentDate 가 속한 달에 적용되던 금액을 사용하며, 그 달에 적용된 기준치가 없으면 nil
+func (db *DB) GetBudgetUsage(ledgerID int, categoryID int, userName string, currentDate time.Time) (*models.BudgetUsage, error) {
 	// 기준치 조회 (사용자별 기준치가 없으면 전체 기준치 조회)
 	var budget models.CategoryBudget
 	var categoryName string
 	var createdAt, updatedAt string
+	var rolloverEnabled int
+	var rolloverCap sql.NullInt64
+	var rolloverFrom sql.NullString
 
 	// 먼저 해당 사용자의 기준치 조회
 	err := db.Conn.QueryRow(`
 		SELECT cb.id, cb.category_id, c.name, cb.user_name, 
 		       cb.monthly_budget, cb.yearly_budget,
+			       cb.rollover_enabled, cb.rollover_cap, cb.rollover_from,
 		       cb.created_at, cb.updated_at
 		FROM category_budgets cb
 		LEFT JOIN categories c ON cb.category_id = c.id
-		WHERE cb.category_id = ? AND cb.user_name = ?`,
-		categoryID, userName).Scan(
+		WHERE cb.ledger_id = ? AND cb.category_id = ? AND cb.user_name = ?`,
+		ledgerID, categoryID, userName).Scan(
 		&budget.ID, &budget.CategoryID, &categoryName, &budget.UserName,
 		&budget.MonthlyBudget, &budget.YearlyBudget,
+		&rolloverEnabled, &rolloverCap, &rolloverFrom,
 		&createdAt, &updatedAt)
 
 	if err != nil {
@@ -255,13 +286,15 @@ func (db *DB) GetBudgetUsage(categoryID int, userName string, currentDate time.T
 		err = db.Conn.QueryRow(`
 			SELECT cb.id, cb.category_id, c.name, cb.user_name, 
 			       cb.monthly_budget, cb.yearly_budget,
+			       cb.rollover_enabled, cb.rollover_cap, cb.rollover_from,
 			       cb.created_at, cb.updated_at
 			FROM category_budgets cb
 			LEFT JOIN categories c ON cb.category_id = c.id
-			WHERE cb.category_id = ? AND cb.user_name = ''`,
-			categoryID).Scan(
+			WHERE cb.ledger_id = ? AND cb.category_id = ? AND cb.user_name = ''`,
+			ledgerID, categoryID).Scan(
 			&budget.ID, &budget.CategoryID, &categoryName, &budget.UserName,
 			&budget.MonthlyBudget, &budget.YearlyBudget,
+			&rolloverEnabled, &rolloverCap, &rolloverFrom,
 			&createdAt, &updatedAt)
 
 		if err != nil {
@@ -271,6 +304,17 @@ func (db *DB) GetBudgetUsage(categoryID int, userName string, currentDate time.T
 	}
 
 	budget.CategoryName = categoryName
+	scanBudgetRollover(&budget, rolloverEnabled, rolloverCap, rolloverFrom)
+
+	// 해당 월에 적용되던 기준치로 평가 (이후 변경된 기준치로 과거를 다시 판정하지 않음)
+	monthly, yearly, found, err := budgetPeriodAt(db.Conn, budget.ID, currentDate.Format("2006-01"))
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+	budget.MonthlyBudget, budget.YearlyBudget = monthly, yearly
 
 	// 시간 파싱
 	if budget.CreatedAt, err = time.Parse("2006-01-02 15:04:05", createdAt); err != nil {
@@ -289,19 +333,19 @@ func (db *DB) GetBudgetUsage(categoryID int, userName string, currentDate time.T
 	// 전체 기준치(user_name = "")인 경우 모든 사용자의 지출 합산
 	if budget.UserName == "" {
 		err = db.Conn.QueryRow(`
-			SELECT COALESCE(SUM(money), 0) FROM out_account_data 
-			WHERE category_id = ? 
+			SELECT COALESCE(SUM(money), 0) FROM out_account_line_items 
+			WHERE ledger_id = ? AND category_id = ? 
 			AND date >= ? AND date <= ?`,
-			categoryID,
+			ledgerID, categoryID,
 			monthStart.Format("2006-01-02 15:04:05"),
 			monthEnd.Format("2006-01-02 15:04:05")).Scan(&monthlyUsed)
 	} else {
 		// 특정 사용자의 지출만 계산
 		err = db.Conn.QueryRow(`
-			SELECT COALESCE(SUM(money), 0) FROM out_account_data 
-			WHERE category_id = ? AND user = ? 
+			SELECT COALESCE(SUM(money), 0) FROM out_account_line_items 
+			WHERE ledger_id = ? AND category_id = ? AND user = ? 
 			AND date >= ? AND date <= ?`,
-			categoryID, userName,
+			ledgerID, categoryID, userName,
 			monthStart.Format("2006-01-02 15:04:05"),
 			monthEnd.Format("2006-01-02 15:04:05")).Scan(&monthlyUsed)
 	}
@@ -319,19 +363,19 @@ func (db *DB) GetBudgetUsage(categoryID int, userName string, currentDate time.T
 	// 전체 기준치(user_name = "")인 경우 모든 사용자의 지출 합산
 	if budget.UserName == "" {
 		err = db.Conn.QueryRow(`
-			SELECT COALESCE(SUM(money), 0) FROM out_account_data 
-			WHERE category_id = ? 
+			SELECT COALESCE(SUM(money), 0) FROM out_account_line_items 
+			WHERE ledger_id = ? AND category_id = ? 
 			AND date >= ? AND date <= ?`,
-			categoryID,
+			ledgerID, categoryID,
 			yearStart.Format("2006-01-02 15:04:05"),
 			yearEnd.Format("2006-01-02 15:04:05")).Scan(&yearlyUsed)
 	} else {
 		// 특정 사용자의 지출만 계산
 		err = db.Conn.QueryRow(`
-			SELECT COALESCE(SUM(money), 0) FROM out_account_data 
-			WHERE category_id = ? AND user = ? 
+			SELECT COALESCE(SUM(money), 0) FROM out_account_line_items 
+			WHERE ledger_id = ? AND category_id = ? AND user = ? 
 			AND date >= ? AND date <= ?`,
-			categoryID, userName,
+			ledgerID, categoryID, userName,
 			yearStart.Format("2006-01-02 15:04:05"),
 			yearEnd.Format("2006-01-02 15:04:05")).Scan(&yearlyUsed)
 	}
@@ -352,10 +396,28 @@ func (db *DB) GetBudgetUsage(categoryID int, userName string, currentDate time.T
 		YearlyRemaining:  budget.YearlyBudget - yearlyUsed,
 	}
 
+	// 이월 모드면 지난달까지의 이월 금액을 더한 사용 가능 금액 기준으로 평가
+	available := budget.MonthlyBudget
+	if budget.RolloverEnabled {
+		month := currentDate.Format("2006-01")
+		carries, err := budgetRolloverCarries(db.Conn, ledgerID, budget, month)
+		if err != nil {
+			return nil, err
+		}
+		usage.RolloverEnabled = true
+		usage.CarriedAmount = carries[month]
+		available += usage.CarriedAmount
+		usage.MonthlyRemaining = available - monthlyUsed
+	}
+	usage.AvailableAmount = available
+
 	// 퍼센티지 계산
-	if budget.MonthlyBudget > 0 {
-		usage.MonthlyPercent = float64(monthlyUsed) / float64(budget.MonthlyBudget) * 100
-		usage.IsMonthlyOver = monthlyUsed > budget.MonthlyBudget
+	if available > 0 {
+		usage.MonthlyPercent = float64(monthlyUsed) / float64(available) * 100
+		usage.IsMonthlyOver = monthlyUsed > available
+	} else if budget.RolloverEnabled {
+		// 이월된 초과 지출로 사용 가능 금액이 없으면 지출이 있는 순간 초과
+		usage.IsMonthlyOver = monthlyUsed > available
 	}
 
 	if budget.YearlyBudget > 0 {
@@ -367,16 +429,16 @@ func (db *DB) GetBudgetUsage(categoryID int, userName string, currentDate time.T
 }
 
 // GetAllBudgetUsages 사용자의 모든 카테고리 기준치 사용량 조회
-func (db *DB) GetAllBudgetUsages(userName string, currentDate time.Time) ([]models.BudgetUsage, error) {
+func (db *DB) GetAllBudgetUsages(ledgerID int, userName string, currentDate time.Time) ([]models.BudgetUsage, error) {
 	// 사용자의 모든 기준치 조회
-	budgets, err := db.GetCategoryBudgets(userName, nil)
+	budgets, err := db.GetCategoryBudgets(ledgerID, userName, nil)
 	if err != nil {
 		return nil, fmt.Errorf("기준치 목록 조회 오류: %v", err)
 	}
 
 	var usages []models.BudgetUsage
 	for _, budget := range budgets {
-		usage, err := db.GetBudgetUsage(budget.CategoryID, userName, currentDate)
+		usage, err := db.GetBudgetUsage(ledgerID, budget.CategoryID, userName, currentDate)
 		if err != nil {
 			continue // 오류가 있는 항목은 건너뜀
 		}
@@ -8,7 +8,7 @@ import (
 )
 
 // GetCategories 카테고리 목록 조회
-func (db *DB) GetCategories(categoryType string) ([]models.Category, error) {
+func (db *DB) GetCategories(ledgerID int, categoryType string) ([]models.Category, error) {
 	var query string
 	var args []interface{}
 
@@ -16,14 +16,16 @@ func (db *DB) GetCategories(categoryType string) ([]models.Category, error) {
 		query = `
 			SELECT id, name, type, COALESCE(expense_type, 'variable') as expense_type, is_active, created_at, updated_at 
 			FROM categories 
-			WHERE type = ?
+			WHERE ledger_id = ? AND type = ?
 			ORDER BY name ASC`
-		args = append(args, categoryType)
+		args = append(args, ledgerID, categoryType)
 	} else {
 		query = `
 			SELECT id, name, type, COALESCE(expense_type, 'variable') as expense_type, is_active, created_at, updated_at 
 			FROM categories 
+			WHERE ledger_id = ?
 			ORDER BY type ASC, name ASC`
+		args = append(args, ledgerID)
 	}
 
 	rows, err := db.Conn.Query(query, args...)
@@ -57,12 +59,12 @@ func (db *DB) GetCategories(categoryType string) ([]models.Category, error) {
 }
 
 // CreateCategory 카테고리 생성
-func (db *DB) CreateCategory(name, categoryType, expenseType string) (int64, error) {
-	// 중복 확인 (같은 타입에서 같은 이름의 활성 카테고리)
+func (db *DB) CreateCategory(ledgerID int, name, categoryType, expenseType string) (int64, error) {
+	// 중복 확인 (같은 가계부, 같은 타입에서 같은 이름의 활성 카테고리)
 	var count int
 	err := db.Conn.QueryRow(`
 		SELECT COUNT(*) FROM categories 
-		WHERE name = ? AND type = ? AND is_active = 1`, name, categoryType).Scan(&count)
+		WHERE ledger_id = ? AND name = ? AND type = ? AND is_active = 1`, ledgerID, name, categoryType).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("카테고리 중복 확인 오류: %v", err)
 	}
@@ -76,10 +78,10 @@ func (db *DB) CreateCategory(name, categoryType, expenseType string) (int64, err
 	}
 
 	query := `
-		INSERT INTO categories (name, type, expense_type, created_at, updated_at) 
-		VALUES (?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+		INSERT INTO categories (ledger_id, name, type, expense_type, created_at, updated_at) 
+		VALUES (?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
 
-	result, err := db.Conn.Exec(query, name, categoryType, expenseType)
+	result, err := db.Conn.Exec(query, ledgerID, name, categoryType, expenseType)
 	if err != nil {
 		return 0, fmt.Errorf("카테고리 생성 오류: %v", err)
 	}
@@ -93,13 +95,13 @@ func (db *DB) CreateCategory(name, categoryType, expenseType string) (int64, err
 }
 
 // UpdateCategory 카테고리 수정
-func (db *DB) UpdateCategory(id int, name string, categoryType string, expenseType string) error {
+func (db *DB) UpdateCategory(ledgerID int, id int, name string, categoryType string, expenseType string) error {
 	// 중복 확인 (자신 제외)
 	var count int
 	err := db.Conn.QueryRow(`
 		SELECT COUNT(*) FROM categories 
-		WHERE name = ? AND type = ? AND id != ? AND is_active = 1`,
-		name, categoryType, id).Scan(&count)
+		WHERE ledger_id = ? AND name = ? AND type = ? AND id != ? AND is_active = 1`,
+		ledgerID, name, categoryType, id).Scan(&count)
 	if err != nil {
 		return fmt.Errorf("카테고리 중복 확인 오류: %v", err)
 	}
@@ -115,9 +117,9 @@ func (db *DB) UpdateCategory(id int, name string, categoryType string, expenseTy
 	query := `
 		UPDATE categories 
 		SET name = ?, type = ?, expense_type = ?, updated_at = CURRENT_TIMESTAMP 
-		WHERE id = ?`
+		WHERE id = ? AND ledger_id = ?`
 
-	result, err := db.Conn.Exec(query, name, categoryType, expenseType, id)
+	result, err := db.Conn.Exec(query, name, categoryType, expenseType, id, ledgerID)
 	if err != nil {
 		return fmt.Errorf("카테고리 수정 오류: %v", err)
 	}
@@ -135,19 +137,19 @@ func (db *DB) UpdateCategory(id int, name string, categoryType string, expenseTy
 }
 
 // CheckCategoryUsage 카테고리 사용 여부 확인
-func (db *DB) CheckCategoryUsage(categoryID int) (bool, error) {
+func (db *DB) CheckCategoryUsage(ledgerID int, categoryID int) (bool, error) {
 	// 지출 데이터에서 사용 여부 확인
-	outQuery := `SELECT COUNT(*) FROM out_account_data WHERE category_id = ?`
+	outQuery := `SELECT COUNT(*) FROM out_account_line_items WHERE ledger_id = ? AND category_id = ?`
 	var outCount int
-	err := db.Conn.QueryRow(outQuery, categoryID).Scan(&outCount)
+	err := db.Conn.QueryRow(outQuery, ledgerID, categoryID).Scan(&outCount)
 	if err != nil {
 		return false, fmt.Errorf("지출 데이터에서 카테고리 사용 여부 확인 오류: %v", err)
 	}
 
 	// 수입 데이터에서 사용 여부 확인
-	inQuery := `SELECT COUNT(*) FROM in_account_data WHERE category_id = ?`
+	inQuery := `SELECT COUNT(*) FROM in_account_data WHERE ledger_id = ? AND category_id = ?`
 	var inCount int
-	err = db.Conn.QueryRow(inQuery, categoryID).Scan(&inCount)
+	err = db.Conn.QueryRow(inQuery, ledgerID, categoryID).Scan(&inCount)
 	if err != nil {
 		return false, fmt.Errorf("수입 데이터에서 카테고리 사용 여부 확인 오류: %v", err)
 	}
@@ -161,14 +163,14 @@ func (db *DB) CheckCategoryUsage(categoryID int) (bool, error) {
 }
 
 // DeleteCategory 카테고리 삭제 (비활성화로 변경하여 기존 가계부 정보 유지)
-func (db *DB) DeleteCategory(id int) error {
+func (db *DB) DeleteCategory(ledgerID int, id int) error {
 	// 비활성화로 변경하여 기존 가계부 정보 유지
 	query := `
 		UPDATE categories 
 		SET is_active = 0, updated_at = CURRENT_TIMESTAMP 
-		WHERE id = ?`
+		WHERE id = ? AND ledger_id = ?`
 
-	result, err := db.Conn.Exec(query, id)
+	result, err := db.Conn.Exec(query, id, ledgerID)
 	if err != nil {
 		return fmt.Errorf("카테고리 삭제 오류: %v", err)
 	}
@@ -186,14 +188,14 @@ func (db *DB) DeleteCategory(id int) error {
 }
 
 // ForceDeleteCategory 카테고리 강제 삭제 (비활성화로 변경하여 기존 가계부 정보 유지)
-func (db *DB) ForceDeleteCategory(id int) error {
+func (db *DB) ForceDeleteCategory(ledgerID int, id int) error {
 	// 사용 중이어도 비활성화만 하여 기존 가계부 정보 유지
 	query := `
 		UPDATE categories 
 		SET is_active = 0, updated_at = CURRENT_TIMESTAMP 
-		WHERE id = ?`
+		WHERE id = ? AND ledger_id = ?`
 
-	result, err := db.Conn.Exec(query, id)
+	result, err := db.Conn.Exec(query, id, ledgerID)
 	if err != nil {
 		return fmt.Errorf("카테고리 강제 삭제 오류: %v", err)
 	}
@@ -211,16 +213,16 @@ func (db *DB) ForceDeleteCategory(id int) error {
 }
 
 // GetCategoryByID ID로 카테고리 조회
-func (db *DB) GetCategoryByID(id int) (*models.Category, error) {
+func (db *DB) GetCategoryByID(ledgerID int, id int) (*models.Category, error) {
 	query := `
 		SELECT id, name, type, COALESCE(expense_type, 'variable') as expense_type, is_active, created_at, updated_at 
 		FROM categories 
-		WHERE id = ?`
+		WHERE id = ? AND ledger_id = ?`
 
 	var category models.Category
 	var createdAt, updatedAt string
 
-	err := db.Conn.QueryRow(query, id).Scan(&category.ID, &category.Name, &category.Type, &category.ExpenseType, &category.IsActive, &createdAt, &updatedAt)
+	err := db.Conn.QueryRow(query, id, ledgerID).Scan(&category.ID, &category.Name, &category.Type, &category.ExpenseType, &category.IsActive, &createdAt, &updatedAt)
 	if err != nil {
 		return nil, fmt.Errorf("카테고리 조회 오류: %v", err)
 	}
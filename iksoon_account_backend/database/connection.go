@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"os"
+	"sync"
 
 	_ "github.com/mattn/go-sqlite3"
 )
@@ -11,10 +12,50 @@ import (
 // DB 구조체
 type DB struct {
 	Conn *sql.DB
+	Path string // 데이터베이스 파일 경로 (백업/복원에 사용)
+
+	// 첨부파일 저장 디렉토리 (비어있으면 첨부파일 저장 불가, 백업/복원에 함께 포함)
+	AttachmentDir string
+
+	// 백업 스냅샷이 DB와 첨부파일을 같은 시점으로 담도록 첨부파일 삭제와 스냅샷 생성 사이를 동기화
+	attachmentMu sync.RWMutex
+
+	// 복원 시 Conn 교체와 사용 중인 요청/작업 사이의 동기화 (AcquireConn, AcquireExclusive)
+	connMu sync.RWMutex
+
+	// 거래 검색에 FTS5 인덱스를 사용하는지 여부 (EnsureSearchIndex 에서 설정, false 면 부분 일치 검색)
+	searchIndex bool
 }
 
-// InitDB 데이터베이스 초기화
+// sqlExecutor *sql.DB와 *sql.Tx를 공통으로 다루기 위한 인터페이스
+type sqlExecutor interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// InitDB 데이터베이스 연결 후 대기 중인 스키마 마이그레이션 적용 및 거래 검색 인덱스 준비
 func InitDB(dbPath string) (*DB, error) {
+	db, err := OpenDB(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := db.Migrate(); err != nil {
+		db.Conn.Close()
+		return nil, err
+	}
+
+	if err := db.EnsureSearchIndex(); err != nil {
+		db.Conn.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// OpenDB 데이터베이스 연결 (마이그레이션은 적용하지 않음)
+func OpenDB(dbPath string) (*DB, error) {
 	// 환경변수에서 데이터베이스 경로 가져오기
 	if dbPath == "" {
 		dbPath = os.Getenv("SQLITE_DB_PATH")
@@ -23,6 +64,16 @@ func InitDB(dbPath string) (*DB, error) {
 		}
 	}
 
+	conn, err := openConn(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DB{Conn: conn, Path: dbPath}, nil
+}
+
+// openConn SQLite 연결 생성 및 공통 PRAGMA 설정
+func openConn(dbPath string) (*sql.DB, error) {
 	conn, err := sql.Open("sqlite3", dbPath)
 	if err != nil {
 		return nil, err
@@ -31,70 +82,63 @@ func InitDB(dbPath string) (*DB, error) {
 	// 외래키 제약조건 활성화
 	_, err = conn.Exec("PRAGMA foreign_keys = ON;")
 	if err != nil {
+		conn.Close()
 		return nil, fmt.Errorf("외래키 활성화 오류: %v", err)
 	}
 
 	// WAL 모드 활성화 (성능 향상)
 	_, err = conn.Exec("PRAGMA journal_mode = WAL;")
 	if err != nil {
+		conn.Close()
 		return nil, fmt.Errorf("WAL 모드 활성화 오류: %v", err)
 	}
 
-	db := &DB{Conn: conn}
-
-	// 테이블 생성 순서 중요 (외래키 제약조건 때문에)
-	if err := db.createUserTable(); err != nil {
-		return nil, err
-	}
-
-	if err := db.createCategoryTable(); err != nil {
-		return nil, err
-	}
-
-	if err := db.createKeywordTable(); err != nil {
-		return nil, err
-	}
-
-	if err := db.createPaymentMethodTable(); err != nil {
-		return nil, err
-	}
-
-	if err := db.createDepositPathTable(); err != nil {
-		return nil, err
-	}
-
-	if err := db.createOutAccountTable(); err != nil {
-		return nil, err
-	}
-
-	if err := db.createInAccountTable(); err != nil {
-		return nil, err
-	}
-
-	if err := db.createCategoryBudgetTable(); err != nil {
-		return nil, err
-	}
-
-	return db, nil
+	return conn, nil
 }
 
 // 테이블 생성 메서드들
 
 // tableExists 테이블 존재 여부 확인 헬퍼 함수
-func (db *DB) tableExists(tableName string) (bool, error) {
+func tableExists(exec sqlExecutor, tableName string) (bool, error) {
 	var count int
 	query := `SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name=?`
-	err := db.Conn.QueryRow(query, tableName).Scan(&count)
+	err := exec.QueryRow(query, tableName).Scan(&count)
 	if err != nil {
 		return false, err
 	}
 	return count > 0, nil
 }
 
+// columnExists 테이블에 컬럼이 존재하는지 확인
+func columnExists(exec sqlExecutor, tableName, columnName string) (bool, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?`
+	if err := exec.QueryRow(query, tableName, columnName).Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// addColumnIfNotExists 컬럼이 없을 때만 추가 (추가된 경우 true 반환, 실패 시 오류 보고)
+func addColumnIfNotExists(exec sqlExecutor, tableName, columnName, definition string) (bool, error) {
+	exists, err := columnExists(exec, tableName, columnName)
+	if err != nil {
+		return false, fmt.Errorf("%s.%s 컬럼 확인 오류: %v", tableName, columnName, err)
+	}
+	if exists {
+		return false, nil
+	}
+
+	if _, err := exec.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", tableName, columnName, definition)); err != nil {
+		return false, fmt.Errorf("%s.%s 컬럼 추가 오류: %v", tableName, columnName, err)
+	}
+	return true, nil
+}
+
 // 사용자 테이블 생성
-func (db *DB) createUserTable() error {
+func (db *DB) createUserTable(exec sqlExecutor) error {
 	// 테이블이 이미 존재하는지 확인
-	exists, err := db.tableExists("users")
+	exists, err := tableExists(exec, "users")
 	if err != nil {
 		return fmt.Errorf("테이블 존재 여부 확인 오류: %v", err)
 	}
@@ -109,21 +153,21 @@ func (db *DB) createUserTable() error {
         updated_at TEXT DEFAULT CURRENT_TIMESTAMP
     );`
 
-	_, err = db.Conn.Exec(createUserTable)
+	_, err = exec.Exec(createUserTable)
 	if err != nil {
 		return fmt.Errorf("사용자 테이블 생성 오류: %v", err)
 	}
 
 	// 테이블이 새로 생성된 경우에만 기본 데이터 삽입
 	if !exists {
-		db.insertDefaultUsers()
+		return db.insertDefaultUsers(exec)
 	}
 	return nil
 }
 
-func (db *DB) createCategoryTable() error {
+func (db *DB) createCategoryTable(exec sqlExecutor) error {
 	// 테이블이 이미 존재하는지 확인
-	exists, err := db.tableExists("categories")
+	exists, err := tableExists(exec, "categories")
 	if err != nil {
 		return fmt.Errorf("테이블 존재 여부 확인 오류: %v", err)
 	}
@@ -131,31 +175,30 @@ func (db *DB) createCategoryTable() error {
 	createCategoryTable := `
     CREATE TABLE IF NOT EXISTS categories (
         id INTEGER PRIMARY KEY AUTOINCREMENT,
+        ledger_id INTEGER NOT NULL DEFAULT 1,
         name VARCHAR(255) NOT NULL,
         type VARCHAR(10) NOT NULL CHECK (type IN ('out', 'in')),
         expense_type VARCHAR(10) DEFAULT 'variable' CHECK (expense_type IN ('fixed', 'variable')),
         is_active BOOLEAN DEFAULT 1,
         created_at TEXT DEFAULT CURRENT_TIMESTAMP,
         updated_at TEXT DEFAULT CURRENT_TIMESTAMP,
-        UNIQUE(name, type)
+        FOREIGN KEY (ledger_id) REFERENCES ledgers(id) ON DELETE CASCADE,
+        UNIQUE(ledger_id, name, type)
     );`
 
-	_, err = db.Conn.Exec(createCategoryTable)
+	_, err = exec.Exec(createCategoryTable)
 	if err != nil {
 		return fmt.Errorf("카테고리 테이블 생성 오류: %v", err)
 	}
 
-	// 기존 테이블에 is_active 컬럼 추가 (마이그레이션)
-	db.addCategoryIsActiveColumn()
-
 	// 테이블이 새로 생성된 경우에만 기본 데이터 삽입
 	if !exists {
-		db.insertDefaultCategories()
+		return db.insertDefaultCategories(exec, DefaultLedgerID)
 	}
 	return nil
 }
 
-func (db *DB) createKeywordTable() error {
+func (db *DB) createKeywordTable(exec sqlExecutor) error {
 	createKeywordTable := `
     CREATE TABLE IF NOT EXISTS keywords (
         id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -169,20 +212,17 @@ func (db *DB) createKeywordTable() error {
         UNIQUE(category_id, name)
     );`
 
-	_, err := db.Conn.Exec(createKeywordTable)
+	_, err := exec.Exec(createKeywordTable)
 	if err != nil {
 		return fmt.Errorf("키워드 테이블 생성 오류: %v", err)
 	}
 
-	// 기존 테이블에 is_active 컬럼 추가 (마이그레이션)
-	db.addKeywordIsActiveColumn()
-
 	return nil
 }
 
-func (db *DB) createPaymentMethodTable() error {
+func (db *DB) createPaymentMethodTable(exec sqlExecutor) error {
 	// 테이블이 이미 존재하는지 확인
-	exists, err := db.tableExists("payment_methods")
+	exists, err := tableExists(exec, "payment_methods")
 	if err != nil {
 		return fmt.Errorf("테이블 존재 여부 확인 오류: %v", err)
 	}
@@ -190,30 +230,32 @@ func (db *DB) createPaymentMethodTable() error {
 	createPaymentMethodTable := `
     CREATE TABLE IF NOT EXISTS payment_methods (
         id INTEGER PRIMARY KEY AUTOINCREMENT,
+        ledger_id INTEGER NOT NULL DEFAULT 1,
         name VARCHAR(255) NOT NULL,
         parent_id INTEGER NULL,
         is_active BOOLEAN DEFAULT TRUE,
         created_at TEXT DEFAULT CURRENT_TIMESTAMP,
         updated_at TEXT DEFAULT CURRENT_TIMESTAMP,
         FOREIGN KEY (parent_id) REFERENCES payment_methods(id),
-        UNIQUE(name, parent_id)
+        FOREIGN KEY (ledger_id) REFERENCES ledgers(id) ON DELETE CASCADE,
+        UNIQUE(ledger_id, name, parent_id)
     );`
 
-	_, err = db.Conn.Exec(createPaymentMethodTable)
+	_, err = exec.Exec(createPaymentMethodTable)
 	if err != nil {
 		return fmt.Errorf("결제수단 테이블 생성 오류: %v", err)
 	}
 
 	// 테이블이 새로 생성된 경우에만 기본 데이터 삽입
 	if !exists {
-		db.insertDefaultPaymentMethods()
+		return db.insertDefaultPaymentMethods(exec, DefaultLedgerID)
 	}
 	return nil
 }
 
-func (db *DB) createDepositPathTable() error {
+func (db *DB) createDepositPathTable(exec sqlExecutor) error {
 	// 테이블이 이미 존재하는지 확인
-	exists, err := db.tableExists("deposit_paths")
+	exists, err := tableExists(exec, "deposit_paths")
 	if err != nil {
 		return fmt.Errorf("테이블 존재 여부 확인 오류: %v", err)
 	}
@@ -221,28 +263,32 @@ func (db *DB) createDepositPathTable() error {
 	createDepositPathTable := `
     CREATE TABLE IF NOT EXISTS deposit_paths (
         id INTEGER PRIMARY KEY AUTOINCREMENT,
-        name VARCHAR(255) NOT NULL UNIQUE,
+        ledger_id INTEGER NOT NULL DEFAULT 1,
+        name VARCHAR(255) NOT NULL,
         is_active BOOLEAN DEFAULT TRUE,
         created_at TEXT DEFAULT CURRENT_TIMESTAMP,
-        updated_at TEXT DEFAULT CURRENT_TIMESTAMP
+        updated_at TEXT DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (ledger_id) REFERENCES ledgers(id) ON DELETE CASCADE,
+        UNIQUE(ledger_id, name)
     );`
 
-	_, err = db.Conn.Exec(createDepositPathTable)
+	_, err = exec.Exec(createDepositPathTable)
 	if err != nil {
 		return fmt.Errorf("입금경로 테이블 생성 오류: %v", err)
 	}
 
 	// 테이블이 새로 생성된 경우에만 기본 데이터 삽입
 	if !exists {
-		db.insertDefaultDepositPaths()
+		return db.insertDefaultDepositPaths(exec, DefaultLedgerID)
 	}
 	return nil
 }
 
-func (db *DB) createOutAccountTable() error {
+func (db *DB) createOutAccountTable(exec sqlExecutor) error {
 	createOutAccountTable := `
     CREATE TABLE IF NOT EXISTS out_account_data (
         uuid TEXT PRIMARY KEY,
+        ledger_id INTEGER NOT NULL DEFAULT 1,
         date TEXT NOT NULL,
         money INT NOT NULL,
         user VARCHAR(255) NOT NULL,
@@ -257,17 +303,18 @@ func (db *DB) createOutAccountTable() error {
         FOREIGN KEY (payment_method_id) REFERENCES payment_methods(id)
     );`
 
-	_, err := db.Conn.Exec(createOutAccountTable)
+	_, err := exec.Exec(createOutAccountTable)
 	if err != nil {
 		return fmt.Errorf("지출 테이블 생성 오류: %v", err)
 	}
 	return nil
 }
 
-func (db *DB) createInAccountTable() error {
+func (db *DB) createInAccountTable(exec sqlExecutor) error {
 	createInAccountTable := `
     CREATE TABLE IF NOT EXISTS in_account_data (
         uuid TEXT PRIMARY KEY,
+        ledger_id INTEGER NOT NULL DEFAULT 1,
         date TEXT NOT NULL,
         money INT NOT NULL,
         user VARCHAR(255) NOT NULL,
@@ -282,7 +329,7 @@ func (db *DB) createInAccountTable() error {
         FOREIGN KEY (deposit_path_id) REFERENCES deposit_paths(id)
     );`
 
-	_, err := db.Conn.Exec(createInAccountTable)
+	_, err := exec.Exec(createInAccountTable)
 	if err != nil {
 		return fmt.Errorf("수입 테이블 생성 오류: %v", err)
 	}
@@ -290,10 +337,12 @@ func (db *DB) createInAccountTable() error {
 }
 
 // 기본 데이터 삽입 메서드들
-func (db *DB) insertDefaultCategories() error {
+
+// insertDefaultCategories 가계부에 기본 카테고리 삽입 (가계부별로 한번만 실행)
+func (db *DB) insertDefaultCategories(exec sqlExecutor, ledgerID int) error {
 	// 기존 카테고리 데이터 존재 여부 확인 (한번만 실행되도록)
 	var count int
-	err := db.Conn.QueryRow("SELECT COUNT(*) FROM categories").Scan(&count)
+	err := exec.QueryRow("SELECT COUNT(*) FROM categories WHERE ledger_id = ?", ledgerID).Scan(&count)
 	if err != nil {
 		return fmt.Errorf("카테고리 개수 확인 오류: %v", err)
 	}
@@ -318,9 +367,9 @@ func (db *DB) insertDefaultCategories() error {
 
 	// 지출 카테고리 삽입
 	for _, category := range outCategories {
-		_, err := db.Conn.Exec(`
-			INSERT OR IGNORE INTO categories (name, type, is_active) 
-			VALUES (?, 'out', 1)`, category)
+		_, err := exec.Exec(`
+			INSERT OR IGNORE INTO categories (ledger_id, name, type, is_active) 
+			VALUES (?, ?, 'out', 1)`, ledgerID, category)
 		if err != nil {
 			return fmt.Errorf("기본 지출 카테고리 삽입 오류: %v", err)
 		}
@@ -328,9 +377,9 @@ func (db *DB) insertDefaultCategories() error {
 
 	// 수입 카테고리 삽입
 	for _, category := range inCategories {
-		_, err := db.Conn.Exec(`
-			INSERT OR IGNORE INTO categories (name, type, is_active) 
-			VALUES (?, 'in', 1)`, category)
+		_, err := exec.Exec(`
+			INSERT OR IGNORE INTO categories (ledger_id, name, type, is_active) 
+			VALUES (?, ?, 'in', 1)`, ledgerID, category)
 		if err != nil {
 			return fmt.Errorf("기본 수입 카테고리 삽입 오류: %v", err)
 		}
@@ -340,10 +389,10 @@ func (db *DB) insertDefaultCategories() error {
 }
 
 // 기본 사용자 데이터 삽입
-func (db *DB) insertDefaultUsers() error {
+func (db *DB) insertDefaultUsers(exec sqlExecutor) error {
 	// 기존 사용자 데이터 존재 여부 확인 (한번만 실행되도록)
 	var count int
-	err := db.Conn.QueryRow("SELECT COUNT(*) FROM users").Scan(&count)
+	err := exec.QueryRow("SELECT COUNT(*) FROM users").Scan(&count)
 	if err != nil {
 		return fmt.Errorf("사용자 개수 확인 오류: %v", err)
 	}
@@ -356,7 +405,7 @@ func (db *DB) insertDefaultUsers() error {
 	defaultUsers := []string{"관리자", "손님"}
 
 	for _, userName := range defaultUsers {
-		_, err := db.Conn.Exec(`
+		_, err := exec.Exec(`
 			INSERT OR IGNORE INTO users (name) 
 			VALUES (?)`, userName)
 		if err != nil {
@@ -367,10 +416,11 @@ func (db *DB) insertDefaultUsers() error {
 	return nil
 }
 
-func (db *DB) insertDefaultPaymentMethods() error {
+// insertDefaultPaymentMethods 가계부에 기본 결제수단 삽입 (가계부별로 한번만 실행)
+func (db *DB) insertDefaultPaymentMethods(exec sqlExecutor, ledgerID int) error {
 	// 기존 데이터 존재 여부 확인 (한번만 실행되도록)
 	var count int
-	err := db.Conn.QueryRow("SELECT COUNT(*) FROM payment_methods WHERE parent_id IS NULL").Scan(&count)
+	err := exec.QueryRow("SELECT COUNT(*) FROM payment_methods WHERE ledger_id = ? AND parent_id IS NULL", ledgerID).Scan(&count)
 	if err != nil {
 		return fmt.Errorf("결제수단 개수 확인 오류: %v", err)
 	}
@@ -384,9 +434,9 @@ func (db *DB) insertDefaultPaymentMethods() error {
 	defaultCategories := []string{"카드", "계좌이체", "현금", "기타"}
 
 	for _, category := range defaultCategories {
-		_, err := db.Conn.Exec(`
-			INSERT OR IGNORE INTO payment_methods (name, parent_id, is_active) 
-			VALUES (?, NULL, 1)`, category)
+		_, err := exec.Exec(`
+			INSERT OR IGNORE INTO payment_methods (ledger_id, name, parent_id, is_active) 
+			VALUES (?, ?, NULL, 1)`, ledgerID, category)
 		if err != nil {
 			return fmt.Errorf("기본 결제수단 카테고리 삽입 오류: %v", err)
 		}
@@ -394,13 +444,13 @@ func (db *DB) insertDefaultPaymentMethods() error {
 
 	// 2단계: 기본 세부 결제수단 삽입
 	// 카드 하위
-	cardParentID, err := db.getPaymentMethodIDByName("카드")
+	cardParentID, err := getPaymentMethodIDByName(exec, ledgerID, "카드")
 	if err == nil {
 		cardMethods := []string{"신용카드", "체크카드"}
 		for _, method := range cardMethods {
-			_, err := db.Conn.Exec(`
-				INSERT OR IGNORE INTO payment_methods (name, parent_id, is_active) 
-				VALUES (?, ?, 1)`, method, cardParentID)
+			_, err := exec.Exec(`
+				INSERT OR IGNORE INTO payment_methods (ledger_id, name, parent_id, is_active) 
+				VALUES (?, ?, ?, 1)`, ledgerID, method, cardParentID)
 			if err != nil {
 				return fmt.Errorf("카드 세부 결제수단 삽입 오류: %v", err)
 			}
@@ -408,13 +458,13 @@ func (db *DB) insertDefaultPaymentMethods() error {
 	}
 
 	// 계좌이체 하위
-	transferParentID, err := db.getPaymentMethodIDByName("계좌이체")
+	transferParentID, err := getPaymentMethodIDByName(exec, ledgerID, "계좌이체")
 	if err == nil {
 		transferMethods := []string{"온라인뱅킹", "ATM"}
 		for _, method := range transferMethods {
-			_, err := db.Conn.Exec(`
-				INSERT OR IGNORE INTO payment_methods (name, parent_id, is_active) 
-				VALUES (?, ?, 1)`, method, transferParentID)
+			_, err := exec.Exec(`
+				INSERT OR IGNORE INTO payment_methods (ledger_id, name, parent_id, is_active) 
+				VALUES (?, ?, ?, 1)`, ledgerID, method, transferParentID)
 			if err != nil {
 				return fmt.Errorf("계좌이체 세부 결제수단 삽입 오류: %v", err)
 			}
@@ -424,17 +474,18 @@ func (db *DB) insertDefaultPaymentMethods() error {
 	return nil
 }
 
-// 결제수단 이름으로 ID 조회 헬퍼 함수
-func (db *DB) getPaymentMethodIDByName(name string) (int, error) {
+// 결제수단 이름으로 ID 조회 헬퍼 함수 (가계부 범위)
+func getPaymentMethodIDByName(exec sqlExecutor, ledgerID int, name string) (int, error) {
 	var id int
-	err := db.Conn.QueryRow("SELECT id FROM payment_methods WHERE name = ? AND parent_id IS NULL", name).Scan(&id)
+	err := exec.QueryRow("SELECT id FROM payment_methods WHERE ledger_id = ? AND name = ? AND parent_id IS NULL", ledgerID, name).Scan(&id)
 	return id, err
 }
 
-func (db *DB) insertDefaultDepositPaths() error {
+// insertDefaultDepositPaths 가계부에 기본 입금경로 삽입 (가계부별로 한번만 실행)
+func (db *DB) insertDefaultDepositPaths(exec sqlExecutor, ledgerID int) error {
 	// 기존 입금경로 데이터 존재 여부 확인 (한번만 실행되도록)
 	var count int
-	err := db.Conn.QueryRow("SELECT COUNT(*) FROM deposit_paths").Scan(&count)
+	err := exec.QueryRow("SELECT COUNT(*) FROM deposit_paths WHERE ledger_id = ?", ledgerID).Scan(&count)
 	if err != nil {
 		return fmt.Errorf("입금경로 개수 확인 오류: %v", err)
 	}
@@ -453,9 +504,9 @@ func (db *DB) insertDefaultDepositPaths() error {
 	}
 
 	for _, path := range defaultPaths {
-		_, err := db.Conn.Exec(`
-			INSERT OR IGNORE INTO deposit_paths (name, is_active) 
-			VALUES (?, 1)`, path)
+		_, err := exec.Exec(`
+			INSERT OR IGNORE INTO deposit_paths (ledger_id, name, is_active) 
+			VALUES (?, ?, 1)`, ledgerID, path)
 		if err != nil {
 			return fmt.Errorf("기본 입금경로 삽입 오류: %v", err)
 		}
@@ -464,96 +515,147 @@ func (db *DB) insertDefaultDepositPaths() error {
 	return nil
 }
 
-// addCategoryIsActiveColumn 카테고리 테이블에 is_active 컬럼 추가 (마이그레이션)
-func (db *DB) addCategoryIsActiveColumn() {
-	// 컬럼이 이미 존재하는지 확인
-	checkQuery := `PRAGMA table_info(categories)`
-	rows, err := db.Conn.Query(checkQuery)
+// 카테고리 기준치 테이블 생성
+func (db *DB) createCategoryBudgetTable(exec sqlExecutor) error {
+	createCategoryBudgetTable := `
+    CREATE TABLE IF NOT EXISTS category_budgets (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        ledger_id INTEGER NOT NULL DEFAULT 1,
+        category_id INTEGER NOT NULL,
+        user_name VARCHAR(255) DEFAULT '', 
+        monthly_budget INTEGER DEFAULT 0,
+        yearly_budget INTEGER DEFAULT 0,
+        created_at TEXT DEFAULT CURRENT_TIMESTAMP,
+        updated_at TEXT DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (category_id) REFERENCES categories(id),
+        UNIQUE(category_id, user_name)
+    );`
+
+	_, err := exec.Exec(createCategoryBudgetTable)
 	if err != nil {
-		return
+		return fmt.Errorf("카테고리 기준치 테이블 생성 오류: %v", err)
 	}
-	defer rows.Close()
-
-	hasIsActive := false
-	for rows.Next() {
-		var cid int
-		var name, dataType string
-		var notNull int
-		var defaultValue interface{}
-		var pk int
+	return nil
+}
 
-		err := rows.Scan(&cid, &name, &dataType, &notNull, &defaultValue, &pk)
-		if err != nil {
-			continue
-		}
+// 정기 거래 규칙 및 생성 이력 테이블 생성
+func (db *DB) createRecurringTables(exec sqlExecutor) error {
+	createRecurringRuleTable := `
+    CREATE TABLE IF NOT EXISTS recurring_rules (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        ledger_id INTEGER NOT NULL DEFAULT 1,
+        type VARCHAR(10) NOT NULL CHECK (type IN ('out', 'in')),
+        frequency VARCHAR(10) NOT NULL CHECK (frequency IN ('monthly', 'weekly', 'yearly')),
+        day_of_month INTEGER DEFAULT 1,
+        day_of_week INTEGER DEFAULT 1,
+        month_of_year INTEGER DEFAULT 1,
+        money INTEGER NOT NULL,
+        user VARCHAR(255) NOT NULL,
+        category_id INTEGER NOT NULL,
+        keyword_id INTEGER NULL,
+        payment_method_id INTEGER NULL,
+        deposit_path_id INTEGER NULL,
+        memo TEXT DEFAULT '',
+        start_date TEXT NOT NULL,
+        end_date TEXT NULL,
+        last_generated_date TEXT NULL,
+        is_active BOOLEAN DEFAULT 1,
+        created_at TEXT DEFAULT CURRENT_TIMESTAMP,
+        updated_at TEXT DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (category_id) REFERENCES categories(id),
+        FOREIGN KEY (keyword_id) REFERENCES keywords(id),
+        FOREIGN KEY (payment_method_id) REFERENCES payment_methods(id),
+        FOREIGN KEY (deposit_path_id) REFERENCES deposit_paths(id)
+    );`
 
-		if name == "is_active" {
-			hasIsActive = true
-			break
-		}
+	_, err := exec.Exec(createRecurringRuleTable)
+	if err != nil {
+		return fmt.Errorf("정기 거래 규칙 테이블 생성 오류: %v", err)
 	}
 
-	// 컬럼이 없으면 추가
-	if !hasIsActive {
-		alterQuery := `ALTER TABLE categories ADD COLUMN is_active BOOLEAN DEFAULT 1`
-		db.Conn.Exec(alterQuery)
-	}
-}
+	// 주기별로 한 번만 생성되도록 (rule_id, period_key) 고유 제약
+	createRecurringOccurrenceTable := `
+    CREATE TABLE IF NOT EXISTS recurring_occurrences (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        rule_id INTEGER NOT NULL,
+        period_key VARCHAR(20) NOT NULL,
+        occurrence_date TEXT NOT NULL,
+        account_uuid TEXT NULL,
+        status VARCHAR(10) NOT NULL CHECK (status IN ('created', 'matched')),
+        created_at TEXT DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (rule_id) REFERENCES recurring_rules(id) ON DELETE CASCADE,
+        UNIQUE(rule_id, period_key)
+    );`
 
-// addKeywordIsActiveColumn 키워드 테이블에 is_active 컬럼 추가 (마이그레이션)
-func (db *DB) addKeywordIsActiveColumn() {
-	// 컬럼이 이미 존재하는지 확인
-	checkQuery := `PRAGMA table_info(keywords)`
-	rows, err := db.Conn.Query(checkQuery)
+	_, err = exec.Exec(createRecurringOccurrenceTable)
 	if err != nil {
-		return
+		return fmt.Errorf("정기 거래 생성 이력 테이블 생성 오류: %v", err)
 	}
-	defer rows.Close()
-
-	hasIsActive := false
-	for rows.Next() {
-		var cid int
-		var name, dataType string
-		var notNull int
-		var defaultValue interface{}
-		var pk int
+	return nil
+}
 
-		err := rows.Scan(&cid, &name, &dataType, &notNull, &defaultValue, &pk)
-		if err != nil {
-			continue
-		}
+// 인증 관련 컬럼 및 세션 테이블 생성
+func (db *DB) createAuthTables(exec sqlExecutor) error {
+	if _, err := addColumnIfNotExists(exec, "users", "password_hash", "TEXT NULL"); err != nil {
+		return err
+	}
 
-		if name == "is_active" {
-			hasIsActive = true
-			break
+	added, err := addColumnIfNotExists(exec, "users", "is_admin", "BOOLEAN DEFAULT 0")
+	if err != nil {
+		return err
+	}
+	// 컬럼이 처음 추가될 때 기본 관리자 계정에 관리자 권한 부여
+	if added {
+		if _, err := exec.Exec(`UPDATE users SET is_admin = 1 WHERE name = '관리자'`); err != nil {
+			return fmt.Errorf("기본 관리자 권한 설정 오류: %v", err)
 		}
 	}
 
-	// 컬럼이 없으면 추가
-	if !hasIsActive {
-		alterQuery := `ALTER TABLE keywords ADD COLUMN is_active BOOLEAN DEFAULT 1`
-		db.Conn.Exec(alterQuery)
+	// 원본 토큰은 저장하지 않고 SHA-256 해시만 저장
+	createSessionTable := `
+    CREATE TABLE IF NOT EXISTS sessions (
+        token_hash TEXT PRIMARY KEY,
+        user_id INTEGER NOT NULL,
+        expires_at TEXT NOT NULL,
+        created_at TEXT DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+    );`
+
+	if _, err := exec.Exec(createSessionTable); err != nil {
+		return fmt.Errorf("세션 테이블 생성 오류: %v", err)
 	}
+	return nil
 }
 
-// 카테고리 기준치 테이블 생성
-func (db *DB) createCategoryBudgetTable() error {
-	createCategoryBudgetTable := `
-    CREATE TABLE IF NOT EXISTS category_budgets (
+// 명세서 가져오기 컬럼 매핑 프로필 테이블 생성
+func (db *DB) createImportProfileTable(exec sqlExecutor) error {
+	createImportProfileTable := `
+    CREATE TABLE IF NOT EXISTS import_profiles (
         id INTEGER PRIMARY KEY AUTOINCREMENT,
-        category_id INTEGER NOT NULL,
-        user_name VARCHAR(255) DEFAULT '', 
-        monthly_budget INTEGER DEFAULT 0,
-        yearly_budget INTEGER DEFAULT 0,
+        ledger_id INTEGER NOT NULL,
+        name VARCHAR(100) NOT NULL,
+        type VARCHAR(10) NOT NULL CHECK (type IN ('out', 'in')),
+        has_header BOOLEAN DEFAULT 1,
+        skip_rows INTEGER DEFAULT 0,
+        delimiter VARCHAR(4) DEFAULT ',',
+        date_column VARCHAR(100) NOT NULL,
+        date_format VARCHAR(50) DEFAULT '',
+        amount_column VARCHAR(100) NOT NULL,
+        merchant_column VARCHAR(100) NOT NULL,
+        memo_column VARCHAR(100) DEFAULT '',
+        negate_amount BOOLEAN DEFAULT 0,
+        payment_method_id INTEGER NULL,
+        deposit_path_id INTEGER NULL,
         created_at TEXT DEFAULT CURRENT_TIMESTAMP,
         updated_at TEXT DEFAULT CURRENT_TIMESTAMP,
-        FOREIGN KEY (category_id) REFERENCES categories(id),
-        UNIQUE(category_id, user_name)
+        FOREIGN KEY (ledger_id) REFERENCES ledgers(id) ON DELETE CASCADE,
+        FOREIGN KEY (payment_method_id) REFERENCES payment_methods(id),
+        FOREIGN KEY (deposit_path_id) REFERENCES deposit_paths(id),
+        UNIQUE(ledger_id, name)
     );`
 
-	_, err := db.Conn.Exec(createCategoryBudgetTable)
-	if err != nil {
-		return fmt.Errorf("카테고리 기준치 테이블 생성 오류: %v", err)
+	if _, err := exec.Exec(createImportProfileTable); err != nil {
+		return fmt.Errorf("가져오기 프로필 테이블 생성 오류: %v", err)
 	}
 	return nil
 }
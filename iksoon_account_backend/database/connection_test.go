@@ -0,0 +1,65 @@
+package database
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"iksoon_account_backend/utils"
+)
+
+// newTestDB 임시 디렉토리에 마이그레이션을 모두 적용한 테스트용 DB 생성 (테스트 종료 시 닫힘)
+func newTestDB(t *testing.T) *DB {
+	t.Helper()
+
+	db, err := InitDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("테스트 DB 생성 실패: %v", err)
+	}
+	t.Cleanup(func() { db.Conn.Close() })
+	return db
+}
+
+// createTestCategory 기본 가계부에 테스트용 카테고리 생성
+func createTestCategory(t *testing.T, db *DB, name, categoryType string) int {
+	t.Helper()
+
+	id, err := db.CreateCategory(DefaultLedgerID, name, categoryType, "")
+	if err != nil {
+		t.Fatalf("카테고리 생성 실패: %v", err)
+	}
+	return int(id)
+}
+
+// createTestPaymentMethod 기본 가계부에 테스트용 결제수단 생성
+func createTestPaymentMethod(t *testing.T, db *DB, name string) int {
+	t.Helper()
+
+	id, err := db.CreatePaymentMethod(DefaultLedgerID, name, nil)
+	if err != nil {
+		t.Fatalf("결제수단 생성 실패: %v", err)
+	}
+	return int(id)
+}
+
+// insertTestOutAccount 기본 가계부에 원화 지출 등록
+func insertTestOutAccount(t *testing.T, db *DB, date string, money, categoryID, paymentMethodID int) string {
+	t.Helper()
+
+	uuid, err := db.InsertOutAccount(DefaultLedgerID, date, "테스트", money, categoryID, nil, paymentMethodID, "", "", 0)
+	if err != nil {
+		t.Fatalf("지출 등록 실패: %v", err)
+	}
+	return uuid
+}
+
+// mustParseKST 테스트용 KST 시각 파싱
+func mustParseKST(t *testing.T, value string) time.Time {
+	t.Helper()
+
+	parsed, err := utils.ParseDateTimeKST(value)
+	if err != nil {
+		t.Fatalf("시각 파싱 실패: %v", err)
+	}
+	return parsed
+}
@@ -0,0 +1,634 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// currencyCodePattern 통화 코드 형식 (ISO 4217 영문 3자리)
+var currencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// rateCurrencyPattern 환율 파일의 통화 셀 형식 (예: USD, JPY(100), JPY 100 - 괄호 안 숫자는 고시 단위)
+var rateCurrencyPattern = regexp.MustCompile(`^([A-Za-z]{3})\s*\(?\s*(\d*)\s*\)?$`)
+
+// exchangeRateDateLayouts 환율 파일에서 허용하는 날짜 형식
+var exchangeRateDateLayouts = []string{"2006-01-02", "2006.01.02", "2006/01/02", "20060102", "2006.1.2", "2006-1-2", "2006/1/2"}
+
+// createCurrencyTables 환율 테이블과 수입/지출 외화 정보 테이블 생성
+// 거래의 money 는 항상 원화 환산 금액이며, 외화 거래는 원래 통화/금액과 적용 환율을 별도로 보관
+func createCurrencyTables(exec sqlExecutor) error {
+	steps := []string{
+		`CREATE TABLE IF NOT EXISTS exchange_rates (
+            id INTEGER PRIMARY KEY AUTOINCREMENT,
+            ledger_id INTEGER NOT NULL,
+            currency VARCHAR(3) NOT NULL,
+            date TEXT NOT NULL,
+            rate REAL NOT NULL CHECK (rate > 0),
+            created_at TEXT DEFAULT CURRENT_TIMESTAMP,
+            updated_at TEXT DEFAULT CURRENT_TIMESTAMP,
+            FOREIGN KEY (ledger_id) REFERENCES ledgers(id) ON DELETE CASCADE,
+            UNIQUE(ledger_id, currency, date)
+        )`,
+		`CREATE TABLE IF NOT EXISTS transaction_currencies (
+            account_type VARCHAR(3) NOT NULL CHECK (account_type IN ('out', 'in')),
+            account_uuid TEXT NOT NULL,
+            ledger_id INTEGER NOT NULL,
+            currency VARCHAR(3) NOT NULL,
+            original_money REAL NOT NULL CHECK (original_money > 0),
+            rate REAL NOT NULL,
+            PRIMARY KEY (account_type, account_uuid),
+            FOREIGN KEY (ledger_id) REFERENCES ledgers(id) ON DELETE CASCADE
+        )`,
+		`CREATE INDEX IF NOT EXISTS idx_transaction_currencies_currency ON transaction_currencies(ledger_id, currency)`,
+	}
+	for _, step := range steps {
+		if _, err := exec.Exec(step); err != nil {
+			return fmt.Errorf("환율 테이블 생성 오류: %v", err)
+		}
+	}
+	return nil
+}
+
+// GetExchangeRates 환율 목록 조회 (currency 지정 시 해당 통화만, 통화별 최신 날짜순)
+func (db *DB) GetExchangeRates(ledgerID int, currency string) ([]models.ExchangeRate, error) {
+	query := `SELECT id, currency, date, rate, created_at, updated_at FROM exchange_rates WHERE ledger_id = ?`
+	args := []interface{}{ledgerID}
+	if currency != "" {
+		query += ` AND currency = ?`
+		args = append(args, strings.ToUpper(strings.TrimSpace(currency)))
+	}
+	query += ` ORDER BY currency, date DESC`
+
+	rows, err := db.Conn.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("환율 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	rates := []models.ExchangeRate{}
+	for rows.Next() {
+		var rate models.ExchangeRate
+		if err := rows.Scan(&rate.ID, &rate.Currency, &rate.Date, &rate.Rate, &rate.CreatedAt, &rate.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("환율 데이터 읽기 오류: %v", err)
+		}
+		rates = append(rates, rate)
+	}
+	return rates, rows.Err()
+}
+
+// GetExchangeRateByID ID로 환율 조회
+func (db *DB) GetExchangeRateByID(ledgerID, id int) (*models.ExchangeRate, error) {
+	var rate models.ExchangeRate
+	err := db.Conn.QueryRow(`SELECT id, currency, date, rate, created_at, updated_at FROM exchange_rates WHERE id = ? AND ledger_id = ?`, id, ledgerID).
+		Scan(&rate.ID, &rate.Currency, &rate.Date, &rate.Rate, &rate.CreatedAt, &rate.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, apiErrors.ErrExchangeRateNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("환율 조회 오류: %v", err)
+	}
+	return &rate, nil
+}
+
+// SaveExchangeRate 환율 등록 (같은 통화/날짜가 있으면 덮어씀) 후 해당 통화 거래의 원화 금액 재계산
+// 저장된 환율 ID와 금액이 바뀐 거래 수 반환
+func (db *DB) SaveExchangeRate(ledgerID int, req models.ExchangeRateRequest) (int, int, error) {
+	currency, err := normalizeForeignCurrency(req.Currency)
+	if err != nil {
+		return 0, 0, err
+	}
+	if _, err := time.Parse("2006-01-02", req.Date); err != nil {
+		return 0, 0, apiErrors.ErrInvalidExchangeRate.WithMessage("date는 YYYY-MM-DD 형식이어야 합니다")
+	}
+	if req.Rate <= 0 || math.IsInf(req.Rate, 0) || math.IsNaN(req.Rate) {
+		return 0, 0, apiErrors.ErrInvalidExchangeRate.WithMessage("rate는 0보다 커야 합니다")
+	}
+
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return 0, 0, fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	id, err := upsertExchangeRate(tx, ledgerID, currency, req.Date, req.Rate)
+	if err != nil {
+		return 0, 0, err
+	}
+	reconverted, err := reconvertTransactions(tx, ledgerID, currency, "", "")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, fmt.Errorf("환율 저장 커밋 오류: %v", err)
+	}
+	return id, reconverted, nil
+}
+
+// DeleteExchangeRate 환율 삭제 후 해당 통화 거래의 원화 금액 재계산 (적용할 환율이 없어진 거래는 기존 금액 유지)
+func (db *DB) DeleteExchangeRate(ledgerID, id int) (int, error) {
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	var currency string
+	err = tx.QueryRow(`SELECT currency FROM exchange_rates WHERE id = ? AND ledger_id = ?`, id, ledgerID).Scan(&currency)
+	if err == sql.ErrNoRows {
+		return 0, apiErrors.ErrExchangeRateNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("환율 조회 오류: %v", err)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM exchange_rates WHERE id = ? AND ledger_id = ?`, id, ledgerID); err != nil {
+		return 0, fmt.Errorf("환율 삭제 오류: %v", err)
+	}
+	reconverted, err := reconvertTransactions(tx, ledgerID, currency, "", "")
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("환율 삭제 커밋 오류: %v", err)
+	}
+	return reconverted, nil
+}
+
+// ImportExchangeRates 환율 파일 행 가져오기 (날짜, 통화, 환율[, 단위] 순서, 첫 행이 머리글이면 건너뜀)
+// 한 행이라도 잘못되면 전체를 취소하며, 저장 후 가져온 통화의 거래 원화 금액을 재계산
+func (db *DB) ImportExchangeRates(ledgerID int, rows [][]string) (*models.ExchangeRateImportResult, error) {
+	type parsedRate struct {
+		currency string
+		date     string
+		rate     float64
+	}
+
+	var rates []parsedRate
+	headerChecked := false
+	for i, row := range rows {
+		if isBlankImportRow(row) {
+			continue
+		}
+		isFirstRow := !headerChecked
+		headerChecked = true
+		if len(row) < 3 && !isFirstRow {
+			return nil, apiErrors.ErrInvalidExchangeRate.WithMessage(fmt.Sprintf("%d행: 날짜, 통화, 환율 컬럼이 필요합니다", i+1))
+		}
+
+		date, err := parseExchangeRateDate(row[0])
+		if err != nil || len(row) < 3 {
+			if isFirstRow {
+				continue // 머리글 행
+			}
+			return nil, apiErrors.ErrInvalidExchangeRate.WithMessage(fmt.Sprintf("%d행: 날짜 형식이 올바르지 않습니다 (%s)", i+1, row[0]))
+		}
+
+		match := rateCurrencyPattern.FindStringSubmatch(strings.TrimSpace(row[1]))
+		if match == nil {
+			return nil, apiErrors.ErrInvalidExchangeRate.WithMessage(fmt.Sprintf("%d행: 통화 코드가 올바르지 않습니다 (%s)", i+1, row[1]))
+		}
+		currency, err := normalizeForeignCurrency(match[1])
+		if err != nil {
+			return nil, apiErrors.ErrInvalidExchangeRate.WithMessage(fmt.Sprintf("%d행: %s", i+1, err.Error()))
+		}
+
+		rate, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(row[2]), ",", ""), 64)
+		if err != nil || rate <= 0 || math.IsInf(rate, 0) {
+			return nil, apiErrors.ErrInvalidExchangeRate.WithMessage(fmt.Sprintf("%d행: 환율이 올바르지 않습니다 (%s)", i+1, row[2]))
+		}
+
+		// 고시 단위(예: 100엔당 환율)는 1단위당 환율로 변환
+		unitText := match[2]
+		if len(row) > 3 && strings.TrimSpace(row[3]) != "" {
+			unitText = strings.TrimSpace(row[3])
+		}
+		if unitText != "" {
+			unit, err := strconv.ParseFloat(strings.ReplaceAll(unitText, ",", ""), 64)
+			if err != nil || unit <= 0 {
+				return nil, apiErrors.ErrInvalidExchangeRate.WithMessage(fmt.Sprintf("%d행: 단위가 올바르지 않습니다 (%s)", i+1, unitText))
+			}
+			rate /= unit
+		}
+
+		rates = append(rates, parsedRate{currency: currency, date: date, rate: rate})
+	}
+	if len(rates) == 0 {
+		return nil, apiErrors.ErrInvalidExchangeRate.WithMessage("가져올 환율이 없습니다")
+	}
+
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	result := &models.ExchangeRateImportResult{Currencies: []string{}}
+	currencies := map[string]bool{}
+	for _, rate := range rates {
+		if _, err := upsertExchangeRate(tx, ledgerID, rate.currency, rate.date, rate.rate); err != nil {
+			return nil, err
+		}
+		result.Imported++
+		if !currencies[rate.currency] {
+			currencies[rate.currency] = true
+			result.Currencies = append(result.Currencies, rate.currency)
+		}
+	}
+	sort.Strings(result.Currencies)
+
+	for _, currency := range result.Currencies {
+		reconverted, err := reconvertTransactions(tx, ledgerID, currency, "", "")
+		if err != nil {
+			return nil, err
+		}
+		result.Reconverted += reconverted
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("환율 가져오기 커밋 오류: %v", err)
+	}
+	return result, nil
+}
+
+// ConvertToBaseCurrency 외화 금액을 거래 날짜의 환율로 원화 환산 (환산 금액과 적용 환율 반환)
+func (db *DB) ConvertToBaseCurrency(ledgerID int, currency, date string, amount float64) (int, float64, error) {
+	currency, err := normalizeForeignCurrency(currency)
+	if err != nil {
+		return 0, 0, err
+	}
+	parsedDate, err := utils.ParseDateTimeKST(date)
+	if err != nil {
+		return 0, 0, apiErrors.ErrInvalidCurrency.WithMessage("날짜 형식이 올바르지 않습니다")
+	}
+	return convertToBase(db.Conn, ledgerID, currency, utils.FormatDateKST(parsedDate), amount)
+}
+
+// SetTransactionCurrency 수입/지출의 외화 금액 지정 (거래 날짜의 환율로 money 를 원화 환산 금액으로 갱신)
+// currency 가 비어 있거나 기준 통화이면 외화 정보만 해제하고 money 는 그대로 유지
+func (db *DB) SetTransactionCurrency(ledgerID int, accountType, uuidStr, currency string, originalMoney float64) error {
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	if err := setTransactionCurrency(tx, ledgerID, accountType, uuidStr, currency, originalMoney); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("외화 정보 저장 커밋 오류: %v", err)
+	}
+	return nil
+}
+
+// setTransactionCurrency 외화 금액 지정 공통 로직 (등록과 같은 트랜잭션에서도 사용)
+func setTransactionCurrency(exec sqlExecutor, ledgerID int, accountType, uuidStr, currency string, originalMoney float64) error {
+	table, ok := transactionTables[accountType]
+	if !ok {
+		return apiErrors.ErrInvalidCurrency.WithMessage("type은 'out' 또는 'in'이어야 합니다")
+	}
+
+	if err := ensureTransactionExists(exec, ledgerID, accountType, uuidStr); err != nil {
+		return err
+	}
+
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" || currency == models.BaseCurrency {
+		return deleteTransactionCurrency(exec, accountType, uuidStr)
+	}
+
+	if accountType == models.AccountTypeOut {
+		if err := ensureNotInstallmentPortion(exec, ledgerID, uuidStr); err != nil {
+			return err
+		}
+		var splitCount int
+		if err := exec.QueryRow(`SELECT COUNT(*) FROM out_account_splits WHERE out_account_uuid = ?`, uuidStr).Scan(&splitCount); err != nil {
+			return fmt.Errorf("분할 지출 확인 오류: %v", err)
+		}
+		if splitCount > 0 {
+			return apiErrors.ErrInvalidCurrency.WithMessage("분할 지출은 외화로 지정할 수 없습니다")
+		}
+	}
+
+	var date string
+	if err := exec.QueryRow(fmt.Sprintf(`SELECT date(date) FROM %s WHERE uuid = ?`, table), uuidStr).Scan(&date); err != nil {
+		return fmt.Errorf("거래 날짜 조회 오류: %v", err)
+	}
+	currency, err := normalizeForeignCurrency(currency)
+	if err != nil {
+		return err
+	}
+	money, rate, err := convertToBase(exec, ledgerID, currency, date, originalMoney)
+	if err != nil {
+		return err
+	}
+
+	if _, err := exec.Exec(fmt.Sprintf(`UPDATE %s SET money = ?, updated_at = CURRENT_TIMESTAMP WHERE uuid = ?`, table), money, uuidStr); err != nil {
+		return fmt.Errorf("원화 환산 금액 저장 오류: %v", err)
+	}
+	_, err = exec.Exec(`
+        INSERT INTO transaction_currencies (account_type, account_uuid, ledger_id, currency, original_money, rate)
+        VALUES (?, ?, ?, ?, ?, ?)
+        ON CONFLICT(account_type, account_uuid) DO UPDATE SET
+            currency = excluded.currency, original_money = excluded.original_money, rate = excluded.rate`,
+		accountType, uuidStr, ledgerID, currency, originalMoney, rate)
+	if err != nil {
+		return fmt.Errorf("외화 정보 저장 오류: %v", err)
+	}
+
+	utils.Debug("외화 거래 지정: %s/%s, %s %.2f → %d원 (환율 %.4f)", accountType, uuidStr, currency, originalMoney, money, rate)
+	return nil
+}
+
+// RefreshTransactionCurrency 외화 거래의 원화 금액을 현재 거래 날짜의 환율로 다시 계산 (수정 후 호출, 외화 거래가 아니면 무시)
+func (db *DB) RefreshTransactionCurrency(ledgerID int, accountType, uuidStr string) error {
+	_, err := reconvertTransactions(db.Conn, ledgerID, "", accountType, uuidStr)
+	return err
+}
+
+// convertToBase 거래 날짜(YYYY-MM-DD) 이전의 가장 최근 환율로 원화 환산
+func convertToBase(exec sqlExecutor, ledgerID int, currency, date string, amount float64) (int, float64, error) {
+	if amount <= 0 || math.IsInf(amount, 0) || math.IsNaN(amount) {
+		return 0, 0, apiErrors.ErrInvalidCurrency.WithMessage("original_money는 0보다 커야 합니다")
+	}
+
+	rate, ok, err := exchangeRateOn(exec, ledgerID, currency, date)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !ok {
+		return 0, 0, apiErrors.ErrExchangeRateNotFound.WithMessage(fmt.Sprintf("%s 이전의 %s 환율이 없습니다. 환율을 먼저 등록해주세요", date, currency))
+	}
+
+	money := int(math.Round(amount * rate))
+	if money <= 0 {
+		return 0, 0, apiErrors.ErrInvalidCurrency.WithMessage("원화 환산 금액이 0원입니다")
+	}
+	return money, rate, nil
+}
+
+// exchangeRateOn 해당 날짜에 적용되는 환율 조회 (그 날짜 이전의 가장 최근 환율)
+func exchangeRateOn(exec sqlExecutor, ledgerID int, currency, date string) (float64, bool, error) {
+	var rate float64
+	err := exec.QueryRow(`
+        SELECT rate FROM exchange_rates
+        WHERE ledger_id = ? AND currency = ? AND date <= ?
+        ORDER BY date DESC LIMIT 1`, ledgerID, currency, date).Scan(&rate)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("환율 조회 오류: %v", err)
+	}
+	return rate, true, nil
+}
+
+// upsertExchangeRate 통화/날짜별 환율 저장 (있으면 덮어쓰기) 후 ID 반환
+func upsertExchangeRate(exec sqlExecutor, ledgerID int, currency, date string, rate float64) (int, error) {
+	_, err := exec.Exec(`
+        INSERT INTO exchange_rates (ledger_id, currency, date, rate) VALUES (?, ?, ?, ?)
+        ON CONFLICT(ledger_id, currency, date) DO UPDATE SET rate = excluded.rate, updated_at = CURRENT_TIMESTAMP`,
+		ledgerID, currency, date, rate)
+	if err != nil {
+		return 0, fmt.Errorf("환율 저장 오류: %v", err)
+	}
+
+	var id int
+	err = exec.QueryRow(`SELECT id FROM exchange_rates WHERE ledger_id = ? AND currency = ? AND date = ?`, ledgerID, currency, date).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("환율 ID 조회 오류: %v", err)
+	}
+	return id, nil
+}
+
+// reconvertTransactions 외화 거래의 원화 금액과 적용 환율을 거래 날짜의 환율로 다시 계산 (금액이 바뀐 거래 수 반환)
+// currency/accountType/uuid 가 비어 있지 않으면 해당 조건의 거래만, 적용할 환율이 없는 거래는 기존 금액 유지
+func reconvertTransactions(exec sqlExecutor, ledgerID int, currency, accountType, uuidStr string) (int, error) {
+	type foreignTransaction struct {
+		accountType string
+		uuid        string
+		currency    string
+		date        string
+		original    float64
+		money       int
+		rate        float64
+	}
+
+	var transactions []foreignTransaction
+	for _, t := range []string{models.AccountTypeOut, models.AccountTypeIn} {
+		if accountType != "" && accountType != t {
+			continue
+		}
+
+		query := fmt.Sprintf(`
+            SELECT tc.account_uuid, tc.currency, date(t.date), tc.original_money, t.money, tc.rate
+            FROM transaction_currencies tc
+            JOIN %s t ON t.uuid = tc.account_uuid
+            WHERE tc.account_type = ? AND tc.ledger_id = ?`, transactionTables[t])
+		args := []interface{}{t, ledgerID}
+		if currency != "" {
+			query += ` AND tc.currency = ?`
+			args = append(args, currency)
+		}
+		if uuidStr != "" {
+			query += ` AND tc.account_uuid = ?`
+			args = append(args, uuidStr)
+		}
+
+		rows, err := exec.Query(query, args...)
+		if err != nil {
+			return 0, fmt.Errorf("외화 거래 조회 오류: %v", err)
+		}
+		for rows.Next() {
+			item := foreignTransaction{accountType: t}
+			if err := rows.Scan(&item.uuid, &item.currency, &item.date, &item.original, &item.money, &item.rate); err != nil {
+				rows.Close()
+				return 0, fmt.Errorf("외화 거래 데이터 읽기 오류: %v", err)
+			}
+			transactions = append(transactions, item)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return 0, fmt.Errorf("외화 거래 조회 오류: %v", err)
+		}
+	}
+
+	changed := 0
+	for _, item := range transactions {
+		rate, ok, err := exchangeRateOn(exec, ledgerID, item.currency, item.date)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			utils.Warning("적용할 환율이 없어 원화 금액 유지: %s/%s, %s %s", item.accountType, item.uuid, item.currency, item.date)
+			continue
+		}
+
+		money := int(math.Round(item.original * rate))
+		if money == item.money && rate == item.rate {
+			continue
+		}
+		if _, err := exec.Exec(fmt.Sprintf(`UPDATE %s SET money = ?, updated_at = CURRENT_TIMESTAMP WHERE uuid = ?`, transactionTables[item.accountType]), money, item.uuid); err != nil {
+			return 0, fmt.Errorf("원화 환산 금액 갱신 오류: %v", err)
+		}
+		if _, err := exec.Exec(`UPDATE transaction_currencies SET rate = ? WHERE account_type = ? AND account_uuid = ?`, rate, item.accountType, item.uuid); err != nil {
+			return 0, fmt.Errorf("적용 환율 갱신 오류: %v", err)
+		}
+		if money != item.money {
+			changed++
+		}
+	}
+
+	if changed > 0 {
+		utils.Info("외화 거래 원화 금액 재계산: %d건", changed)
+	}
+	return changed, nil
+}
+
+// deleteTransactionCurrency 수입/지출의 외화 정보 삭제 (거래 삭제 시에도 사용)
+func deleteTransactionCurrency(exec sqlExecutor, accountType, uuidStr string) error {
+	if _, err := exec.Exec(`DELETE FROM transaction_currencies WHERE account_type = ? AND account_uuid = ?`, accountType, uuidStr); err != nil {
+		return fmt.Errorf("외화 정보 삭제 오류: %v", err)
+	}
+	return nil
+}
+
+// ensureNotForeignCurrency 외화 거래가 아닌지 확인 (외화 지출은 환율에 따라 금액이 바뀌므로 분할 불가)
+func ensureNotForeignCurrency(exec sqlExecutor, accountType, uuidStr string) error {
+	var currency string
+	err := exec.QueryRow(`SELECT currency FROM transaction_currencies WHERE account_type = ? AND account_uuid = ?`, accountType, uuidStr).Scan(&currency)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("외화 정보 확인 오류: %v", err)
+	}
+	return apiErrors.ErrInvalidCurrency.WithMessage(fmt.Sprintf("외화(%s) 거래는 분할할 수 없습니다", currency))
+}
+
+// attachOutAccountCurrencies 지출 목록에 외화 정보 채우기
+func attachOutAccountCurrencies(exec sqlExecutor, accounts []models.OutAccount) error {
+	uuids := make([]string, len(accounts))
+	for i := range accounts {
+		uuids[i] = accounts[i].UUID
+	}
+
+	currencies, err := loadTransactionCurrencies(exec, models.AccountTypeOut, uuids)
+	if err != nil {
+		return err
+	}
+	for i := range accounts {
+		if info, ok := currencies[accounts[i].UUID]; ok {
+			accounts[i].Currency, accounts[i].OriginalMoney, accounts[i].ExchangeRate = info.currency, info.originalMoney, info.rate
+		}
+	}
+	return nil
+}
+
+// attachInAccountCurrencies 수입 목록에 외화 정보 채우기
+func attachInAccountCurrencies(exec sqlExecutor, accounts []models.InAccount) error {
+	uuids := make([]string, len(accounts))
+	for i := range accounts {
+		uuids[i] = accounts[i].UUID
+	}
+
+	currencies, err := loadTransactionCurrencies(exec, models.AccountTypeIn, uuids)
+	if err != nil {
+		return err
+	}
+	for i := range accounts {
+		if info, ok := currencies[accounts[i].UUID]; ok {
+			accounts[i].Currency, accounts[i].OriginalMoney, accounts[i].ExchangeRate = info.currency, info.originalMoney, info.rate
+		}
+	}
+	return nil
+}
+
+// transactionCurrency 거래별 외화 정보
+type transactionCurrency struct {
+	currency      string
+	originalMoney float64
+	rate          float64
+}
+
+// loadTransactionCurrencies 여러 수입/지출의 외화 정보를 UUID별로 조회 (SQLite 변수 개수 제한을 피해 나누어 조회)
+func loadTransactionCurrencies(exec sqlExecutor, accountType string, uuids []string) (map[string]transactionCurrency, error) {
+	const chunkSize = 500
+	result := make(map[string]transactionCurrency)
+
+	for start := 0; start < len(uuids); start += chunkSize {
+		end := start + chunkSize
+		if end > len(uuids) {
+			end = len(uuids)
+		}
+		chunk := uuids[start:end]
+
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(chunk)), ", ")
+		args := []interface{}{accountType}
+		for _, uuidStr := range chunk {
+			args = append(args, uuidStr)
+		}
+
+		rows, err := exec.Query(`
+            SELECT account_uuid, currency, original_money, rate
+            FROM transaction_currencies
+            WHERE account_type = ? AND account_uuid IN (`+placeholders+`)`, args...)
+		if err != nil {
+			return nil, fmt.Errorf("외화 정보 조회 오류: %v", err)
+		}
+		for rows.Next() {
+			var uuidStr string
+			var info transactionCurrency
+			if err := rows.Scan(&uuidStr, &info.currency, &info.originalMoney, &info.rate); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("외화 정보 읽기 오류: %v", err)
+			}
+			result[uuidStr] = info
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("외화 정보 조회 오류: %v", err)
+		}
+	}
+	return result, nil
+}
+
+// normalizeForeignCurrency 외화 통화 코드 정규화 및 검증 (기준 통화는 외화가 아니므로 거부)
+func normalizeForeignCurrency(currency string) (string, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if !currencyCodePattern.MatchString(currency) {
+		return "", apiErrors.ErrInvalidCurrency.WithMessage("통화 코드는 영문 3자리여야 합니다 (예: USD, JPY)")
+	}
+	if currency == models.BaseCurrency {
+		return "", apiErrors.ErrInvalidCurrency.WithMessage(fmt.Sprintf("%s는 기준 통화입니다", models.BaseCurrency))
+	}
+	return currency, nil
+}
+
+// parseExchangeRateDate 환율 파일의 날짜 셀 파싱 (엑셀 날짜 일련번호 포함, YYYY-MM-DD 반환)
+func parseExchangeRateDate(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if parsed, ok := utils.ParseExcelSerialDate(value); ok {
+		return parsed.Format("2006-01-02"), nil
+	}
+	parsed, err := utils.ParseDateInLayoutsKST(value, exchangeRateDateLayouts...)
+	if err != nil {
+		return "", err
+	}
+	return parsed.Format("2006-01-02"), nil
+}
@@ -8,14 +8,14 @@ import (
 )
 
 // GetDepositPaths 입금경로 목록 조회
-func (db *DB) GetDepositPaths() ([]models.DepositPath, error) {
+func (db *DB) GetDepositPaths(ledgerID int) ([]models.DepositPath, error) {
 	query := `
 		SELECT id, name, is_active, created_at, updated_at
 		FROM deposit_paths 
-		WHERE is_active = TRUE
+		WHERE ledger_id = ? AND is_active = TRUE
 		ORDER BY name ASC`
 
-	rows, err := db.Conn.Query(query)
+	rows, err := db.Conn.Query(query, ledgerID)
 	if err != nil {
 		return nil, fmt.Errorf("입금경로 조회 오류: %v", err)
 	}
@@ -46,11 +46,11 @@ func (db *DB) GetDepositPaths() ([]models.DepositPath, error) {
 }
 
 // CreateDepositPath 입금경로 생성
-func (db *DB) CreateDepositPath(name string) (int64, error) {
+func (db *DB) CreateDepositPath(ledgerID int, name string) (int64, error) {
 	// 중복 이름 확인
-	checkQuery := `SELECT COUNT(*) FROM deposit_paths WHERE name = ? AND is_active = 1`
+	checkQuery := `SELECT COUNT(*) FROM deposit_paths WHERE ledger_id = ? AND name = ? AND is_active = 1`
 	var count int
-	err := db.Conn.QueryRow(checkQuery, name).Scan(&count)
+	err := db.Conn.QueryRow(checkQuery, ledgerID, name).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("입금경로 중복 확인 오류: %v", err)
 	}
@@ -60,10 +60,10 @@ func (db *DB) CreateDepositPath(name string) (int64, error) {
 	}
 
 	query := `
-		INSERT INTO deposit_paths (name, is_active, created_at, updated_at) 
-		VALUES (?, TRUE, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+		INSERT INTO deposit_paths (ledger_id, name, is_active, created_at, updated_at) 
+		VALUES (?, ?, TRUE, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
 
-	result, err := db.Conn.Exec(query, name)
+	result, err := db.Conn.Exec(query, ledgerID, name)
 	if err != nil {
 		return 0, fmt.Errorf("입금경로 생성 오류: %v", err)
 	}
@@ -77,11 +77,11 @@ func (db *DB) CreateDepositPath(name string) (int64, error) {
 }
 
 // UpdateDepositPath 입금경로 수정
-func (db *DB) UpdateDepositPath(id int, name string) error {
+func (db *DB) UpdateDepositPath(ledgerID int, id int, name string) error {
 	// 중복 이름 확인 (자기 자신 제외)
-	checkQuery := `SELECT COUNT(*) FROM deposit_paths WHERE name = ? AND id != ? AND is_active = 1`
+	checkQuery := `SELECT COUNT(*) FROM deposit_paths WHERE ledger_id = ? AND name = ? AND id != ? AND is_active = 1`
 	var count int
-	err := db.Conn.QueryRow(checkQuery, name, id).Scan(&count)
+	err := db.Conn.QueryRow(checkQuery, ledgerID, name, id).Scan(&count)
 	if err != nil {
 		return fmt.Errorf("입금경로 중복 확인 오류: %v", err)
 	}
@@ -93,9 +93,9 @@ func (db *DB) UpdateDepositPath(id int, name string) error {
 	query := `
 		UPDATE deposit_paths 
 		SET name = ?, updated_at = CURRENT_TIMESTAMP 
-		WHERE id = ?`
+		WHERE id = ? AND ledger_id = ?`
 
-	result, err := db.Conn.Exec(query, name, id)
+	result, err := db.Conn.Exec(query, name, id, ledgerID)
 	if err != nil {
 		return fmt.Errorf("입금경로 수정 오류: %v", err)
 	}
@@ -113,11 +113,11 @@ func (db *DB) UpdateDepositPath(id int, name string) error {
 }
 
 // CheckDepositPathExists 입금경로 존재 여부 확인
-func (db *DB) CheckDepositPathExists(id int) (bool, error) {
-	query := `SELECT COUNT(*) FROM deposit_paths WHERE id = ? AND is_active = 1`
+func (db *DB) CheckDepositPathExists(ledgerID int, id int) (bool, error) {
+	query := `SELECT COUNT(*) FROM deposit_paths WHERE id = ? AND ledger_id = ? AND is_active = 1`
 
 	var count int
-	err := db.Conn.QueryRow(query, id).Scan(&count)
+	err := db.Conn.QueryRow(query, id, ledgerID).Scan(&count)
 	if err != nil {
 		return false, fmt.Errorf("입금경로 존재 여부 확인 오류: %v", err)
 	}
@@ -126,14 +126,14 @@ func (db *DB) CheckDepositPathExists(id int) (bool, error) {
 }
 
 // CheckDepositPathUsage 입금경로 사용 여부 확인
-func (db *DB) CheckDepositPathUsage(depositPathID int) (bool, error) {
+func (db *DB) CheckDepositPathUsage(ledgerID int, depositPathID int) (bool, error) {
 	query := `
 		SELECT COUNT(*) 
 		FROM in_account_data 
-		WHERE deposit_path_id = ?`
+		WHERE ledger_id = ? AND deposit_path_id = ?`
 
 	var count int
-	err := db.Conn.QueryRow(query, depositPathID).Scan(&count)
+	err := db.Conn.QueryRow(query, ledgerID, depositPathID).Scan(&count)
 	if err != nil {
 		return false, fmt.Errorf("입금경로 사용 여부 확인 오류: %v", err)
 	}
@@ -142,13 +142,13 @@ func (db *DB) CheckDepositPathUsage(depositPathID int) (bool, error) {
 }
 
 // DeleteDepositPath 입금경로 논리 삭제
-func (db *DB) DeleteDepositPath(id int) error {
+func (db *DB) DeleteDepositPath(ledgerID int, id int) error {
 	query := `
 		UPDATE deposit_paths 
 		SET is_active = 0, updated_at = CURRENT_TIMESTAMP 
-		WHERE id = ?`
+		WHERE id = ? AND ledger_id = ?`
 
-	result, err := db.Conn.Exec(query, id)
+	result, err := db.Conn.Exec(query, id, ledgerID)
 	if err != nil {
 		return fmt.Errorf("입금경로 삭제 오류: %v", err)
 	}
@@ -166,13 +166,13 @@ func (db *DB) DeleteDepositPath(id int) error {
 }
 
 // ForceDeleteDepositPath 입금경로 강제 삭제 (사용 중인 경우)
-func (db *DB) ForceDeleteDepositPath(id int) error {
+func (db *DB) ForceDeleteDepositPath(ledgerID int, id int) error {
 	query := `
 		UPDATE deposit_paths 
 		SET is_active = 0, updated_at = CURRENT_TIMESTAMP 
-		WHERE id = ?`
+		WHERE id = ? AND ledger_id = ?`
 
-	result, err := db.Conn.Exec(query, id)
+	result, err := db.Conn.Exec(query, id, ledgerID)
 	if err != nil {
 		return fmt.Errorf("입금경로 강제 삭제 오류: %v", err)
 	}
@@ -190,16 +190,16 @@ func (db *DB) ForceDeleteDepositPath(id int) error {
 }
 
 // GetDepositPathByID ID로 입금경로 조회
-func (db *DB) GetDepositPathByID(id int) (*models.DepositPath, error) {
+func (db *DB) GetDepositPathByID(ledgerID int, id int) (*models.DepositPath, error) {
 	query := `
 		SELECT id, name, is_active, created_at, updated_at
 		FROM deposit_paths 
-		WHERE id = ? AND is_active = 1`
+		WHERE id = ? AND ledger_id = ? AND is_active = 1`
 
 	var path models.DepositPath
 	var createdAt, updatedAt string
 
-	err := db.Conn.QueryRow(query, id).Scan(&path.ID, &path.Name,
+	err := db.Conn.QueryRow(query, id, ledgerID).Scan(&path.ID, &path.Name,
 		&path.IsActive, &createdAt, &updatedAt)
 	if err != nil {
 		return nil, fmt.Errorf("입금경로 조회 오류: %v", err)
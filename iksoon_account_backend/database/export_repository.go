@@ -0,0 +1,138 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// exportOutQuery 지출 내보내기 쿼리 (수입 쿼리와 컬럼 순서 동일)
+const exportOutQuery = `
+    SELECT 'out' as type, oa.uuid as uuid, oa.date as date, oa.user as user, oa.money as money, oa.category_id as category_id,
+           COALESCE(c.name, '') as category_name,
+           COALESCE(k.name, '') as keyword_name,
+           oa.payment_method_id, COALESCE(pm.name, '') as payment_method_name,
+           NULL as deposit_path_id, '' as deposit_path_name,
+           COALESCE(oa.memo, '') as memo, oa.created_at as created_at, oa.updated_at as updated_at,
+           (SELECT group_concat(a.file_name, char(31)) FROM attachments a WHERE a.account_type = 'out' AND a.account_uuid = oa.uuid) as attachment_names,
+           (SELECT group_concat(name, char(31)) FROM (
+                SELECT t.name FROM transaction_tags tt JOIN tags t ON tt.tag_id = t.id
+                WHERE tt.account_type = 'out' AND tt.account_uuid = oa.uuid ORDER BY t.name)) as tag_names,
+           COALESCE(tc.currency, '') as currency, COALESCE(tc.original_money, 0) as original_money, COALESCE(tc.rate, 0) as exchange_rate
+    FROM out_account_data oa
+    LEFT JOIN categories c ON oa.category_id = c.id
+    LEFT JOIN keywords k ON oa.keyword_id = k.id
+    LEFT JOIN payment_methods pm ON oa.payment_method_id = pm.id
+    LEFT JOIN transaction_currencies tc ON tc.account_type = 'out' AND tc.account_uuid = oa.uuid`
+
+// exportInQuery 수입 내보내기 쿼리
+const exportInQuery = `
+    SELECT 'in' as type, ia.uuid as uuid, ia.date as date, ia.user as user, ia.money as money, ia.category_id as category_id,
+           COALESCE(c.name, '') as category_name,
+           COALESCE(k.name, '') as keyword_name,
+           NULL as payment_method_id, '' as payment_method_name,
+           ia.deposit_path_id, COALESCE(dp.name, '') as deposit_path_name,
+           COALESCE(ia.memo, '') as memo, ia.created_at as created_at, ia.updated_at as updated_at,
+           (SELECT group_concat(a.file_name, char(31)) FROM attachments a WHERE a.account_type = 'in' AND a.account_uuid = ia.uuid) as attachment_names,
+           (SELECT group_concat(name, char(31)) FROM (
+                SELECT t.name FROM transaction_tags tt JOIN tags t ON tt.tag_id = t.id
+                WHERE tt.account_type = 'in' AND tt.account_uuid = ia.uuid ORDER BY t.name)) as tag_names,
+           COALESCE(tc.currency, '') as currency, COALESCE(tc.original_money, 0) as original_money, COALESCE(tc.rate, 0) as exchange_rate
+    FROM in_account_data ia
+    LEFT JOIN categories c ON ia.category_id = c.id
+    LEFT JOIN keywords k ON ia.keyword_id = k.id
+    LEFT JOIN deposit_paths dp ON ia.deposit_path_id = dp.id
+    LEFT JOIN transaction_currencies tc ON tc.account_type = 'in' AND tc.account_uuid = ia.uuid`
+
+// StreamAccountExport 조건에 맞는 지출/수입 거래를 날짜순으로 한 건씩 전달
+// 전체 결과를 메모리에 올리지 않도록 행을 읽는 즉시 fn 을 호출하며, fn 이 오류를 반환하면 중단
+func (db *DB) StreamAccountExport(ledgerID int, filter models.ExportFilter, fn func(models.ExportRow) error) error {
+	var parts []string
+	var args []interface{}
+
+	if filter.Type == "out" || filter.Type == "all" {
+		where, whereArgs := exportWhereClause("oa", ledgerID, filter, filter.PaymentMethodID)
+		parts = append(parts, exportOutQuery+where)
+		args = append(args, whereArgs...)
+	}
+	// 결제수단 조건이 있으면 수입은 대상이 아님
+	if (filter.Type == "in" || filter.Type == "all") && filter.PaymentMethodID == nil {
+		where, whereArgs := exportWhereClause("ia", ledgerID, filter, nil)
+		parts = append(parts, exportInQuery+where)
+		args = append(args, whereArgs...)
+	}
+	if len(parts) == 0 {
+		return nil
+	}
+
+	query := strings.Join(parts, "\n    UNION ALL") + "\n    ORDER BY date ASC, created_at ASC"
+
+	rows, err := db.Conn.Query(query, args...)
+	if err != nil {
+		utils.LogError("내보내기 데이터 조회", err)
+		return fmt.Errorf("내보내기 데이터 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		var row models.ExportRow
+		var attachmentNames, tagNames sql.NullString
+		err := rows.Scan(&row.Type, &row.UUID, &row.Date, &row.User, &row.Money, &row.CategoryID,
+			&row.CategoryName, &row.KeywordName, &row.PaymentMethodID, &row.PaymentMethodName,
+			&row.DepositPathID, &row.DepositPathName, &row.Memo, &row.CreatedAt, &row.UpdatedAt, &attachmentNames,
+			&tagNames, &row.Currency, &row.OriginalMoney, &row.ExchangeRate)
+		if err != nil {
+			return fmt.Errorf("내보내기 데이터 읽기 오류: %v", err)
+		}
+		if attachmentNames.Valid {
+			row.Attachments = strings.Split(attachmentNames.String, "\x1f")
+		}
+		if tagNames.Valid {
+			row.Tags = strings.Split(tagNames.String, "\x1f")
+		}
+
+		if err := fn(row); err != nil {
+			return err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("내보내기 데이터 읽기 오류: %v", err)
+	}
+
+	utils.Debug("내보내기 완료: 유형=%s, %d건", filter.Type, count)
+	return nil
+}
+
+// exportWhereClause 내보내기 조건을 WHERE 절과 인자로 변환
+func exportWhereClause(alias string, ledgerID int, filter models.ExportFilter, paymentMethodID *int) (string, []interface{}) {
+	conditions := []string{alias + ".ledger_id = ?"}
+	args := []interface{}{ledgerID}
+
+	if filter.StartDate != "" {
+		conditions = append(conditions, "DATE("+alias+".date) >= ?")
+		args = append(args, filter.StartDate)
+	}
+	if filter.EndDate != "" {
+		conditions = append(conditions, "DATE("+alias+".date) <= ?")
+		args = append(args, filter.EndDate)
+	}
+	if filter.User != "" {
+		conditions = append(conditions, alias+".user = ?")
+		args = append(args, filter.User)
+	}
+	if filter.CategoryID != nil {
+		conditions = append(conditions, alias+".category_id = ?")
+		args = append(args, *filter.CategoryID)
+	}
+	if paymentMethodID != nil {
+		conditions = append(conditions, alias+".payment_method_id = ?")
+		args = append(args, *paymentMethodID)
+	}
+
+	return "\n    WHERE " + strings.Join(conditions, " AND "), args
+}
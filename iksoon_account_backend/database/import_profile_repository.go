@@ -0,0 +1,183 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+)
+
+// importProfileSelectQuery 가져오기 프로필 조회 공통 쿼리
+const importProfileSelectQuery = `
+    SELECT id, ledger_id, name, type, has_header, skip_rows, COALESCE(delimiter, ','),
+           date_column, COALESCE(date_format, ''), amount_column, merchant_column, COALESCE(memo_column, ''),
+           negate_amount, payment_method_id, deposit_path_id, created_at, updated_at
+    FROM import_profiles`
+
+// scanImportProfile 가져오기 프로필 행 스캔
+func scanImportProfile(scanner interface{ Scan(...interface{}) error }) (*models.ImportProfile, error) {
+	var profile models.ImportProfile
+	var createdAt, updatedAt string
+
+	err := scanner.Scan(&profile.ID, &profile.LedgerID, &profile.Name, &profile.Type, &profile.HasHeader, &profile.SkipRows, &profile.Delimiter,
+		&profile.DateColumn, &profile.DateFormat, &profile.AmountColumn, &profile.MerchantColumn, &profile.MemoColumn,
+		&profile.NegateAmount, &profile.PaymentMethodID, &profile.DepositPathID, &createdAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	// 시간 파싱
+	if profile.CreatedAt, err = time.Parse("2006-01-02 15:04:05", createdAt); err != nil {
+		profile.CreatedAt = time.Now()
+	}
+	if profile.UpdatedAt, err = time.Parse("2006-01-02 15:04:05", updatedAt); err != nil {
+		profile.UpdatedAt = time.Now()
+	}
+
+	return &profile, nil
+}
+
+// GetImportProfiles 가계부의 가져오기 프로필 목록 조회
+func (db *DB) GetImportProfiles(ledgerID int) ([]models.ImportProfile, error) {
+	rows, err := db.Conn.Query(importProfileSelectQuery+` WHERE ledger_id = ? ORDER BY name ASC`, ledgerID)
+	if err != nil {
+		return nil, fmt.Errorf("가져오기 프로필 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	profiles := []models.ImportProfile{}
+	for rows.Next() {
+		profile, err := scanImportProfile(rows)
+		if err != nil {
+			return nil, fmt.Errorf("가져오기 프로필 데이터 읽기 오류: %v", err)
+		}
+		profiles = append(profiles, *profile)
+	}
+
+	return profiles, rows.Err()
+}
+
+// GetImportProfileByID ID로 가져오기 프로필 조회
+func (db *DB) GetImportProfileByID(ledgerID int, id int) (*models.ImportProfile, error) {
+	profile, err := scanImportProfile(db.Conn.QueryRow(importProfileSelectQuery+` WHERE id = ? AND ledger_id = ?`, id, ledgerID))
+	if err == sql.ErrNoRows {
+		return nil, apiErrors.ErrImportProfileNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("가져오기 프로필 조회 오류: %v", err)
+	}
+	return profile, nil
+}
+
+// CreateImportProfile 가져오기 프로필 생성
+func (db *DB) CreateImportProfile(ledgerID int, profile models.ImportProfile) (int64, error) {
+	if err := normalizeImportProfile(db.Conn, ledgerID, &profile); err != nil {
+		return 0, err
+	}
+
+	query := `
+		INSERT INTO import_profiles (ledger_id, name, type, has_header, skip_rows, delimiter,
+			date_column, date_format, amount_column, merchant_column, memo_column,
+			negate_amount, payment_method_id, deposit_path_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+
+	result, err := db.Conn.Exec(query, ledgerID, profile.Name, profile.Type, profile.HasHeader, profile.SkipRows, profile.Delimiter,
+		profile.DateColumn, profile.DateFormat, profile.AmountColumn, profile.MerchantColumn, profile.MemoColumn,
+		profile.NegateAmount, profile.PaymentMethodID, profile.DepositPathID)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return 0, apiErrors.ErrAlreadyExists.WithMessage("이미 존재하는 프로필 이름입니다")
+		}
+		return 0, fmt.Errorf("가져오기 프로필 생성 오류: %v", err)
+	}
+
+	return result.LastInsertId()
+}
+
+// UpdateImportProfile 가져오기 프로필 수정
+func (db *DB) UpdateImportProfile(ledgerID int, id int, profile models.ImportProfile) error {
+	if err := normalizeImportProfile(db.Conn, ledgerID, &profile); err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE import_profiles
+		SET name = ?, type = ?, has_header = ?, skip_rows = ?, delimiter = ?,
+			date_column = ?, date_format = ?, amount_column = ?, merchant_column = ?, memo_column = ?,
+			negate_amount = ?, payment_method_id = ?, deposit_path_id = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND ledger_id = ?`
+
+	result, err := db.Conn.Exec(query, profile.Name, profile.Type, profile.HasHeader, profile.SkipRows, profile.Delimiter,
+		profile.DateColumn, profile.DateFormat, profile.AmountColumn, profile.MerchantColumn, profile.MemoColumn,
+		profile.NegateAmount, profile.PaymentMethodID, profile.DepositPathID, id, ledgerID)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return apiErrors.ErrAlreadyExists.WithMessage("이미 존재하는 프로필 이름입니다")
+		}
+		return fmt.Errorf("가져오기 프로필 수정 오류: %v", err)
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return apiErrors.ErrImportProfileNotFound
+	}
+	return nil
+}
+
+// DeleteImportProfile 가져오기 프로필 삭제
+func (db *DB) DeleteImportProfile(ledgerID int, id int) error {
+	result, err := db.Conn.Exec(`DELETE FROM import_profiles WHERE id = ? AND ledger_id = ?`, id, ledgerID)
+	if err != nil {
+		return fmt.Errorf("가져오기 프로필 삭제 오류: %v", err)
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return apiErrors.ErrImportProfileNotFound
+	}
+	return nil
+}
+
+// normalizeImportProfile 가져오기 프로필 기본값 보정 및 유효성 검사
+func normalizeImportProfile(exec sqlExecutor, ledgerID int, profile *models.ImportProfile) error {
+	profile.Name = strings.TrimSpace(profile.Name)
+	profile.DateColumn = strings.TrimSpace(profile.DateColumn)
+	profile.AmountColumn = strings.TrimSpace(profile.AmountColumn)
+	profile.MerchantColumn = strings.TrimSpace(profile.MerchantColumn)
+	profile.MemoColumn = strings.TrimSpace(profile.MemoColumn)
+	if profile.Delimiter == "" {
+		profile.Delimiter = ","
+	}
+
+	if profile.Name == "" {
+		return apiErrors.ErrMissingRequired.WithMessage("프로필 이름은 필수입니다")
+	}
+	if profile.Type != "out" && profile.Type != "in" {
+		return apiErrors.ErrInvalidImportData.WithMessage("type은 'out' 또는 'in'이어야 합니다")
+	}
+	if profile.DateColumn == "" || profile.AmountColumn == "" || profile.MerchantColumn == "" {
+		return apiErrors.ErrMissingRequired.WithMessage("날짜, 금액, 가맹점 컬럼은 필수입니다")
+	}
+	if profile.SkipRows < 0 {
+		return apiErrors.ErrInvalidImportData.WithMessage("skip_rows는 0 이상이어야 합니다")
+	}
+
+	// 지출 프로필은 결제수단, 수입 프로필은 입금경로만 사용
+	if profile.Type == "out" {
+		profile.DepositPathID = nil
+		if profile.PaymentMethodID != nil {
+			if err := ensureLedgerRow(exec, "payment_methods", *profile.PaymentMethodID, ledgerID); err != nil {
+				return err
+			}
+		}
+	} else {
+		profile.PaymentMethodID = nil
+		if profile.DepositPathID != nil {
+			if err := ensureLedgerRow(exec, "deposit_paths", *profile.DepositPathID, ledgerID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
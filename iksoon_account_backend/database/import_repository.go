@@ -0,0 +1,412 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// importDateLayouts 날짜 형식이 지정되지 않았을 때 순서대로 시도하는 명세서 날짜 형식
+var importDateLayouts = []string{
+	"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02",
+	"2006.01.02 15:04:05", "2006.01.02 15:04", "2006.01.02",
+	"2006/01/02 15:04:05", "2006/01/02 15:04", "2006/01/02",
+	"2006. 1. 2.", "2006. 1. 2", "2006.1.2", "2006-1-2", "2006/1/2",
+	"20060102", "06.01.02", "06/01/02", "06-01-02",
+}
+
+// importKeyword 가맹점명 매칭에 사용하는 키워드 정보
+type importKeyword struct {
+	id           int
+	name         string
+	categoryID   int
+	categoryName string
+}
+
+// BuildImportPreview 명세서 행에 프로필을 적용해 미리보기 생성
+// 자동 분류 규칙을 먼저 적용하고, 규칙으로 카테고리가 정해지지 않으면 가맹점명과 일치(또는 포함)하는 기존 키워드로 카테고리/키워드를 매핑
+// 날짜·금액·메모가 같은 기존 거래는 중복으로 표시
+func (db *DB) BuildImportPreview(ledgerID int, profile models.ImportProfile, rows [][]string, user string) (*models.ImportPreview, error) {
+	if profile.SkipRows >= len(rows) {
+		return nil, apiErrors.ErrInvalidImportData.WithMessage("파일에 가져올 행이 없습니다")
+	}
+
+	dataRows := rows[profile.SkipRows:]
+	firstRowNumber := profile.SkipRows + 1
+	var header []string
+	if profile.HasHeader {
+		header = dataRows[0]
+		dataRows = dataRows[1:]
+		firstRowNumber++
+	}
+
+	dateIdx, err := resolveImportColumn(header, profile.DateColumn)
+	if err != nil {
+		return nil, err
+	}
+	amountIdx, err := resolveImportColumn(header, profile.AmountColumn)
+	if err != nil {
+		return nil, err
+	}
+	merchantIdx, err := resolveImportColumn(header, profile.MerchantColumn)
+	if err != nil {
+		return nil, err
+	}
+	memoIdx := -1
+	if profile.MemoColumn != "" {
+		if memoIdx, err = resolveImportColumn(header, profile.MemoColumn); err != nil {
+			return nil, err
+		}
+	}
+
+	keywords, err := db.getImportKeywords(ledgerID, profile.Type)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := loadCategorizationRules(db.Conn, ledgerID, profile.Type, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &models.ImportPreview{Profile: profile, Rows: []models.ImportPreviewRow{}}
+	seen := make(map[string]bool)
+
+	for i, row := range dataRows {
+		if isBlankImportRow(row) {
+			continue
+		}
+
+		previewRow := models.ImportPreviewRow{
+			RowNumber:       firstRowNumber + i,
+			User:            user,
+			Merchant:        strings.TrimSpace(importCell(row, merchantIdx)),
+			PaymentMethodID: profile.PaymentMethodID,
+			DepositPathID:   profile.DepositPathID,
+		}
+		previewRow.Memo = previewRow.Merchant
+		if memoIdx >= 0 {
+			previewRow.Memo = strings.TrimSpace(importCell(row, memoIdx))
+		}
+
+		date, dateErr := parseImportDate(importCell(row, dateIdx), profile.DateFormat)
+		money, moneyErr := parseImportAmount(importCell(row, amountIdx), profile.NegateAmount)
+		switch {
+		case dateErr != nil:
+			previewRow.Error = dateErr.Error()
+		case moneyErr != nil:
+			previewRow.Error = moneyErr.Error()
+		case money <= 0:
+			previewRow.Error = "금액이 0 이하인 행입니다 (취소/환불 건은 직접 입력해주세요)"
+		}
+		previewRow.Date = date
+		previewRow.Money = money
+
+		keyword := matchImportKeyword(keywords, previewRow.Merchant)
+		match := evaluateCategorizationRules(rules, models.RuleSubject{
+			Type: profile.Type, Memo: previewRow.Memo, Merchant: previewRow.Merchant, User: user, Money: money,
+		})
+		if match.Matched {
+			applyImportRuleMatch(&previewRow, match, keyword)
+			preview.RuleMatchedRows++
+		}
+
+		switch {
+		case previewRow.CategoryID != nil:
+			preview.MatchedRows++
+		case keyword != nil:
+			previewRow.CategoryID = &keyword.categoryID
+			previewRow.CategoryName = keyword.categoryName
+			previewRow.KeywordID = &keyword.id
+			previewRow.KeywordName = keyword.name
+			preview.MatchedRows++
+		default:
+			// 매칭되지 않으면 가맹점명을 새 키워드로 제안 (다음 가져오기부터 자동 매핑)
+			previewRow.KeywordName = previewRow.Merchant
+		}
+
+		if previewRow.Error == "" {
+			key := fmt.Sprintf("%s|%d|%s", previewRow.Date, previewRow.Money, previewRow.Memo)
+			duplicateUUID, err := db.findImportDuplicate(ledgerID, profile.Type, previewRow.Date, previewRow.Money, previewRow.Memo)
+			if err != nil {
+				return nil, err
+			}
+			if duplicateUUID != "" || seen[key] {
+				previewRow.IsDuplicate = true
+				previewRow.DuplicateUUID = duplicateUUID
+				preview.DuplicateRows++
+			}
+			seen[key] = true
+		} else {
+			preview.ErrorRows++
+		}
+
+		preview.Rows = append(preview.Rows, previewRow)
+	}
+
+	preview.TotalRows = len(preview.Rows)
+	return preview, nil
+}
+
+// CommitImport 미리보기에서 승인한 행을 하나의 트랜잭션으로 등록 (한 행이라도 실패하면 전체 취소)
+func (db *DB) CommitImport(ledgerID int, req models.ImportCommitRequest) (*models.ImportCommitResult, error) {
+	if req.Type != "out" && req.Type != "in" {
+		return nil, apiErrors.ErrInvalidImportData.WithMessage("type은 'out' 또는 'in'이어야 합니다")
+	}
+	if len(req.Rows) == 0 {
+		return nil, apiErrors.ErrInvalidImportData.WithMessage("등록할 행이 없습니다")
+	}
+
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	result := &models.ImportCommitResult{UUIDs: make([]string, 0, len(req.Rows))}
+	for i, row := range req.Rows {
+		if row.Date == "" || row.User == "" || row.Money <= 0 || row.CategoryID <= 0 {
+			return nil, apiErrors.ErrInvalidImportData.WithMessage(fmt.Sprintf("%d번째 행: 날짜, 사용자, 금액, 카테고리는 필수입니다", i+1))
+		}
+
+		var keywordID *int
+		if name := strings.TrimSpace(row.KeywordName); name != "" {
+			id, err := upsertKeyword(tx, ledgerID, row.CategoryID, name)
+			if err != nil {
+				return nil, wrapImportRowError(i, err)
+			}
+			kid := int(id)
+			keywordID = &kid
+		}
+
+		var uuid string
+		if req.Type == "out" {
+			if row.PaymentMethodID <= 0 {
+				return nil, apiErrors.ErrInvalidImportData.WithMessage(fmt.Sprintf("%d번째 행: 결제수단은 필수입니다", i+1))
+			}
+			uuid, err = insertOutAccount(tx, ledgerID, row.Date, row.User, row.Money, row.CategoryID, keywordID, row.PaymentMethodID, row.Memo)
+		} else {
+			if row.DepositPathID <= 0 {
+				return nil, apiErrors.ErrInvalidImportData.WithMessage(fmt.Sprintf("%d번째 행: 입금경로는 필수입니다", i+1))
+			}
+			uuid, err = insertInAccount(tx, ledgerID, row.Date, row.User, row.Money, row.CategoryID, keywordID, row.DepositPathID, row.Memo)
+		}
+		if err != nil {
+			return nil, wrapImportRowError(i, err)
+		}
+
+		result.UUIDs = append(result.UUIDs, uuid)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("트랜잭션 커밋 오류: %v", err)
+	}
+
+	result.Inserted = len(result.UUIDs)
+	return result, nil
+}
+
+// applyImportRuleMatch 자동 분류 규칙 결과를 미리보기 행에 반영
+// 결제수단/입금경로는 프로필에 지정되지 않은 경우에만 채우고, 규칙에 키워드가 없으면 같은 카테고리의 키워드 매칭 결과 또는 가맹점명을 사용
+func applyImportRuleMatch(row *models.ImportPreviewRow, match models.RuleResult, keyword *importKeyword) {
+	for _, ref := range match.MatchedRules {
+		if len(ref.Fields) > 0 {
+			row.RuleIDs = append(row.RuleIDs, ref.ID)
+		}
+	}
+	if row.PaymentMethodID == nil {
+		row.PaymentMethodID = match.PaymentMethodID
+	}
+	if row.DepositPathID == nil {
+		row.DepositPathID = match.DepositPathID
+	}
+	if match.CategoryID == nil {
+		return
+	}
+
+	row.CategoryID = match.CategoryID
+	row.CategoryName = match.CategoryName
+	switch {
+	case match.KeywordName != "":
+		row.KeywordName = match.KeywordName
+	case keyword != nil && keyword.categoryID == *match.CategoryID:
+		row.KeywordID = &keyword.id
+		row.KeywordName = keyword.name
+	default:
+		row.KeywordName = row.Merchant
+	}
+}
+
+// wrapImportRowError 가져오기 중 발생한 오류에 행 번호 추가 (ErrorCode 는 상태 코드 유지)
+func wrapImportRowError(index int, err error) error {
+	if code, ok := err.(apiErrors.ErrorCode); ok {
+		return code.WithMessage(fmt.Sprintf("%d번째 행: %s", index+1, code.Message))
+	}
+	return fmt.Errorf("%d번째 행: %v", index+1, err)
+}
+
+// getImportKeywords 가맹점명 매칭용 키워드 목록 조회 (긴 이름, 많이 사용한 순)
+func (db *DB) getImportKeywords(ledgerID int, accountType string) ([]importKeyword, error) {
+	query := `
+		SELECT k.id, k.name, k.category_id, c.name
+		FROM keywords k
+		JOIN categories c ON k.category_id = c.id
+		WHERE c.ledger_id = ? AND c.type = ? AND c.is_active = 1 AND k.is_active = 1
+		ORDER BY LENGTH(k.name) DESC, k.usage_count DESC`
+
+	rows, err := db.Conn.Query(query, ledgerID, accountType)
+	if err != nil {
+		return nil, fmt.Errorf("가져오기 키워드 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	var keywords []importKeyword
+	for rows.Next() {
+		var keyword importKeyword
+		if err := rows.Scan(&keyword.id, &keyword.name, &keyword.categoryID, &keyword.categoryName); err != nil {
+			return nil, fmt.Errorf("가져오기 키워드 읽기 오류: %v", err)
+		}
+		keywords = append(keywords, keyword)
+	}
+	return keywords, rows.Err()
+}
+
+// matchImportKeyword 가맹점명과 정확히 일치하는 키워드를 우선, 없으면 가맹점명에 포함된 가장 긴 키워드 반환
+func matchImportKeyword(keywords []importKeyword, merchant string) *importKeyword {
+	if merchant == "" {
+		return nil
+	}
+
+	normalized := normalizeMatchText(merchant)
+	var partial *importKeyword
+	for i := range keywords {
+		name := normalizeMatchText(keywords[i].name)
+		if name == "" {
+			continue
+		}
+		if name == normalized {
+			return &keywords[i]
+		}
+		if partial == nil && strings.Contains(normalized, name) {
+			partial = &keywords[i]
+		}
+	}
+	return partial
+}
+
+// findImportDuplicate 날짜/금액/메모가 같은 기존 거래 UUID 조회 (없으면 빈 문자열)
+func (db *DB) findImportDuplicate(ledgerID int, accountType, date string, money int, memo string) (string, error) {
+	table := "out_account_data"
+	if accountType == "in" {
+		table = "in_account_data"
+	}
+
+	query := fmt.Sprintf(`
+		SELECT uuid FROM %s
+		WHERE ledger_id = ? AND DATE(date) = DATE(?) AND money = ? AND COALESCE(memo, '') = ?
+		LIMIT 1`, table)
+
+	var uuid string
+	err := db.Conn.QueryRow(query, ledgerID, date, money, memo).Scan(&uuid)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("중복 거래 조회 오류: %v", err)
+	}
+	return uuid, nil
+}
+
+// resolveImportColumn 컬럼 지정값(헤더 이름 또는 1부터 시작하는 열 번호)을 열 인덱스로 변환
+func resolveImportColumn(header []string, spec string) (int, error) {
+	spec = strings.TrimSpace(spec)
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(name), spec) {
+			return i, nil
+		}
+	}
+
+	if index, err := strconv.Atoi(spec); err == nil && index > 0 {
+		return index - 1, nil
+	}
+
+	return 0, apiErrors.ErrInvalidImportData.WithMessage(fmt.Sprintf("파일에서 '%s' 컬럼을 찾을 수 없습니다", spec))
+}
+
+// importCell 행에서 열 값 조회 (열이 부족하면 빈 문자열)
+func importCell(row []string, index int) string {
+	if index < 0 || index >= len(row) {
+		return ""
+	}
+	return row[index]
+}
+
+// isBlankImportRow 모든 열이 비어있는 행인지 확인 (명세서 하단 합계 앞 공백 행 등)
+func isBlankImportRow(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// parseImportDate 명세서 날짜를 YYYY-MM-DD 형식으로 변환 (엑셀 날짜 일련번호 포함)
+func parseImportDate(value, layout string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", fmt.Errorf("날짜가 비어있습니다")
+	}
+
+	if layout != "" {
+		parsed, err := utils.ParseDateInLayoutsKST(value, layout)
+		if err != nil {
+			return "", fmt.Errorf("날짜 형식이 '%s'와 일치하지 않습니다: %s", layout, value)
+		}
+		return utils.FormatDateKST(parsed), nil
+	}
+
+	// 8자리 숫자는 YYYYMMDD, 그 외 숫자는 엑셀 날짜 일련번호로 해석
+	if _, err := strconv.ParseFloat(value, 64); err == nil && len(value) != 8 {
+		if parsed, ok := utils.ParseExcelSerialDate(value); ok {
+			return utils.FormatDateKST(parsed), nil
+		}
+	}
+
+	parsed, err := utils.ParseDateInLayoutsKST(value, importDateLayouts...)
+	if err != nil {
+		return "", fmt.Errorf("날짜를 인식할 수 없습니다: %s", value)
+	}
+	return utils.FormatDateKST(parsed), nil
+}
+
+// parseImportAmount 명세서 금액을 정수로 변환 (쉼표, 통화 기호, 괄호 음수 표기 처리)
+func parseImportAmount(value string, negate bool) (int, error) {
+	cleaned := strings.TrimSpace(value)
+	negative := false
+	if strings.HasPrefix(cleaned, "(") && strings.HasSuffix(cleaned, ")") {
+		negative = true
+		cleaned = strings.Trim(cleaned, "()")
+	}
+	cleaned = strings.NewReplacer(",", "", "원", "", "₩", "", "\\", "", " ", "").Replace(cleaned)
+	if cleaned == "" {
+		return 0, fmt.Errorf("금액이 비어있습니다")
+	}
+
+	amount, err := strconv.ParseFloat(cleaned, 64)
+	if err != nil {
+		return 0, fmt.Errorf("금액을 인식할 수 없습니다: %s", value)
+	}
+	if negative {
+		amount = -amount
+	}
+	if negate {
+		amount = -amount
+	}
+	return int(math.Round(amount)), nil
+}
@@ -9,38 +9,73 @@ import (
 	"iksoon_account_backend/utils"
 )
 
-// InsertInAccount 수입 데이터 삽입
-func (db *DB) InsertInAccount(date, user string, money, categoryID int, keywordID *int, depositPathID int, memo string) error {
+// InsertInAccount 수입 데이터 삽입 (생성된 UUID 반환)
+// currency 가 외화이면 외화 금액과 환율을 같은 트랜잭션에서 함께 저장 (빈 값/KRW 는 원화 거래)
+func (db *DB) InsertInAccount(ledgerID int, date, user string, money, categoryID int, keywordID *int, depositPathID int, memo, currency string, originalMoney float64) (string, error) {
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return "", fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	uuidStr, err := insertInAccount(tx, ledgerID, date, user, money, categoryID, keywordID, depositPathID, memo)
+	if err != nil {
+		return "", err
+	}
+	if err := setTransactionCurrency(tx, ledgerID, models.AccountTypeIn, uuidStr, currency, originalMoney); err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("수입 데이터 저장 커밋 오류: %v", err)
+	}
+	return uuidStr, nil
+}
+
+// insertInAccount 수입 데이터 삽입 공통 로직 (트랜잭션 내부에서도 사용, 생성된 UUID 반환)
+func insertInAccount(exec sqlExecutor, ledgerID int, date, user string, money, categoryID int, keywordID *int, depositPathID int, memo string) (string, error) {
 	uuidStr := uuid.New().String()
 	parsedDate, err := utils.ParseDateTimeKST(date)
 	if err != nil {
 		utils.LogError("수입 데이터 날짜 파싱", err)
-		return fmt.Errorf("날짜 파싱 오류: %v", err)
+		return "", fmt.Errorf("날짜 파싱 오류: %v", err)
 	}
 	formattedDate := utils.FormatDateTimeKST(parsedDate)
 
+	if err := ensureInAccountReferences(exec, ledgerID, categoryID, depositPathID); err != nil {
+		return "", err
+	}
+
 	insertQuery := `
-    INSERT INTO in_account_data (uuid, date, money, user, category_id, keyword_id, deposit_path_id, memo, created_at, updated_at)
-    VALUES (?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+    INSERT INTO in_account_data (uuid, ledger_id, date, money, user, category_id, keyword_id, deposit_path_id, memo, created_at, updated_at)
+    VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
 
 	// 디버그용 상세 로깅
 	utils.Debug("수입 데이터 삽입 시도: UUID=%s, Date=%s, User=%s, Money=%d, CategoryID=%d, KeywordID=%v, DepositPathID=%d, Memo=%s",
 		uuidStr, formattedDate, user, money, categoryID, keywordID, depositPathID, memo)
 
-	_, err = db.Conn.Exec(insertQuery, uuidStr, formattedDate, money, user, categoryID, keywordID, depositPathID, memo)
+	_, err = exec.Exec(insertQuery, uuidStr, ledgerID, formattedDate, money, user, categoryID, keywordID, depositPathID, memo)
 	if err != nil {
 		utils.LogError("수입 데이터 SQL 실행", err)
 		utils.Debug("실패한 SQL: %s", insertQuery)
 		utils.Debug("실패한 파라미터: [%s, %s, %d, %s, %d, %v, %d, %s]",
 			uuidStr, formattedDate, money, user, categoryID, keywordID, depositPathID, memo)
-		return fmt.Errorf("수입 데이터 삽입 오류: %v", err)
+		return "", fmt.Errorf("수입 데이터 삽입 오류: %v", err)
 	}
 	utils.Debug("수입 데이터 삽입 성공: UUID=%s", uuidStr)
-	return nil
+	return uuidStr, nil
+}
+
+// ensureInAccountReferences 수입의 카테고리/입금경로가 같은 가계부에 속하는지 확인
+func ensureInAccountReferences(exec sqlExecutor, ledgerID, categoryID, depositPathID int) error {
+	if err := ensureLedgerRow(exec, "categories", categoryID, ledgerID); err != nil {
+		return err
+	}
+	return ensureLedgerRow(exec, "deposit_paths", depositPathID, ledgerID)
 }
 
 // GetInAccountsByDate 일별 수입 데이터 조회
-func (db *DB) GetInAccountsByDate(date string) ([]models.InAccount, error) {
+func (db *DB) GetInAccountsByDate(ledgerID int, date string) ([]models.InAccount, error) {
 	query := `
     SELECT ia.uuid, ia.date, ia.user, ia.money, ia.category_id, ia.keyword_id, ia.deposit_path_id, ia.memo, ia.created_at, ia.updated_at,
            c.name as category_name,
@@ -50,9 +85,9 @@ func (db *DB) GetInAccountsByDate(date string) ([]models.InAccount, error) {
     LEFT JOIN categories c ON ia.category_id = c.id
     LEFT JOIN keywords k ON ia.keyword_id = k.id
     LEFT JOIN deposit_paths dp ON ia.deposit_path_id = dp.id
-    WHERE date(ia.date) = date(?)`
+    WHERE ia.ledger_id = ? AND date(ia.date) = date(?)`
 
-	rows, err := db.Conn.Query(query, date)
+	rows, err := db.Conn.Query(query, ledgerID, date)
 	if err != nil {
 		return nil, fmt.Errorf("수입 데이터 조회 오류: %v", err)
 	}
@@ -75,11 +110,17 @@ func (db *DB) GetInAccountsByDate(date string) ([]models.InAccount, error) {
 		inAccounts = append(inAccounts, inAccount)
 	}
 
+	if err := attachInAccountTags(db.Conn, inAccounts); err != nil {
+		return nil, err
+	}
+	if err := attachInAccountCurrencies(db.Conn, inAccounts); err != nil {
+		return nil, err
+	}
 	return inAccounts, nil
 }
 
 // GetInAccountsForMonth 월별 수입 데이터 조회
-func (db *DB) GetInAccountsForMonth(year, month string) ([]models.InAccount, error) {
+func (db *DB) GetInAccountsForMonth(ledgerID int, year, month string) ([]models.InAccount, error) {
 	query := `
     SELECT ia.uuid, ia.date, ia.user, ia.money, ia.category_id, ia.keyword_id, ia.deposit_path_id, ia.memo, ia.created_at, ia.updated_at,
            c.name as category_name,
@@ -89,9 +130,9 @@ func (db *DB) GetInAccountsForMonth(year, month string) ([]models.InAccount, err
     LEFT JOIN categories c ON ia.category_id = c.id
     LEFT JOIN keywords k ON ia.keyword_id = k.id
     LEFT JOIN deposit_paths dp ON ia.deposit_path_id = dp.id
-    WHERE substr(ia.date, 1, 7) = ?`
+    WHERE ia.ledger_id = ? AND substr(ia.date, 1, 7) = ?`
 
-	rows, err := db.Conn.Query(query, year+"-"+month)
+	rows, err := db.Conn.Query(query, ledgerID, year+"-"+month)
 	if err != nil {
 		return nil, fmt.Errorf("월별 수입 데이터 조회 오류: %v", err)
 	}
@@ -114,99 +155,31 @@ func (db *DB) GetInAccountsForMonth(year, month string) ([]models.InAccount, err
 		accounts = append(accounts, account)
 	}
 
-	return accounts, nil
-}
-
-// GetInAccountsByDateRange 기간별 수입 데이터 조회
-func (db *DB) GetInAccountsByDateRange(startDate, endDate string) ([]models.InAccount, error) {
-	query := `
-    SELECT ia.uuid, ia.date, ia.user, ia.money, ia.category_id, ia.keyword_id, ia.deposit_path_id, ia.memo, ia.created_at, ia.updated_at,
-           c.name as category_name,
-           COALESCE(k.name, '') as keyword_name,
-           dp.name as deposit_path_name
-    FROM in_account_data ia
-    LEFT JOIN categories c ON ia.category_id = c.id
-    LEFT JOIN keywords k ON ia.keyword_id = k.id
-    LEFT JOIN deposit_paths dp ON ia.deposit_path_id = dp.id
-    WHERE DATE(ia.date) >= ? AND DATE(ia.date) <= ?
-    ORDER BY ia.date DESC`
-
-	rows, err := db.Conn.Query(query, startDate, endDate)
-	if err != nil {
-		utils.LogError("기간별 수입 데이터 조회", err)
-		return nil, fmt.Errorf("기간별 수입 데이터 조회 오류: %v", err)
+	if err := attachInAccountTags(db.Conn, accounts); err != nil {
+		return nil, err
 	}
-	defer rows.Close()
-
-	var accounts []models.InAccount
-	for rows.Next() {
-		var account models.InAccount
-		var keywordID *int
-
-		err := rows.Scan(
-			&account.UUID, &account.Date, &account.User, &account.Money, &account.CategoryID,
-			&keywordID, &account.DepositPathID, &account.Memo, &account.CreatedAt, &account.UpdatedAt,
-			&account.CategoryName, &account.KeywordName, &account.DepositPathName,
-		)
-		if err != nil {
-			utils.LogError("기간별 수입 데이터 스캔", err)
-			continue
-		}
-
-		account.KeywordID = keywordID
-		accounts = append(accounts, account)
+	if err := attachInAccountCurrencies(db.Conn, accounts); err != nil {
+		return nil, err
 	}
-
 	return accounts, nil
 }
 
-// SearchInAccountsByKeyword 키워드로 수입 데이터 검색
-func (db *DB) SearchInAccountsByKeyword(keyword, startDate, endDate string) ([]models.InAccount, error) {
-	query := `
-    SELECT ia.uuid, ia.date, ia.user, ia.money, ia.category_id, ia.keyword_id, ia.deposit_path_id, ia.memo, ia.created_at, ia.updated_at,
-           c.name as category_name,
-           COALESCE(k.name, '') as keyword_name,
-           dp.name as deposit_path_name
-    FROM in_account_data ia
-    LEFT JOIN categories c ON ia.category_id = c.id
-    LEFT JOIN keywords k ON ia.keyword_id = k.id
-    LEFT JOIN deposit_paths dp ON ia.deposit_path_id = dp.id
-    WHERE DATE(ia.date) >= ? AND DATE(ia.date) <= ?
-    AND (k.name LIKE ? OR ia.memo LIKE ?)
-    ORDER BY ia.date DESC`
+// GetInAccountsByDateRange 기간별 수입 데이터 조회 (opts 로 태그/금액/카테고리 등 필터, 정렬, 커서 페이지네이션)
+func (db *DB) GetInAccountsByDateRange(ledgerID int, startDate, endDate string, opts models.ListOptions) ([]models.InAccount, models.PageInfo, error) {
+	return db.queryInAccountList(`ia.ledger_id = ? AND DATE(ia.date) >= ? AND DATE(ia.date) <= ?`,
+		[]interface{}{ledgerID, startDate, endDate}, opts, "기간별 수입 데이터 조회")
+}
 
+// SearchInAccountsByKeyword 키워드로 수입 데이터 검색 (opts 로 태그/금액/카테고리 등 필터, 정렬, 커서 페이지네이션)
+func (db *DB) SearchInAccountsByKeyword(ledgerID int, keyword, startDate, endDate string, opts models.ListOptions) ([]models.InAccount, models.PageInfo, error) {
 	keywordPattern := "%" + keyword + "%"
-	rows, err := db.Conn.Query(query, startDate, endDate, keywordPattern, keywordPattern)
-	if err != nil {
-		utils.LogError("키워드 수입 데이터 검색", err)
-		return nil, fmt.Errorf("키워드 수입 데이터 검색 오류: %v", err)
-	}
-	defer rows.Close()
-
-	var accounts []models.InAccount
-	for rows.Next() {
-		var account models.InAccount
-		var keywordID *int
-
-		err := rows.Scan(
-			&account.UUID, &account.Date, &account.User, &account.Money, &account.CategoryID,
-			&keywordID, &account.DepositPathID, &account.Memo, &account.CreatedAt, &account.UpdatedAt,
-			&account.CategoryName, &account.KeywordName, &account.DepositPathName,
-		)
-		if err != nil {
-			utils.LogError("키워드 수입 데이터 스캔", err)
-			continue
-		}
-
-		account.KeywordID = keywordID
-		accounts = append(accounts, account)
-	}
-
-	return accounts, nil
+	return db.queryInAccountList(`ia.ledger_id = ? AND DATE(ia.date) >= ? AND DATE(ia.date) <= ?
+    AND (k.name LIKE ? OR ia.memo LIKE ?)`,
+		[]interface{}{ledgerID, startDate, endDate, keywordPattern, keywordPattern}, opts, "키워드 수입 데이터 검색")
 }
 
 // UpdateInAccount 수입 데이터 업데이트
-func (db *DB) UpdateInAccount(uuidStr, date, user string, money, categoryID int, keywordID *int, depositPathID int, memo string) error {
+func (db *DB) UpdateInAccount(ledgerID int, uuidStr, date, user string, money, categoryID int, keywordID *int, depositPathID int, memo string) error {
 	parsedDate, err := utils.ParseDateTimeKST(date)
 	if err != nil {
 		utils.LogError("수입 업데이트 날짜 파싱", err)
@@ -214,16 +187,20 @@ func (db *DB) UpdateInAccount(uuidStr, date, user string, money, categoryID int,
 	}
 	formattedDate := utils.FormatDateTimeKST(parsedDate)
 
+	if err := ensureInAccountReferences(db.Conn, ledgerID, categoryID, depositPathID); err != nil {
+		return err
+	}
+
 	updateQuery := `
     UPDATE in_account_data
     SET date = ?, money = ?, user = ?, category_id = ?, keyword_id = ?, deposit_path_id = ?, memo = ?, updated_at = CURRENT_TIMESTAMP
-    WHERE uuid = ?`
+    WHERE uuid = ? AND ledger_id = ?`
 
 	// 디버깅용 상세 로깅
 	utils.Debug("수입 데이터 업데이트 시도: UUID=%s, Date=%s, User=%s, Money=%d, CategoryID=%d, KeywordID=%v, DepositPathID=%d, Memo=%s",
 		uuidStr, formattedDate, user, money, categoryID, keywordID, depositPathID, memo)
 
-	result, err := db.Conn.Exec(updateQuery, formattedDate, money, user, categoryID, keywordID, depositPathID, memo, uuidStr)
+	result, err := db.Conn.Exec(updateQuery, formattedDate, money, user, categoryID, keywordID, depositPathID, memo, uuidStr, ledgerID)
 	if err != nil {
 		utils.LogError("수입 데이터 SQL 업데이트 실행", err)
 		utils.Debug("실패한 업데이트 SQL: %s", updateQuery)
@@ -250,9 +227,15 @@ func (db *DB) UpdateInAccount(uuidStr, date, user string, money, categoryID int,
 }
 
 // DeleteInAccount 수입 데이터 삭제
-func (db *DB) DeleteInAccount(uuidStr string) error {
-	deleteQuery := `DELETE FROM in_account_data WHERE uuid = ?`
-	result, err := db.Conn.Exec(deleteQuery, uuidStr)
+func (db *DB) DeleteInAccount(ledgerID int, uuidStr string) error {
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	deleteQuery := `DELETE FROM in_account_data WHERE uuid = ? AND ledger_id = ?`
+	result, err := tx.Exec(deleteQuery, uuidStr, ledgerID)
 	if err != nil {
 		return fmt.Errorf("수입 데이터 삭제 오류: %v", err)
 	}
@@ -266,11 +249,29 @@ func (db *DB) DeleteInAccount(uuidStr string) error {
 		return fmt.Errorf("no rows affected")
 	}
 
+	if err := deleteTransactionTags(tx, models.AccountTypeIn, uuidStr); err != nil {
+		return err
+	}
+	if err := releaseReimbursementIncome(tx, uuidStr); err != nil {
+		return err
+	}
+	if err := deleteTransactionCurrency(tx, models.AccountTypeIn, uuidStr); err != nil {
+		return err
+	}
+	attachmentFiles, err := deleteTransactionAttachments(tx, models.AccountTypeIn, uuidStr)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	db.removeAttachmentFiles(attachmentFiles)
 	return nil
 }
 
 // GetInAccountByUUID UUID로 수입 데이터 조회
-func (db *DB) GetInAccountByUUID(uuidStr string) (*models.InAccount, error) {
+func (db *DB) GetInAccountByUUID(ledgerID int, uuidStr string) (*models.InAccount, error) {
 	query := `
     SELECT ia.uuid, ia.date, ia.user, ia.money, ia.category_id, ia.keyword_id, ia.deposit_path_id, ia.memo, ia.created_at, ia.updated_at,
            c.name as category_name,
@@ -280,12 +281,12 @@ func (db *DB) GetInAccountByUUID(uuidStr string) (*models.InAccount, error) {
     LEFT JOIN categories c ON ia.category_id = c.id
     LEFT JOIN keywords k ON ia.keyword_id = k.id
     LEFT JOIN deposit_paths dp ON ia.deposit_path_id = dp.id
-    WHERE ia.uuid = ?`
+    WHERE ia.ledger_id = ? AND ia.uuid = ?`
 
 	var inAccount models.InAccount
 	var keywordID *int
 
-	err := db.Conn.QueryRow(query, uuidStr).Scan(&inAccount.UUID, &inAccount.Date, &inAccount.User, &inAccount.Money,
+	err := db.Conn.QueryRow(query, ledgerID, uuidStr).Scan(&inAccount.UUID, &inAccount.Date, &inAccount.User, &inAccount.Money,
 		&inAccount.CategoryID, &keywordID, &inAccount.DepositPathID, &inAccount.Memo,
 		&inAccount.CreatedAt, &inAccount.UpdatedAt,
 		&inAccount.CategoryName, &inAccount.KeywordName, &inAccount.DepositPathName)
@@ -294,5 +295,16 @@ func (db *DB) GetInAccountByUUID(uuidStr string) (*models.InAccount, error) {
 	}
 
 	inAccount.KeywordID = keywordID
-	return &inAccount, nil
+
+	tags, err := loadTransactionTags(db.Conn, models.AccountTypeIn, []string{uuidStr})
+	if err != nil {
+		return nil, err
+	}
+	inAccount.Tags = tags[uuidStr]
+
+	accounts := []models.InAccount{inAccount}
+	if err := attachInAccountCurrencies(db.Conn, accounts); err != nil {
+		return nil, err
+	}
+	return &accounts[0], nil
 }
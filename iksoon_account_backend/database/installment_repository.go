@@ -0,0 +1,446 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+
+	"github.com/google/uuid"
+)
+
+// 할부 개월 수 범위
+const (
+	minInstallmentMonths = 2
+	maxInstallmentMonths = 60
+)
+
+// createInstallmentTables 할부 테이블 생성 및 지출 데이터에 할부 회차 컬럼 추가
+// 회차 지출은 out_account_data 에 월별로 기록되므로 예산/통계는 각 달의 회차만 집계함
+func createInstallmentTables(exec sqlExecutor) error {
+	createInstallmentTable := `
+    CREATE TABLE IF NOT EXISTS installments (
+        uuid TEXT PRIMARY KEY,
+        ledger_id INTEGER NOT NULL,
+        purchase_date TEXT NOT NULL,
+        principal INT NOT NULL CHECK (principal > 0),
+        months INTEGER NOT NULL CHECK (months >= 2),
+        interest_rate REAL NOT NULL DEFAULT 0,
+        total_interest INT NOT NULL DEFAULT 0,
+        user VARCHAR(255) NOT NULL,
+        category_id INTEGER NOT NULL,
+        keyword_id INTEGER,
+        payment_method_id INTEGER NOT NULL,
+        memo TEXT DEFAULT '',
+        created_at TEXT DEFAULT CURRENT_TIMESTAMP,
+        updated_at TEXT DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (ledger_id) REFERENCES ledgers(id) ON DELETE CASCADE,
+        FOREIGN KEY (category_id) REFERENCES categories(id),
+        FOREIGN KEY (keyword_id) REFERENCES keywords(id),
+        FOREIGN KEY (payment_method_id) REFERENCES payment_methods(id)
+    );`
+
+	if _, err := exec.Exec(createInstallmentTable); err != nil {
+		return fmt.Errorf("할부 테이블 생성 오류: %v", err)
+	}
+
+	if _, err := addColumnIfNotExists(exec, "out_account_data", "installment_id", "TEXT NULL"); err != nil {
+		return err
+	}
+	if _, err := addColumnIfNotExists(exec, "out_account_data", "installment_seq", "INTEGER NULL"); err != nil {
+		return err
+	}
+
+	indexes := []string{
+		`CREATE INDEX IF NOT EXISTS idx_installments_ledger_date ON installments(ledger_id, purchase_date)`,
+		`CREATE INDEX IF NOT EXISTS idx_out_account_installment ON out_account_data(installment_id)`,
+	}
+	for _, index := range indexes {
+		if _, err := exec.Exec(index); err != nil {
+			return fmt.Errorf("할부 인덱스 생성 오류: %v", err)
+		}
+	}
+	return nil
+}
+
+// dropInstallmentTables 할부 테이블과 회차 컬럼 제거 (이미 기록된 회차는 일반 지출로 남음)
+func dropInstallmentTables(exec sqlExecutor) error {
+	steps := []string{
+		`DROP INDEX IF EXISTS idx_out_account_installment`,
+		`ALTER TABLE out_account_data DROP COLUMN installment_seq`,
+		`ALTER TABLE out_account_data DROP COLUMN installment_id`,
+		`DROP TABLE IF EXISTS installments`,
+	}
+	for _, step := range steps {
+		if _, err := exec.Exec(step); err != nil {
+			return fmt.Errorf("할부 테이블 제거 오류: %v", err)
+		}
+	}
+	return nil
+}
+
+// installmentSelectQuery 할부 조회 공통 쿼리 (이름 조인 및 도래한 회차 합계 포함, 첫 번째 인자는 기준일)
+const installmentSelectQuery = `
+    SELECT i.uuid, i.purchase_date, i.principal, i.months, i.interest_rate, i.total_interest,
+           COALESCE((SELECT SUM(oa.money) FROM out_account_data oa WHERE oa.installment_id = i.uuid AND DATE(oa.date) <= ?), 0),
+           i.user, i.category_id, i.keyword_id, i.payment_method_id, COALESCE(i.memo, ''), i.created_at, i.updated_at,
+           COALESCE(c.name, ''), COALESCE(k.name, ''), COALESCE(pm.name, '')
+    FROM installments i
+    LEFT JOIN categories c ON i.category_id = c.id
+    LEFT JOIN keywords k ON i.keyword_id = k.id
+    LEFT JOIN payment_methods pm ON i.payment_method_id = pm.id`
+
+// scanInstallment 할부 행 스캔
+func scanInstallment(scanner interface{ Scan(...interface{}) error }) (*models.Installment, error) {
+	var installment models.Installment
+	err := scanner.Scan(&installment.UUID, &installment.PurchaseDate, &installment.Principal, &installment.Months,
+		&installment.InterestRate, &installment.TotalInterest, &installment.PaidAmount,
+		&installment.User, &installment.CategoryID, &installment.KeywordID, &installment.PaymentMethodID, &installment.Memo,
+		&installment.CreatedAt, &installment.UpdatedAt,
+		&installment.CategoryName, &installment.KeywordName, &installment.PaymentMethodName)
+	if err != nil {
+		return nil, err
+	}
+
+	installment.TotalAmount = installment.Principal + installment.TotalInterest
+	installment.RemainingAmount = installment.TotalAmount - installment.PaidAmount
+	return &installment, nil
+}
+
+// GetInstallments 할부 목록 조회 (activeOnly 이면 아직 남은 회차가 있는 할부만)
+func (db *DB) GetInstallments(ledgerID int, activeOnly bool) ([]models.Installment, error) {
+	today := utils.FormatDateKST(utils.GetCurrentKST())
+	query := installmentSelectQuery + ` WHERE i.ledger_id = ? ORDER BY i.purchase_date DESC, i.created_at DESC`
+
+	rows, err := db.Conn.Query(query, today, ledgerID)
+	if err != nil {
+		return nil, fmt.Errorf("할부 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	installments := []models.Installment{}
+	for rows.Next() {
+		installment, err := scanInstallment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("할부 데이터 읽기 오류: %v", err)
+		}
+		if activeOnly && installment.RemainingAmount <= 0 {
+			continue
+		}
+		installments = append(installments, *installment)
+	}
+
+	return installments, rows.Err()
+}
+
+// GetInstallmentByUUID UUID로 할부와 월별 회차 지출 조회
+func (db *DB) GetInstallmentByUUID(ledgerID int, uuidStr string) (*models.Installment, error) {
+	today := utils.FormatDateKST(utils.GetCurrentKST())
+	installment, err := scanInstallment(db.Conn.QueryRow(installmentSelectQuery+` WHERE i.ledger_id = ? AND i.uuid = ?`, today, ledgerID, uuidStr))
+	if err == sql.ErrNoRows {
+		return nil, apiErrors.ErrInstallmentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("할부 조회 오류: %v", err)
+	}
+
+	if installment.Portions, err = installmentPortions(db.Conn, ledgerID, uuidStr); err != nil {
+		return nil, err
+	}
+	if err := attachOutAccountTags(db.Conn, installment.Portions); err != nil {
+		return nil, err
+	}
+	return installment, nil
+}
+
+// InsertInstallment 할부 구매 등록 후 월별 회차 지출 생성 (생성된 할부 UUID 반환)
+func (db *DB) InsertInstallment(ledgerID int, req models.InstallmentRequest, keywordID *int) (string, error) {
+	purchaseDate, err := normalizeInstallmentRequest(db.Conn, ledgerID, &req)
+	if err != nil {
+		return "", err
+	}
+
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return "", fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	uuidStr := uuid.New().String()
+	totalInterest, err := insertInstallmentPortions(tx, ledgerID, uuidStr, req, keywordID, purchaseDate)
+	if err != nil {
+		return "", err
+	}
+
+	query := `
+    INSERT INTO installments (uuid, ledger_id, purchase_date, principal, months, interest_rate, total_interest,
+                              user, category_id, keyword_id, payment_method_id, memo, created_at, updated_at)
+    VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+
+	_, err = tx.Exec(query, uuidStr, ledgerID, utils.FormatDateTimeKST(purchaseDate), req.Money, req.Months, req.InterestRate, totalInterest,
+		req.User, req.CategoryID, keywordID, req.PaymentMethodID, req.Memo)
+	if err != nil {
+		return "", fmt.Errorf("할부 등록 오류: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("할부 등록 커밋 오류: %v", err)
+	}
+	return uuidStr, nil
+}
+
+// UpdateInstallment 할부 조건 수정 (기존 회차를 모두 지우고 새 조건으로 다시 생성)
+func (db *DB) UpdateInstallment(ledgerID int, uuidStr string, req models.InstallmentRequest, keywordID *int) error {
+	purchaseDate, err := normalizeInstallmentRequest(db.Conn, ledgerID, &req)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+    UPDATE installments
+    SET purchase_date = ?, principal = ?, months = ?, interest_rate = ?,
+        user = ?, category_id = ?, keyword_id = ?, payment_method_id = ?, memo = ?, updated_at = CURRENT_TIMESTAMP
+    WHERE uuid = ? AND ledger_id = ?`
+
+	result, err := tx.Exec(query, utils.FormatDateTimeKST(purchaseDate), req.Money, req.Months, req.InterestRate,
+		req.User, req.CategoryID, keywordID, req.PaymentMethodID, req.Memo, uuidStr, ledgerID)
+	if err != nil {
+		return fmt.Errorf("할부 수정 오류: %v", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return apiErrors.ErrInstallmentNotFound
+	}
+
+	// 회차에 붙어 있던 태그와 첨부파일은 새로 만든 회차에 다시 연결
+	tagIDs, err := installmentTagIDs(tx, ledgerID, uuidStr)
+	if err != nil {
+		return err
+	}
+	attachmentSeqs, err := installmentAttachmentSeqs(tx, ledgerID, uuidStr)
+	if err != nil {
+		return err
+	}
+	if err := deleteInstallmentPortions(tx, ledgerID, uuidStr); err != nil {
+		return err
+	}
+	totalInterest, err := insertInstallmentPortions(tx, ledgerID, uuidStr, req, keywordID, purchaseDate)
+	if err != nil {
+		return err
+	}
+	if err := tagInstallmentPortions(tx, ledgerID, uuidStr, tagIDs); err != nil {
+		return err
+	}
+	if err := reattachInstallmentAttachments(tx, ledgerID, uuidStr, attachmentSeqs, req.Months); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`UPDATE installments SET total_interest = ? WHERE uuid = ?`, totalInterest, uuidStr); err != nil {
+		return fmt.Errorf("할부 이자 갱신 오류: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("할부 수정 커밋 오류: %v", err)
+	}
+	return nil
+}
+
+// DeleteInstallment 할부 취소 (할부와 모든 회차 지출 삭제)
+func (db *DB) DeleteInstallment(ledgerID int, uuidStr string) error {
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`DELETE FROM installments WHERE uuid = ? AND ledger_id = ?`, uuidStr, ledgerID)
+	if err != nil {
+		return fmt.Errorf("할부 삭제 오류: %v", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return apiErrors.ErrInstallmentNotFound
+	}
+
+	attachmentFiles, err := deleteInstallmentAttachments(tx, ledgerID, uuidStr)
+	if err != nil {
+		return err
+	}
+	if err := deleteInstallmentPortions(tx, ledgerID, uuidStr); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("할부 삭제 커밋 오류: %v", err)
+	}
+	db.removeAttachmentFiles(attachmentFiles)
+	return nil
+}
+
+// installmentPortion 할부 한 회차의 원금/이자
+type installmentPortion struct {
+	principal int
+	interest  int
+}
+
+// splitInstallment 원금을 개월 수로 균등 분할하고 회차별 이자 계산
+// 나머지 원금은 첫 회차에 포함하고, 이자는 회차 시작 시점의 남은 원금 × 연 이자율 / 12 (원 단위 반올림)
+func splitInstallment(principal, months int, interestRate float64) []installmentPortion {
+	base := principal / months
+	portions := make([]installmentPortion, months)
+	remaining := principal
+	for i := range portions {
+		portions[i].principal = base
+		if i == 0 {
+			portions[i].principal += principal - base*months
+		}
+		portions[i].interest = int(math.Round(float64(remaining) * interestRate / 100 / 12))
+		remaining -= portions[i].principal
+	}
+	return portions
+}
+
+// insertInstallmentPortions 월별 회차 지출 생성 (총 이자 반환)
+// 회차 날짜는 구매일과 같은 일자이며, 해당 월에 그 일자가 없으면 말일로 조정
+func insertInstallmentPortions(tx *sql.Tx, ledgerID int, installmentID string, req models.InstallmentRequest, keywordID *int, purchaseDate time.Time) (int, error) {
+	loc := purchaseDate.Location()
+	firstOfMonth := time.Date(purchaseDate.Year(), purchaseDate.Month(), 1, 0, 0, 0, 0, loc)
+	timeOfDay := purchaseDate.Sub(time.Date(purchaseDate.Year(), purchaseDate.Month(), purchaseDate.Day(), 0, 0, 0, 0, loc))
+
+	totalInterest := 0
+	for i, portion := range splitInstallment(req.Money, req.Months, req.InterestRate) {
+		month := firstOfMonth.AddDate(0, i, 0)
+		portionDate := clampDayOfMonth(month.Year(), month.Month(), purchaseDate.Day(), loc).Add(timeOfDay)
+
+		memo := fmt.Sprintf("할부 %d/%d", i+1, req.Months)
+		if req.Memo != "" {
+			memo = fmt.Sprintf("%s (%s)", req.Memo, memo)
+		}
+
+		portionUUID, err := insertOutAccount(tx, ledgerID, utils.FormatDateTimeKST(portionDate), req.User, portion.principal+portion.interest,
+			req.CategoryID, keywordID, req.PaymentMethodID, memo)
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = tx.Exec(`UPDATE out_account_data SET installment_id = ?, installment_seq = ? WHERE uuid = ?`, installmentID, i+1, portionUUID)
+		if err != nil {
+			return 0, fmt.Errorf("할부 회차 연결 오류: %v", err)
+		}
+		totalInterest += portion.interest
+	}
+
+	utils.Debug("할부 회차 생성: 할부=%s, %d개월, 총 이자=%d", installmentID, req.Months, totalInterest)
+	return totalInterest, nil
+}
+
+// deleteInstallmentPortions 할부의 모든 회차 지출 삭제 (회차의 태그 연결 포함)
+func deleteInstallmentPortions(exec sqlExecutor, ledgerID int, installmentID string) error {
+	_, err := exec.Exec(`
+        DELETE FROM transaction_tags
+        WHERE account_type = 'out' AND account_uuid IN (SELECT uuid FROM out_account_data WHERE installment_id = ? AND ledger_id = ?)`, installmentID, ledgerID)
+	if err != nil {
+		return fmt.Errorf("할부 회차 태그 삭제 오류: %v", err)
+	}
+
+	_, err = exec.Exec(`DELETE FROM out_account_data WHERE installment_id = ? AND ledger_id = ?`, installmentID, ledgerID)
+	if err != nil {
+		return fmt.Errorf("할부 회차 삭제 오류: %v", err)
+	}
+	return nil
+}
+
+// installmentPortions 할부의 회차 지출 조회 (회차 오름차순)
+func installmentPortions(exec sqlExecutor, ledgerID int, installmentID string) ([]models.OutAccount, error) {
+	query := `
+    SELECT oa.uuid, oa.date, oa.user, oa.money, oa.category_id, oa.keyword_id, oa.payment_method_id, oa.memo, oa.installment_id, oa.installment_seq, oa.created_at, oa.updated_at,
+           COALESCE(c.name, '') as category_name,
+           COALESCE(k.name, '') as keyword_name,
+           COALESCE(pm.name, '') as payment_method_name
+    FROM out_account_data oa
+    LEFT JOIN categories c ON oa.category_id = c.id
+    LEFT JOIN keywords k ON oa.keyword_id = k.id
+    LEFT JOIN payment_methods pm ON oa.payment_method_id = pm.id
+    WHERE oa.ledger_id = ? AND oa.installment_id = ?
+    ORDER BY oa.installment_seq ASC`
+
+	rows, err := exec.Query(query, ledgerID, installmentID)
+	if err != nil {
+		return nil, fmt.Errorf("할부 회차 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	portions := []models.OutAccount{}
+	for rows.Next() {
+		var portion models.OutAccount
+		err := rows.Scan(
+			&portion.UUID, &portion.Date, &portion.User, &portion.Money, &portion.CategoryID,
+			&portion.KeywordID, &portion.PaymentMethodID, &portion.Memo, &portion.InstallmentID, &portion.InstallmentSeq, &portion.CreatedAt, &portion.UpdatedAt,
+			&portion.CategoryName, &portion.KeywordName, &portion.PaymentMethodName,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("할부 회차 읽기 오류: %v", err)
+		}
+		portions = append(portions, portion)
+	}
+	return portions, rows.Err()
+}
+
+// ensureNotInstallmentPortion 지출이 할부 회차이면 개별 수정/삭제를 막음
+func ensureNotInstallmentPortion(exec sqlExecutor, ledgerID int, uuidStr string) error {
+	var installmentID sql.NullString
+	err := exec.QueryRow(`SELECT installment_id FROM out_account_data WHERE uuid = ? AND ledger_id = ?`, uuidStr, ledgerID).Scan(&installmentID)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("할부 회차 확인 오류: %v", err)
+	}
+	if installmentID.Valid {
+		return apiErrors.ErrInstallmentPortionLocked.WithDetails("installment_id=" + installmentID.String)
+	}
+	return nil
+}
+
+// normalizeInstallmentRequest 할부 요청 유효성 검사 (KST 구매 일시 반환)
+func normalizeInstallmentRequest(exec sqlExecutor, ledgerID int, req *models.InstallmentRequest) (time.Time, error) {
+	req.User = strings.TrimSpace(req.User)
+	req.Memo = strings.TrimSpace(req.Memo)
+
+	if req.Money <= 0 {
+		return time.Time{}, apiErrors.ErrInvalidInstallment.WithMessage("할부 금액은 0보다 커야 합니다")
+	}
+	if req.User == "" {
+		return time.Time{}, apiErrors.ErrMissingRequired.WithMessage("사용자는 필수입니다")
+	}
+	if req.CategoryID <= 0 || req.PaymentMethodID <= 0 {
+		return time.Time{}, apiErrors.ErrMissingRequired.WithMessage("카테고리와 결제수단은 필수입니다")
+	}
+	if req.Months < minInstallmentMonths || req.Months > maxInstallmentMonths {
+		return time.Time{}, apiErrors.ErrInvalidInstallment.WithMessage(fmt.Sprintf("할부 개월 수는 %d~%d 사이여야 합니다", minInstallmentMonths, maxInstallmentMonths))
+	}
+	if req.Money < req.Months {
+		return time.Time{}, apiErrors.ErrInvalidInstallment.WithMessage("할부 금액은 개월 수 이상이어야 합니다")
+	}
+	if req.InterestRate < 0 || req.InterestRate > 100 || math.IsNaN(req.InterestRate) {
+		return time.Time{}, apiErrors.ErrInvalidInstallment.WithMessage("할부 이자율은 0~100(%) 사이여야 합니다")
+	}
+
+	purchaseDate, err := utils.ParseDateTimeKST(req.Date)
+	if err != nil {
+		return time.Time{}, apiErrors.ErrInvalidInstallment.WithMessage("날짜 형식이 올바르지 않습니다")
+	}
+
+	if err := ensureOutAccountReferences(exec, ledgerID, req.CategoryID, req.PaymentMethodID); err != nil {
+		return time.Time{}, err
+	}
+	return purchaseDate, nil
+}
@@ -0,0 +1,133 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+
+	"iksoon_account_backend/models"
+)
+
+func TestInsertInstallmentPortions(t *testing.T) {
+	db := newTestDB(t)
+	categoryID := createTestCategory(t, db, "테스트 가전", "out")
+	methodID := createTestPaymentMethod(t, db, "테스트 카드")
+
+	uuid, err := db.InsertInstallment(DefaultLedgerID, models.InstallmentRequest{
+		Date: "2024-01-31 10:30:00", User: "테스트", Money: 1000000, CategoryID: categoryID,
+		PaymentMethodID: methodID, Memo: "냉장고", Months: 3, InterestRate: 12,
+	}, nil)
+	if err != nil {
+		t.Fatalf("InsertInstallment() error = %v", err)
+	}
+
+	rows, err := db.Conn.Query(`
+        SELECT date, money, memo, installment_seq FROM out_account_data
+        WHERE installment_id = ? ORDER BY installment_seq`, uuid)
+	if err != nil {
+		t.Fatalf("회차 조회 실패: %v", err)
+	}
+	defer rows.Close()
+
+	type portionRow struct {
+		date  string
+		money int
+		memo  string
+		seq   int
+	}
+	var got []portionRow
+	for rows.Next() {
+		var row portionRow
+		if err := rows.Scan(&row.date, &row.money, &row.memo, &row.seq); err != nil {
+			t.Fatalf("회차 읽기 실패: %v", err)
+		}
+		got = append(got, row)
+	}
+
+	// 31일이 없는 달은 말일로 조정, 구매 시각은 유지
+	want := []portionRow{
+		{"2024-01-31 10:30:00", 343334, "냉장고 (할부 1/3)", 1},
+		{"2024-02-29 10:30:00", 340000, "냉장고 (할부 2/3)", 2},
+		{"2024-03-31 10:30:00", 336666, "냉장고 (할부 3/3)", 3},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("회차 지출 = %v, want %v", got, want)
+	}
+
+	var principal, totalInterest int
+	if err := db.Conn.QueryRow(`SELECT principal, total_interest FROM installments WHERE uuid = ?`, uuid).Scan(&principal, &totalInterest); err != nil {
+		t.Fatalf("할부 조회 실패: %v", err)
+	}
+	if principal != 1000000 || totalInterest != 20000 {
+		t.Errorf("원금/총 이자 = %d/%d, want 1000000/20000", principal, totalInterest)
+	}
+}
+
+func TestInstallmentCountsMonthlyPortions(t *testing.T) {
+	db := newTestDB(t)
+	categoryID := createTestCategory(t, db, "테스트 가전", "out")
+	methodID := createTestPaymentMethod(t, db, "테스트 카드")
+	if _, err := db.CreateCategoryBudget(DefaultLedgerID, categoryID, "", 400000, 0, "2024-01"); err != nil {
+		t.Fatalf("기준치 생성 실패: %v", err)
+	}
+
+	req := models.InstallmentRequest{
+		Date: "2024-01-10", User: "테스트", Money: 1200000, CategoryID: categoryID,
+		PaymentMethodID: methodID, Memo: "노트북", Months: 3,
+	}
+	uuid, err := db.InsertInstallment(DefaultLedgerID, req, nil)
+	if err != nil {
+		t.Fatalf("InsertInstallment() error = %v", err)
+	}
+
+	// 구매 월에 전액이 아니라 회차 금액만 기준치와 통계에 반영
+	monthlyUsed := func(month string) int {
+		t.Helper()
+		usage, err := db.GetBudgetUsage(DefaultLedgerID, categoryID, "", mustParseKST(t, month+"-20 12:00:00"))
+		if err != nil {
+			t.Fatalf("%s GetBudgetUsage() error = %v", month, err)
+		}
+		return usage.MonthlyUsed
+	}
+	for _, month := range []string{"2024-01", "2024-02", "2024-03"} {
+		if used := monthlyUsed(month); used != 400000 {
+			t.Errorf("%s 기준치 사용량 = %d, want 400000", month, used)
+		}
+	}
+	total, count, err := db.GetTotalAmount(DefaultLedgerID, "2024-01-01", "2024-01-31", "out")
+	if err != nil {
+		t.Fatalf("GetTotalAmount() error = %v", err)
+	}
+	if total != 400000 || count != 1 {
+		t.Errorf("1월 지출 합계 = %d (%d건), want 400000 (1건)", total, count)
+	}
+
+	// 조건 수정은 회차 전체를 다시 생성
+	req.Months = 2
+	if err := db.UpdateInstallment(DefaultLedgerID, uuid, req, nil); err != nil {
+		t.Fatalf("UpdateInstallment() error = %v", err)
+	}
+	installment, err := db.GetInstallmentByUUID(DefaultLedgerID, uuid)
+	if err != nil {
+		t.Fatalf("GetInstallmentByUUID() error = %v", err)
+	}
+	if installment.Months != 2 || len(installment.Portions) != 2 || installment.TotalAmount != 1200000 {
+		t.Errorf("수정 후 할부 = %d개월, 회차 %d건, 총 %d원; want 2개월, 2건, 1200000원", installment.Months, len(installment.Portions), installment.TotalAmount)
+	}
+	if used := monthlyUsed("2024-02"); used != 600000 {
+		t.Errorf("수정 후 2월 사용량 = %d, want 600000", used)
+	}
+	if used := monthlyUsed("2024-03"); used != 0 {
+		t.Errorf("수정 후 3월 사용량 = %d, want 0", used)
+	}
+
+	// 취소하면 할부와 모든 회차가 함께 삭제
+	if err := db.DeleteInstallment(DefaultLedgerID, uuid); err != nil {
+		t.Fatalf("DeleteInstallment() error = %v", err)
+	}
+	if used := monthlyUsed("2024-01"); used != 0 {
+		t.Errorf("취소 후 1월 사용량 = %d, want 0", used)
+	}
+	if _, err := db.GetInstallmentByUUID(DefaultLedgerID, uuid); err == nil {
+		t.Error("취소한 할부가 조회됨")
+	}
+}
@@ -7,8 +7,11 @@ import (
 	"iksoon_account_backend/models"
 )
 
+// keywordLedgerFilter 키워드가 속한 카테고리의 가계부로 범위를 제한하는 조건
+const keywordLedgerFilter = `category_id IN (SELECT id FROM categories WHERE ledger_id = ?)`
+
 // GetKeywordSuggestions 키워드 자동완성 목록 조회
-func (db *DB) GetKeywordSuggestions(categoryID int, query string, limit int) ([]models.KeywordSuggestion, error) {
+func (db *DB) GetKeywordSuggestions(ledgerID int, categoryID int, query string, limit int) ([]models.KeywordSuggestion, error) {
 	var sqlQuery string
 	var args []interface{}
 
@@ -16,18 +19,18 @@ func (db *DB) GetKeywordSuggestions(categoryID int, query string, limit int) ([]
 		sqlQuery = `
 			SELECT id, name, usage_count 
 			FROM keywords 
-			WHERE category_id = ? AND name LIKE ? AND is_active = 1
+			WHERE category_id = ? AND name LIKE ? AND is_active = 1 AND ` + keywordLedgerFilter + `
 			ORDER BY usage_count DESC, last_used DESC, name ASC
 			LIMIT ?`
-		args = []interface{}{categoryID, "%" + query + "%", limit}
+		args = []interface{}{categoryID, "%" + query + "%", ledgerID, limit}
 	} else {
 		sqlQuery = `
 			SELECT id, name, usage_count 
 			FROM keywords 
-			WHERE category_id = ? AND is_active = 1
+			WHERE category_id = ? AND is_active = 1 AND ` + keywordLedgerFilter + `
 			ORDER BY usage_count DESC, last_used DESC, name ASC
 			LIMIT ?`
-		args = []interface{}{categoryID, limit}
+		args = []interface{}{categoryID, ledgerID, limit}
 	}
 
 	rows, err := db.Conn.Query(sqlQuery, args...)
@@ -50,14 +53,14 @@ func (db *DB) GetKeywordSuggestions(categoryID int, query string, limit int) ([]
 }
 
 // GetKeywordsByCategory 카테고리별 키워드 목록 조회
-func (db *DB) GetKeywordsByCategory(categoryID int) ([]models.Keyword, error) {
+func (db *DB) GetKeywordsByCategory(ledgerID int, categoryID int) ([]models.Keyword, error) {
 	query := `
 		SELECT id, category_id, name, usage_count, last_used, created_at
 		FROM keywords 
-		WHERE category_id = ? AND is_active = 1
+		WHERE category_id = ? AND is_active = 1 AND ` + keywordLedgerFilter + `
 		ORDER BY usage_count DESC, last_used DESC, name ASC`
 
-	rows, err := db.Conn.Query(query, categoryID)
+	rows, err := db.Conn.Query(query, categoryID, ledgerID)
 	if err != nil {
 		return nil, fmt.Errorf("키워드 조회 오류: %v", err)
 	}
@@ -89,13 +92,23 @@ func (db *DB) GetKeywordsByCategory(categoryID int) ([]models.Keyword, error) {
 }
 
 // UpsertKeyword 키워드 생성 또는 업데이트 (이미 존재하면 사용 횟수 증가)
-func (db *DB) UpsertKeyword(categoryID int, name string) (int64, error) {
+func (db *DB) UpsertKeyword(ledgerID int, categoryID int, name string) (int64, error) {
+	return upsertKeyword(db.Conn, ledgerID, categoryID, name)
+}
+
+// upsertKeyword 키워드 upsert 공통 로직 (트랜잭션 내부에서도 사용)
+func upsertKeyword(exec sqlExecutor, ledgerID int, categoryID int, name string) (int64, error) {
+	// 다른 가계부의 카테고리에는 키워드를 만들 수 없음
+	if err := ensureLedgerRow(exec, "categories", categoryID, ledgerID); err != nil {
+		return 0, err
+	}
+
 	// 기존 키워드 확인
 	var existingID int64
 	var usageCount int
 
 	checkQuery := `SELECT id, usage_count FROM keywords WHERE category_id = ? AND name = ?`
-	err := db.Conn.QueryRow(checkQuery, categoryID, name).Scan(&existingID, &usageCount)
+	err := exec.QueryRow(checkQuery, categoryID, name).Scan(&existingID, &usageCount)
 
 	if err == nil {
 		// 기존 키워드가 있으면 사용 횟수와 마지막 사용 시간 업데이트
@@ -104,7 +117,7 @@ func (db *DB) UpsertKeyword(categoryID int, name string) (int64, error) {
 			SET usage_count = usage_count + 1, last_used = CURRENT_TIMESTAMP 
 			WHERE id = ?`
 
-		_, err = db.Conn.Exec(updateQuery, existingID)
+		_, err = exec.Exec(updateQuery, existingID)
 		if err != nil {
 			return 0, fmt.Errorf("키워드 업데이트 오류: %v", err)
 		}
@@ -117,7 +130,7 @@ func (db *DB) UpsertKeyword(categoryID int, name string) (int64, error) {
 		INSERT INTO keywords (category_id, name, usage_count, last_used, created_at) 
 		VALUES (?, ?, 1, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
 
-	result, err := db.Conn.Exec(insertQuery, categoryID, name)
+	result, err := exec.Exec(insertQuery, categoryID, name)
 	if err != nil {
 		return 0, fmt.Errorf("키워드 생성 오류: %v", err)
 	}
@@ -131,19 +144,19 @@ func (db *DB) UpsertKeyword(categoryID int, name string) (int64, error) {
 }
 
 // CheckKeywordUsage 키워드 사용 여부 확인
-func (db *DB) CheckKeywordUsage(keywordID int) (bool, error) {
+func (db *DB) CheckKeywordUsage(ledgerID int, keywordID int) (bool, error) {
 	// 지출 데이터에서 사용 여부 확인
-	outQuery := `SELECT COUNT(*) FROM out_account_data WHERE keyword_id = ?`
+	outQuery := `SELECT COUNT(*) FROM out_account_line_items WHERE ledger_id = ? AND keyword_id = ?`
 	var outCount int
-	err := db.Conn.QueryRow(outQuery, keywordID).Scan(&outCount)
+	err := db.Conn.QueryRow(outQuery, ledgerID, keywordID).Scan(&outCount)
 	if err != nil {
 		return false, fmt.Errorf("지출 데이터에서 키워드 사용 여부 확인 오류: %v", err)
 	}
 
 	// 수입 데이터에서 사용 여부 확인
-	inQuery := `SELECT COUNT(*) FROM in_account_data WHERE keyword_id = ?`
+	inQuery := `SELECT COUNT(*) FROM in_account_data WHERE ledger_id = ? AND keyword_id = ?`
 	var inCount int
-	err = db.Conn.QueryRow(inQuery, keywordID).Scan(&inCount)
+	err = db.Conn.QueryRow(inQuery, ledgerID, keywordID).Scan(&inCount)
 	if err != nil {
 		return false, fmt.Errorf("수입 데이터에서 키워드 사용 여부 확인 오류: %v", err)
 	}
@@ -152,14 +165,14 @@ func (db *DB) CheckKeywordUsage(keywordID int) (bool, error) {
 }
 
 // DeleteKeyword 키워드 삭제 (비활성화로 변경하여 기존 가계부 정보 유지)
-func (db *DB) DeleteKeyword(id int) error {
+func (db *DB) DeleteKeyword(ledgerID int, id int) error {
 	// 비활성화로 변경하여 기존 가계부 정보 유지
 	query := `
 		UPDATE keywords 
 		SET is_active = 0, last_used = CURRENT_TIMESTAMP 
-		WHERE id = ?`
+		WHERE id = ? AND ` + keywordLedgerFilter
 
-	result, err := db.Conn.Exec(query, id)
+	result, err := db.Conn.Exec(query, id, ledgerID)
 	if err != nil {
 		return fmt.Errorf("키워드 삭제 오류: %v", err)
 	}
@@ -177,16 +190,16 @@ func (db *DB) DeleteKeyword(id int) error {
 }
 
 // GetKeywordByID ID로 키워드 조회
-func (db *DB) GetKeywordByID(id int) (*models.Keyword, error) {
+func (db *DB) GetKeywordByID(ledgerID int, id int) (*models.Keyword, error) {
 	query := `
 		SELECT id, category_id, name, usage_count, last_used, created_at
 		FROM keywords 
-		WHERE id = ?`
+		WHERE id = ? AND ` + keywordLedgerFilter
 
 	var keyword models.Keyword
 	var lastUsed, createdAt string
 
-	err := db.Conn.QueryRow(query, id).Scan(&keyword.ID, &keyword.CategoryID,
+	err := db.Conn.QueryRow(query, id, ledgerID).Scan(&keyword.ID, &keyword.CategoryID,
 		&keyword.Name, &keyword.UsageCount, &lastUsed, &createdAt)
 	if err != nil {
 		return nil, fmt.Errorf("키워드 조회 오류: %v", err)
@@ -204,16 +217,16 @@ func (db *DB) GetKeywordByID(id int) (*models.Keyword, error) {
 }
 
 // GetKeywordByName 이름으로 키워드 조회
-func (db *DB) GetKeywordByName(categoryID int, name string) (*models.Keyword, error) {
+func (db *DB) GetKeywordByName(ledgerID int, categoryID int, name string) (*models.Keyword, error) {
 	query := `
 		SELECT id, category_id, name, usage_count, last_used, created_at
 		FROM keywords 
-		WHERE category_id = ? AND name = ?`
+		WHERE category_id = ? AND name = ? AND ` + keywordLedgerFilter
 
 	var keyword models.Keyword
 	var lastUsed, createdAt string
 
-	err := db.Conn.QueryRow(query, categoryID, name).Scan(&keyword.ID, &keyword.CategoryID,
+	err := db.Conn.QueryRow(query, categoryID, name, ledgerID).Scan(&keyword.ID, &keyword.CategoryID,
 		&keyword.Name, &keyword.UsageCount, &lastUsed, &createdAt)
 	if err != nil {
 		return nil, fmt.Errorf("키워드 조회 오류: %v", err)
@@ -0,0 +1,208 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"iksoon_account_backend/utils"
+)
+
+// DefaultLedgerID 가계부 도입 이전 데이터가 속하는 기본 가계부 ID
+const DefaultLedgerID = utils.DefaultLedgerID
+
+// 가계부 및 가계부 멤버 테이블 생성
+func (db *DB) createLedgerTables(exec sqlExecutor) error {
+	exists, err := tableExists(exec, "ledgers")
+	if err != nil {
+		return fmt.Errorf("테이블 존재 여부 확인 오류: %v", err)
+	}
+
+	createLedgerTable := `
+    CREATE TABLE IF NOT EXISTS ledgers (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        name VARCHAR(100) NOT NULL,
+        created_at TEXT DEFAULT CURRENT_TIMESTAMP,
+        updated_at TEXT DEFAULT CURRENT_TIMESTAMP
+    );`
+
+	if _, err := exec.Exec(createLedgerTable); err != nil {
+		return fmt.Errorf("가계부 테이블 생성 오류: %v", err)
+	}
+
+	createLedgerMemberTable := `
+    CREATE TABLE IF NOT EXISTS ledger_members (
+        ledger_id INTEGER NOT NULL,
+        user_id INTEGER NOT NULL,
+        role VARCHAR(10) NOT NULL DEFAULT 'member' CHECK (role IN ('owner', 'member')),
+        created_at TEXT DEFAULT CURRENT_TIMESTAMP,
+        PRIMARY KEY (ledger_id, user_id),
+        FOREIGN KEY (ledger_id) REFERENCES ledgers(id) ON DELETE CASCADE,
+        FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+    );`
+
+	if _, err := exec.Exec(createLedgerMemberTable); err != nil {
+		return fmt.Errorf("가계부 멤버 테이블 생성 오류: %v", err)
+	}
+
+	if _, err := exec.Exec(`CREATE INDEX IF NOT EXISTS idx_ledger_members_user ON ledger_members(user_id)`); err != nil {
+		return fmt.Errorf("가계부 멤버 인덱스 생성 오류: %v", err)
+	}
+
+	// 테이블이 새로 생성된 경우 기본 가계부를 만들고 기존 사용자를 모두 멤버로 등록
+	if !exists {
+		if err := db.insertDefaultLedger(exec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertDefaultLedger 기본 가계부 생성 및 기존 사용자 멤버 등록 (관리자는 소유자)
+func (db *DB) insertDefaultLedger(exec sqlExecutor) error {
+	if _, err := exec.Exec(`INSERT OR IGNORE INTO ledgers (id, name) VALUES (?, '기본 가계부')`, DefaultLedgerID); err != nil {
+		return fmt.Errorf("기본 가계부 생성 오류: %v", err)
+	}
+
+	// is_admin 컬럼은 인증 테이블 생성 시 추가되므로 여기서는 기본 관리자 이름으로 판단
+	_, err := exec.Exec(`
+		INSERT OR IGNORE INTO ledger_members (ledger_id, user_id, role)
+		SELECT ?, id, CASE WHEN name = '관리자' THEN 'owner' ELSE 'member' END
+		FROM users`, DefaultLedgerID)
+	if err != nil {
+		return fmt.Errorf("기본 가계부 멤버 등록 오류: %v", err)
+	}
+	return nil
+}
+
+// seedLedgerDefaults 새 가계부에 기본 카테고리/결제수단/입금경로 삽입
+func (db *DB) seedLedgerDefaults(exec sqlExecutor, ledgerID int) error {
+	if err := db.insertDefaultCategories(exec, ledgerID); err != nil {
+		return err
+	}
+	if err := db.insertDefaultPaymentMethods(exec, ledgerID); err != nil {
+		return err
+	}
+	return db.insertDefaultDepositPaths(exec, ledgerID)
+}
+
+// ledgerRebuildTable 가계부 도입 시 고유 제약조건이 바뀌어 재생성이 필요한 테이블 정보
+type ledgerRebuildTable struct {
+	name      string
+	columns   string // 기존 테이블에서 그대로 복사할 컬럼 목록
+	createSQL string // 새 테이블 생성 SQL (%s 자리에 테이블 이름)
+}
+
+// ledgerRebuildTables 고유 제약조건에 ledger_id 가 포함되어야 하는 테이블 목록
+var ledgerRebuildTables = []ledgerRebuildTable{
+	{
+		name:    "categories",
+		columns: "id, name, type, expense_type, is_active, created_at, updated_at",
+		createSQL: `
+        CREATE TABLE %s (
+            id INTEGER PRIMARY KEY AUTOINCREMENT,
+            ledger_id INTEGER NOT NULL DEFAULT 1,
+            name VARCHAR(255) NOT NULL,
+            type VARCHAR(10) NOT NULL CHECK (type IN ('out', 'in')),
+            expense_type VARCHAR(10) DEFAULT 'variable' CHECK (expense_type IN ('fixed', 'variable')),
+            is_active BOOLEAN DEFAULT 1,
+            created_at TEXT DEFAULT CURRENT_TIMESTAMP,
+            updated_at TEXT DEFAULT CURRENT_TIMESTAMP,
+            FOREIGN KEY (ledger_id) REFERENCES ledgers(id) ON DELETE CASCADE,
+            UNIQUE(ledger_id, name, type)
+        );`,
+	},
+	{
+		name:    "payment_methods",
+		columns: "id, name, parent_id, is_active, created_at, updated_at",
+		createSQL: `
+        CREATE TABLE %s (
+            id INTEGER PRIMARY KEY AUTOINCREMENT,
+            ledger_id INTEGER NOT NULL DEFAULT 1,
+            name VARCHAR(255) NOT NULL,
+            parent_id INTEGER NULL,
+            is_active BOOLEAN DEFAULT TRUE,
+            created_at TEXT DEFAULT CURRENT_TIMESTAMP,
+            updated_at TEXT DEFAULT CURRENT_TIMESTAMP,
+            FOREIGN KEY (parent_id) REFERENCES payment_methods(id),
+            FOREIGN KEY (ledger_id) REFERENCES ledgers(id) ON DELETE CASCADE,
+            UNIQUE(ledger_id, name, parent_id)
+        );`,
+	},
+	{
+		name:    "deposit_paths",
+		columns: "id, name, is_active, created_at, updated_at",
+		createSQL: `
+        CREATE TABLE %s (
+            id INTEGER PRIMARY KEY AUTOINCREMENT,
+            ledger_id INTEGER NOT NULL DEFAULT 1,
+            name VARCHAR(255) NOT NULL,
+            is_active BOOLEAN DEFAULT TRUE,
+            created_at TEXT DEFAULT CURRENT_TIMESTAMP,
+            updated_at TEXT DEFAULT CURRENT_TIMESTAMP,
+            FOREIGN KEY (ledger_id) REFERENCES ledgers(id) ON DELETE CASCADE,
+            UNIQUE(ledger_id, name)
+        );`,
+	},
+}
+
+// ledgerColumnTables ledger_id 컬럼만 추가하면 되는 테이블 목록
+var ledgerColumnTables = []string{"out_account_data", "in_account_data", "category_budgets", "recurring_rules"}
+
+// migrateLedgerScope 가계부 도입 이전 데이터베이스를 가계부 단위 구조로 마이그레이션
+// 기존 데이터는 모두 기본 가계부(ID 1)에 속하게 됨
+func (db *DB) migrateLedgerScope(exec sqlExecutor) error {
+	for _, table := range ledgerRebuildTables {
+		exists, err := columnExists(exec, table.name, "ledger_id")
+		if err != nil {
+			return fmt.Errorf("%s.ledger_id 컬럼 확인 오류: %v", table.name, err)
+		}
+		if exists {
+			continue
+		}
+		if err := rebuildTableWithLedger(exec, table); err != nil {
+			return err
+		}
+		utils.Info("%s 테이블을 가계부 단위로 마이그레이션했습니다", table.name)
+	}
+
+	for _, table := range ledgerColumnTables {
+		if _, err := addColumnIfNotExists(exec, table, "ledger_id", "INTEGER NOT NULL DEFAULT 1"); err != nil {
+			return err
+		}
+	}
+
+	indexes := []string{
+		`CREATE INDEX IF NOT EXISTS idx_categories_ledger ON categories(ledger_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_payment_methods_ledger ON payment_methods(ledger_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_deposit_paths_ledger ON deposit_paths(ledger_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_out_account_ledger_date ON out_account_data(ledger_id, date)`,
+		`CREATE INDEX IF NOT EXISTS idx_in_account_ledger_date ON in_account_data(ledger_id, date)`,
+		`CREATE INDEX IF NOT EXISTS idx_category_budgets_ledger ON category_budgets(ledger_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_recurring_rules_ledger ON recurring_rules(ledger_id)`,
+	}
+	for _, index := range indexes {
+		if _, err := exec.Exec(index); err != nil {
+			return fmt.Errorf("가계부 인덱스 생성 오류: %v", err)
+		}
+	}
+	return nil
+}
+
+// rebuildTableWithLedger 테이블을 ledger_id 가 포함된 구조로 재생성 (기존 행은 기본 가계부로 복사)
+// SQLite 는 고유 제약조건을 변경할 수 없어 새 테이블 생성 → 복사 → 교체 순서로 진행
+// 외래키 검사를 끈 마이그레이션 트랜잭션 안에서 호출해야 함 (참조하는 테이블의 행이 삭제되지 않도록)
+func rebuildTableWithLedger(exec sqlExecutor, table ledgerRebuildTable) error {
+	newName := table.name + "_ledger_new"
+	steps := []string{
+		fmt.Sprintf(strings.TrimSpace(table.createSQL), newName),
+		fmt.Sprintf("INSERT INTO %s (ledger_id, %s) SELECT %d, %s FROM %s", newName, table.columns, DefaultLedgerID, table.columns, table.name),
+		fmt.Sprintf("DROP TABLE %s", table.name),
+		fmt.Sprintf("ALTER TABLE %s RENAME TO %s", newName, table.name),
+	}
+	for _, step := range steps {
+		if _, err := exec.Exec(step); err != nil {
+			return fmt.Errorf("%s 테이블 재생성 오류: %v", table.name, err)
+		}
+	}
+	return nil
+}
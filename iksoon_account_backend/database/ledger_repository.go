@@ -0,0 +1,292 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+)
+
+// ledgerRowNotFound 가계부 범위 확인 대상 테이블별 not found 에러
+var ledgerRowNotFound = map[string]apiErrors.ErrorCode{
+	"categories":      apiErrors.ErrCategoryNotFound,
+	"payment_methods": apiErrors.ErrPaymentMethodNotFound,
+	"deposit_paths":   apiErrors.ErrNotFound.WithMessage("입금경로를 찾을 수 없습니다"),
+	"accounts":        apiErrors.ErrBankAccountNotFound.WithMessage("계좌를 찾을 수 없습니다"),
+}
+
+// ensureLedgerRow 참조하려는 행이 같은 가계부에 속하는지 확인 (다른 가계부의 데이터면 not found)
+func ensureLedgerRow(exec sqlExecutor, table string, id, ledgerID int) error {
+	notFound, ok := ledgerRowNotFound[table]
+	if !ok {
+		return fmt.Errorf("가계부 범위 확인을 지원하지 않는 테이블입니다: %s", table)
+	}
+
+	var exists int
+	err := exec.QueryRow(fmt.Sprintf("SELECT 1 FROM %s WHERE id = ? AND ledger_id = ?", table), id, ledgerID).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return notFound
+	}
+	if err != nil {
+		return fmt.Errorf("%s 가계부 확인 오류: %v", table, err)
+	}
+	return nil
+}
+
+// ledgerSelectQuery 가계부 조회 공통 쿼리 (멤버 수 포함)
+const ledgerSelectQuery = `
+	SELECT l.id, l.name, l.created_at, l.updated_at,
+		(SELECT COUNT(*) FROM ledger_members lm WHERE lm.ledger_id = l.id)
+	FROM ledgers l`
+
+// scanLedger 가계부 행 스캔
+func scanLedger(scanner interface{ Scan(...interface{}) error }, withRole bool) (models.Ledger, error) {
+	var ledger models.Ledger
+	var createdAt, updatedAt string
+	dest := []interface{}{&ledger.ID, &ledger.Name, &createdAt, &updatedAt, &ledger.MemberCount}
+	if withRole {
+		dest = append(dest, &ledger.Role)
+	}
+	if err := scanner.Scan(dest...); err != nil {
+		return ledger, err
+	}
+	ledger.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
+	ledger.UpdatedAt, _ = time.Parse("2006-01-02 15:04:05", updatedAt)
+	return ledger, nil
+}
+
+// GetLedgers 전체 가계부 목록 조회 (인증 비활성화 시 사용)
+func (db *DB) GetLedgers() ([]models.Ledger, error) {
+	rows, err := db.Conn.Query(ledgerSelectQuery + ` ORDER BY l.id ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("가계부 목록 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	ledgers := []models.Ledger{}
+	for rows.Next() {
+		ledger, err := scanLedger(rows, false)
+		if err != nil {
+			return nil, fmt.Errorf("가계부 스캔 오류: %v", err)
+		}
+		ledgers = append(ledgers, ledger)
+	}
+	return ledgers, rows.Err()
+}
+
+// GetLedgersForUser 사용자가 속한 가계부 목록 조회 (사용자의 역할 포함)
+func (db *DB) GetLedgersForUser(userID int) ([]models.Ledger, error) {
+	query := `
+	SELECT l.id, l.name, l.created_at, l.updated_at,
+		(SELECT COUNT(*) FROM ledger_members m WHERE m.ledger_id = l.id),
+		lm.role
+	FROM ledgers l
+	JOIN ledger_members lm ON lm.ledger_id = l.id
+	WHERE lm.user_id = ?
+	ORDER BY l.id ASC`
+
+	rows, err := db.Conn.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("사용자 가계부 목록 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	ledgers := []models.Ledger{}
+	for rows.Next() {
+		ledger, err := scanLedger(rows, true)
+		if err != nil {
+			return nil, fmt.Errorf("가계부 스캔 오류: %v", err)
+		}
+		ledgers = append(ledgers, ledger)
+	}
+	return ledgers, rows.Err()
+}
+
+// GetLedgerByID ID로 가계부 조회
+func (db *DB) GetLedgerByID(id int) (*models.Ledger, error) {
+	ledger, err := scanLedger(db.Conn.QueryRow(ledgerSelectQuery+` WHERE l.id = ?`, id), false)
+	if err == sql.ErrNoRows {
+		return nil, apiErrors.ErrLedgerNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("가계부 조회 오류: %v", err)
+	}
+	return &ledger, nil
+}
+
+// GetDefaultLedgerForUser 사용자의 기본 가계부 ID 조회 (가장 먼저 가입한 가계부, 없으면 ErrLedgerNotFound)
+func (db *DB) GetDefaultLedgerForUser(userID int) (int, error) {
+	var ledgerID int
+	err := db.Conn.QueryRow(`SELECT ledger_id FROM ledger_members WHERE user_id = ? ORDER BY ledger_id ASC LIMIT 1`, userID).Scan(&ledgerID)
+	if err == sql.ErrNoRows {
+		return 0, apiErrors.ErrLedgerNotFound.WithMessage("소속된 가계부가 없습니다")
+	}
+	if err != nil {
+		return 0, fmt.Errorf("기본 가계부 조회 오류: %v", err)
+	}
+	return ledgerID, nil
+}
+
+// GetLedgerRole 가계부에서 사용자의 역할 조회 (멤버가 아니면 빈 문자열)
+func (db *DB) GetLedgerRole(ledgerID, userID int) (string, error) {
+	var role string
+	err := db.Conn.QueryRow(`SELECT role FROM ledger_members WHERE ledger_id = ? AND user_id = ?`, ledgerID, userID).Scan(&role)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("가계부 멤버 역할 조회 오류: %v", err)
+	}
+	return role, nil
+}
+
+// CreateLedger 가계부 생성 (생성자는 소유자로 등록되고 기본 카테고리/결제수단/입금경로가 함께 생성됨)
+func (db *DB) CreateLedger(name string, ownerUserID *int) (int64, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return 0, apiErrors.ErrInvalidLedgerData.WithMessage("가계부 이름은 필수입니다")
+	}
+
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`INSERT INTO ledgers (name, created_at, updated_at) VALUES (?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`, name)
+	if err != nil {
+		return 0, fmt.Errorf("가계부 생성 오류: %v", err)
+	}
+	ledgerID, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("가계부 ID 조회 오류: %v", err)
+	}
+
+	if ownerUserID != nil {
+		if err := addLedgerMember(tx, int(ledgerID), *ownerUserID, models.LedgerRoleOwner); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := db.seedLedgerDefaults(tx, int(ledgerID)); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("트랜잭션 커밋 오류: %v", err)
+	}
+	return ledgerID, nil
+}
+
+// UpdateLedger 가계부 이름 수정
+func (db *DB) UpdateLedger(id int, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return apiErrors.ErrInvalidLedgerData.WithMessage("가계부 이름은 필수입니다")
+	}
+
+	result, err := db.Conn.Exec(`UPDATE ledgers SET name = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`, name, id)
+	if err != nil {
+		return fmt.Errorf("가계부 수정 오류: %v", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return apiErrors.ErrLedgerNotFound
+	}
+	return nil
+}
+
+// GetLedgerMembers 가계부 멤버 목록 조회
+func (db *DB) GetLedgerMembers(ledgerID int) ([]models.LedgerMember, error) {
+	query := `
+	SELECT lm.ledger_id, lm.user_id, u.name, lm.role, lm.created_at
+	FROM ledger_members lm
+	JOIN users u ON u.id = lm.user_id
+	WHERE lm.ledger_id = ? AND u.is_active = 1
+	ORDER BY lm.role DESC, u.name ASC`
+
+	rows, err := db.Conn.Query(query, ledgerID)
+	if err != nil {
+		return nil, fmt.Errorf("가계부 멤버 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	members := []models.LedgerMember{}
+	for rows.Next() {
+		var member models.LedgerMember
+		var createdAt string
+		if err := rows.Scan(&member.LedgerID, &member.UserID, &member.UserName, &member.Role, &createdAt); err != nil {
+			return nil, fmt.Errorf("가계부 멤버 스캔 오류: %v", err)
+		}
+		member.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAt)
+		members = append(members, member)
+	}
+	return members, rows.Err()
+}
+
+// AddLedgerMember 가계부 멤버 추가 (이미 멤버면 역할만 변경)
+func (db *DB) AddLedgerMember(ledgerID, userID int, role string) error {
+	if _, err := db.GetLedgerByID(ledgerID); err != nil {
+		return err
+	}
+
+	var exists int
+	err := db.Conn.QueryRow(`SELECT 1 FROM users WHERE id = ? AND is_active = 1`, userID).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return apiErrors.ErrUserNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("사용자 조회 오류: %v", err)
+	}
+
+	return addLedgerMember(db.Conn, ledgerID, userID, role)
+}
+
+// addLedgerMember 가계부 멤버 추가/역할 변경 헬퍼 (트랜잭션 공용)
+func addLedgerMember(exec sqlExecutor, ledgerID, userID int, role string) error {
+	if role == "" {
+		role = models.LedgerRoleMember
+	}
+	if role != models.LedgerRoleOwner && role != models.LedgerRoleMember {
+		return apiErrors.ErrInvalidLedgerData.WithMessage("멤버 역할은 'owner' 또는 'member'여야 합니다")
+	}
+
+	_, err := exec.Exec(`
+		INSERT INTO ledger_members (ledger_id, user_id, role, created_at)
+		VALUES (?, ?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT(ledger_id, user_id) DO UPDATE SET role = excluded.role`,
+		ledgerID, userID, role)
+	if err != nil {
+		return fmt.Errorf("가계부 멤버 추가 오류: %v", err)
+	}
+	return nil
+}
+
+// RemoveLedgerMember 가계부 멤버 제거 (마지막 소유자는 제거할 수 없음)
+func (db *DB) RemoveLedgerMember(ledgerID, userID int) error {
+	role, err := db.GetLedgerRole(ledgerID, userID)
+	if err != nil {
+		return err
+	}
+	if role == "" {
+		return apiErrors.ErrNotFound.WithMessage("가계부 멤버를 찾을 수 없습니다")
+	}
+
+	if role == models.LedgerRoleOwner {
+		var owners int
+		err := db.Conn.QueryRow(`SELECT COUNT(*) FROM ledger_members WHERE ledger_id = ? AND role = ?`, ledgerID, models.LedgerRoleOwner).Scan(&owners)
+		if err != nil {
+			return fmt.Errorf("가계부 소유자 수 조회 오류: %v", err)
+		}
+		if owners <= 1 {
+			return apiErrors.ErrInvalidLedgerData.WithMessage("마지막 소유자는 가계부에서 제거할 수 없습니다")
+		}
+	}
+
+	if _, err := db.Conn.Exec(`DELETE FROM ledger_members WHERE ledger_id = ? AND user_id = ?`, ledgerID, userID); err != nil {
+		return fmt.Errorf("가계부 멤버 제거 오류: %v", err)
+	}
+	return nil
+}
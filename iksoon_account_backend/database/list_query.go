@@ -0,0 +1,341 @@
+package database
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// outAccountListQuery 지출 목록 조회 공통 SELECT/JOIN (WHERE 절은 호출하는 쪽에서 추가)
+const outAccountListQuery = `
+    SELECT oa.uuid, oa.date, oa.user, oa.money, oa.category_id, oa.keyword_id, oa.payment_method_id, oa.memo, oa.installment_id, oa.installment_seq, oa.created_at, oa.updated_at,
+           c.name as category_name,
+           COALESCE(k.name, '') as keyword_name,
+           pm.name as payment_method_name`
+
+// outAccountListFrom 지출 목록 조회 공통 FROM 절 (건수 조회에도 사용)
+const outAccountListFrom = `
+    FROM out_account_data oa
+    LEFT JOIN categories c ON oa.category_id = c.id
+    LEFT JOIN keywords k ON oa.keyword_id = k.id
+    LEFT JOIN payment_methods pm ON oa.payment_method_id = pm.id`
+
+// inAccountListQuery 수입 목록 조회 공통 SELECT/JOIN (WHERE 절은 호출하는 쪽에서 추가)
+const inAccountListQuery = `
+    SELECT ia.uuid, ia.date, ia.user, ia.money, ia.category_id, ia.keyword_id, ia.deposit_path_id, ia.memo, ia.created_at, ia.updated_at,
+           c.name as category_name,
+           COALESCE(k.name, '') as keyword_name,
+           dp.name as deposit_path_name`
+
+// inAccountListFrom 수입 목록 조회 공통 FROM 절 (건수 조회에도 사용)
+const inAccountListFrom = `
+    FROM in_account_data ia
+    LEFT JOIN categories c ON ia.category_id = c.id
+    LEFT JOIN keywords k ON ia.keyword_id = k.id
+    LEFT JOIN deposit_paths dp ON ia.deposit_path_id = dp.id`
+
+// listCursor 커서에 담는 마지막 행의 정렬 값 (정렬 기준/방향이 바뀐 커서는 거부)
+type listCursor struct {
+	Sort  string `json:"s"`
+	Order string `json:"o"`
+	Value string `json:"v"`
+	UUID  string `json:"u"`
+}
+
+// listRowKey 커서를 만들 때 필요한 행의 정렬 값
+type listRowKey struct {
+	uuid      string
+	date      string
+	money     int
+	createdAt string
+}
+
+// normalizeListOptions 정렬 기준/방향 기본값을 채우고 목록 조회 옵션 검증
+func normalizeListOptions(opts *models.ListOptions) error {
+	opts.Sort = strings.ToLower(strings.TrimSpace(opts.Sort))
+	if opts.Sort == "" {
+		opts.Sort = models.ListSortDate
+	}
+	if opts.Sort != models.ListSortDate && opts.Sort != models.ListSortMoney && opts.Sort != models.ListSortCreatedAt {
+		return apiErrors.ErrInvalidListOption.WithMessage("sort는 date, money, created_at 중 하나여야 합니다")
+	}
+
+	opts.Order = strings.ToLower(strings.TrimSpace(opts.Order))
+	if opts.Order == "" {
+		opts.Order = models.ListOrderDesc
+	}
+	if opts.Order != models.ListOrderAsc && opts.Order != models.ListOrderDesc {
+		return apiErrors.ErrInvalidListOption.WithMessage("order는 asc 또는 desc여야 합니다")
+	}
+
+	if opts.Limit < 0 || opts.Limit > models.MaxListLimit {
+		return apiErrors.ErrInvalidListOption.WithMessage(fmt.Sprintf("limit은 1 이상 %d 이하여야 합니다", models.MaxListLimit))
+	}
+	if opts.Cursor != "" && opts.Limit == 0 {
+		opts.Limit = models.DefaultListLimit
+	}
+	if opts.MinAmount != nil && opts.MaxAmount != nil && *opts.MinAmount > *opts.MaxAmount {
+		return apiErrors.ErrInvalidListOption.WithMessage("min_amount는 max_amount보다 클 수 없습니다")
+	}
+	return nil
+}
+
+// listFilterClause 금액/카테고리/결제수단/입금경로/사용자/태그 필터 조건 (alias 는 거래 테이블 별칭)
+func listFilterClause(alias, accountType string, opts models.ListOptions) (string, []interface{}) {
+	clause, args := tagFilterClause(alias+".uuid", accountType, opts.TagID)
+
+	if opts.MinAmount != nil {
+		clause += fmt.Sprintf(` AND %s.money >= ?`, alias)
+		args = append(args, *opts.MinAmount)
+	}
+	if opts.MaxAmount != nil {
+		clause += fmt.Sprintf(` AND %s.money <= ?`, alias)
+		args = append(args, *opts.MaxAmount)
+	}
+	if opts.CategoryID != nil {
+		clause += fmt.Sprintf(` AND %s.category_id = ?`, alias)
+		args = append(args, *opts.CategoryID)
+	}
+	if opts.PaymentMethodID != nil && accountType == models.AccountTypeOut {
+		clause += fmt.Sprintf(` AND %s.payment_method_id = ?`, alias)
+		args = append(args, *opts.PaymentMethodID)
+	}
+	if opts.DepositPathID != nil && accountType == models.AccountTypeIn {
+		clause += fmt.Sprintf(` AND %s.deposit_path_id = ?`, alias)
+		args = append(args, *opts.DepositPathID)
+	}
+	if opts.User != "" {
+		clause += fmt.Sprintf(` AND %s.user = ?`, alias)
+		args = append(args, opts.User)
+	}
+	return clause, args
+}
+
+// listPageClause 커서 조건과 ORDER BY / LIMIT 절
+// 같은 정렬 값끼리는 uuid 로 순서를 고정하고, 다음 페이지가 있는지 알 수 있도록 limit 보다 한 건 더 조회
+func listPageClause(alias string, opts models.ListOptions) (string, []interface{}, error) {
+	column := alias + "." + opts.Sort
+	direction, compare := "DESC", "<"
+	if opts.Order == models.ListOrderAsc {
+		direction, compare = "ASC", ">"
+	}
+
+	clause := ""
+	var args []interface{}
+	if opts.Cursor != "" {
+		cursor, err := decodeListCursor(opts)
+		if err != nil {
+			return "", nil, err
+		}
+		var value interface{} = cursor.Value
+		if opts.Sort == models.ListSortMoney {
+			money, err := strconv.Atoi(cursor.Value)
+			if err != nil {
+				return "", nil, apiErrors.ErrInvalidCursor
+			}
+			value = money
+		}
+		clause = fmt.Sprintf(` AND (%s %s ? OR (%s = ? AND %s.uuid %s ?))`, column, compare, column, alias, compare)
+		args = append(args, value, value, cursor.UUID)
+	}
+
+	clause += fmt.Sprintf(`
+    ORDER BY %s %s, %s.uuid %s`, column, direction, alias, direction)
+	if opts.Paginated() {
+		clause += ` LIMIT ?`
+		args = append(args, opts.Limit+1)
+	}
+	return clause, args, nil
+}
+
+// encodeListCursor 행의 정렬 값으로 다음 페이지 커서 생성
+func encodeListCursor(opts models.ListOptions, key listRowKey) string {
+	cursor := listCursor{Sort: opts.Sort, Order: opts.Order, UUID: key.uuid}
+	switch opts.Sort {
+	case models.ListSortMoney:
+		cursor.Value = strconv.Itoa(key.money)
+	case models.ListSortCreatedAt:
+		cursor.Value = key.createdAt
+	default:
+		cursor.Value = key.date
+	}
+
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeListCursor 커서 해석 (다른 정렬 기준/방향으로 만든 커서면 오류)
+func decodeListCursor(opts models.ListOptions) (*listCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(opts.Cursor)
+	if err != nil {
+		return nil, apiErrors.ErrInvalidCursor
+	}
+
+	var cursor listCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.UUID == "" {
+		return nil, apiErrors.ErrInvalidCursor
+	}
+	if cursor.Sort != opts.Sort || cursor.Order != opts.Order {
+		return nil, apiErrors.ErrInvalidCursor.WithMessage("커서를 만든 정렬 기준과 현재 정렬 기준이 다릅니다")
+	}
+	return &cursor, nil
+}
+
+// buildListPage 한 건 더 조회한 결과로 다음 페이지 여부와 커서 계산 (반환값은 응답에 포함할 건수)
+func buildListPage(opts models.ListOptions, fetched int, keyAt func(i int) listRowKey) (int, models.PageInfo) {
+	info := models.PageInfo{Limit: opts.Limit, TotalCount: fetched}
+	if !opts.Paginated() || fetched <= opts.Limit {
+		return fetched, info
+	}
+
+	info.HasMore = true
+	info.NextCursor = encodeListCursor(opts, keyAt(opts.Limit-1))
+	return opts.Limit, info
+}
+
+// countListRows 필터 조건에 맞는 전체 건수 조회 (커서 조건은 제외)
+func countListRows(exec sqlExecutor, from, where string, args []interface{}) (int, error) {
+	var count int
+	if err := exec.QueryRow(`SELECT COUNT(*)`+from+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("목록 건수 조회 오류: %v", err)
+	}
+	return count, nil
+}
+
+// queryOutAccountList 지출 목록 조회 공통 처리 (where 는 기본 조건, action 은 로그에 남길 작업 이름)
+// opts 의 필터와 정렬을 적용하고, limit 이 있으면 커서 기준으로 한 페이지만 조회
+func (db *DB) queryOutAccountList(ledgerID int, where string, args []interface{}, opts models.ListOptions, action string) ([]models.OutAccount, models.PageInfo, error) {
+	if err := normalizeListOptions(&opts); err != nil {
+		return nil, models.PageInfo{}, err
+	}
+
+	filter, filterArgs := listFilterClause("oa", models.AccountTypeOut, opts)
+	where = `
+    WHERE ` + where + filter
+	args = append(append([]interface{}{}, args...), filterArgs...)
+
+	page, pageArgs, err := listPageClause("oa", opts)
+	if err != nil {
+		return nil, models.PageInfo{}, err
+	}
+
+	rows, err := db.Conn.Query(outAccountListQuery+outAccountListFrom+where+page, append(append([]interface{}{}, args...), pageArgs...)...)
+	if err != nil {
+		utils.LogError(action, err)
+		return nil, models.PageInfo{}, fmt.Errorf("%s 오류: %v", action, err)
+	}
+	defer rows.Close()
+
+	accounts := []models.OutAccount{}
+	for rows.Next() {
+		var account models.OutAccount
+		var keywordID *int
+
+		err := rows.Scan(
+			&account.UUID, &account.Date, &account.User, &account.Money, &account.CategoryID,
+			&keywordID, &account.PaymentMethodID, &account.Memo, &account.InstallmentID, &account.InstallmentSeq, &account.CreatedAt, &account.UpdatedAt,
+			&account.CategoryName, &account.KeywordName, &account.PaymentMethodName,
+		)
+		if err != nil {
+			utils.LogError(action+" 스캔", err)
+			continue
+		}
+
+		account.KeywordID = keywordID
+		accounts = append(accounts, account)
+	}
+	rows.Close()
+
+	size, info := buildListPage(opts, len(accounts), func(i int) listRowKey {
+		return listRowKey{uuid: accounts[i].UUID, date: accounts[i].Date, money: accounts[i].Money, createdAt: accounts[i].CreatedAt}
+	})
+	accounts = accounts[:size]
+	if opts.Paginated() {
+		if info.TotalCount, err = countListRows(db.Conn, outAccountListFrom, where, args); err != nil {
+			return nil, models.PageInfo{}, err
+		}
+	}
+
+	if err := attachOutAccountSplits(db.Conn, ledgerID, accounts); err != nil {
+		return nil, models.PageInfo{}, err
+	}
+	if err := attachOutAccountTags(db.Conn, accounts); err != nil {
+		return nil, models.PageInfo{}, err
+	}
+	if err := attachOutAccountCurrencies(db.Conn, accounts); err != nil {
+		return nil, models.PageInfo{}, err
+	}
+	if err := attachReimbursementStatus(db.Conn, accounts); err != nil {
+		return nil, models.PageInfo{}, err
+	}
+	return accounts, info, nil
+}
+
+// queryInAccountList 수입 목록 조회 공통 처리 (where 는 기본 조건, action 은 로그에 남길 작업 이름)
+// opts 의 필터와 정렬을 적용하고, limit 이 있으면 커서 기준으로 한 페이지만 조회
+func (db *DB) queryInAccountList(where string, args []interface{}, opts models.ListOptions, action string) ([]models.InAccount, models.PageInfo, error) {
+	if err := normalizeListOptions(&opts); err != nil {
+		return nil, models.PageInfo{}, err
+	}
+
+	filter, filterArgs := listFilterClause("ia", models.AccountTypeIn, opts)
+	where = `
+    WHERE ` + where + filter
+	args = append(append([]interface{}{}, args...), filterArgs...)
+
+	page, pageArgs, err := listPageClause("ia", opts)
+	if err != nil {
+		return nil, models.PageInfo{}, err
+	}
+
+	rows, err := db.Conn.Query(inAccountListQuery+inAccountListFrom+where+page, append(append([]interface{}{}, args...), pageArgs...)...)
+	if err != nil {
+		utils.LogError(action, err)
+		return nil, models.PageInfo{}, fmt.Errorf("%s 오류: %v", action, err)
+	}
+	defer rows.Close()
+
+	accounts := []models.InAccount{}
+	for rows.Next() {
+		var account models.InAccount
+		var keywordID *int
+
+		err := rows.Scan(
+			&account.UUID, &account.Date, &account.User, &account.Money, &account.CategoryID,
+			&keywordID, &account.DepositPathID, &account.Memo, &account.CreatedAt, &account.UpdatedAt,
+			&account.CategoryName, &account.KeywordName, &account.DepositPathName,
+		)
+		if err != nil {
+			utils.LogError(action+" 스캔", err)
+			continue
+		}
+
+		account.KeywordID = keywordID
+		accounts = append(accounts, account)
+	}
+	rows.Close()
+
+	size, info := buildListPage(opts, len(accounts), func(i int) listRowKey {
+		return listRowKey{uuid: accounts[i].UUID, date: accounts[i].Date, money: accounts[i].Money, createdAt: accounts[i].CreatedAt}
+	})
+	accounts = accounts[:size]
+	if opts.Paginated() {
+		if info.TotalCount, err = countListRows(db.Conn, inAccountListFrom, where, args); err != nil {
+			return nil, models.PageInfo{}, err
+		}
+	}
+
+	if err := attachInAccountTags(db.Conn, accounts); err != nil {
+		return nil, models.PageInfo{}, err
+	}
+	if err := attachInAccountCurrencies(db.Conn, accounts); err != nil {
+		return nil, models.PageInfo{}, err
+	}
+	return accounts, info, nil
+}
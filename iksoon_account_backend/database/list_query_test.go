@@ -0,0 +1,72 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+
+	"iksoon_account_backend/models"
+)
+
+func TestOutAccountListCursorPagination(t *testing.T) {
+	db := newTestDB(t)
+	categoryID := createTestCategory(t, db, "테스트 식비", "out")
+	methodID := createTestPaymentMethod(t, db, "테스트 현금")
+
+	// 같은 날짜/금액이 여러 건이어도 페이지 경계에서 빠지거나 겹치지 않아야 함
+	for _, row := range []struct {
+		date  string
+		money int
+	}{
+		{"2024-03-01", 5000}, {"2024-03-01", 5000}, {"2024-03-01", 12000},
+		{"2024-03-02", 5000}, {"2024-03-02", 8000}, {"2024-03-03", 12000},
+		{"2024-03-03", 12000}, {"2024-03-04", 1000},
+	} {
+		insertTestOutAccount(t, db, row.date, row.money, categoryID, methodID)
+	}
+
+	for _, sort := range []string{models.ListSortDate, models.ListSortMoney, models.ListSortCreatedAt} {
+		for _, order := range []string{models.ListOrderAsc, models.ListOrderDesc} {
+			opts := models.ListOptions{Sort: sort, Order: order}
+			all, _, err := db.GetOutAccountsByDateRange(DefaultLedgerID, "2024-03-01", "2024-03-31", opts)
+			if err != nil {
+				t.Fatalf("%s %s 전체 조회 error = %v", sort, order, err)
+			}
+			if len(all) != 8 {
+				t.Fatalf("%s %s 전체 조회 건수 = %d, want 8", sort, order, len(all))
+			}
+
+			var paged []string
+			opts.Limit = 3
+			for page := 0; ; page++ {
+				if page > len(all) {
+					t.Fatalf("%s %s 페이지가 끝나지 않음", sort, order)
+				}
+				accounts, info, err := db.GetOutAccountsByDateRange(DefaultLedgerID, "2024-03-01", "2024-03-31", opts)
+				if err != nil {
+					t.Fatalf("%s %s %d페이지 error = %v", sort, order, page+1, err)
+				}
+				if info.TotalCount != 8 {
+					t.Errorf("%s %s %d페이지 total_count = %d, want 8", sort, order, page+1, info.TotalCount)
+				}
+				for _, account := range accounts {
+					paged = append(paged, account.UUID)
+				}
+				if !info.HasMore {
+					if info.NextCursor != "" {
+						t.Errorf("%s %s 마지막 페이지에 커서가 있음", sort, order)
+					}
+					break
+				}
+				opts.Cursor = info.NextCursor
+			}
+
+			var want []string
+			for _, account := range all {
+				want = append(want, account.UUID)
+			}
+			if !reflect.DeepEqual(paged, want) {
+				t.Errorf("%s %s 페이지 순서 = %v, want %v", sort, order, paged, want)
+			}
+		}
+	}
+}
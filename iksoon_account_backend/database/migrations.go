@@ -0,0 +1,332 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// schemaMigration 번호가 매겨진 스키마 마이그레이션
+// up 은 기존 배포 DB(마이그레이션 이력 없이 만들어진 DB)에서도 안전하도록 멱등적으로 작성
+// down 이 nil 이면 되돌릴 수 없는 마이그레이션
+type schemaMigration struct {
+	version int
+	name    string
+	up      func(exec sqlExecutor) error
+	down    func(exec sqlExecutor) error
+}
+
+// schemaMigrations 전체 마이그레이션 목록 (버전 오름차순, 이미 배포된 항목은 수정하지 말고 새 버전을 추가)
+func (db *DB) schemaMigrations() []schemaMigration {
+	return []schemaMigration{
+		{version: 1, name: "create_core_tables", up: db.createCoreTables},
+		{version: 2, name: "add_is_active_columns", up: addIsActiveColumns},
+		{version: 3, name: "create_recurring_tables", up: db.createRecurringTables, down: dropTables("recurring_occurrences", "recurring_rules")},
+		{version: 4, name: "add_auth", up: db.createAuthTables, down: dropAuthTables},
+		{version: 5, name: "scope_by_ledger", up: db.migrateLedgerScope},
+		{version: 6, name: "create_import_profiles", up: db.createImportProfileTable, down: dropTables("import_profiles")},
+		{version: 7, name: "create_accounts", up: createAccountTables, down: dropAccountTables},
+		{version: 8, name: "create_transfers", up: createTransferTable, down: dropTables("transfers")},
+		{version: 9, name: "add_card_billing_cycles", up: addCardBillingColumns, down: dropCardBillingColumns},
+		{version: 10, name: "create_installments", up: createInstallmentTables, down: dropInstallmentTables},
+		{version: 11, name: "create_out_account_splits", up: createSplitTables, down: dropSplitTables},
+		{version: 12, name: "create_tags", up: createTagTables, down: dropTables("transaction_tags", "tags")},
+		{version: 13, name: "create_attachments", up: createAttachmentTable, down: dropTables("attachments")},
+		{version: 14, name: "create_reimbursements", up: createReimbursementTable, down: dropReimbursementTables},
+		{version: 15, name: "create_settlements", up: createSettlementTables, down: dropTables("settlement_transfers", "settlements", "sharing_rule_shares", "sharing_rules")},
+		{version: 16, name: "create_exchange_rates", up: createCurrencyTables, down: dropTables("transaction_currencies", "exchange_rates")},
+		{version: 17, name: "create_savings_goals", up: createSavingsGoalTable, down: dropTables("savings_goals")},
+		{version: 18, name: "create_budget_periods", up: createBudgetPeriodTable, down: dropTables("category_budget_periods")},
+		{version: 19, name: "add_budget_rollover", up: addBudgetRolloverColumns, down: dropBudgetRolloverColumns},
+		{version: 20, name: "create_notifications", up: createNotificationTables, down: dropTables("notification_deliveries", "budget_alert_events", "budget_alert_thresholds", "notification_channels")},
+		{version: 21, name: "create_categorization_rules", up: createCategorizationRuleTable, down: dropTables("categorization_rules")},
+	}
+}
+
+// createCoreTables 기본 테이블 생성 (테이블 생성 순서 중요 - 외래키 제약조건 때문에)
+func (db *DB) createCoreTables(exec sqlExecutor) error {
+	steps := []func(exec sqlExecutor) error{
+		db.createUserTable,
+		db.createLedgerTables,
+		db.createCategoryTable,
+		db.createKeywordTable,
+		db.createPaymentMethodTable,
+		db.createDepositPathTable,
+		db.createOutAccountTable,
+		db.createInAccountTable,
+		db.createCategoryBudgetTable,
+	}
+	for _, step := range steps {
+		if err := step(exec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addIsActiveColumns is_active 컬럼 도입 이전에 만들어진 카테고리/키워드 테이블에 컬럼 추가
+func addIsActiveColumns(exec sqlExecutor) error {
+	if _, err := addColumnIfNotExists(exec, "categories", "is_active", "BOOLEAN DEFAULT 1"); err != nil {
+		return err
+	}
+	_, err := addColumnIfNotExists(exec, "keywords", "is_active", "BOOLEAN DEFAULT 1")
+	return err
+}
+
+// dropAuthTables 세션 테이블과 사용자 인증 컬럼 제거
+func dropAuthTables(exec sqlExecutor) error {
+	steps := []string{
+		`DROP TABLE IF EXISTS sessions`,
+		`ALTER TABLE users DROP COLUMN password_hash`,
+		`ALTER TABLE users DROP COLUMN is_admin`,
+	}
+	for _, step := range steps {
+		if _, err := exec.Exec(step); err != nil {
+			return fmt.Errorf("인증 테이블 제거 오류: %v", err)
+		}
+	}
+	return nil
+}
+
+// dropTables 테이블을 순서대로 삭제하는 down 마이그레이션 생성
+func dropTables(tables ...string) func(exec sqlExecutor) error {
+	return func(exec sqlExecutor) error {
+		for _, table := range tables {
+			if _, err := exec.Exec("DROP TABLE IF EXISTS " + table); err != nil {
+				return fmt.Errorf("%s 테이블 삭제 오류: %v", table, err)
+			}
+		}
+		return nil
+	}
+}
+
+// createMigrationTable 마이그레이션 이력 테이블 생성
+func (db *DB) createMigrationTable() error {
+	createMigrationTable := `
+    CREATE TABLE IF NOT EXISTS schema_migrations (
+        version INTEGER PRIMARY KEY,
+        name VARCHAR(100) NOT NULL,
+        applied_at TEXT NOT NULL
+    );`
+
+	if _, err := db.Conn.Exec(createMigrationTable); err != nil {
+		return fmt.Errorf("마이그레이션 이력 테이블 생성 오류: %v", err)
+	}
+	return nil
+}
+
+// appliedMigrations 적용된 마이그레이션 목록 조회 (버전 → 적용 시각)
+func (db *DB) appliedMigrations() (map[int]models.MigrationStatus, error) {
+	if err := db.createMigrationTable(); err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Conn.Query(`SELECT version, name, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("마이그레이션 이력 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]models.MigrationStatus)
+	for rows.Next() {
+		status := models.MigrationStatus{Applied: true}
+		if err := rows.Scan(&status.Version, &status.Name, &status.AppliedAt); err != nil {
+			return nil, fmt.Errorf("마이그레이션 이력 읽기 오류: %v", err)
+		}
+		applied[status.Version] = status
+	}
+	return applied, rows.Err()
+}
+
+// MigrationStatus 전체 마이그레이션의 적용 상태 조회 (버전 오름차순)
+func (db *DB) MigrationStatus() ([]models.MigrationStatus, error) {
+	applied, err := db.appliedMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []models.MigrationStatus
+	for _, m := range db.schemaMigrations() {
+		status := models.MigrationStatus{Version: m.version, Name: m.name, Reversible: m.down != nil}
+		if record, ok := applied[m.version]; ok {
+			status.Applied = true
+			status.AppliedAt = record.AppliedAt
+			delete(applied, m.version)
+		}
+		statuses = append(statuses, status)
+	}
+
+	// 더 최신 버전의 서버가 적용한 마이그레이션
+	for _, record := range applied {
+		record.Unknown = true
+		statuses = append(statuses, record)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+
+	return statuses, nil
+}
+
+// Migrate 적용되지 않은 마이그레이션을 버전 순서대로 적용 (적용한 개수 반환)
+// 각 마이그레이션은 이력 기록과 함께 하나의 트랜잭션으로 실행되며, 실패하면 해당 마이그레이션 전체가 취소되고 오류를 반환
+func (db *DB) Migrate() (int, error) {
+	applied, err := db.appliedMigrations()
+	if err != nil {
+		return 0, err
+	}
+
+	migrations := db.schemaMigrations()
+	latest := migrations[len(migrations)-1].version
+	for version, record := range applied {
+		if version > latest {
+			utils.Warning("현재 서버가 알지 못하는 마이그레이션이 적용되어 있습니다: %d_%s (더 최신 버전에서 적용됨)", version, record.Name)
+		}
+	}
+
+	if len(applied) == 0 {
+		if existing, err := tableExists(db.Conn, "users"); err == nil && existing {
+			utils.Info("마이그레이션 이력이 없는 기존 데이터베이스입니다. 현재 스키마를 확인하며 이력을 기록합니다")
+		}
+	}
+
+	count := 0
+	for _, m := range migrations {
+		if _, ok := applied[m.version]; ok {
+			continue
+		}
+		if err := db.runMigration(m, true); err != nil {
+			return count, err
+		}
+		utils.Info("마이그레이션 적용: %d_%s", m.version, m.name)
+		count++
+	}
+	return count, nil
+}
+
+// RollbackMigrations 최근 적용된 마이그레이션부터 steps 개를 되돌림 (되돌린 목록 반환)
+// 대상 중 되돌릴 수 없는 마이그레이션이 있으면 아무것도 되돌리지 않고 오류 반환
+func (db *DB) RollbackMigrations(steps int) ([]models.MigrationStatus, error) {
+	applied, err := db.appliedMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[int]schemaMigration)
+	for _, m := range db.schemaMigrations() {
+		known[m.version] = m
+	}
+
+	versions := make([]int, 0, len(applied))
+	for version := range applied {
+		versions = append(versions, version)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(versions)))
+
+	// 하나라도 되돌릴 수 없으면 아무것도 되돌리지 않도록 대상부터 확인
+	if len(versions) > steps {
+		versions = versions[:steps]
+	}
+	targets := make([]schemaMigration, 0, len(versions))
+	for _, version := range versions {
+		m, ok := known[version]
+		if !ok {
+			return nil, fmt.Errorf("마이그레이션 %d_%s 는 현재 버전에 정의되어 있지 않아 되돌릴 수 없습니다", version, applied[version].Name)
+		}
+		if m.down == nil {
+			return nil, fmt.Errorf("마이그레이션 %d_%s 는 되돌릴 수 없습니다", m.version, m.name)
+		}
+		targets = append(targets, m)
+	}
+
+	var rolledBack []models.MigrationStatus
+	for _, m := range targets {
+		if err := db.runMigration(m, false); err != nil {
+			return rolledBack, err
+		}
+
+		utils.Info("마이그레이션 되돌림: %d_%s", m.version, m.name)
+		rolledBack = append(rolledBack, models.MigrationStatus{Version: m.version, Name: m.name, Reversible: true})
+	}
+	return rolledBack, nil
+}
+
+// runMigration 마이그레이션 하나를 트랜잭션으로 실행하고 이력을 기록/삭제
+// 테이블 재생성 시 참조 행이 지워지지 않도록 전용 연결에서 외래키 검사를 끄고 실행한 뒤,
+// 커밋 전에 외래키 위반이 새로 생기지 않았는지 확인
+func (db *DB) runMigration(m schemaMigration, up bool) error {
+	ctx := context.Background()
+
+	// PRAGMA foreign_keys 는 연결 단위 설정이며 트랜잭션 안에서는 바꿀 수 없음
+	conn, err := db.Conn.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("마이그레이션 연결 획득 오류: %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "PRAGMA foreign_keys = OFF"); err != nil {
+		return fmt.Errorf("외래키 비활성화 오류: %v", err)
+	}
+	defer conn.ExecContext(ctx, "PRAGMA foreign_keys = ON")
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	violationsBefore, err := countForeignKeyViolations(tx)
+	if err != nil {
+		return err
+	}
+
+	if up {
+		err = m.up(tx)
+	} else {
+		err = m.down(tx)
+	}
+	if err != nil {
+		return fmt.Errorf("마이그레이션 %d_%s 실패: %v", m.version, m.name, err)
+	}
+
+	violationsAfter, err := countForeignKeyViolations(tx)
+	if err != nil {
+		return err
+	}
+	if violationsAfter > violationsBefore {
+		return fmt.Errorf("마이그레이션 %d_%s 실패: 외래키 위반이 %d건 발생했습니다", m.version, m.name, violationsAfter-violationsBefore)
+	}
+
+	if up {
+		_, err = tx.Exec(`INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)`,
+			m.version, m.name, utils.FormatDateTimeKST(utils.GetCurrentKST()))
+	} else {
+		_, err = tx.Exec(`DELETE FROM schema_migrations WHERE version = ?`, m.version)
+	}
+	if err != nil {
+		return fmt.Errorf("마이그레이션 이력 기록 오류 (%d_%s): %v", m.version, m.name, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("마이그레이션 %d_%s 커밋 오류: %v", m.version, m.name, err)
+	}
+	return nil
+}
+
+// countForeignKeyViolations 현재 외래키 위반 건수 조회
+// 기존 DB에 이미 있던 위반 때문에 마이그레이션이 막히지 않도록 적용 전후 건수를 비교하는 데 사용
+func countForeignKeyViolations(tx *sql.Tx) (int, error) {
+	rows, err := tx.Query("PRAGMA foreign_key_check")
+	if err != nil {
+		return 0, fmt.Errorf("외래키 검사 오류: %v", err)
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		count++
+	}
+	return count, rows.Err()
+}
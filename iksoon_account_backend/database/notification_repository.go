@@ -0,0 +1,346 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// maxNotificationAttempts 알림 1건의 최대 발송 시도 횟수 (넘으면 failed)
+const maxNotificationAttempts = 5
+
+// notificationRetryDelays 발송 실패 후 다음 시도까지의 대기 시간 (시도 횟수 순, 마지막 값 반복)
+var notificationRetryDelays = []time.Duration{time.Minute, 5 * time.Minute, 30 * time.Minute, 2 * time.Hour}
+
+// createNotificationTables 알림 채널, 기준치 알림 임계값/발생 이력, 알림 발송 기록 테이블 생성
+func createNotificationTables(exec sqlExecutor) error {
+	tables := []string{
+		`CREATE TABLE IF NOT EXISTS notification_channels (
+            id INTEGER PRIMARY KEY AUTOINCREMENT,
+            ledger_id INTEGER NOT NULL,
+            type TEXT NOT NULL CHECK (type IN ('webhook', 'email')),
+            name VARCHAR(100) NOT NULL,
+            target TEXT NOT NULL,
+            enabled INTEGER NOT NULL DEFAULT 1,
+            created_at TEXT DEFAULT CURRENT_TIMESTAMP,
+            updated_at TEXT DEFAULT CURRENT_TIMESTAMP,
+            FOREIGN KEY (ledger_id) REFERENCES ledgers(id) ON DELETE CASCADE,
+            UNIQUE(ledger_id, name)
+        );`,
+		`CREATE TABLE IF NOT EXISTS budget_alert_thresholds (
+            id INTEGER PRIMARY KEY AUTOINCREMENT,
+            budget_id INTEGER NOT NULL,
+            period TEXT NOT NULL CHECK (period IN ('monthly', 'yearly')),
+            percent INTEGER NOT NULL CHECK (percent > 0),
+            created_at TEXT DEFAULT CURRENT_TIMESTAMP,
+            FOREIGN KEY (budget_id) REFERENCES category_budgets(id) ON DELETE CASCADE,
+            UNIQUE(budget_id, period, percent)
+        );`,
+		`CREATE TABLE IF NOT EXISTS budget_alert_events (
+            threshold_id INTEGER NOT NULL,
+            period_key TEXT NOT NULL,
+            percent REAL NOT NULL,
+            fired_at TEXT NOT NULL,
+            PRIMARY KEY (threshold_id, period_key),
+            FOREIGN KEY (threshold_id) REFERENCES budget_alert_thresholds(id) ON DELETE CASCADE
+        );`,
+		`CREATE TABLE IF NOT EXISTS notification_deliveries (
+            id INTEGER PRIMARY KEY AUTOINCREMENT,
+            ledger_id INTEGER NOT NULL,
+            channel_id INTEGER NOT NULL,
+            threshold_id INTEGER NULL,
+            period_key TEXT NOT NULL DEFAULT '',
+            subject TEXT NOT NULL,
+            message TEXT NOT NULL,
+            status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'failed')),
+            attempts INTEGER NOT NULL DEFAULT 0,
+            last_error TEXT NOT NULL DEFAULT '',
+            next_attempt_at TEXT NOT NULL,
+            sent_at TEXT NULL,
+            created_at TEXT NOT NULL,
+            FOREIGN KEY (ledger_id) REFERENCES ledgers(id) ON DELETE CASCADE,
+            FOREIGN KEY (channel_id) REFERENCES notification_channels(id) ON DELETE CASCADE
+        );`,
+		`CREATE INDEX IF NOT EXISTS idx_notification_deliveries_due ON notification_deliveries(status, next_attempt_at)`,
+		`CREATE INDEX IF NOT EXISTS idx_notification_deliveries_ledger ON notification_deliveries(ledger_id, created_at)`,
+	}
+
+	for _, table := range tables {
+		if _, err := exec.Exec(table); err != nil {
+			return fmt.Errorf("알림 테이블 생성 오류: %v", err)
+		}
+	}
+	return nil
+}
+
+// notificationChannelSelectQuery 알림 채널 조회 공통 쿼리
+const notificationChannelSelectQuery = `
+    SELECT id, type, name, target, enabled, created_at, updated_at FROM notification_channels`
+
+// GetNotificationChannels 가계부의 알림 채널 목록 조회
+func (db *DB) GetNotificationChannels(ledgerID int) ([]models.NotificationChannel, error) {
+	rows, err := db.Conn.Query(notificationChannelSelectQuery+` WHERE ledger_id = ? ORDER BY id ASC`, ledgerID)
+	if err != nil {
+		return nil, fmt.Errorf("알림 채널 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	channels := []models.NotificationChannel{}
+	for rows.Next() {
+		channel, err := scanNotificationChannel(rows)
+		if err != nil {
+			return nil, fmt.Errorf("알림 채널 데이터 읽기 오류: %v", err)
+		}
+		channels = append(channels, *channel)
+	}
+	return channels, rows.Err()
+}
+
+// GetNotificationChannelByID 알림 채널 조회
+func (db *DB) GetNotificationChannelByID(ledgerID, id int) (*models.NotificationChannel, error) {
+	channel, err := scanNotificationChannel(db.Conn.QueryRow(notificationChannelSelectQuery+` WHERE id = ? AND ledger_id = ?`, id, ledgerID))
+	if err == sql.ErrNoRows {
+		return nil, apiErrors.ErrNotificationChannelNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("알림 채널 조회 오류: %v", err)
+	}
+	return channel, nil
+}
+
+// CreateNotificationChannel 알림 채널 생성 (같은 이름의 채널이 있으면 409)
+func (db *DB) CreateNotificationChannel(ledgerID int, req models.NotificationChannelRequest) (int64, error) {
+	if err := validateNotificationChannel(&req); err != nil {
+		return 0, err
+	}
+
+	enabled := req.Enabled == nil || *req.Enabled
+	result, err := db.Conn.Exec(`
+        INSERT INTO notification_channels (ledger_id, type, name, target, enabled) VALUES (?, ?, ?, ?, ?)`,
+		ledgerID, req.Type, req.Name, req.Target, enabled)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return 0, apiErrors.ErrAlreadyExists.WithMessage("같은 이름의 알림 채널이 이미 있습니다")
+		}
+		return 0, fmt.Errorf("알림 채널 생성 오류: %v", err)
+	}
+	return result.LastInsertId()
+}
+
+// UpdateNotificationChannel 알림 채널 수정 (enabled 를 생략하면 기존 값 유지)
+func (db *DB) UpdateNotificationChannel(ledgerID, id int, req models.NotificationChannelRequest) error {
+	existing, err := db.GetNotificationChannelByID(ledgerID, id)
+	if err != nil {
+		return err
+	}
+	if err := validateNotificationChannel(&req); err != nil {
+		return err
+	}
+
+	enabled := existing.Enabled
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+	if _, err := db.Conn.Exec(`
+        UPDATE notification_channels SET type = ?, name = ?, target = ?, enabled = ?, updated_at = CURRENT_TIMESTAMP
+        WHERE id = ? AND ledger_id = ?`, req.Type, req.Name, req.Target, enabled, id, ledgerID); err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return apiErrors.ErrAlreadyExists.WithMessage("같은 이름의 알림 채널이 이미 있습니다")
+		}
+		return fmt.Errorf("알림 채널 수정 오류: %v", err)
+	}
+	return nil
+}
+
+// DeleteNotificationChannel 알림 채널과 해당 채널의 발송 기록 삭제
+func (db *DB) DeleteNotificationChannel(ledgerID, id int) error {
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`DELETE FROM notification_channels WHERE id = ? AND ledger_id = ?`, id, ledgerID)
+	if err != nil {
+		return fmt.Errorf("알림 채널 삭제 오류: %v", err)
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return apiErrors.ErrNotificationChannelNotFound
+	}
+	if _, err := tx.Exec(`DELETE FROM notification_deliveries WHERE channel_id = ?`, id); err != nil {
+		return fmt.Errorf("알림 발송 기록 삭제 오류: %v", err)
+	}
+	return tx.Commit()
+}
+
+// QueueTestNotification 채널로 보낼 테스트 알림을 발송 대기열에 추가 (사용 중지된 채널도 허용)
+func (db *DB) QueueTestNotification(ledgerID, channelID int) (int64, error) {
+	channel, err := db.GetNotificationChannelByID(ledgerID, channelID)
+	if err != nil {
+		return 0, err
+	}
+
+	now := utils.FormatDateTimeKST(utils.GetCurrentKST())
+	result, err := db.Conn.Exec(`
+        INSERT INTO notification_deliveries (ledger_id, channel_id, subject, message, next_attempt_at, created_at)
+        VALUES (?, ?, ?, ?, ?, ?)`,
+		ledgerID, channel.ID, "테스트 알림", fmt.Sprintf("'%s' 채널 알림 설정이 정상적으로 동작합니다.", channel.Name), now, now)
+	if err != nil {
+		return 0, fmt.Errorf("테스트 알림 등록 오류: %v", err)
+	}
+	return result.LastInsertId()
+}
+
+// notificationDeliverySelectQuery 알림 발송 기록 조회 공통 쿼리 (채널 이름/종류/대상 포함)
+const notificationDeliverySelectQuery = `
+    SELECT d.id, d.channel_id, c.name, c.type, c.target, d.threshold_id, d.period_key,
+           d.subject, d.message, d.status, d.attempts, d.last_error, d.next_attempt_at,
+           COALESCE(d.sent_at, ''), d.created_at
+    FROM notification_deliveries d
+    JOIN notification_channels c ON d.channel_id = c.id`
+
+// GetNotificationDeliveries 가계부의 알림 발송 기록 조회 (최근 순, status 필터)
+func (db *DB) GetNotificationDeliveries(ledgerID int, status string, limit int) ([]models.NotificationDelivery, error) {
+	query := notificationDeliverySelectQuery + ` WHERE d.ledger_id = ?`
+	args := []interface{}{ledgerID}
+	if status != "" {
+		if status != models.NotificationPending && status != models.NotificationSent && status != models.NotificationFailed {
+			return nil, apiErrors.ErrInvalidNotification.WithMessage("status는 pending, sent, failed 중 하나여야 합니다")
+		}
+		query += ` AND d.status = ?`
+		args = append(args, status)
+	}
+	query += ` ORDER BY d.created_at DESC, d.id DESC LIMIT ?`
+	args = append(args, limit)
+
+	return queryNotificationDeliveries(db.Conn, query, args...)
+}
+
+// GetDueNotificationDeliveries 발송 시각이 된 대기 중 알림 조회 (사용 중인 채널만, 오래된 순)
+func (db *DB) GetDueNotificationDeliveries(now time.Time, limit int) ([]models.NotificationDelivery, error) {
+	return queryNotificationDeliveries(db.Conn, notificationDeliverySelectQuery+`
+        WHERE d.status = 'pending' AND d.next_attempt_at <= ? AND (c.enabled = 1 OR d.threshold_id IS NULL)
+        ORDER BY d.next_attempt_at ASC, d.id ASC LIMIT ?`, utils.FormatDateTimeKST(now), limit)
+}
+
+// CompleteNotificationDelivery 발송 결과 기록 (실패하면 재시도 시각을 늦추고, 최대 시도 횟수를 넘으면 failed)
+func (db *DB) CompleteNotificationDelivery(id int, sendErr error, now time.Time) error {
+	if sendErr == nil {
+		if _, err := db.Conn.Exec(`
+            UPDATE notification_deliveries SET status = 'sent', attempts = attempts + 1, last_error = '', sent_at = ?
+            WHERE id = ?`, utils.FormatDateTimeKST(now), id); err != nil {
+			return fmt.Errorf("알림 발송 결과 저장 오류: %v", err)
+		}
+		return nil
+	}
+
+	var attempts int
+	if err := db.Conn.QueryRow(`SELECT attempts FROM notification_deliveries WHERE id = ?`, id).Scan(&attempts); err != nil {
+		return fmt.Errorf("알림 발송 기록 조회 오류: %v", err)
+	}
+	attempts++
+
+	status := models.NotificationPending
+	delay := notificationRetryDelays[len(notificationRetryDelays)-1]
+	if attempts <= len(notificationRetryDelays) {
+		delay = notificationRetryDelays[attempts-1]
+	}
+	if attempts >= maxNotificationAttempts {
+		status = models.NotificationFailed
+	}
+
+	if _, err := db.Conn.Exec(`
+        UPDATE notification_deliveries SET status = ?, attempts = ?, last_error = ?, next_attempt_at = ?
+        WHERE id = ?`, status, attempts, sendErr.Error(), utils.FormatDateTimeKST(now.Add(delay)), id); err != nil {
+		return fmt.Errorf("알림 발송 결과 저장 오류: %v", err)
+	}
+	return nil
+}
+
+// RetryNotificationDelivery 실패한 알림을 즉시 다시 보내도록 대기열에 되돌림 (시도 횟수 초기화)
+func (db *DB) RetryNotificationDelivery(ledgerID, id int) error {
+	result, err := db.Conn.Exec(`
+        UPDATE notification_deliveries SET status = 'pending', attempts = 0, next_attempt_at = ?
+        WHERE id = ? AND ledger_id = ? AND status = 'failed'`, utils.FormatDateTimeKST(utils.GetCurrentKST()), id, ledgerID)
+	if err != nil {
+		return fmt.Errorf("알림 재시도 등록 오류: %v", err)
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return apiErrors.ErrNotificationNotFound.WithMessage("재시도할 실패 알림을 찾을 수 없습니다")
+	}
+	return nil
+}
+
+// queryNotificationDeliveries 알림 발송 기록 목록 조회
+func queryNotificationDeliveries(exec sqlExecutor, query string, args ...interface{}) ([]models.NotificationDelivery, error) {
+	rows, err := exec.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("알림 발송 기록 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	deliveries := []models.NotificationDelivery{}
+	for rows.Next() {
+		var delivery models.NotificationDelivery
+		var thresholdID sql.NullInt64
+		if err := rows.Scan(&delivery.ID, &delivery.ChannelID, &delivery.ChannelName, &delivery.ChannelType, &delivery.Target,
+			&thresholdID, &delivery.PeriodKey, &delivery.Subject, &delivery.Message, &delivery.Status, &delivery.Attempts,
+			&delivery.LastError, &delivery.NextAttemptAt, &delivery.SentAt, &delivery.CreatedAt); err != nil {
+			return nil, fmt.Errorf("알림 발송 기록 읽기 오류: %v", err)
+		}
+		if thresholdID.Valid {
+			id := int(thresholdID.Int64)
+			delivery.ThresholdID = &id
+		}
+		if delivery.Status != models.NotificationPending {
+			delivery.NextAttemptAt = ""
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+// scanNotificationChannel 알림 채널 한 행 읽기
+func scanNotificationChannel(scanner interface{ Scan(...interface{}) error }) (*models.NotificationChannel, error) {
+	var channel models.NotificationChannel
+	if err := scanner.Scan(&channel.ID, &channel.Type, &channel.Name, &channel.Target, &channel.Enabled, &channel.CreatedAt, &channel.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &channel, nil
+}
+
+// validateNotificationChannel 알림 채널 요청 검증 (웹훅은 http/https URL, 이메일은 쉼표로 구분한 주소 목록)
+func validateNotificationChannel(req *models.NotificationChannelRequest) error {
+	req.Type = strings.ToLower(strings.TrimSpace(req.Type))
+	req.Name = strings.TrimSpace(req.Name)
+	req.Target = strings.TrimSpace(req.Target)
+
+	if req.Name == "" {
+		return apiErrors.ErrMissingRequired.WithMessage("채널 이름은 필수입니다")
+	}
+	if req.Target == "" {
+		return apiErrors.ErrMissingRequired.WithMessage("target(웹훅 URL 또는 이메일 주소)은 필수입니다")
+	}
+
+	switch req.Type {
+	case models.NotificationChannelWebhook:
+		parsed, err := url.Parse(req.Target)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return apiErrors.ErrInvalidNotification.WithMessage("웹훅 target은 http 또는 https URL이어야 합니다")
+		}
+	case models.NotificationChannelEmail:
+		if _, err := mail.ParseAddressList(req.Target); err != nil {
+			return apiErrors.ErrInvalidNotification.WithMessage("이메일 target은 쉼표로 구분한 이메일 주소여야 합니다")
+		}
+	default:
+		return apiErrors.ErrInvalidNotification.WithMessage("type은 webhook 또는 email이어야 합니다")
+	}
+	return nil
+}
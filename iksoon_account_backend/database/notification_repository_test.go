@@ -0,0 +1,162 @@
+package database
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// createTestWebhookChannel 기본 가계부에 웹훅 알림 채널 생성
+func createTestWebhookChannel(t *testing.T, db *DB, name string) int {
+	t.Helper()
+
+	id, err := db.CreateNotificationChannel(DefaultLedgerID, models.NotificationChannelRequest{
+		Type: models.NotificationChannelWebhook, Name: name, Target: "https://example.com/hook",
+	})
+	if err != nil {
+		t.Fatalf("알림 채널 생성 실패: %v", err)
+	}
+	return int(id)
+}
+
+func TestEvaluateBudgetAlertsFiresOncePerPeriod(t *testing.T) {
+	db := newTestDB(t)
+	categoryID := createTestCategory(t, db, "테스트 식비", "out")
+	methodID := createTestPaymentMethod(t, db, "테스트 현금")
+	createTestWebhookChannel(t, db, "슬랙")
+
+	budgetID, err := db.CreateCategoryBudget(DefaultLedgerID, categoryID, "", 100000, 0, "2024-01")
+	if err != nil {
+		t.Fatalf("기준치 생성 실패: %v", err)
+	}
+	for _, percent := range []int{50, 80} {
+		if _, err := db.CreateBudgetAlertThreshold(DefaultLedgerID, models.BudgetAlertThresholdRequest{BudgetID: int(budgetID), Percent: percent}); err != nil {
+			t.Fatalf("기준치 알림 생성 실패: %v", err)
+		}
+	}
+
+	steps := []struct {
+		name        string
+		spend       int    // 확인 전에 등록할 지출 (0 이면 등록 안 함)
+		date        string // 지출 날짜이자 확인 시각
+		wantQueued  int
+		wantSubject string
+	}{
+		{"임계값 미달", 40000, "2024-03-05", 0, ""},
+		{"50% 도달", 20000, "2024-03-06", 1, "50%"},
+		{"같은 달 재확인", 0, "2024-03-07", 0, ""},
+		{"같은 달 추가 지출", 5000, "2024-03-08", 0, ""},
+		{"80% 도달", 20000, "2024-03-09", 1, "80%"},
+		{"80% 이후 재확인", 0, "2024-03-10", 0, ""},
+		{"다음 달 두 임계값 동시 도달", 90000, "2024-04-02", 1, "80%"},
+		{"다음 달 재확인", 0, "2024-04-03", 0, ""},
+	}
+	for _, step := range steps {
+		if step.spend > 0 {
+			insertTestOutAccount(t, db, step.date, step.spend, categoryID, methodID)
+		}
+		queued, err := db.EvaluateBudgetAlerts(mustParseKST(t, step.date+" 12:00:00"))
+		if err != nil {
+			t.Fatalf("%s: EvaluateBudgetAlerts() error = %v", step.name, err)
+		}
+		if queued != step.wantQueued {
+			t.Errorf("%s: 등록된 알림 = %d, want %d", step.name, queued, step.wantQueued)
+		}
+		if step.wantSubject == "" {
+			continue
+		}
+		deliveries, err := db.GetNotificationDeliveries(DefaultLedgerID, "", 1)
+		if err != nil {
+			t.Fatalf("알림 발송 기록 조회 실패: %v", err)
+		}
+		if len(deliveries) != 1 || !strings.Contains(deliveries[0].Subject, step.wantSubject) {
+			t.Errorf("%s: 최근 알림 = %+v, want 제목에 %s 포함", step.name, deliveries, step.wantSubject)
+		}
+	}
+
+	deliveries, err := db.GetNotificationDeliveries(DefaultLedgerID, "", 10)
+	if err != nil {
+		t.Fatalf("알림 발송 기록 조회 실패: %v", err)
+	}
+	if len(deliveries) != 3 {
+		t.Errorf("전체 알림 수 = %d, want 3", len(deliveries))
+	}
+}
+
+func TestCompleteNotificationDeliveryRetryBackoff(t *testing.T) {
+	db := newTestDB(t)
+	channelID := createTestWebhookChannel(t, db, "슬랙")
+	id, err := db.QueueTestNotification(DefaultLedgerID, channelID)
+	if err != nil {
+		t.Fatalf("테스트 알림 등록 실패: %v", err)
+	}
+
+	now := mustParseKST(t, "2024-03-01 09:00:00")
+	sendErr := errors.New("HTTP 500")
+	tests := []struct {
+		wantStatus string
+		wantDelay  time.Duration // pending 일 때 다음 시도까지 대기 시간
+	}{
+		{models.NotificationPending, time.Minute},
+		{models.NotificationPending, 5 * time.Minute},
+		{models.NotificationPending, 30 * time.Minute},
+		{models.NotificationPending, 2 * time.Hour},
+		{models.NotificationFailed, 0},
+	}
+	for i, tt := range tests {
+		attempt := i + 1
+		if err := db.CompleteNotificationDelivery(int(id), sendErr, now); err != nil {
+			t.Fatalf("%d번째 시도: CompleteNotificationDelivery() error = %v", attempt, err)
+		}
+
+		deliveries, err := db.GetNotificationDeliveries(DefaultLedgerID, "", 1)
+		if err != nil {
+			t.Fatalf("알림 발송 기록 조회 실패: %v", err)
+		}
+		delivery := deliveries[0]
+		if delivery.Status != tt.wantStatus || delivery.Attempts != attempt || delivery.LastError != "HTTP 500" {
+			t.Errorf("%d번째 시도: status=%s attempts=%d last_error=%q, want %s %d HTTP 500",
+				attempt, delivery.Status, delivery.Attempts, delivery.LastError, tt.wantStatus, attempt)
+		}
+		if tt.wantStatus == models.NotificationPending {
+			if want := utils.FormatDateTimeKST(now.Add(tt.wantDelay)); delivery.NextAttemptAt != want {
+				t.Errorf("%d번째 시도: next_attempt_at = %s, want %s", attempt, delivery.NextAttemptAt, want)
+			}
+			// 대기 시간이 지나기 전에는 발송 대상이 아니고, 지나면 다시 발송 대상
+			if due, _ := db.GetDueNotificationDeliveries(now.Add(tt.wantDelay-time.Second), 10); len(due) != 0 {
+				t.Errorf("%d번째 시도: 대기 시간 전에 발송 대상에 포함됨", attempt)
+			}
+			if due, _ := db.GetDueNotificationDeliveries(now.Add(tt.wantDelay), 10); len(due) != 1 {
+				t.Errorf("%d번째 시도: 대기 시간 후 발송 대상에 없음", attempt)
+			}
+		}
+		now = now.Add(tt.wantDelay)
+	}
+
+	// 최대 시도 횟수를 넘긴 알림은 더 이상 발송하지 않음
+	if due, _ := db.GetDueNotificationDeliveries(now.Add(24*time.Hour), 10); len(due) != 0 {
+		t.Errorf("실패 처리된 알림이 발송 대상에 포함됨: %+v", due)
+	}
+
+	// 수동 재시도하면 시도 횟수가 초기화되고 성공 시 sent
+	if err := db.RetryNotificationDelivery(DefaultLedgerID, int(id)); err != nil {
+		t.Fatalf("RetryNotificationDelivery() error = %v", err)
+	}
+	if err := db.CompleteNotificationDelivery(int(id), nil, now); err != nil {
+		t.Fatalf("CompleteNotificationDelivery() error = %v", err)
+	}
+	deliveries, err := db.GetNotificationDeliveries(DefaultLedgerID, models.NotificationSent, 1)
+	if err != nil {
+		t.Fatalf("알림 발송 기록 조회 실패: %v", err)
+	}
+	if len(deliveries) != 1 || deliveries[0].Attempts != 1 || deliveries[0].LastError != "" || deliveries[0].SentAt != utils.FormatDateTimeKST(now) {
+		t.Errorf("재시도 성공 후 기록 = %+v, want sent, attempts=1, sent_at=%s", deliveries, utils.FormatDateTimeKST(now))
+	}
+	if err := db.RetryNotificationDelivery(DefaultLedgerID, int(id)); err == nil {
+		t.Error("발송 완료된 알림의 재시도가 허용됨")
+	}
+}
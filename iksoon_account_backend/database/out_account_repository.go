@@ -9,40 +9,75 @@ import (
 	"github.com/google/uuid"
 )
 
-// InsertOutAccount 지출 데이터 삽입
-func (db *DB) InsertOutAccount(date, user string, money, categoryID int, keywordID *int, paymentMethodID int, memo string) error {
+// InsertOutAccount 지출 데이터 삽입 (생성된 UUID 반환)
+// currency 가 외화이면 외화 금액과 환율을 같은 트랜잭션에서 함께 저장 (빈 값/KRW 는 원화 거래)
+func (db *DB) InsertOutAccount(ledgerID int, date, user string, money, categoryID int, keywordID *int, paymentMethodID int, memo, currency string, originalMoney float64) (string, error) {
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return "", fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	uuidStr, err := insertOutAccount(tx, ledgerID, date, user, money, categoryID, keywordID, paymentMethodID, memo)
+	if err != nil {
+		return "", err
+	}
+	if err := setTransactionCurrency(tx, ledgerID, models.AccountTypeOut, uuidStr, currency, originalMoney); err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("지출 데이터 저장 커밋 오류: %v", err)
+	}
+	return uuidStr, nil
+}
+
+// insertOutAccount 지출 데이터 삽입 공통 로직 (트랜잭션 내부에서도 사용, 생성된 UUID 반환)
+func insertOutAccount(exec sqlExecutor, ledgerID int, date, user string, money, categoryID int, keywordID *int, paymentMethodID int, memo string) (string, error) {
 	uuidStr := uuid.New().String()
 	parsedDate, err := utils.ParseDateTimeKST(date)
 	if err != nil {
 		utils.LogError("지출 데이터 날짜 파싱", err)
-		return fmt.Errorf("날짜 파싱 오류: %v", err)
+		return "", fmt.Errorf("날짜 파싱 오류: %v", err)
 	}
 	formattedDate := utils.FormatDateTimeKST(parsedDate)
 
+	if err := ensureOutAccountReferences(exec, ledgerID, categoryID, paymentMethodID); err != nil {
+		return "", err
+	}
+
 	insertQuery := `
-    INSERT INTO out_account_data (uuid, date, money, user, category_id, keyword_id, payment_method_id, memo, created_at, updated_at)
-    VALUES (?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+    INSERT INTO out_account_data (uuid, ledger_id, date, money, user, category_id, keyword_id, payment_method_id, memo, created_at, updated_at)
+    VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
 
 	// 디버그용 상세 로깅
 	utils.Debug("지출 데이터 삽입 시도: UUID=%s, Date=%s, User=%s, Money=%d, CategoryID=%d, KeywordID=%v, PaymentMethodID=%d, Memo=%s",
 		uuidStr, formattedDate, user, money, categoryID, keywordID, paymentMethodID, memo)
 
-	_, err = db.Conn.Exec(insertQuery, uuidStr, formattedDate, money, user, categoryID, keywordID, paymentMethodID, memo)
+	_, err = exec.Exec(insertQuery, uuidStr, ledgerID, formattedDate, money, user, categoryID, keywordID, paymentMethodID, memo)
 	if err != nil {
 		utils.LogError("지출 데이터 SQL 실행", err)
 		utils.Debug("실패한 SQL: %s", insertQuery)
 		utils.Debug("실패한 파라미터: [%s, %s, %d, %s, %d, %v, %d, %s]",
 			uuidStr, formattedDate, money, user, categoryID, keywordID, paymentMethodID, memo)
-		return fmt.Errorf("지출 데이터 삽입 오류: %v", err)
+		return "", fmt.Errorf("지출 데이터 삽입 오류: %v", err)
 	}
 	utils.Debug("지출 데이터 삽입 성공: UUID=%s", uuidStr)
-	return nil
+	return uuidStr, nil
+}
+
+// ensureOutAccountReferences 지출의 카테고리/결제수단이 같은 가계부에 속하는지 확인
+func ensureOutAccountReferences(exec sqlExecutor, ledgerID, categoryID, paymentMethodID int) error {
+	if err := ensureLedgerRow(exec, "categories", categoryID, ledgerID); err != nil {
+		return err
+	}
+	return ensureLedgerRow(exec, "payment_methods", paymentMethodID, ledgerID)
 }
 
 // GetOutAccountsByDate 일별 지출 데이터 조회
-func (db *DB) GetOutAccountsByDate(date string) ([]models.OutAccount, error) {
+func (db *DB) GetOutAccountsByDate(ledgerID int, date string) ([]models.OutAccount, error) {
 	query := `
-    SELECT oa.uuid, oa.date, oa.user, oa.money, oa.category_id, oa.keyword_id, oa.payment_method_id, oa.memo, oa.created_at, oa.updated_at,
+    SELECT oa.uuid, oa.date, oa.user, oa.money, oa.category_id, oa.keyword_id, oa.payment_method_id, oa.memo, oa.installment_id, oa.installment_seq, oa.created_at, oa.updated_at,
            c.name as category_name,
            COALESCE(k.name, '') as keyword_name,
            pm.name as payment_method_name
@@ -50,9 +85,9 @@ func (db *DB) GetOutAccountsByDate(date string) ([]models.OutAccount, error) {
     LEFT JOIN categories c ON oa.category_id = c.id
     LEFT JOIN keywords k ON oa.keyword_id = k.id
     LEFT JOIN payment_methods pm ON oa.payment_method_id = pm.id
-    WHERE date(oa.date) = date(?)`
+    WHERE oa.ledger_id = ? AND date(oa.date) = date(?)`
 
-	rows, err := db.Conn.Query(query, date)
+	rows, err := db.Conn.Query(query, ledgerID, date)
 	if err != nil {
 		return nil, fmt.Errorf("지출 데이터 조회 오류: %v", err)
 	}
@@ -64,7 +99,7 @@ func (db *DB) GetOutAccountsByDate(date string) ([]models.OutAccount, error) {
 		var keywordID *int
 
 		err := rows.Scan(&outAccount.UUID, &outAccount.Date, &outAccount.User, &outAccount.Money,
-			&outAccount.CategoryID, &keywordID, &outAccount.PaymentMethodID, &outAccount.Memo,
+			&outAccount.CategoryID, &keywordID, &outAccount.PaymentMethodID, &outAccount.Memo, &outAccount.InstallmentID, &outAccount.InstallmentSeq,
 			&outAccount.CreatedAt, &outAccount.UpdatedAt,
 			&outAccount.CategoryName, &outAccount.KeywordName, &outAccount.PaymentMethodName)
 		if err != nil {
@@ -75,13 +110,25 @@ func (db *DB) GetOutAccountsByDate(date string) ([]models.OutAccount, error) {
 		outAccounts = append(outAccounts, outAccount)
 	}
 
+	if err := attachOutAccountSplits(db.Conn, ledgerID, outAccounts); err != nil {
+		return nil, err
+	}
+	if err := attachOutAccountTags(db.Conn, outAccounts); err != nil {
+		return nil, err
+	}
+	if err := attachOutAccountCurrencies(db.Conn, outAccounts); err != nil {
+		return nil, err
+	}
+	if err := attachReimbursementStatus(db.Conn, outAccounts); err != nil {
+		return nil, err
+	}
 	return outAccounts, nil
 }
 
 // GetOutAccountsForMonth 월별 지출 데이터 조회
-func (db *DB) GetOutAccountsForMonth(year, month string) ([]models.OutAccount, error) {
+func (db *DB) GetOutAccountsForMonth(ledgerID int, year, month string) ([]models.OutAccount, error) {
 	query := `
-    SELECT oa.uuid, oa.date, oa.user, oa.money, oa.category_id, oa.keyword_id, oa.payment_method_id, oa.memo, oa.created_at, oa.updated_at,
+    SELECT oa.uuid, oa.date, oa.user, oa.money, oa.category_id, oa.keyword_id, oa.payment_method_id, oa.memo, oa.installment_id, oa.installment_seq, oa.created_at, oa.updated_at,
            c.name as category_name,
            COALESCE(k.name, '') as keyword_name,
            pm.name as payment_method_name
@@ -89,9 +136,9 @@ func (db *DB) GetOutAccountsForMonth(year, month string) ([]models.OutAccount, e
     LEFT JOIN categories c ON oa.category_id = c.id
     LEFT JOIN keywords k ON oa.keyword_id = k.id
     LEFT JOIN payment_methods pm ON oa.payment_method_id = pm.id
-    WHERE substr(oa.date, 1, 7) = ?`
+    WHERE oa.ledger_id = ? AND substr(oa.date, 1, 7) = ?`
 
-	rows, err := db.Conn.Query(query, year+"-"+month)
+	rows, err := db.Conn.Query(query, ledgerID, year+"-"+month)
 	if err != nil {
 		return nil, fmt.Errorf("월별 지출 데이터 조회 오류: %v", err)
 	}
@@ -103,7 +150,7 @@ func (db *DB) GetOutAccountsForMonth(year, month string) ([]models.OutAccount, e
 		var keywordID *int
 
 		err := rows.Scan(&account.UUID, &account.Date, &account.User, &account.Money,
-			&account.CategoryID, &keywordID, &account.PaymentMethodID, &account.Memo,
+			&account.CategoryID, &keywordID, &account.PaymentMethodID, &account.Memo, &account.InstallmentID, &account.InstallmentSeq,
 			&account.CreatedAt, &account.UpdatedAt,
 			&account.CategoryName, &account.KeywordName, &account.PaymentMethodName)
 		if err != nil {
@@ -114,56 +161,31 @@ func (db *DB) GetOutAccountsForMonth(year, month string) ([]models.OutAccount, e
 		accounts = append(accounts, account)
 	}
 
-	return accounts, nil
-}
-
-// GetOutAccountsByDateRange 기간별 지출 데이터 조회
-func (db *DB) GetOutAccountsByDateRange(startDate, endDate string) ([]models.OutAccount, error) {
-	query := `
-    SELECT oa.uuid, oa.date, oa.user, oa.money, oa.category_id, oa.keyword_id, oa.payment_method_id, oa.memo, oa.created_at, oa.updated_at,
-           c.name as category_name,
-           COALESCE(k.name, '') as keyword_name,
-           pm.name as payment_method_name
-    FROM out_account_data oa
-    LEFT JOIN categories c ON oa.category_id = c.id
-    LEFT JOIN keywords k ON oa.keyword_id = k.id
-    LEFT JOIN payment_methods pm ON oa.payment_method_id = pm.id
-    WHERE DATE(oa.date) >= ? AND DATE(oa.date) <= ?
-    ORDER BY oa.date DESC`
-
-	rows, err := db.Conn.Query(query, startDate, endDate)
-	if err != nil {
-		utils.LogError("기간별 지출 데이터 조회", err)
-		return nil, fmt.Errorf("기간별 지출 데이터 조회 오류: %v", err)
+	if err := attachOutAccountSplits(db.Conn, ledgerID, accounts); err != nil {
+		return nil, err
 	}
-	defer rows.Close()
-
-	var accounts []models.OutAccount
-	for rows.Next() {
-		var account models.OutAccount
-		var keywordID *int
-
-		err := rows.Scan(
-			&account.UUID, &account.Date, &account.User, &account.Money, &account.CategoryID,
-			&keywordID, &account.PaymentMethodID, &account.Memo, &account.CreatedAt, &account.UpdatedAt,
-			&account.CategoryName, &account.KeywordName, &account.PaymentMethodName,
-		)
-		if err != nil {
-			utils.LogError("기간별 지출 데이터 스캔", err)
-			continue
-		}
-
-		account.KeywordID = keywordID
-		accounts = append(accounts, account)
+	if err := attachOutAccountTags(db.Conn, accounts); err != nil {
+		return nil, err
+	}
+	if err := attachOutAccountCurrencies(db.Conn, accounts); err != nil {
+		return nil, err
+	}
+	if err := attachReimbursementStatus(db.Conn, accounts); err != nil {
+		return nil, err
 	}
-
 	return accounts, nil
 }
 
+// GetOutAccountsByDateRange 기간별 지출 데이터 조회 (opts 로 태그/금액/카테고리 등 필터, 정렬, 커서 페이지네이션)
+func (db *DB) GetOutAccountsByDateRange(ledgerID int, startDate, endDate string, opts models.ListOptions) ([]models.OutAccount, models.PageInfo, error) {
+	return db.queryOutAccountList(ledgerID, `oa.ledger_id = ? AND DATE(oa.date) >= ? AND DATE(oa.date) <= ?`,
+		[]interface{}{ledgerID, startDate, endDate}, opts, "기간별 지출 데이터 조회")
+}
+
 // GetOutAccountsByPaymentMethod 결제수단별 지출 데이터 조회
-func (db *DB) GetOutAccountsByPaymentMethod(paymentMethodID int, startDate, endDate string) ([]models.OutAccount, error) {
+func (db *DB) GetOutAccountsByPaymentMethod(ledgerID int, paymentMethodID int, startDate, endDate string) ([]models.OutAccount, error) {
 	query := `
-    SELECT oa.uuid, oa.date, oa.user, oa.money, oa.category_id, oa.keyword_id, oa.payment_method_id, oa.memo, oa.created_at, oa.updated_at,
+    SELECT oa.uuid, oa.date, oa.user, oa.money, oa.category_id, oa.keyword_id, oa.payment_method_id, oa.memo, oa.installment_id, oa.installment_seq, oa.created_at, oa.updated_at,
            c.name as category_name,
            COALESCE(k.name, '') as keyword_name,
            pm.name as payment_method_name
@@ -171,10 +193,10 @@ func (db *DB) GetOutAccountsByPaymentMethod(paymentMethodID int, startDate, endD
     LEFT JOIN categories c ON oa.category_id = c.id
     LEFT JOIN keywords k ON oa.keyword_id = k.id
     LEFT JOIN payment_methods pm ON oa.payment_method_id = pm.id
-    WHERE oa.payment_method_id = ? AND DATE(oa.date) >= ? AND DATE(oa.date) <= ?
+    WHERE oa.ledger_id = ? AND oa.payment_method_id = ? AND DATE(oa.date) >= ? AND DATE(oa.date) <= ?
     ORDER BY oa.date DESC`
 
-	rows, err := db.Conn.Query(query, paymentMethodID, startDate, endDate)
+	rows, err := db.Conn.Query(query, ledgerID, paymentMethodID, startDate, endDate)
 	if err != nil {
 		utils.LogError("결제수단별 지출 데이터 조회", err)
 		return nil, fmt.Errorf("결제수단별 지출 데이터 조회 오류: %v", err)
@@ -188,7 +210,7 @@ func (db *DB) GetOutAccountsByPaymentMethod(paymentMethodID int, startDate, endD
 
 		err := rows.Scan(
 			&account.UUID, &account.Date, &account.User, &account.Money, &account.CategoryID,
-			&keywordID, &account.PaymentMethodID, &account.Memo, &account.CreatedAt, &account.UpdatedAt,
+			&keywordID, &account.PaymentMethodID, &account.Memo, &account.InstallmentID, &account.InstallmentSeq, &account.CreatedAt, &account.UpdatedAt,
 			&account.CategoryName, &account.KeywordName, &account.PaymentMethodName,
 		)
 		if err != nil {
@@ -203,96 +225,22 @@ func (db *DB) GetOutAccountsByPaymentMethod(paymentMethodID int, startDate, endD
 	return accounts, nil
 }
 
-// GetOutAccountsByUser 사용자별 지출 데이터 조회
-func (db *DB) GetOutAccountsByUser(userName, startDate, endDate string) ([]models.OutAccount, error) {
-	query := `
-    SELECT oa.uuid, oa.date, oa.user, oa.money, oa.category_id, oa.keyword_id, oa.payment_method_id, oa.memo, oa.created_at, oa.updated_at,
-           c.name as category_name,
-           COALESCE(k.name, '') as keyword_name,
-           pm.name as payment_method_name
-    FROM out_account_data oa
-    LEFT JOIN categories c ON oa.category_id = c.id
-    LEFT JOIN keywords k ON oa.keyword_id = k.id
-    LEFT JOIN payment_methods pm ON oa.payment_method_id = pm.id
-    WHERE oa.user = ? AND DATE(oa.date) >= ? AND DATE(oa.date) <= ?
-    ORDER BY oa.date DESC`
-
-	rows, err := db.Conn.Query(query, userName, startDate, endDate)
-	if err != nil {
-		utils.LogError("사용자별 지출 데이터 조회", err)
-		return nil, fmt.Errorf("사용자별 지출 데이터 조회 오류: %v", err)
-	}
-	defer rows.Close()
-
-	var accounts []models.OutAccount
-	for rows.Next() {
-		var account models.OutAccount
-		var keywordID *int
-
-		err := rows.Scan(
-			&account.UUID, &account.Date, &account.User, &account.Money, &account.CategoryID,
-			&keywordID, &account.PaymentMethodID, &account.Memo, &account.CreatedAt, &account.UpdatedAt,
-			&account.CategoryName, &account.KeywordName, &account.PaymentMethodName,
-		)
-		if err != nil {
-			utils.LogError("사용자별 지출 데이터 스캔", err)
-			continue
-		}
-
-		account.KeywordID = keywordID
-		accounts = append(accounts, account)
-	}
-
-	return accounts, nil
+// GetOutAccountsByUser 사용자별 지출 데이터 조회 (opts 로 필터, 정렬, 커서 페이지네이션)
+func (db *DB) GetOutAccountsByUser(ledgerID int, userName, startDate, endDate string, opts models.ListOptions) ([]models.OutAccount, models.PageInfo, error) {
+	return db.queryOutAccountList(ledgerID, `oa.ledger_id = ? AND oa.user = ? AND DATE(oa.date) >= ? AND DATE(oa.date) <= ?`,
+		[]interface{}{ledgerID, userName, startDate, endDate}, opts, "사용자별 지출 데이터 조회")
 }
 
-// SearchOutAccountsByKeyword 키워드로 지출 데이터 검색
-func (db *DB) SearchOutAccountsByKeyword(keyword, startDate, endDate string) ([]models.OutAccount, error) {
-	query := `
-    SELECT oa.uuid, oa.date, oa.user, oa.money, oa.category_id, oa.keyword_id, oa.payment_method_id, oa.memo, oa.created_at, oa.updated_at,
-           c.name as category_name,
-           COALESCE(k.name, '') as keyword_name,
-           pm.name as payment_method_name
-    FROM out_account_data oa
-    LEFT JOIN categories c ON oa.category_id = c.id
-    LEFT JOIN keywords k ON oa.keyword_id = k.id
-    LEFT JOIN payment_methods pm ON oa.payment_method_id = pm.id
-    WHERE DATE(oa.date) >= ? AND DATE(oa.date) <= ?
-    AND (k.name LIKE ? OR oa.memo LIKE ?)
-    ORDER BY oa.date DESC`
-
+// SearchOutAccountsByKeyword 키워드로 지출 데이터 검색 (opts 로 태그/금액/카테고리 등 필터, 정렬, 커서 페이지네이션)
+func (db *DB) SearchOutAccountsByKeyword(ledgerID int, keyword, startDate, endDate string, opts models.ListOptions) ([]models.OutAccount, models.PageInfo, error) {
 	keywordPattern := "%" + keyword + "%"
-	rows, err := db.Conn.Query(query, startDate, endDate, keywordPattern, keywordPattern)
-	if err != nil {
-		utils.LogError("키워드 지출 데이터 검색", err)
-		return nil, fmt.Errorf("키워드 지출 데이터 검색 오류: %v", err)
-	}
-	defer rows.Close()
-
-	var accounts []models.OutAccount
-	for rows.Next() {
-		var account models.OutAccount
-		var keywordID *int
-
-		err := rows.Scan(
-			&account.UUID, &account.Date, &account.User, &account.Money, &account.CategoryID,
-			&keywordID, &account.PaymentMethodID, &account.Memo, &account.CreatedAt, &account.UpdatedAt,
-			&account.CategoryName, &account.KeywordName, &account.PaymentMethodName,
-		)
-		if err != nil {
-			utils.LogError("키워드 지출 데이터 스캔", err)
-			continue
-		}
-
-		account.KeywordID = keywordID
-		accounts = append(accounts, account)
-	}
-
-	return accounts, nil
+	return db.queryOutAccountList(ledgerID, `oa.ledger_id = ? AND DATE(oa.date) >= ? AND DATE(oa.date) <= ?
+    AND (k.name LIKE ? OR oa.memo LIKE ?)`,
+		[]interface{}{ledgerID, startDate, endDate, keywordPattern, keywordPattern}, opts, "키워드 지출 데이터 검색")
 }
 
 // UpdateOutAccount 지출 데이터 업데이트
-func (db *DB) UpdateOutAccount(uuidStr, date, user string, money, categoryID int, keywordID *int, paymentMethodID int, memo string) error {
+func (db *DB) UpdateOutAccount(ledgerID int, uuidStr, date, user string, money, categoryID int, keywordID *int, paymentMethodID int, memo string) error {
 	parsedDate, err := utils.ParseDateTimeKST(date)
 	if err != nil {
 		utils.LogError("지출 업데이트 날짜 파싱", err)
@@ -300,16 +248,26 @@ func (db *DB) UpdateOutAccount(uuidStr, date, user string, money, categoryID int
 	}
 	formattedDate := utils.FormatDateTimeKST(parsedDate)
 
+	if err := ensureOutAccountReferences(db.Conn, ledgerID, categoryID, paymentMethodID); err != nil {
+		return err
+	}
+	if err := ensureNotInstallmentPortion(db.Conn, ledgerID, uuidStr); err != nil {
+		return err
+	}
+	if err := ensureSplitTotal(db.Conn, uuidStr, money); err != nil {
+		return err
+	}
+
 	updateQuery := `
     UPDATE out_account_data
     SET date = ?, money = ?, user = ?, category_id = ?, keyword_id = ?, payment_method_id = ?, memo = ?, updated_at = CURRENT_TIMESTAMP
-    WHERE uuid = ?`
+    WHERE uuid = ? AND ledger_id = ?`
 
 	// 디버깅용 상세 로깅
 	utils.Debug("지출 데이터 업데이트 시도: UUID=%s, Date=%s, User=%s, Money=%d, CategoryID=%d, KeywordID=%v, PaymentMethodID=%d, Memo=%s",
 		uuidStr, formattedDate, user, money, categoryID, keywordID, paymentMethodID, memo)
 
-	result, err := db.Conn.Exec(updateQuery, formattedDate, money, user, categoryID, keywordID, paymentMethodID, memo, uuidStr)
+	result, err := db.Conn.Exec(updateQuery, formattedDate, money, user, categoryID, keywordID, paymentMethodID, memo, uuidStr, ledgerID)
 	if err != nil {
 		utils.LogError("지출 데이터 SQL 업데이트 실행", err)
 		utils.Debug("실패한 지출 업데이트 SQL: %s", updateQuery)
@@ -331,14 +289,29 @@ func (db *DB) UpdateOutAccount(uuidStr, date, user string, money, categoryID int
 		return fmt.Errorf("no rows affected")
 	}
 
+	// 분할된 지출은 요청의 카테고리 대신 항목 중 대표 카테고리 유지
+	if err := syncSplitPrimary(db.Conn, uuidStr); err != nil {
+		return err
+	}
+
 	utils.Debug("지출 데이터 업데이트 성공: UUID=%s", uuidStr)
 	return nil
 }
 
 // DeleteOutAccount 지출 데이터 삭제
-func (db *DB) DeleteOutAccount(uuidStr string) error {
-	deleteQuery := `DELETE FROM out_account_data WHERE uuid = ?`
-	result, err := db.Conn.Exec(deleteQuery, uuidStr)
+func (db *DB) DeleteOutAccount(ledgerID int, uuidStr string) error {
+	if err := ensureNotInstallmentPortion(db.Conn, ledgerID, uuidStr); err != nil {
+		return err
+	}
+
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	deleteQuery := `DELETE FROM out_account_data WHERE uuid = ? AND ledger_id = ?`
+	result, err := tx.Exec(deleteQuery, uuidStr, ledgerID)
 	if err != nil {
 		return fmt.Errorf("지출 데이터 삭제 오류: %v", err)
 	}
@@ -352,13 +325,34 @@ func (db *DB) DeleteOutAccount(uuidStr string) error {
 		return fmt.Errorf("no rows affected")
 	}
 
+	if err := deleteOutAccountSplits(tx, uuidStr); err != nil {
+		return err
+	}
+	if err := deleteTransactionTags(tx, models.AccountTypeOut, uuidStr); err != nil {
+		return err
+	}
+	if err := deleteOutAccountReimbursement(tx, uuidStr); err != nil {
+		return err
+	}
+	if err := deleteTransactionCurrency(tx, models.AccountTypeOut, uuidStr); err != nil {
+		return err
+	}
+	attachmentFiles, err := deleteTransactionAttachments(tx, models.AccountTypeOut, uuidStr)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	db.removeAttachmentFiles(attachmentFiles)
 	return nil
 }
 
 // GetOutAccountByUUID UUID로 지출 데이터 조회
-func (db *DB) GetOutAccountByUUID(uuidStr string) (*models.OutAccount, error) {
+func (db *DB) GetOutAccountByUUID(ledgerID int, uuidStr string) (*models.OutAccount, error) {
 	query := `
-    SELECT oa.uuid, oa.date, oa.user, oa.money, oa.category_id, oa.keyword_id, oa.payment_method_id, oa.memo, oa.created_at, oa.updated_at,
+    SELECT oa.uuid, oa.date, oa.user, oa.money, oa.category_id, oa.keyword_id, oa.payment_method_id, oa.memo, oa.installment_id, oa.installment_seq, oa.created_at, oa.updated_at,
            c.name as category_name,
            COALESCE(k.name, '') as keyword_name,
            pm.name as payment_method_name
@@ -366,13 +360,13 @@ func (db *DB) GetOutAccountByUUID(uuidStr string) (*models.OutAccount, error) {
     LEFT JOIN categories c ON oa.category_id = c.id
     LEFT JOIN keywords k ON oa.keyword_id = k.id
     LEFT JOIN payment_methods pm ON oa.payment_method_id = pm.id
-    WHERE oa.uuid = ?`
+    WHERE oa.ledger_id = ? AND oa.uuid = ?`
 
 	var outAccount models.OutAccount
 	var keywordID *int
 
-	err := db.Conn.QueryRow(query, uuidStr).Scan(&outAccount.UUID, &outAccount.Date, &outAccount.User, &outAccount.Money,
-		&outAccount.CategoryID, &keywordID, &outAccount.PaymentMethodID, &outAccount.Memo,
+	err := db.Conn.QueryRow(query, ledgerID, uuidStr).Scan(&outAccount.UUID, &outAccount.Date, &outAccount.User, &outAccount.Money,
+		&outAccount.CategoryID, &keywordID, &outAccount.PaymentMethodID, &outAccount.Memo, &outAccount.InstallmentID, &outAccount.InstallmentSeq,
 		&outAccount.CreatedAt, &outAccount.UpdatedAt,
 		&outAccount.CategoryName, &outAccount.KeywordName, &outAccount.PaymentMethodName)
 	if err != nil {
@@ -380,5 +374,24 @@ func (db *DB) GetOutAccountByUUID(uuidStr string) (*models.OutAccount, error) {
 	}
 
 	outAccount.KeywordID = keywordID
-	return &outAccount, nil
+	splits, err := loadOutAccountSplits(db.Conn, ledgerID, []string{uuidStr})
+	if err != nil {
+		return nil, err
+	}
+	outAccount.Splits = splits[uuidStr]
+
+	tags, err := loadTransactionTags(db.Conn, models.AccountTypeOut, []string{uuidStr})
+	if err != nil {
+		return nil, err
+	}
+	outAccount.Tags = tags[uuidStr]
+
+	accounts := []models.OutAccount{outAccount}
+	if err := attachReimbursementStatus(db.Conn, accounts); err != nil {
+		return nil, err
+	}
+	if err := attachOutAccountCurrencies(db.Conn, accounts); err != nil {
+		return nil, err
+	}
+	return &accounts[0], nil
 }
@@ -8,15 +8,15 @@ import (
 )
 
 // GetPaymentMethods 결제수단 목록 조회 (계층구조)
-func (db *DB) GetPaymentMethods() ([]models.PaymentMethod, error) {
+func (db *DB) GetPaymentMethods(ledgerID int) ([]models.PaymentMethod, error) {
 	// 1단계: 부모 결제수단들 조회
 	parentQuery := `
-		SELECT id, name, parent_id, is_active, created_at, updated_at
+		SELECT id, name, parent_id, is_active, statement_closing_day, payment_due_day, created_at, updated_at
 		FROM payment_methods 
-		WHERE parent_id IS NULL AND is_active = TRUE
+		WHERE ledger_id = ? AND parent_id IS NULL AND is_active = TRUE
 		ORDER BY name ASC`
 
-	parentRows, err := db.Conn.Query(parentQuery)
+	parentRows, err := db.Conn.Query(parentQuery, ledgerID)
 	if err != nil {
 		return nil, fmt.Errorf("부모 결제수단 조회 오류: %v", err)
 	}
@@ -27,7 +27,8 @@ func (db *DB) GetPaymentMethods() ([]models.PaymentMethod, error) {
 		var method models.PaymentMethod
 		var createdAt, updatedAt string
 
-		err := parentRows.Scan(&method.ID, &method.Name, &method.ParentID, &method.IsActive, &createdAt, &updatedAt)
+		err := parentRows.Scan(&method.ID, &method.Name, &method.ParentID, &method.IsActive,
+			&method.StatementClosingDay, &method.PaymentDueDay, &createdAt, &updatedAt)
 		if err != nil {
 			return nil, fmt.Errorf("부모 결제수단 데이터 읽기 오류: %v", err)
 		}
@@ -42,7 +43,7 @@ func (db *DB) GetPaymentMethods() ([]models.PaymentMethod, error) {
 
 		// 2단계: 각 부모의 자식 결제수단들 조회
 		childQuery := `
-			SELECT id, name, parent_id, is_active, created_at, updated_at
+			SELECT id, name, parent_id, is_active, statement_closing_day, payment_due_day, created_at, updated_at
 			FROM payment_methods 
 			WHERE parent_id = ? AND is_active = TRUE
 			ORDER BY name ASC`
@@ -57,7 +58,8 @@ func (db *DB) GetPaymentMethods() ([]models.PaymentMethod, error) {
 			var child models.PaymentMethod
 			var childCreatedAt, childUpdatedAt string
 
-			err := childRows.Scan(&child.ID, &child.Name, &child.ParentID, &child.IsActive, &childCreatedAt, &childUpdatedAt)
+			err := childRows.Scan(&child.ID, &child.Name, &child.ParentID, &child.IsActive,
+				&child.StatementClosingDay, &child.PaymentDueDay, &childCreatedAt, &childUpdatedAt)
 			if err != nil {
 				childRows.Close()
 				return nil, fmt.Errorf("자식 결제수단 데이터 읽기 오류: %v", err)
@@ -83,11 +85,18 @@ func (db *DB) GetPaymentMethods() ([]models.PaymentMethod, error) {
 }
 
 // CreatePaymentMethod 결제수단 생성
-func (db *DB) CreatePaymentMethod(name string, parentID *int) (int64, error) {
+func (db *DB) CreatePaymentMethod(ledgerID int, name string, parentID *int) (int64, error) {
+	// 부모 결제수단은 같은 가계부에 속해야 함
+	if parentID != nil {
+		if err := ensureLedgerRow(db.Conn, "payment_methods", *parentID, ledgerID); err != nil {
+			return 0, err
+		}
+	}
+
 	// 중복 이름 확인 (같은 부모 하에서)
-	checkQuery := `SELECT COUNT(*) FROM payment_methods WHERE name = ? AND parent_id = ? AND is_active = 1`
+	checkQuery := `SELECT COUNT(*) FROM payment_methods WHERE ledger_id = ? AND name = ? AND parent_id = ? AND is_active = 1`
 	var count int
-	err := db.Conn.QueryRow(checkQuery, name, parentID).Scan(&count)
+	err := db.Conn.QueryRow(checkQuery, ledgerID, name, parentID).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("결제수단 중복 확인 오류: %v", err)
 	}
@@ -97,10 +106,10 @@ func (db *DB) CreatePaymentMethod(name string, parentID *int) (int64, error) {
 	}
 
 	query := `
-		INSERT INTO payment_methods (name, parent_id, is_active, created_at, updated_at) 
-		VALUES (?, ?, TRUE, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+		INSERT INTO payment_methods (ledger_id, name, parent_id, is_active, created_at, updated_at) 
+		VALUES (?, ?, ?, TRUE, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
 
-	result, err := db.Conn.Exec(query, name, parentID)
+	result, err := db.Conn.Exec(query, ledgerID, name, parentID)
 	if err != nil {
 		return 0, fmt.Errorf("결제수단 생성 오류: %v", err)
 	}
@@ -114,11 +123,11 @@ func (db *DB) CreatePaymentMethod(name string, parentID *int) (int64, error) {
 }
 
 // UpdatePaymentMethod 결제수단 수정
-func (db *DB) UpdatePaymentMethod(id int, name string) error {
+func (db *DB) UpdatePaymentMethod(ledgerID int, id int, name string) error {
 	// 중복 이름 확인 (자기 자신 제외)
-	checkQuery := `SELECT COUNT(*) FROM payment_methods WHERE name = ? AND id != ? AND is_active = 1`
+	checkQuery := `SELECT COUNT(*) FROM payment_methods WHERE ledger_id = ? AND name = ? AND id != ? AND is_active = 1`
 	var count int
-	err := db.Conn.QueryRow(checkQuery, name, id).Scan(&count)
+	err := db.Conn.QueryRow(checkQuery, ledgerID, name, id).Scan(&count)
 	if err != nil {
 		return fmt.Errorf("결제수단 중복 확인 오류: %v", err)
 	}
@@ -130,9 +139,9 @@ func (db *DB) UpdatePaymentMethod(id int, name string) error {
 	query := `
 		UPDATE payment_methods 
 		SET name = ?, updated_at = CURRENT_TIMESTAMP 
-		WHERE id = ?`
+		WHERE id = ? AND ledger_id = ?`
 
-	result, err := db.Conn.Exec(query, name, id)
+	result, err := db.Conn.Exec(query, name, id, ledgerID)
 	if err != nil {
 		return fmt.Errorf("결제수단 수정 오류: %v", err)
 	}
@@ -150,11 +159,11 @@ func (db *DB) UpdatePaymentMethod(id int, name string) error {
 }
 
 // CheckPaymentMethodExists 결제수단 존재 여부 확인
-func (db *DB) CheckPaymentMethodExists(id int) (bool, error) {
-	query := `SELECT COUNT(*) FROM payment_methods WHERE id = ? AND is_active = 1`
+func (db *DB) CheckPaymentMethodExists(ledgerID int, id int) (bool, error) {
+	query := `SELECT COUNT(*) FROM payment_methods WHERE id = ? AND ledger_id = ? AND is_active = 1`
 
 	var count int
-	err := db.Conn.QueryRow(query, id).Scan(&count)
+	err := db.Conn.QueryRow(query, id, ledgerID).Scan(&count)
 	if err != nil {
 		return false, fmt.Errorf("결제수단 존재 여부 확인 오류: %v", err)
 	}
@@ -163,14 +172,14 @@ func (db *DB) CheckPaymentMethodExists(id int) (bool, error) {
 }
 
 // CheckPaymentMethodUsage 결제수단 사용 여부 확인
-func (db *DB) CheckPaymentMethodUsage(paymentMethodID int) (bool, error) {
+func (db *DB) CheckPaymentMethodUsage(ledgerID int, paymentMethodID int) (bool, error) {
 	query := `
 		SELECT COUNT(*) 
 		FROM out_account_data 
-		WHERE payment_method_id = ?`
+		WHERE ledger_id = ? AND payment_method_id = ?`
 
 	var count int
-	err := db.Conn.QueryRow(query, paymentMethodID).Scan(&count)
+	err := db.Conn.QueryRow(query, ledgerID, paymentMethodID).Scan(&count)
 	if err != nil {
 		return false, fmt.Errorf("결제수단 사용 여부 확인 오류: %v", err)
 	}
@@ -179,13 +188,13 @@ func (db *DB) CheckPaymentMethodUsage(paymentMethodID int) (bool, error) {
 }
 
 // DeletePaymentMethod 결제수단 논리 삭제
-func (db *DB) DeletePaymentMethod(id int) error {
+func (db *DB) DeletePaymentMethod(ledgerID int, id int) error {
 	query := `
 		UPDATE payment_methods 
 		SET is_active = 0, updated_at = CURRENT_TIMESTAMP 
-		WHERE id = ?`
+		WHERE id = ? AND ledger_id = ?`
 
-	result, err := db.Conn.Exec(query, id)
+	result, err := db.Conn.Exec(query, id, ledgerID)
 	if err != nil {
 		return fmt.Errorf("결제수단 삭제 오류: %v", err)
 	}
@@ -203,13 +212,13 @@ func (db *DB) DeletePaymentMethod(id int) error {
 }
 
 // ForceDeletePaymentMethod 결제수단 강제 삭제 (사용 중인 경우)
-func (db *DB) ForceDeletePaymentMethod(id int) error {
+func (db *DB) ForceDeletePaymentMethod(ledgerID int, id int) error {
 	query := `
 		UPDATE payment_methods 
 		SET is_active = 0, updated_at = CURRENT_TIMESTAMP 
-		WHERE id = ?`
+		WHERE id = ? AND ledger_id = ?`
 
-	result, err := db.Conn.Exec(query, id)
+	result, err := db.Conn.Exec(query, id, ledgerID)
 	if err != nil {
 		return fmt.Errorf("결제수단 강제 삭제 오류: %v", err)
 	}
@@ -227,17 +236,17 @@ func (db *DB) ForceDeletePaymentMethod(id int) error {
 }
 
 // GetPaymentMethodByID ID로 결제수단 조회
-func (db *DB) GetPaymentMethodByID(id int) (*models.PaymentMethod, error) {
+func (db *DB) GetPaymentMethodByID(ledgerID int, id int) (*models.PaymentMethod, error) {
 	query := `
-		SELECT id, name, is_active, created_at, updated_at
+		SELECT id, name, parent_id, is_active, statement_closing_day, payment_due_day, created_at, updated_at
 		FROM payment_methods 
-		WHERE id = ? AND is_active = 1`
+		WHERE id = ? AND ledger_id = ? AND is_active = 1`
 
 	var method models.PaymentMethod
 	var createdAt, updatedAt string
 
-	err := db.Conn.QueryRow(query, id).Scan(&method.ID, &method.Name,
-		&method.IsActive, &createdAt, &updatedAt)
+	err := db.Conn.QueryRow(query, id, ledgerID).Scan(&method.ID, &method.Name, &method.ParentID,
+		&method.IsActive, &method.StatementClosingDay, &method.PaymentDueDay, &createdAt, &updatedAt)
 	if err != nil {
 		return nil, fmt.Errorf("결제수단 조회 오류: %v", err)
 	}
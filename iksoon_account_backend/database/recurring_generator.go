@@ -0,0 +1,276 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// recurringOccurrence 정기 거래 생성 대상 (생성일 + 중복 판단용 주기 정보)
+type recurringOccurrence struct {
+	date        time.Time
+	periodKey   string
+	periodStart time.Time
+	periodEnd   time.Time
+}
+
+// GenerateRecurringTransactions 모든 가계부의 활성화된 정기 거래 규칙으로 now 시점까지의 거래를 생성
+// 마지막 생성일 이후 놓친 주기도 모두 따라잡으며, 해당 주기에 이미 같은 거래가 있으면 건너뜀
+func (db *DB) GenerateRecurringTransactions(now time.Time) (*models.RecurringGenerationResult, error) {
+	rules, err := db.listRecurringRules("")
+	if err != nil {
+		return nil, err
+	}
+
+	today := utils.StartOfDayKST(now)
+	result := &models.RecurringGenerationResult{}
+
+	for _, rule := range rules {
+		if !rule.IsActive {
+			continue
+		}
+		result.RulesChecked++
+
+		occurrences, err := recurringOccurrences(rule, today)
+		if err != nil {
+			utils.LogError(fmt.Sprintf("정기 거래 규칙 %d 주기 계산", rule.ID), err)
+			result.Failed++
+			continue
+		}
+
+		// 결제수단/입금경로가 없어진 규칙은 매번 실패하지 않도록 비활성화하고 밀린 주기를 건너뜀
+		if reason := missingRecurringReference(rule); reason != "" && len(occurrences) > 0 {
+			last := occurrences[len(occurrences)-1]
+			if err := db.deactivateRecurringRule(rule.ID, last.date); err != nil {
+				utils.LogError(fmt.Sprintf("정기 거래 규칙 %d 비활성화", rule.ID), err)
+				result.Failed++
+				continue
+			}
+			utils.Warning("정기 거래 규칙 %d 비활성화: %s (%s까지 %d건 생성하지 않음)", rule.ID, reason, utils.FormatDateKST(last.date), len(occurrences))
+			result.Deactivated++
+			continue
+		}
+
+		for _, occ := range occurrences {
+			created, err := db.generateRecurringOccurrence(rule, occ)
+			if err != nil {
+				utils.LogError(fmt.Sprintf("정기 거래 규칙 %d 생성 (%s)", rule.ID, occ.periodKey), err)
+				result.Failed++
+				break // 다음 실행에서 같은 주기부터 다시 시도
+			}
+			if created {
+				result.Created++
+			} else {
+				result.Skipped++
+			}
+		}
+	}
+
+	if result.Created > 0 || result.Failed > 0 || result.Deactivated > 0 {
+		utils.Info("정기 거래 생성 완료: 규칙 %d개, 생성 %d건, 건너뜀 %d건, 실패 %d건, 비활성화 %d개",
+			result.RulesChecked, result.Created, result.Skipped, result.Failed, result.Deactivated)
+	}
+
+	return result, nil
+}
+
+// generateRecurringOccurrence 한 주기의 거래를 트랜잭션으로 생성 (생성 시 true, 건너뛰면 false)
+func (db *DB) generateRecurringOccurrence(rule models.RecurringRule, occ recurringOccurrence) (bool, error) {
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return false, fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	occurrenceDate := utils.FormatDateKST(occ.date)
+	created := false
+
+	var exists int
+	err = tx.QueryRow(`SELECT 1 FROM recurring_occurrences WHERE rule_id = ? AND period_key = ?`, rule.ID, occ.periodKey).Scan(&exists)
+	switch {
+	case err == nil:
+		// 이미 처리된 주기
+	case err != sql.ErrNoRows:
+		return false, fmt.Errorf("정기 거래 생성 이력 조회 오류: %v", err)
+	default:
+		accountUUID, err := findRecurringMatch(tx, rule, occ)
+		if err != nil {
+			return false, err
+		}
+
+		status := "matched"
+		if accountUUID == "" {
+			if rule.Type == "out" {
+				accountUUID, err = insertOutAccount(tx, rule.LedgerID, occurrenceDate, rule.User, rule.Money, rule.CategoryID, rule.KeywordID, *rule.PaymentMethodID, rule.Memo)
+			} else {
+				accountUUID, err = insertInAccount(tx, rule.LedgerID, occurrenceDate, rule.User, rule.Money, rule.CategoryID, rule.KeywordID, *rule.DepositPathID, rule.Memo)
+			}
+			if err != nil {
+				return false, err
+			}
+			status = "created"
+			created = true
+		}
+
+		_, err = tx.Exec(`
+			INSERT INTO recurring_occurrences (rule_id, period_key, occurrence_date, account_uuid, status, created_at)
+			VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP)`,
+			rule.ID, occ.periodKey, occurrenceDate, accountUUID, status)
+		if err != nil {
+			return false, fmt.Errorf("정기 거래 생성 이력 저장 오류: %v", err)
+		}
+	}
+
+	_, err = tx.Exec(`UPDATE recurring_rules SET last_generated_date = ? WHERE id = ?`, occurrenceDate, rule.ID)
+	if err != nil {
+		return false, fmt.Errorf("정기 거래 마지막 생성일 갱신 오류: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("트랜잭션 커밋 오류: %v", err)
+	}
+
+	return created, nil
+}
+
+// missingRecurringReference 거래 생성에 필요한 결제수단(지출)/입금경로(수입)가 없으면 이유 반환
+// (규칙 저장 시 검증하지만 이후 결제수단/입금경로가 삭제될 수 있음)
+func missingRecurringReference(rule models.RecurringRule) string {
+	if rule.Type == "out" && (rule.PaymentMethodID == nil || rule.PaymentMethodName == "") {
+		return "결제수단이 없습니다"
+	}
+	if rule.Type == "in" && (rule.DepositPathID == nil || rule.DepositPathName == "") {
+		return "입금경로가 없습니다"
+	}
+	return ""
+}
+
+// deactivateRecurringRule 규칙을 비활성화하고 마지막 생성일을 skipUntil 로 옮겨 밀린 주기를 건너뜀
+// (결제수단/입금경로를 다시 지정해 활성화하면 그 이후 주기부터 생성)
+func (db *DB) deactivateRecurringRule(id int, skipUntil time.Time) error {
+	_, err := db.Conn.Exec(`
+		UPDATE recurring_rules SET is_active = 0, last_generated_date = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?`, utils.FormatDateKST(skipUntil), id)
+	if err != nil {
+		return fmt.Errorf("정기 거래 규칙 비활성화 오류: %v", err)
+	}
+	return nil
+}
+
+// findRecurringMatch 같은 주기에 동일한 거래(카테고리/사용자/금액)가 이미 있으면 해당 UUID 반환
+func findRecurringMatch(exec sqlExecutor, rule models.RecurringRule, occ recurringOccurrence) (string, error) {
+	table := "out_account_data"
+	if rule.Type == "in" {
+		table = "in_account_data"
+	}
+
+	query := fmt.Sprintf(`
+		SELECT uuid FROM %s
+		WHERE ledger_id = ? AND category_id = ? AND user = ? AND money = ?
+		AND DATE(date) >= ? AND DATE(date) <= ?
+		LIMIT 1`, table)
+
+	var accountUUID string
+	err := exec.QueryRow(query, rule.LedgerID, rule.CategoryID, rule.User, rule.Money,
+		utils.FormatDateKST(occ.periodStart), utils.FormatDateKST(occ.periodEnd)).Scan(&accountUUID)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("기존 거래 중복 확인 오류: %v", err)
+	}
+	return accountUUID, nil
+}
+
+// recurringOccurrences 마지막 생성일(없으면 시작일)부터 today까지 규칙에 해당하는 생성일 목록 계산
+func recurringOccurrences(rule models.RecurringRule, today time.Time) ([]recurringOccurrence, error) {
+	startDate, err := utils.ParseDateTimeKST(rule.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("시작일 파싱 오류: %v", err)
+	}
+	from := utils.StartOfDayKST(startDate)
+
+	if rule.LastGeneratedDate != nil && *rule.LastGeneratedDate != "" {
+		lastGenerated, err := utils.ParseDateTimeKST(*rule.LastGeneratedDate)
+		if err != nil {
+			return nil, fmt.Errorf("마지막 생성일 파싱 오류: %v", err)
+		}
+		if next := utils.StartOfDayKST(lastGenerated).AddDate(0, 0, 1); next.After(from) {
+			from = next
+		}
+	}
+
+	until := today
+	if rule.EndDate != nil && *rule.EndDate != "" {
+		endDate, err := utils.ParseDateTimeKST(*rule.EndDate)
+		if err != nil {
+			return nil, fmt.Errorf("종료일 파싱 오류: %v", err)
+		}
+		if endDate = utils.StartOfDayKST(endDate); endDate.Before(until) {
+			until = endDate
+		}
+	}
+
+	var occurrences []recurringOccurrence
+	if from.After(until) {
+		return occurrences, nil
+	}
+
+	loc := from.Location()
+	switch rule.Frequency {
+	case models.RecurringFrequencyMonthly:
+		for cursor := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, loc); !cursor.After(until); cursor = cursor.AddDate(0, 1, 0) {
+			date := clampDayOfMonth(cursor.Year(), cursor.Month(), rule.DayOfMonth, loc)
+			if date.Before(from) || date.After(until) {
+				continue
+			}
+			occurrences = append(occurrences, recurringOccurrence{
+				date:        date,
+				periodKey:   date.Format("2006-01"),
+				periodStart: cursor,
+				periodEnd:   cursor.AddDate(0, 1, -1),
+			})
+		}
+	case models.RecurringFrequencyWeekly:
+		offset := (rule.DayOfWeek - int(from.Weekday()) + 7) % 7
+		for date := from.AddDate(0, 0, offset); !date.After(until); date = date.AddDate(0, 0, 7) {
+			// 주 단위 중복 판단은 월요일~일요일 기준
+			weekStart := date.AddDate(0, 0, -((int(date.Weekday()) + 6) % 7))
+			occurrences = append(occurrences, recurringOccurrence{
+				date:        date,
+				periodKey:   date.Format("2006-01-02"),
+				periodStart: weekStart,
+				periodEnd:   weekStart.AddDate(0, 0, 6),
+			})
+		}
+	case models.RecurringFrequencyYearly:
+		for year := from.Year(); year <= until.Year(); year++ {
+			date := clampDayOfMonth(year, time.Month(rule.MonthOfYear), rule.DayOfMonth, loc)
+			if date.Before(from) || date.After(until) {
+				continue
+			}
+			occurrences = append(occurrences, recurringOccurrence{
+				date:        date,
+				periodKey:   date.Format("2006"),
+				periodStart: time.Date(year, 1, 1, 0, 0, 0, 0, loc),
+				periodEnd:   time.Date(year, 12, 31, 0, 0, 0, 0, loc),
+			})
+		}
+	default:
+		return nil, fmt.Errorf("지원되지 않는 주기: %s", rule.Frequency)
+	}
+
+	return occurrences, nil
+}
+
+// clampDayOfMonth 해당 월의 말일을 넘는 일자는 말일로 보정 (예: 31일 규칙의 2월 → 28/29일)
+func clampDayOfMonth(year int, month time.Month, day int, loc *time.Location) time.Time {
+	lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, loc).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
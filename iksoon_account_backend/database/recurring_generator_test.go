@@ -0,0 +1,101 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"iksoon_account_backend/models"
+)
+
+func TestCreateRecurringRuleRequiresPaymentSource(t *testing.T) {
+	db := newTestDB(t)
+	rent := createTestCategory(t, db, "테스트 월세", "out")
+	salary := createTestCategory(t, db, "테스트 급여", "in")
+	missingMethodID := 9999
+
+	tests := []struct {
+		name string
+		req  models.RecurringRuleRequest
+	}{
+		{"결제수단 없는 지출 규칙", models.RecurringRuleRequest{Type: "out", CategoryID: rent}},
+		{"입금경로 없는 수입 규칙", models.RecurringRuleRequest{Type: "in", CategoryID: salary}},
+		{"없는 결제수단", models.RecurringRuleRequest{Type: "out", CategoryID: rent, PaymentMethodID: &missingMethodID}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.Frequency = models.RecurringFrequencyMonthly
+			tt.req.DayOfMonth = 25
+			tt.req.Money = 500000
+			tt.req.User = "테스트"
+			tt.req.StartDate = "2024-01-01"
+
+			if _, err := db.CreateRecurringRule(DefaultLedgerID, tt.req); err == nil {
+				t.Fatal("규칙이 생성됨, want 오류")
+			}
+		})
+	}
+}
+
+func TestGenerateRecurringDeactivatesRuleWithoutPaymentMethod(t *testing.T) {
+	db := newTestDB(t)
+	rent := createTestCategory(t, db, "테스트 월세", "out")
+	methodID := createTestPaymentMethod(t, db, "테스트 통장이체")
+
+	ruleID, err := db.CreateRecurringRule(DefaultLedgerID, models.RecurringRuleRequest{
+		Type: "out", Frequency: models.RecurringFrequencyMonthly, DayOfMonth: 25, Money: 500000,
+		User: "테스트", CategoryID: rent, PaymentMethodID: &methodID, StartDate: "2024-01-01",
+	})
+	if err != nil {
+		t.Fatalf("CreateRecurringRule() error = %v", err)
+	}
+
+	// 규칙 저장 뒤 결제수단 연결이 끊긴 경우 (이전 버전 데이터 등)
+	if _, err := db.Conn.Exec(`UPDATE recurring_rules SET payment_method_id = NULL WHERE id = ?`, ruleID); err != nil {
+		t.Fatalf("결제수단 제거 실패: %v", err)
+	}
+
+	now := time.Date(2024, 3, 28, 9, 0, 0, 0, time.UTC)
+	result, err := db.GenerateRecurringTransactions(now)
+	if err != nil {
+		t.Fatalf("GenerateRecurringTransactions() error = %v", err)
+	}
+	if result.Deactivated != 1 || result.Failed != 0 || result.Created != 0 {
+		t.Errorf("결과 = %+v, want 비활성화 1, 실패 0, 생성 0", *result)
+	}
+
+	rule, err := db.GetRecurringRuleByID(DefaultLedgerID, int(ruleID))
+	if err != nil {
+		t.Fatalf("GetRecurringRuleByID() error = %v", err)
+	}
+	if rule.IsActive {
+		t.Error("결제수단 없는 규칙이 활성 상태로 남음")
+	}
+	if rule.LastGeneratedDate == nil || *rule.LastGeneratedDate != "2024-03-25" {
+		t.Errorf("마지막 생성일 = %v, want 2024-03-25 (밀린 주기 건너뜀)", rule.LastGeneratedDate)
+	}
+
+	// 다음 실행부터는 대상에서 빠져 실패가 반복되지 않음
+	result, err = db.GenerateRecurringTransactions(now.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatalf("GenerateRecurringTransactions() error = %v", err)
+	}
+	if result.RulesChecked != 0 || result.Failed != 0 {
+		t.Errorf("다음 실행 결과 = %+v, want 확인 0, 실패 0", *result)
+	}
+
+	// 결제수단을 다시 지정해 활성화하면 건너뛴 주기 이후부터 생성
+	active := true
+	if err := db.UpdateRecurringRule(DefaultLedgerID, int(ruleID), models.RecurringRuleRequest{
+		Type: "out", Frequency: models.RecurringFrequencyMonthly, DayOfMonth: 25, Money: 500000,
+		User: "테스트", CategoryID: rent, PaymentMethodID: &methodID, StartDate: "2024-01-01", IsActive: &active,
+	}); err != nil {
+		t.Fatalf("UpdateRecurringRule() error = %v", err)
+	}
+	result, err = db.GenerateRecurringTransactions(now.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatalf("GenerateRecurringTransactions() error = %v", err)
+	}
+	if result.Created != 1 {
+		t.Errorf("재활성화 후 생성 = %d, want 1 (4월분만)", result.Created)
+	}
+}
@@ -0,0 +1,270 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// recurringRuleSelectQuery 정기 거래 규칙 조회 공통 쿼리 (이름 조인 포함)
+const recurringRuleSelectQuery = `
+    SELECT rr.id, rr.ledger_id, rr.type, rr.frequency, rr.day_of_month, rr.day_of_week, rr.month_of_year,
+           rr.money, rr.user, rr.category_id, rr.keyword_id, rr.payment_method_id, rr.deposit_path_id,
+           COALESCE(rr.memo, ''), rr.start_date, rr.end_date, rr.last_generated_date, rr.is_active,
+           rr.created_at, rr.updated_at,
+           COALESCE(c.name, ''), COALESCE(k.name, ''), COALESCE(pm.name, ''), COALESCE(dp.name, '')
+    FROM recurring_rules rr
+    LEFT JOIN categories c ON rr.category_id = c.id
+    LEFT JOIN keywords k ON rr.keyword_id = k.id
+    LEFT JOIN payment_methods pm ON rr.payment_method_id = pm.id
+    LEFT JOIN deposit_paths dp ON rr.deposit_path_id = dp.id`
+
+// scanRecurringRule 정기 거래 규칙 행 스캔
+func scanRecurringRule(scanner interface{ Scan(...interface{}) error }) (*models.RecurringRule, error) {
+	var rule models.RecurringRule
+	var createdAt, updatedAt string
+
+	err := scanner.Scan(&rule.ID, &rule.LedgerID, &rule.Type, &rule.Frequency, &rule.DayOfMonth, &rule.DayOfWeek, &rule.MonthOfYear,
+		&rule.Money, &rule.User, &rule.CategoryID, &rule.KeywordID, &rule.PaymentMethodID, &rule.DepositPathID,
+		&rule.Memo, &rule.StartDate, &rule.EndDate, &rule.LastGeneratedDate, &rule.IsActive,
+		&createdAt, &updatedAt,
+		&rule.CategoryName, &rule.KeywordName, &rule.PaymentMethodName, &rule.DepositPathName)
+	if err != nil {
+		return nil, err
+	}
+
+	// 시간 파싱
+	if rule.CreatedAt, err = time.Parse("2006-01-02 15:04:05", createdAt); err != nil {
+		rule.CreatedAt = time.Now()
+	}
+	if rule.UpdatedAt, err = time.Parse("2006-01-02 15:04:05", updatedAt); err != nil {
+		rule.UpdatedAt = time.Now()
+	}
+
+	return &rule, nil
+}
+
+// GetRecurringRules 가계부의 정기 거래 규칙 목록 조회 (accountType이 비어있으면 전체)
+func (db *DB) GetRecurringRules(ledgerID int, accountType string) ([]models.RecurringRule, error) {
+	filter := ` WHERE rr.ledger_id = ?`
+	args := []interface{}{ledgerID}
+	if accountType != "" {
+		filter += ` AND rr.type = ?`
+		args = append(args, accountType)
+	}
+	return db.listRecurringRules(filter, args...)
+}
+
+// listRecurringRules 조건에 맞는 정기 거래 규칙 목록 조회 (filter는 WHERE 절, 비어있으면 모든 가계부)
+func (db *DB) listRecurringRules(filter string, args ...interface{}) ([]models.RecurringRule, error) {
+	query := recurringRuleSelectQuery + filter + ` ORDER BY rr.ledger_id ASC, rr.type ASC, rr.id ASC`
+
+	rows, err := db.Conn.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("정기 거래 규칙 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	var rules []models.RecurringRule
+	for rows.Next() {
+		rule, err := scanRecurringRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("정기 거래 규칙 데이터 읽기 오류: %v", err)
+		}
+		rules = append(rules, *rule)
+	}
+
+	return rules, nil
+}
+
+// GetRecurringRuleByID ID로 정기 거래 규칙 조회
+func (db *DB) GetRecurringRuleByID(ledgerID int, id int) (*models.RecurringRule, error) {
+	rule, err := scanRecurringRule(db.Conn.QueryRow(recurringRuleSelectQuery+` WHERE rr.id = ? AND rr.ledger_id = ?`, id, ledgerID))
+	if err == sql.ErrNoRows {
+		return nil, apiErrors.ErrRecurringRuleNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("정기 거래 규칙 조회 오류: %v", err)
+	}
+	return rule, nil
+}
+
+// CreateRecurringRule 정기 거래 규칙 생성
+func (db *DB) CreateRecurringRule(ledgerID int, req models.RecurringRuleRequest) (int64, error) {
+	if err := db.validateRecurringRule(ledgerID, req); err != nil {
+		return 0, err
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	query := `
+		INSERT INTO recurring_rules (ledger_id, type, frequency, day_of_month, day_of_week, month_of_year, money, user,
+		                             category_id, keyword_id, payment_method_id, deposit_path_id, memo,
+		                             start_date, end_date, is_active, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+
+	result, err := db.Conn.Exec(query, ledgerID, req.Type, req.Frequency, req.DayOfMonth, req.DayOfWeek, req.MonthOfYear,
+		req.Money, req.User, req.CategoryID, req.KeywordID, recurringPaymentMethod(req), recurringDepositPath(req),
+		req.Memo, req.StartDate, req.EndDate, isActive)
+	if err != nil {
+		return 0, fmt.Errorf("정기 거래 규칙 생성 오류: %v", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("정기 거래 규칙 ID 조회 오류: %v", err)
+	}
+
+	return id, nil
+}
+
+// UpdateRecurringRule 정기 거래 규칙 수정 (이미 생성된 거래는 변경하지 않음)
+func (db *DB) UpdateRecurringRule(ledgerID int, id int, req models.RecurringRuleRequest) error {
+	if err := db.validateRecurringRule(ledgerID, req); err != nil {
+		return err
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	query := `
+		UPDATE recurring_rules
+		SET type = ?, frequency = ?, day_of_month = ?, day_of_week = ?, month_of_year = ?, money = ?, user = ?,
+		    category_id = ?, keyword_id = ?, payment_method_id = ?, deposit_path_id = ?, memo = ?,
+		    start_date = ?, end_date = ?, is_active = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND ledger_id = ?`
+
+	result, err := db.Conn.Exec(query, req.Type, req.Frequency, req.DayOfMonth, req.DayOfWeek, req.MonthOfYear,
+		req.Money, req.User, req.CategoryID, req.KeywordID, recurringPaymentMethod(req), recurringDepositPath(req),
+		req.Memo, req.StartDate, req.EndDate, isActive, id, ledgerID)
+	if err != nil {
+		return fmt.Errorf("정기 거래 규칙 수정 오류: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("정기 거래 규칙 수정 결과 확인 오류: %v", err)
+	}
+
+	if rowsAffected == 0 {
+		return apiErrors.ErrRecurringRuleNotFound
+	}
+
+	return nil
+}
+
+// DeleteRecurringRule 정기 거래 규칙 삭제 (생성 이력은 함께 삭제, 생성된 거래는 유지)
+func (db *DB) DeleteRecurringRule(ledgerID int, id int) error {
+	result, err := db.Conn.Exec(`DELETE FROM recurring_rules WHERE id = ? AND ledger_id = ?`, id, ledgerID)
+	if err != nil {
+		return fmt.Errorf("정기 거래 규칙 삭제 오류: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("정기 거래 규칙 삭제 결과 확인 오류: %v", err)
+	}
+
+	if rowsAffected == 0 {
+		return apiErrors.ErrRecurringRuleNotFound
+	}
+
+	return nil
+}
+
+// validateRecurringRule 정기 거래 규칙 입력값 및 참조 데이터 검증
+func (db *DB) validateRecurringRule(ledgerID int, req models.RecurringRuleRequest) error {
+	invalid := apiErrors.ErrInvalidRecurringRule
+
+	if req.Type != "out" && req.Type != "in" {
+		return invalid.WithMessage("거래 유형은 'out' 또는 'in'이어야 합니다")
+	}
+	switch req.Frequency {
+	case models.RecurringFrequencyMonthly:
+		if req.DayOfMonth < 1 || req.DayOfMonth > 31 {
+			return invalid.WithMessage("생성일은 1~31 사이여야 합니다")
+		}
+	case models.RecurringFrequencyWeekly:
+		if req.DayOfWeek < 0 || req.DayOfWeek > 6 {
+			return invalid.WithMessage("요일은 0(일)~6(토) 사이여야 합니다")
+		}
+	case models.RecurringFrequencyYearly:
+		if req.MonthOfYear < 1 || req.MonthOfYear > 12 || req.DayOfMonth < 1 || req.DayOfMonth > 31 {
+			return invalid.WithMessage("생성 월(1~12)과 일(1~31)을 확인해주세요")
+		}
+	default:
+		return invalid.WithMessage("주기는 'monthly', 'weekly', 'yearly' 중 하나여야 합니다")
+	}
+	if req.Money <= 0 {
+		return invalid.WithMessage("금액은 0보다 커야 합니다")
+	}
+	if req.User == "" {
+		return invalid.WithMessage("사용자는 필수입니다")
+	}
+
+	startDate, err := utils.ParseDateTimeKST(req.StartDate)
+	if err != nil {
+		return invalid.WithMessage("시작일 형식이 올바르지 않습니다")
+	}
+	if req.EndDate != nil && *req.EndDate != "" {
+		endDate, err := utils.ParseDateTimeKST(*req.EndDate)
+		if err != nil {
+			return invalid.WithMessage("종료일 형식이 올바르지 않습니다")
+		}
+		if endDate.Before(startDate) {
+			return apiErrors.ErrInvalidDateRange.WithMessage("종료일은 시작일 이후여야 합니다")
+		}
+	}
+
+	// 카테고리 유형이 거래 유형과 일치하는지 확인
+	var exists int
+	err = db.Conn.QueryRow("SELECT 1 FROM categories WHERE id = ? AND ledger_id = ? AND type = ? AND is_active = 1", req.CategoryID, ledgerID, req.Type).Scan(&exists)
+	if err != nil {
+		return apiErrors.ErrCategoryNotFound.WithMessage(fmt.Sprintf("존재하지 않거나 비활성화된 카테고리입니다 (ID: %d)", req.CategoryID))
+	}
+
+	if req.Type == "out" {
+		if req.PaymentMethodID == nil {
+			return invalid.WithMessage("지출 규칙은 결제수단이 필요합니다")
+		}
+		err = db.Conn.QueryRow("SELECT 1 FROM payment_methods WHERE id = ? AND ledger_id = ? AND is_active = 1", *req.PaymentMethodID, ledgerID).Scan(&exists)
+		if err != nil {
+			return apiErrors.ErrPaymentMethodNotFound
+		}
+	} else {
+		if req.DepositPathID == nil {
+			return invalid.WithMessage("수입 규칙은 입금경로가 필요합니다")
+		}
+		err = db.Conn.QueryRow("SELECT 1 FROM deposit_paths WHERE id = ? AND ledger_id = ? AND is_active = 1", *req.DepositPathID, ledgerID).Scan(&exists)
+		if err != nil {
+			return apiErrors.ErrNotFound.WithMessage("입금경로를 찾을 수 없습니다")
+		}
+	}
+
+	return nil
+}
+
+// recurringPaymentMethod 지출 규칙일 때만 결제수단 저장
+func recurringPaymentMethod(req models.RecurringRuleRequest) *int {
+	if req.Type == "out" {
+		return req.PaymentMethodID
+	}
+	return nil
+}
+
+// recurringDepositPath 수입 규칙일 때만 입금경로 저장
+func recurringDepositPath(req models.RecurringRuleRequest) *int {
+	if req.Type == "in" {
+		return req.DepositPathID
+	}
+	return nil
+}
@@ -0,0 +1,414 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// createReimbursementTable 환급 대상 지출 테이블과 환급을 뺀 통계용 뷰 생성
+// 지출 1건당 1행이며 in_account_uuid 가 비어 있으면 환급 대기, 채워져 있으면 환급 완료 (환급 수입 1건에 여러 지출 연결 가능)
+func createReimbursementTable(exec sqlExecutor) error {
+	createTable := `
+    CREATE TABLE IF NOT EXISTS reimbursements (
+        out_account_uuid TEXT PRIMARY KEY,
+        ledger_id INTEGER NOT NULL,
+        in_account_uuid TEXT NULL,
+        created_at TEXT DEFAULT CURRENT_TIMESTAMP,
+        settled_at TEXT NULL,
+        FOREIGN KEY (ledger_id) REFERENCES ledgers(id) ON DELETE CASCADE,
+        FOREIGN KEY (out_account_uuid) REFERENCES out_account_data(uuid) ON DELETE CASCADE
+    );`
+
+	if _, err := exec.Exec(createTable); err != nil {
+		return fmt.Errorf("환급 테이블 생성 오류: %v", err)
+	}
+
+	_, err := exec.Exec(`CREATE INDEX IF NOT EXISTS idx_reimbursements_in_account ON reimbursements(in_account_uuid)`)
+	if err != nil {
+		return fmt.Errorf("환급 인덱스 생성 오류: %v", err)
+	}
+
+	for _, view := range reimbursementViews {
+		if _, err := exec.Exec(view); err != nil {
+			return fmt.Errorf("환급 통계 뷰 생성 오류: %v", err)
+		}
+	}
+	return nil
+}
+
+// reimbursementViews 통계에서 환급받은 금액만 빼기 위한 뷰 (순서대로 생성)
+//   - reimbursement_shares: 환급 수입 금액(연결된 지출 합계를 넘는 부분 제외)을 연결된 지출 금액 비율로 나눈 지출별 환급액
+//     누적 합계 기준으로 나누어 반올림 오차 없이 지출별 환급액의 합이 환급 수입 금액과 같음
+//   - out_account_net_data: 환급액을 뺀 지출 (전액 환급된 지출은 제외)
+//   - out_account_net_line_items: out_account_line_items 에서 지출별 환급액을 분할 항목 금액 비율로 나누어 뺀 항목
+//   - in_account_net_data: 지출 환급에 쓰인 금액을 뺀 수입 (지출 합계를 넘는 환급 수입은 남는 금액만 수입으로 집계)
+var reimbursementViews = []string{
+	`CREATE VIEW IF NOT EXISTS reimbursement_shares AS
+    SELECT out_account_uuid, in_account_uuid,
+           running * refund / total - (running - money) * refund / total AS amount
+    FROM (
+        SELECT r.out_account_uuid, r.in_account_uuid, oa.money,
+               SUM(oa.money) OVER (PARTITION BY r.in_account_uuid ORDER BY r.out_account_uuid ROWS UNBOUNDED PRECEDING) AS running,
+               SUM(oa.money) OVER (PARTITION BY r.in_account_uuid) AS total,
+               MIN(ia.money, SUM(oa.money) OVER (PARTITION BY r.in_account_uuid)) AS refund
+        FROM reimbursements r
+        JOIN out_account_data oa ON r.out_account_uuid = oa.uuid
+        JOIN in_account_data ia ON r.in_account_uuid = ia.uuid
+    )
+    WHERE total > 0`,
+	`CREATE VIEW IF NOT EXISTS out_account_net_data AS
+    SELECT oa.uuid, oa.ledger_id, oa.date, oa.user, oa.money - COALESCE(rs.amount, 0) AS money,
+           oa.category_id, oa.keyword_id, oa.payment_method_id
+    FROM out_account_data oa
+    LEFT JOIN reimbursement_shares rs ON rs.out_account_uuid = oa.uuid
+    WHERE rs.amount IS NULL OR oa.money > rs.amount`,
+	`CREATE VIEW IF NOT EXISTS out_account_net_line_items AS
+    SELECT uuid, ledger_id, date, user,
+           money - COALESCE(running * refund / total - (running - money) * refund / total, 0) AS money,
+           category_id, keyword_id, payment_method_id
+    FROM (
+        SELECT li.uuid, li.ledger_id, li.date, li.user, li.money, li.category_id, li.keyword_id, li.payment_method_id,
+               SUM(li.money) OVER (PARTITION BY li.uuid ORDER BY li.category_id, li.keyword_id, li.money ROWS UNBOUNDED PRECEDING) AS running,
+               oa.money AS total, rs.amount AS refund
+        FROM out_account_line_items li
+        JOIN out_account_data oa ON li.uuid = oa.uuid
+        LEFT JOIN reimbursement_shares rs ON rs.out_account_uuid = li.uuid
+    )
+    WHERE refund IS NULL OR money > running * refund / total - (running - money) * refund / total`,
+	`CREATE VIEW IF NOT EXISTS in_account_net_data AS
+    SELECT ia.uuid, ia.ledger_id, ia.date, ia.user, ia.money - COALESCE(used.amount, 0) AS money,
+           ia.category_id, ia.keyword_id, ia.deposit_path_id
+    FROM in_account_data ia
+    LEFT JOIN (
+        SELECT in_account_uuid, SUM(amount) AS amount FROM reimbursement_shares GROUP BY in_account_uuid
+    ) used ON used.in_account_uuid = ia.uuid
+    WHERE used.amount IS NULL OR ia.money > used.amount`,
+}
+
+// dropReimbursementTables 환급 통계 뷰와 환급 테이블 제거
+func dropReimbursementTables(exec sqlExecutor) error {
+	steps := []string{
+		`DROP VIEW IF EXISTS in_account_net_data`,
+		`DROP VIEW IF EXISTS out_account_net_line_items`,
+		`DROP VIEW IF EXISTS out_account_net_data`,
+		`DROP VIEW IF EXISTS reimbursement_shares`,
+		`DROP TABLE IF EXISTS reimbursements`,
+	}
+	for _, step := range steps {
+		if _, err := exec.Exec(step); err != nil {
+			return fmt.Errorf("환급 테이블 제거 오류: %v", err)
+		}
+	}
+	return nil
+}
+
+// reimbursementSelectQuery 환급 목록 조회 공통 쿼리 (지출/환급 수입 정보 포함)
+const reimbursementSelectQuery = `
+    SELECT r.out_account_uuid, oa.date, oa.user, oa.money, COALESCE(c.name, ''), COALESCE(oa.memo, ''),
+           r.in_account_uuid, COALESCE(ia.date, ''), COALESCE(ia.money, 0), r.created_at, r.settled_at
+    FROM reimbursements r
+    JOIN out_account_data oa ON r.out_account_uuid = oa.uuid
+    LEFT JOIN categories c ON oa.category_id = c.id
+    LEFT JOIN in_account_data ia ON r.in_account_uuid = ia.uuid`
+
+// scanReimbursement 환급 행 스캔
+func scanReimbursement(scanner interface{ Scan(...interface{}) error }) (*models.Reimbursement, error) {
+	var reimbursement models.Reimbursement
+	err := scanner.Scan(&reimbursement.OutAccountUUID, &reimbursement.Date, &reimbursement.User, &reimbursement.Money,
+		&reimbursement.CategoryName, &reimbursement.Memo, &reimbursement.InAccountUUID, &reimbursement.InDate, &reimbursement.InMoney,
+		&reimbursement.CreatedAt, &reimbursement.SettledAt)
+	if err != nil {
+		return nil, err
+	}
+
+	reimbursement.Status = models.ReimbursementPending
+	if reimbursement.InAccountUUID != nil {
+		reimbursement.Status = models.ReimbursementSettled
+	}
+	return &reimbursement, nil
+}
+
+// GetReimbursements 환급 대상 지출 목록 조회 (status: pending/settled/빈 값은 전체, user 지정 시 해당 사용자만, 지출일 최신순)
+func (db *DB) GetReimbursements(ledgerID int, status, user string) ([]models.Reimbursement, error) {
+	conditions := []string{"r.ledger_id = ?"}
+	args := []interface{}{ledgerID}
+
+	switch status {
+	case "":
+	case models.ReimbursementPending:
+		conditions = append(conditions, "r.in_account_uuid IS NULL")
+	case models.ReimbursementSettled:
+		conditions = append(conditions, "r.in_account_uuid IS NOT NULL")
+	default:
+		return nil, apiErrors.ErrInvalidReimbursement.WithMessage("status는 'pending' 또는 'settled'여야 합니다")
+	}
+	if user != "" {
+		conditions = append(conditions, "oa.user = ?")
+		args = append(args, user)
+	}
+
+	rows, err := db.Conn.Query(reimbursementSelectQuery+`
+    WHERE `+strings.Join(conditions, " AND ")+`
+    ORDER BY oa.date DESC, r.created_at DESC`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("환급 목록 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	reimbursements := []models.Reimbursement{}
+	for rows.Next() {
+		reimbursement, err := scanReimbursement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("환급 데이터 읽기 오류: %v", err)
+		}
+		reimbursements = append(reimbursements, *reimbursement)
+	}
+	return reimbursements, rows.Err()
+}
+
+// GetReimbursement 지출 UUID로 환급 정보 조회
+func (db *DB) GetReimbursement(ledgerID int, outUUID string) (*models.Reimbursement, error) {
+	reimbursement, err := scanReimbursement(db.Conn.QueryRow(reimbursementSelectQuery+`
+    WHERE r.ledger_id = ? AND r.out_account_uuid = ?`, ledgerID, outUUID))
+	if err == sql.ErrNoRows {
+		return nil, apiErrors.ErrReimbursementNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("환급 정보 조회 오류: %v", err)
+	}
+	return reimbursement, nil
+}
+
+// MarkReimbursable 지출을 환급 대상(환급 대기)으로 지정
+func (db *DB) MarkReimbursable(ledgerID int, outUUID string) error {
+	if err := ensureReimbursableOut(db.Conn, ledgerID, outUUID); err != nil {
+		return err
+	}
+
+	_, err := db.Conn.Exec(`INSERT INTO reimbursements (out_account_uuid, ledger_id, created_at) VALUES (?, ?, CURRENT_TIMESTAMP)`, outUUID, ledgerID)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return apiErrors.ErrAlreadyExists.WithMessage("이미 환급 대상으로 지정된 지출입니다")
+		}
+		return fmt.Errorf("환급 대상 지정 오류: %v", err)
+	}
+	return nil
+}
+
+// UnmarkReimbursable 지출의 환급 대상 지정 해제 (환급 수입이 연결되어 있었다면 연결도 해제)
+func (db *DB) UnmarkReimbursable(ledgerID int, outUUID string) error {
+	result, err := db.Conn.Exec(`DELETE FROM reimbursements WHERE out_account_uuid = ? AND ledger_id = ?`, outUUID, ledgerID)
+	if err != nil {
+		return fmt.Errorf("환급 대상 해제 오류: %v", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return apiErrors.ErrReimbursementNotFound
+	}
+	return nil
+}
+
+// SettleReimbursements 환급 수입을 지출들에 연결해 환급 완료 처리 (환급 대상이 아니던 지출은 함께 지정)
+// 이미 다른 환급 수입과 연결된 지출이 있으면 전체를 취소
+func (db *DB) SettleReimbursements(ledgerID int, outUUIDs []string, inUUID string) error {
+	if inUUID == "" {
+		return apiErrors.ErrMissingRequired.WithMessage("in_uuid는 필수입니다")
+	}
+	outUUIDs = uniqueNonEmpty(outUUIDs)
+	if len(outUUIDs) == 0 {
+		return apiErrors.ErrMissingRequired.WithMessage("out_uuids는 필수입니다")
+	}
+
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	if err := ensureTransactionExists(tx, ledgerID, models.AccountTypeIn, inUUID); err != nil {
+		return err
+	}
+
+	for _, outUUID := range outUUIDs {
+		if err := ensureReimbursableOut(tx, ledgerID, outUUID); err != nil {
+			return err
+		}
+
+		var linked sql.NullString
+		err := tx.QueryRow(`SELECT in_account_uuid FROM reimbursements WHERE out_account_uuid = ?`, outUUID).Scan(&linked)
+		if err != nil && err != sql.ErrNoRows {
+			return fmt.Errorf("환급 정보 조회 오류: %v", err)
+		}
+		if linked.Valid && linked.String != inUUID {
+			return apiErrors.ErrInvalidReimbursement.WithMessage("이미 다른 환급 수입과 연결된 지출입니다").WithDetails("out_uuid=" + outUUID)
+		}
+
+		_, err = tx.Exec(`
+            INSERT INTO reimbursements (out_account_uuid, ledger_id, in_account_uuid, created_at, settled_at)
+            VALUES (?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+            ON CONFLICT(out_account_uuid) DO UPDATE SET in_account_uuid = excluded.in_account_uuid, settled_at = excluded.settled_at`,
+			outUUID, ledgerID, inUUID)
+		if err != nil {
+			return fmt.Errorf("환급 완료 처리 오류: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("환급 완료 커밋 오류: %v", err)
+	}
+	utils.Debug("환급 완료 처리: 수입=%s, 지출 %d건", inUUID, len(outUUIDs))
+	return nil
+}
+
+// UnsettleReimbursement 지출의 환급 수입 연결 해제 (환급 대기로 되돌림)
+func (db *DB) UnsettleReimbursement(ledgerID int, outUUID string) error {
+	result, err := db.Conn.Exec(`
+        UPDATE reimbursements SET in_account_uuid = NULL, settled_at = NULL
+        WHERE out_account_uuid = ? AND ledger_id = ? AND in_account_uuid IS NOT NULL`, outUUID, ledgerID)
+	if err != nil {
+		return fmt.Errorf("환급 연결 해제 오류: %v", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return apiErrors.ErrReimbursementNotFound.WithMessage("환급 완료된 지출을 찾을 수 없습니다")
+	}
+	return nil
+}
+
+// GetOutstandingReimbursements 사용자별 미환급 금액 보고서 (user 지정 시 해당 사용자만, 금액 큰 순)
+func (db *DB) GetOutstandingReimbursements(ledgerID int, user string) (*models.OutstandingReimbursementReport, error) {
+	query := `
+    SELECT oa.user, COUNT(*), SUM(oa.money), MIN(oa.date)
+    FROM reimbursements r
+    JOIN out_account_data oa ON r.out_account_uuid = oa.uuid
+    WHERE r.ledger_id = ? AND r.in_account_uuid IS NULL`
+	args := []interface{}{ledgerID}
+	if user != "" {
+		query += ` AND oa.user = ?`
+		args = append(args, user)
+	}
+	query += `
+    GROUP BY oa.user
+    ORDER BY SUM(oa.money) DESC, oa.user`
+
+	rows, err := db.Conn.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("미환급 금액 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	report := &models.OutstandingReimbursementReport{Users: []models.OutstandingReimbursement{}}
+	for rows.Next() {
+		var item models.OutstandingReimbursement
+		if err := rows.Scan(&item.User, &item.Count, &item.Amount, &item.OldestDate); err != nil {
+			return nil, fmt.Errorf("미환급 금액 데이터 읽기 오류: %v", err)
+		}
+		report.TotalCount += item.Count
+		report.TotalAmount += item.Amount
+		report.Users = append(report.Users, item)
+	}
+	return report, rows.Err()
+}
+
+// ensureReimbursableOut 환급 대상으로 지정할 수 있는 지출인지 확인 (할부 회차는 수정 시 다시 생성되므로 제외)
+func ensureReimbursableOut(exec sqlExecutor, ledgerID int, outUUID string) error {
+	if outUUID == "" {
+		return apiErrors.ErrMissingRequired.WithMessage("uuid는 필수입니다")
+	}
+
+	var installmentID sql.NullString
+	err := exec.QueryRow(`SELECT installment_id FROM out_account_data WHERE uuid = ? AND ledger_id = ?`, outUUID, ledgerID).Scan(&installmentID)
+	if err == sql.ErrNoRows {
+		return apiErrors.ErrAccountNotFound.WithMessage("지출 데이터를 찾을 수 없습니다")
+	}
+	if err != nil {
+		return fmt.Errorf("지출 데이터 조회 오류: %v", err)
+	}
+	if installmentID.Valid {
+		return apiErrors.ErrInvalidReimbursement.WithMessage("할부 회차는 환급 대상으로 지정할 수 없습니다")
+	}
+	return nil
+}
+
+// deleteOutAccountReimbursement 지출 삭제 시 환급 정보 삭제
+func deleteOutAccountReimbursement(exec sqlExecutor, outUUID string) error {
+	if _, err := exec.Exec(`DELETE FROM reimbursements WHERE out_account_uuid = ?`, outUUID); err != nil {
+		return fmt.Errorf("환급 정보 삭제 오류: %v", err)
+	}
+	return nil
+}
+
+// releaseReimbursementIncome 환급 수입 삭제 시 연결된 지출을 환급 대기로 되돌림
+func releaseReimbursementIncome(exec sqlExecutor, inUUID string) error {
+	_, err := exec.Exec(`UPDATE reimbursements SET in_account_uuid = NULL, settled_at = NULL WHERE in_account_uuid = ?`, inUUID)
+	if err != nil {
+		return fmt.Errorf("환급 연결 해제 오류: %v", err)
+	}
+	return nil
+}
+
+// attachReimbursementStatus 지출 목록에 환급 상태 채우기 (SQLite 변수 개수 제한을 피해 나누어 조회)
+func attachReimbursementStatus(exec sqlExecutor, accounts []models.OutAccount) error {
+	const chunkSize = 500
+	index := make(map[string]int, len(accounts))
+	for i := range accounts {
+		index[accounts[i].UUID] = i
+	}
+
+	for start := 0; start < len(accounts); start += chunkSize {
+		end := start + chunkSize
+		if end > len(accounts) {
+			end = len(accounts)
+		}
+
+		args := make([]interface{}, 0, end-start)
+		for _, account := range accounts[start:end] {
+			args = append(args, account.UUID)
+		}
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(args)), ", ")
+
+		rows, err := exec.Query(`
+            SELECT out_account_uuid, in_account_uuid IS NOT NULL
+            FROM reimbursements WHERE out_account_uuid IN (`+placeholders+`)`, args...)
+		if err != nil {
+			return fmt.Errorf("환급 상태 조회 오류: %v", err)
+		}
+		for rows.Next() {
+			var outUUID string
+			var settled bool
+			if err := rows.Scan(&outUUID, &settled); err != nil {
+				rows.Close()
+				return fmt.Errorf("환급 상태 읽기 오류: %v", err)
+			}
+			status := models.ReimbursementPending
+			if settled {
+				status = models.ReimbursementSettled
+			}
+			accounts[index[outUUID]].Reimbursement = status
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return fmt.Errorf("환급 상태 조회 오류: %v", err)
+		}
+	}
+	return nil
+}
+
+// uniqueNonEmpty 빈 값과 중복을 제거한 목록 (순서 유지)
+func uniqueNonEmpty(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		result = append(result, value)
+	}
+	return result
+}
@@ -0,0 +1,370 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// createSavingsGoalTable 저축 목표 테이블 생성
+// 입금경로(deposit_path_id) 또는 수입 카테고리(category_id) 중 하나로 적립액을 집계
+func createSavingsGoalTable(exec sqlExecutor) error {
+	createTable := `
+    CREATE TABLE IF NOT EXISTS savings_goals (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        ledger_id INTEGER NOT NULL,
+        name VARCHAR(100) NOT NULL,
+        target_amount INTEGER NOT NULL CHECK (target_amount > 0),
+        start_date TEXT NOT NULL,
+        target_date TEXT NOT NULL,
+        deposit_path_id INTEGER NULL,
+        category_id INTEGER NULL,
+        initial_amount INTEGER NOT NULL DEFAULT 0 CHECK (initial_amount >= 0),
+        memo TEXT NOT NULL DEFAULT '',
+        created_at TEXT DEFAULT CURRENT_TIMESTAMP,
+        updated_at TEXT DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (ledger_id) REFERENCES ledgers(id) ON DELETE CASCADE,
+        FOREIGN KEY (deposit_path_id) REFERENCES deposit_paths(id),
+        FOREIGN KEY (category_id) REFERENCES categories(id),
+        CHECK ((deposit_path_id IS NULL) != (category_id IS NULL)),
+        UNIQUE(ledger_id, name)
+    );`
+
+	if _, err := exec.Exec(createTable); err != nil {
+		return fmt.Errorf("저축 목표 테이블 생성 오류: %v", err)
+	}
+	return nil
+}
+
+// savingsGoalSelectQuery 저축 목표 조회 공통 쿼리 (입금경로/카테고리 이름 포함)
+const savingsGoalSelectQuery = `
+    SELECT g.id, g.name, g.target_amount, g.start_date, g.target_date,
+           g.deposit_path_id, COALESCE(dp.name, ''), g.category_id, COALESCE(c.name, ''),
+           g.initial_amount, g.memo, g.created_at, g.updated_at
+    FROM savings_goals g
+    LEFT JOIN deposit_paths dp ON g.deposit_path_id = dp.id
+    LEFT JOIN categories c ON g.category_id = c.id`
+
+// savingsGoalDateLayouts 목표일/시작일로 허용하는 날짜 형식
+var savingsGoalDateLayouts = []string{"2006-01-02", "2006-01", "2006/01/02", "2006/01"}
+
+// GetSavingsGoals 가계부의 저축 목표 목록 조회 (목표일 순, 오늘 기준 진행 상황 포함)
+func (db *DB) GetSavingsGoals(ledgerID int) ([]models.SavingsGoal, error) {
+	rows, err := db.Conn.Query(savingsGoalSelectQuery+` WHERE g.ledger_id = ? ORDER BY g.target_date ASC, g.id ASC`, ledgerID)
+	if err != nil {
+		return nil, fmt.Errorf("저축 목표 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	goals := []models.SavingsGoal{}
+	for rows.Next() {
+		goal, err := scanSavingsGoal(rows)
+		if err != nil {
+			return nil, fmt.Errorf("저축 목표 데이터 읽기 오류: %v", err)
+		}
+		goals = append(goals, *goal)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("저축 목표 조회 오류: %v", err)
+	}
+
+	today := utils.GetCurrentKST()
+	for i := range goals {
+		months, err := savingsGoalContributions(db.Conn, ledgerID, &goals[i], utils.FormatDateKST(today))
+		if err != nil {
+			return nil, err
+		}
+		goals[i].Progress = computeSavingsGoalProgress(&goals[i], sumSavingsGoalMonths(months), today)
+	}
+	return goals, nil
+}
+
+// GetSavingsGoalDetail 저축 목표 조회 (오늘 기준 진행 상황과 월별 적립 내역 포함)
+func (db *DB) GetSavingsGoalDetail(ledgerID, id int) (*models.SavingsGoalDetail, error) {
+	goal, err := scanSavingsGoal(db.Conn.QueryRow(savingsGoalSelectQuery+` WHERE g.id = ? AND g.ledger_id = ?`, id, ledgerID))
+	if err == sql.ErrNoRows {
+		return nil, apiErrors.ErrSavingsGoalNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("저축 목표 조회 오류: %v", err)
+	}
+
+	today := utils.GetCurrentKST()
+	months, err := savingsGoalContributions(db.Conn, ledgerID, goal, utils.FormatDateKST(today))
+	if err != nil {
+		return nil, err
+	}
+
+	cumulative := goal.InitialAmount
+	for i := range months {
+		cumulative += months[i].Amount
+		months[i].Cumulative = cumulative
+	}
+	goal.Progress = computeSavingsGoalProgress(goal, cumulative-goal.InitialAmount, today)
+
+	return &models.SavingsGoalDetail{SavingsGoal: *goal, Months: months}, nil
+}
+
+// CreateSavingsGoal 저축 목표 생성 (같은 이름의 목표가 있으면 409)
+func (db *DB) CreateSavingsGoal(ledgerID int, req models.SavingsGoalRequest) (int64, error) {
+	startDate, targetDate, err := validateSavingsGoal(db.Conn, ledgerID, &req)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := db.Conn.Exec(`
+        INSERT INTO savings_goals (ledger_id, name, target_amount, start_date, target_date, deposit_path_id, category_id, initial_amount, memo)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		ledgerID, req.Name, req.TargetAmount, startDate, targetDate, req.DepositPathID, req.CategoryID, req.InitialAmount, req.Memo)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return 0, apiErrors.ErrAlreadyExists.WithMessage("같은 이름의 저축 목표가 이미 있습니다")
+		}
+		return 0, fmt.Errorf("저축 목표 생성 오류: %v", err)
+	}
+	return result.LastInsertId()
+}
+
+// UpdateSavingsGoal 저축 목표 수정
+func (db *DB) UpdateSavingsGoal(ledgerID, id int, req models.SavingsGoalRequest) error {
+	startDate, targetDate, err := validateSavingsGoal(db.Conn, ledgerID, &req)
+	if err != nil {
+		return err
+	}
+
+	result, err := db.Conn.Exec(`
+        UPDATE savings_goals
+        SET name = ?, target_amount = ?, start_date = ?, target_date = ?, deposit_path_id = ?, category_id = ?,
+            initial_amount = ?, memo = ?, updated_at = CURRENT_TIMESTAMP
+        WHERE id = ? AND ledger_id = ?`,
+		req.Name, req.TargetAmount, startDate, targetDate, req.DepositPathID, req.CategoryID, req.InitialAmount, req.Memo, id, ledgerID)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return apiErrors.ErrAlreadyExists.WithMessage("같은 이름의 저축 목표가 이미 있습니다")
+		}
+		return fmt.Errorf("저축 목표 수정 오류: %v", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return apiErrors.ErrSavingsGoalNotFound
+	}
+	return nil
+}
+
+// DeleteSavingsGoal 저축 목표 삭제 (집계 대상 수입은 그대로 유지)
+func (db *DB) DeleteSavingsGoal(ledgerID, id int) error {
+	result, err := db.Conn.Exec(`DELETE FROM savings_goals WHERE id = ? AND ledger_id = ?`, id, ledgerID)
+	if err != nil {
+		return fmt.Errorf("저축 목표 삭제 오류: %v", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return apiErrors.ErrSavingsGoalNotFound
+	}
+	return nil
+}
+
+// scanSavingsGoal 저축 목표 행 스캔
+func scanSavingsGoal(scanner interface{ Scan(...interface{}) error }) (*models.SavingsGoal, error) {
+	var goal models.SavingsGoal
+	var depositPathID, categoryID sql.NullInt64
+	err := scanner.Scan(&goal.ID, &goal.Name, &goal.TargetAmount, &goal.StartDate, &goal.TargetDate,
+		&depositPathID, &goal.DepositPathName, &categoryID, &goal.CategoryName,
+		&goal.InitialAmount, &goal.Memo, &goal.CreatedAt, &goal.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if depositPathID.Valid {
+		id := int(depositPathID.Int64)
+		goal.DepositPathID = &id
+	}
+	if categoryID.Valid {
+		id := int(categoryID.Int64)
+		goal.CategoryID = &id
+	}
+	return &goal, nil
+}
+
+// validateSavingsGoal 저축 목표 요청 검증 후 시작일/목표일(YYYY-MM-DD) 반환
+func validateSavingsGoal(exec sqlExecutor, ledgerID int, req *models.SavingsGoalRequest) (string, string, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return "", "", apiErrors.ErrMissingRequired.WithMessage("저축 목표 이름은 필수입니다")
+	}
+	if req.TargetAmount <= 0 {
+		return "", "", apiErrors.ErrInvalidSavingsGoal.WithMessage("목표 금액은 0보다 커야 합니다")
+	}
+	if req.InitialAmount < 0 {
+		return "", "", apiErrors.ErrInvalidSavingsGoal.WithMessage("시작 금액은 0 이상이어야 합니다")
+	}
+
+	targetDate, err := parseSavingsGoalDate(req.TargetDate, true)
+	if err != nil {
+		return "", "", apiErrors.ErrInvalidSavingsGoal.WithMessage("목표일은 YYYY-MM 또는 YYYY-MM-DD 형식이어야 합니다")
+	}
+	startDate := utils.FormatDateKST(utils.GetCurrentKST())
+	if strings.TrimSpace(req.StartDate) != "" {
+		if startDate, err = parseSavingsGoalDate(req.StartDate, false); err != nil {
+			return "", "", apiErrors.ErrInvalidSavingsGoal.WithMessage("시작일은 YYYY-MM 또는 YYYY-MM-DD 형식이어야 합니다")
+		}
+	}
+	if targetDate < startDate {
+		return "", "", apiErrors.ErrInvalidSavingsGoal.WithMessage("목표일은 시작일 이후여야 합니다")
+	}
+
+	if (req.DepositPathID == nil) == (req.CategoryID == nil) {
+		return "", "", apiErrors.ErrInvalidSavingsGoal.WithMessage("deposit_path_id 또는 category_id 중 하나만 지정해야 합니다")
+	}
+
+	var exists int
+	if req.DepositPathID != nil {
+		err = exec.QueryRow(`SELECT 1 FROM deposit_paths WHERE id = ? AND ledger_id = ? AND is_active = 1`, *req.DepositPathID, ledgerID).Scan(&exists)
+		if err == sql.ErrNoRows {
+			return "", "", apiErrors.ErrInvalidSavingsGoal.WithMessage(fmt.Sprintf("입금경로를 찾을 수 없습니다 (ID: %d)", *req.DepositPathID))
+		}
+	} else {
+		err = exec.QueryRow(`SELECT 1 FROM categories WHERE id = ? AND ledger_id = ? AND type = 'in' AND is_active = 1`, *req.CategoryID, ledgerID).Scan(&exists)
+		if err == sql.ErrNoRows {
+			return "", "", apiErrors.ErrInvalidSavingsGoal.WithMessage(fmt.Sprintf("수입 카테고리를 찾을 수 없습니다 (ID: %d)", *req.CategoryID))
+		}
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("저축 목표 집계 기준 확인 오류: %v", err)
+	}
+
+	return startDate, targetDate, nil
+}
+
+// parseSavingsGoalDate 저축 목표 날짜 파싱 (YYYY-MM 형식은 목표일이면 말일, 시작일이면 1일)
+func parseSavingsGoalDate(value string, endOfMonth bool) (string, error) {
+	value = strings.TrimSpace(value)
+	parsed, err := utils.ParseDateInLayoutsKST(value, savingsGoalDateLayouts...)
+	if err != nil {
+		return "", err
+	}
+	if endOfMonth && len(value) <= len("2006-01") {
+		parsed = utils.EndOfMonthKST(parsed)
+	}
+	return parsed.Format("2006-01-02"), nil
+}
+
+// savingsGoalContributions 시작일부터 endDate 까지의 월별 적립액 조회
+// 입금경로 목표는 해당 입금경로의 수입과, 입금경로가 계좌에 연결되어 있으면 그 계좌로의 순이체액(들어온 이체 - 나간 이체)을 합산
+// 카테고리 목표는 해당 수입 카테고리의 수입 합계. 지출 환급에 쓰인 수입 금액은 저축이 아니므로 제외
+func savingsGoalContributions(exec sqlExecutor, ledgerID int, goal *models.SavingsGoal, endDate string) ([]models.SavingsGoalMonth, error) {
+	months := []models.SavingsGoalMonth{}
+	if endDate < goal.StartDate {
+		return months, nil
+	}
+
+	column, sourceID := "category_id", 0
+	if goal.DepositPathID != nil {
+		column, sourceID = "deposit_path_id", *goal.DepositPathID
+	} else if goal.CategoryID != nil {
+		sourceID = *goal.CategoryID
+	}
+
+	parts := []string{fmt.Sprintf(`
+        SELECT date, money AS amount FROM in_account_net_data
+        WHERE ledger_id = ? AND %s = ? AND DATE(date) BETWEEN ? AND ?`, column)}
+	args := []interface{}{ledgerID, sourceID, goal.StartDate, endDate}
+
+	if goal.DepositPathID != nil {
+		var accountID sql.NullInt64
+		err := exec.QueryRow(`SELECT account_id FROM deposit_paths WHERE id = ?`, *goal.DepositPathID).Scan(&accountID)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, fmt.Errorf("입금경로 계좌 조회 오류: %v", err)
+		}
+		if accountID.Valid {
+			parts = append(parts,
+				`SELECT date, money FROM transfers WHERE ledger_id = ? AND to_account_id = ? AND DATE(date) BETWEEN ? AND ?`,
+				`SELECT date, -money FROM transfers WHERE ledger_id = ? AND from_account_id = ? AND DATE(date) BETWEEN ? AND ?`)
+			args = append(args, ledgerID, accountID.Int64, goal.StartDate, endDate, ledgerID, accountID.Int64, goal.StartDate, endDate)
+		}
+	}
+
+	query := fmt.Sprintf(`
+        SELECT strftime('%%Y-%%m', date) AS month, SUM(amount)
+        FROM (%s)
+        GROUP BY month
+        ORDER BY month ASC`, strings.Join(parts, " UNION ALL "))
+
+	rows, err := exec.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("저축 목표 적립액 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var month models.SavingsGoalMonth
+		if err := rows.Scan(&month.Month, &month.Amount); err != nil {
+			return nil, fmt.Errorf("저축 목표 적립액 읽기 오류: %v", err)
+		}
+		months = append(months, month)
+	}
+	return months, rows.Err()
+}
+
+// sumSavingsGoalMonths 월별 적립액 합계
+func sumSavingsGoalMonths(months []models.SavingsGoalMonth) int {
+	total := 0
+	for _, month := range months {
+		total += month.Amount
+	}
+	return total
+}
+
+// computeSavingsGoalProgress 적립액으로 오늘 기준 진행률/월 필요 금액/목표 달성 가능 여부 계산
+// 예상 금액은 시작일의 시작 금액에서 목표일의 목표 금액까지 날짜에 비례해 늘어난다고 가정
+func computeSavingsGoalProgress(goal *models.SavingsGoal, contributed int, today time.Time) *models.SavingsGoalProgress {
+	saved := goal.InitialAmount + contributed
+	progress := &models.SavingsGoalProgress{
+		SavedAmount:     saved,
+		ProgressPercent: math.Round(float64(saved)/float64(goal.TargetAmount)*1000) / 10,
+	}
+	if saved < goal.TargetAmount {
+		progress.RemainingAmount = goal.TargetAmount - saved
+	}
+
+	todayStr := utils.FormatDateKST(today)
+	start, _ := utils.ParseDateTimeKST(goal.StartDate)
+	target, _ := utils.ParseDateTimeKST(goal.TargetDate)
+	current, _ := utils.ParseDateTimeKST(todayStr)
+
+	switch {
+	case todayStr < goal.StartDate:
+		progress.ExpectedAmount = goal.InitialAmount
+	case todayStr >= goal.TargetDate:
+		progress.ExpectedAmount = goal.TargetAmount
+	default:
+		totalDays := target.Sub(start).Hours()/24 + 1
+		elapsedDays := current.Sub(start).Hours()/24 + 1
+		progress.ExpectedAmount = goal.InitialAmount + int(float64(goal.TargetAmount-goal.InitialAmount)*elapsedDays/totalDays)
+	}
+
+	switch {
+	case progress.RemainingAmount == 0:
+		progress.Status = models.SavingsGoalAchieved
+		progress.OnTrack = true
+	case todayStr > goal.TargetDate:
+		progress.Status = models.SavingsGoalOverdue
+		progress.MonthlyNeeded = progress.RemainingAmount
+	default:
+		if current.Before(start) {
+			current = start
+		}
+		progress.MonthsRemaining = (target.Year()-current.Year())*12 + int(target.Month()-current.Month()) + 1
+		progress.MonthlyNeeded = int(math.Ceil(float64(progress.RemainingAmount) / float64(progress.MonthsRemaining)))
+		progress.OnTrack = saved >= progress.ExpectedAmount
+		progress.Status = models.SavingsGoalBehind
+		if progress.OnTrack {
+			progress.Status = models.SavingsGoalOnTrack
+		}
+	}
+	return progress
+}
@@ -0,0 +1,255 @@
+package database
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	apiErrors "iksoon_account_backend/errors"
+)
+
+// 검색어 제한
+const (
+	maxSearchTerms  = 16
+	minTrigramRunes = 3 // trigram 토크나이저가 인덱스로 찾을 수 있는 최소 글자 수
+)
+
+// searchColumns 검색 대상 컬럼 (메모, 키워드, 카테고리, 결제수단/입금경로, 사용자)
+var searchColumns = []string{"memo", "keyword", "category", "method", "user"}
+
+// searchClause AND 로 묶이는 검색 조건 하나 (terms 중 하나라도 일치하면 참, negate 면 일치하는 거래 제외)
+type searchClause struct {
+	terms  []string
+	negate bool
+}
+
+// parseSearchQuery 검색어를 조건 목록으로 해석
+// 공백으로 구분한 단어는 모두 포함(AND), OR 로 이은 단어는 하나 이상 포함, -단어 또는 NOT 단어는 제외,
+// "큰따옴표" 는 공백을 포함한 구문, 끝의 * 는 접두어 검색 (trigram 은 부분 일치라 접두어도 포함)
+func parseSearchQuery(input string) ([]searchClause, error) {
+	var clauses []searchClause
+	negateNext, orNext := false, false
+	termCount := 0
+
+	for _, token := range tokenizeSearchQuery(input) {
+		if !token.quoted {
+			switch token.text {
+			case "OR":
+				orNext = len(clauses) > 0 && !clauses[len(clauses)-1].negate
+				continue
+			case "AND":
+				continue
+			case "NOT":
+				negateNext = true
+				continue
+			}
+		}
+
+		text, negate := token.text, negateNext || token.negate
+		if !token.quoted {
+			text = strings.TrimRight(text, "*")
+		}
+		text = strings.TrimSpace(text)
+		negateNext = false
+		if text == "" {
+			continue
+		}
+
+		termCount++
+		if termCount > maxSearchTerms {
+			return nil, apiErrors.ErrInvalidSearchQuery.WithMessage("검색어는 최대 16개까지 사용할 수 있습니다")
+		}
+
+		if orNext && !negate {
+			last := &clauses[len(clauses)-1]
+			last.terms = append(last.terms, text)
+		} else {
+			clauses = append(clauses, searchClause{terms: []string{text}, negate: negate})
+		}
+		orNext = false
+	}
+
+	positive := false
+	for _, clause := range clauses {
+		if !clause.negate {
+			positive = true
+		}
+	}
+	if len(clauses) == 0 {
+		return nil, apiErrors.ErrInvalidSearchQuery.WithMessage("검색어가 필요합니다")
+	}
+	if !positive {
+		return nil, apiErrors.ErrInvalidSearchQuery.WithMessage("제외할 검색어만으로는 검색할 수 없습니다")
+	}
+	return clauses, nil
+}
+
+// searchToken 검색어 토큰 (큰따옴표 구문 여부와 - 접두사 여부)
+type searchToken struct {
+	text   string
+	quoted bool
+	negate bool
+}
+
+// tokenizeSearchQuery 검색어를 공백과 큰따옴표 기준으로 토큰 분리 (닫히지 않은 따옴표는 끝까지를 구문으로 처리)
+func tokenizeSearchQuery(input string) []searchToken {
+	var tokens []searchToken
+	runes := []rune(input)
+	for i := 0; i < len(runes); {
+		if runes[i] == ' ' || runes[i] == '\t' || runes[i] == '\n' || runes[i] == '\r' {
+			i++
+			continue
+		}
+
+		negate := false
+		if runes[i] == '-' && i+1 < len(runes) && runes[i+1] != ' ' {
+			negate = true
+			i++
+		}
+
+		if runes[i] == '"' {
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			tokens = append(tokens, searchToken{text: string(runes[i+1 : end]), quoted: true, negate: negate})
+			i = end + 1
+			continue
+		}
+
+		end := i
+		for end < len(runes) && runes[end] != ' ' && runes[end] != '\t' && runes[end] != '\n' && runes[end] != '\r' && runes[end] != '"' {
+			end++
+		}
+		tokens = append(tokens, searchToken{text: string(runes[i:end]), negate: negate})
+		i = end
+	}
+	return tokens
+}
+
+// indexable trigram 인덱스(MATCH)로 찾을 수 있는 조건인지 여부 (모든 단어가 3글자 이상인 포함 조건)
+func (c searchClause) indexable() bool {
+	if c.negate {
+		return false
+	}
+	for _, term := range c.terms {
+		if utf8.RuneCountInString(term) < minTrigramRunes {
+			return false
+		}
+	}
+	return true
+}
+
+// buildSearchMatch 인덱스로 찾을 수 있는 조건을 FTS5 MATCH 식으로 변환 (각 단어는 큰따옴표로 감싸 구문으로 처리)
+// 반환값이 비어 있으면 MATCH 없이 LIKE 조건만 사용
+func buildSearchMatch(clauses []searchClause) string {
+	var parts []string
+	for _, clause := range clauses {
+		if !clause.indexable() {
+			continue
+		}
+		quoted := make([]string, len(clause.terms))
+		for i, term := range clause.terms {
+			quoted[i] = `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+		}
+		parts = append(parts, "("+strings.Join(quoted, " OR ")+")")
+	}
+	return strings.Join(parts, " AND ")
+}
+
+// buildSearchLikeClause MATCH 로 처리하지 못한 조건을 LIKE 조건으로 변환 (useMatch 가 false 면 모든 조건)
+// alias 는 검색 컬럼을 가진 테이블 별칭
+func buildSearchLikeClause(alias string, clauses []searchClause, useMatch bool) (string, []interface{}) {
+	var clause string
+	var args []interface{}
+	for _, c := range clauses {
+		if useMatch && c.indexable() {
+			continue
+		}
+
+		var terms []string
+		for _, term := range c.terms {
+			pattern := "%" + escapeLikePattern(term) + "%"
+			var columns []string
+			for _, column := range searchColumns {
+				columns = append(columns, alias+"."+column+` LIKE ? ESCAPE '\'`)
+				args = append(args, pattern)
+			}
+			terms = append(terms, strings.Join(columns, " OR "))
+		}
+
+		condition := "(" + strings.Join(terms, " OR ") + ")"
+		if c.negate {
+			condition = "NOT " + condition
+		}
+		clause += " AND " + condition
+	}
+	return clause, args
+}
+
+// escapeLikePattern LIKE 패턴의 %, _, \ 를 문자 그대로 찾도록 이스케이프
+func escapeLikePattern(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(value)
+}
+
+// highlightSearchTerms 검색 단어가 처음 나오는 필드를 골라 일치 부분을 <mark> 로 감싼 발췌 생성
+// (MATCH 를 쓰지 않은 검색에서 snippet 대신 사용)
+func highlightSearchTerms(fields []string, clauses []searchClause) string {
+	var terms []string
+	for _, clause := range clauses {
+		if !clause.negate {
+			terms = append(terms, clause.terms...)
+		}
+	}
+
+	for _, field := range fields {
+		lower := strings.ToLower(field)
+		for _, term := range terms {
+			if strings.Contains(lower, strings.ToLower(term)) {
+				return markSearchTerms(field, terms)
+			}
+		}
+	}
+	if len(fields) > 0 {
+		return fields[0]
+	}
+	return ""
+}
+
+// markSearchTerms 텍스트에서 검색 단어와 일치하는 부분을 모두 <mark></mark> 로 감싸기 (영문 대소문자 무시)
+func markSearchTerms(text string, terms []string) string {
+	lower := strings.ToLower(text)
+	if len(lower) != len(text) {
+		return text
+	}
+
+	marked := make([]bool, len(text))
+	for _, term := range terms {
+		needle := strings.ToLower(term)
+		if needle == "" {
+			continue
+		}
+		for start := 0; ; {
+			index := strings.Index(lower[start:], needle)
+			if index < 0 {
+				break
+			}
+			for i := start + index; i < start+index+len(needle); i++ {
+				marked[i] = true
+			}
+			start += index + len(needle)
+		}
+	}
+
+	var builder strings.Builder
+	for i := 0; i < len(text); i++ {
+		if marked[i] && (i == 0 || !marked[i-1]) {
+			builder.WriteString("<mark>")
+		}
+		builder.WriteByte(text[i])
+		if marked[i] && (i == len(text)-1 || !marked[i+1]) {
+			builder.WriteString("</mark>")
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,339 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// 거래 검색 방식
+const (
+	searchModeFTS  = "fts"  // FTS5 trigram 인덱스 (MATCH, 관련도 순위, snippet)
+	searchModeLike = "like" // FTS5 를 지원하지 않는 빌드의 부분 일치 검색
+)
+
+// searchIndexTables 검색 인덱스 테이블
+// transaction_search_rows 는 거래(유형, UUID)와 FTS 행 번호를 잇는 일반 테이블 (VACUUM 에도 바뀌지 않는 INTEGER PRIMARY KEY 사용)
+const searchIndexTables = `
+    CREATE TABLE IF NOT EXISTS transaction_search_rows (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        account_type TEXT NOT NULL,
+        uuid TEXT NOT NULL,
+        ledger_id INTEGER NOT NULL,
+        UNIQUE(account_type, uuid)
+    );
+    CREATE INDEX IF NOT EXISTS idx_transaction_search_rows_ledger ON transaction_search_rows(ledger_id);
+    CREATE VIRTUAL TABLE IF NOT EXISTS transaction_search USING fts5(
+        memo, keyword, category, method, user,
+        tokenize = 'trigram'
+    );`
+
+// searchSourceQuery 검색 인덱스에 넣을 거래별 텍스트 (인덱스 재생성과 FTS5 없는 빌드의 검색에 사용)
+const searchSourceQuery = `
+    SELECT 'out' AS account_type, oa.uuid AS uuid, oa.ledger_id AS ledger_id,
+           COALESCE(oa.memo, '') AS memo, COALESCE(k.name, '') AS keyword, COALESCE(c.name, '') AS category,
+           COALESCE(pm.name, '') AS method, oa.user AS user` + outAccountListFrom + `
+    UNION ALL
+    SELECT 'in' AS account_type, ia.uuid AS uuid, ia.ledger_id AS ledger_id,
+           COALESCE(ia.memo, '') AS memo, COALESCE(k.name, '') AS keyword, COALESCE(c.name, '') AS category,
+           COALESCE(dp.name, '') AS method, ia.user AS user` + inAccountListFrom
+
+// searchRowID 거래의 검색 인덱스 행 번호를 찾는 트리거용 서브쿼리 (%s: 'out'/'in', old/new)
+const searchRowID = `(SELECT id FROM transaction_search_rows WHERE account_type = '%s' AND uuid = %s.uuid)`
+
+// searchIndexTriggers 거래와 이름 테이블 변경을 검색 인덱스에 반영하는 트리거 (이름, 생성 SQL)
+var searchIndexTriggers = buildSearchIndexTriggers()
+
+// buildSearchIndexTriggers 지출/수입 등록·수정·삭제와 카테고리/키워드/결제수단/입금경로 이름 변경 트리거 생성
+func buildSearchIndexTriggers() [][2]string {
+	var triggers [][2]string
+	for _, source := range []struct{ accountType, table, methodTable, methodColumn string }{
+		{models.AccountTypeOut, "out_account_data", "payment_methods", "payment_method_id"},
+		{models.AccountTypeIn, "in_account_data", "deposit_paths", "deposit_path_id"},
+	} {
+		insert := fmt.Sprintf(`
+        INSERT INTO transaction_search_rows (account_type, uuid, ledger_id) VALUES ('%[1]s', new.uuid, new.ledger_id);
+        INSERT INTO transaction_search (rowid, memo, keyword, category, method, user) VALUES (
+            %[2]s, COALESCE(new.memo, ''),
+            COALESCE((SELECT name FROM keywords WHERE id = new.keyword_id), ''),
+            COALESCE((SELECT name FROM categories WHERE id = new.category_id), ''),
+            COALESCE((SELECT name FROM %[3]s WHERE id = new.%[4]s), ''),
+            new.user);`,
+			source.accountType, fmt.Sprintf(searchRowID, source.accountType, "new"), source.methodTable, source.methodColumn)
+		// 이미 색인된 행이 있어도 등록이 실패하지 않도록 지운 뒤 다시 색인
+		remove := func(row string) string {
+			return fmt.Sprintf(`
+        DELETE FROM transaction_search WHERE rowid = %[1]s;
+        DELETE FROM transaction_search_rows WHERE account_type = '%[2]s' AND uuid = %[3]s.uuid;`,
+				fmt.Sprintf(searchRowID, source.accountType, row), source.accountType, row)
+		}
+
+		name := "trg_transaction_search_" + source.accountType
+		triggers = append(triggers,
+			[2]string{name + "_insert", fmt.Sprintf(`CREATE TRIGGER %s_insert AFTER INSERT ON %s BEGIN%s%s
+    END`, name, source.table, remove("new"), insert)},
+			[2]string{name + "_update", fmt.Sprintf(`CREATE TRIGGER %s_update AFTER UPDATE ON %s BEGIN%s%s
+    END`, name, source.table, remove("old"), insert)},
+			[2]string{name + "_delete", fmt.Sprintf(`CREATE TRIGGER %s_delete AFTER DELETE ON %s BEGIN%s
+    END`, name, source.table, remove("old"))},
+		)
+	}
+
+	// rows 는 이름이 바뀐 항목을 쓰는 거래의 검색 인덱스 행 번호
+	for _, rename := range []struct{ table, column, rows string }{
+		{"categories", "category", `
+            SELECT m.id FROM transaction_search_rows m JOIN out_account_data oa ON m.account_type = 'out' AND m.uuid = oa.uuid WHERE oa.category_id = new.id
+            UNION ALL
+            SELECT m.id FROM transaction_search_rows m JOIN in_account_data ia ON m.account_type = 'in' AND m.uuid = ia.uuid WHERE ia.category_id = new.id`},
+		{"keywords", "keyword", `
+            SELECT m.id FROM transaction_search_rows m JOIN out_account_data oa ON m.account_type = 'out' AND m.uuid = oa.uuid WHERE oa.keyword_id = new.id
+            UNION ALL
+            SELECT m.id FROM transaction_search_rows m JOIN in_account_data ia ON m.account_type = 'in' AND m.uuid = ia.uuid WHERE ia.keyword_id = new.id`},
+		{"payment_methods", "method", `
+            SELECT m.id FROM transaction_search_rows m JOIN out_account_data oa ON m.account_type = 'out' AND m.uuid = oa.uuid WHERE oa.payment_method_id = new.id`},
+		{"deposit_paths", "method", `
+            SELECT m.id FROM transaction_search_rows m JOIN in_account_data ia ON m.account_type = 'in' AND m.uuid = ia.uuid WHERE ia.deposit_path_id = new.id`},
+	} {
+		name := "trg_transaction_search_" + rename.table + "_rename"
+		triggers = append(triggers, [2]string{name, fmt.Sprintf(`CREATE TRIGGER %s AFTER UPDATE OF name ON %s BEGIN
+        UPDATE transaction_search SET %s = new.name WHERE rowid IN (%s
+        );
+    END`, name, rename.table, rename.column, rename.rows)})
+	}
+
+	// 키워드가 삭제되면 거래 목록처럼 빈 키워드로 검색
+	triggers = append(triggers, [2]string{"trg_transaction_search_keywords_delete", `CREATE TRIGGER trg_transaction_search_keywords_delete AFTER DELETE ON keywords BEGIN
+        UPDATE transaction_search SET keyword = '' WHERE rowid IN (
+            SELECT m.id FROM transaction_search_rows m JOIN out_account_data oa ON m.account_type = 'out' AND m.uuid = oa.uuid WHERE oa.keyword_id = old.id
+            UNION ALL
+            SELECT m.id FROM transaction_search_rows m JOIN in_account_data ia ON m.account_type = 'in' AND m.uuid = ia.uuid WHERE ia.keyword_id = old.id
+        );
+    END`})
+	return triggers
+}
+
+// fts5Available SQLite 가 FTS5 를 포함해 빌드되었는지 확인 (go-sqlite3 는 -tags sqlite_fts5 로 빌드해야 포함)
+func fts5Available(exec sqlExecutor) bool {
+	var enabled int
+	if err := exec.QueryRow(`SELECT sqlite_compileoption_used('ENABLE_FTS5')`).Scan(&enabled); err != nil {
+		return false
+	}
+	return enabled == 1
+}
+
+// EnsureSearchIndex 거래 검색 인덱스 준비 (서버 시작과 백업 복원 후 실행)
+// FTS5 를 쓸 수 있으면 인덱스 테이블과 트리거를 만들고, 트리거가 빠져 있던 경우(새 DB, FTS5 없이 실행된 적이 있는 DB,
+// 다른 빌드의 백업) 인덱스를 다시 생성. FTS5 가 없으면 등록/수정이 실패하지 않도록 트리거를 지우고 부분 일치 검색 사용
+// 빌드 옵션에 따라 만들 수 없는 테이블이라 번호별 마이그레이션이 아닌 시작 시 점검으로 관리
+func (db *DB) EnsureSearchIndex() error {
+	if !fts5Available(db.Conn) {
+		db.searchIndex = false
+		for _, trigger := range searchIndexTriggers {
+			if _, err := db.Conn.Exec(`DROP TRIGGER IF EXISTS ` + trigger[0]); err != nil {
+				return fmt.Errorf("검색 인덱스 트리거 삭제 오류: %v", err)
+			}
+		}
+		utils.Warning("SQLite FTS5 를 지원하지 않는 빌드입니다. 거래 검색은 부분 일치(LIKE)로 동작합니다 (go build -tags sqlite_fts5 권장)")
+		return nil
+	}
+
+	var existing int
+	names := make([]interface{}, len(searchIndexTriggers))
+	for i, trigger := range searchIndexTriggers {
+		names[i] = trigger[0]
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(names)), ", ")
+	if err := db.Conn.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'trigger' AND name IN (`+placeholders+`)`, names...).Scan(&existing); err != nil {
+		return fmt.Errorf("검색 인덱스 트리거 확인 오류: %v", err)
+	}
+	if existing == len(searchIndexTriggers) {
+		db.searchIndex = true
+		return nil
+	}
+
+	count, err := db.rebuildSearchIndex()
+	if err != nil {
+		return err
+	}
+	db.searchIndex = true
+	utils.Info("거래 검색 인덱스 생성: %d건", count)
+	return nil
+}
+
+// rebuildSearchIndex 검색 인덱스 테이블/트리거를 다시 만들고 모든 거래를 색인 (색인한 거래 수 반환)
+func (db *DB) rebuildSearchIndex() (int, error) {
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(searchIndexTables); err != nil {
+		return 0, fmt.Errorf("검색 인덱스 테이블 생성 오류: %v", err)
+	}
+	for _, trigger := range searchIndexTriggers {
+		if _, err := tx.Exec(`DROP TRIGGER IF EXISTS ` + trigger[0]); err != nil {
+			return 0, fmt.Errorf("검색 인덱스 트리거 삭제 오류: %v", err)
+		}
+		if _, err := tx.Exec(trigger[1]); err != nil {
+			return 0, fmt.Errorf("검색 인덱스 트리거 생성 오류 (%s): %v", trigger[0], err)
+		}
+	}
+
+	if _, err := tx.Exec(`DELETE FROM transaction_search`); err != nil {
+		return 0, fmt.Errorf("검색 인덱스 초기화 오류: %v", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM transaction_search_rows`); err != nil {
+		return 0, fmt.Errorf("검색 인덱스 초기화 오류: %v", err)
+	}
+	result, err := tx.Exec(`
+        INSERT INTO transaction_search_rows (account_type, uuid, ledger_id)
+        SELECT 'out', uuid, ledger_id FROM out_account_data
+        UNION ALL
+        SELECT 'in', uuid, ledger_id FROM in_account_data`)
+	if err != nil {
+		return 0, fmt.Errorf("검색 인덱스 생성 오류: %v", err)
+	}
+	if _, err := tx.Exec(`
+        INSERT INTO transaction_search (rowid, memo, keyword, category, method, user)
+        SELECT m.id, src.memo, src.keyword, src.category, src.method, src.user
+        FROM transaction_search_rows m
+        JOIN (` + searchSourceQuery + `
+        ) src ON src.account_type = m.account_type AND src.uuid = m.uuid`); err != nil {
+		return 0, fmt.Errorf("검색 인덱스 생성 오류: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("검색 인덱스 저장 오류: %v", err)
+	}
+	count, _ := result.RowsAffected()
+	return int(count), nil
+}
+
+// SearchTransactions 메모/키워드/카테고리/결제수단·입금경로/사용자 전체 기간 검색
+// FTS5 인덱스가 있으면 3글자 이상 단어는 MATCH 로 찾아 관련도 순으로 정렬하고 일치 부분을 발췌하며,
+// trigram 으로 찾을 수 없는 2글자 이하 단어와 제외어는 LIKE 조건으로 처리 (인덱스가 없으면 모두 LIKE, 최신순)
+func (db *DB) SearchTransactions(ledgerID int, q models.SearchQuery) (*models.SearchResult, error) {
+	clauses, err := parseSearchQuery(q.Query)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateSearchQuery(&q); err != nil {
+		return nil, err
+	}
+
+	result := &models.SearchResult{Query: q.Query, Mode: searchModeLike, Limit: q.Limit, Offset: q.Offset, Items: []models.SearchHit{}}
+	match := ""
+	var from string
+	var args []interface{}
+	identity := "s"
+	if db.searchIndex {
+		result.Mode = searchModeFTS
+		match = buildSearchMatch(clauses)
+		if match != "" {
+			from = `
+        FROM (SELECT rowid AS id, memo, keyword, category, method, user,
+                     snippet(transaction_search, -1, '<mark>', '</mark>', '…', 16) AS snippet,
+                     bm25(transaction_search, 1.0, 2.0, 1.0, 0.5, 0.5) AS score
+              FROM transaction_search WHERE transaction_search MATCH ?) s`
+			args = append(args, match)
+		} else {
+			from = `
+        FROM (SELECT rowid AS id, memo, keyword, category, method, user, '' AS snippet, 0 AS score FROM transaction_search) s`
+		}
+		from += `
+        JOIN transaction_search_rows m ON m.id = s.id`
+		identity = "m"
+	} else {
+		from = `
+        FROM (SELECT account_type, uuid, ledger_id, memo, keyword, category, method, user, '' AS snippet, 0 AS score
+              FROM (` + searchSourceQuery + `)) s`
+	}
+	from += fmt.Sprintf(`
+        LEFT JOIN out_account_data oa ON %[1]s.account_type = 'out' AND oa.uuid = %[1]s.uuid
+        LEFT JOIN in_account_data ia ON %[1]s.account_type = 'in' AND ia.uuid = %[1]s.uuid`, identity)
+
+	where := fmt.Sprintf(`
+        WHERE %s.ledger_id = ?`, identity)
+	args = append(args, ledgerID)
+	if q.Type != "" {
+		where += fmt.Sprintf(` AND %s.account_type = ?`, identity)
+		args = append(args, q.Type)
+	}
+	if q.StartDate != "" {
+		where += ` AND DATE(COALESCE(oa.date, ia.date)) >= ?`
+		args = append(args, q.StartDate)
+	}
+	if q.EndDate != "" {
+		where += ` AND DATE(COALESCE(oa.date, ia.date)) <= ?`
+		args = append(args, q.EndDate)
+	}
+	likeClause, likeArgs := buildSearchLikeClause("s", clauses, match != "")
+	where += likeClause
+	args = append(args, likeArgs...)
+
+	if err := db.Conn.QueryRow(`SELECT COUNT(*)`+from+where, args...).Scan(&result.TotalCount); err != nil {
+		utils.LogError("거래 검색 건수 조회", err)
+		return nil, fmt.Errorf("거래 검색 오류: %v", err)
+	}
+
+	order := `
+        ORDER BY COALESCE(oa.date, ia.date) DESC, ` + identity + `.uuid DESC`
+	if match != "" {
+		order = `
+        ORDER BY s.score ASC, COALESCE(oa.date, ia.date) DESC, ` + identity + `.uuid DESC`
+	}
+	rows, err := db.Conn.Query(fmt.Sprintf(`
+        SELECT %[1]s.account_type, %[1]s.uuid, COALESCE(oa.date, ia.date, ''), COALESCE(oa.money, ia.money, 0),
+               s.user, s.category, s.keyword, s.method, s.memo, s.snippet, s.score`, identity)+from+where+order+`
+        LIMIT ? OFFSET ?`, append(args, q.Limit, q.Offset)...)
+	if err != nil {
+		utils.LogError("거래 검색", err)
+		return nil, fmt.Errorf("거래 검색 오류: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var hit models.SearchHit
+		if err := rows.Scan(&hit.Type, &hit.UUID, &hit.Date, &hit.Money, &hit.User, &hit.CategoryName, &hit.KeywordName,
+			&hit.MethodName, &hit.Memo, &hit.Snippet, &hit.Rank); err != nil {
+			return nil, fmt.Errorf("거래 검색 결과 읽기 오류: %v", err)
+		}
+		if hit.Snippet == "" {
+			hit.Snippet = highlightSearchTerms([]string{hit.Memo, hit.KeywordName, hit.CategoryName, hit.MethodName, hit.User}, clauses)
+		}
+		result.Items = append(result.Items, hit)
+	}
+	return result, rows.Err()
+}
+
+// validateSearchQuery 검색 유형/기간/개수 검증과 기본값 적용
+func validateSearchQuery(q *models.SearchQuery) error {
+	q.Type = strings.ToLower(strings.TrimSpace(q.Type))
+	if q.Type != "" && q.Type != models.AccountTypeOut && q.Type != models.AccountTypeIn {
+		return apiErrors.ErrInvalidSearchQuery.WithMessage("type은 out 또는 in이어야 합니다")
+	}
+	for _, date := range []string{q.StartDate, q.EndDate} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return apiErrors.ErrInvalidDateRange.WithMessage("start_date와 end_date는 YYYY-MM-DD 형식이어야 합니다")
+		}
+	}
+
+	if q.Limit == 0 {
+		q.Limit = models.DefaultSearchLimit
+	}
+	if q.Limit < 0 || q.Limit > models.MaxSearchLimit {
+		return apiErrors.ErrInvalidSearchQuery.WithMessage(fmt.Sprintf("limit은 1 이상 %d 이하여야 합니다", models.MaxSearchLimit))
+	}
+	if q.Offset < 0 {
+		return apiErrors.ErrInvalidSearchQuery.WithMessage("offset은 0 이상이어야 합니다")
+	}
+	return nil
+}
@@ -0,0 +1,497 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// settlementExcludedOuts 정산에서 제외할 지출 UUID 서브쿼리 (환급 대상 지출은 외부에서 돌려받으므로 대기/완료 모두 제외)
+const settlementExcludedOuts = `SELECT out_account_uuid FROM reimbursements`
+
+// GetSettlementBalance 기간 내 멤버별 정산 잔액과 최소 송금 목록 계산
+// startDate 생략 시 마지막 정산 다음 날(정산 기록이 없으면 첫 지출일), endDate 생략 시 오늘
+func (db *DB) GetSettlementBalance(ledgerID int, startDate, endDate string) (*models.SettlementBalance, error) {
+	return computeSettlementBalance(db.Conn, ledgerID, startDate, endDate)
+}
+
+// GetSettlements 기록된 정산 목록 조회 (최근 정산 먼저)
+func (db *DB) GetSettlements(ledgerID int) ([]models.Settlement, error) {
+	rows, err := db.Conn.Query(`
+        SELECT id, start_date, end_date, total_expense, memo, created_at
+        FROM settlements WHERE ledger_id = ?
+        ORDER BY end_date DESC, id DESC`, ledgerID)
+	if err != nil {
+		return nil, fmt.Errorf("정산 목록 조회 오류: %v", err)
+	}
+
+	settlements := []models.Settlement{}
+	index := map[int]int{}
+	for rows.Next() {
+		var settlement models.Settlement
+		if err := rows.Scan(&settlement.ID, &settlement.StartDate, &settlement.EndDate, &settlement.TotalExpense, &settlement.Memo, &settlement.CreatedAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("정산 데이터 읽기 오류: %v", err)
+		}
+		settlement.Transfers = []models.SettlementTransfer{}
+		index[settlement.ID] = len(settlements)
+		settlements = append(settlements, settlement)
+	}
+	err = rows.Err()
+	rows.Close()
+	if err != nil {
+		return nil, fmt.Errorf("정산 목록 조회 오류: %v", err)
+	}
+
+	transferRows, err := db.Conn.Query(`
+        SELECT t.settlement_id, t.from_user, t.to_user, t.amount
+        FROM settlement_transfers t
+        JOIN settlements s ON t.settlement_id = s.id
+        WHERE s.ledger_id = ?
+        ORDER BY t.amount DESC`, ledgerID)
+	if err != nil {
+		return nil, fmt.Errorf("정산 송금 조회 오류: %v", err)
+	}
+	defer transferRows.Close()
+
+	for transferRows.Next() {
+		var settlementID int
+		var transfer models.SettlementTransfer
+		if err := transferRows.Scan(&settlementID, &transfer.FromUser, &transfer.ToUser, &transfer.Amount); err != nil {
+			return nil, fmt.Errorf("정산 송금 데이터 읽기 오류: %v", err)
+		}
+		if i, ok := index[settlementID]; ok {
+			settlements[i].Transfers = append(settlements[i].Transfers, transfer)
+		}
+	}
+	return settlements, transferRows.Err()
+}
+
+// GetSettlementByID ID로 기록된 정산 조회
+func (db *DB) GetSettlementByID(ledgerID, id int) (*models.Settlement, error) {
+	settlements, err := db.GetSettlements(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	for _, settlement := range settlements {
+		if settlement.ID == id {
+			return &settlement, nil
+		}
+	}
+	return nil, apiErrors.ErrSettlementNotFound
+}
+
+// RecordSettlement 마지막 정산 다음 날부터 endDate 까지의 잔액을 계산해 송금 목록과 함께 정산으로 기록
+// 기록된 송금은 이후 잔액 계산에 반영되어 정산한 기간의 잔액이 0이 됨
+func (db *DB) RecordSettlement(ledgerID int, req models.SettlementRequest) (int64, error) {
+	today := utils.FormatDateKST(utils.GetCurrentKST())
+	if req.EndDate == "" {
+		req.EndDate = today
+	}
+	if _, err := time.Parse("2006-01-02", req.EndDate); err != nil {
+		return 0, apiErrors.ErrInvalidDateRange.WithMessage("end_date는 YYYY-MM-DD 형식이어야 합니다")
+	}
+	if req.EndDate > today {
+		return 0, apiErrors.ErrInvalidSettlement.WithMessage("미래 날짜까지 정산할 수 없습니다")
+	}
+
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	lastEnd, err := lastSettlementEndDate(tx, ledgerID)
+	if err != nil {
+		return 0, err
+	}
+	if lastEnd != "" && req.EndDate <= lastEnd {
+		return 0, apiErrors.ErrInvalidSettlement.WithMessage(fmt.Sprintf("마지막 정산일(%s) 이후 날짜까지만 정산할 수 있습니다", lastEnd))
+	}
+
+	balance, err := computeSettlementBalance(tx, ledgerID, "", req.EndDate)
+	if err != nil {
+		return 0, err
+	}
+	if len(balance.Transfers) == 0 {
+		return 0, apiErrors.ErrInvalidSettlement.WithMessage("정산할 금액이 없습니다")
+	}
+
+	result, err := tx.Exec(`
+        INSERT INTO settlements (ledger_id, start_date, end_date, total_expense, memo)
+        VALUES (?, ?, ?, ?, ?)`, ledgerID, balance.StartDate, balance.EndDate, balance.TotalExpense, req.Memo)
+	if err != nil {
+		return 0, fmt.Errorf("정산 기록 오류: %v", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("정산 ID 조회 오류: %v", err)
+	}
+
+	for _, transfer := range balance.Transfers {
+		_, err := tx.Exec(`INSERT INTO settlement_transfers (settlement_id, from_user, to_user, amount) VALUES (?, ?, ?, ?)`,
+			id, transfer.FromUser, transfer.ToUser, transfer.Amount)
+		if err != nil {
+			return 0, fmt.Errorf("정산 송금 기록 오류: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("정산 기록 커밋 오류: %v", err)
+	}
+	return id, nil
+}
+
+// DeleteSettlement 기록된 정산 삭제 (해당 기간의 잔액이 다시 살아남)
+func (db *DB) DeleteSettlement(ledgerID, id int) error {
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	// 외래 키 CASCADE 가 꺼져 있을 수 있으므로 송금 기록을 직접 삭제
+	if _, err := tx.Exec(`DELETE FROM settlement_transfers WHERE settlement_id IN (SELECT id FROM settlements WHERE id = ? AND ledger_id = ?)`, id, ledgerID); err != nil {
+		return fmt.Errorf("정산 송금 삭제 오류: %v", err)
+	}
+	result, err := tx.Exec(`DELETE FROM settlements WHERE id = ? AND ledger_id = ?`, id, ledgerID)
+	if err != nil {
+		return fmt.Errorf("정산 삭제 오류: %v", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return apiErrors.ErrSettlementNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("정산 삭제 커밋 오류: %v", err)
+	}
+	return nil
+}
+
+// computeSettlementBalance 멤버별 지출액/분담액/정산액으로 잔액을 계산하고 최소 송금 목록 생성
+// 분담액은 지출 항목(분할 지출은 항목별)의 카테고리 규칙 → 기본 규칙 → 전체 멤버 균등 순으로 적용
+func computeSettlementBalance(exec sqlExecutor, ledgerID int, startDate, endDate string) (*models.SettlementBalance, error) {
+	startDate, endDate, err := resolveSettlementPeriod(exec, ledgerID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	balance := &models.SettlementBalance{StartDate: startDate, EndDate: endDate, Transfers: []models.SettlementTransfer{}}
+	members := map[string]*models.SettlementMember{}
+	member := func(name string) *models.SettlementMember {
+		if members[name] == nil {
+			members[name] = &models.SettlementMember{UserName: name}
+		}
+		return members[name]
+	}
+
+	// 멤버별 실제 지출액
+	paid, err := querySettlementTotals(exec, `
+        SELECT user, SUM(money) FROM out_account_data
+        WHERE ledger_id = ? AND date(date) >= ? AND date(date) <= ?
+            AND uuid NOT IN (`+settlementExcludedOuts+`)
+        GROUP BY user`, ledgerID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	for name, amount := range paid {
+		member(name).Paid += amount
+		balance.TotalExpense += amount
+	}
+
+	// 카테고리별 지출액을 분담 규칙에 따라 배분
+	categoryTotals, err := querySettlementTotals(exec, `
+        SELECT CAST(category_id AS TEXT), SUM(money) FROM out_account_line_items
+        WHERE ledger_id = ? AND date(date) >= ? AND date(date) <= ?
+            AND uuid NOT IN (`+settlementExcludedOuts+`)
+        GROUP BY category_id`, ledgerID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	shares, err := distributeSettlementShares(exec, ledgerID, categoryTotals)
+	if err != nil {
+		return nil, err
+	}
+	for name, amount := range shares {
+		member(name).Share += amount
+	}
+
+	// 기간 내 기록된 정산 송금 반영 (보낸 사람은 잔액 증가, 받은 사람은 잔액 감소)
+	rows, err := exec.Query(`
+        SELECT t.from_user, t.to_user, SUM(t.amount)
+        FROM settlement_transfers t
+        JOIN settlements s ON t.settlement_id = s.id
+        WHERE s.ledger_id = ? AND s.end_date >= ? AND s.end_date <= ?
+        GROUP BY t.from_user, t.to_user`, ledgerID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("정산 송금 조회 오류: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var fromUser, toUser string
+		var amount int
+		if err := rows.Scan(&fromUser, &toUser, &amount); err != nil {
+			return nil, fmt.Errorf("정산 송금 데이터 읽기 오류: %v", err)
+		}
+		member(fromUser).Settled += amount
+		member(toUser).Settled -= amount
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("정산 송금 조회 오류: %v", err)
+	}
+
+	balance.Members = make([]models.SettlementMember, 0, len(members))
+	for _, m := range members {
+		m.Balance = m.Paid - m.Share + m.Settled
+		balance.Members = append(balance.Members, *m)
+	}
+	sort.Slice(balance.Members, func(i, j int) bool {
+		if balance.Members[i].Balance != balance.Members[j].Balance {
+			return balance.Members[i].Balance > balance.Members[j].Balance
+		}
+		return balance.Members[i].UserName < balance.Members[j].UserName
+	})
+
+	balance.Transfers = minimizeSettlementTransfers(balance.Members)
+	return balance, nil
+}
+
+// resolveSettlementPeriod 정산 기간 기본값 적용 및 검증
+func resolveSettlementPeriod(exec sqlExecutor, ledgerID int, startDate, endDate string) (string, string, error) {
+	if endDate == "" {
+		endDate = utils.FormatDateKST(utils.GetCurrentKST())
+	}
+	end, err := time.Parse("2006-01-02", endDate)
+	if err != nil {
+		return "", "", apiErrors.ErrInvalidDateRange.WithMessage("end_date는 YYYY-MM-DD 형식이어야 합니다")
+	}
+
+	if startDate == "" {
+		lastEnd, err := lastSettlementEndDate(exec, ledgerID)
+		if err != nil {
+			return "", "", err
+		}
+		if lastEnd != "" {
+			last, err := time.Parse("2006-01-02", lastEnd)
+			if err != nil {
+				return "", "", fmt.Errorf("마지막 정산일 파싱 오류: %v", err)
+			}
+			if !last.Before(end) {
+				return "", "", apiErrors.ErrInvalidDateRange.WithMessage(fmt.Sprintf("%s까지는 이미 정산되었습니다", lastEnd))
+			}
+			startDate = last.AddDate(0, 0, 1).Format("2006-01-02")
+		} else {
+			// 정산 기록이 없으면 첫 지출일부터 (지출이 없거나 end_date 이후면 end_date 하루)
+			var first sql.NullString
+			if err := exec.QueryRow(`SELECT MIN(date(date)) FROM out_account_data WHERE ledger_id = ?`, ledgerID).Scan(&first); err != nil {
+				return "", "", fmt.Errorf("첫 지출일 조회 오류: %v", err)
+			}
+			startDate = endDate
+			if first.Valid && first.String < endDate {
+				startDate = first.String
+			}
+		}
+	}
+
+	start, err := time.Parse("2006-01-02", startDate)
+	if err != nil {
+		return "", "", apiErrors.ErrInvalidDateRange.WithMessage("start_date는 YYYY-MM-DD 형식이어야 합니다")
+	}
+	if start.After(end) {
+		return "", "", apiErrors.ErrInvalidDateRange.WithMessage("start_date는 end_date보다 늦을 수 없습니다")
+	}
+	return startDate, endDate, nil
+}
+
+// lastSettlementEndDate 가계부의 마지막 정산 종료일 조회 (정산 기록이 없으면 빈 문자열)
+func lastSettlementEndDate(exec sqlExecutor, ledgerID int) (string, error) {
+	var lastEnd sql.NullString
+	if err := exec.QueryRow(`SELECT MAX(end_date) FROM settlements WHERE ledger_id = ?`, ledgerID).Scan(&lastEnd); err != nil {
+		return "", fmt.Errorf("마지막 정산일 조회 오류: %v", err)
+	}
+	return lastEnd.String, nil
+}
+
+// querySettlementTotals 키별 금액 합계 조회 (키가 NULL 인 행은 빈 문자열)
+func querySettlementTotals(exec sqlExecutor, query string, args ...interface{}) (map[string]int, error) {
+	rows, err := exec.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("정산 금액 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	totals := map[string]int{}
+	for rows.Next() {
+		var key sql.NullString
+		var amount int
+		if err := rows.Scan(&key, &amount); err != nil {
+			return nil, fmt.Errorf("정산 금액 데이터 읽기 오류: %v", err)
+		}
+		totals[key.String] += amount
+	}
+	return totals, rows.Err()
+}
+
+// distributeSettlementShares 카테고리별 지출액을 분담 규칙에 따라 멤버별 부담액으로 배분
+// 같은 규칙이 적용되는 카테고리는 합산한 뒤 한 번에 나누어 반올림 오차를 줄임
+func distributeSettlementShares(exec sqlExecutor, ledgerID int, categoryTotals map[string]int) (map[string]int, error) {
+	rules, err := querySharingRules(exec, ledgerID)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultRule := -1
+	categoryRules := map[string]int{}
+	for i, rule := range rules {
+		if rule.CategoryID == nil {
+			defaultRule = i
+			continue
+		}
+		categoryRules[fmt.Sprint(*rule.CategoryID)] = i
+	}
+
+	// 규칙 인덱스별 합계 (-1 은 규칙이 없어 전체 멤버 균등 분담)
+	ruleTotals := map[int]int{}
+	for categoryID, amount := range categoryTotals {
+		ruleIndex, ok := categoryRules[categoryID]
+		if !ok {
+			ruleIndex = defaultRule
+		}
+		ruleTotals[ruleIndex] += amount
+	}
+
+	var memberNames []string
+	shares := map[string]int{}
+	for ruleIndex, amount := range ruleTotals {
+		if amount == 0 {
+			continue
+		}
+
+		var names []string
+		var weights []float64
+		if ruleIndex >= 0 && len(rules[ruleIndex].Shares) > 0 {
+			for _, share := range rules[ruleIndex].Shares {
+				weight := 1.0
+				if rules[ruleIndex].Method == models.SharingMethodPercentage {
+					weight = share.Percentage
+				}
+				names = append(names, share.UserName)
+				weights = append(weights, weight)
+			}
+		} else {
+			if memberNames == nil {
+				if memberNames, err = activeLedgerMemberNames(exec, ledgerID); err != nil {
+					return nil, err
+				}
+			}
+			for _, name := range memberNames {
+				names = append(names, name)
+				weights = append(weights, 1)
+			}
+		}
+		if len(names) == 0 {
+			return nil, apiErrors.ErrInvalidSettlement.WithMessage("지출을 분담할 가계부 멤버가 없습니다")
+		}
+
+		for i, portion := range splitByWeights(amount, weights) {
+			shares[names[i]] += portion
+		}
+	}
+	return shares, nil
+}
+
+// activeLedgerMemberNames 가계부의 활성 멤버 이름 목록 (이름순)
+func activeLedgerMemberNames(exec sqlExecutor, ledgerID int) ([]string, error) {
+	rows, err := exec.Query(`
+        SELECT u.name FROM users u
+        JOIN ledger_members m ON m.user_id = u.id
+        WHERE m.ledger_id = ? AND u.is_active = 1
+        ORDER BY u.name`, ledgerID)
+	if err != nil {
+		return nil, fmt.Errorf("가계부 멤버 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	names := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("가계부 멤버 데이터 읽기 오류: %v", err)
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+// splitByWeights 금액을 가중치 비율로 원 단위 배분 (내림 후 남는 금액은 소수점 이하가 큰 순으로 1원씩 배분해 합계 유지)
+func splitByWeights(amount int, weights []float64) []int {
+	totalWeight := 0.0
+	for _, weight := range weights {
+		totalWeight += weight
+	}
+
+	portions := make([]int, len(weights))
+	remainders := make([]float64, len(weights))
+	assigned := 0
+	for i, weight := range weights {
+		exact := float64(amount) * weight / totalWeight
+		portions[i] = int(math.Floor(exact))
+		remainders[i] = exact - float64(portions[i])
+		assigned += portions[i]
+	}
+
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return remainders[order[a]] > remainders[order[b]] })
+	for i := 0; assigned < amount; i++ {
+		portions[order[i%len(order)]]++
+		assigned++
+	}
+	return portions
+}
+
+// minimizeSettlementTransfers 잔액을 0으로 맞추는 송금 목록 생성
+// 가장 많이 받을 사람과 가장 많이 보낼 사람을 차례로 짝지어 최대 (멤버 수 - 1)건으로 정산
+func minimizeSettlementTransfers(members []models.SettlementMember) []models.SettlementTransfer {
+	type party struct {
+		name   string
+		amount int
+	}
+	var creditors, debtors []party
+	for _, member := range members {
+		if member.Balance > 0 {
+			creditors = append(creditors, party{member.UserName, member.Balance})
+		} else if member.Balance < 0 {
+			debtors = append(debtors, party{member.UserName, -member.Balance})
+		}
+	}
+	sort.SliceStable(creditors, func(i, j int) bool { return creditors[i].amount > creditors[j].amount })
+	sort.SliceStable(debtors, func(i, j int) bool { return debtors[i].amount > debtors[j].amount })
+
+	transfers := []models.SettlementTransfer{}
+	for c, d := 0, 0; c < len(creditors) && d < len(debtors); {
+		amount := creditors[c].amount
+		if debtors[d].amount < amount {
+			amount = debtors[d].amount
+		}
+		transfers = append(transfers, models.SettlementTransfer{FromUser: debtors[d].name, ToUser: creditors[c].name, Amount: amount})
+
+		creditors[c].amount -= amount
+		debtors[d].amount -= amount
+		if creditors[c].amount == 0 {
+			c++
+		}
+		if debtors[d].amount == 0 {
+			d++
+		}
+	}
+	return transfers
+}
@@ -0,0 +1,306 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"strings"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// createSettlementTables 공동 지출 분담 규칙/정산 기록 테이블 생성
+// 분담 규칙은 가계부당 카테고리별 1개 (category_id 가 NULL 이면 기본 규칙), 정산 송금은 지출 데이터처럼 사용자 이름으로 기록
+func createSettlementTables(exec sqlExecutor) error {
+	steps := []string{
+		`CREATE TABLE IF NOT EXISTS sharing_rules (
+            id INTEGER PRIMARY KEY AUTOINCREMENT,
+            ledger_id INTEGER NOT NULL,
+            category_id INTEGER NULL,
+            method VARCHAR(10) NOT NULL CHECK (method IN ('equal', 'percentage')),
+            created_at TEXT DEFAULT CURRENT_TIMESTAMP,
+            updated_at TEXT DEFAULT CURRENT_TIMESTAMP,
+            FOREIGN KEY (ledger_id) REFERENCES ledgers(id) ON DELETE CASCADE,
+            FOREIGN KEY (category_id) REFERENCES categories(id)
+        )`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_sharing_rules_category ON sharing_rules(ledger_id, COALESCE(category_id, 0))`,
+		`CREATE TABLE IF NOT EXISTS sharing_rule_shares (
+            rule_id INTEGER NOT NULL,
+            user_id INTEGER NOT NULL,
+            percentage REAL NOT NULL DEFAULT 0,
+            PRIMARY KEY (rule_id, user_id),
+            FOREIGN KEY (rule_id) REFERENCES sharing_rules(id) ON DELETE CASCADE,
+            FOREIGN KEY (user_id) REFERENCES users(id)
+        )`,
+		`CREATE TABLE IF NOT EXISTS settlements (
+            id INTEGER PRIMARY KEY AUTOINCREMENT,
+            ledger_id INTEGER NOT NULL,
+            start_date TEXT NOT NULL,
+            end_date TEXT NOT NULL,
+            total_expense INTEGER NOT NULL DEFAULT 0,
+            memo TEXT NOT NULL DEFAULT '',
+            created_at TEXT DEFAULT CURRENT_TIMESTAMP,
+            FOREIGN KEY (ledger_id) REFERENCES ledgers(id) ON DELETE CASCADE
+        )`,
+		`CREATE INDEX IF NOT EXISTS idx_settlements_ledger_end ON settlements(ledger_id, end_date)`,
+		`CREATE TABLE IF NOT EXISTS settlement_transfers (
+            settlement_id INTEGER NOT NULL,
+            from_user VARCHAR(50) NOT NULL,
+            to_user VARCHAR(50) NOT NULL,
+            amount INTEGER NOT NULL CHECK (amount > 0),
+            FOREIGN KEY (settlement_id) REFERENCES settlements(id) ON DELETE CASCADE
+        )`,
+		`CREATE INDEX IF NOT EXISTS idx_settlement_transfers_settlement ON settlement_transfers(settlement_id)`,
+	}
+	for _, step := range steps {
+		if _, err := exec.Exec(step); err != nil {
+			return fmt.Errorf("정산 테이블 생성 오류: %v", err)
+		}
+	}
+	return nil
+}
+
+// GetSharingRules 가계부의 분담 규칙 목록 조회 (기본 규칙 먼저, 이후 카테고리 이름순)
+func (db *DB) GetSharingRules(ledgerID int) ([]models.SharingRule, error) {
+	return querySharingRules(db.Conn, ledgerID)
+}
+
+// GetSharingRuleByID ID로 분담 규칙 조회
+func (db *DB) GetSharingRuleByID(ledgerID, id int) (*models.SharingRule, error) {
+	rules, err := querySharingRules(db.Conn, ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range rules {
+		if rule.ID == id {
+			return &rule, nil
+		}
+	}
+	return nil, apiErrors.ErrSharingRuleNotFound
+}
+
+// CreateSharingRule 분담 규칙 생성 (같은 카테고리의 규칙이 이미 있으면 409)
+func (db *DB) CreateSharingRule(ledgerID int, req models.SharingRuleRequest) (int64, error) {
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	if err := validateSharingRule(tx, ledgerID, &req); err != nil {
+		return 0, err
+	}
+
+	result, err := tx.Exec(`INSERT INTO sharing_rules (ledger_id, category_id, method) VALUES (?, ?, ?)`, ledgerID, req.CategoryID, req.Method)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return 0, apiErrors.ErrAlreadyExists.WithMessage("해당 카테고리의 분담 규칙이 이미 있습니다")
+		}
+		return 0, fmt.Errorf("분담 규칙 생성 오류: %v", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("분담 규칙 ID 조회 오류: %v", err)
+	}
+
+	if err := insertSharingShares(tx, id, req.Shares); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("분담 규칙 커밋 오류: %v", err)
+	}
+	return id, nil
+}
+
+// UpdateSharingRule 분담 규칙 수정 (참여자 목록은 전체 교체)
+func (db *DB) UpdateSharingRule(ledgerID, id int, req models.SharingRuleRequest) error {
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	if err := validateSharingRule(tx, ledgerID, &req); err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(`
+        UPDATE sharing_rules SET category_id = ?, method = ?, updated_at = CURRENT_TIMESTAMP
+        WHERE id = ? AND ledger_id = ?`, req.CategoryID, req.Method, id, ledgerID)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return apiErrors.ErrAlreadyExists.WithMessage("해당 카테고리의 분담 규칙이 이미 있습니다")
+		}
+		return fmt.Errorf("분담 규칙 수정 오류: %v", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return apiErrors.ErrSharingRuleNotFound
+	}
+
+	if _, err := tx.Exec(`DELETE FROM sharing_rule_shares WHERE rule_id = ?`, id); err != nil {
+		return fmt.Errorf("분담 규칙 참여자 삭제 오류: %v", err)
+	}
+	if err := insertSharingShares(tx, int64(id), req.Shares); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("분담 규칙 커밋 오류: %v", err)
+	}
+	return nil
+}
+
+// DeleteSharingRule 분담 규칙 삭제 (이후 해당 카테고리는 기본 규칙으로 분담)
+func (db *DB) DeleteSharingRule(ledgerID, id int) error {
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	// 외래 키 CASCADE 가 꺼져 있을 수 있으므로 참여자를 직접 삭제
+	if _, err := tx.Exec(`DELETE FROM sharing_rule_shares WHERE rule_id IN (SELECT id FROM sharing_rules WHERE id = ? AND ledger_id = ?)`, id, ledgerID); err != nil {
+		return fmt.Errorf("분담 규칙 참여자 삭제 오류: %v", err)
+	}
+	result, err := tx.Exec(`DELETE FROM sharing_rules WHERE id = ? AND ledger_id = ?`, id, ledgerID)
+	if err != nil {
+		return fmt.Errorf("분담 규칙 삭제 오류: %v", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return apiErrors.ErrSharingRuleNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("분담 규칙 삭제 커밋 오류: %v", err)
+	}
+	return nil
+}
+
+// querySharingRules 가계부의 분담 규칙과 참여자 조회
+func querySharingRules(exec sqlExecutor, ledgerID int) ([]models.SharingRule, error) {
+	rows, err := exec.Query(`
+        SELECT r.id, r.category_id, COALESCE(c.name, ''), r.method, r.created_at, r.updated_at
+        FROM sharing_rules r
+        LEFT JOIN categories c ON r.category_id = c.id
+        WHERE r.ledger_id = ?
+        ORDER BY r.category_id IS NOT NULL, c.name, r.id`, ledgerID)
+	if err != nil {
+		return nil, fmt.Errorf("분담 규칙 조회 오류: %v", err)
+	}
+
+	rules := []models.SharingRule{}
+	index := map[int]int{}
+	for rows.Next() {
+		var rule models.SharingRule
+		var categoryID sql.NullInt64
+		if err := rows.Scan(&rule.ID, &categoryID, &rule.CategoryName, &rule.Method, &rule.CreatedAt, &rule.UpdatedAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("분담 규칙 데이터 읽기 오류: %v", err)
+		}
+		if categoryID.Valid {
+			id := int(categoryID.Int64)
+			rule.CategoryID = &id
+		}
+		rule.Shares = []models.SharingShare{}
+		index[rule.ID] = len(rules)
+		rules = append(rules, rule)
+	}
+	err = rows.Err()
+	rows.Close()
+	if err != nil {
+		return nil, fmt.Errorf("분담 규칙 조회 오류: %v", err)
+	}
+
+	shareRows, err := exec.Query(`
+        SELECT s.rule_id, s.user_id, u.name, s.percentage
+        FROM sharing_rule_shares s
+        JOIN sharing_rules r ON s.rule_id = r.id
+        JOIN users u ON s.user_id = u.id
+        WHERE r.ledger_id = ?
+        ORDER BY s.percentage DESC, u.name`, ledgerID)
+	if err != nil {
+		return nil, fmt.Errorf("분담 규칙 참여자 조회 오류: %v", err)
+	}
+	defer shareRows.Close()
+
+	for shareRows.Next() {
+		var ruleID int
+		var share models.SharingShare
+		if err := shareRows.Scan(&ruleID, &share.UserID, &share.UserName, &share.Percentage); err != nil {
+			return nil, fmt.Errorf("분담 규칙 참여자 읽기 오류: %v", err)
+		}
+		if i, ok := index[ruleID]; ok {
+			rules[i].Shares = append(rules[i].Shares, share)
+		}
+	}
+	return rules, shareRows.Err()
+}
+
+// validateSharingRule 분담 규칙 요청 검증 (지출 카테고리, 가계부 멤버, 비율 합계 100)
+func validateSharingRule(exec sqlExecutor, ledgerID int, req *models.SharingRuleRequest) error {
+	if req.Method != models.SharingMethodEqual && req.Method != models.SharingMethodPercentage {
+		return apiErrors.ErrInvalidSharingRule.WithMessage("method는 'equal' 또는 'percentage'여야 합니다")
+	}
+
+	if req.CategoryID != nil {
+		var exists int
+		err := exec.QueryRow(`SELECT 1 FROM categories WHERE id = ? AND ledger_id = ? AND type = 'out' AND is_active = 1`, *req.CategoryID, ledgerID).Scan(&exists)
+		if err == sql.ErrNoRows {
+			return apiErrors.ErrInvalidSharingRule.WithMessage(fmt.Sprintf("지출 카테고리를 찾을 수 없습니다 (ID: %d)", *req.CategoryID))
+		}
+		if err != nil {
+			return fmt.Errorf("분담 규칙 카테고리 확인 오류: %v", err)
+		}
+	}
+
+	if req.Method == models.SharingMethodPercentage && len(req.Shares) == 0 {
+		return apiErrors.ErrInvalidSharingRule.WithMessage("percentage 방식은 참여자별 비율이 필요합니다")
+	}
+
+	seen := map[int]bool{}
+	total := 0.0
+	for i, share := range req.Shares {
+		if seen[share.UserID] {
+			return apiErrors.ErrInvalidSharingRule.WithMessage(fmt.Sprintf("%d번째 참여자가 중복되었습니다 (user_id: %d)", i+1, share.UserID))
+		}
+		seen[share.UserID] = true
+
+		var exists int
+		err := exec.QueryRow(`
+            SELECT 1 FROM users u JOIN ledger_members m ON m.user_id = u.id
+            WHERE u.id = ? AND u.is_active = 1 AND m.ledger_id = ?`, share.UserID, ledgerID).Scan(&exists)
+		if err == sql.ErrNoRows {
+			return apiErrors.ErrInvalidSharingRule.WithMessage(fmt.Sprintf("%d번째 참여자는 가계부 멤버가 아닙니다 (user_id: %d)", i+1, share.UserID))
+		}
+		if err != nil {
+			return fmt.Errorf("분담 규칙 참여자 확인 오류: %v", err)
+		}
+
+		if req.Method == models.SharingMethodEqual {
+			req.Shares[i].Percentage = 0
+			continue
+		}
+		if share.Percentage <= 0 {
+			return apiErrors.ErrInvalidSharingRule.WithMessage(fmt.Sprintf("%d번째 참여자의 비율은 0보다 커야 합니다", i+1))
+		}
+		total += share.Percentage
+	}
+
+	if req.Method == models.SharingMethodPercentage && math.Abs(total-100) > 0.001 {
+		return apiErrors.ErrInvalidSharingRule.WithMessage(fmt.Sprintf("비율 합계(%g%%)가 100%%가 아닙니다", total))
+	}
+	return nil
+}
+
+// insertSharingShares 분담 규칙 참여자 저장
+func insertSharingShares(exec sqlExecutor, ruleID int64, shares []models.SharingShare) error {
+	for _, share := range shares {
+		_, err := exec.Exec(`INSERT INTO sharing_rule_shares (rule_id, user_id, percentage) VALUES (?, ?, ?)`, ruleID, share.UserID, share.Percentage)
+		if err != nil {
+			return fmt.Errorf("분담 규칙 참여자 저장 오류: %v", err)
+		}
+	}
+	utils.Debug("분담 규칙 참여자 저장: 규칙=%d, %d명", ruleID, len(shares))
+	return nil
+}
@@ -0,0 +1,338 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// minSplitItems 분할 지출의 최소 항목 수
+const minSplitItems = 2
+
+// createSplitTables 분할 지출 항목 테이블과 항목 단위 지출 뷰 생성
+// out_account_line_items 뷰는 분할되지 않은 지출은 그대로, 분할된 지출은 항목별로 펼쳐서 보여줌
+// (카테고리/키워드 통계와 예산 사용량은 이 뷰로 집계)
+func createSplitTables(exec sqlExecutor) error {
+	createSplitTable := `
+    CREATE TABLE IF NOT EXISTS out_account_splits (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        ledger_id INTEGER NOT NULL,
+        out_account_uuid TEXT NOT NULL,
+        seq INTEGER NOT NULL,
+        category_id INTEGER NOT NULL,
+        keyword_id INTEGER NULL,
+        money INT NOT NULL CHECK (money > 0),
+        memo TEXT DEFAULT '',
+        created_at TEXT DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (out_account_uuid) REFERENCES out_account_data(uuid) ON DELETE CASCADE,
+        FOREIGN KEY (category_id) REFERENCES categories(id),
+        FOREIGN KEY (keyword_id) REFERENCES keywords(id),
+        UNIQUE(out_account_uuid, seq)
+    );`
+
+	if _, err := exec.Exec(createSplitTable); err != nil {
+		return fmt.Errorf("분할 지출 테이블 생성 오류: %v", err)
+	}
+
+	steps := []string{
+		`CREATE INDEX IF NOT EXISTS idx_out_account_splits_category ON out_account_splits(category_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_out_account_splits_keyword ON out_account_splits(keyword_id)`,
+		`CREATE VIEW IF NOT EXISTS out_account_line_items AS
+        SELECT oa.uuid, oa.ledger_id, oa.date, oa.user, oa.money, oa.category_id, oa.keyword_id, oa.payment_method_id
+        FROM out_account_data oa
+        WHERE NOT EXISTS (SELECT 1 FROM out_account_splits s WHERE s.out_account_uuid = oa.uuid)
+        UNION ALL
+        SELECT oa.uuid, oa.ledger_id, oa.date, oa.user, s.money, s.category_id, s.keyword_id, oa.payment_method_id
+        FROM out_account_splits s
+        JOIN out_account_data oa ON s.out_account_uuid = oa.uuid`,
+	}
+	for _, step := range steps {
+		if _, err := exec.Exec(step); err != nil {
+			return fmt.Errorf("분할 지출 인덱스/뷰 생성 오류: %v", err)
+		}
+	}
+	return nil
+}
+
+// dropSplitTables 항목 단위 지출 뷰와 분할 지출 항목 테이블 제거 (분할된 지출은 대표 카테고리로만 남음)
+func dropSplitTables(exec sqlExecutor) error {
+	steps := []string{
+		`DROP VIEW IF EXISTS out_account_line_items`,
+		`DROP TABLE IF EXISTS out_account_splits`,
+	}
+	for _, step := range steps {
+		if _, err := exec.Exec(step); err != nil {
+			return fmt.Errorf("분할 지출 테이블 제거 오류: %v", err)
+		}
+	}
+	return nil
+}
+
+// GetOutAccountSplits 지출의 분할 항목 조회 (분할되지 않은 지출은 빈 목록)
+func (db *DB) GetOutAccountSplits(ledgerID int, uuidStr string) ([]models.OutAccountSplit, error) {
+	if _, err := outAccountMoney(db.Conn, ledgerID, uuidStr); err != nil {
+		return nil, err
+	}
+
+	splits, err := loadOutAccountSplits(db.Conn, ledgerID, []string{uuidStr})
+	if err != nil {
+		return nil, err
+	}
+	if items, ok := splits[uuidStr]; ok {
+		return items, nil
+	}
+	return []models.OutAccountSplit{}, nil
+}
+
+// SetOutAccountSplits 지출의 분할 항목 전체 교체 (빈 목록이면 분할 해제)
+func (db *DB) SetOutAccountSplits(ledgerID int, uuidStr string, splits []models.OutAccountSplitRequest) error {
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	money, err := outAccountMoney(tx, ledgerID, uuidStr)
+	if err != nil {
+		return err
+	}
+	if err := ensureNotInstallmentPortion(tx, ledgerID, uuidStr); err != nil {
+		return err
+	}
+	if len(splits) > 0 {
+		if err := ensureNotForeignCurrency(tx, models.AccountTypeOut, uuidStr); err != nil {
+			return err
+		}
+	}
+
+	if err := replaceOutAccountSplits(tx, ledgerID, uuidStr, money, splits); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("분할 지출 저장 커밋 오류: %v", err)
+	}
+	return nil
+}
+
+// InsertSplitOutAccount 분할 지출 등록 (대표 카테고리는 금액이 가장 큰 항목, 생성된 UUID 반환)
+func (db *DB) InsertSplitOutAccount(ledgerID int, date, user string, money, paymentMethodID int, memo string, splits []models.OutAccountSplitRequest) (string, error) {
+	primary, err := validateOutAccountSplits(db.Conn, ledgerID, money, splits)
+	if err != nil {
+		return "", err
+	}
+	if primary < 0 {
+		return "", apiErrors.ErrInvalidSplit.WithMessage(fmt.Sprintf("분할 항목은 %d개 이상이어야 합니다", minSplitItems))
+	}
+
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return "", fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	uuidStr, err := insertOutAccount(tx, ledgerID, date, user, money, splits[primary].CategoryID, splits[primary].KeywordID, paymentMethodID, memo)
+	if err != nil {
+		return "", err
+	}
+	if err := replaceOutAccountSplits(tx, ledgerID, uuidStr, money, splits); err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("분할 지출 등록 커밋 오류: %v", err)
+	}
+	return uuidStr, nil
+}
+
+// replaceOutAccountSplits 기존 분할 항목을 지우고 새 항목 저장 후 지출의 대표 카테고리/키워드 갱신
+func replaceOutAccountSplits(exec sqlExecutor, ledgerID int, uuidStr string, money int, splits []models.OutAccountSplitRequest) error {
+	primary, err := validateOutAccountSplits(exec, ledgerID, money, splits)
+	if err != nil {
+		return err
+	}
+
+	if err := deleteOutAccountSplits(exec, uuidStr); err != nil {
+		return err
+	}
+	if primary < 0 {
+		utils.Debug("분할 지출 해제: UUID=%s", uuidStr)
+		return nil
+	}
+
+	insertQuery := `
+    INSERT INTO out_account_splits (ledger_id, out_account_uuid, seq, category_id, keyword_id, money, memo, created_at)
+    VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)`
+	for i, split := range splits {
+		if _, err := exec.Exec(insertQuery, ledgerID, uuidStr, i+1, split.CategoryID, split.KeywordID, split.Money, strings.TrimSpace(split.Memo)); err != nil {
+			return fmt.Errorf("분할 지출 항목 저장 오류: %v", err)
+		}
+	}
+
+	_, err = exec.Exec(`UPDATE out_account_data SET category_id = ?, keyword_id = ?, updated_at = CURRENT_TIMESTAMP WHERE uuid = ?`,
+		splits[primary].CategoryID, splits[primary].KeywordID, uuidStr)
+	if err != nil {
+		return fmt.Errorf("분할 지출 대표 카테고리 갱신 오류: %v", err)
+	}
+
+	utils.Debug("분할 지출 저장: UUID=%s, 항목 %d개", uuidStr, len(splits))
+	return nil
+}
+
+// validateOutAccountSplits 분할 항목 검증 (금액이 가장 큰 항목의 인덱스 반환, 빈 목록이면 -1)
+// 각 항목은 같은 가계부의 활성 지출 카테고리여야 하고, 항목 합계는 결제 금액과 같아야 함
+func validateOutAccountSplits(exec sqlExecutor, ledgerID int, money int, splits []models.OutAccountSplitRequest) (int, error) {
+	if len(splits) == 0 {
+		return -1, nil
+	}
+	if len(splits) < minSplitItems {
+		return -1, apiErrors.ErrInvalidSplit.WithMessage(fmt.Sprintf("분할 항목은 %d개 이상이어야 합니다", minSplitItems))
+	}
+
+	primary, total := 0, 0
+	for i, split := range splits {
+		if split.Money <= 0 {
+			return -1, apiErrors.ErrInvalidSplit.WithMessage(fmt.Sprintf("%d번째 항목의 금액은 0보다 커야 합니다", i+1))
+		}
+
+		var exists int
+		err := exec.QueryRow(`SELECT 1 FROM categories WHERE id = ? AND ledger_id = ? AND type = 'out' AND is_active = 1`, split.CategoryID, ledgerID).Scan(&exists)
+		if err == sql.ErrNoRows {
+			return -1, apiErrors.ErrInvalidSplit.WithMessage(fmt.Sprintf("%d번째 항목의 지출 카테고리를 찾을 수 없습니다 (ID: %d)", i+1, split.CategoryID))
+		}
+		if err != nil {
+			return -1, fmt.Errorf("분할 항목 카테고리 확인 오류: %v", err)
+		}
+
+		if split.Money > splits[primary].Money {
+			primary = i
+		}
+		total += split.Money
+	}
+
+	if total != money {
+		return -1, apiErrors.ErrInvalidSplit.WithMessage(fmt.Sprintf("분할 항목 합계(%d)가 결제 금액(%d)과 다릅니다", total, money))
+	}
+	return primary, nil
+}
+
+// ensureSplitTotal 분할된 지출의 금액을 바꿀 때 항목 합계와 맞는지 확인하고 대표 카테고리/키워드 유지
+func ensureSplitTotal(exec sqlExecutor, uuidStr string, money int) error {
+	var count, total int
+	err := exec.QueryRow(`SELECT COUNT(*), COALESCE(SUM(money), 0) FROM out_account_splits WHERE out_account_uuid = ?`, uuidStr).Scan(&count, &total)
+	if err != nil {
+		return fmt.Errorf("분할 지출 확인 오류: %v", err)
+	}
+	if count > 0 && total != money {
+		return apiErrors.ErrInvalidSplit.WithMessage(fmt.Sprintf("분할 항목 합계(%d)와 금액(%d)이 다릅니다. 분할 항목을 먼저 수정해주세요", total, money))
+	}
+	return nil
+}
+
+// syncSplitPrimary 분할된 지출의 대표 카테고리/키워드를 금액이 가장 큰 항목으로 맞춤
+func syncSplitPrimary(exec sqlExecutor, uuidStr string) error {
+	query := `
+    UPDATE out_account_data
+    SET category_id = s.category_id, keyword_id = s.keyword_id
+    FROM (SELECT category_id, keyword_id FROM out_account_splits WHERE out_account_uuid = ? ORDER BY money DESC, seq ASC LIMIT 1) s
+    WHERE uuid = ?`
+	if _, err := exec.Exec(query, uuidStr, uuidStr); err != nil {
+		return fmt.Errorf("분할 지출 대표 카테고리 갱신 오류: %v", err)
+	}
+	return nil
+}
+
+// deleteOutAccountSplits 지출의 분할 항목 삭제
+func deleteOutAccountSplits(exec sqlExecutor, uuidStr string) error {
+	if _, err := exec.Exec(`DELETE FROM out_account_splits WHERE out_account_uuid = ?`, uuidStr); err != nil {
+		return fmt.Errorf("분할 지출 항목 삭제 오류: %v", err)
+	}
+	return nil
+}
+
+// outAccountMoney 같은 가계부의 지출 금액 조회 (존재 확인 겸용)
+func outAccountMoney(exec sqlExecutor, ledgerID int, uuidStr string) (int, error) {
+	var money int
+	err := exec.QueryRow(`SELECT money FROM out_account_data WHERE uuid = ? AND ledger_id = ?`, uuidStr, ledgerID).Scan(&money)
+	if err == sql.ErrNoRows {
+		return 0, apiErrors.ErrAccountNotFound.WithMessage("지출 데이터를 찾을 수 없습니다")
+	}
+	if err != nil {
+		return 0, fmt.Errorf("지출 데이터 조회 오류: %v", err)
+	}
+	return money, nil
+}
+
+// attachOutAccountSplits 지출 목록에 분할 항목 채우기
+func attachOutAccountSplits(exec sqlExecutor, ledgerID int, accounts []models.OutAccount) error {
+	if len(accounts) == 0 {
+		return nil
+	}
+
+	uuids := make([]string, len(accounts))
+	for i := range accounts {
+		uuids[i] = accounts[i].UUID
+	}
+
+	splits, err := loadOutAccountSplits(exec, ledgerID, uuids)
+	if err != nil {
+		return err
+	}
+	for i := range accounts {
+		accounts[i].Splits = splits[accounts[i].UUID]
+	}
+	return nil
+}
+
+// loadOutAccountSplits 여러 지출의 분할 항목을 지출 UUID별로 조회 (SQLite 변수 개수 제한을 피해 나누어 조회)
+func loadOutAccountSplits(exec sqlExecutor, ledgerID int, uuids []string) (map[string][]models.OutAccountSplit, error) {
+	const chunkSize = 500
+	result := make(map[string][]models.OutAccountSplit)
+
+	for start := 0; start < len(uuids); start += chunkSize {
+		end := start + chunkSize
+		if end > len(uuids) {
+			end = len(uuids)
+		}
+		chunk := uuids[start:end]
+
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(chunk)), ", ")
+		query := `
+        SELECT s.out_account_uuid, s.id, s.seq, s.category_id, COALESCE(c.name, ''), s.keyword_id, COALESCE(k.name, ''), s.money, COALESCE(s.memo, '')
+        FROM out_account_splits s
+        LEFT JOIN categories c ON s.category_id = c.id
+        LEFT JOIN keywords k ON s.keyword_id = k.id
+        WHERE s.ledger_id = ? AND s.out_account_uuid IN (` + placeholders + `)
+        ORDER BY s.out_account_uuid, s.seq`
+
+		args := []interface{}{ledgerID}
+		for _, uuidStr := range chunk {
+			args = append(args, uuidStr)
+		}
+
+		rows, err := exec.Query(query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("분할 지출 항목 조회 오류: %v", err)
+		}
+		for rows.Next() {
+			var uuidStr string
+			var split models.OutAccountSplit
+			if err := rows.Scan(&uuidStr, &split.ID, &split.Seq, &split.CategoryID, &split.CategoryName,
+				&split.KeywordID, &split.KeywordName, &split.Money, &split.Memo); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("분할 지출 항목 읽기 오류: %v", err)
+			}
+			result[uuidStr] = append(result[uuidStr], split)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("분할 지출 항목 조회 오류: %v", err)
+		}
+	}
+	return result, nil
+}
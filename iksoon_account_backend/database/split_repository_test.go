@@ -0,0 +1,150 @@
+package database
+
+import (
+	"errors"
+	"testing"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+)
+
+func TestInsertSplitOutAccountValidation(t *testing.T) {
+	db := newTestDB(t)
+	food := createTestCategory(t, db, "테스트 식비", "out")
+	living := createTestCategory(t, db, "테스트 생활", "out")
+	salary := createTestCategory(t, db, "테스트 월급", "in")
+	methodID := createTestPaymentMethod(t, db, "테스트 카드")
+
+	split := func(categoryID, money int) models.OutAccountSplitRequest {
+		return models.OutAccountSplitRequest{CategoryID: categoryID, Money: money}
+	}
+	tests := []struct {
+		name        string
+		money       int
+		splits      []models.OutAccountSplitRequest
+		wantPrimary int // 0 이면 오류
+	}{
+		{"합계 일치", 10000, []models.OutAccountSplitRequest{split(food, 3000), split(living, 7000)}, living},
+		{"가장 큰 금액이 같으면 앞 항목이 대표", 10000, []models.OutAccountSplitRequest{split(food, 5000), split(living, 5000)}, food},
+		{"세 항목", 10001, []models.OutAccountSplitRequest{split(food, 3333), split(living, 3334), split(food, 3334)}, living},
+		{"항목 없음", 10000, nil, 0},
+		{"항목 1개", 10000, []models.OutAccountSplitRequest{split(food, 10000)}, 0},
+		{"합계 부족", 10000, []models.OutAccountSplitRequest{split(food, 3000), split(living, 6999)}, 0},
+		{"합계 초과", 10000, []models.OutAccountSplitRequest{split(food, 3000), split(living, 7001)}, 0},
+		{"0원 항목", 10000, []models.OutAccountSplitRequest{split(food, 10000), split(living, 0)}, 0},
+		{"음수 항목으로 합계 맞추기", 10000, []models.OutAccountSplitRequest{split(food, 12000), split(living, -2000)}, 0},
+		{"수입 카테고리", 10000, []models.OutAccountSplitRequest{split(food, 3000), split(salary, 7000)}, 0},
+		{"없는 카테고리", 10000, []models.OutAccountSplitRequest{split(food, 3000), split(99999, 7000)}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uuid, err := db.InsertSplitOutAccount(DefaultLedgerID, "2024-03-05", "테스트", tt.money, methodID, "마트", tt.splits)
+			if tt.wantPrimary == 0 {
+				var apiErr apiErrors.ErrorCode
+				if !errors.As(err, &apiErr) || apiErr.Code != apiErrors.ErrInvalidSplit.Code {
+					t.Fatalf("error = %v, want %s", err, apiErrors.ErrInvalidSplit.Code)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("InsertSplitOutAccount() error = %v", err)
+			}
+
+			account, err := db.GetOutAccountByUUID(DefaultLedgerID, uuid)
+			if err != nil {
+				t.Fatalf("GetOutAccountByUUID() error = %v", err)
+			}
+			if account.CategoryID != tt.wantPrimary || account.Money != tt.money {
+				t.Errorf("지출 = 카테고리 %d, %d원; want 대표 카테고리 %d, %d원", account.CategoryID, account.Money, tt.wantPrimary, tt.money)
+			}
+			splits, err := db.GetOutAccountSplits(DefaultLedgerID, uuid)
+			if err != nil {
+				t.Fatalf("GetOutAccountSplits() error = %v", err)
+			}
+			if len(splits) != len(tt.splits) {
+				t.Errorf("분할 항목 %d건, want %d건", len(splits), len(tt.splits))
+			}
+		})
+	}
+}
+
+func TestSplitOutAccountStatistics(t *testing.T) {
+	db := newTestDB(t)
+	food := createTestCategory(t, db, "테스트 식비", "out")
+	living := createTestCategory(t, db, "테스트 생활", "out")
+	methodID := createTestPaymentMethod(t, db, "테스트 카드")
+	if _, err := db.CreateCategoryBudget(DefaultLedgerID, living, "", 100000, 0, "2024-03"); err != nil {
+		t.Fatalf("기준치 생성 실패: %v", err)
+	}
+
+	uuid, err := db.InsertSplitOutAccount(DefaultLedgerID, "2024-03-05", "테스트", 50000, methodID, "마트", []models.OutAccountSplitRequest{
+		{CategoryID: food, Money: 30000}, {CategoryID: living, Money: 20000},
+	})
+	if err != nil {
+		t.Fatalf("InsertSplitOutAccount() error = %v", err)
+	}
+
+	// 카테고리 통계와 기준치 사용량은 대표 카테고리가 아니라 항목 단위로 집계
+	categoryTotals := func() map[int]int {
+		t.Helper()
+		stats, err := db.GetCategoryStatistics(DefaultLedgerID, "2024-03-01", "2024-03-31", "out")
+		if err != nil {
+			t.Fatalf("GetCategoryStatistics() error = %v", err)
+		}
+		totals := map[int]int{}
+		for _, stat := range stats {
+			totals[stat.CategoryID] = stat.TotalAmount
+		}
+		return totals
+	}
+	livingUsed := func() int {
+		t.Helper()
+		usage, err := db.GetBudgetUsage(DefaultLedgerID, living, "", mustParseKST(t, "2024-03-20 12:00:00"))
+		if err != nil {
+			t.Fatalf("GetBudgetUsage() error = %v", err)
+		}
+		return usage.MonthlyUsed
+	}
+	if totals := categoryTotals(); totals[food] != 30000 || totals[living] != 20000 {
+		t.Errorf("카테고리 통계 = %v, want 식비 30000, 생활 20000", totals)
+	}
+	if used := livingUsed(); used != 20000 {
+		t.Errorf("생활 기준치 사용량 = %d, want 20000", used)
+	}
+
+	// 분할된 지출의 금액은 항목 합계와 다르게 바꿀 수 없음
+	if err := db.UpdateOutAccount(DefaultLedgerID, uuid, "2024-03-05", "테스트", 50001, food, nil, methodID, "마트"); err == nil {
+		t.Error("항목 합계와 다른 금액으로 수정됨")
+	}
+
+	// 항목을 바꾸면 대표 카테고리도 가장 큰 항목으로 바뀜
+	if err := db.SetOutAccountSplits(DefaultLedgerID, uuid, []models.OutAccountSplitRequest{
+		{CategoryID: food, Money: 10000}, {CategoryID: living, Money: 40000},
+	}); err != nil {
+		t.Fatalf("SetOutAccountSplits() error = %v", err)
+	}
+	account, err := db.GetOutAccountByUUID(DefaultLedgerID, uuid)
+	if err != nil {
+		t.Fatalf("GetOutAccountByUUID() error = %v", err)
+	}
+	if account.CategoryID != living {
+		t.Errorf("항목 변경 후 대표 카테고리 = %d, want %d", account.CategoryID, living)
+	}
+	if used := livingUsed(); used != 40000 {
+		t.Errorf("항목 변경 후 생활 기준치 사용량 = %d, want 40000", used)
+	}
+
+	// 분할을 해제하면 대표 카테고리로 전체 금액이 잡히고 금액도 자유롭게 수정 가능
+	if err := db.SetOutAccountSplits(DefaultLedgerID, uuid, nil); err != nil {
+		t.Fatalf("분할 해제 error = %v", err)
+	}
+	if totals := categoryTotals(); totals[food] != 0 || totals[living] != 50000 {
+		t.Errorf("분할 해제 후 카테고리 통계 = %v, want 생활 50000", totals)
+	}
+	if err := db.UpdateOutAccount(DefaultLedgerID, uuid, "2024-03-05", "테스트", 70000, living, nil, methodID, "마트"); err != nil {
+		t.Errorf("분할되지 않은 지출의 금액 수정 error = %v", err)
+	}
+	if used := livingUsed(); used != 70000 {
+		t.Errorf("금액 수정 후 생활 기준치 사용량 = %d, want 70000", used)
+	}
+}
@@ -6,8 +6,8 @@ import (
 	"iksoon_account_backend/models"
 )
 
-// GetCategoryStatistics 카테고리별 통계 조회
-func (db *DB) GetCategoryStatistics(startDate, endDate, accountType string) ([]models.CategoryStatistics, error) {
+// GetCategoryStatistics 카테고리별 통계 조회 (분할 지출은 항목 단위로 집계)
+func (db *DB) GetCategoryStatistics(ledgerID int, startDate, endDate, accountType string) ([]models.CategoryStatistics, error) {
 	var query string
 
 	if accountType == "out" {
@@ -18,9 +18,9 @@ func (db *DB) GetCategoryStatistics(startDate, endDate, accountType string) ([]m
 			COALESCE(SUM(oa.money), 0) as total_amount,
 			COALESCE(COUNT(oa.uuid), 0) as count
 		FROM categories c
-		LEFT JOIN out_account_data oa ON c.id = oa.category_id 
+		LEFT JOIN out_account_net_line_items oa ON c.id = oa.category_id 
 			AND date(oa.date) >= ? AND date(oa.date) <= ?
-		WHERE c.type = 'out'
+		WHERE c.ledger_id = ? AND c.type = 'out'
 		GROUP BY c.id, c.name
 		HAVING total_amount > 0
 		ORDER BY total_amount DESC`
@@ -32,15 +32,15 @@ func (db *DB) GetCategoryStatistics(startDate, endDate, accountType string) ([]m
 			COALESCE(SUM(ia.money), 0) as total_amount,
 			COALESCE(COUNT(ia.uuid), 0) as count
 		FROM categories c
-		LEFT JOIN in_account_data ia ON c.id = ia.category_id 
+		LEFT JOIN in_account_net_data ia ON c.id = ia.category_id 
 			AND date(ia.date) >= ? AND date(ia.date) <= ?
-		WHERE c.type = 'in'
+		WHERE c.ledger_id = ? AND c.type = 'in'
 		GROUP BY c.id, c.name
 		HAVING total_amount > 0
 		ORDER BY total_amount DESC`
 	}
 
-	rows, err := db.Conn.Query(query, startDate, endDate)
+	rows, err := db.Conn.Query(query, startDate, endDate, ledgerID)
 	if err != nil {
 		return nil, fmt.Errorf("카테고리 통계 조회 오류: %v", err)
 	}
@@ -59,8 +59,8 @@ func (db *DB) GetCategoryStatistics(startDate, endDate, accountType string) ([]m
 	return statistics, nil
 }
 
-// GetKeywordStatistics 키워드별 통계 조회
-func (db *DB) GetKeywordStatistics(categoryID int, startDate, endDate, accountType string) ([]models.KeywordStatistics, error) {
+// GetKeywordStatistics 키워드별 통계 조회 (분할 지출은 항목 단위로 집계)
+func (db *DB) GetKeywordStatistics(ledgerID int, categoryID int, startDate, endDate, accountType string) ([]models.KeywordStatistics, error) {
 	var query string
 
 	if accountType == "out" {
@@ -71,10 +71,10 @@ func (db *DB) GetKeywordStatistics(categoryID int, startDate, endDate, accountTy
 			COALESCE(SUM(oa.money), 0) as total_amount,
 			COALESCE(COUNT(oa.uuid), 0) as count
 		FROM keywords k
-		LEFT JOIN out_account_data oa ON k.id = oa.keyword_id 
+		LEFT JOIN out_account_net_line_items oa ON k.id = oa.keyword_id 
 			AND oa.category_id = ? 
 			AND date(oa.date) >= ? AND date(oa.date) <= ?
-		WHERE k.category_id = ?
+		WHERE k.category_id = ? AND k.category_id IN (SELECT id FROM categories WHERE ledger_id = ?)
 		GROUP BY k.id, k.name
 		HAVING total_amount > 0
 		ORDER BY total_amount DESC`
@@ -86,16 +86,16 @@ func (db *DB) GetKeywordStatistics(categoryID int, startDate, endDate, accountTy
 			COALESCE(SUM(ia.money), 0) as total_amount,
 			COALESCE(COUNT(ia.uuid), 0) as count
 		FROM keywords k
-		LEFT JOIN in_account_data ia ON k.id = ia.keyword_id 
+		LEFT JOIN in_account_net_data ia ON k.id = ia.keyword_id 
 			AND ia.category_id = ? 
 			AND date(ia.date) >= ? AND date(ia.date) <= ?
-		WHERE k.category_id = ?
+		WHERE k.category_id = ? AND k.category_id IN (SELECT id FROM categories WHERE ledger_id = ?)
 		GROUP BY k.id, k.name
 		HAVING total_amount > 0
 		ORDER BY total_amount DESC`
 	}
 
-	rows, err := db.Conn.Query(query, categoryID, startDate, endDate, categoryID)
+	rows, err := db.Conn.Query(query, categoryID, startDate, endDate, categoryID, ledgerID)
 	if err != nil {
 		return nil, fmt.Errorf("키워드 통계 조회 오류: %v", err)
 	}
@@ -114,8 +114,8 @@ func (db *DB) GetKeywordStatistics(categoryID int, startDate, endDate, accountTy
 	return statistics, nil
 }
 
-// GetTotalAmount 총 금액과 개수 조회
-func (db *DB) GetTotalAmount(startDate, endDate, accountType string) (int, int, error) {
+// GetTotalAmount 총 금액과 개수 조회 (환급 완료된 지출과 환급 수입은 환급받은 금액만큼 서로 상쇄해 집계)
+func (db *DB) GetTotalAmount(ledgerID int, startDate, endDate, accountType string) (int, int, error) {
 	var query string
 
 	if accountType == "out" {
@@ -123,19 +123,19 @@ func (db *DB) GetTotalAmount(startDate, endDate, accountType string) (int, int,
 		SELECT 
 			COALESCE(SUM(money), 0) as total_amount,
 			COALESCE(COUNT(uuid), 0) as total_count
-		FROM out_account_data 
-		WHERE date(date) >= ? AND date(date) <= ?`
+		FROM out_account_net_data 
+		WHERE ledger_id = ? AND date(date) >= ? AND date(date) <= ?`
 	} else {
 		query = `
 		SELECT 
 			COALESCE(SUM(money), 0) as total_amount,
 			COALESCE(COUNT(uuid), 0) as total_count
-		FROM in_account_data 
-		WHERE date(date) >= ? AND date(date) <= ?`
+		FROM in_account_net_data 
+		WHERE ledger_id = ? AND date(date) >= ? AND date(date) <= ?`
 	}
 
 	var totalAmount, totalCount int
-	err := db.Conn.QueryRow(query, startDate, endDate).Scan(&totalAmount, &totalCount)
+	err := db.Conn.QueryRow(query, ledgerID, startDate, endDate).Scan(&totalAmount, &totalCount)
 	if err != nil {
 		return 0, 0, fmt.Errorf("총 금액 조회 오류: %v", err)
 	}
@@ -144,7 +144,7 @@ func (db *DB) GetTotalAmount(startDate, endDate, accountType string) (int, int,
 }
 
 // GetMonthlyTrend 월별 트렌드 조회 (최근 12개월)
-func (db *DB) GetMonthlyTrend(accountType string) ([]map[string]interface{}, error) {
+func (db *DB) GetMonthlyTrend(ledgerID int, accountType string) ([]map[string]interface{}, error) {
 	var query string
 
 	if accountType == "out" {
@@ -153,8 +153,8 @@ func (db *DB) GetMonthlyTrend(accountType string) ([]map[string]interface{}, err
 			substr(date, 1, 7) as month,
 			SUM(money) as total_amount,
 			COUNT(uuid) as total_count
-		FROM out_account_data 
-		WHERE date >= date('now', '-12 months')
+		FROM out_account_net_data 
+		WHERE ledger_id = ? AND date >= date('now', '-12 months')
 		GROUP BY substr(date, 1, 7)
 		ORDER BY month ASC`
 	} else {
@@ -163,13 +163,13 @@ func (db *DB) GetMonthlyTrend(accountType string) ([]map[string]interface{}, err
 			substr(date, 1, 7) as month,
 			SUM(money) as total_amount,
 			COUNT(uuid) as total_count
-		FROM in_account_data 
-		WHERE date >= date('now', '-12 months')
+		FROM in_account_net_data 
+		WHERE ledger_id = ? AND date >= date('now', '-12 months')
 		GROUP BY substr(date, 1, 7)
 		ORDER BY month ASC`
 	}
 
-	rows, err := db.Conn.Query(query)
+	rows, err := db.Conn.Query(query, ledgerID)
 	if err != nil {
 		return nil, fmt.Errorf("월별 트렌드 조회 오류: %v", err)
 	}
@@ -197,7 +197,7 @@ func (db *DB) GetMonthlyTrend(accountType string) ([]map[string]interface{}, err
 }
 
 // GetDailyTrend 일별 트렌드 조회 (최근 30일)
-func (db *DB) GetDailyTrend(accountType string) ([]map[string]interface{}, error) {
+func (db *DB) GetDailyTrend(ledgerID int, accountType string) ([]map[string]interface{}, error) {
 	var query string
 
 	if accountType == "out" {
@@ -206,8 +206,8 @@ func (db *DB) GetDailyTrend(accountType string) ([]map[string]interface{}, error
 			date(date) as day,
 			SUM(money) as total_amount,
 			COUNT(uuid) as total_count
-		FROM out_account_data 
-		WHERE date >= date('now', '-30 days')
+		FROM out_account_net_data 
+		WHERE ledger_id = ? AND date >= date('now', '-30 days')
 		GROUP BY date(date)
 		ORDER BY day ASC`
 	} else {
@@ -216,13 +216,13 @@ func (db *DB) GetDailyTrend(accountType string) ([]map[string]interface{}, error
 			date(date) as day,
 			SUM(money) as total_amount,
 			COUNT(uuid) as total_count
-		FROM in_account_data 
-		WHERE date >= date('now', '-30 days')
+		FROM in_account_net_data 
+		WHERE ledger_id = ? AND date >= date('now', '-30 days')
 		GROUP BY date(date)
 		ORDER BY day ASC`
 	}
 
-	rows, err := db.Conn.Query(query)
+	rows, err := db.Conn.Query(query, ledgerID)
 	if err != nil {
 		return nil, fmt.Errorf("일별 트렌드 조회 오류: %v", err)
 	}
@@ -250,7 +250,7 @@ func (db *DB) GetDailyTrend(accountType string) ([]map[string]interface{}, error
 }
 
 // GetTopCategories 상위 카테고리 조회 (특정 개수)
-func (db *DB) GetTopCategories(startDate, endDate, accountType string, limit int) ([]models.CategoryStatistics, error) {
+func (db *DB) GetTopCategories(ledgerID int, startDate, endDate, accountType string, limit int) ([]models.CategoryStatistics, error) {
 	var query string
 
 	if accountType == "out" {
@@ -261,9 +261,9 @@ func (db *DB) GetTopCategories(startDate, endDate, accountType string, limit int
 			COALESCE(SUM(oa.money), 0) as total_amount,
 			COALESCE(COUNT(oa.uuid), 0) as count
 		FROM categories c
-		LEFT JOIN out_account_data oa ON c.id = oa.category_id 
+		LEFT JOIN out_account_net_line_items oa ON c.id = oa.category_id 
 			AND date(oa.date) >= ? AND date(oa.date) <= ?
-		WHERE c.type = 'out'
+		WHERE c.ledger_id = ? AND c.type = 'out'
 		GROUP BY c.id, c.name
 		HAVING total_amount > 0
 		ORDER BY total_amount DESC
@@ -276,16 +276,16 @@ func (db *DB) GetTopCategories(startDate, endDate, accountType string, limit int
 			COALESCE(SUM(ia.money), 0) as total_amount,
 			COALESCE(COUNT(ia.uuid), 0) as count
 		FROM categories c
-		LEFT JOIN in_account_data ia ON c.id = ia.category_id 
+		LEFT JOIN in_account_net_data ia ON c.id = ia.category_id 
 			AND date(ia.date) >= ? AND date(ia.date) <= ?
-		WHERE c.type = 'in'
+		WHERE c.ledger_id = ? AND c.type = 'in'
 		GROUP BY c.id, c.name
 		HAVING total_amount > 0
 		ORDER BY total_amount DESC
 		LIMIT ?`
 	}
 
-	rows, err := db.Conn.Query(query, startDate, endDate, limit)
+	rows, err := db.Conn.Query(query, startDate, endDate, ledgerID, limit)
 	if err != nil {
 		return nil, fmt.Errorf("상위 카테고리 조회 오류: %v", err)
 	}
@@ -305,7 +305,7 @@ func (db *DB) GetTopCategories(startDate, endDate, accountType string, limit int
 }
 
 // GetPaymentMethodStatistics 결제수단별 통계 조회 (지출만)
-func (db *DB) GetPaymentMethodStatistics(startDate, endDate string) ([]models.PaymentMethodStatistics, error) {
+func (db *DB) GetPaymentMethodStatistics(ledgerID int, startDate, endDate string) ([]models.PaymentMethodStatistics, error) {
 	query := `
 	SELECT 
 		pm.id as payment_method_id,
@@ -313,14 +313,14 @@ func (db *DB) GetPaymentMethodStatistics(startDate, endDate string) ([]models.Pa
 		COALESCE(SUM(oa.money), 0) as total_amount,
 		COALESCE(COUNT(oa.uuid), 0) as count
 	FROM payment_methods pm
-	LEFT JOIN out_account_data oa ON pm.id = oa.payment_method_id 
+	LEFT JOIN out_account_net_data oa ON pm.id = oa.payment_method_id 
 		AND date(oa.date) >= ? AND date(oa.date) <= ?
-	WHERE pm.is_active = 1
+	WHERE pm.ledger_id = ? AND pm.is_active = 1
 	GROUP BY pm.id, pm.name
 	HAVING total_amount > 0
 	ORDER BY total_amount DESC`
 
-	rows, err := db.Conn.Query(query, startDate, endDate)
+	rows, err := db.Conn.Query(query, startDate, endDate, ledgerID)
 	if err != nil {
 		return nil, fmt.Errorf("결제수단 통계 조회 오류: %v", err)
 	}
@@ -340,7 +340,7 @@ func (db *DB) GetPaymentMethodStatistics(startDate, endDate string) ([]models.Pa
 }
 
 // GetPaymentMethodCategoryStatistics 결제수단별 카테고리 통계 조회
-func (db *DB) GetPaymentMethodCategoryStatistics(paymentMethodID int, startDate, endDate string) ([]models.CategoryStatistics, error) {
+func (db *DB) GetPaymentMethodCategoryStatistics(ledgerID int, paymentMethodID int, startDate, endDate string) ([]models.CategoryStatistics, error) {
 	query := `
 	SELECT 
 		c.id as category_id,
@@ -348,15 +348,15 @@ func (db *DB) GetPaymentMethodCategoryStatistics(paymentMethodID int, startDate,
 		COALESCE(SUM(oa.money), 0) as total_amount,
 		COALESCE(COUNT(oa.uuid), 0) as count
 	FROM categories c
-	LEFT JOIN out_account_data oa ON c.id = oa.category_id 
+	LEFT JOIN out_account_net_line_items oa ON c.id = oa.category_id 
 		AND oa.payment_method_id = ?
 		AND date(oa.date) >= ? AND date(oa.date) <= ?
-	WHERE c.type = 'out'
+	WHERE c.ledger_id = ? AND c.type = 'out'
 	GROUP BY c.id, c.name
 	HAVING total_amount > 0
 	ORDER BY total_amount DESC`
 
-	rows, err := db.Conn.Query(query, paymentMethodID, startDate, endDate)
+	rows, err := db.Conn.Query(query, paymentMethodID, startDate, endDate, ledgerID)
 	if err != nil {
 		return nil, fmt.Errorf("결제수단별 카테고리 통계 조회 오류: %v", err)
 	}
@@ -376,19 +376,19 @@ func (db *DB) GetPaymentMethodCategoryStatistics(paymentMethodID int, startDate,
 }
 
 // GetUserStatistics 사용자별 통계 조회 (지출만)
-func (db *DB) GetUserStatistics(startDate, endDate string) ([]models.UserStatistics, error) {
+func (db *DB) GetUserStatistics(ledgerID int, startDate, endDate string) ([]models.UserStatistics, error) {
 	query := `
 	SELECT 
 		oa.user,
 		COALESCE(SUM(oa.money), 0) as total_amount,
 		COALESCE(COUNT(oa.uuid), 0) as count
-	FROM out_account_data oa
-	WHERE date(oa.date) >= ? AND date(oa.date) <= ?
+	FROM out_account_net_data oa
+	WHERE oa.ledger_id = ? AND date(oa.date) >= ? AND date(oa.date) <= ?
 	GROUP BY oa.user
 	HAVING total_amount > 0
 	ORDER BY total_amount DESC`
 
-	rows, err := db.Conn.Query(query, startDate, endDate)
+	rows, err := db.Conn.Query(query, ledgerID, startDate, endDate)
 	if err != nil {
 		return nil, fmt.Errorf("사용자별 통계 조회 오류: %v", err)
 	}
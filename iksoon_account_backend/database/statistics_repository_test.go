@@ -0,0 +1,131 @@
+package database
+
+import (
+	"testing"
+
+	"iksoon_account_backend/models"
+)
+
+// insertTestReimbursementIncome 기본 가계부에 환급 수입을 등록하고 지출들에 연결
+func insertTestReimbursementIncome(t *testing.T, db *DB, date string, money int, outUUIDs ...string) string {
+	t.Helper()
+
+	categoryID := createTestCategory(t, db, "테스트 환급 "+date, "in")
+	depositPathID, err := db.CreateDepositPath(DefaultLedgerID, "테스트 통장 "+date)
+	if err != nil {
+		t.Fatalf("입금경로 생성 실패: %v", err)
+	}
+	inUUID, err := db.InsertInAccount(DefaultLedgerID, date, "테스트", money, categoryID, nil, int(depositPathID), "회사 경비", "", 0)
+	if err != nil {
+		t.Fatalf("수입 등록 실패: %v", err)
+	}
+	if err := db.SettleReimbursements(DefaultLedgerID, outUUIDs, inUUID); err != nil {
+		t.Fatalf("SettleReimbursements() error = %v", err)
+	}
+	return inUUID
+}
+
+func TestStatisticsSubtractPartialReimbursement(t *testing.T) {
+	db := newTestDB(t)
+	food := createTestCategory(t, db, "테스트 식비", "out")
+	methodID := createTestPaymentMethod(t, db, "테스트 카드")
+
+	outUUID := insertTestOutAccount(t, db, "2024-03-05", 100000, food, methodID)
+	insertTestReimbursementIncome(t, db, "2024-03-20", 30000, outUUID)
+
+	// 지출 100,000 중 30,000 을 환급받았으면 지출은 70,000, 환급 수입은 모두 상쇄
+	outTotal, outCount, err := db.GetTotalAmount(DefaultLedgerID, "2024-03-01", "2024-03-31", "out")
+	if err != nil {
+		t.Fatalf("GetTotalAmount(out) error = %v", err)
+	}
+	if outTotal != 70000 || outCount != 1 {
+		t.Errorf("지출 합계 = %d (%d건), want 70000 (1건)", outTotal, outCount)
+	}
+	inTotal, inCount, err := db.GetTotalAmount(DefaultLedgerID, "2024-03-01", "2024-03-31", "in")
+	if err != nil {
+		t.Fatalf("GetTotalAmount(in) error = %v", err)
+	}
+	if inTotal != 0 || inCount != 0 {
+		t.Errorf("수입 합계 = %d (%d건), want 0 (0건)", inTotal, inCount)
+	}
+
+	categories, err := db.GetCategoryStatistics(DefaultLedgerID, "2024-03-01", "2024-03-31", "out")
+	if err != nil {
+		t.Fatalf("GetCategoryStatistics() error = %v", err)
+	}
+	if len(categories) != 1 || categories[0].CategoryID != food || categories[0].TotalAmount != 70000 {
+		t.Errorf("카테고리 통계 = %+v, want 테스트 식비 70000", categories)
+	}
+
+	methods, err := db.GetPaymentMethodStatistics(DefaultLedgerID, "2024-03-01", "2024-03-31")
+	if err != nil {
+		t.Fatalf("GetPaymentMethodStatistics() error = %v", err)
+	}
+	if len(methods) != 1 || methods[0].TotalAmount != 70000 {
+		t.Errorf("결제수단 통계 = %+v, want 70000", methods)
+	}
+
+	// 연결을 해제하면 지출과 수입이 모두 원래 금액으로 집계
+	if err := db.UnsettleReimbursement(DefaultLedgerID, outUUID); err != nil {
+		t.Fatalf("UnsettleReimbursement() error = %v", err)
+	}
+	if outTotal, _, _ = db.GetTotalAmount(DefaultLedgerID, "2024-03-01", "2024-03-31", "out"); outTotal != 100000 {
+		t.Errorf("연결 해제 후 지출 합계 = %d, want 100000", outTotal)
+	}
+	if inTotal, _, _ = db.GetTotalAmount(DefaultLedgerID, "2024-03-01", "2024-03-31", "in"); inTotal != 30000 {
+		t.Errorf("연결 해제 후 수입 합계 = %d, want 30000", inTotal)
+	}
+}
+
+func TestStatisticsSplitReimbursementAcrossExpenses(t *testing.T) {
+	db := newTestDB(t)
+	food := createTestCategory(t, db, "테스트 식비", "out")
+	living := createTestCategory(t, db, "테스트 생활", "out")
+	methodID := createTestPaymentMethod(t, db, "테스트 카드")
+
+	// 분할 지출 60,000(식비 40,000/생활 20,000)과 일반 지출 30,000 에 환급 수입 45,000 연결
+	splitUUID, err := db.InsertSplitOutAccount(DefaultLedgerID, "2024-04-02", "테스트", 60000, methodID, "출장", []models.OutAccountSplitRequest{
+		{CategoryID: food, Money: 40000}, {CategoryID: living, Money: 20000},
+	})
+	if err != nil {
+		t.Fatalf("InsertSplitOutAccount() error = %v", err)
+	}
+	plainUUID := insertTestOutAccount(t, db, "2024-04-03", 30000, food, methodID)
+	insertTestReimbursementIncome(t, db, "2024-04-25", 45000, splitUUID, plainUUID)
+
+	// 환급액은 지출 금액 비율(2:1)로 나뉘고 분할 지출 안에서는 항목 금액 비율로 다시 나뉨
+	outTotal, _, err := db.GetTotalAmount(DefaultLedgerID, "2024-04-01", "2024-04-30", "out")
+	if err != nil {
+		t.Fatalf("GetTotalAmount(out) error = %v", err)
+	}
+	if outTotal != 45000 {
+		t.Errorf("지출 합계 = %d, want 45000", outTotal)
+	}
+
+	categories, err := db.GetCategoryStatistics(DefaultLedgerID, "2024-04-01", "2024-04-30", "out")
+	if err != nil {
+		t.Fatalf("GetCategoryStatistics() error = %v", err)
+	}
+	byCategory := map[int]int{}
+	for _, stat := range categories {
+		byCategory[stat.CategoryID] = stat.TotalAmount
+	}
+	// 분할 지출 환급 30,000 → 식비 20,000/생활 10,000, 일반 지출 환급 15,000
+	if byCategory[food] != 35000 || byCategory[living] != 10000 {
+		t.Errorf("카테고리 통계 = %v, want 식비 35000, 생활 10000", byCategory)
+	}
+
+	// 지출 합계보다 큰 환급 수입은 남는 금액만 수입으로 집계
+	extraUUID := insertTestOutAccount(t, db, "2024-05-02", 10000, food, methodID)
+	insertTestReimbursementIncome(t, db, "2024-05-10", 12000, extraUUID)
+	inTotal, inCount, err := db.GetTotalAmount(DefaultLedgerID, "2024-05-01", "2024-05-31", "in")
+	if err != nil {
+		t.Fatalf("GetTotalAmount(in) error = %v", err)
+	}
+	if inTotal != 2000 || inCount != 1 {
+		t.Errorf("수입 합계 = %d (%d건), want 2000 (1건)", inTotal, inCount)
+	}
+	if outTotal, outCount, _ := db.GetTotalAmount(DefaultLedgerID, "2024-05-01", "2024-05-31", "out"); outTotal != 0 || outCount != 0 {
+		t.Errorf("전액 환급된 지출 합계 = %d (%d건), want 0 (0건)", outTotal, outCount)
+	}
+}
@@ -0,0 +1,517 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// maxTagNameLength 태그 이름 최대 길이 (문자 수)
+const maxTagNameLength = 50
+
+// transactionTables 태그/첨부파일을 붙일 수 있는 거래 유형별 테이블
+var transactionTables = map[string]string{
+	models.AccountTypeOut: "out_account_data",
+	models.AccountTypeIn:  "in_account_data",
+}
+
+// createTagTables 태그와 수입/지출-태그 연결 테이블 생성
+// 연결 테이블은 수입/지출 양쪽을 가리키므로 거래 쪽 외래키 없이 account_type 으로 구분하고 삭제 시 직접 정리
+func createTagTables(exec sqlExecutor) error {
+	createTagTable := `
+    CREATE TABLE IF NOT EXISTS tags (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        ledger_id INTEGER NOT NULL,
+        name VARCHAR(50) NOT NULL,
+        created_at TEXT DEFAULT CURRENT_TIMESTAMP,
+        updated_at TEXT DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (ledger_id) REFERENCES ledgers(id) ON DELETE CASCADE,
+        UNIQUE(ledger_id, name)
+    );`
+
+	if _, err := exec.Exec(createTagTable); err != nil {
+		return fmt.Errorf("태그 테이블 생성 오류: %v", err)
+	}
+
+	createTransactionTagTable := `
+    CREATE TABLE IF NOT EXISTS transaction_tags (
+        tag_id INTEGER NOT NULL,
+        account_type VARCHAR(3) NOT NULL CHECK (account_type IN ('out', 'in')),
+        account_uuid TEXT NOT NULL,
+        created_at TEXT DEFAULT CURRENT_TIMESTAMP,
+        PRIMARY KEY (tag_id, account_type, account_uuid),
+        FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+    );`
+
+	if _, err := exec.Exec(createTransactionTagTable); err != nil {
+		return fmt.Errorf("거래 태그 테이블 생성 오류: %v", err)
+	}
+
+	_, err := exec.Exec(`CREATE INDEX IF NOT EXISTS idx_transaction_tags_account ON transaction_tags(account_type, account_uuid)`)
+	if err != nil {
+		return fmt.Errorf("거래 태그 인덱스 생성 오류: %v", err)
+	}
+	return nil
+}
+
+// tagSelectQuery 태그 조회 공통 쿼리 (사용 건수 포함)
+const tagSelectQuery = `
+    SELECT t.id, t.ledger_id, t.name,
+        (SELECT COUNT(*) FROM transaction_tags tt WHERE tt.tag_id = t.id),
+        t.created_at, t.updated_at
+    FROM tags t`
+
+// scanTag 태그 행 스캔
+func scanTag(scanner interface{ Scan(...interface{}) error }) (*models.Tag, error) {
+	var tag models.Tag
+	var createdAt, updatedAt string
+
+	err := scanner.Scan(&tag.ID, &tag.LedgerID, &tag.Name, &tag.UsageCount, &createdAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	// 시간 파싱
+	if tag.CreatedAt, err = time.Parse("2006-01-02 15:04:05", createdAt); err != nil {
+		tag.CreatedAt = time.Now()
+	}
+	if tag.UpdatedAt, err = time.Parse("2006-01-02 15:04:05", updatedAt); err != nil {
+		tag.UpdatedAt = time.Now()
+	}
+
+	return &tag, nil
+}
+
+// GetTags 가계부의 태그 목록 조회 (이름순, 사용 건수 포함)
+func (db *DB) GetTags(ledgerID int) ([]models.Tag, error) {
+	rows, err := db.Conn.Query(tagSelectQuery+` WHERE t.ledger_id = ? ORDER BY t.name ASC`, ledgerID)
+	if err != nil {
+		return nil, fmt.Errorf("태그 목록 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	tags := []models.Tag{}
+	for rows.Next() {
+		tag, err := scanTag(rows)
+		if err != nil {
+			return nil, fmt.Errorf("태그 데이터 읽기 오류: %v", err)
+		}
+		tags = append(tags, *tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("태그 목록 조회 오류: %v", err)
+	}
+	return tags, nil
+}
+
+// GetTagByID ID로 태그 조회
+func (db *DB) GetTagByID(ledgerID int, id int) (*models.Tag, error) {
+	tag, err := scanTag(db.Conn.QueryRow(tagSelectQuery+` WHERE t.id = ? AND t.ledger_id = ?`, id, ledgerID))
+	if err == sql.ErrNoRows {
+		return nil, apiErrors.ErrTagNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("태그 조회 오류: %v", err)
+	}
+	return tag, nil
+}
+
+// CreateTag 태그 생성
+func (db *DB) CreateTag(ledgerID int, name string) (int64, error) {
+	name, err := normalizeTagName(name)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := db.Conn.Exec(`INSERT INTO tags (ledger_id, name, created_at, updated_at) VALUES (?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`, ledgerID, name)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return 0, apiErrors.ErrAlreadyExists.WithMessage("이미 존재하는 태그입니다")
+		}
+		return 0, fmt.Errorf("태그 생성 오류: %v", err)
+	}
+
+	return result.LastInsertId()
+}
+
+// UpdateTag 태그 이름 변경 (붙어 있는 수입/지출에는 새 이름으로 보임)
+func (db *DB) UpdateTag(ledgerID int, id int, name string) error {
+	name, err := normalizeTagName(name)
+	if err != nil {
+		return err
+	}
+
+	result, err := db.Conn.Exec(`UPDATE tags SET name = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND ledger_id = ?`, name, id, ledgerID)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return apiErrors.ErrAlreadyExists.WithMessage("이미 존재하는 태그입니다")
+		}
+		return fmt.Errorf("태그 수정 오류: %v", err)
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return apiErrors.ErrTagNotFound
+	}
+	return nil
+}
+
+// DeleteTag 태그 삭제 (수입/지출 데이터는 그대로 두고 태그 연결만 해제)
+func (db *DB) DeleteTag(ledgerID int, id int) error {
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM transaction_tags WHERE tag_id IN (SELECT id FROM tags WHERE id = ? AND ledger_id = ?)`, id, ledgerID); err != nil {
+		return fmt.Errorf("태그 연결 삭제 오류: %v", err)
+	}
+
+	result, err := tx.Exec(`DELETE FROM tags WHERE id = ? AND ledger_id = ?`, id, ledgerID)
+	if err != nil {
+		return fmt.Errorf("태그 삭제 오류: %v", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return apiErrors.ErrTagNotFound
+	}
+
+	return tx.Commit()
+}
+
+// SetTransactionTags 수입/지출의 태그 전체 교체 (없는 태그는 생성, 빈 목록이면 모두 해제), 저장된 태그 이름 반환
+func (db *DB) SetTransactionTags(ledgerID int, accountType, uuidStr string, names []string) ([]string, error) {
+	if _, ok := transactionTables[accountType]; !ok {
+		return nil, apiErrors.ErrInvalidTagData.WithMessage("type은 'out' 또는 'in'이어야 합니다")
+	}
+
+	normalized := make([]string, 0, len(names))
+	seen := make(map[string]bool)
+	for _, name := range names {
+		name, err := normalizeTagName(name)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[name] {
+			seen[name] = true
+			normalized = append(normalized, name)
+		}
+	}
+
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	if err := ensureTransactionExists(tx, ledgerID, accountType, uuidStr); err != nil {
+		return nil, err
+	}
+	if err := deleteTransactionTags(tx, accountType, uuidStr); err != nil {
+		return nil, err
+	}
+	for _, name := range normalized {
+		tagID, err := upsertTag(tx, ledgerID, name)
+		if err != nil {
+			return nil, err
+		}
+		_, err = tx.Exec(`INSERT OR IGNORE INTO transaction_tags (tag_id, account_type, account_uuid) VALUES (?, ?, ?)`, tagID, accountType, uuidStr)
+		if err != nil {
+			return nil, fmt.Errorf("거래 태그 연결 오류: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("거래 태그 저장 커밋 오류: %v", err)
+	}
+
+	tags, err := loadTransactionTags(db.Conn, accountType, []string{uuidStr})
+	if err != nil {
+		return nil, err
+	}
+	if saved, ok := tags[uuidStr]; ok {
+		return saved, nil
+	}
+	return []string{}, nil
+}
+
+// GetTagStatistics 태그별 수입/지출 합계 조회 (기간을 비우면 전체 기간)
+// 지출 카테고리별 합계는 분할 지출을 항목 단위로 집계
+func (db *DB) GetTagStatistics(ledgerID int, tagID int, startDate, endDate string) (*models.TagStatistics, error) {
+	tag, err := db.GetTagByID(ledgerID, tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &models.TagStatistics{
+		TagID:      tag.ID,
+		TagName:    tag.Name,
+		StartDate:  startDate,
+		EndDate:    endDate,
+		Categories: []models.CategoryStatistics{},
+		Users:      []models.UserStatistics{},
+	}
+	if startDate == "" {
+		startDate = "0000-01-01"
+	}
+	if endDate == "" {
+		endDate = "9999-12-31"
+	}
+
+	totalsQuery := `
+    SELECT COALESCE(SUM(money), 0), COUNT(*), COALESCE(MIN(date(date)), ''), COALESCE(MAX(date(date)), '')
+    FROM %s
+    WHERE ledger_id = ? AND date(date) >= ? AND date(date) <= ?
+    AND uuid IN (SELECT account_uuid FROM transaction_tags WHERE tag_id = ? AND account_type = ?)`
+
+	var expenseFirst, expenseLast, incomeFirst, incomeLast string
+	err = db.Conn.QueryRow(fmt.Sprintf(totalsQuery, "out_account_data"), ledgerID, startDate, endDate, tagID, models.AccountTypeOut).
+		Scan(&stats.TotalExpense, &stats.ExpenseCount, &expenseFirst, &expenseLast)
+	if err != nil {
+		return nil, fmt.Errorf("태그 지출 합계 조회 오류: %v", err)
+	}
+	err = db.Conn.QueryRow(fmt.Sprintf(totalsQuery, "in_account_data"), ledgerID, startDate, endDate, tagID, models.AccountTypeIn).
+		Scan(&stats.TotalIncome, &stats.IncomeCount, &incomeFirst, &incomeLast)
+	if err != nil {
+		return nil, fmt.Errorf("태그 수입 합계 조회 오류: %v", err)
+	}
+	stats.Net = stats.TotalIncome - stats.TotalExpense
+	stats.FirstDate = earlierDate(expenseFirst, incomeFirst)
+	stats.LastDate = laterDate(expenseLast, incomeLast)
+
+	categoryQuery := `
+    SELECT li.category_id, COALESCE(c.name, ''), SUM(li.money), COUNT(*)
+    FROM out_account_line_items li
+    LEFT JOIN categories c ON li.category_id = c.id
+    WHERE li.ledger_id = ? AND date(li.date) >= ? AND date(li.date) <= ?
+    AND li.uuid IN (SELECT account_uuid FROM transaction_tags WHERE tag_id = ? AND account_type = 'out')
+    GROUP BY li.category_id, c.name
+    ORDER BY SUM(li.money) DESC`
+
+	rows, err := db.Conn.Query(categoryQuery, ledgerID, startDate, endDate, tagID)
+	if err != nil {
+		return nil, fmt.Errorf("태그 카테고리 통계 조회 오류: %v", err)
+	}
+	for rows.Next() {
+		var stat models.CategoryStatistics
+		if err := rows.Scan(&stat.CategoryID, &stat.CategoryName, &stat.TotalAmount, &stat.Count); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("태그 카테고리 통계 읽기 오류: %v", err)
+		}
+		stats.Categories = append(stats.Categories, stat)
+	}
+	rows.Close()
+
+	userQuery := `
+    SELECT user, SUM(money), COUNT(*)
+    FROM out_account_data
+    WHERE ledger_id = ? AND date(date) >= ? AND date(date) <= ?
+    AND uuid IN (SELECT account_uuid FROM transaction_tags WHERE tag_id = ? AND account_type = 'out')
+    GROUP BY user
+    ORDER BY SUM(money) DESC`
+
+	rows, err = db.Conn.Query(userQuery, ledgerID, startDate, endDate, tagID)
+	if err != nil {
+		return nil, fmt.Errorf("태그 사용자 통계 조회 오류: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var stat models.UserStatistics
+		if err := rows.Scan(&stat.UserName, &stat.TotalAmount, &stat.Count); err != nil {
+			return nil, fmt.Errorf("태그 사용자 통계 읽기 오류: %v", err)
+		}
+		stats.Users = append(stats.Users, stat)
+	}
+
+	return stats, rows.Err()
+}
+
+// ensureTransactionExists 수입/지출이 같은 가계부에 있는지 확인 (accountType 은 검증된 값이어야 함)
+func ensureTransactionExists(exec sqlExecutor, ledgerID int, accountType, uuidStr string) error {
+	var exists int
+	err := exec.QueryRow(fmt.Sprintf(`SELECT 1 FROM %s WHERE uuid = ? AND ledger_id = ?`, transactionTables[accountType]), uuidStr, ledgerID).Scan(&exists)
+	if err == sql.ErrNoRows {
+		if accountType == models.AccountTypeIn {
+			return apiErrors.ErrAccountNotFound.WithMessage("수입 데이터를 찾을 수 없습니다")
+		}
+		return apiErrors.ErrAccountNotFound.WithMessage("지출 데이터를 찾을 수 없습니다")
+	}
+	if err != nil {
+		return fmt.Errorf("거래 데이터 조회 오류: %v", err)
+	}
+	return nil
+}
+
+// normalizeTagName 태그 이름 앞뒤 공백과 앞의 '#' 제거 후 검증
+func normalizeTagName(name string) (string, error) {
+	name = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(name), "#"))
+	if name == "" {
+		return "", apiErrors.ErrInvalidTagData.WithMessage("태그 이름은 필수입니다")
+	}
+	if len([]rune(name)) > maxTagNameLength {
+		return "", apiErrors.ErrInvalidTagData.WithMessage(fmt.Sprintf("태그 이름은 %d자 이하여야 합니다", maxTagNameLength))
+	}
+	return name, nil
+}
+
+// upsertTag 이름으로 태그 조회, 없으면 생성 후 ID 반환
+func upsertTag(exec sqlExecutor, ledgerID int, name string) (int64, error) {
+	var id int64
+	err := exec.QueryRow(`SELECT id FROM tags WHERE ledger_id = ? AND name = ?`, ledgerID, name).Scan(&id)
+	if err == nil {
+		return id, nil
+	}
+	if err != sql.ErrNoRows {
+		return 0, fmt.Errorf("태그 조회 오류: %v", err)
+	}
+
+	result, err := exec.Exec(`INSERT INTO tags (ledger_id, name, created_at, updated_at) VALUES (?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`, ledgerID, name)
+	if err != nil {
+		return 0, fmt.Errorf("태그 생성 오류: %v", err)
+	}
+	utils.Debug("태그 자동 생성: LedgerID=%d, Name=%s", ledgerID, name)
+	return result.LastInsertId()
+}
+
+// deleteTransactionTags 수입/지출의 태그 연결 삭제
+func deleteTransactionTags(exec sqlExecutor, accountType, uuidStr string) error {
+	if _, err := exec.Exec(`DELETE FROM transaction_tags WHERE account_type = ? AND account_uuid = ?`, accountType, uuidStr); err != nil {
+		return fmt.Errorf("거래 태그 삭제 오류: %v", err)
+	}
+	return nil
+}
+
+// tagFilterClause 태그 필터 조건 (tagID 가 0 이면 조건 없음)
+func tagFilterClause(column, accountType string, tagID int) (string, []interface{}) {
+	if tagID == 0 {
+		return "", nil
+	}
+	clause := fmt.Sprintf(` AND %s IN (SELECT account_uuid FROM transaction_tags WHERE tag_id = ? AND account_type = '%s')`, column, accountType)
+	return clause, []interface{}{tagID}
+}
+
+// attachOutAccountTags 지출 목록에 태그 이름 채우기
+func attachOutAccountTags(exec sqlExecutor, accounts []models.OutAccount) error {
+	uuids := make([]string, len(accounts))
+	for i := range accounts {
+		uuids[i] = accounts[i].UUID
+	}
+
+	tags, err := loadTransactionTags(exec, models.AccountTypeOut, uuids)
+	if err != nil {
+		return err
+	}
+	for i := range accounts {
+		accounts[i].Tags = tags[accounts[i].UUID]
+	}
+	return nil
+}
+
+// attachInAccountTags 수입 목록에 태그 이름 채우기
+func attachInAccountTags(exec sqlExecutor, accounts []models.InAccount) error {
+	uuids := make([]string, len(accounts))
+	for i := range accounts {
+		uuids[i] = accounts[i].UUID
+	}
+
+	tags, err := loadTransactionTags(exec, models.AccountTypeIn, uuids)
+	if err != nil {
+		return err
+	}
+	for i := range accounts {
+		accounts[i].Tags = tags[accounts[i].UUID]
+	}
+	return nil
+}
+
+// loadTransactionTags 여러 수입/지출의 태그 이름을 UUID별로 조회 (SQLite 변수 개수 제한을 피해 나누어 조회)
+func loadTransactionTags(exec sqlExecutor, accountType string, uuids []string) (map[string][]string, error) {
+	const chunkSize = 500
+	result := make(map[string][]string)
+
+	for start := 0; start < len(uuids); start += chunkSize {
+		end := start + chunkSize
+		if end > len(uuids) {
+			end = len(uuids)
+		}
+		chunk := uuids[start:end]
+
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(chunk)), ", ")
+		query := `
+        SELECT tt.account_uuid, t.name
+        FROM transaction_tags tt
+        JOIN tags t ON tt.tag_id = t.id
+        WHERE tt.account_type = ? AND tt.account_uuid IN (` + placeholders + `)
+        ORDER BY t.name`
+
+		args := []interface{}{accountType}
+		for _, uuidStr := range chunk {
+			args = append(args, uuidStr)
+		}
+
+		rows, err := exec.Query(query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("거래 태그 조회 오류: %v", err)
+		}
+		for rows.Next() {
+			var uuidStr, name string
+			if err := rows.Scan(&uuidStr, &name); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("거래 태그 읽기 오류: %v", err)
+			}
+			result[uuidStr] = append(result[uuidStr], name)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("거래 태그 조회 오류: %v", err)
+		}
+	}
+	return result, nil
+}
+
+// installmentTagIDs 할부 회차들에 붙어 있는 태그 ID 목록 (회차 재생성 시 유지용)
+func installmentTagIDs(exec sqlExecutor, ledgerID int, installmentID string) ([]int, error) {
+	ids, err := queryIDs(exec, `
+        SELECT DISTINCT tt.tag_id FROM transaction_tags tt
+        JOIN out_account_data oa ON tt.account_uuid = oa.uuid AND tt.account_type = 'out'
+        WHERE oa.installment_id = ? AND oa.ledger_id = ?
+        ORDER BY tt.tag_id`, installmentID, ledgerID)
+	if err != nil {
+		return nil, fmt.Errorf("할부 회차 태그 조회 오류: %v", err)
+	}
+	return ids, nil
+}
+
+// tagInstallmentPortions 할부의 모든 회차 지출에 태그 연결
+func tagInstallmentPortions(exec sqlExecutor, ledgerID int, installmentID string, tagIDs []int) error {
+	for _, tagID := range tagIDs {
+		_, err := exec.Exec(`
+            INSERT OR IGNORE INTO transaction_tags (tag_id, account_type, account_uuid)
+            SELECT ?, 'out', uuid FROM out_account_data WHERE installment_id = ? AND ledger_id = ?`, tagID, installmentID, ledgerID)
+		if err != nil {
+			return fmt.Errorf("할부 회차 태그 연결 오류: %v", err)
+		}
+	}
+	return nil
+}
+
+// earlierDate 비어 있지 않은 두 날짜 중 빠른 날짜
+func earlierDate(a, b string) string {
+	if a == "" || (b != "" && b < a) {
+		return b
+	}
+	return a
+}
+
+// laterDate 비어 있지 않은 두 날짜 중 늦은 날짜
+func laterDate(a, b string) string {
+	if b > a {
+		return b
+	}
+	return a
+}
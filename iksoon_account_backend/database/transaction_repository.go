@@ -0,0 +1,249 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// outTransactionColumns 통합 목록용 지출 컬럼 (수입과 UNION 할 수 있도록 같은 순서/이름 사용)
+const outTransactionColumns = `
+    SELECT 'out' AS type, oa.uuid AS uuid, oa.date AS date, oa.user AS user, oa.money AS money,
+           oa.category_id AS category_id, COALESCE(c.name, '') AS category_name,
+           oa.keyword_id AS keyword_id, COALESCE(k.name, '') AS keyword_name,
+           oa.payment_method_id AS payment_method_id, COALESCE(pm.name, '') AS payment_method_name,
+           NULL AS deposit_path_id, '' AS deposit_path_name, COALESCE(oa.memo, '') AS memo,
+           oa.installment_id AS installment_id, oa.installment_seq AS installment_seq,
+           oa.created_at AS created_at, oa.updated_at AS updated_at`
+
+// inTransactionColumns 통합 목록용 수입 컬럼 (지출과 UNION 할 수 있도록 같은 순서/이름 사용)
+const inTransactionColumns = `
+    SELECT 'in' AS type, ia.uuid AS uuid, ia.date AS date, ia.user AS user, ia.money AS money,
+           ia.category_id AS category_id, COALESCE(c.name, '') AS category_name,
+           ia.keyword_id AS keyword_id, COALESCE(k.name, '') AS keyword_name,
+           NULL AS payment_method_id, '' AS payment_method_name,
+           ia.deposit_path_id AS deposit_path_id, COALESCE(dp.name, '') AS deposit_path_name, COALESCE(ia.memo, '') AS memo,
+           NULL AS installment_id, NULL AS installment_seq,
+           ia.created_at AS created_at, ia.updated_at AS updated_at`
+
+// validateTransactionQuery 통합 조회 조건 검증 (거래 유형, 기간)
+func validateTransactionQuery(q *models.TransactionQuery) error {
+	q.Type = strings.ToLower(strings.TrimSpace(q.Type))
+	if q.Type != "" && q.Type != models.AccountTypeOut && q.Type != models.AccountTypeIn {
+		return apiErrors.ErrInvalidListOption.WithMessage("type은 out 또는 in이어야 합니다")
+	}
+
+	start, err := time.Parse("2006-01-02", q.StartDate)
+	if err != nil {
+		return apiErrors.ErrInvalidDateRange.WithMessage("start_date는 YYYY-MM-DD 형식이어야 합니다")
+	}
+	end, err := time.Parse("2006-01-02", q.EndDate)
+	if err != nil {
+		return apiErrors.ErrInvalidDateRange.WithMessage("end_date는 YYYY-MM-DD 형식이어야 합니다")
+	}
+	if end.Before(start) {
+		return apiErrors.ErrInvalidDateRange.WithMessage("end_date는 start_date 이후여야 합니다")
+	}
+	return nil
+}
+
+// transactionUnion 조건에 맞는 지출과 수입을 합친 서브쿼리
+// 결제수단 필터가 있으면 수입을, 입금경로 필터가 있으면 지출을 제외하며, 남는 거래 유형이 없으면 빈 문자열 반환
+func transactionUnion(ledgerID int, q models.TransactionQuery) (string, []interface{}) {
+	var branches []string
+	var args []interface{}
+	keywordPattern := "%" + q.Keyword + "%"
+
+	if q.Type != models.AccountTypeIn && q.DepositPathID == nil {
+		where := `
+    WHERE oa.ledger_id = ? AND DATE(oa.date) >= ? AND DATE(oa.date) <= ?`
+		args = append(args, ledgerID, q.StartDate, q.EndDate)
+		if q.Keyword != "" {
+			where += ` AND (k.name LIKE ? OR oa.memo LIKE ?)`
+			args = append(args, keywordPattern, keywordPattern)
+		}
+		filter, filterArgs := listFilterClause("oa", models.AccountTypeOut, q.ListOptions)
+		branches = append(branches, outTransactionColumns+outAccountListFrom+where+filter)
+		args = append(args, filterArgs...)
+	}
+
+	if q.Type != models.AccountTypeOut && q.PaymentMethodID == nil {
+		where := `
+    WHERE ia.ledger_id = ? AND DATE(ia.date) >= ? AND DATE(ia.date) <= ?`
+		args = append(args, ledgerID, q.StartDate, q.EndDate)
+		if q.Keyword != "" {
+			where += ` AND (k.name LIKE ? OR ia.memo LIKE ?)`
+			args = append(args, keywordPattern, keywordPattern)
+		}
+		filter, filterArgs := listFilterClause("ia", models.AccountTypeIn, q.ListOptions)
+		branches = append(branches, inTransactionColumns+inAccountListFrom+where+filter)
+		args = append(args, filterArgs...)
+	}
+
+	if len(branches) == 0 {
+		return "", nil
+	}
+	return strings.Join(branches, `
+    UNION ALL`), args
+}
+
+// GetTransactions 기간의 수입/지출을 하나의 목록으로 조회 (정렬/필터/커서 페이지네이션은 개별 목록과 동일)
+func (db *DB) GetTransactions(ledgerID int, q models.TransactionQuery) ([]models.Transaction, models.PageInfo, error) {
+	if err := validateTransactionQuery(&q); err != nil {
+		return nil, models.PageInfo{}, err
+	}
+	if err := normalizeListOptions(&q.ListOptions); err != nil {
+		return nil, models.PageInfo{}, err
+	}
+
+	transactions := []models.Transaction{}
+	union, args := transactionUnion(ledgerID, q)
+	if union == "" {
+		return transactions, models.PageInfo{Limit: q.Limit}, nil
+	}
+
+	from := `
+    FROM (` + union + `
+    ) t`
+	where := `
+    WHERE 1 = 1`
+	page, pageArgs, err := listPageClause("t", q.ListOptions)
+	if err != nil {
+		return nil, models.PageInfo{}, err
+	}
+
+	rows, err := db.Conn.Query(`SELECT t.*`+from+where+page, append(append([]interface{}{}, args...), pageArgs...)...)
+	if err != nil {
+		utils.LogError("통합 거래 목록 조회", err)
+		return nil, models.PageInfo{}, fmt.Errorf("통합 거래 목록 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var transaction models.Transaction
+		if err := rows.Scan(
+			&transaction.Type, &transaction.UUID, &transaction.Date, &transaction.User, &transaction.Money,
+			&transaction.CategoryID, &transaction.CategoryName, &transaction.KeywordID, &transaction.KeywordName,
+			&transaction.PaymentMethodID, &transaction.PaymentMethodName, &transaction.DepositPathID, &transaction.DepositPathName,
+			&transaction.Memo, &transaction.InstallmentID, &transaction.InstallmentSeq, &transaction.CreatedAt, &transaction.UpdatedAt,
+		); err != nil {
+			return nil, models.PageInfo{}, fmt.Errorf("통합 거래 데이터 읽기 오류: %v", err)
+		}
+		transactions = append(transactions, transaction)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, models.PageInfo{}, fmt.Errorf("통합 거래 목록 조회 오류: %v", err)
+	}
+	rows.Close()
+
+	size, info := buildListPage(q.ListOptions, len(transactions), func(i int) listRowKey {
+		return listRowKey{uuid: transactions[i].UUID, date: transactions[i].Date, money: transactions[i].Money, createdAt: transactions[i].CreatedAt}
+	})
+	transactions = transactions[:size]
+	if q.Paginated() {
+		if info.TotalCount, err = countListRows(db.Conn, from, where, args); err != nil {
+			return nil, models.PageInfo{}, err
+		}
+	}
+
+	if err := attachTransactionDetails(db.Conn, ledgerID, transactions); err != nil {
+		return nil, models.PageInfo{}, err
+	}
+	return transactions, info, nil
+}
+
+// attachTransactionDetails 통합 목록에 분할 항목/태그/외화/환급 상태 채우기 (지출·수입 목록용 함수를 그대로 사용)
+func attachTransactionDetails(exec sqlExecutor, ledgerID int, transactions []models.Transaction) error {
+	var outIndexes, inIndexes []int
+	var outAccounts []models.OutAccount
+	var inAccounts []models.InAccount
+	for i, transaction := range transactions {
+		if transaction.Type == models.AccountTypeOut {
+			outIndexes = append(outIndexes, i)
+			outAccounts = append(outAccounts, models.OutAccount{UUID: transaction.UUID})
+		} else {
+			inIndexes = append(inIndexes, i)
+			inAccounts = append(inAccounts, models.InAccount{UUID: transaction.UUID})
+		}
+	}
+
+	if err := attachOutAccountSplits(exec, ledgerID, outAccounts); err != nil {
+		return err
+	}
+	if err := attachOutAccountTags(exec, outAccounts); err != nil {
+		return err
+	}
+	if err := attachOutAccountCurrencies(exec, outAccounts); err != nil {
+		return err
+	}
+	if err := attachReimbursementStatus(exec, outAccounts); err != nil {
+		return err
+	}
+	if err := attachInAccountTags(exec, inAccounts); err != nil {
+		return err
+	}
+	if err := attachInAccountCurrencies(exec, inAccounts); err != nil {
+		return err
+	}
+
+	for n, i := range outIndexes {
+		account := outAccounts[n]
+		transactions[i].Splits, transactions[i].Tags, transactions[i].Reimbursement = account.Splits, account.Tags, account.Reimbursement
+		transactions[i].Currency, transactions[i].OriginalMoney, transactions[i].ExchangeRate = account.Currency, account.OriginalMoney, account.ExchangeRate
+	}
+	for n, i := range inIndexes {
+		account := inAccounts[n]
+		transactions[i].Tags = account.Tags
+		transactions[i].Currency, transactions[i].OriginalMoney, transactions[i].ExchangeRate = account.Currency, account.OriginalMoney, account.ExchangeRate
+	}
+	return nil
+}
+
+// GetDailyNet 기간의 날짜별 수입/지출 합계와 순액 조회 (통합 목록과 같은 필터 적용, 정렬/페이지 옵션은 무시)
+func (db *DB) GetDailyNet(ledgerID int, q models.TransactionQuery) (*models.DailyNetSummary, error) {
+	if err := validateTransactionQuery(&q); err != nil {
+		return nil, err
+	}
+	if err := normalizeListOptions(&q.ListOptions); err != nil {
+		return nil, err
+	}
+
+	summary := &models.DailyNetSummary{StartDate: q.StartDate, EndDate: q.EndDate, Days: []models.DailyNet{}}
+	union, args := transactionUnion(ledgerID, q)
+	if union == "" {
+		return summary, nil
+	}
+
+	rows, err := db.Conn.Query(`
+    SELECT DATE(t.date),
+           COALESCE(SUM(CASE WHEN t.type = 'in' THEN t.money END), 0),
+           COALESCE(SUM(CASE WHEN t.type = 'out' THEN t.money END), 0),
+           SUM(CASE WHEN t.type = 'in' THEN 1 ELSE 0 END),
+           SUM(CASE WHEN t.type = 'out' THEN 1 ELSE 0 END)
+    FROM (`+union+`
+    ) t
+    GROUP BY DATE(t.date)
+    ORDER BY DATE(t.date) ASC`, args...)
+	if err != nil {
+		utils.LogError("날짜별 순액 조회", err)
+		return nil, fmt.Errorf("날짜별 순액 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var day models.DailyNet
+		if err := rows.Scan(&day.Date, &day.Income, &day.Expense, &day.IncomeCount, &day.ExpenseCount); err != nil {
+			return nil, fmt.Errorf("날짜별 순액 읽기 오류: %v", err)
+		}
+		day.Net = day.Income - day.Expense
+		summary.Days = append(summary.Days, day)
+		summary.TotalIncome += day.Income
+		summary.TotalExpense += day.Expense
+	}
+	summary.TotalNet = summary.TotalIncome - summary.TotalExpense
+	return summary, rows.Err()
+}
@@ -0,0 +1,206 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+
+	"github.com/google/uuid"
+)
+
+// createTransferTable 계좌 간 이체 테이블 생성
+// 수입/지출 테이블과 분리되어 있어 통계 쿼리에는 포함되지 않음
+func createTransferTable(exec sqlExecutor) error {
+	createTransferTable := `
+    CREATE TABLE IF NOT EXISTS transfers (
+        uuid TEXT PRIMARY KEY,
+        ledger_id INTEGER NOT NULL,
+        date TEXT NOT NULL,
+        money INT NOT NULL CHECK (money > 0),
+        from_account_id INTEGER NOT NULL,
+        to_account_id INTEGER NOT NULL,
+        user VARCHAR(255) NOT NULL,
+        memo TEXT DEFAULT '',
+        created_at TEXT DEFAULT CURRENT_TIMESTAMP,
+        updated_at TEXT DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (ledger_id) REFERENCES ledgers(id) ON DELETE CASCADE,
+        FOREIGN KEY (from_account_id) REFERENCES accounts(id),
+        FOREIGN KEY (to_account_id) REFERENCES accounts(id),
+        CHECK (from_account_id != to_account_id)
+    );`
+
+	if _, err := exec.Exec(createTransferTable); err != nil {
+		return fmt.Errorf("이체 테이블 생성 오류: %v", err)
+	}
+
+	indexes := []string{
+		`CREATE INDEX IF NOT EXISTS idx_transfers_ledger_date ON transfers(ledger_id, date)`,
+		`CREATE INDEX IF NOT EXISTS idx_transfers_from_account ON transfers(from_account_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_transfers_to_account ON transfers(to_account_id)`,
+	}
+	for _, index := range indexes {
+		if _, err := exec.Exec(index); err != nil {
+			return fmt.Errorf("이체 인덱스 생성 오류: %v", err)
+		}
+	}
+	return nil
+}
+
+// transferSelectQuery 이체 조회 공통 쿼리 (계좌 이름 포함)
+const transferSelectQuery = `
+    SELECT t.uuid, t.date, t.money, t.from_account_id, COALESCE(fa.name, ''), t.to_account_id, COALESCE(ta.name, ''),
+           t.user, COALESCE(t.memo, ''), t.created_at, t.updated_at
+    FROM transfers t
+    LEFT JOIN accounts fa ON t.from_account_id = fa.id
+    LEFT JOIN accounts ta ON t.to_account_id = ta.id`
+
+// scanTransfer 이체 행 스캔
+func scanTransfer(scanner interface{ Scan(...interface{}) error }) (*models.Transfer, error) {
+	var transfer models.Transfer
+	err := scanner.Scan(&transfer.UUID, &transfer.Date, &transfer.Money, &transfer.FromAccountID, &transfer.FromAccountName,
+		&transfer.ToAccountID, &transfer.ToAccountName, &transfer.User, &transfer.Memo, &transfer.CreatedAt, &transfer.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+// GetTransfers 기간별 이체 조회 (accountID 가 0 이 아니면 해당 계좌의 입출금 이체만)
+func (db *DB) GetTransfers(ledgerID int, startDate, endDate string, accountID int) ([]models.Transfer, error) {
+	query := transferSelectQuery + ` WHERE t.ledger_id = ? AND DATE(t.date) >= ? AND DATE(t.date) <= ?`
+	args := []interface{}{ledgerID, startDate, endDate}
+	if accountID != 0 {
+		query += ` AND (t.from_account_id = ? OR t.to_account_id = ?)`
+		args = append(args, accountID, accountID)
+	}
+	query += ` ORDER BY t.date DESC, t.created_at DESC`
+
+	rows, err := db.Conn.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("이체 조회 오류: %v", err)
+	}
+	defer rows.Close()
+
+	transfers := []models.Transfer{}
+	for rows.Next() {
+		transfer, err := scanTransfer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("이체 데이터 읽기 오류: %v", err)
+		}
+		transfers = append(transfers, *transfer)
+	}
+
+	return transfers, rows.Err()
+}
+
+// GetTransferByUUID UUID로 이체 조회
+func (db *DB) GetTransferByUUID(ledgerID int, uuidStr string) (*models.Transfer, error) {
+	transfer, err := scanTransfer(db.Conn.QueryRow(transferSelectQuery+` WHERE t.ledger_id = ? AND t.uuid = ?`, ledgerID, uuidStr))
+	if err == sql.ErrNoRows {
+		return nil, apiErrors.ErrTransferNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("이체 조회 오류: %v", err)
+	}
+	return transfer, nil
+}
+
+// InsertTransfer 이체 등록 (생성된 UUID 반환)
+func (db *DB) InsertTransfer(ledgerID int, req models.TransferRequest) (string, error) {
+	formattedDate, err := normalizeTransferRequest(db.Conn, ledgerID, &req)
+	if err != nil {
+		return "", err
+	}
+
+	uuidStr := uuid.New().String()
+	query := `
+    INSERT INTO transfers (uuid, ledger_id, date, money, from_account_id, to_account_id, user, memo, created_at, updated_at)
+    VALUES (?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
+
+	_, err = db.Conn.Exec(query, uuidStr, ledgerID, formattedDate, req.Money, req.FromAccountID, req.ToAccountID, req.User, req.Memo)
+	if err != nil {
+		return "", fmt.Errorf("이체 등록 오류: %v", err)
+	}
+	return uuidStr, nil
+}
+
+// UpdateTransfer 이체 수정
+func (db *DB) UpdateTransfer(ledgerID int, uuidStr string, req models.TransferRequest) error {
+	formattedDate, err := normalizeTransferRequest(db.Conn, ledgerID, &req)
+	if err != nil {
+		return err
+	}
+
+	query := `
+    UPDATE transfers
+    SET date = ?, money = ?, from_account_id = ?, to_account_id = ?, user = ?, memo = ?, updated_at = CURRENT_TIMESTAMP
+    WHERE uuid = ? AND ledger_id = ?`
+
+	result, err := db.Conn.Exec(query, formattedDate, req.Money, req.FromAccountID, req.ToAccountID, req.User, req.Memo, uuidStr, ledgerID)
+	if err != nil {
+		return fmt.Errorf("이체 수정 오류: %v", err)
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return apiErrors.ErrTransferNotFound
+	}
+	return nil
+}
+
+// DeleteTransfer 이체 삭제
+func (db *DB) DeleteTransfer(ledgerID int, uuidStr string) error {
+	result, err := db.Conn.Exec(`DELETE FROM transfers WHERE uuid = ? AND ledger_id = ?`, uuidStr, ledgerID)
+	if err != nil {
+		return fmt.Errorf("이체 삭제 오류: %v", err)
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return apiErrors.ErrTransferNotFound
+	}
+	return nil
+}
+
+// countAccountTransfers 계좌가 출금/입금 계좌로 사용된 이체 건수
+func countAccountTransfers(exec sqlExecutor, accountID int) (int, error) {
+	var count int
+	err := exec.QueryRow(`SELECT COUNT(*) FROM transfers WHERE from_account_id = ? OR to_account_id = ?`, accountID, accountID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("계좌 이체 내역 확인 오류: %v", err)
+	}
+	return count, nil
+}
+
+// normalizeTransferRequest 이체 요청 유효성 검사 (저장할 KST 날짜 문자열 반환)
+func normalizeTransferRequest(exec sqlExecutor, ledgerID int, req *models.TransferRequest) (string, error) {
+	req.User = strings.TrimSpace(req.User)
+	req.Memo = strings.TrimSpace(req.Memo)
+
+	if req.Money <= 0 {
+		return "", apiErrors.ErrInvalidTransfer.WithMessage("이체 금액은 0보다 커야 합니다")
+	}
+	if req.User == "" {
+		return "", apiErrors.ErrMissingRequired.WithMessage("사용자는 필수입니다")
+	}
+	if req.FromAccountID <= 0 || req.ToAccountID <= 0 {
+		return "", apiErrors.ErrMissingRequired.WithMessage("출금 계좌와 입금 계좌는 필수입니다")
+	}
+	if req.FromAccountID == req.ToAccountID {
+		return "", apiErrors.ErrInvalidTransfer.WithMessage("출금 계좌와 입금 계좌가 같을 수 없습니다")
+	}
+
+	parsedDate, err := utils.ParseDateTimeKST(req.Date)
+	if err != nil {
+		return "", apiErrors.ErrInvalidTransfer.WithMessage("날짜 형식이 올바르지 않습니다")
+	}
+
+	for _, accountID := range []int{req.FromAccountID, req.ToAccountID} {
+		if err := ensureLedgerRow(exec, "accounts", accountID, ledgerID); err != nil {
+			return "", err
+		}
+	}
+	return utils.FormatDateTimeKST(parsedDate), nil
+}
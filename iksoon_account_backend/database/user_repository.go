@@ -8,15 +8,16 @@ import (
 	"iksoon_account_backend/models"
 )
 
-// GetUsers 사용자 목록 조회
-func (db *DB) GetUsers() ([]models.User, error) {
+// GetUsers 가계부 멤버인 사용자 목록 조회
+func (db *DB) GetUsers(ledgerID int) ([]models.User, error) {
 	query := `
-		SELECT id, name, COALESCE(email, ''), is_active, created_at, updated_at 
+		SELECT id, name, COALESCE(email, ''), is_active, is_admin, password_hash IS NOT NULL, created_at, updated_at 
 		FROM users 
 		WHERE is_active = 1 
+		AND id IN (SELECT user_id FROM ledger_members WHERE ledger_id = ?)
 		ORDER BY name ASC`
 
-	rows, err := db.Conn.Query(query)
+	rows, err := db.Conn.Query(query, ledgerID)
 	if err != nil {
 		return nil, fmt.Errorf("사용자 조회 오류: %v", err)
 	}
@@ -32,6 +33,8 @@ func (db *DB) GetUsers() ([]models.User, error) {
 			&user.Name,
 			&user.Email,
 			&user.IsActive,
+			&user.IsAdmin,
+			&user.HasPassword,
 			&createdAt,
 			&updatedAt,
 		)
@@ -56,7 +59,7 @@ func (db *DB) GetUsers() ([]models.User, error) {
 // GetUserByID ID로 사용자 조회
 func (db *DB) GetUserByID(id int) (*models.User, error) {
 	query := `
-		SELECT id, name, COALESCE(email, ''), is_active, created_at, updated_at 
+		SELECT id, name, COALESCE(email, ''), is_active, is_admin, password_hash IS NOT NULL, created_at, updated_at 
 		FROM users 
 		WHERE id = ?`
 
@@ -68,6 +71,8 @@ func (db *DB) GetUserByID(id int) (*models.User, error) {
 		&user.Name,
 		&user.Email,
 		&user.IsActive,
+		&user.IsAdmin,
+		&user.HasPassword,
 		&createdAt,
 		&updatedAt,
 	)
@@ -89,8 +94,8 @@ func (db *DB) GetUserByID(id int) (*models.User, error) {
 	return &user, nil
 }
 
-// CreateUser 사용자 생성
-func (db *DB) CreateUser(name, email string) (int64, error) {
+// CreateUser 사용자 생성 (생성된 사용자는 가계부 멤버로 등록)
+func (db *DB) CreateUser(ledgerID int, name, email string) (int64, error) {
 	// 중복 이름 확인
 	var count int
 	err := db.Conn.QueryRow("SELECT COUNT(*) FROM users WHERE name = ? AND is_active = 1", name).Scan(&count)
@@ -105,12 +110,30 @@ func (db *DB) CreateUser(name, email string) (int64, error) {
 		INSERT INTO users (name, email, updated_at) 
 		VALUES (?, ?, CURRENT_TIMESTAMP)`
 
-	result, err := db.Conn.Exec(query, name, email)
+	tx, err := db.Conn.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("트랜잭션 시작 오류: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(query, name, email)
 	if err != nil {
 		return 0, fmt.Errorf("사용자 생성 오류: %v", err)
 	}
 
-	return result.LastInsertId()
+	userID, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("사용자 ID 조회 오류: %v", err)
+	}
+
+	if err := addLedgerMember(tx, ledgerID, int(userID), models.LedgerRoleMember); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("트랜잭션 커밋 오류: %v", err)
+	}
+	return userID, nil
 }
 
 // UpdateUser 사용자 수정
@@ -172,6 +195,34 @@ func (db *DB) UpdateUser(id int, name, email string) error {
 		if err != nil {
 			return fmt.Errorf("수입 데이터 사용자명 업데이트 오류: %v", err)
 		}
+
+		// 정산 송금 기록 업데이트
+		_, err = tx.Exec("UPDATE settlement_transfers SET from_user = ? WHERE from_user = ?", name, oldName)
+		if err != nil {
+			return fmt.Errorf("정산 송금 사용자명 업데이트 오류: %v", err)
+		}
+		_, err = tx.Exec("UPDATE settlement_transfers SET to_user = ? WHERE to_user = ?", name, oldName)
+		if err != nil {
+			return fmt.Errorf("정산 송금 사용자명 업데이트 오류: %v", err)
+		}
+
+		// 이체, 할부, 정기 거래 규칙, 사용자별 기준치, 자동 분류 규칙 업데이트
+		// (이후 생성되는 할부 회차/정기 거래와 규칙 일치가 바뀐 이름으로 이어지도록)
+		renames := []struct {
+			query string
+			label string
+		}{
+			{"UPDATE transfers SET user = ?, updated_at = CURRENT_TIMESTAMP WHERE user = ?", "이체"},
+			{"UPDATE installments SET user = ?, updated_at = CURRENT_TIMESTAMP WHERE user = ?", "할부"},
+			{"UPDATE recurring_rules SET user = ?, updated_at = CURRENT_TIMESTAMP WHERE user = ?", "정기 거래 규칙"},
+			{"UPDATE category_budgets SET user_name = ?, updated_at = CURRENT_TIMESTAMP WHERE user_name = ?", "카테고리 기준치"},
+			{"UPDATE categorization_rules SET user = ?, updated_at = CURRENT_TIMESTAMP WHERE user = ?", "자동 분류 규칙"},
+		}
+		for _, rename := range renames {
+			if _, err := tx.Exec(rename.query, name, oldName); err != nil {
+				return fmt.Errorf("%s 사용자명 업데이트 오류: %v", rename.label, err)
+			}
+		}
 	}
 
 	// 트랜잭션 커밋
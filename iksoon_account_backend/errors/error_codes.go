@@ -147,6 +147,308 @@ var (
 		Message: "잘못된 날짜 범위입니다",
 		Status:  http.StatusBadRequest,
 	}
+
+	// 사용자 관련 에러
+	ErrUserNotFound = ErrorCode{
+		Code:    "USER_NOT_FOUND",
+		Message: "사용자를 찾을 수 없습니다",
+		Status:  http.StatusNotFound,
+	}
+
+	// 인증 관련 에러
+	ErrUnauthorized = ErrorCode{
+		Code:    "UNAUTHORIZED",
+		Message: "로그인이 필요합니다",
+		Status:  http.StatusUnauthorized,
+	}
+
+	ErrForbidden = ErrorCode{
+		Code:    "FORBIDDEN",
+		Message: "권한이 없습니다",
+		Status:  http.StatusForbidden,
+	}
+
+	ErrInvalidCredentials = ErrorCode{
+		Code:    "INVALID_CREDENTIALS",
+		Message: "사용자 이름 또는 비밀번호가 올바르지 않습니다",
+		Status:  http.StatusUnauthorized,
+	}
+
+	ErrTooManyLoginAttempts = ErrorCode{
+		Code:    "TOO_MANY_LOGIN_ATTEMPTS",
+		Message: "로그인 실패 횟수가 너무 많습니다. 잠시 후 다시 시도해주세요",
+		Status:  http.StatusTooManyRequests,
+	}
+
+	// 가계부 관련 에러
+	ErrLedgerNotFound = ErrorCode{
+		Code:    "LEDGER_NOT_FOUND",
+		Message: "가계부를 찾을 수 없습니다",
+		Status:  http.StatusNotFound,
+	}
+
+	ErrInvalidLedgerData = ErrorCode{
+		Code:    "INVALID_LEDGER_DATA",
+		Message: "가계부 정보가 올바르지 않습니다",
+		Status:  http.StatusBadRequest,
+	}
+
+	// 가져오기 관련 에러
+	ErrImportProfileNotFound = ErrorCode{
+		Code:    "IMPORT_PROFILE_NOT_FOUND",
+		Message: "가져오기 프로필을 찾을 수 없습니다",
+		Status:  http.StatusNotFound,
+	}
+
+	ErrInvalidImportData = ErrorCode{
+		Code:    "INVALID_IMPORT_DATA",
+		Message: "가져오기 데이터가 올바르지 않습니다",
+		Status:  http.StatusBadRequest,
+	}
+
+	// 백업 관련 에러
+	ErrBackupNotFound = ErrorCode{
+		Code:    "BACKUP_NOT_FOUND",
+		Message: "백업 파일을 찾을 수 없습니다",
+		Status:  http.StatusNotFound,
+	}
+
+	ErrInvalidBackup = ErrorCode{
+		Code:    "INVALID_BACKUP",
+		Message: "백업 파일이 손상되었거나 복원할 수 없습니다",
+		Status:  http.StatusUnprocessableEntity,
+	}
+
+	// 이체 관련 에러
+	ErrTransferNotFound = ErrorCode{
+		Code:    "TRANSFER_NOT_FOUND",
+		Message: "이체 내역을 찾을 수 없습니다",
+		Status:  http.StatusNotFound,
+	}
+
+	ErrInvalidTransfer = ErrorCode{
+		Code:    "INVALID_TRANSFER",
+		Message: "이체 정보가 올바르지 않습니다",
+		Status:  http.StatusBadRequest,
+	}
+
+	// 할부 관련 에러
+	ErrInstallmentNotFound = ErrorCode{
+		Code:    "INSTALLMENT_NOT_FOUND",
+		Message: "할부 내역을 찾을 수 없습니다",
+		Status:  http.StatusNotFound,
+	}
+
+	ErrInvalidInstallment = ErrorCode{
+		Code:    "INVALID_INSTALLMENT",
+		Message: "할부 정보가 올바르지 않습니다",
+		Status:  http.StatusBadRequest,
+	}
+
+	ErrInstallmentPortionLocked = ErrorCode{
+		Code:    "INSTALLMENT_PORTION_LOCKED",
+		Message: "할부 회차는 개별로 수정/삭제할 수 없습니다. 할부 전체를 수정하거나 취소해주세요",
+		Status:  http.StatusConflict,
+	}
+
+	// 분할 지출 관련 에러
+	ErrInvalidSplit = ErrorCode{
+		Code:    "INVALID_SPLIT",
+		Message: "분할 지출 정보가 올바르지 않습니다",
+		Status:  http.StatusBadRequest,
+	}
+
+	// 태그 관련 에러
+	ErrTagNotFound = ErrorCode{
+		Code:    "TAG_NOT_FOUND",
+		Message: "태그를 찾을 수 없습니다",
+		Status:  http.StatusNotFound,
+	}
+
+	ErrInvalidTagData = ErrorCode{
+		Code:    "INVALID_TAG_DATA",
+		Message: "태그 정보가 올바르지 않습니다",
+		Status:  http.StatusBadRequest,
+	}
+
+	// 첨부파일 관련 에러
+	ErrAttachmentNotFound = ErrorCode{
+		Code:    "ATTACHMENT_NOT_FOUND",
+		Message: "첨부파일을 찾을 수 없습니다",
+		Status:  http.StatusNotFound,
+	}
+
+	ErrInvalidAttachment = ErrorCode{
+		Code:    "INVALID_ATTACHMENT",
+		Message: "첨부파일 정보가 올바르지 않습니다",
+		Status:  http.StatusBadRequest,
+	}
+
+	ErrAttachmentTooLarge = ErrorCode{
+		Code:    "ATTACHMENT_TOO_LARGE",
+		Message: "첨부파일 크기가 허용 범위를 초과했습니다",
+		Status:  http.StatusRequestEntityTooLarge,
+	}
+
+	ErrUnsupportedAttachmentType = ErrorCode{
+		Code:    "UNSUPPORTED_ATTACHMENT_TYPE",
+		Message: "지원하지 않는 첨부파일 형식입니다 (JPEG, PNG, GIF, WEBP, HEIC, PDF만 가능)",
+		Status:  http.StatusUnsupportedMediaType,
+	}
+
+	// 환급 관련 에러
+	ErrReimbursementNotFound = ErrorCode{
+		Code:    "REIMBURSEMENT_NOT_FOUND",
+		Message: "환급 대상 지출을 찾을 수 없습니다",
+		Status:  http.StatusNotFound,
+	}
+
+	ErrInvalidReimbursement = ErrorCode{
+		Code:    "INVALID_REIMBURSEMENT",
+		Message: "환급 정보가 올바르지 않습니다",
+		Status:  http.StatusBadRequest,
+	}
+
+	// 공동 지출 정산 관련 에러
+	ErrSharingRuleNotFound = ErrorCode{
+		Code:    "SHARING_RULE_NOT_FOUND",
+		Message: "분담 규칙을 찾을 수 없습니다",
+		Status:  http.StatusNotFound,
+	}
+
+	ErrInvalidSharingRule = ErrorCode{
+		Code:    "INVALID_SHARING_RULE",
+		Message: "분담 규칙 정보가 올바르지 않습니다",
+		Status:  http.StatusBadRequest,
+	}
+
+	ErrSettlementNotFound = ErrorCode{
+		Code:    "SETTLEMENT_NOT_FOUND",
+		Message: "정산 기록을 찾을 수 없습니다",
+		Status:  http.StatusNotFound,
+	}
+
+	ErrInvalidSettlement = ErrorCode{
+		Code:    "INVALID_SETTLEMENT",
+		Message: "정산 정보가 올바르지 않습니다",
+		Status:  http.StatusBadRequest,
+	}
+
+	// 외화/환율 관련 에러
+	ErrExchangeRateNotFound = ErrorCode{
+		Code:    "EXCHANGE_RATE_NOT_FOUND",
+		Message: "환율 정보를 찾을 수 없습니다",
+		Status:  http.StatusNotFound,
+	}
+
+	ErrInvalidExchangeRate = ErrorCode{
+		Code:    "INVALID_EXCHANGE_RATE",
+		Message: "환율 정보가 올바르지 않습니다",
+		Status:  http.StatusBadRequest,
+	}
+
+	ErrInvalidCurrency = ErrorCode{
+		Code:    "INVALID_CURRENCY",
+		Message: "통화 정보가 올바르지 않습니다",
+		Status:  http.StatusBadRequest,
+	}
+
+	// 기준치 적용 기간 관련 에러
+	ErrBudgetNotFound = ErrorCode{
+		Code:    "BUDGET_NOT_FOUND",
+		Message: "기준치를 찾을 수 없습니다",
+		Status:  http.StatusNotFound,
+	}
+
+	ErrInvalidBudgetPeriod = ErrorCode{
+		Code:    "INVALID_BUDGET_PERIOD",
+		Message: "기준치 적용 기간 정보가 올바르지 않습니다",
+		Status:  http.StatusBadRequest,
+	}
+
+	ErrInvalidBudgetRollover = ErrorCode{
+		Code:    "INVALID_BUDGET_ROLLOVER",
+		Message: "기준치 이월 설정이 올바르지 않습니다",
+		Status:  http.StatusBadRequest,
+	}
+
+	// 저축 목표 관련 에러
+	ErrSavingsGoalNotFound = ErrorCode{
+		Code:    "SAVINGS_GOAL_NOT_FOUND",
+		Message: "저축 목표를 찾을 수 없습니다",
+		Status:  http.StatusNotFound,
+	}
+
+	ErrInvalidSavingsGoal = ErrorCode{
+		Code:    "INVALID_SAVINGS_GOAL",
+		Message: "저축 목표 정보가 올바르지 않습니다",
+		Status:  http.StatusBadRequest,
+	}
+
+	// 알림 관련 에러
+	ErrNotificationChannelNotFound = ErrorCode{
+		Code:    "NOTIFICATION_CHANNEL_NOT_FOUND",
+		Message: "알림 채널을 찾을 수 없습니다",
+		Status:  http.StatusNotFound,
+	}
+
+	ErrNotificationNotFound = ErrorCode{
+		Code:    "NOTIFICATION_NOT_FOUND",
+		Message: "알림을 찾을 수 없습니다",
+		Status:  http.StatusNotFound,
+	}
+
+	ErrInvalidNotification = ErrorCode{
+		Code:    "INVALID_NOTIFICATION",
+		Message: "알림 설정 정보가 올바르지 않습니다",
+		Status:  http.StatusBadRequest,
+	}
+
+	// 자동 분류 규칙 관련 에러
+	ErrCategorizationRuleNotFound = ErrorCode{
+		Code:    "CATEGORIZATION_RULE_NOT_FOUND",
+		Message: "자동 분류 규칙을 찾을 수 없습니다",
+		Status:  http.StatusNotFound,
+	}
+
+	ErrInvalidCategorizationRule = ErrorCode{
+		Code:    "INVALID_CATEGORIZATION_RULE",
+		Message: "자동 분류 규칙 정보가 올바르지 않습니다",
+		Status:  http.StatusBadRequest,
+	}
+
+	// 정기 거래 관련 에러
+	ErrRecurringRuleNotFound = ErrorCode{
+		Code:    "RECURRING_RULE_NOT_FOUND",
+		Message: "정기 거래 규칙을 찾을 수 없습니다",
+		Status:  http.StatusNotFound,
+	}
+
+	ErrInvalidRecurringRule = ErrorCode{
+		Code:    "INVALID_RECURRING_RULE",
+		Message: "정기 거래 규칙 정보가 올바르지 않습니다",
+		Status:  http.StatusBadRequest,
+	}
+
+	// 목록 조회 관련 에러
+	ErrInvalidListOption = ErrorCode{
+		Code:    "INVALID_LIST_OPTION",
+		Message: "목록 조회 조건이 올바르지 않습니다",
+		Status:  http.StatusBadRequest,
+	}
+
+	ErrInvalidCursor = ErrorCode{
+		Code:    "INVALID_CURSOR",
+		Message: "페이지 커서가 올바르지 않습니다",
+		Status:  http.StatusBadRequest,
+	}
+
+	// 검색 관련 에러
+	ErrInvalidSearchQuery = ErrorCode{
+		Code:    "INVALID_SEARCH_QUERY",
+		Message: "검색 조건이 올바르지 않습니다",
+		Status:  http.StatusBadRequest,
+	}
 )
 
 // ErrorResponse 응답 구조체
@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+type AccountHandler struct {
+	DB AccountRepository
+}
+
+type AccountRepository interface {
+	GetAccounts(ledgerID int) ([]models.Account, error)
+	GetAccountByID(ledgerID int, id int) (*models.Account, error)
+	CreateAccount(ledgerID int, req models.AccountRequest) (int64, error)
+	UpdateAccount(ledgerID int, id int, req models.AccountRequest) error
+	DeleteAccount(ledgerID int, id int) error
+	LinkAccount(ledgerID int, req models.AccountLinkRequest) error
+	GetAccountBalance(ledgerID int, id int, date string) (*models.AccountBalance, error)
+	GetAccountBalanceHistory(ledgerID int, id int, startDate, endDate string) (*models.AccountBalanceHistory, error)
+	ReconcileAccount(ledgerID int, req models.AccountReconciliationRequest) (*models.AccountReconciliation, error)
+	GetAccountReconciliations(ledgerID int, accountID int) ([]models.AccountReconciliation, error)
+}
+
+// GetAccountsHandler 계좌 목록 조회 핸들러 (id 지정 시 단건 조회)
+func (h *AccountHandler) GetAccountsHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	if r.URL.Query().Get("id") != "" {
+		id, ok := utils.ParseIDFromQuery(w, r, "id")
+		if !ok {
+			return
+		}
+		account, err := h.DB.GetAccountByID(utils.LedgerIDFromRequest(r), id)
+		if err != nil {
+			utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("계좌 조회 실패"))
+			return
+		}
+		utils.SendSuccessResponse(w, account)
+		return
+	}
+
+	accounts, err := h.DB.GetAccounts(utils.LedgerIDFromRequest(r))
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("계좌 목록 조회 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, accounts)
+}
+
+// CreateAccountHandler 계좌 생성 핸들러
+func (h *AccountHandler) CreateAccountHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	var req models.AccountRequest
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+
+	ledgerID := utils.LedgerIDFromRequest(r)
+	id, err := h.DB.CreateAccount(ledgerID, req)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("계좌 생성 실패"))
+		return
+	}
+
+	created, err := h.DB.GetAccountByID(ledgerID, int(id))
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("생성된 계좌 조회 실패"))
+		return
+	}
+
+	utils.Info("계좌 생성: ID=%d, 이름=%s, 개시 잔액=%d", id, created.Name, created.OpeningBalance)
+	utils.SendCreatedResponse(w, created)
+}
+
+// UpdateAccountHandler 계좌 수정 핸들러
+func (h *AccountHandler) UpdateAccountHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPut) {
+		return
+	}
+
+	id, ok := utils.ParseIDFromQuery(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.AccountRequest
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+
+	ledgerID := utils.LedgerIDFromRequest(r)
+	if err := h.DB.UpdateAccount(ledgerID, id, req); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("계좌 수정 실패"))
+		return
+	}
+
+	updated, err := h.DB.GetAccountByID(ledgerID, id)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("수정된 계좌 조회 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, updated)
+}
+
+// DeleteAccountHandler 계좌 삭제 핸들러 (연결된 결제수단/입금경로는 연결만 해제)
+func (h *AccountHandler) DeleteAccountHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodDelete) {
+		return
+	}
+
+	id, ok := utils.ParseIDFromQuery(w, r, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.DB.DeleteAccount(utils.LedgerIDFromRequest(r), id); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("계좌 삭제 실패"))
+		return
+	}
+
+	utils.Info("계좌 삭제: ID=%d", id)
+	utils.SendSuccessResponse(w, utils.CreateSuccessMessage("계좌가 삭제되었습니다."))
+}
+
+// LinkAccountHandler 결제수단/입금경로 계좌 연결 핸들러
+func (h *AccountHandler) LinkAccountHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPut) {
+		return
+	}
+
+	var req models.AccountLinkRequest
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+
+	if err := h.DB.LinkAccount(utils.LedgerIDFromRequest(r), req); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("계좌 연결 실패"))
+		return
+	}
+
+	message := "계좌가 연결되었습니다."
+	if req.AccountID == nil {
+		message = "계좌 연결이 해제되었습니다."
+	}
+	utils.SendSuccessResponse(w, utils.CreateSuccessMessage(message))
+}
+
+// GetAccountBalanceHandler 계좌 잔액 조회 핸들러 (date 미지정 시 오늘 기준)
+func (h *AccountHandler) GetAccountBalanceHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	id, ok := utils.ParseIDFromQuery(w, r, "id")
+	if !ok {
+		return
+	}
+
+	date, ok := parseAccountDate(w, r.URL.Query().Get("date"), "date")
+	if !ok {
+		return
+	}
+
+	balance, err := h.DB.GetAccountBalance(utils.LedgerIDFromRequest(r), id, date)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("계좌 잔액 조회 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, balance)
+}
+
+// GetAccountBalanceHistoryHandler 계좌 잔액 추이 조회 핸들러
+// start_date 미지정 시 계좌 개시일, end_date 미지정 시 오늘 기준
+func (h *AccountHandler) GetAccountBalanceHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	id, ok := utils.ParseIDFromQuery(w, r, "id")
+	if !ok {
+		return
+	}
+
+	startDate := r.URL.Query().Get("start_date")
+	if startDate != "" {
+		if startDate, ok = parseAccountDate(w, startDate, "start_date"); !ok {
+			return
+		}
+	}
+	endDate, ok := parseAccountDate(w, r.URL.Query().Get("end_date"), "end_date")
+	if !ok {
+		return
+	}
+
+	history, err := h.DB.GetAccountBalanceHistory(utils.LedgerIDFromRequest(r), id, startDate, endDate)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("계좌 잔액 추이 조회 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, history)
+}
+
+// ReconcileAccountHandler 실제 잔액 대조 핸들러 (계산 잔액과의 차이 반환)
+func (h *AccountHandler) ReconcileAccountHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	var req models.AccountReconciliationRequest
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+
+	if req.AccountID <= 0 {
+		utils.SendError(w, apiErrors.ErrMissingRequired.WithMessage("account_id는 필수입니다"))
+		return
+	}
+	date, ok := parseAccountDate(w, req.Date, "date")
+	if !ok {
+		return
+	}
+	req.Date = date
+
+	reconciliation, err := h.DB.ReconcileAccount(utils.LedgerIDFromRequest(r), req)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("잔액 대조 실패"))
+		return
+	}
+
+	if reconciliation.Difference != 0 {
+		utils.Warning("잔액 불일치: 계좌 ID=%d, 기준일=%s, 실제=%d, 계산=%d, 차이=%d",
+			req.AccountID, req.Date, reconciliation.StatementBalance, reconciliation.ComputedBalance, reconciliation.Difference)
+	}
+	utils.SendCreatedResponse(w, reconciliation)
+}
+
+// GetAccountReconciliationsHandler 잔액 대조 기록 조회 핸들러
+func (h *AccountHandler) GetAccountReconciliationsHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	id, ok := utils.ParseIDFromQuery(w, r, "id")
+	if !ok {
+		return
+	}
+
+	reconciliations, err := h.DB.GetAccountReconciliations(utils.LedgerIDFromRequest(r), id)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("잔액 대조 기록 조회 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, reconciliations)
+}
+
+// parseAccountDate YYYY-MM-DD 날짜 파라미터 검증 (비어 있으면 오늘 KST)
+func parseAccountDate(w http.ResponseWriter, value, name string) (string, bool) {
+	if value == "" {
+		return utils.FormatDateKST(utils.GetCurrentKST()), true
+	}
+	if _, err := time.Parse("2006-01-02", value); err != nil {
+		utils.SendError(w, apiErrors.ErrInvalidRequest.WithMessage(name+"는 YYYY-MM-DD 형식이어야 합니다"))
+		return "", false
+	}
+	return value, true
+}
@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+type AttachmentHandler struct {
+	DB      AttachmentRepository
+	MaxSize int64 // 첨부파일 최대 크기 (bytes)
+}
+
+type AttachmentRepository interface {
+	GetAttachments(ledgerID int, accountType, accountUUID string) ([]models.Attachment, error)
+	GetAttachmentByUUID(ledgerID int, uuid string) (*models.Attachment, error)
+	SaveAttachment(ledgerID int, accountType, accountUUID, fileName, contentType string, data []byte) (*models.Attachment, error)
+	DeleteAttachment(ledgerID int, uuid string) error
+	AttachmentFilePath(attachment models.Attachment) (string, error)
+}
+
+// multipartOverhead 업로드 요청 본문 제한에 더하는 multipart 헤더/필드 여유분
+const multipartOverhead = 1 << 20
+
+// GetAttachmentsHandler 수입/지출의 첨부파일 목록 조회 핸들러 (type, uuid 파라미터)
+func (h *AttachmentHandler) GetAttachmentsHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	accountType := r.URL.Query().Get("type")
+	accountUUID := r.URL.Query().Get("uuid")
+	if accountUUID == "" {
+		utils.SendError(w, apiErrors.ErrMissingRequired.WithMessage("uuid는 필수입니다"))
+		return
+	}
+
+	attachments, err := h.DB.GetAttachments(utils.LedgerIDFromRequest(r), accountType, accountUUID)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("첨부파일 목록 조회 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, attachments)
+}
+
+// UploadAttachmentHandler 첨부파일 업로드 핸들러 (multipart/form-data: file, type, uuid)
+// 파일 형식은 확장자가 아닌 내용으로 판별하며 영수증 이미지(JPEG/PNG/GIF/WEBP/HEIC)와 PDF만 허용
+func (h *AttachmentHandler) UploadAttachmentHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.MaxSize+multipartOverhead)
+	if err := r.ParseMultipartForm(h.MaxSize + multipartOverhead); err != nil {
+		if strings.Contains(err.Error(), "request body too large") {
+			utils.SendError(w, apiErrors.ErrAttachmentTooLarge.WithMessage(attachmentTooLargeMessage(h.MaxSize)))
+			return
+		}
+		utils.SendError(w, apiErrors.ErrInvalidAttachment.WithMessage("multipart/form-data 형식의 요청이 아닙니다"))
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	accountType := r.FormValue("type")
+	accountUUID := r.FormValue("uuid")
+	if accountUUID == "" {
+		utils.SendError(w, apiErrors.ErrMissingRequired.WithMessage("uuid는 필수입니다"))
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		utils.SendError(w, apiErrors.ErrMissingRequired.WithMessage("file은 필수입니다"))
+		return
+	}
+	defer file.Close()
+
+	if header.Size > h.MaxSize {
+		utils.SendError(w, apiErrors.ErrAttachmentTooLarge.WithMessage(attachmentTooLargeMessage(h.MaxSize)))
+		return
+	}
+	data, err := io.ReadAll(io.LimitReader(file, h.MaxSize+1))
+	if err != nil {
+		utils.LogError("첨부파일 읽기", err)
+		utils.SendError(w, apiErrors.ErrInvalidAttachment.WithMessage("첨부파일을 읽을 수 없습니다"))
+		return
+	}
+	if int64(len(data)) > h.MaxSize {
+		utils.SendError(w, apiErrors.ErrAttachmentTooLarge.WithMessage(attachmentTooLargeMessage(h.MaxSize)))
+		return
+	}
+	if len(data) == 0 {
+		utils.SendError(w, apiErrors.ErrInvalidAttachment.WithMessage("빈 파일은 첨부할 수 없습니다"))
+		return
+	}
+
+	contentType := detectAttachmentType(data)
+	if contentType == "" {
+		utils.Warning("지원하지 않는 첨부파일 형식: 파일=%s, 감지된 형식=%s", header.Filename, http.DetectContentType(data))
+		utils.SendError(w, apiErrors.ErrUnsupportedAttachmentType)
+		return
+	}
+
+	attachment, err := h.DB.SaveAttachment(utils.LedgerIDFromRequest(r), accountType, accountUUID, header.Filename, contentType, data)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("첨부파일 저장 실패"))
+		return
+	}
+
+	utils.Info("첨부파일 업로드: UUID=%s, 거래=%s/%s, 파일=%s (%s, %d bytes)",
+		attachment.UUID, accountType, accountUUID, attachment.FileName, contentType, attachment.Size)
+	utils.SendCreatedResponse(w, attachment)
+}
+
+// DownloadAttachmentHandler 첨부파일 다운로드 핸들러 (uuid 파라미터, inline=true 이면 브라우저에서 바로 표시)
+func (h *AttachmentHandler) DownloadAttachmentHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	uuid := r.URL.Query().Get("uuid")
+	if uuid == "" {
+		utils.SendError(w, apiErrors.ErrMissingRequired.WithMessage("uuid는 필수입니다"))
+		return
+	}
+
+	attachment, err := h.DB.GetAttachmentByUUID(utils.LedgerIDFromRequest(r), uuid)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("첨부파일 조회 실패"))
+		return
+	}
+	path, err := h.DB.AttachmentFilePath(*attachment)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrInternalServer)
+		return
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		utils.LogError("첨부파일 열기", err)
+		utils.SendError(w, apiErrors.ErrAttachmentNotFound)
+		return
+	}
+	defer file.Close()
+
+	disposition := "attachment"
+	if r.URL.Query().Get("inline") == "true" {
+		disposition = "inline"
+	}
+
+	// 등록 시각을 알 수 없으면 zero time 이 되어 Last-Modified 를 생략
+	modTime, _ := time.Parse("2006-01-02 15:04:05", attachment.CreatedAt)
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`%s; filename*=UTF-8''%s`, disposition, url.PathEscape(attachment.FileName)))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, attachment.FileName, modTime, file)
+}
+
+// DeleteAttachmentHandler 첨부파일 삭제 핸들러 (uuid 파라미터)
+func (h *AttachmentHandler) DeleteAttachmentHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodDelete) {
+		return
+	}
+
+	uuid := r.URL.Query().Get("uuid")
+	if uuid == "" {
+		utils.SendError(w, apiErrors.ErrMissingRequired.WithMessage("uuid는 필수입니다"))
+		return
+	}
+
+	if err := h.DB.DeleteAttachment(utils.LedgerIDFromRequest(r), uuid); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("첨부파일 삭제 실패"))
+		return
+	}
+
+	utils.Info("첨부파일 삭제: UUID=%s", uuid)
+	utils.SendSuccessResponse(w, utils.CreateSuccessMessage("첨부파일이 삭제되었습니다."))
+}
+
+// detectAttachmentType 파일 내용으로 허용된 첨부파일 형식 판별 (허용되지 않으면 빈 문자열)
+// HEIC 는 http.DetectContentType 이 인식하지 못하므로 ftyp 브랜드로 직접 확인
+func detectAttachmentType(data []byte) string {
+	switch detected := http.DetectContentType(data); detected {
+	case "image/jpeg", "image/png", "image/gif", "image/webp", "application/pdf":
+		return detected
+	}
+
+	if len(data) >= 12 && bytes.Equal(data[4:8], []byte("ftyp")) {
+		switch string(data[8:12]) {
+		case "heic", "heix", "heim", "heis", "hevc", "hevx", "mif1", "msf1":
+			return "image/heic"
+		}
+	}
+	return ""
+}
+
+// attachmentTooLargeMessage 첨부파일 크기 초과 안내 메시지
+func attachmentTooLargeMessage(maxSize int64) string {
+	return fmt.Sprintf("첨부파일은 %dMB 이하만 업로드할 수 있습니다", maxSize>>20)
+}
@@ -0,0 +1,296 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// minPasswordLength 비밀번호 최소 길이
+const minPasswordLength = 8
+
+type AuthHandler struct {
+	DB         AuthRepository
+	Enabled    bool                 // false 이면 로그인 없이도 모든 API 허용 (기존 동작)
+	SessionTTL time.Duration        // 세션 유효 기간
+	Throttle   *utils.LoginThrottle // 로그인 실패 횟수 제한 (nil 이면 제한 없음)
+	Proxies    utils.TrustedProxies // X-Real-IP 헤더를 믿을 프록시 주소 (비어 있으면 접속 주소만 사용)
+}
+
+var (
+	// dummyPasswordHash 없는 사용자/비밀번호 미설정 사용자 로그인 시에도 같은 비용의 해시 비교를 하기 위한 값
+	// (응답 시간 차이로 사용자 존재 여부가 드러나지 않도록)
+	dummyPasswordHash     string
+	dummyPasswordHashOnce sync.Once
+)
+
+type AuthRepository interface {
+	GetUserCredentialsByName(name string) (*models.User, string, error)
+	GetUserPasswordHash(userID int) (string, error)
+	SetUserPassword(userID int, passwordHash string) error
+	CountUsersWithPassword() (int, error)
+	CreateSession(tokenHash string, userID int, expiresAt time.Time) error
+	GetSessionUser(tokenHash string) (*models.User, error)
+	DeleteSession(tokenHash string) error
+	DeleteExpiredSessions() (int64, error)
+}
+
+// authPublicPaths 인증 없이 접근 가능한 경로
+var authPublicPaths = map[string]bool{
+	"/auth/login":    true,
+	"/auth/password": true, // 최초 비밀번호 설정용 (핸들러에서 권한 재검사)
+	"/health":        true,
+}
+
+// LoginHandler 로그인 핸들러 (사용자 이름 + 비밀번호로 세션 토큰 발급)
+func (h *AuthHandler) LoginHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	var req struct {
+		Name     string `json:"name"`
+		Password string `json:"password"`
+	}
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+
+	if req.Name == "" || req.Password == "" {
+		utils.SendError(w, apiErrors.ErrMissingRequired.WithMessage("사용자 이름과 비밀번호는 필수입니다"))
+		return
+	}
+
+	ip := h.Proxies.ClientIP(r)
+	now := utils.GetCurrentKST()
+	if h.Throttle != nil {
+		if retryAfter, blocked := h.Throttle.Blocked(req.Name, ip, now); blocked {
+			utils.Warning("로그인 시도 차단: 사용자=%s, IP=%s, %v 후 재시도 가능", req.Name, ip, retryAfter.Round(time.Second))
+			w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+			utils.SendError(w, apiErrors.ErrTooManyLoginAttempts)
+			return
+		}
+	}
+
+	// 없는 사용자, 비밀번호 미설정, 비밀번호 불일치는 모두 같은 응답 (사용자 존재 여부를 드러내지 않음)
+	user, passwordHash, err := h.DB.GetUserCredentialsByName(req.Name)
+	var code apiErrors.ErrorCode
+	if err != nil && !(errors.As(err, &code) && code.Code == apiErrors.ErrInvalidCredentials.Code) {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("사용자 조회 실패"))
+		return
+	}
+	if user == nil || passwordHash == "" {
+		dummyPasswordHashOnce.Do(func() {
+			dummyPasswordHash, _ = utils.HashPassword("dummy-password")
+		})
+		passwordHash, user = dummyPasswordHash, nil
+	}
+	if !utils.VerifyPassword(req.Password, passwordHash) || user == nil {
+		utils.Warning("로그인 실패: 사용자=%s, IP=%s", req.Name, ip)
+		if h.Throttle != nil {
+			h.Throttle.RecordFailure(req.Name, ip, now)
+		}
+		utils.SendError(w, apiErrors.ErrInvalidCredentials)
+		return
+	}
+	if h.Throttle != nil {
+		h.Throttle.Reset(req.Name)
+	}
+
+	if _, err := h.DB.DeleteExpiredSessions(); err != nil {
+		utils.LogError("만료 세션 정리", err)
+	}
+
+	token, tokenHash, err := utils.GenerateSessionToken()
+	if err != nil {
+		utils.LogError("세션 토큰 생성", err)
+		utils.SendError(w, apiErrors.ErrInternalServer)
+		return
+	}
+
+	expiresAt := utils.GetCurrentKST().Add(h.SessionTTL)
+	if err := h.DB.CreateSession(tokenHash, user.ID, expiresAt); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("세션 생성 실패"))
+		return
+	}
+
+	utils.Info("로그인 성공: 사용자=%s", user.Name)
+	utils.SendSuccessResponse(w, map[string]interface{}{
+		"token":      token,
+		"expires_at": utils.FormatDateTimeKST(expiresAt),
+		"user":       user,
+	})
+}
+
+// LogoutHandler 로그아웃 핸들러 (현재 세션 토큰 폐기)
+func (h *AuthHandler) LogoutHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	if token := bearerToken(r); token != "" {
+		if err := h.DB.DeleteSession(utils.HashSessionToken(token)); err != nil {
+			utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("로그아웃 실패"))
+			return
+		}
+	}
+
+	utils.SendSuccessResponse(w, utils.CreateSuccessMessage("로그아웃되었습니다."))
+}
+
+// MeHandler 현재 로그인한 사용자 조회 핸들러 (인증 비활성화 시 user 는 null)
+func (h *AuthHandler) MeHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	utils.SendSuccessResponse(w, map[string]interface{}{
+		"auth_enabled": h.Enabled,
+		"user":         utils.AuthUserFromRequest(r),
+	})
+}
+
+// SetPasswordHandler 비밀번호 설정/변경 핸들러
+// - 본인: 기존 비밀번호 확인 후 변경 (최초 설정 시 생략)
+// - 관리자: 다른 사용자의 비밀번호 설정 가능
+// - 비로그인: 아직 비밀번호가 설정된 사용자가 한 명도 없을 때만 허용 (최초 설정)
+func (h *AuthHandler) SetPasswordHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	var req struct {
+		UserID          int    `json:"user_id"`
+		CurrentPassword string `json:"current_password"`
+		NewPassword     string `json:"new_password"`
+	}
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+
+	if len(req.NewPassword) < minPasswordLength {
+		utils.SendError(w, apiErrors.ErrInvalidData.WithMessage("비밀번호는 8자 이상이어야 합니다"))
+		return
+	}
+
+	authUser := utils.AuthUserFromRequest(r)
+	if authUser == nil {
+		count, err := h.DB.CountUsersWithPassword()
+		if err != nil {
+			utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("비밀번호 설정 상태 조회 실패"))
+			return
+		}
+		if count > 0 {
+			utils.SendError(w, apiErrors.ErrUnauthorized)
+			return
+		}
+		if req.UserID <= 0 {
+			utils.SendError(w, apiErrors.ErrMissingRequired.WithMessage("user_id는 필수입니다"))
+			return
+		}
+	} else {
+		if req.UserID <= 0 {
+			req.UserID = authUser.ID
+		}
+		if req.UserID != authUser.ID && !authUser.IsAdmin {
+			utils.SendError(w, apiErrors.ErrForbidden.WithMessage("다른 사용자의 비밀번호는 관리자만 변경할 수 있습니다"))
+			return
+		}
+		if req.UserID == authUser.ID {
+			currentHash, err := h.DB.GetUserPasswordHash(authUser.ID)
+			if err != nil {
+				utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("비밀번호 조회 실패"))
+				return
+			}
+			if currentHash != "" && !utils.VerifyPassword(req.CurrentPassword, currentHash) {
+				utils.SendError(w, apiErrors.ErrInvalidCredentials.WithMessage("현재 비밀번호가 올바르지 않습니다"))
+				return
+			}
+		}
+	}
+
+	passwordHash, err := utils.HashPassword(req.NewPassword)
+	if err != nil {
+		utils.LogError("비밀번호 해시 생성", err)
+		utils.SendError(w, apiErrors.ErrInternalServer)
+		return
+	}
+
+	if err := h.DB.SetUserPassword(req.UserID, passwordHash); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("비밀번호 저장 실패"))
+		return
+	}
+
+	utils.Info("비밀번호 변경: 사용자 ID=%d", req.UserID)
+	utils.SendSuccessResponse(w, utils.CreateSuccessMessage("비밀번호가 설정되었습니다. 다시 로그인해주세요."))
+}
+
+// Middleware 세션 토큰으로 사용자를 식별해 요청 컨텍스트에 저장
+// 인증이 활성화된 경우 공개 경로를 제외하고 로그인하지 않은 요청은 401 응답
+func (h *AuthHandler) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token := bearerToken(r); token != "" {
+			user, err := h.DB.GetSessionUser(utils.HashSessionToken(token))
+			if err == nil {
+				r = utils.WithAuthUser(r, user)
+			} else if h.Enabled && !authPublicPaths[r.URL.Path] {
+				utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("세션 조회 실패"))
+				return
+			}
+		}
+
+		if h.Enabled && utils.AuthUserFromRequest(r) == nil && !authPublicPaths[r.URL.Path] {
+			utils.SendError(w, apiErrors.ErrUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireAdmin 관리자만 호출 가능한 핸들러로 감싸기
+// 강제 삭제, 백업 다운로드/복원처럼 되돌릴 수 없거나 전체 데이터를 노출하는 기능용으로, 인증 비활성화 시에는 403 응답
+func (h *AuthHandler) RequireAdmin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !h.Enabled {
+			utils.SendError(w, apiErrors.ErrForbidden.WithMessage("인증이 비활성화된 상태에서는 사용할 수 없는 관리자 기능입니다 (AUTH_ENABLED=true 필요)"))
+			return
+		}
+		h.RequireAdminWhenEnabled(next)(w, r)
+	}
+}
+
+// RequireAdminWhenEnabled 인증 활성화 시에만 관리자 권한을 요구하는 핸들러로 감싸기 (인증 비활성화 시 그대로 통과)
+// 사용자 생성/수정/삭제처럼 인증 없이 쓰던 기존 관리 기능용
+func (h *AuthHandler) RequireAdminWhenEnabled(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if h.Enabled {
+			user := utils.AuthUserFromRequest(r)
+			if user == nil {
+				utils.SendError(w, apiErrors.ErrUnauthorized)
+				return
+			}
+			if !user.IsAdmin {
+				utils.SendError(w, apiErrors.ErrForbidden.WithMessage("관리자만 사용할 수 있는 기능입니다"))
+				return
+			}
+		}
+		next(w, r)
+	}
+}
+
+// bearerToken Authorization 헤더에서 Bearer 토큰 추출
+func bearerToken(r *http.Request) string {
+	header := r.Header.Get("Authorization")
+	if len(header) > 7 && strings.EqualFold(header[:7], "Bearer ") {
+		return strings.TrimSpace(header[7:])
+	}
+	return ""
+}
@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+type BackupHandler struct {
+	DB  BackupRepository
+	Dir string // 백업 디렉토리
+}
+
+type BackupRepository interface {
+	AcquireConn() func()
+	AcquireExclusive() func()
+	ListBackups(dir string) ([]models.BackupInfo, error)
+	CreateBackup(dir, kind string) (*models.BackupInfo, error)
+	BackupFilePath(dir, name string) (string, error)
+	BackupAttachmentDir(dir, name string) string
+	DeleteBackup(dir, name string) error
+	VerifyBackup(dir, name string) error
+	RestoreBackup(dir, name string) (*models.BackupRestoreResult, error)
+}
+
+// connLeaseKey 요청 컨텍스트에 ConnGuard 가 잡은 DB 연결 잠금을 저장하기 위한 키 타입
+type connLeaseKey struct{}
+
+// connLease 요청이 잡고 있는 DB 연결 잠금 (복원 요청은 처리 중에 배타 잠금으로 바꿈)
+type connLease struct {
+	release func()
+}
+
+// ConnGuard 요청이 처리되는 동안 DB 연결 공유 잠금을 잡는 미들웨어
+// 복원은 인증과 백업 파일 검사를 마친 뒤 RestoreBackupHandler 에서 배타 잠금으로 바꿔 Conn 교체와 다른 요청이 겹치지 않게 함
+func (h *BackupHandler) ConnGuard(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lease := &connLease{release: h.DB.AcquireConn()}
+		defer func() { lease.release() }()
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), connLeaseKey{}, lease)))
+	})
+}
+
+// acquireExclusive 요청의 공유 잠금을 풀고 배타 잠금을 잡음 (진행 중인 다른 요청이 끝날 때까지 대기)
+// ConnGuard 를 거친 요청이면 배타 잠금은 ConnGuard 가 요청이 끝날 때 풀고, 아니면 반환한 함수로 풀어야 함
+func (h *BackupHandler) acquireExclusive(r *http.Request) func() {
+	lease, ok := r.Context().Value(connLeaseKey{}).(*connLease)
+	if !ok {
+		return h.DB.AcquireExclusive()
+	}
+	lease.release()
+	lease.release = h.DB.AcquireExclusive()
+	return func() {}
+}
+
+// GetBackupsHandler 백업 목록 조회 핸들러 (최신순)
+func (h *BackupHandler) GetBackupsHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	backups, err := h.DB.ListBackups(h.Dir)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrInternalServer.WithDetails("백업 목록 조회 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, backups)
+}
+
+// CreateBackupHandler 수동 백업 생성 핸들러
+func (h *BackupHandler) CreateBackupHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	backup, err := h.DB.CreateBackup(h.Dir, models.BackupKindManual)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrInternalServer.WithDetails("백업 생성 실패"))
+		return
+	}
+
+	utils.SendCreatedResponse(w, backup)
+}
+
+// DownloadBackupHandler 백업 파일 다운로드 핸들러 (name 파라미터)
+// attachments=true 이면 DB 파일과 첨부파일을 zip 하나로 묶어 전송
+func (h *BackupHandler) DownloadBackupHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	name := r.URL.Query().Get("name")
+	path, err := h.DB.BackupFilePath(h.Dir, name)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrInternalServer.WithDetails("백업 파일 조회 실패"))
+		return
+	}
+
+	if r.URL.Query().Get("attachments") == "true" {
+		h.downloadBackupArchive(w, name, path)
+		return
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		utils.LogError("백업 파일 열기", err)
+		utils.SendError(w, apiErrors.ErrBackupNotFound)
+		return
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		utils.LogError("백업 파일 정보 조회", err)
+		utils.SendError(w, apiErrors.ErrInternalServer)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/vnd.sqlite3")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, name))
+	utils.Info("백업 다운로드: %s", name)
+	http.ServeContent(w, r, name, stat.ModTime(), file)
+}
+
+// downloadBackupArchive 백업 DB 파일과 첨부파일 스냅샷을 zip 으로 스트리밍 (첨부파일은 attachments/ 아래)
+func (h *BackupHandler) downloadBackupArchive(w http.ResponseWriter, name, path string) {
+	archiveName := strings.TrimSuffix(name, ".db") + ".zip"
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, archiveName))
+
+	archive := zip.NewWriter(w)
+	err := utils.WriteZipFile(archive, name, path)
+
+	count := 0
+	if snapshot := h.DB.BackupAttachmentDir(h.Dir, name); snapshot != "" && err == nil {
+		err = filepath.Walk(snapshot, func(file string, info os.FileInfo, walkErr error) error {
+			if walkErr != nil || info.IsDir() {
+				return walkErr
+			}
+			rel, err := filepath.Rel(snapshot, file)
+			if err != nil {
+				return err
+			}
+			count++
+			return utils.WriteZipFile(archive, "attachments/"+filepath.ToSlash(rel), file)
+		})
+	}
+	if err == nil {
+		err = archive.Close()
+	}
+
+	if err != nil {
+		// 이미 전송이 시작되어 상태 코드를 바꿀 수 없으므로 기록만 남김
+		utils.LogError("백업 다운로드 (전송 중단)", err)
+		return
+	}
+	utils.Info("백업 다운로드: %s (첨부파일 %d개 포함)", archiveName, count)
+}
+
+// DeleteBackupHandler 백업 파일 삭제 핸들러 (name 파라미터)
+func (h *BackupHandler) DeleteBackupHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodDelete) {
+		return
+	}
+
+	if err := h.DB.DeleteBackup(h.Dir, r.URL.Query().Get("name")); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrInternalServer.WithDetails("백업 삭제 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, utils.CreateSuccessMessage("백업이 삭제되었습니다."))
+}
+
+// RestoreBackupHandler 백업 복원 핸들러 (name 파라미터)
+// 무결성 검사를 통과한 백업만 복원하며, 복원 직전 DB 스냅샷을 함께 남김
+// 복원된 DB에 현재 세션이 없으면 다시 로그인해야 함
+func (h *BackupHandler) RestoreBackupHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	// 잘못된 이름이나 손상된 백업으로 진행 중인 요청을 기다리게 하지 않도록 배타 잠금 전에 먼저 검사
+	name := r.URL.Query().Get("name")
+	if err := h.DB.VerifyBackup(h.Dir, name); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrInternalServer.WithDetails("백업 검사 실패"))
+		return
+	}
+
+	defer h.acquireExclusive(r)()
+	result, err := h.DB.RestoreBackup(h.Dir, name)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrInternalServer.WithDetails("백업 복원 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, result)
+}
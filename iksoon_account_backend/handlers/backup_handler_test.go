@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// fakeBackupRepository 잠금 사용과 복원 호출을 기록하는 테스트용 백업 저장소
+type fakeBackupRepository struct {
+	connMu sync.RWMutex
+
+	mu         sync.Mutex
+	exclusive  int
+	verified   []string
+	restored   []string
+	verifyErr  error
+	restoredIn bool // 복원이 배타 잠금 안에서 호출되었는지
+	locked     bool
+}
+
+func (f *fakeBackupRepository) AcquireConn() func() {
+	f.connMu.RLock()
+	return f.connMu.RUnlock
+}
+
+func (f *fakeBackupRepository) AcquireExclusive() func() {
+	f.connMu.Lock()
+	f.mu.Lock()
+	f.exclusive++
+	f.locked = true
+	f.mu.Unlock()
+	return func() {
+		f.mu.Lock()
+		f.locked = false
+		f.mu.Unlock()
+		f.connMu.Unlock()
+	}
+}
+
+func (f *fakeBackupRepository) ListBackups(dir string) ([]models.BackupInfo, error) { return nil, nil }
+func (f *fakeBackupRepository) CreateBackup(dir, kind string) (*models.BackupInfo, error) {
+	return &models.BackupInfo{}, nil
+}
+func (f *fakeBackupRepository) BackupFilePath(dir, name string) (string, error) { return "", nil }
+func (f *fakeBackupRepository) BackupAttachmentDir(dir, name string) string     { return "" }
+func (f *fakeBackupRepository) DeleteBackup(dir, name string) error             { return nil }
+
+func (f *fakeBackupRepository) VerifyBackup(dir, name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.verified = append(f.verified, name)
+	return f.verifyErr
+}
+
+func (f *fakeBackupRepository) RestoreBackup(dir, name string) (*models.BackupRestoreResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.restored = append(f.restored, name)
+	f.restoredIn = f.locked
+	return &models.BackupRestoreResult{Restored: name}, nil
+}
+
+// fakeSessionRepository admin-token 세션만 관리자로 인식하는 테스트용 인증 저장소
+type fakeSessionRepository struct{}
+
+func (fakeSessionRepository) GetUserCredentialsByName(name string) (*models.User, string, error) {
+	return nil, "", apiErrors.ErrInvalidCredentials
+}
+func (fakeSessionRepository) GetUserPasswordHash(userID int) (string, error)        { return "", nil }
+func (fakeSessionRepository) SetUserPassword(userID int, passwordHash string) error { return nil }
+func (fakeSessionRepository) CountUsersWithPassword() (int, error)                  { return 1, nil }
+func (fakeSessionRepository) CreateSession(tokenHash string, userID int, expiresAt time.Time) error {
+	return nil
+}
+func (fakeSessionRepository) GetSessionUser(tokenHash string) (*models.User, error) {
+	if tokenHash == utils.HashSessionToken("admin-token") {
+		return &models.User{ID: 1, Name: "관리자", IsAdmin: true}, nil
+	}
+	return nil, apiErrors.ErrUnauthorized
+}
+func (fakeSessionRepository) DeleteSession(tokenHash string) error  { return nil }
+func (fakeSessionRepository) DeleteExpiredSessions() (int64, error) { return 0, nil }
+
+// restoreRoute main.go 와 같은 순서로 미들웨어를 감싼 복원 경로
+func restoreRoute(repo *fakeBackupRepository) http.Handler {
+	backupHandler := &BackupHandler{DB: repo, Dir: "backups"}
+	authHandler := &AuthHandler{DB: fakeSessionRepository{}, Enabled: true}
+	return backupHandler.ConnGuard(authHandler.Middleware(authHandler.RequireAdmin(backupHandler.RestoreBackupHandler)))
+}
+
+// serveWithinTimeout 진행 중인 요청 때문에 막히지 않고 응답하는지 확인하며 요청 처리
+func serveWithinTimeout(t *testing.T, handler http.Handler, r *http.Request) *httptest.ResponseRecorder {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		handler.ServeHTTP(recorder, r)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("진행 중인 요청이 끝날 때까지 응답하지 않음")
+	}
+	return recorder
+}
+
+func TestRestoreBackupUnauthenticatedDoesNotLock(t *testing.T) {
+	repo := &fakeBackupRepository{}
+	route := restoreRoute(repo)
+
+	// 오래 걸리는 내보내기 같은 진행 중인 요청이 공유 잠금을 잡고 있어도 바로 401 응답
+	release := repo.AcquireConn()
+	defer release()
+
+	for _, token := range []string{"", "wrong-token"} {
+		r := httptest.NewRequest(http.MethodPost, "/admin/backups/restore?name=account_app_manual_20240101_000000.db", nil)
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		recorder := serveWithinTimeout(t, route, r)
+		if recorder.Code != http.StatusUnauthorized {
+			t.Errorf("토큰 %q: status = %d, want 401", token, recorder.Code)
+		}
+	}
+
+	if repo.exclusive != 0 || len(repo.verified) != 0 || len(repo.restored) != 0 {
+		t.Errorf("인증 전 배타 잠금 %d회, 검사 %v, 복원 %v; want 모두 없음", repo.exclusive, repo.verified, repo.restored)
+	}
+}
+
+func TestRestoreBackupInvalidBackupDoesNotLock(t *testing.T) {
+	repo := &fakeBackupRepository{verifyErr: apiErrors.ErrInvalidBackup.WithDetails("무결성 검사 실패")}
+	route := restoreRoute(repo)
+
+	release := repo.AcquireConn()
+	defer release()
+
+	r := httptest.NewRequest(http.MethodPost, "/admin/backups/restore?name=broken.db", nil)
+	r.Header.Set("Authorization", "Bearer admin-token")
+	recorder := serveWithinTimeout(t, route, r)
+
+	if recorder.Code != apiErrors.ErrInvalidBackup.Status {
+		t.Errorf("status = %d, want %d", recorder.Code, apiErrors.ErrInvalidBackup.Status)
+	}
+	if repo.exclusive != 0 || len(repo.restored) != 0 {
+		t.Errorf("검사 실패 후 배타 잠금 %d회, 복원 %v; want 모두 없음", repo.exclusive, repo.restored)
+	}
+}
+
+func TestRestoreBackupAdminTakesExclusiveLock(t *testing.T) {
+	repo := &fakeBackupRepository{}
+	route := restoreRoute(repo)
+
+	r := httptest.NewRequest(http.MethodPost, "/admin/backups/restore?name=account_app_manual_20240101_000000.db", nil)
+	r.Header.Set("Authorization", "Bearer admin-token")
+	recorder := serveWithinTimeout(t, route, r)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (%s)", recorder.Code, recorder.Body.String())
+	}
+	if repo.exclusive != 1 || !repo.restoredIn {
+		t.Errorf("배타 잠금 %d회, 배타 잠금 안에서 복원 %v; want 1, true", repo.exclusive, repo.restoredIn)
+	}
+
+	// 요청이 끝나면 배타 잠금이 풀려 다음 요청이 진행 가능
+	serveWithinTimeout(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		repo.AcquireConn()()
+	}), httptest.NewRequest(http.MethodGet, "/", nil))
+}
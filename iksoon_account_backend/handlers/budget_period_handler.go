@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// GetBudgetPeriodsHandler 기준치 적용 기간 이력 조회 핸들러 (id 파라미터)
+func (h *CategoryBudgetHandler) GetBudgetPeriodsHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	id, ok := utils.ParseIDFromQuery(w, r, "id")
+	if !ok {
+		return
+	}
+
+	periods, err := h.DB.GetBudgetPeriods(utils.LedgerIDFromRequest(r), id)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("기준치 적용 기간 조회 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, periods)
+}
+
+// SetBudgetPeriodHandler 특정 월부터 적용할 기준치 설정 핸들러 (id 파라미터, 같은 월이면 덮어씀)
+func (h *CategoryBudgetHandler) SetBudgetPeriodHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPut) {
+		return
+	}
+
+	id, ok := utils.ParseIDFromQuery(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.BudgetPeriodRequest
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+
+	ledgerID := utils.LedgerIDFromRequest(r)
+	if err := h.DB.SetBudgetPeriod(ledgerID, id, req); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("기준치 적용 기간 저장 실패"))
+		return
+	}
+
+	periods, err := h.DB.GetBudgetPeriods(ledgerID, id)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("기준치 적용 기간 조회 실패"))
+		return
+	}
+
+	utils.Info("기준치 적용 기간 저장: 기준치 ID=%d, 적용 월=%s", id, req.EffectiveFrom)
+	utils.SendSuccessResponse(w, periods)
+}
+
+// DeleteBudgetPeriodHandler 기준치 적용 기간 삭제 핸들러 (id, effective_from 파라미터)
+func (h *CategoryBudgetHandler) DeleteBudgetPeriodHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodDelete) {
+		return
+	}
+
+	id, ok := utils.ParseIDFromQuery(w, r, "id")
+	if !ok {
+		return
+	}
+	effectiveFrom := r.URL.Query().Get("effective_from")
+	if effectiveFrom == "" {
+		utils.SendError(w, apiErrors.ErrMissingRequired.WithMessage("effective_from은 필수입니다"))
+		return
+	}
+
+	if err := h.DB.DeleteBudgetPeriod(utils.LedgerIDFromRequest(r), id, effectiveFrom); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("기준치 적용 기간 삭제 실패"))
+		return
+	}
+
+	utils.Info("기준치 적용 기간 삭제: 기준치 ID=%d, 적용 월=%s", id, effectiveFrom)
+	utils.SendSuccessResponse(w, utils.CreateSuccessMessage("기준치 적용 기간이 삭제되었습니다."))
+}
+
+// GetBudgetMonthlyReportHandler 월별 기준치 대비 실제 지출 표 조회 핸들러
+// user, category_id 필터와 start_month/end_month(YYYY-MM, 생략 시 최근 12개월) 지원
+func (h *CategoryBudgetHandler) GetBudgetMonthlyReportHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	query := r.URL.Query()
+	var categoryID *int
+	if categoryIDStr := query.Get("category_id"); categoryIDStr != "" {
+		id, err := strconv.Atoi(categoryIDStr)
+		if err != nil {
+			utils.SendError(w, apiErrors.ErrInvalidRequest.WithMessage("잘못된 카테고리 ID입니다"))
+			return
+		}
+		categoryID = &id
+	}
+
+	reports, err := h.DB.GetBudgetMonthlyReport(utils.LedgerIDFromRequest(r), query.Get("user"), categoryID, query.Get("start_month"), query.Get("end_month"))
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrStatisticsCalculation.WithDetails("월별 기준치 보고서 계산 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, reports)
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// SetBudgetRolloverHandler 기준치 이월 모드 설정 핸들러 (id 파라미터, enabled=false 면 이월 해제)
+func (h *CategoryBudgetHandler) SetBudgetRolloverHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPut) {
+		return
+	}
+
+	id, ok := utils.ParseIDFromQuery(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.BudgetRolloverRequest
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+
+	if err := h.DB.SetBudgetRollover(utils.LedgerIDFromRequest(r), id, req); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("기준치 이월 설정 실패"))
+		return
+	}
+
+	if req.Enabled {
+		capText := "없음"
+		if req.Cap != nil {
+			capText = fmt.Sprintf("%d원", *req.Cap)
+		}
+		utils.Info("기준치 이월 설정: 기준치 ID=%d, 시작 월=%s, 상한=%s", id, req.From, capText)
+		utils.SendSuccessResponse(w, utils.CreateSuccessMessage("기준치 이월이 설정되었습니다."))
+		return
+	}
+	utils.Info("기준치 이월 해제: 기준치 ID=%d", id)
+	utils.SendSuccessResponse(w, utils.CreateSuccessMessage("기준치 이월이 해제되었습니다."))
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+type CardBillingHandler struct {
+	DB CardBillingRepository
+}
+
+type CardBillingRepository interface {
+	SetCardBilling(ledgerID int, id int, req models.CardBillingRequest) error
+	GetPaymentMethodByID(ledgerID int, id int) (*models.PaymentMethod, error)
+	GetCardStatements(ledgerID int, dueMonth time.Time, paymentMethodID int) (*models.CardStatementSummary, error)
+}
+
+// SetCardBillingHandler 카드 결제수단의 명세서 마감일/결제일 설정 핸들러
+func (h *CardBillingHandler) SetCardBillingHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPut) {
+		return
+	}
+
+	id, ok := utils.ParseIDFromQuery(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.CardBillingRequest
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+
+	ledgerID := utils.LedgerIDFromRequest(r)
+	if err := h.DB.SetCardBilling(ledgerID, id, req); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("카드 명세서 주기 설정 실패"))
+		return
+	}
+
+	method, err := h.DB.GetPaymentMethodByID(ledgerID, id)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("결제수단 조회 실패"))
+		return
+	}
+
+	utils.Info("카드 명세서 주기 설정: 결제수단=%s, 마감일=%v, 결제일=%v", method.Name, req.StatementClosingDay, req.PaymentDueDay)
+	utils.SendSuccessResponse(w, method)
+}
+
+// GetCardStatementsHandler 결제월 기준 카드별 명세서 조회 핸들러
+// due_month(YYYY-MM, 기본: 다음 달), payment_method_id(선택) 파라미터
+func (h *CardBillingHandler) GetCardStatementsHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	query := r.URL.Query()
+	now := utils.GetCurrentKST()
+	dueMonth := utils.StartOfMonthKST(now).AddDate(0, 1, 0)
+	if value := query.Get("due_month"); value != "" {
+		parsed, err := utils.ParseDateInLayoutsKST(value, "2006-01")
+		if err != nil {
+			utils.SendError(w, apiErrors.ErrInvalidRequest.WithMessage("due_month는 YYYY-MM 형식이어야 합니다"))
+			return
+		}
+		dueMonth = parsed
+	}
+
+	paymentMethodID := 0
+	if value := query.Get("payment_method_id"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil || id <= 0 {
+			utils.SendError(w, apiErrors.ErrInvalidRequest.WithMessage("payment_method_id가 올바르지 않습니다"))
+			return
+		}
+		paymentMethodID = id
+	}
+
+	summary, err := h.DB.GetCardStatements(utils.LedgerIDFromRequest(r), dueMonth, paymentMethodID)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("카드 명세서 조회 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, summary)
+}
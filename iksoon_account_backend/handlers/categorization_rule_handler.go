@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+type CategorizationRuleHandler struct {
+	DB     CategorizationRuleRepository
+	Alerts BudgetAlertTrigger // 규칙 재적용으로 지출이 바뀌면 기준치 알림 확인 요청
+}
+
+type CategorizationRuleRepository interface {
+	CategorizationRuleMatcher
+	GetCategorizationRules(ledgerID int, accountType string) ([]models.CategorizationRule, error)
+	GetCategorizationRuleByID(ledgerID, id int) (*models.CategorizationRule, error)
+	CreateCategorizationRule(ledgerID int, req models.CategorizationRuleRequest) (int64, error)
+	UpdateCategorizationRule(ledgerID, id int, req models.CategorizationRuleRequest) error
+	DeleteCategorizationRule(ledgerID, id int) error
+	ApplyCategorizationRules(ledgerID int, req models.RuleApplyRequest) (*models.RuleApplyResult, error)
+}
+
+// CategorizationRuleMatcher 거래 등록 시 자동 분류 규칙 평가에 필요한 저장소
+type CategorizationRuleMatcher interface {
+	MatchCategorizationRules(ledgerID int, subject models.RuleSubject) (*models.RuleResult, error)
+}
+
+// applyCategorizationRules 요청에서 비워 둔 카테고리/키워드/결제수단(지출) 또는 입금경로(수입)를 자동 분류 규칙 결과로 채움
+// 요청에 지정한 값은 그대로 두고 methodID 가 nil 이면 결제수단/입금경로는 채우지 않으며, 규칙 평가에 실패해도 거래 등록은 계속 진행 (경고 로그만 남김)
+func applyCategorizationRules(matcher CategorizationRuleMatcher, r *http.Request, subject models.RuleSubject, categoryID *int, keywordName *string, methodID *int) {
+	if matcher == nil || (*categoryID > 0 && *keywordName != "" && (methodID == nil || *methodID > 0)) {
+		return
+	}
+
+	result, err := matcher.MatchCategorizationRules(utils.LedgerIDFromRequest(r), subject)
+	if err != nil {
+		utils.Warning("자동 분류 규칙 평가 실패 (규칙 없이 등록): %v", err)
+		return
+	}
+	if !result.Matched {
+		return
+	}
+
+	if result.CategoryID != nil && (*categoryID <= 0 || *categoryID == *result.CategoryID) {
+		*categoryID = *result.CategoryID
+		if *keywordName == "" {
+			*keywordName = result.KeywordName
+		}
+	}
+	method := result.PaymentMethodID
+	if subject.Type == models.AccountTypeIn {
+		method = result.DepositPathID
+	}
+	if methodID != nil && *methodID <= 0 && method != nil {
+		*methodID = *method
+	}
+
+	utils.Info("자동 분류 규칙 적용: 유형=%s, 메모=%s, 규칙 %d개 일치 -> 카테고리=%d, 키워드=%s",
+		subject.Type, subject.Memo, len(result.MatchedRules), *categoryID, *keywordName)
+}
+
+// GetCategorizationRulesHandler 자동 분류 규칙 목록 조회 핸들러 (type 파라미터, 평가 순서대로)
+func (h *CategorizationRuleHandler) GetCategorizationRulesHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	accountType := strings.TrimSpace(r.URL.Query().Get("type"))
+	if accountType != "" && accountType != models.AccountTypeOut && accountType != models.AccountTypeIn {
+		utils.SendError(w, apiErrors.ErrInvalidCategorizationRule.WithMessage("type은 'out' 또는 'in'이어야 합니다"))
+		return
+	}
+
+	rules, err := h.DB.GetCategorizationRules(utils.LedgerIDFromRequest(r), accountType)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("자동 분류 규칙 목록 조회 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, rules)
+}
+
+// CreateCategorizationRuleHandler 자동 분류 규칙 생성 핸들러
+func (h *CategorizationRuleHandler) CreateCategorizationRuleHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	var req models.CategorizationRuleRequest
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+
+	ledgerID := utils.LedgerIDFromRequest(r)
+	id, err := h.DB.CreateCategorizationRule(ledgerID, req)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("자동 분류 규칙 생성 실패"))
+		return
+	}
+
+	created, err := h.DB.GetCategorizationRuleByID(ledgerID, int(id))
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("생성된 자동 분류 규칙 조회 실패"))
+		return
+	}
+
+	utils.Info("자동 분류 규칙 생성: ID=%d, 이름=%s, 우선순위=%d", id, created.Name, created.Priority)
+	utils.SendCreatedResponse(w, created)
+}
+
+// UpdateCategorizationRuleHandler 자동 분류 규칙 수정 핸들러 (id 파라미터)
+func (h *CategorizationRuleHandler) UpdateCategorizationRuleHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPut) {
+		return
+	}
+
+	id, ok := utils.ParseIDFromQuery(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.CategorizationRuleRequest
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+
+	ledgerID := utils.LedgerIDFromRequest(r)
+	if err := h.DB.UpdateCategorizationRule(ledgerID, id, req); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("자동 분류 규칙 수정 실패"))
+		return
+	}
+
+	updated, err := h.DB.GetCategorizationRuleByID(ledgerID, id)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("수정된 자동 분류 규칙 조회 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, updated)
+}
+
+// DeleteCategorizationRuleHandler 자동 분류 규칙 삭제 핸들러 (id 파라미터)
+func (h *CategorizationRuleHandler) DeleteCategorizationRuleHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodDelete) {
+		return
+	}
+
+	id, ok := utils.ParseIDFromQuery(w, r, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.DB.DeleteCategorizationRule(utils.LedgerIDFromRequest(r), id); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("자동 분류 규칙 삭제 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, utils.CreateSuccessMessage("자동 분류 규칙이 삭제되었습니다."))
+}
+
+// TestCategorizationRulesHandler 예시 거래가 어떤 카테고리/키워드/결제수단으로 분류되는지 확인하는 핸들러 (저장하지 않음)
+func (h *CategorizationRuleHandler) TestCategorizationRulesHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	var subject models.RuleSubject
+	if !utils.ValidateJSONRequest(w, r, &subject) {
+		return
+	}
+	subject.User = utils.ResolveRequestUser(r, subject.User)
+
+	result, err := h.DB.MatchCategorizationRules(utils.LedgerIDFromRequest(r), subject)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("자동 분류 규칙 확인 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, result)
+}
+
+// ApplyCategorizationRulesHandler 기간 내 기존 거래에 자동 분류 규칙을 다시 적용하는 핸들러 (dry_run 이면 변경 내용만 반환)
+func (h *CategorizationRuleHandler) ApplyCategorizationRulesHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	var req models.RuleApplyRequest
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+
+	result, err := h.DB.ApplyCategorizationRules(utils.LedgerIDFromRequest(r), req)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("자동 분류 규칙 적용 실패"))
+		return
+	}
+
+	if !result.DryRun && result.Changed > 0 {
+		utils.Info("자동 분류 규칙 재적용: 기간=%s~%s, 검사 %d건, 일치 %d건, 변경 %d건",
+			req.StartDate, req.EndDate, result.Scanned, result.Matched, result.Changed)
+		triggerBudgetAlerts(h.Alerts)
+	}
+	utils.SendSuccessResponse(w, result)
+}
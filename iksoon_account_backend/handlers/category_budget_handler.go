@@ -2,12 +2,14 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
 	"iksoon_account_backend/database"
+	apiErrors "iksoon_account_backend/errors"
 	"iksoon_account_backend/models"
 	"iksoon_account_backend/utils"
 )
@@ -42,7 +44,7 @@ func (h *CategoryBudgetHandler) GetCategoryBudgetsHandler(w http.ResponseWriter,
 		}
 	}
 
-	budgets, err := h.DB.GetCategoryBudgets(userName, categoryID)
+	budgets, err := h.DB.GetCategoryBudgets(utils.LedgerIDFromRequest(r), userName, categoryID)
 	if err != nil {
 		utils.LogError("기준치 목록 조회", err)
 		utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "기준치 조회 중 오류 발생")
@@ -84,12 +86,15 @@ func (h *CategoryBudgetHandler) CreateCategoryBudgetHandler(w http.ResponseWrite
 	}
 
 	// 기준치 생성
-	id, err := h.DB.CreateCategoryBudget(req.CategoryID, req.UserName, req.MonthlyBudget, req.YearlyBudget)
+	id, err := h.DB.CreateCategoryBudget(utils.LedgerIDFromRequest(r), req.CategoryID, req.UserName, req.MonthlyBudget, req.YearlyBudget, req.EffectiveFrom)
 	if err != nil {
 		utils.LogError("기준치 생성", err)
 		errorMsg := err.Error()
 
-		if errorMsg == "이미 설정된 기준치가 있습니다" {
+		var code apiErrors.ErrorCode
+		if errors.As(err, &code) {
+			utils.SendError(w, code)
+		} else if errorMsg == "이미 설정된 기준치가 있습니다" {
 			utils.SendErrorResponse(w, http.StatusConflict, models.ErrCodeDuplicateEntry, "해당 카테고리에 대한 기준치가 이미 존재합니다.")
 		} else if strings.Contains(errorMsg, "UNIQUE constraint failed") {
 			utils.SendErrorResponse(w, http.StatusConflict, models.ErrCodeDuplicateEntry, "해당 카테고리에 대한 기준치가 이미 존재합니다.")
@@ -127,8 +132,9 @@ func (h *CategoryBudgetHandler) UpdateCategoryBudgetHandler(w http.ResponseWrite
 	}
 
 	var req struct {
-		MonthlyBudget int `json:"monthly_budget"`
-		YearlyBudget  int `json:"yearly_budget"`
+		MonthlyBudget int    `json:"monthly_budget"`
+		YearlyBudget  int    `json:"yearly_budget"`
+		EffectiveFrom string `json:"effective_from,omitempty"` // YYYY-MM, 비어 있으면 이번 달부터 적용
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -150,10 +156,13 @@ func (h *CategoryBudgetHandler) UpdateCategoryBudgetHandler(w http.ResponseWrite
 	}
 
 	// 기준치 수정
-	err = h.DB.UpdateCategoryBudget(id, req.MonthlyBudget, req.YearlyBudget)
+	err = h.DB.UpdateCategoryBudget(utils.LedgerIDFromRequest(r), id, req.MonthlyBudget, req.YearlyBudget, req.EffectiveFrom)
 	if err != nil {
 		utils.LogError("기준치 수정", err)
-		if err.Error() == "수정할 기준치를 찾을 수 없습니다" {
+		var code apiErrors.ErrorCode
+		if errors.As(err, &code) {
+			utils.SendError(w, code)
+		} else if err.Error() == "수정할 기준치를 찾을 수 없습니다" {
 			utils.SendErrorResponse(w, http.StatusNotFound, models.ErrCodeNotFound, err.Error())
 		} else {
 			utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "기준치 수정 중 오류 발생")
@@ -190,7 +199,7 @@ func (h *CategoryBudgetHandler) DeleteCategoryBudgetHandler(w http.ResponseWrite
 	utils.Debug("기준치 삭제 요청: ID=%d", id)
 
 	// 기준치 삭제 (논리적 삭제)
-	err = h.DB.DeleteCategoryBudget(id)
+	err = h.DB.DeleteCategoryBudget(utils.LedgerIDFromRequest(r), id)
 	if err != nil {
 		utils.LogError("기준치 삭제", err)
 		if err.Error() == "삭제할 기준치를 찾을 수 없습니다" {
@@ -224,7 +233,16 @@ func (h *CategoryBudgetHandler) GetBudgetUsageHandler(w http.ResponseWriter, r *
 	// 	return
 	// }
 
+	// month(YYYY-MM) 를 지정하면 그 달에 적용되던 기준치로 과거 사용량 평가
 	currentDate := time.Now()
+	if month := r.URL.Query().Get("month"); month != "" {
+		parsed, err := utils.ParseDateInLayoutsKST(month, "2006-01")
+		if err != nil {
+			utils.SendError(w, apiErrors.ErrInvalidBudgetPeriod.WithMessage("month는 YYYY-MM 형식이어야 합니다"))
+			return
+		}
+		currentDate = parsed
+	}
 
 	if categoryIDStr != "" {
 		// 특정 카테고리의 기준치 사용량 조회
@@ -234,7 +252,7 @@ func (h *CategoryBudgetHandler) GetBudgetUsageHandler(w http.ResponseWriter, r *
 			return
 		}
 
-		usage, err := h.DB.GetBudgetUsage(categoryID, userName, currentDate)
+		usage, err := h.DB.GetBudgetUsage(utils.LedgerIDFromRequest(r), categoryID, userName, currentDate)
 		if err != nil {
 			utils.LogError("기준치 사용량 조회", err)
 			utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "기준치 사용량 조회 중 오류 발생")
@@ -249,7 +267,7 @@ func (h *CategoryBudgetHandler) GetBudgetUsageHandler(w http.ResponseWriter, r *
 		utils.SendSuccessResponse(w, usage)
 	} else {
 		// 사용자의 모든 카테고리 기준치 사용량 조회
-		usages, err := h.DB.GetAllBudgetUsages(userName, currentDate)
+		usages, err := h.DB.GetAllBudgetUsages(utils.LedgerIDFromRequest(r), userName, currentDate)
 		if err != nil {
 			utils.LogError("전체 기준치 사용량 조회", err)
 			utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "기준치 사용량 조회 중 오류 발생")
@@ -285,10 +303,10 @@ func (h *CategoryBudgetHandler) UpdateMonthlyBudgetHandler(w http.ResponseWriter
 		return
 	}
 
-	err := h.DB.UpdateMonthlyBudget(req.CategoryID, req.UserName, req.Amount)
+	err := h.DB.UpdateMonthlyBudget(utils.LedgerIDFromRequest(r), req.CategoryID, req.UserName, req.Amount, req.EffectiveFrom)
 	if err != nil {
 		utils.LogError("월별 기준치 수정", err)
-		utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "월별 기준치 수정 중 오류 발생")
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("월별 기준치 수정 중 오류 발생"))
 		return
 	}
 
@@ -324,10 +342,10 @@ func (h *CategoryBudgetHandler) UpdateYearlyBudgetHandler(w http.ResponseWriter,
 		return
 	}
 
-	err := h.DB.UpdateYearlyBudget(req.CategoryID, req.UserName, req.Amount)
+	err := h.DB.UpdateYearlyBudget(utils.LedgerIDFromRequest(r), req.CategoryID, req.UserName, req.Amount, req.EffectiveFrom)
 	if err != nil {
 		utils.LogError("연별 기준치 수정", err)
-		utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "연별 기준치 수정 중 오류 발생")
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("연별 기준치 수정 중 오류 발생"))
 		return
 	}
 
@@ -15,12 +15,12 @@ type CategoryHandler struct {
 }
 
 type CategoryRepository interface {
-	GetCategories(categoryType string) ([]models.Category, error)
-	CreateCategory(name, categoryType, expenseType string) (int64, error)
-	UpdateCategory(id int, name string, categoryType string, expenseType string) error
-	CheckCategoryUsage(categoryID int) (bool, error)
-	DeleteCategory(id int) error
-	ForceDeleteCategory(id int) error
+	GetCategories(ledgerID int, categoryType string) ([]models.Category, error)
+	CreateCategory(ledgerID int, name, categoryType, expenseType string) (int64, error)
+	UpdateCategory(ledgerID int, id int, name string, categoryType string, expenseType string) error
+	CheckCategoryUsage(ledgerID int, categoryID int) (bool, error)
+	DeleteCategory(ledgerID int, id int) error
+	ForceDeleteCategory(ledgerID int, id int) error
 }
 
 // GetCategoriesHandler 카테고리 목록 조회 핸들러
@@ -33,7 +33,7 @@ func (h *CategoryHandler) GetCategoriesHandler(w http.ResponseWriter, r *http.Re
 	categoryType := r.URL.Query().Get("type") // 'out' 또는 'in'
 	utils.Debug("카테고리 조회 요청: type=%s", categoryType)
 
-	categories, err := h.DB.GetCategories(categoryType)
+	categories, err := h.DB.GetCategories(utils.LedgerIDFromRequest(r), categoryType)
 	if err != nil {
 		utils.LogDatabaseError("카테고리 조회", err)
 		utils.SendError(w, apiErrors.ErrDatabaseConnection.WithDetails("카테고리 조회 실패"))
@@ -78,7 +78,7 @@ func (h *CategoryHandler) CreateCategoryHandler(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	categoryID, err := h.DB.CreateCategory(req.Name, req.Type, expenseType)
+	categoryID, err := h.DB.CreateCategory(utils.LedgerIDFromRequest(r), req.Name, req.Type, expenseType)
 	if err != nil {
 		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
 			utils.SendError(w, apiErrors.ErrAlreadyExists.WithMessage("이미 존재하는 카테고리입니다"))
@@ -144,7 +144,7 @@ func (h *CategoryHandler) UpdateCategoryHandler(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	err = h.DB.UpdateCategory(categoryID, req.Name, req.Type, expenseType)
+	err = h.DB.UpdateCategory(utils.LedgerIDFromRequest(r), categoryID, req.Name, req.Type, expenseType)
 	if err != nil {
 		if strings.Contains(err.Error(), "no rows affected") {
 			utils.SendError(w, apiErrors.ErrNotFound.WithMessage("카테고리를 찾을 수 없습니다"))
@@ -190,7 +190,7 @@ func (h *CategoryHandler) DeleteCategoryHandler(w http.ResponseWriter, r *http.R
 	utils.Debug("카테고리 삭제 요청: ID %d", categoryID)
 
 	// 카테고리를 사용하는 데이터가 있는지 확인
-	hasData, err := h.DB.CheckCategoryUsage(categoryID)
+	hasData, err := h.DB.CheckCategoryUsage(utils.LedgerIDFromRequest(r), categoryID)
 	if err != nil {
 		utils.LogDatabaseError("카테고리 사용 여부 확인", err)
 		utils.SendError(w, apiErrors.ErrDatabaseConnection.WithDetails("카테고리 사용 여부 확인 실패"))
@@ -204,7 +204,7 @@ func (h *CategoryHandler) DeleteCategoryHandler(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	err = h.DB.DeleteCategory(categoryID)
+	err = h.DB.DeleteCategory(utils.LedgerIDFromRequest(r), categoryID)
 	if err != nil {
 		if strings.Contains(err.Error(), "no rows affected") {
 			utils.SendError(w, apiErrors.ErrNotFound.WithMessage("카테고리를 찾을 수 없습니다"))
@@ -245,7 +245,7 @@ func (h *CategoryHandler) ForceDeleteCategoryHandler(w http.ResponseWriter, r *h
 
 	utils.Debug("카테고리 강제 삭제 요청: ID %d", categoryID)
 
-	err = h.DB.ForceDeleteCategory(categoryID)
+	err = h.DB.ForceDeleteCategory(utils.LedgerIDFromRequest(r), categoryID)
 	if err != nil {
 		if strings.Contains(err.Error(), "no rows affected") {
 			utils.SendError(w, apiErrors.ErrNotFound.WithMessage("카테고리를 찾을 수 없습니다"))
@@ -311,7 +311,7 @@ func (h *CategoryHandler) CategoryRESTHandler(w http.ResponseWriter, r *http.Req
 		// 강제 삭제 로직
 		utils.Debug("RESTful 카테고리 강제 삭제 요청: ID %d", categoryID)
 
-		err = h.DB.ForceDeleteCategory(categoryID)
+		err = h.DB.ForceDeleteCategory(utils.LedgerIDFromRequest(r), categoryID)
 		if err != nil {
 			if strings.Contains(err.Error(), "no rows affected") {
 				utils.SendError(w, apiErrors.ErrNotFound.WithMessage("카테고리를 찾을 수 없습니다"))
@@ -329,7 +329,7 @@ func (h *CategoryHandler) CategoryRESTHandler(w http.ResponseWriter, r *http.Req
 		utils.Debug("RESTful 카테고리 삭제 요청: ID %d", categoryID)
 
 		// 카테고리를 사용하는 데이터가 있는지 확인
-		hasData, err := h.DB.CheckCategoryUsage(categoryID)
+		hasData, err := h.DB.CheckCategoryUsage(utils.LedgerIDFromRequest(r), categoryID)
 		if err != nil {
 			utils.LogDatabaseError("카테고리 사용 여부 확인", err)
 			utils.SendError(w, apiErrors.ErrDatabaseConnection.WithDetails("카테고리 사용 여부 확인 실패"))
@@ -343,7 +343,7 @@ func (h *CategoryHandler) CategoryRESTHandler(w http.ResponseWriter, r *http.Req
 			return
 		}
 
-		err = h.DB.DeleteCategory(categoryID)
+		err = h.DB.DeleteCategory(utils.LedgerIDFromRequest(r), categoryID)
 		if err != nil {
 			if strings.Contains(err.Error(), "no rows affected") {
 				utils.SendError(w, apiErrors.ErrNotFound.WithMessage("카테고리를 찾을 수 없습니다"))
@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// maxExchangeRateFileSize 업로드 가능한 환율 파일 최대 크기 (5MB)
+const maxExchangeRateFileSize = 5 << 20
+
+type CurrencyHandler struct {
+	DB CurrencyRepository
+}
+
+type CurrencyRepository interface {
+	GetExchangeRates(ledgerID int, currency string) ([]models.ExchangeRate, error)
+	GetExchangeRateByID(ledgerID, id int) (*models.ExchangeRate, error)
+	SaveExchangeRate(ledgerID int, req models.ExchangeRateRequest) (int, int, error)
+	DeleteExchangeRate(ledgerID, id int) (int, error)
+	ImportExchangeRates(ledgerID int, rows [][]string) (*models.ExchangeRateImportResult, error)
+	ConvertToBaseCurrency(ledgerID int, currency, date string, amount float64) (int, float64, error)
+	SetTransactionCurrency(ledgerID int, accountType, uuid, currency string, originalMoney float64) error
+	RefreshTransactionCurrency(ledgerID int, accountType, uuid string) error
+}
+
+// GetExchangeRatesHandler 환율 목록 조회 핸들러 (currency 필터)
+func (h *CurrencyHandler) GetExchangeRatesHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	rates, err := h.DB.GetExchangeRates(utils.LedgerIDFromRequest(r), r.URL.Query().Get("currency"))
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("환율 목록 조회 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, rates)
+}
+
+// SaveExchangeRateHandler 환율 등록 핸들러 (같은 통화/날짜는 덮어쓰고 해당 통화 거래의 원화 금액 재계산)
+func (h *CurrencyHandler) SaveExchangeRateHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	var req models.ExchangeRateRequest
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+
+	ledgerID := utils.LedgerIDFromRequest(r)
+	id, reconverted, err := h.DB.SaveExchangeRate(ledgerID, req)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("환율 저장 실패"))
+		return
+	}
+
+	rate, err := h.DB.GetExchangeRateByID(ledgerID, id)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("저장된 환율 조회 실패"))
+		return
+	}
+
+	utils.Info("환율 저장: %s %s = %.4f원, 재계산된 거래 %d건", rate.Currency, rate.Date, rate.Rate, reconverted)
+	utils.SendSuccessResponse(w, map[string]interface{}{
+		"exchange_rate": rate,
+		"reconverted":   reconverted,
+	})
+}
+
+// DeleteExchangeRateHandler 환율 삭제 핸들러 (id 파라미터)
+func (h *CurrencyHandler) DeleteExchangeRateHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodDelete) {
+		return
+	}
+
+	id, ok := utils.ParseIDFromQuery(w, r, "id")
+	if !ok {
+		return
+	}
+
+	reconverted, err := h.DB.DeleteExchangeRate(utils.LedgerIDFromRequest(r), id)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("환율 삭제 실패"))
+		return
+	}
+
+	utils.Info("환율 삭제: ID=%d, 재계산된 거래 %d건", id, reconverted)
+	utils.SendSuccessResponse(w, map[string]interface{}{
+		"message":     "환율이 삭제되었습니다.",
+		"reconverted": reconverted,
+	})
+}
+
+// ImportExchangeRatesHandler 환율 CSV/XLSX 가져오기 핸들러 (multipart/form-data: file, 컬럼 순서는 날짜, 통화, 환율[, 단위])
+func (h *CurrencyHandler) ImportExchangeRatesHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxExchangeRateFileSize+1<<20)
+	if err := r.ParseMultipartForm(maxExchangeRateFileSize); err != nil {
+		utils.SendError(w, apiErrors.ErrInvalidExchangeRate.WithMessage("파일 업로드 형식이 올바르지 않거나 5MB를 초과합니다"))
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, fileHeader, err := r.FormFile("file")
+	if err != nil {
+		utils.SendError(w, apiErrors.ErrMissingRequired.WithMessage("가져올 파일(file)은 필수입니다"))
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		utils.LogError("환율 파일 읽기", err)
+		utils.SendError(w, apiErrors.ErrInvalidExchangeRate.WithMessage("파일을 읽을 수 없습니다"))
+		return
+	}
+
+	rows, err := utils.ReadSpreadsheet(fileHeader.Filename, data, r.FormValue("delimiter"))
+	if err != nil {
+		utils.SendError(w, apiErrors.ErrInvalidExchangeRate.WithMessage(err.Error()))
+		return
+	}
+
+	result, err := h.DB.ImportExchangeRates(utils.LedgerIDFromRequest(r), rows)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("환율 가져오기 실패"))
+		return
+	}
+
+	utils.Info("환율 가져오기: 파일=%s, %d건 (%s), 재계산된 거래 %d건", fileHeader.Filename, result.Imported, strings.Join(result.Currencies, ", "), result.Reconverted)
+	utils.SendSuccessResponse(w, result)
+}
+
+// SetTransactionCurrencyHandler 기존 수입/지출의 외화 금액 지정 핸들러 (currency 가 비어 있거나 KRW 이면 외화 정보 해제)
+func (h *CurrencyHandler) SetTransactionCurrencyHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPut) {
+		return
+	}
+
+	var req models.TransactionCurrencyRequest
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+	if req.UUID == "" {
+		utils.SendError(w, apiErrors.ErrMissingRequired.WithMessage("uuid는 필수입니다"))
+		return
+	}
+
+	if err := h.DB.SetTransactionCurrency(utils.LedgerIDFromRequest(r), req.Type, req.UUID, req.Currency, req.OriginalMoney); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("외화 정보 저장 실패"))
+		return
+	}
+
+	utils.Info("외화 정보 저장: Type=%s, UUID=%s, 통화=%s, 금액=%.2f", req.Type, req.UUID, req.Currency, req.OriginalMoney)
+	utils.SendSuccessResponse(w, utils.CreateSuccessMessage("외화 정보가 저장되었습니다."))
+}
+
+// isForeignCurrency 요청의 통화가 기준 통화가 아닌 외화인지 확인 (nil/빈 값/KRW 는 원화 거래)
+func isForeignCurrency(currency *string) bool {
+	if currency == nil {
+		return false
+	}
+	code := strings.ToUpper(strings.TrimSpace(*currency))
+	return code != "" && code != models.BaseCurrency
+}
+
+// requestCurrency 등록 요청의 통화 코드 (생략하면 원화 거래로 빈 값)
+func requestCurrency(currency *string) string {
+	if currency == nil {
+		return ""
+	}
+	return *currency
+}
+
+// saveTransactionCurrency 수입/지출 수정 후 외화 정보 반영 (등록은 Insert 에서 같은 트랜잭션으로 저장)
+// currency 를 보내지 않은 수정 요청은 기존 외화 정보를 유지하고 바뀐 날짜의 환율로 원화 금액만 다시 계산
+func saveTransactionCurrency(db CurrencyRepository, ledgerID int, accountType, uuid string, currency *string, originalMoney float64) error {
+	if currency == nil {
+		return db.RefreshTransactionCurrency(ledgerID, accountType, uuid)
+	}
+	return db.SetTransactionCurrency(ledgerID, accountType, uuid, *currency, originalMoney)
+}
+
+// convertRequestMoney 외화 거래 요청이면 거래 날짜의 환율로 원화 금액(money)을 계산해 덮어씀
+func convertRequestMoney(db CurrencyRepository, ledgerID int, currency *string, date string, originalMoney float64, money *int) error {
+	if !isForeignCurrency(currency) {
+		return nil
+	}
+	converted, _, err := db.ConvertToBaseCurrency(ledgerID, *currency, date, originalMoney)
+	if err != nil {
+		return err
+	}
+	*money = converted
+	return nil
+}
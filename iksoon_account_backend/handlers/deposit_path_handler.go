@@ -16,13 +16,13 @@ type DepositPathHandler struct {
 }
 
 type DepositPathRepository interface {
-	GetDepositPaths() ([]models.DepositPath, error)
-	CreateDepositPath(name string) (int64, error)
-	UpdateDepositPath(id int, name string) error
-	DeleteDepositPath(id int) error
-	ForceDeleteDepositPath(id int) error
-	CheckDepositPathExists(id int) (bool, error)
-	CheckDepositPathUsage(depositPathID int) (bool, error)
+	GetDepositPaths(ledgerID int) ([]models.DepositPath, error)
+	CreateDepositPath(ledgerID int, name string) (int64, error)
+	UpdateDepositPath(ledgerID int, id int, name string) error
+	DeleteDepositPath(ledgerID int, id int) error
+	ForceDeleteDepositPath(ledgerID int, id int) error
+	CheckDepositPathExists(ledgerID int, id int) (bool, error)
+	CheckDepositPathUsage(ledgerID int, depositPathID int) (bool, error)
 }
 
 // GetDepositPathsHandler 입금경로 목록 조회 핸들러
@@ -33,7 +33,7 @@ func (h *DepositPathHandler) GetDepositPathsHandler(w http.ResponseWriter, r *ht
 
 	utils.Debug("입금경로 목록 조회 요청")
 
-	depositPaths, err := h.DB.GetDepositPaths()
+	depositPaths, err := h.DB.GetDepositPaths(utils.LedgerIDFromRequest(r))
 	if err != nil {
 		utils.LogDatabaseError("입금경로 목록 조회", err)
 		utils.SendError(w, apiErrors.ErrDatabaseConnection.WithDetails("입금경로 목록 조회 실패"))
@@ -63,7 +63,7 @@ func (h *DepositPathHandler) CreateDepositPathHandler(w http.ResponseWriter, r *
 		return
 	}
 
-	depositPathID, err := h.DB.CreateDepositPath(req.Name)
+	depositPathID, err := h.DB.CreateDepositPath(utils.LedgerIDFromRequest(r), req.Name)
 	if err != nil {
 		if strings.Contains(err.Error(), "이미 존재하는") {
 			utils.SendError(w, apiErrors.ErrAlreadyExists.WithMessage(err.Error()))
@@ -113,7 +113,7 @@ func (h *DepositPathHandler) UpdateDepositPathHandler(w http.ResponseWriter, r *
 		return
 	}
 
-	err = h.DB.UpdateDepositPath(depositPathID, req.Name)
+	err = h.DB.UpdateDepositPath(utils.LedgerIDFromRequest(r), depositPathID, req.Name)
 	if err != nil {
 		if strings.Contains(err.Error(), "no rows affected") {
 			utils.SendErrorResponse(w, http.StatusNotFound, models.ErrCodeNotFound, "존재하지 않는 입금경로입니다.")
@@ -154,7 +154,7 @@ func (h *DepositPathHandler) DeleteDepositPathHandler(w http.ResponseWriter, r *
 	}
 
 	// 입금경로를 사용하는 데이터가 있는지 확인
-	hasData, err := h.DB.CheckDepositPathUsage(depositPathID)
+	hasData, err := h.DB.CheckDepositPathUsage(utils.LedgerIDFromRequest(r), depositPathID)
 	if err != nil {
 		utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "입금경로 사용 여부 확인 중 오류 발생")
 		return
@@ -165,7 +165,7 @@ func (h *DepositPathHandler) DeleteDepositPathHandler(w http.ResponseWriter, r *
 		return
 	}
 
-	err = h.DB.DeleteDepositPath(depositPathID)
+	err = h.DB.DeleteDepositPath(utils.LedgerIDFromRequest(r), depositPathID)
 	if err != nil {
 		if strings.Contains(err.Error(), "no rows affected") {
 			utils.SendErrorResponse(w, http.StatusNotFound, models.ErrCodeNotFound, "존재하지 않는 입금경로입니다.")
@@ -201,7 +201,7 @@ func (h *DepositPathHandler) ForceDeleteDepositPathHandler(w http.ResponseWriter
 		return
 	}
 
-	err = h.DB.ForceDeleteDepositPath(depositPathID)
+	err = h.DB.ForceDeleteDepositPath(utils.LedgerIDFromRequest(r), depositPathID)
 	if err != nil {
 		if strings.Contains(err.Error(), "no rows affected") {
 			utils.SendErrorResponse(w, http.StatusNotFound, models.ErrCodeNotFound, "존재하지 않는 입금경로입니다.")
@@ -0,0 +1,340 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+type ExportHandler struct {
+	DB ExportRepository
+}
+
+type ExportRepository interface {
+	StreamAccountExport(ledgerID int, filter models.ExportFilter, fn func(models.ExportRow) error) error
+	GetAttachmentsByAccounts(ledgerID int, accountType string, accountUUIDs []string) ([]models.Attachment, error)
+	AttachmentFilePath(attachment models.Attachment) (string, error)
+}
+
+// exportColumns CSV/XLSX 내보내기 헤더
+var exportColumns = []string{"유형", "날짜", "사용자", "금액", "통화", "외화금액", "환율", "카테고리", "키워드", "결제수단", "입금경로", "메모", "태그", "첨부파일", "UUID", "등록일시", "수정일시"}
+
+// exportContentTypes 형식별 Content-Type
+var exportContentTypes = map[string]string{
+	"csv":  "text/csv; charset=utf-8",
+	"json": "application/json; charset=utf-8",
+	"xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// exportEncoder 내보내기 형식별 행 기록기
+type exportEncoder interface {
+	Encode(row models.ExportRow) error
+	Close() error
+}
+
+// ExportHandler 거래 내보내기 핸들러
+// format=csv|json|xlsx, type=out|in|all, start_date, end_date, user, category_id, payment_method_id
+// attachments=true 이면 내보내기 파일과 첨부파일(attachments/<거래UUID>/<파일이름>)을 zip 하나로 묶어 전송
+func (h *ExportHandler) ExportHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	query := r.URL.Query()
+	format := query.Get("format")
+	if format == "" {
+		format = "csv"
+	}
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		utils.SendError(w, apiErrors.ErrInvalidRequest.WithMessage("format은 csv, json, xlsx 중 하나여야 합니다"))
+		return
+	}
+
+	filter, err := parseExportFilter(query.Get)
+	if err != nil {
+		utils.SendError(w, apiErrors.ErrInvalidRequest.WithMessage(err.Error()))
+		return
+	}
+
+	withAttachments := query.Get("attachments") == "true"
+
+	// 첫 바이트가 전송되기 전 오류는 JSON 에러로 응답할 수 있도록 응답 시작 여부를 추적
+	tracker := &responseTracker{ResponseWriter: w}
+	buffered := bufio.NewWriterSize(tracker, 64*1024)
+
+	stamp := utils.GetCurrentKST().Format("20060102_150405")
+	filename := fmt.Sprintf("account_export_%s.%s", stamp, format)
+
+	var output io.Writer = buffered
+	var archive *zip.Writer
+	if withAttachments {
+		archive = zip.NewWriter(buffered)
+		if output, err = archive.Create(filename); err != nil {
+			utils.LogError("내보내기 압축 파일 생성", err)
+			utils.SendError(w, apiErrors.ErrInternalServer)
+			return
+		}
+		filename = fmt.Sprintf("account_export_%s.zip", stamp)
+		contentType = "application/zip"
+	}
+
+	encoder, err := newExportEncoder(format, output)
+	if err != nil {
+		utils.LogError("내보내기 작성기 생성", err)
+		utils.SendError(w, apiErrors.ErrInternalServer)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	ledgerID := utils.LedgerIDFromRequest(r)
+	count := 0
+	attached := map[string][]string{} // 유형별 첨부파일이 있는 거래 UUID
+	err = h.DB.StreamAccountExport(ledgerID, filter, func(row models.ExportRow) error {
+		count++
+		if withAttachments && len(row.Attachments) > 0 {
+			attached[row.Type] = append(attached[row.Type], row.UUID)
+		}
+		return encoder.Encode(row)
+	})
+	if err == nil {
+		err = encoder.Close()
+	}
+	if err == nil && archive != nil {
+		err = h.writeExportAttachments(archive, ledgerID, attached)
+		if err == nil {
+			err = archive.Close()
+		}
+	}
+	if err == nil {
+		err = buffered.Flush()
+	}
+
+	if err != nil {
+		if tracker.started {
+			// 이미 전송이 시작된 경우 상태 코드를 바꿀 수 없으므로 기록만 남김
+			utils.LogError("거래 내보내기 (전송 중단)", err)
+			return
+		}
+		w.Header().Del("Content-Disposition")
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("거래 내보내기 실패"))
+		return
+	}
+
+	utils.Info("거래 내보내기: 형식=%s, 유형=%s, 기간=%s~%s, %d건, 첨부파일 포함=%v", format, filter.Type, filter.StartDate, filter.EndDate, count, withAttachments)
+}
+
+// writeExportAttachments 내보낸 거래의 첨부파일을 zip 에 추가 (같은 거래에 이름이 겹치는 파일은 번호를 붙임)
+// 원본이 없어진 첨부파일은 경고만 남기고 건너뜀
+func (h *ExportHandler) writeExportAttachments(archive *zip.Writer, ledgerID int, attached map[string][]string) error {
+	for _, accountType := range []string{models.AccountTypeOut, models.AccountTypeIn} {
+		if len(attached[accountType]) == 0 {
+			continue
+		}
+
+		attachments, err := h.DB.GetAttachmentsByAccounts(ledgerID, accountType, attached[accountType])
+		if err != nil {
+			return err
+		}
+
+		used := map[string]bool{}
+		for _, attachment := range attachments {
+			path, err := h.DB.AttachmentFilePath(attachment)
+			if err != nil {
+				continue
+			}
+
+			entryName := "attachments/" + attachment.AccountUUID + "/" + attachment.FileName
+			extension := filepath.Ext(attachment.FileName)
+			for i := 2; used[entryName]; i++ {
+				entryName = fmt.Sprintf("attachments/%s/%s (%d)%s", attachment.AccountUUID, strings.TrimSuffix(attachment.FileName, extension), i, extension)
+			}
+			used[entryName] = true
+
+			if err := utils.WriteZipFile(archive, entryName, path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// parseExportFilter 쿼리 파라미터를 내보내기 조건으로 변환
+func parseExportFilter(get func(string) string) (models.ExportFilter, error) {
+	filter := models.ExportFilter{
+		Type:      get("type"),
+		StartDate: get("start_date"),
+		EndDate:   get("end_date"),
+		User:      get("user"),
+	}
+
+	if filter.Type == "" {
+		filter.Type = "all"
+	}
+	if filter.Type != "out" && filter.Type != "in" && filter.Type != "all" {
+		return filter, fmt.Errorf("type은 out, in, all 중 하나여야 합니다")
+	}
+
+	for _, date := range []*string{&filter.StartDate, &filter.EndDate} {
+		if *date == "" {
+			continue
+		}
+		parsed, err := utils.ParseDateTimeKST(*date)
+		if err != nil {
+			return filter, fmt.Errorf("날짜 형식이 올바르지 않습니다: %s", *date)
+		}
+		*date = utils.FormatDateKST(parsed)
+	}
+	if filter.StartDate != "" && filter.EndDate != "" && filter.StartDate > filter.EndDate {
+		return filter, fmt.Errorf("start_date가 end_date보다 늦을 수 없습니다")
+	}
+
+	var err error
+	if filter.CategoryID, err = parseOptionalID(get("category_id"), "category_id"); err != nil {
+		return filter, err
+	}
+	if filter.PaymentMethodID, err = parseOptionalID(get("payment_method_id"), "payment_method_id"); err != nil {
+		return filter, err
+	}
+	if filter.Type == "in" && filter.PaymentMethodID != nil {
+		return filter, fmt.Errorf("payment_method_id는 지출(out) 내보내기에만 사용할 수 있습니다")
+	}
+
+	return filter, nil
+}
+
+// parseOptionalID 선택 ID 파라미터 파싱 (비어있으면 nil)
+func parseOptionalID(value, name string) (*int, error) {
+	if value == "" {
+		return nil, nil
+	}
+	id, err := strconv.Atoi(value)
+	if err != nil || id <= 0 {
+		return nil, fmt.Errorf("%s가 올바르지 않습니다", name)
+	}
+	return &id, nil
+}
+
+// newExportEncoder 형식별 행 기록기 생성
+func newExportEncoder(format string, w io.Writer) (exportEncoder, error) {
+	switch format {
+	case "json":
+		return &jsonExportEncoder{writer: utils.NewJSONArrayWriter(w)}, nil
+	case "xlsx":
+		writer, err := utils.NewXLSXStreamWriter(w, "거래내역")
+		if err != nil {
+			return nil, err
+		}
+		encoder := &xlsxExportEncoder{writer: writer}
+		return encoder, encoder.writeHeader()
+	default:
+		writer, err := utils.NewExcelCSVWriter(w)
+		if err != nil {
+			return nil, err
+		}
+		return &csvExportEncoder{writer: writer}, writer.Write(exportColumns)
+	}
+}
+
+// exportRowValues 내보내기 행을 CSV/XLSX 컬럼 순서의 값으로 변환
+func exportRowValues(row models.ExportRow) []interface{} {
+	typeName := "지출"
+	if row.Type == "in" {
+		typeName = "수입"
+	}
+	// 원화 거래는 통화/외화금액/환율 칸을 비워 둠
+	var currency, originalMoney, exchangeRate interface{}
+	if row.Currency != "" {
+		currency, originalMoney, exchangeRate = row.Currency, row.OriginalMoney, row.ExchangeRate
+	}
+	return []interface{}{typeName, row.Date, row.User, row.Money, currency, originalMoney, exchangeRate, row.CategoryName, row.KeywordName,
+		row.PaymentMethodName, row.DepositPathName, row.Memo, strings.Join(row.Tags, ", "), strings.Join(row.Attachments, ", "),
+		row.UUID, row.CreatedAt, row.UpdatedAt}
+}
+
+type csvExportEncoder struct {
+	writer *csv.Writer
+}
+
+// Encode CSV 행 기록 (문자열 값은 스프레드시트에서 수식으로 실행되지 않도록 처리)
+func (e *csvExportEncoder) Encode(row models.ExportRow) error {
+	values := exportRowValues(row)
+	record := make([]string, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case nil:
+		case string:
+			record[i] = utils.EscapeCSVFormula(v)
+		default:
+			record[i] = fmt.Sprint(v)
+		}
+	}
+	return e.writer.Write(record)
+}
+
+// Close CSV 버퍼 비우기
+func (e *csvExportEncoder) Close() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+type jsonExportEncoder struct {
+	writer *utils.JSONArrayWriter
+}
+
+// Encode JSON 배열 항목 기록
+func (e *jsonExportEncoder) Encode(row models.ExportRow) error {
+	return e.writer.Write(row)
+}
+
+// Close JSON 배열 닫기
+func (e *jsonExportEncoder) Close() error {
+	return e.writer.Close()
+}
+
+type xlsxExportEncoder struct {
+	writer *utils.XLSXStreamWriter
+}
+
+// writeHeader XLSX 헤더 행 기록
+func (e *xlsxExportEncoder) writeHeader() error {
+	header := make([]interface{}, len(exportColumns))
+	for i, column := range exportColumns {
+		header[i] = column
+	}
+	return e.writer.WriteRow(header)
+}
+
+// Encode XLSX 행 기록
+func (e *xlsxExportEncoder) Encode(row models.ExportRow) error {
+	return e.writer.WriteRow(exportRowValues(row))
+}
+
+// Close XLSX 파일 마무리
+func (e *xlsxExportEncoder) Close() error {
+	return e.writer.Close()
+}
+
+// responseTracker 응답 본문 전송이 시작되었는지 기록하는 ResponseWriter 래퍼
+type responseTracker struct {
+	http.ResponseWriter
+	started bool
+}
+
+// Write 본문 기록 (전송 시작 표시)
+func (t *responseTracker) Write(p []byte) (int, error) {
+	t.started = true
+	return t.ResponseWriter.Write(p)
+}
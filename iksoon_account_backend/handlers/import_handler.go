@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// maxImportFileSize 업로드 가능한 명세서 파일 최대 크기 (10MB)
+const maxImportFileSize = 10 << 20
+
+type ImportHandler struct {
+	DB ImportRepository
+}
+
+type ImportRepository interface {
+	GetImportProfiles(ledgerID int) ([]models.ImportProfile, error)
+	GetImportProfileByID(ledgerID int, id int) (*models.ImportProfile, error)
+	CreateImportProfile(ledgerID int, profile models.ImportProfile) (int64, error)
+	UpdateImportProfile(ledgerID int, id int, profile models.ImportProfile) error
+	DeleteImportProfile(ledgerID int, id int) error
+	BuildImportPreview(ledgerID int, profile models.ImportProfile, rows [][]string, user string) (*models.ImportPreview, error)
+	CommitImport(ledgerID int, req models.ImportCommitRequest) (*models.ImportCommitResult, error)
+}
+
+// GetImportProfilesHandler 가져오기 프로필 목록 조회 핸들러
+func (h *ImportHandler) GetImportProfilesHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	profiles, err := h.DB.GetImportProfiles(utils.LedgerIDFromRequest(r))
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("가져오기 프로필 조회 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, profiles)
+}
+
+// CreateImportProfileHandler 가져오기 프로필 생성 핸들러
+func (h *ImportHandler) CreateImportProfileHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	profile := models.ImportProfile{HasHeader: true}
+	if !utils.ValidateJSONRequest(w, r, &profile) {
+		return
+	}
+
+	ledgerID := utils.LedgerIDFromRequest(r)
+	id, err := h.DB.CreateImportProfile(ledgerID, profile)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("가져오기 프로필 생성 실패"))
+		return
+	}
+
+	created, err := h.DB.GetImportProfileByID(ledgerID, int(id))
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("생성된 가져오기 프로필 조회 실패"))
+		return
+	}
+
+	utils.Info("가져오기 프로필 생성: ID=%d, 이름=%s", id, created.Name)
+	utils.SendCreatedResponse(w, created)
+}
+
+// UpdateImportProfileHandler 가져오기 프로필 수정 핸들러
+func (h *ImportHandler) UpdateImportProfileHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPut) {
+		return
+	}
+
+	id, ok := utils.ParseIDFromQuery(w, r, "id")
+	if !ok {
+		return
+	}
+
+	profile := models.ImportProfile{HasHeader: true}
+	if !utils.ValidateJSONRequest(w, r, &profile) {
+		return
+	}
+
+	if err := h.DB.UpdateImportProfile(utils.LedgerIDFromRequest(r), id, profile); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("가져오기 프로필 수정 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, utils.CreateSuccessMessage("가져오기 프로필이 수정되었습니다."))
+}
+
+// DeleteImportProfileHandler 가져오기 프로필 삭제 핸들러
+func (h *ImportHandler) DeleteImportProfileHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodDelete) {
+		return
+	}
+
+	id, ok := utils.ParseIDFromQuery(w, r, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.DB.DeleteImportProfile(utils.LedgerIDFromRequest(r), id); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("가져오기 프로필 삭제 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, utils.CreateSuccessMessage("가져오기 프로필이 삭제되었습니다."))
+}
+
+// PreviewImportHandler 명세서 미리보기 핸들러
+// multipart/form-data: file (CSV 또는 XLSX), profile_id, user (로그인 시 로그인 사용자로 대체)
+func (h *ImportHandler) PreviewImportHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportFileSize+1<<20)
+	if err := r.ParseMultipartForm(maxImportFileSize); err != nil {
+		utils.SendError(w, apiErrors.ErrInvalidImportData.WithMessage("파일 업로드 형식이 올바르지 않거나 10MB를 초과합니다"))
+		return
+	}
+
+	profileID, err := strconv.Atoi(r.FormValue("profile_id"))
+	if err != nil || profileID <= 0 {
+		utils.SendError(w, apiErrors.ErrMissingRequired.WithMessage("profile_id는 필수입니다"))
+		return
+	}
+
+	ledgerID := utils.LedgerIDFromRequest(r)
+	profile, err := h.DB.GetImportProfileByID(ledgerID, profileID)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("가져오기 프로필 조회 실패"))
+		return
+	}
+
+	file, fileHeader, err := r.FormFile("file")
+	if err != nil {
+		utils.SendError(w, apiErrors.ErrMissingRequired.WithMessage("가져올 파일(file)은 필수입니다"))
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		utils.LogError("가져오기 파일 읽기", err)
+		utils.SendError(w, apiErrors.ErrInvalidImportData.WithMessage("파일을 읽을 수 없습니다"))
+		return
+	}
+
+	rows, err := utils.ReadSpreadsheet(fileHeader.Filename, data, profile.Delimiter)
+	if err != nil {
+		utils.SendError(w, apiErrors.ErrInvalidImportData.WithMessage(err.Error()))
+		return
+	}
+
+	user := utils.ResolveRequestUser(r, r.FormValue("user"))
+	preview, err := h.DB.BuildImportPreview(ledgerID, *profile, rows, user)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("가져오기 미리보기 생성 실패"))
+		return
+	}
+
+	utils.Info("가져오기 미리보기: 파일=%s, 행=%d, 매핑=%d, 중복=%d, 오류=%d",
+		fileHeader.Filename, preview.TotalRows, preview.MatchedRows, preview.DuplicateRows, preview.ErrorRows)
+	utils.SendSuccessResponse(w, preview)
+}
+
+// CommitImportHandler 미리보기에서 승인한 행을 한 번에 등록하는 핸들러
+func (h *ImportHandler) CommitImportHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	var req models.ImportCommitRequest
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+
+	for i := range req.Rows {
+		req.Rows[i].User = utils.ResolveRequestUser(r, req.Rows[i].User)
+	}
+
+	result, err := h.DB.CommitImport(utils.LedgerIDFromRequest(r), req)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("가져오기 등록 실패"))
+		return
+	}
+
+	utils.Info("가져오기 등록 완료: 유형=%s, %d건", req.Type, result.Inserted)
+	utils.SendCreatedResponse(w, result)
+}
@@ -7,24 +7,27 @@ import (
 	"strings"
 
 	"iksoon_account_backend/database"
+	apiErrors "iksoon_account_backend/errors"
 	"iksoon_account_backend/models"
 	"iksoon_account_backend/utils"
 )
 
 type InAccountHandler struct {
-	DB        InAccountRepository
-	KeywordDB KeywordRepository
+	DB         InAccountRepository
+	KeywordDB  KeywordRepository
+	CurrencyDB CurrencyRepository
+	Rules      CategorizationRuleMatcher // 비워 둔 카테고리/키워드/입금경로를 자동 분류 규칙으로 채움 (nil 이면 사용 안 함)
 }
 
 type InAccountRepository interface {
-	InsertInAccount(date, user string, money, categoryID int, keywordID *int, depositPathID int, memo string) error
-	GetInAccountsByDate(date string) ([]models.InAccount, error)
-	GetInAccountsForMonth(year, month string) ([]models.InAccount, error)
-	GetInAccountsByDateRange(startDate, endDate string) ([]models.InAccount, error)
-	SearchInAccountsByKeyword(keyword, startDate, endDate string) ([]models.InAccount, error)
-	UpdateInAccount(uuid, date, user string, money, categoryID int, keywordID *int, depositPathID int, memo string) error
-	DeleteInAccount(uuid string) error
-	GetInAccountByUUID(uuid string) (*models.InAccount, error)
+	InsertInAccount(ledgerID int, date, user string, money, categoryID int, keywordID *int, depositPathID int, memo, currency string, originalMoney float64) (string, error)
+	GetInAccountsByDate(ledgerID int, date string) ([]models.InAccount, error)
+	GetInAccountsForMonth(ledgerID int, year, month string) ([]models.InAccount, error)
+	GetInAccountsByDateRange(ledgerID int, startDate, endDate string, opts models.ListOptions) ([]models.InAccount, models.PageInfo, error)
+	SearchInAccountsByKeyword(ledgerID int, keyword, startDate, endDate string, opts models.ListOptions) ([]models.InAccount, models.PageInfo, error)
+	UpdateInAccount(ledgerID int, uuid, date, user string, money, categoryID int, keywordID *int, depositPathID int, memo string) error
+	DeleteInAccount(ledgerID int, uuid string) error
+	GetInAccountByUUID(ledgerID int, uuid string) (*models.InAccount, error)
 }
 
 // 새로운 구조의 수입 데이터 삽입 핸들러
@@ -42,6 +45,9 @@ func (h *InAccountHandler) InsertInAccountHandler(w http.ResponseWriter, r *http
 		KeywordName string `json:"keyword_name,omitempty"`
 		DepositPath string `json:"deposit_path"`
 		Memo        string `json:"memo"`
+		// 외화 수입이면 통화 코드와 외화 금액 (money 는 거래 날짜의 환율로 계산)
+		Currency      *string `json:"currency,omitempty"`
+		OriginalMoney float64 `json:"original_money,omitempty"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -49,6 +55,25 @@ func (h *InAccountHandler) InsertInAccountHandler(w http.ResponseWriter, r *http
 		return
 	}
 
+	// 외화 수입은 거래 날짜의 환율로 원화 금액 계산
+	if err := convertRequestMoney(h.CurrencyDB, utils.LedgerIDFromRequest(r), req.Currency, req.Date, req.OriginalMoney, &req.Money); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrInvalidCurrency.WithDetails("원화 환산 실패"))
+		return
+	}
+
+	// 로그인한 사용자가 있으면 요청 본문의 사용자명 대신 사용
+	req.User = utils.ResolveRequestUser(r, req.User)
+
+	// 카테고리/키워드/입금경로를 비워 두면 자동 분류 규칙으로 채움 (입금경로는 이름을 비워 둔 경우에만)
+	var depositPathID int
+	ruleDepositPath := &depositPathID
+	if req.DepositPath != "" {
+		ruleDepositPath = nil
+	}
+	applyCategorizationRules(h.Rules, r, models.RuleSubject{
+		Type: models.AccountTypeIn, Memo: req.Memo, User: req.User, Money: req.Money,
+	}, &req.CategoryID, &req.KeywordName, ruleDepositPath)
+
 	// 입력 검증
 	if req.Date == "" {
 		utils.SendErrorResponse(w, http.StatusBadRequest, models.ErrCodeInvalidInput, "날짜는 필수입니다.")
@@ -66,26 +91,27 @@ func (h *InAccountHandler) InsertInAccountHandler(w http.ResponseWriter, r *http
 		utils.SendErrorResponse(w, http.StatusBadRequest, models.ErrCodeInvalidInput, "카테고리를 선택해주세요.")
 		return
 	}
-	if req.DepositPath == "" {
+	if req.DepositPath == "" && depositPathID <= 0 {
 		utils.SendErrorResponse(w, http.StatusBadRequest, models.ErrCodeInvalidInput, "입금 경로를 선택해주세요.")
 		return
 	}
 
-	// 입금 경로에서 ID 찾기
-	var depositPathID int
-	// 입금 경로 이름으로 ID 찾기 (DepositPath repository를 사용해야 함)
-	// 임시로 간단한 쿼리 사용
-	utils.Debug("입금 경로 조회 시도: %s", req.DepositPath)
-	err := h.DB.(*database.DB).Conn.QueryRow("SELECT id FROM deposit_paths WHERE name = ? AND is_active = 1", req.DepositPath).Scan(&depositPathID)
-	if err != nil {
-		utils.LogError("입금 경로 조회", err)
-		utils.SendErrorResponse(w, http.StatusBadRequest, models.ErrCodeInvalidInput, "유효하지 않은 입금 경로입니다.")
-		return
+	// 입금 경로에서 ID 찾기 (자동 분류 규칙으로 정해진 경우 제외)
+	if req.DepositPath != "" {
+		// 입금 경로 이름으로 ID 찾기 (DepositPath repository를 사용해야 함)
+		// 임시로 간단한 쿼리 사용
+		utils.Debug("입금 경로 조회 시도: %s", req.DepositPath)
+		err := h.DB.(*database.DB).Conn.QueryRow("SELECT id FROM deposit_paths WHERE ledger_id = ? AND name = ? AND is_active = 1", utils.LedgerIDFromRequest(r), req.DepositPath).Scan(&depositPathID)
+		if err != nil {
+			utils.LogError("입금 경로 조회", err)
+			utils.SendErrorResponse(w, http.StatusBadRequest, models.ErrCodeInvalidInput, "유효하지 않은 입금 경로입니다.")
+			return
+		}
+		utils.Debug("입금 경로 ID 찾음: %s -> %d", req.DepositPath, depositPathID)
 	}
-	utils.Debug("입금 경로 ID 찾음: %s -> %d", req.DepositPath, depositPathID)
 
 	// 외래키 참조 데이터 존재 여부 검증
-	if err := h.validateInAccountReferences(req.CategoryID, depositPathID); err != nil {
+	if err := h.validateInAccountReferences(utils.LedgerIDFromRequest(r), req.CategoryID, depositPathID); err != nil {
 		utils.LogError("외래키 검증 (수입)", err)
 		utils.SendErrorResponse(w, http.StatusBadRequest, models.ErrCodeInvalidInput, err.Error())
 		return
@@ -94,7 +120,7 @@ func (h *InAccountHandler) InsertInAccountHandler(w http.ResponseWriter, r *http
 	// 키워드 처리 (있는 경우)
 	var keywordID *int
 	if req.KeywordName != "" {
-		id, err := h.KeywordDB.UpsertKeyword(req.CategoryID, req.KeywordName)
+		id, err := h.KeywordDB.UpsertKeyword(utils.LedgerIDFromRequest(r), req.CategoryID, req.KeywordName)
 		if err != nil {
 			utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "키워드 처리 중 오류 발생")
 			return
@@ -103,15 +129,17 @@ func (h *InAccountHandler) InsertInAccountHandler(w http.ResponseWriter, r *http
 		keywordID = &keywordIDValue
 	}
 
-	// 수입 데이터 삽입
-	err = h.DB.InsertInAccount(req.Date, req.User, req.Money, req.CategoryID, keywordID, depositPathID, req.Memo)
+	// 수입 데이터 삽입 (외화 수입은 외화 정보까지 함께 저장)
+	uuid, err := h.DB.InsertInAccount(utils.LedgerIDFromRequest(r), req.Date, req.User, req.Money, req.CategoryID, keywordID, depositPathID, req.Memo,
+		requestCurrency(req.Currency), req.OriginalMoney)
 	if err != nil {
-		utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "데이터 삽입 중 오류 발생")
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("데이터 삽입 중 오류 발생"))
 		return
 	}
 
 	response := map[string]string{
 		"message": "수입 데이터가 성공적으로 저장되었습니다.",
+		"uuid":    uuid,
 	}
 
 	utils.SendCreatedResponse(w, response)
@@ -130,7 +158,7 @@ func (h *InAccountHandler) GetInAccountByDateHandler(w http.ResponseWriter, r *h
 		return
 	}
 
-	data, err := h.DB.GetInAccountsByDate(date)
+	data, err := h.DB.GetInAccountsByDate(utils.LedgerIDFromRequest(r), date)
 	if err != nil {
 		utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "데이터 조회 중 오류 발생")
 		return
@@ -154,7 +182,7 @@ func (h *InAccountHandler) GetInAccountByMonthHandler(w http.ResponseWriter, r *
 		return
 	}
 
-	inAccounts, err := h.DB.GetInAccountsForMonth(year, month)
+	inAccounts, err := h.DB.GetInAccountsForMonth(utils.LedgerIDFromRequest(r), year, month)
 	if err != nil {
 		utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "수입 데이터 조회 중 오류 발생")
 		return
@@ -163,7 +191,7 @@ func (h *InAccountHandler) GetInAccountByMonthHandler(w http.ResponseWriter, r *
 	utils.SendSuccessResponse(w, inAccounts)
 }
 
-// GetInAccountsByDateRangeHandler 기간별 수입 데이터 조회 핸들러
+// GetInAccountsByDateRangeHandler 기간별 수입 데이터 조회 핸들러 (필터/정렬 파라미터, limit 또는 cursor 가 있으면 페이지 응답)
 func (h *InAccountHandler) GetInAccountsByDateRangeHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		utils.SendErrorResponse(w, http.StatusMethodNotAllowed, models.ErrCodeInvalidInput, "지원되지 않는 메소드입니다.")
@@ -178,16 +206,25 @@ func (h *InAccountHandler) GetInAccountsByDateRangeHandler(w http.ResponseWriter
 		return
 	}
 
-	inAccounts, err := h.DB.GetInAccountsByDateRange(startDate, endDate)
+	opts, ok := parseListOptions(w, r)
+	if !ok {
+		return
+	}
+
+	inAccounts, page, err := h.DB.GetInAccountsByDateRange(utils.LedgerIDFromRequest(r), startDate, endDate, opts)
 	if err != nil {
-		utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "수입 데이터 조회 중 오류 발생")
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("수입 데이터 조회 중 오류 발생"))
 		return
 	}
 
+	if opts.Paginated() {
+		utils.SendSuccessResponse(w, models.InAccountPage{Items: inAccounts, PageInfo: page})
+		return
+	}
 	utils.SendSuccessResponse(w, inAccounts)
 }
 
-// SearchInAccountsByKeywordHandler 키워드로 수입 데이터 검색 핸들러
+// SearchInAccountsByKeywordHandler 키워드로 수입 데이터 검색 핸들러 (필터/정렬 파라미터, limit 또는 cursor 가 있으면 페이지 응답)
 func (h *InAccountHandler) SearchInAccountsByKeywordHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		utils.SendErrorResponse(w, http.StatusMethodNotAllowed, models.ErrCodeInvalidInput, "지원되지 않는 메소드입니다.")
@@ -208,12 +245,21 @@ func (h *InAccountHandler) SearchInAccountsByKeywordHandler(w http.ResponseWrite
 		return
 	}
 
-	inAccounts, err := h.DB.SearchInAccountsByKeyword(keyword, startDate, endDate)
+	opts, ok := parseListOptions(w, r)
+	if !ok {
+		return
+	}
+
+	inAccounts, page, err := h.DB.SearchInAccountsByKeyword(utils.LedgerIDFromRequest(r), keyword, startDate, endDate, opts)
 	if err != nil {
-		utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "키워드 검색 중 오류 발생")
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("키워드 검색 중 오류 발생"))
 		return
 	}
 
+	if opts.Paginated() {
+		utils.SendSuccessResponse(w, models.InAccountPage{Items: inAccounts, PageInfo: page})
+		return
+	}
 	utils.SendSuccessResponse(w, inAccounts)
 }
 
@@ -233,6 +279,9 @@ func (h *InAccountHandler) UpdateInAccountHandler(w http.ResponseWriter, r *http
 		KeywordName string `json:"keyword_name,omitempty"`
 		DepositPath string `json:"deposit_path"`
 		Memo        string `json:"memo"`
+		// 외화 정보 변경 시 통화 코드와 외화 금액 (생략하면 기존 외화 정보 유지, 빈 값/KRW 면 해제)
+		Currency      *string `json:"currency,omitempty"`
+		OriginalMoney float64 `json:"original_money,omitempty"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -240,6 +289,13 @@ func (h *InAccountHandler) UpdateInAccountHandler(w http.ResponseWriter, r *http
 		utils.SendErrorResponse(w, http.StatusBadRequest, models.ErrCodeInvalidInput, "잘못된 요청 데이터입니다.")
 		return
 	}
+	req.User = utils.ResolveRequestUser(r, req.User)
+
+	// 외화 수입은 거래 날짜의 환율로 원화 금액 계산
+	if err := convertRequestMoney(h.CurrencyDB, utils.LedgerIDFromRequest(r), req.Currency, req.Date, req.OriginalMoney, &req.Money); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrInvalidCurrency.WithDetails("원화 환산 실패"))
+		return
+	}
 
 	utils.Debug("수입 업데이트 요청 데이터: %+v", req)
 	utils.Debug("카테고리 ID 상세 확인: CategoryID=%d", req.CategoryID)
@@ -273,7 +329,7 @@ func (h *InAccountHandler) UpdateInAccountHandler(w http.ResponseWriter, r *http
 	// 입금 경로에서 ID 찾기
 	var depositPathID int
 	utils.Debug("입금 경로 조회 시도 (업데이트): %s", req.DepositPath)
-	err := h.DB.(*database.DB).Conn.QueryRow("SELECT id FROM deposit_paths WHERE name = ? AND is_active = 1", req.DepositPath).Scan(&depositPathID)
+	err := h.DB.(*database.DB).Conn.QueryRow("SELECT id FROM deposit_paths WHERE ledger_id = ? AND name = ? AND is_active = 1", utils.LedgerIDFromRequest(r), req.DepositPath).Scan(&depositPathID)
 	if err != nil {
 		utils.LogError("입금 경로 조회 (업데이트)", err)
 		utils.SendErrorResponse(w, http.StatusBadRequest, models.ErrCodeInvalidInput, "유효하지 않은 입금 경로입니다.")
@@ -282,7 +338,7 @@ func (h *InAccountHandler) UpdateInAccountHandler(w http.ResponseWriter, r *http
 	utils.Debug("입금 경로 ID 찾음 (업데이트): %s -> %d", req.DepositPath, depositPathID)
 
 	// 외래키 참조 데이터 존재 여부 검증
-	if err := h.validateInAccountReferences(req.CategoryID, depositPathID); err != nil {
+	if err := h.validateInAccountReferences(utils.LedgerIDFromRequest(r), req.CategoryID, depositPathID); err != nil {
 		utils.LogError("외래키 검증 (수입 업데이트)", err)
 		utils.SendErrorResponse(w, http.StatusBadRequest, models.ErrCodeInvalidInput, err.Error())
 		return
@@ -291,7 +347,7 @@ func (h *InAccountHandler) UpdateInAccountHandler(w http.ResponseWriter, r *http
 	// 키워드 처리 (있는 경우)
 	var keywordID *int
 	if req.KeywordName != "" {
-		id, err := h.KeywordDB.UpsertKeyword(req.CategoryID, req.KeywordName)
+		id, err := h.KeywordDB.UpsertKeyword(utils.LedgerIDFromRequest(r), req.CategoryID, req.KeywordName)
 		if err != nil {
 			utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "키워드 처리 중 오류 발생")
 			return
@@ -301,7 +357,7 @@ func (h *InAccountHandler) UpdateInAccountHandler(w http.ResponseWriter, r *http
 	}
 
 	// UUID 존재 여부 먼저 확인
-	existingAccount, err := h.DB.GetInAccountByUUID(req.UUID)
+	existingAccount, err := h.DB.GetInAccountByUUID(utils.LedgerIDFromRequest(r), req.UUID)
 	if err != nil {
 		utils.LogError("수입 데이터 존재 확인", err)
 		utils.SendErrorResponse(w, http.StatusNotFound, models.ErrCodeNotFound, "해당 UUID의 수입 데이터를 찾을 수 없습니다")
@@ -310,7 +366,7 @@ func (h *InAccountHandler) UpdateInAccountHandler(w http.ResponseWriter, r *http
 	utils.Debug("업데이트 대상 수입 데이터 확인: UUID=%s, 기존 데이터=%+v", req.UUID, existingAccount)
 
 	// 수입 데이터 업데이트
-	err = h.DB.UpdateInAccount(req.UUID, req.Date, req.User, req.Money, req.CategoryID, keywordID, depositPathID, req.Memo)
+	err = h.DB.UpdateInAccount(utils.LedgerIDFromRequest(r), req.UUID, req.Date, req.User, req.Money, req.CategoryID, keywordID, depositPathID, req.Memo)
 	if err != nil {
 		if strings.Contains(err.Error(), "no rows affected") {
 			utils.SendErrorResponse(w, http.StatusNotFound, models.ErrCodeNotFound, "업데이트할 수입 데이터를 찾을 수 없습니다")
@@ -321,6 +377,12 @@ func (h *InAccountHandler) UpdateInAccountHandler(w http.ResponseWriter, r *http
 		return
 	}
 
+	// 외화 정보 반영 (생략 시 기존 외화 금액을 바뀐 날짜의 환율로 다시 환산)
+	if err := saveTransactionCurrency(h.CurrencyDB, utils.LedgerIDFromRequest(r), models.AccountTypeIn, req.UUID, req.Currency, req.OriginalMoney); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("외화 정보 저장 실패"))
+		return
+	}
+
 	response := map[string]string{
 		"message": "수입 데이터가 성공적으로 업데이트되었습니다.",
 	}
@@ -341,7 +403,7 @@ func (h *InAccountHandler) DeleteInAccountHandler(w http.ResponseWriter, r *http
 		return
 	}
 
-	err := h.DB.DeleteInAccount(uuid)
+	err := h.DB.DeleteInAccount(utils.LedgerIDFromRequest(r), uuid)
 	if err != nil {
 		utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "데이터 삭제 중 오류 발생")
 		return
@@ -354,13 +416,13 @@ func (h *InAccountHandler) DeleteInAccountHandler(w http.ResponseWriter, r *http
 	utils.SendSuccessResponse(w, response)
 }
 
-// validateInAccountReferences 수입 데이터의 외래키 참조 검증
-func (h *InAccountHandler) validateInAccountReferences(categoryID, depositPathID int) error {
+// validateInAccountReferences 수입 데이터의 외래키 참조 검증 (같은 가계부의 데이터만 허용)
+func (h *InAccountHandler) validateInAccountReferences(ledgerID, categoryID, depositPathID int) error {
 	db := h.DB.(*database.DB)
 
 	// 카테고리 존재 여부 확인
 	var categoryExists bool
-	err := db.Conn.QueryRow("SELECT 1 FROM categories WHERE id = ? AND type = 'in' AND is_active = 1", categoryID).Scan(&categoryExists)
+	err := db.Conn.QueryRow("SELECT 1 FROM categories WHERE id = ? AND ledger_id = ? AND type = 'in' AND is_active = 1", categoryID, ledgerID).Scan(&categoryExists)
 	if err != nil {
 		utils.Debug("카테고리 검증 실패 (수입): categoryID=%d, err=%v", categoryID, err)
 		return fmt.Errorf("존재하지 않거나 비활성화된 수입 카테고리입니다 (ID: %d)", categoryID)
@@ -368,7 +430,7 @@ func (h *InAccountHandler) validateInAccountReferences(categoryID, depositPathID
 
 	// 입금경로 존재 여부 확인
 	var depositPathExists bool
-	err = db.Conn.QueryRow("SELECT 1 FROM deposit_paths WHERE id = ? AND is_active = 1", depositPathID).Scan(&depositPathExists)
+	err = db.Conn.QueryRow("SELECT 1 FROM deposit_paths WHERE id = ? AND ledger_id = ? AND is_active = 1", depositPathID, ledgerID).Scan(&depositPathExists)
 	if err != nil {
 		utils.Debug("입금경로 검증 실패: depositPathID=%d, err=%v", depositPathID, err)
 		return fmt.Errorf("존재하지 않거나 비활성화된 입금경로입니다 (ID: %d)", depositPathID)
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"net/http"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+type InstallmentHandler struct {
+	DB        InstallmentRepository
+	KeywordDB KeywordRepository
+}
+
+type InstallmentRepository interface {
+	GetInstallments(ledgerID int, activeOnly bool) ([]models.Installment, error)
+	GetInstallmentByUUID(ledgerID int, uuid string) (*models.Installment, error)
+	UpdateInstallment(ledgerID int, uuid string, req models.InstallmentRequest, keywordID *int) error
+	DeleteInstallment(ledgerID int, uuid string) error
+}
+
+// GetInstallmentsHandler 할부 목록 조회 핸들러
+// uuid 지정 시 월별 회차를 포함한 단건, active=true 이면 남은 회차가 있는 할부만 조회
+func (h *InstallmentHandler) GetInstallmentsHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	query := r.URL.Query()
+	ledgerID := utils.LedgerIDFromRequest(r)
+
+	if uuid := query.Get("uuid"); uuid != "" {
+		installment, err := h.DB.GetInstallmentByUUID(ledgerID, uuid)
+		if err != nil {
+			utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("할부 조회 실패"))
+			return
+		}
+		utils.SendSuccessResponse(w, installment)
+		return
+	}
+
+	installments, err := h.DB.GetInstallments(ledgerID, query.Get("active") == "true")
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("할부 목록 조회 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, installments)
+}
+
+// UpdateInstallmentHandler 할부 수정 핸들러 (월별 회차를 새 조건으로 다시 생성)
+func (h *InstallmentHandler) UpdateInstallmentHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPut) {
+		return
+	}
+
+	uuid := r.URL.Query().Get("uuid")
+	if uuid == "" {
+		utils.SendError(w, apiErrors.ErrMissingRequired.WithMessage("uuid는 필수입니다"))
+		return
+	}
+
+	var req models.InstallmentRequest
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+	req.User = utils.ResolveRequestUser(r, req.User)
+
+	ledgerID := utils.LedgerIDFromRequest(r)
+	var keywordID *int
+	if req.KeywordName != "" && req.CategoryID > 0 {
+		id, err := h.KeywordDB.UpsertKeyword(ledgerID, req.CategoryID, req.KeywordName)
+		if err != nil {
+			utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("키워드 처리 실패"))
+			return
+		}
+		keywordIDValue := int(id)
+		keywordID = &keywordIDValue
+	}
+
+	if err := h.DB.UpdateInstallment(ledgerID, uuid, req, keywordID); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("할부 수정 실패"))
+		return
+	}
+
+	updated, err := h.DB.GetInstallmentByUUID(ledgerID, uuid)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("수정된 할부 조회 실패"))
+		return
+	}
+
+	utils.Info("할부 수정: UUID=%s, 원금=%d, %d개월", uuid, updated.Principal, updated.Months)
+	utils.SendSuccessResponse(w, updated)
+}
+
+// DeleteInstallmentHandler 할부 취소 핸들러 (모든 회차 지출 함께 삭제)
+func (h *InstallmentHandler) DeleteInstallmentHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodDelete) {
+		return
+	}
+
+	uuid := r.URL.Query().Get("uuid")
+	if uuid == "" {
+		utils.SendError(w, apiErrors.ErrMissingRequired.WithMessage("uuid는 필수입니다"))
+		return
+	}
+
+	if err := h.DB.DeleteInstallment(utils.LedgerIDFromRequest(r), uuid); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("할부 취소 실패"))
+		return
+	}
+
+	utils.Info("할부 취소: UUID=%s", uuid)
+	utils.SendSuccessResponse(w, utils.CreateSuccessMessage("할부가 취소되었습니다."))
+}
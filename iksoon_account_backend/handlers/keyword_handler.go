@@ -14,11 +14,11 @@ type KeywordHandler struct {
 }
 
 type KeywordRepository interface {
-	GetKeywordSuggestions(categoryID int, query string, limit int) ([]models.KeywordSuggestion, error)
-	GetKeywordsByCategory(categoryID int) ([]models.Keyword, error)
-	UpsertKeyword(categoryID int, name string) (int64, error)
-	CheckKeywordUsage(keywordID int) (bool, error)
-	DeleteKeyword(id int) error
+	GetKeywordSuggestions(ledgerID int, categoryID int, query string, limit int) ([]models.KeywordSuggestion, error)
+	GetKeywordsByCategory(ledgerID int, categoryID int) ([]models.Keyword, error)
+	UpsertKeyword(ledgerID int, categoryID int, name string) (int64, error)
+	CheckKeywordUsage(ledgerID int, keywordID int) (bool, error)
+	DeleteKeyword(ledgerID int, id int) error
 }
 
 // 키워드 자동완성 핸들러
@@ -49,7 +49,7 @@ func (h *KeywordHandler) GetKeywordSuggestionsHandler(w http.ResponseWriter, r *
 		}
 	}
 
-	suggestions, err := h.DB.GetKeywordSuggestions(categoryID, query, limit)
+	suggestions, err := h.DB.GetKeywordSuggestions(utils.LedgerIDFromRequest(r), categoryID, query, limit)
 	if err != nil {
 		utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "키워드 조회 중 오류 발생")
 		return
@@ -77,7 +77,7 @@ func (h *KeywordHandler) GetKeywordsByCategoryHandler(w http.ResponseWriter, r *
 		return
 	}
 
-	keywords, err := h.DB.GetKeywordsByCategory(categoryID)
+	keywords, err := h.DB.GetKeywordsByCategory(utils.LedgerIDFromRequest(r), categoryID)
 	if err != nil {
 		utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "키워드 조회 중 오류 발생")
 		return
@@ -114,7 +114,7 @@ func (h *KeywordHandler) UpsertKeywordHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	keywordID, err := h.DB.UpsertKeyword(req.CategoryID, req.Name)
+	keywordID, err := h.DB.UpsertKeyword(utils.LedgerIDFromRequest(r), req.CategoryID, req.Name)
 	if err != nil {
 		utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "키워드 처리 중 오류 발생")
 		return
@@ -148,7 +148,7 @@ func (h *KeywordHandler) DeleteKeywordHandler(w http.ResponseWriter, r *http.Req
 	}
 
 	// 키워드를 사용하는 데이터가 있는지 확인
-	hasData, err := h.DB.CheckKeywordUsage(keywordID)
+	hasData, err := h.DB.CheckKeywordUsage(utils.LedgerIDFromRequest(r), keywordID)
 	if err != nil {
 		utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "키워드 사용 여부 확인 중 오류 발생")
 		return
@@ -159,7 +159,7 @@ func (h *KeywordHandler) DeleteKeywordHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	err = h.DB.DeleteKeyword(keywordID)
+	err = h.DB.DeleteKeyword(utils.LedgerIDFromRequest(r), keywordID)
 	if err != nil {
 		utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "키워드 삭제 중 오류 발생")
 		return
@@ -0,0 +1,277 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// LedgerHeader 요청 대상 가계부를 지정하는 헤더 이름 (쿼리 파라미터 ledger_id 로도 지정 가능)
+const LedgerHeader = "X-Ledger-ID"
+
+type LedgerHandler struct {
+	DB LedgerRepository
+}
+
+type LedgerRepository interface {
+	GetLedgers() ([]models.Ledger, error)
+	GetLedgersForUser(userID int) ([]models.Ledger, error)
+	GetLedgerByID(id int) (*models.Ledger, error)
+	GetDefaultLedgerForUser(userID int) (int, error)
+	GetLedgerRole(ledgerID, userID int) (string, error)
+	CreateLedger(name string, ownerUserID *int) (int64, error)
+	UpdateLedger(id int, name string) error
+	GetLedgerMembers(ledgerID int) ([]models.LedgerMember, error)
+	AddLedgerMember(ledgerID, userID int, role string) error
+	RemoveLedgerMember(ledgerID, userID int) error
+}
+
+// ledgerFreePaths 가계부 선택 없이 처리하는 경로 (인증, 가계부 목록/생성, DB 전체 백업)
+var ledgerFreePaths = map[string]bool{
+	"/auth/login":             true,
+	"/auth/logout":            true,
+	"/auth/me":                true,
+	"/auth/password":          true,
+	"/health":                 true,
+	"/ledgers":                true,
+	"/ledgers/create":         true,
+	"/admin/backups":          true,
+	"/admin/backups/create":   true,
+	"/admin/backups/download": true,
+	"/admin/backups/delete":   true,
+	"/admin/backups/restore":  true,
+}
+
+// GetLedgersHandler 가계부 목록 조회 핸들러 (로그인 시 소속된 가계부만, 인증 비활성화 시 전체)
+func (h *LedgerHandler) GetLedgersHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	var ledgers []models.Ledger
+	var err error
+	if user := utils.AuthUserFromRequest(r); user != nil {
+		ledgers, err = h.DB.GetLedgersForUser(user.ID)
+	} else {
+		ledgers, err = h.DB.GetLedgers()
+	}
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("가계부 목록 조회 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, ledgers)
+}
+
+// CreateLedgerHandler 가계부 생성 핸들러 (로그인한 사용자가 소유자가 됨)
+func (h *LedgerHandler) CreateLedgerHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	var req struct {
+		Name string `json:"name"`
+	}
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+
+	var ownerUserID *int
+	if user := utils.AuthUserFromRequest(r); user != nil {
+		ownerUserID = &user.ID
+	}
+
+	id, err := h.DB.CreateLedger(req.Name, ownerUserID)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("가계부 생성 실패"))
+		return
+	}
+
+	ledger, err := h.DB.GetLedgerByID(int(id))
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("생성된 가계부 조회 실패"))
+		return
+	}
+
+	utils.Info("가계부 생성: ID=%d, 이름=%s", id, ledger.Name)
+	utils.SendCreatedResponse(w, ledger)
+}
+
+// UpdateLedgerHandler 현재 가계부 이름 수정 핸들러 (소유자만 가능)
+func (h *LedgerHandler) UpdateLedgerHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPut) {
+		return
+	}
+
+	var req struct {
+		Name string `json:"name"`
+	}
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+
+	ledgerID := utils.LedgerIDFromRequest(r)
+	if !h.requireOwner(w, r, ledgerID) {
+		return
+	}
+
+	if err := h.DB.UpdateLedger(ledgerID, req.Name); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("가계부 수정 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, utils.CreateSuccessMessage("가계부가 수정되었습니다."))
+}
+
+// GetLedgerMembersHandler 현재 가계부 멤버 목록 조회 핸들러
+func (h *LedgerHandler) GetLedgerMembersHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	members, err := h.DB.GetLedgerMembers(utils.LedgerIDFromRequest(r))
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("가계부 멤버 조회 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, members)
+}
+
+// AddLedgerMemberHandler 현재 가계부에 멤버 추가 핸들러 (소유자만 가능, 이미 멤버면 역할 변경)
+func (h *LedgerHandler) AddLedgerMemberHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	var req struct {
+		UserID int    `json:"user_id"`
+		Role   string `json:"role"`
+	}
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+	if req.UserID <= 0 {
+		utils.SendError(w, apiErrors.ErrMissingRequired.WithMessage("user_id는 필수입니다"))
+		return
+	}
+
+	ledgerID := utils.LedgerIDFromRequest(r)
+	if !h.requireOwner(w, r, ledgerID) {
+		return
+	}
+
+	if err := h.DB.AddLedgerMember(ledgerID, req.UserID, req.Role); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("가계부 멤버 추가 실패"))
+		return
+	}
+
+	utils.Info("가계부 멤버 추가: 가계부 ID=%d, 사용자 ID=%d", ledgerID, req.UserID)
+	utils.SendSuccessResponse(w, utils.CreateSuccessMessage("가계부 멤버가 추가되었습니다."))
+}
+
+// RemoveLedgerMemberHandler 현재 가계부에서 멤버 제거 핸들러 (소유자 또는 본인 탈퇴)
+func (h *LedgerHandler) RemoveLedgerMemberHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodDelete) {
+		return
+	}
+
+	userID, ok := utils.ParseIDFromQuery(w, r, "user_id")
+	if !ok {
+		return
+	}
+
+	ledgerID := utils.LedgerIDFromRequest(r)
+	if user := utils.AuthUserFromRequest(r); user == nil || user.ID != userID {
+		if !h.requireOwner(w, r, ledgerID) {
+			return
+		}
+	}
+
+	if err := h.DB.RemoveLedgerMember(ledgerID, userID); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("가계부 멤버 제거 실패"))
+		return
+	}
+
+	utils.Info("가계부 멤버 제거: 가계부 ID=%d, 사용자 ID=%d", ledgerID, userID)
+	utils.SendSuccessResponse(w, utils.CreateSuccessMessage("가계부 멤버가 제거되었습니다."))
+}
+
+// requireOwner 로그인한 사용자가 가계부 소유자 또는 관리자인지 확인 (인증 비활성화 시 통과)
+func (h *LedgerHandler) requireOwner(w http.ResponseWriter, r *http.Request, ledgerID int) bool {
+	user := utils.AuthUserFromRequest(r)
+	if user == nil || user.IsAdmin {
+		return true
+	}
+
+	role, err := h.DB.GetLedgerRole(ledgerID, user.ID)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("가계부 권한 조회 실패"))
+		return false
+	}
+	if role != models.LedgerRoleOwner {
+		utils.SendError(w, apiErrors.ErrForbidden.WithMessage("가계부 소유자만 사용할 수 있는 기능입니다"))
+		return false
+	}
+	return true
+}
+
+// Middleware 요청 대상 가계부를 결정해 요청 컨텍스트에 저장
+// X-Ledger-ID 헤더 또는 ledger_id 쿼리로 지정하며, 없으면 로그인 사용자의 첫 가계부(비로그인 시 기본 가계부)를 사용
+// 로그인한 사용자는 소속된 가계부에만 접근 가능
+func (h *LedgerHandler) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ledgerFreePaths[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		user := utils.AuthUserFromRequest(r)
+
+		raw := r.Header.Get(LedgerHeader)
+		if raw == "" {
+			raw = r.URL.Query().Get("ledger_id")
+		}
+
+		var ledgerID int
+		switch {
+		case raw != "":
+			id, err := strconv.Atoi(raw)
+			if err != nil || id <= 0 {
+				utils.SendError(w, apiErrors.ErrInvalidRequest.WithMessage("가계부 ID가 올바르지 않습니다"))
+				return
+			}
+			if _, err := h.DB.GetLedgerByID(id); err != nil {
+				utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("가계부 조회 실패"))
+				return
+			}
+			ledgerID = id
+		case user != nil:
+			id, err := h.DB.GetDefaultLedgerForUser(user.ID)
+			if err != nil {
+				utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("기본 가계부 조회 실패"))
+				return
+			}
+			ledgerID = id
+		default:
+			ledgerID = utils.DefaultLedgerID
+		}
+
+		if user != nil {
+			role, err := h.DB.GetLedgerRole(ledgerID, user.ID)
+			if err != nil {
+				utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("가계부 권한 조회 실패"))
+				return
+			}
+			if role == "" {
+				utils.SendError(w, apiErrors.ErrForbidden.WithMessage("접근 권한이 없는 가계부입니다"))
+				return
+			}
+		}
+
+		next.ServeHTTP(w, utils.WithLedgerID(r, ledgerID))
+	})
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// parseListOptions 목록 조회 공통 파라미터 파싱
+// limit, cursor, sort, order, min_amount, max_amount, category_id, payment_method_id, deposit_path_id, user, tag_id
+// limit 과 cursor 가 모두 없으면 페이지를 나누지 않음 (기존 배열 응답 유지)
+func parseListOptions(w http.ResponseWriter, r *http.Request) (models.ListOptions, bool) {
+	query := r.URL.Query()
+	opts := models.ListOptions{
+		Cursor: strings.TrimSpace(query.Get("cursor")),
+		Sort:   query.Get("sort"),
+		Order:  query.Get("order"),
+		User:   strings.TrimSpace(query.Get("user")),
+	}
+
+	tagID, ok := parseTagFilter(w, r)
+	if !ok {
+		return opts, false
+	}
+	opts.TagID = tagID
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 || limit > models.MaxListLimit {
+			utils.SendError(w, apiErrors.ErrInvalidListOption.WithMessage(fmt.Sprintf("limit은 1 이상 %d 이하여야 합니다", models.MaxListLimit)))
+			return opts, false
+		}
+		opts.Limit = limit
+	} else if opts.Cursor != "" {
+		opts.Limit = models.DefaultListLimit
+	}
+
+	intParams := []struct {
+		name     string
+		target   **int
+		positive bool
+	}{
+		{"min_amount", &opts.MinAmount, false},
+		{"max_amount", &opts.MaxAmount, false},
+		{"category_id", &opts.CategoryID, true},
+		{"payment_method_id", &opts.PaymentMethodID, true},
+		{"deposit_path_id", &opts.DepositPathID, true},
+	}
+	for _, param := range intParams {
+		value := query.Get(param.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 || (param.positive && parsed == 0) {
+			utils.SendError(w, apiErrors.ErrInvalidListOption.WithMessage(param.name+"가 올바르지 않습니다"))
+			return opts, false
+		}
+		*param.target = &parsed
+	}
+
+	return opts, true
+}
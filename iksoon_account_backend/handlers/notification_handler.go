@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// defaultNotificationLogLimit 알림 발송 기록 기본 조회 개수
+const defaultNotificationLogLimit = 100
+
+type NotificationHandler struct {
+	DB     NotificationRepository
+	Alerts BudgetAlertTrigger
+}
+
+type NotificationRepository interface {
+	GetNotificationChannels(ledgerID int) ([]models.NotificationChannel, error)
+	GetNotificationChannelByID(ledgerID, id int) (*models.NotificationChannel, error)
+	CreateNotificationChannel(ledgerID int, req models.NotificationChannelRequest) (int64, error)
+	UpdateNotificationChannel(ledgerID, id int, req models.NotificationChannelRequest) error
+	DeleteNotificationChannel(ledgerID, id int) error
+	QueueTestNotification(ledgerID, channelID int) (int64, error)
+	GetNotificationDeliveries(ledgerID int, status string, limit int) ([]models.NotificationDelivery, error)
+	RetryNotificationDelivery(ledgerID, id int) error
+	GetBudgetAlertThresholds(ledgerID, budgetID int) ([]models.BudgetAlertThreshold, error)
+	CreateBudgetAlertThreshold(ledgerID int, req models.BudgetAlertThresholdRequest) (int64, error)
+	DeleteBudgetAlertThreshold(ledgerID, id int) error
+}
+
+// BudgetAlertTrigger 기준치 알림 확인과 알림 발송을 바로 실행하도록 요청하는 인터페이스 (알림 스케줄러)
+type BudgetAlertTrigger interface {
+	Trigger()
+}
+
+// triggerBudgetAlerts 알림 스케줄러가 설정되어 있으면 기준치 알림 확인 요청
+func triggerBudgetAlerts(alerts BudgetAlertTrigger) {
+	if alerts != nil {
+		alerts.Trigger()
+	}
+}
+
+// GetNotificationChannelsHandler 알림 채널 목록 조회 핸들러
+func (h *NotificationHandler) GetNotificationChannelsHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	channels, err := h.DB.GetNotificationChannels(utils.LedgerIDFromRequest(r))
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("알림 채널 목록 조회 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, channels)
+}
+
+// CreateNotificationChannelHandler 알림 채널 생성 핸들러
+func (h *NotificationHandler) CreateNotificationChannelHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	var req models.NotificationChannelRequest
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+
+	ledgerID := utils.LedgerIDFromRequest(r)
+	id, err := h.DB.CreateNotificationChannel(ledgerID, req)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("알림 채널 생성 실패"))
+		return
+	}
+
+	channel, err := h.DB.GetNotificationChannelByID(ledgerID, int(id))
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("생성된 알림 채널 조회 실패"))
+		return
+	}
+
+	utils.Info("알림 채널 생성: ID=%d, 종류=%s, 이름=%s", channel.ID, channel.Type, channel.Name)
+	utils.SendCreatedResponse(w, channel)
+}
+
+// UpdateNotificationChannelHandler 알림 채널 수정 핸들러 (id 파라미터)
+func (h *NotificationHandler) UpdateNotificationChannelHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPut) {
+		return
+	}
+
+	id, ok := utils.ParseIDFromQuery(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.NotificationChannelRequest
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+
+	ledgerID := utils.LedgerIDFromRequest(r)
+	if err := h.DB.UpdateNotificationChannel(ledgerID, id, req); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("알림 채널 수정 실패"))
+		return
+	}
+
+	channel, err := h.DB.GetNotificationChannelByID(ledgerID, id)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("수정된 알림 채널 조회 실패"))
+		return
+	}
+
+	utils.Info("알림 채널 수정: ID=%d, 종류=%s, 이름=%s, 사용=%v", channel.ID, channel.Type, channel.Name, channel.Enabled)
+	utils.SendSuccessResponse(w, channel)
+}
+
+// DeleteNotificationChannelHandler 알림 채널 삭제 핸들러 (id 파라미터, 발송 기록도 함께 삭제)
+func (h *NotificationHandler) DeleteNotificationChannelHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodDelete) {
+		return
+	}
+
+	id, ok := utils.ParseIDFromQuery(w, r, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.DB.DeleteNotificationChannel(utils.LedgerIDFromRequest(r), id); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("알림 채널 삭제 실패"))
+		return
+	}
+
+	utils.Info("알림 채널 삭제: ID=%d", id)
+	utils.SendSuccessResponse(w, utils.CreateSuccessMessage("알림 채널이 삭제되었습니다."))
+}
+
+// TestNotificationChannelHandler 알림 채널로 테스트 알림 발송 요청 핸들러 (id 파라미터, 결과는 발송 기록에서 확인)
+func (h *NotificationHandler) TestNotificationChannelHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	id, ok := utils.ParseIDFromQuery(w, r, "id")
+	if !ok {
+		return
+	}
+
+	deliveryID, err := h.DB.QueueTestNotification(utils.LedgerIDFromRequest(r), id)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("테스트 알림 등록 실패"))
+		return
+	}
+	triggerBudgetAlerts(h.Alerts)
+
+	utils.Info("테스트 알림 등록: 채널 ID=%d, 발송 ID=%d", id, deliveryID)
+	utils.SendSuccessResponse(w, map[string]interface{}{
+		"message":     "테스트 알림이 발송 대기열에 등록되었습니다.",
+		"delivery_id": deliveryID,
+	})
+}
+
+// GetNotificationLogsHandler 알림 발송 기록 조회 핸들러 (status, limit 파라미터)
+func (h *NotificationHandler) GetNotificationLogsHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	query := r.URL.Query()
+	limit := defaultNotificationLogLimit
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed <= 0 || parsed > 1000 {
+			utils.SendError(w, apiErrors.ErrInvalidRequest.WithMessage("limit은 1 이상 1000 이하여야 합니다"))
+			return
+		}
+		limit = parsed
+	}
+
+	deliveries, err := h.DB.GetNotificationDeliveries(utils.LedgerIDFromRequest(r), query.Get("status"), limit)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("알림 발송 기록 조회 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, deliveries)
+}
+
+// RetryNotificationHandler 발송에 실패한 알림 재시도 핸들러 (id 파라미터)
+func (h *NotificationHandler) RetryNotificationHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	id, ok := utils.ParseIDFromQuery(w, r, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.DB.RetryNotificationDelivery(utils.LedgerIDFromRequest(r), id); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("알림 재시도 등록 실패"))
+		return
+	}
+	triggerBudgetAlerts(h.Alerts)
+
+	utils.Info("알림 재시도 등록: ID=%d", id)
+	utils.SendSuccessResponse(w, utils.CreateSuccessMessage("알림이 다시 발송 대기열에 등록되었습니다."))
+}
+
+// GetBudgetAlertsHandler 기준치 알림 임계값 목록 조회 핸들러 (budget_id 파라미터 선택)
+func (h *NotificationHandler) GetBudgetAlertsHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	budgetID := 0
+	if budgetIDStr := r.URL.Query().Get("budget_id"); budgetIDStr != "" {
+		parsed, err := strconv.Atoi(budgetIDStr)
+		if err != nil {
+			utils.SendError(w, apiErrors.ErrInvalidRequest.WithMessage("잘못된 기준치 ID입니다"))
+			return
+		}
+		budgetID = parsed
+	}
+
+	thresholds, err := h.DB.GetBudgetAlertThresholds(utils.LedgerIDFromRequest(r), budgetID)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("기준치 알림 목록 조회 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, thresholds)
+}
+
+// CreateBudgetAlertHandler 기준치 알림 임계값 생성 핸들러 (이미 넘은 사용률이면 바로 알림 발생)
+func (h *NotificationHandler) CreateBudgetAlertHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	var req models.BudgetAlertThresholdRequest
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+
+	id, err := h.DB.CreateBudgetAlertThreshold(utils.LedgerIDFromRequest(r), req)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("기준치 알림 생성 실패"))
+		return
+	}
+	triggerBudgetAlerts(h.Alerts)
+
+	utils.Info("기준치 알림 생성: ID=%d, 기준치 ID=%d, %s %d%%", id, req.BudgetID, req.Period, req.Percent)
+	utils.SendCreatedResponse(w, map[string]interface{}{
+		"id":      id,
+		"message": "기준치 알림이 생성되었습니다.",
+	})
+}
+
+// DeleteBudgetAlertHandler 기준치 알림 임계값 삭제 핸들러 (id 파라미터)
+func (h *NotificationHandler) DeleteBudgetAlertHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodDelete) {
+		return
+	}
+
+	id, ok := utils.ParseIDFromQuery(w, r, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.DB.DeleteBudgetAlertThreshold(utils.LedgerIDFromRequest(r), id); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("기준치 알림 삭제 실패"))
+		return
+	}
+
+	utils.Info("기준치 알림 삭제: ID=%d", id)
+	utils.SendSuccessResponse(w, utils.CreateSuccessMessage("기준치 알림이 삭제되었습니다."))
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -15,196 +16,261 @@ import (
 )
 
 type OutAccountHandler struct {
-	DB        OutAccountRepository
-	KeywordDB KeywordRepository
+	DB         OutAccountRepository
+	KeywordDB  KeywordRepository
+	CurrencyDB CurrencyRepository
+	Alerts     BudgetAlertTrigger        // 지출 등록/수정 후 기준치 알림 확인 요청 (nil 이면 스케줄러 주기에만 확인)
+	Rules      CategorizationRuleMatcher // 비워 둔 카테고리/키워드/결제수단을 자동 분류 규칙으로 채움 (nil 이면 사용 안 함)
 }
 
 type OutAccountRepository interface {
-	InsertOutAccount(date, user string, money, categoryID int, keywordID *int, paymentMethodID int, memo string) error
-	GetOutAccountsByDate(date string) ([]models.OutAccount, error)
-	GetOutAccountsForMonth(year, month string) ([]models.OutAccount, error)
-	GetOutAccountsByDateRange(startDate, endDate string) ([]models.OutAccount, error)
-	GetOutAccountsByPaymentMethod(paymentMethodID int, startDate, endDate string) ([]models.OutAccount, error)
-	GetOutAccountsByUser(userName, startDate, endDate string) ([]models.OutAccount, error)
-	SearchOutAccountsByKeyword(keyword, startDate, endDate string) ([]models.OutAccount, error)
-	UpdateOutAccount(uuid, date, user string, money, categoryID int, keywordID *int, paymentMethodID int, memo string) error
-	DeleteOutAccount(uuid string) error
-	GetOutAccountByUUID(uuid string) (*models.OutAccount, error)
-	GetBudgetUsage(categoryID int, userName string, currentDate time.Time) (*models.BudgetUsage, error)
+	InsertOutAccount(ledgerID int, date, user string, money, categoryID int, keywordID *int, paymentMethodID int, memo, currency string, originalMoney float64) (string, error)
+	GetOutAccountsByDate(ledgerID int, date string) ([]models.OutAccount, error)
+	GetOutAccountsForMonth(ledgerID int, year, month string) ([]models.OutAccount, error)
+	GetOutAccountsByDateRange(ledgerID int, startDate, endDate string, opts models.ListOptions) ([]models.OutAccount, models.PageInfo, error)
+	GetOutAccountsByPaymentMethod(ledgerID int, paymentMethodID int, startDate, endDate string) ([]models.OutAccount, error)
+	GetOutAccountsByUser(ledgerID int, userName, startDate, endDate string, opts models.ListOptions) ([]models.OutAccount, models.PageInfo, error)
+	SearchOutAccountsByKeyword(ledgerID int, keyword, startDate, endDate string, opts models.ListOptions) ([]models.OutAccount, models.PageInfo, error)
+	UpdateOutAccount(ledgerID int, uuid, date, user string, money, categoryID int, keywordID *int, paymentMethodID int, memo string) error
+	DeleteOutAccount(ledgerID int, uuid string) error
+	GetOutAccountByUUID(ledgerID int, uuid string) (*models.OutAccount, error)
+	GetBudgetUsage(ledgerID int, categoryID int, userName string, currentDate time.Time) (*models.BudgetUsage, error)
+	InsertInstallment(ledgerID int, req models.InstallmentRequest, keywordID *int) (string, error)
+	GetInstallmentByUUID(ledgerID int, uuid string) (*models.Installment, error)
+	InsertSplitOutAccount(ledgerID int, date, user string, money, paymentMethodID int, memo string, splits []models.OutAccountSplitRequest) (string, error)
 }
 
-// InsertOutAccountHandler 새로운 구조의 지출 데이터 삽입 핸들러
-func (h *OutAccountHandler) InsertOutAccountHandler(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		utils.SendError(w, apiErrors.ErrInvalidRequest.WithMessage("지원되지 않는 메소드입니다"))
-		return
-	}
+// outAccountInsertRequest 지출 등록 요청 (일반/할부/분할/외화 지출 공통)
+type outAccountInsertRequest struct {
+	Date            string `json:"date"`
+	User            string `json:"user"`
+	Money           int    `json:"money"`
+	CategoryID      int    `json:"category_id"`
+	KeywordName     string `json:"keyword_name,omitempty"`
+	PaymentMethodID int    `json:"payment_method_id"`
+	Memo            string `json:"memo"`
+	// 2 이상이면 할부 구매로 등록 (월별 회차 지출 생성)
+	InstallmentMonths       int     `json:"installment_months,omitempty"`
+	InstallmentInterestRate float64 `json:"installment_interest_rate,omitempty"`
+	// 2개 이상이면 분할 지출로 등록 (항목별 카테고리/키워드/금액/메모, 합계 = money)
+	Splits []models.OutAccountSplitRequest `json:"splits,omitempty"`
+	// 외화 지출이면 통화 코드와 외화 금액 (money 는 거래 날짜의 환율로 계산)
+	Currency      *string `json:"currency,omitempty"`
+	OriginalMoney float64 `json:"original_money,omitempty"`
+}
 
-	var req struct {
-		Date            string `json:"date"`
-		User            string `json:"user"`
-		Money           int    `json:"money"`
-		CategoryID      int    `json:"category_id"`
-		KeywordName     string `json:"keyword_name,omitempty"`
-		PaymentMethodID int    `json:"payment_method_id"`
-		Memo            string `json:"memo"`
-	}
+// outAccountInsertResult 지출 등록 결과 (분할 지출이면 OutAccount, 할부 지출이면 Installment 가 채워짐)
+type outAccountInsertResult struct {
+	Message     string
+	UUID        string
+	OutAccount  *models.OutAccount
+	Installment *models.Installment
+}
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.LogError("JSON 디코드", err)
-		utils.SendError(w, apiErrors.ErrInvalidJSON)
+// InsertOutAccountHandler 새로운 구조의 지출 데이터 삽입 핸들러
+func (h *OutAccountHandler) InsertOutAccountHandler(w http.ResponseWriter, r *http.Request) {
+	_, result, ok := h.parseAndInsertOutAccount(w, r)
+	if !ok {
 		return
 	}
 
-	utils.Debug("지출 데이터 삽입 요청: %+v", req)
-
-	// 외래키 참조 데이터 존재 여부 검증
-	if err := h.validateOutAccountReferences(req.CategoryID, req.PaymentMethodID); err != nil {
-		utils.LogError("외래키 검증", err)
-		utils.SendErrorResponse(w, http.StatusBadRequest, models.ErrCodeInvalidInput, err.Error())
-		return
+	triggerBudgetAlerts(h.Alerts)
+	switch {
+	case result.OutAccount != nil:
+		utils.SendCreatedResponse(w, map[string]interface{}{
+			"message":     result.Message,
+			"out_account": result.OutAccount,
+		})
+	case result.Installment != nil:
+		utils.SendCreatedResponse(w, map[string]interface{}{
+			"message":     result.Message,
+			"installment": result.Installment,
+		})
+	default:
+		utils.SendCreatedResponse(w, map[string]string{
+			"message": result.Message,
+			"uuid":    result.UUID,
+		})
 	}
+}
 
-	// 입력 검증
-	if req.Date == "" {
-		utils.SendError(w, apiErrors.ErrMissingRequired.WithMessage("날짜는 필수입니다"))
-		return
-	}
-	if req.User == "" {
-		utils.SendError(w, apiErrors.ErrMissingRequired.WithMessage("사용자는 필수입니다"))
-		return
-	}
-	if req.Money <= 0 {
-		utils.SendErrorResponse(w, http.StatusBadRequest, models.ErrCodeInvalidInput, "금액은 0보다 커야 합니다.")
-		return
-	}
-	if req.CategoryID <= 0 {
-		utils.SendErrorResponse(w, http.StatusBadRequest, models.ErrCodeInvalidInput, "카테고리를 선택해주세요.")
-		return
-	}
-	if req.PaymentMethodID <= 0 {
-		utils.SendErrorResponse(w, http.StatusBadRequest, models.ErrCodeInvalidInput, "결제수단을 선택해주세요.")
+// InsertOutAccountWithBudgetHandler 지출 데이터 삽입 후 기준치 정보 반환
+// (할부는 이번 달 회차만, 분할 지출은 해당 카테고리 항목 금액만 기준치에 반영됨)
+func (h *OutAccountHandler) InsertOutAccountWithBudgetHandler(w http.ResponseWriter, r *http.Request) {
+	req, result, ok := h.parseAndInsertOutAccount(w, r)
+	if !ok {
 		return
 	}
 
-	// 키워드 처리 (있는 경우)
-	var keywordID *int
-	if req.KeywordName != "" {
-		id, err := h.KeywordDB.UpsertKeyword(req.CategoryID, req.KeywordName)
-		if err != nil {
-			utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "키워드 처리 중 오류 발생")
-			return
-		}
-		keywordIDValue := int(id)
-		keywordID = &keywordIDValue
+	// 기준치 정보 조회
+	parsedDate, err := utils.ParseDateTimeKST(req.Date)
+	if err != nil {
+		parsedDate = time.Now()
 	}
 
-	// 지출 데이터 삽입
-	err := h.DB.InsertOutAccount(req.Date, req.User, req.Money, req.CategoryID, keywordID, req.PaymentMethodID, req.Memo)
+	budgetUsage, err := h.DB.GetBudgetUsage(utils.LedgerIDFromRequest(r), req.CategoryID, req.User, parsedDate)
 	if err != nil {
-		utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "데이터 삽입 중 오류 발생")
-		return
+		// 기준치 조회 오류는 무시하고 성공 메시지만 반환
+		utils.LogError("기준치 조회", err)
+		budgetUsage = nil
 	}
 
-	response := map[string]string{
-		"message": "지출 데이터가 성공적으로 저장되었습니다.",
+	response := models.OutAccountWithBudget{
+		Message:     result.Message,
+		BudgetUsage: budgetUsage,
 	}
 
+	triggerBudgetAlerts(h.Alerts)
 	utils.SendCreatedResponse(w, response)
 }
 
-// InsertOutAccountWithBudgetHandler 지출 데이터 삽입 후 기준치 정보 반환
-func (h *OutAccountHandler) InsertOutAccountWithBudgetHandler(w http.ResponseWriter, r *http.Request) {
+// parseAndInsertOutAccount 지출 등록 요청을 읽고 검증한 뒤 일반/할부/분할 지출로 저장 (두 등록 핸들러 공통)
+// 오류 응답을 이미 보냈으면 false 반환
+func (h *OutAccountHandler) parseAndInsertOutAccount(w http.ResponseWriter, r *http.Request) (*outAccountInsertRequest, *outAccountInsertResult, bool) {
 	if r.Method != http.MethodPost {
 		utils.SendError(w, apiErrors.ErrInvalidRequest.WithMessage("지원되지 않는 메소드입니다"))
-		return
-	}
-
-	var req struct {
-		Date            string `json:"date"`
-		User            string `json:"user"`
-		Money           int    `json:"money"`
-		CategoryID      int    `json:"category_id"`
-		KeywordName     string `json:"keyword_name,omitempty"`
-		PaymentMethodID int    `json:"payment_method_id"`
-		Memo            string `json:"memo"`
+		return nil, nil, false
 	}
 
+	var req outAccountInsertRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		utils.LogError("JSON 디코드", err)
 		utils.SendError(w, apiErrors.ErrInvalidJSON)
-		return
+		return nil, nil, false
+	}
+
+	utils.Debug("지출 데이터 삽입 요청 (%s): %+v", r.URL.Path, req)
+	ledgerID := utils.LedgerIDFromRequest(r)
+
+	// 외화 지출은 거래 날짜의 환율로 원화 금액 계산 (분할/할부 불가)
+	if isForeignCurrency(req.Currency) && (len(req.Splits) > 0 || req.InstallmentMonths > 1) {
+		utils.SendError(w, apiErrors.ErrInvalidCurrency.WithMessage("외화 지출은 분할/할부로 등록할 수 없습니다"))
+		return nil, nil, false
+	}
+	if err := convertRequestMoney(h.CurrencyDB, ledgerID, req.Currency, req.Date, req.OriginalMoney, &req.Money); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrInvalidCurrency.WithDetails("원화 환산 실패"))
+		return nil, nil, false
+	}
+
+	// 로그인한 사용자가 있으면 요청 본문의 사용자명 대신 사용
+	req.User = utils.ResolveRequestUser(r, req.User)
+
+	// 카테고리/키워드/결제수단을 비워 두면 자동 분류 규칙으로 채움 (분할 지출은 항목별로 지정)
+	if len(req.Splits) == 0 {
+		applyCategorizationRules(h.Rules, r, models.RuleSubject{
+			Type: models.AccountTypeOut, Memo: req.Memo, User: req.User, Money: req.Money,
+		}, &req.CategoryID, &req.KeywordName, &req.PaymentMethodID)
 	}
 
-	utils.Debug("기준치 포함 지출 데이터 삽입 요청: %+v", req)
+	// 분할 지출은 첫 항목의 카테고리로 참조 검증 (항목별 카테고리는 저장 시 검증)
+	if len(req.Splits) > 0 && req.CategoryID <= 0 {
+		req.CategoryID = req.Splits[0].CategoryID
+	}
 
 	// 외래키 참조 데이터 존재 여부 검증
-	if err := h.validateOutAccountReferences(req.CategoryID, req.PaymentMethodID); err != nil {
+	if err := h.validateOutAccountReferences(ledgerID, req.CategoryID, req.PaymentMethodID); err != nil {
 		utils.LogError("외래키 검증", err)
 		utils.SendErrorResponse(w, http.StatusBadRequest, models.ErrCodeInvalidInput, err.Error())
-		return
+		return nil, nil, false
 	}
 
 	// 입력 검증
 	if req.Date == "" {
 		utils.SendError(w, apiErrors.ErrMissingRequired.WithMessage("날짜는 필수입니다"))
-		return
+		return nil, nil, false
 	}
 	if req.User == "" {
 		utils.SendError(w, apiErrors.ErrMissingRequired.WithMessage("사용자는 필수입니다"))
-		return
+		return nil, nil, false
 	}
 	if req.Money <= 0 {
 		utils.SendErrorResponse(w, http.StatusBadRequest, models.ErrCodeInvalidInput, "금액은 0보다 커야 합니다.")
-		return
+		return nil, nil, false
 	}
 	if req.CategoryID <= 0 {
 		utils.SendErrorResponse(w, http.StatusBadRequest, models.ErrCodeInvalidInput, "카테고리를 선택해주세요.")
-		return
+		return nil, nil, false
 	}
 	if req.PaymentMethodID <= 0 {
 		utils.SendErrorResponse(w, http.StatusBadRequest, models.ErrCodeInvalidInput, "결제수단을 선택해주세요.")
-		return
+		return nil, nil, false
+	}
+
+	// 분할 지출은 항목별 카테고리/키워드로 기록
+	if len(req.Splits) > 0 {
+		created, err := h.insertSplitOutAccount(r, req.Date, req.User, req.Money, req.PaymentMethodID, req.Memo, req.InstallmentMonths, req.Splits)
+		if err != nil {
+			utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("분할 지출 등록 실패"))
+			return nil, nil, false
+		}
+		return &req, &outAccountInsertResult{Message: "분할 지출이 성공적으로 저장되었습니다.", UUID: created.UUID, OutAccount: created}, true
 	}
 
 	// 키워드 처리 (있는 경우)
 	var keywordID *int
 	if req.KeywordName != "" {
-		id, err := h.KeywordDB.UpsertKeyword(req.CategoryID, req.KeywordName)
+		id, err := h.KeywordDB.UpsertKeyword(ledgerID, req.CategoryID, req.KeywordName)
 		if err != nil {
 			utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "키워드 처리 중 오류 발생")
-			return
+			return nil, nil, false
 		}
 		keywordIDValue := int(id)
 		keywordID = &keywordIDValue
 	}
 
-	// 지출 데이터 삽입
-	err := h.DB.InsertOutAccount(req.Date, req.User, req.Money, req.CategoryID, keywordID, req.PaymentMethodID, req.Memo)
-	if err != nil {
-		utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "데이터 삽입 중 오류 발생")
-		return
+	// 할부 구매는 원금을 월별 회차로 나누어 기록
+	if req.InstallmentMonths > 1 {
+		installment, err := h.insertInstallment(r, models.InstallmentRequest{
+			Date: req.Date, User: req.User, Money: req.Money, CategoryID: req.CategoryID, PaymentMethodID: req.PaymentMethodID,
+			Memo: req.Memo, Months: req.InstallmentMonths, InterestRate: req.InstallmentInterestRate,
+		}, keywordID)
+		if err != nil {
+			utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("할부 등록 실패"))
+			return nil, nil, false
+		}
+		return &req, &outAccountInsertResult{Message: "할부 지출이 성공적으로 저장되었습니다.", UUID: installment.UUID, Installment: installment}, true
 	}
 
-	// 기준치 정보 조회
-	parsedDate, err := utils.ParseDateTimeKST(req.Date)
+	// 지출 데이터 삽입 (외화 지출은 외화 정보까지 함께 저장)
+	uuid, err := h.DB.InsertOutAccount(ledgerID, req.Date, req.User, req.Money, req.CategoryID, keywordID, req.PaymentMethodID, req.Memo,
+		requestCurrency(req.Currency), req.OriginalMoney)
 	if err != nil {
-		parsedDate = time.Now()
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("데이터 삽입 중 오류 발생"))
+		return nil, nil, false
 	}
+	return &req, &outAccountInsertResult{Message: "지출 데이터가 성공적으로 저장되었습니다.", UUID: uuid}, true
+}
 
-	budgetUsage, err := h.DB.GetBudgetUsage(req.CategoryID, req.User, parsedDate)
+// insertInstallment 할부 구매 등록 후 회차 포함 할부 정보 조회
+func (h *OutAccountHandler) insertInstallment(r *http.Request, req models.InstallmentRequest, keywordID *int) (*models.Installment, error) {
+	ledgerID := utils.LedgerIDFromRequest(r)
+	uuid, err := h.DB.InsertInstallment(ledgerID, req, keywordID)
 	if err != nil {
-		// 기준치 조회 오류는 무시하고 성공 메시지만 반환
-		utils.LogError("기준치 조회", err)
-		budgetUsage = nil
+		return nil, err
 	}
 
-	response := models.OutAccountWithBudget{
-		Message:     "지출 데이터가 성공적으로 저장되었습니다.",
-		BudgetUsage: budgetUsage,
+	utils.Info("할부 지출 등록: UUID=%s, 원금=%d, %d개월, 연 이자율=%.2f%%", uuid, req.Money, req.Months, req.InterestRate)
+	return h.DB.GetInstallmentByUUID(ledgerID, uuid)
+}
+
+// insertSplitOutAccount 분할 지출 등록 후 항목 포함 지출 조회
+func (h *OutAccountHandler) insertSplitOutAccount(r *http.Request, date, user string, money, paymentMethodID int, memo string, installmentMonths int, splits []models.OutAccountSplitRequest) (*models.OutAccount, error) {
+	if installmentMonths > 1 {
+		return nil, apiErrors.ErrInvalidSplit.WithMessage("할부 지출은 분할할 수 없습니다")
 	}
 
-	utils.SendCreatedResponse(w, response)
+	ledgerID := utils.LedgerIDFromRequest(r)
+	if err := resolveSplitKeywords(h.KeywordDB, ledgerID, splits); err != nil {
+		return nil, err
+	}
+
+	uuid, err := h.DB.InsertSplitOutAccount(ledgerID, date, user, money, paymentMethodID, memo, splits)
+	if err != nil {
+		return nil, err
+	}
+
+	utils.Info("분할 지출 등록: UUID=%s, 금액=%d, 항목 %d개", uuid, money, len(splits))
+	return h.DB.GetOutAccountByUUID(ledgerID, uuid)
 }
 
 // 새로운 구조의 지출 데이터 조회 핸들러 (특정 날짜)
@@ -220,7 +286,7 @@ func (h *OutAccountHandler) GetOutAccountByDateHandler(w http.ResponseWriter, r
 		return
 	}
 
-	data, err := h.DB.GetOutAccountsByDate(date)
+	data, err := h.DB.GetOutAccountsByDate(utils.LedgerIDFromRequest(r), date)
 	if err != nil {
 		utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "데이터 조회 중 오류 발생")
 		return
@@ -244,7 +310,7 @@ func (h *OutAccountHandler) GetOutAccountByMonthHandler(w http.ResponseWriter, r
 		return
 	}
 
-	outAccounts, err := h.DB.GetOutAccountsForMonth(year, month)
+	outAccounts, err := h.DB.GetOutAccountsForMonth(utils.LedgerIDFromRequest(r), year, month)
 	if err != nil {
 		utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "지출 데이터 조회 중 오류 발생")
 		return
@@ -253,7 +319,7 @@ func (h *OutAccountHandler) GetOutAccountByMonthHandler(w http.ResponseWriter, r
 	utils.SendSuccessResponse(w, outAccounts)
 }
 
-// GetOutAccountsByDateRangeHandler 기간별 지출 데이터 조회 핸들러
+// GetOutAccountsByDateRangeHandler 기간별 지출 데이터 조회 핸들러 (필터/정렬 파라미터, limit 또는 cursor 가 있으면 페이지 응답)
 func (h *OutAccountHandler) GetOutAccountsByDateRangeHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		utils.SendErrorResponse(w, http.StatusMethodNotAllowed, models.ErrCodeInvalidInput, "지원되지 않는 메소드입니다.")
@@ -268,16 +334,25 @@ func (h *OutAccountHandler) GetOutAccountsByDateRangeHandler(w http.ResponseWrit
 		return
 	}
 
-	outAccounts, err := h.DB.GetOutAccountsByDateRange(startDate, endDate)
+	opts, ok := parseListOptions(w, r)
+	if !ok {
+		return
+	}
+
+	outAccounts, page, err := h.DB.GetOutAccountsByDateRange(utils.LedgerIDFromRequest(r), startDate, endDate, opts)
 	if err != nil {
-		utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "지출 데이터 조회 중 오류 발생")
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("지출 데이터 조회 중 오류 발생"))
 		return
 	}
 
+	if opts.Paginated() {
+		utils.SendSuccessResponse(w, models.OutAccountPage{Items: outAccounts, PageInfo: page})
+		return
+	}
 	utils.SendSuccessResponse(w, outAccounts)
 }
 
-// SearchOutAccountsByKeywordHandler 키워드로 지출 데이터 검색 핸들러
+// SearchOutAccountsByKeywordHandler 키워드로 지출 데이터 검색 핸들러 (필터/정렬 파라미터, limit 또는 cursor 가 있으면 페이지 응답)
 func (h *OutAccountHandler) SearchOutAccountsByKeywordHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		utils.SendErrorResponse(w, http.StatusMethodNotAllowed, models.ErrCodeInvalidInput, "지원되지 않는 메소드입니다.")
@@ -298,12 +373,21 @@ func (h *OutAccountHandler) SearchOutAccountsByKeywordHandler(w http.ResponseWri
 		return
 	}
 
-	outAccounts, err := h.DB.SearchOutAccountsByKeyword(keyword, startDate, endDate)
+	opts, ok := parseListOptions(w, r)
+	if !ok {
+		return
+	}
+
+	outAccounts, page, err := h.DB.SearchOutAccountsByKeyword(utils.LedgerIDFromRequest(r), keyword, startDate, endDate, opts)
 	if err != nil {
-		utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "키워드 검색 중 오류 발생")
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("키워드 검색 중 오류 발생"))
 		return
 	}
 
+	if opts.Paginated() {
+		utils.SendSuccessResponse(w, models.OutAccountPage{Items: outAccounts, PageInfo: page})
+		return
+	}
 	utils.SendSuccessResponse(w, outAccounts)
 }
 
@@ -323,6 +407,9 @@ func (h *OutAccountHandler) UpdateOutAccountHandler(w http.ResponseWriter, r *ht
 		KeywordName     string `json:"keyword_name,omitempty"`
 		PaymentMethodID int    `json:"payment_method_id"`
 		Memo            string `json:"memo"`
+		// 외화 정보 변경 시 통화 코드와 외화 금액 (생략하면 기존 외화 정보 유지, 빈 값/KRW 면 해제)
+		Currency      *string `json:"currency,omitempty"`
+		OriginalMoney float64 `json:"original_money,omitempty"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -330,9 +417,16 @@ func (h *OutAccountHandler) UpdateOutAccountHandler(w http.ResponseWriter, r *ht
 		utils.SendErrorResponse(w, http.StatusBadRequest, models.ErrCodeInvalidInput, "잘못된 요청 데이터입니다.")
 		return
 	}
+	req.User = utils.ResolveRequestUser(r, req.User)
 
 	utils.Debug("지출 업데이트 요청 데이터: %+v", req)
 
+	// 외화 지출은 거래 날짜의 환율로 원화 금액 계산
+	if err := convertRequestMoney(h.CurrencyDB, utils.LedgerIDFromRequest(r), req.Currency, req.Date, req.OriginalMoney, &req.Money); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrInvalidCurrency.WithDetails("원화 환산 실패"))
+		return
+	}
+
 	// 입력 검증
 	if req.UUID == "" {
 		utils.SendErrorResponse(w, http.StatusBadRequest, models.ErrCodeInvalidInput, "UUID는 필수입니다.")
@@ -362,7 +456,7 @@ func (h *OutAccountHandler) UpdateOutAccountHandler(w http.ResponseWriter, r *ht
 	// 키워드 처리 (있는 경우)
 	var keywordID *int
 	if req.KeywordName != "" {
-		id, err := h.KeywordDB.UpsertKeyword(req.CategoryID, req.KeywordName)
+		id, err := h.KeywordDB.UpsertKeyword(utils.LedgerIDFromRequest(r), req.CategoryID, req.KeywordName)
 		if err != nil {
 			utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "키워드 처리 중 오류 발생")
 			return
@@ -372,7 +466,7 @@ func (h *OutAccountHandler) UpdateOutAccountHandler(w http.ResponseWriter, r *ht
 	}
 
 	// UUID 존재 여부 먼저 확인
-	existingAccount, err := h.DB.GetOutAccountByUUID(req.UUID)
+	existingAccount, err := h.DB.GetOutAccountByUUID(utils.LedgerIDFromRequest(r), req.UUID)
 	if err != nil {
 		utils.LogError("지출 데이터 존재 확인", err)
 		utils.SendErrorResponse(w, http.StatusNotFound, models.ErrCodeNotFound, "해당 UUID의 지출 데이터를 찾을 수 없습니다")
@@ -381,21 +475,33 @@ func (h *OutAccountHandler) UpdateOutAccountHandler(w http.ResponseWriter, r *ht
 	utils.Debug("업데이트 대상 지출 데이터 확인: UUID=%s, 기존 데이터=%+v", req.UUID, existingAccount)
 
 	// 지출 데이터 업데이트
-	err = h.DB.UpdateOutAccount(req.UUID, req.Date, req.User, req.Money, req.CategoryID, keywordID, req.PaymentMethodID, req.Memo)
+	err = h.DB.UpdateOutAccount(utils.LedgerIDFromRequest(r), req.UUID, req.Date, req.User, req.Money, req.CategoryID, keywordID, req.PaymentMethodID, req.Memo)
 	if err != nil {
 		if strings.Contains(err.Error(), "no rows affected") {
 			utils.SendErrorResponse(w, http.StatusNotFound, models.ErrCodeNotFound, "업데이트할 지출 데이터를 찾을 수 없습니다")
 			return
 		}
+		var code apiErrors.ErrorCode
+		if errors.As(err, &code) {
+			utils.SendError(w, code)
+			return
+		}
 		utils.LogError("지출 데이터 업데이트", err)
 		utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "데이터 업데이트 중 오류 발생")
 		return
 	}
 
+	// 외화 정보 반영 (생략 시 기존 외화 금액을 바뀐 날짜의 환율로 다시 환산)
+	if err := saveTransactionCurrency(h.CurrencyDB, utils.LedgerIDFromRequest(r), models.AccountTypeOut, req.UUID, req.Currency, req.OriginalMoney); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("외화 정보 저장 실패"))
+		return
+	}
+
 	response := map[string]string{
 		"message": "지출 데이터가 성공적으로 업데이트되었습니다.",
 	}
 
+	triggerBudgetAlerts(h.Alerts)
 	utils.SendSuccessResponse(w, response)
 }
 
@@ -412,8 +518,13 @@ func (h *OutAccountHandler) DeleteOutAccountHandler(w http.ResponseWriter, r *ht
 		return
 	}
 
-	err := h.DB.DeleteOutAccount(uuid)
+	err := h.DB.DeleteOutAccount(utils.LedgerIDFromRequest(r), uuid)
 	if err != nil {
+		var code apiErrors.ErrorCode
+		if errors.As(err, &code) {
+			utils.SendError(w, code)
+			return
+		}
 		utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "데이터 삭제 중 오류 발생")
 		return
 	}
@@ -425,13 +536,13 @@ func (h *OutAccountHandler) DeleteOutAccountHandler(w http.ResponseWriter, r *ht
 	utils.SendSuccessResponse(w, response)
 }
 
-// validateOutAccountReferences 지출 데이터의 외래키 참조 검증
-func (h *OutAccountHandler) validateOutAccountReferences(categoryID, paymentMethodID int) error {
+// validateOutAccountReferences 지출 데이터의 외래키 참조 검증 (같은 가계부의 데이터만 허용)
+func (h *OutAccountHandler) validateOutAccountReferences(ledgerID, categoryID, paymentMethodID int) error {
 	db := h.DB.(*database.DB)
 
 	// 카테고리 존재 여부 확인
 	var categoryExists bool
-	err := db.Conn.QueryRow("SELECT 1 FROM categories WHERE id = ? AND type = 'out' AND is_active = 1", categoryID).Scan(&categoryExists)
+	err := db.Conn.QueryRow("SELECT 1 FROM categories WHERE id = ? AND ledger_id = ? AND type = 'out' AND is_active = 1", categoryID, ledgerID).Scan(&categoryExists)
 	if err != nil {
 		utils.Debug("카테고리 검증 실패: categoryID=%d, err=%v", categoryID, err)
 		return fmt.Errorf("존재하지 않거나 비활성화된 지출 카테고리입니다 (ID: %d)", categoryID)
@@ -439,7 +550,7 @@ func (h *OutAccountHandler) validateOutAccountReferences(categoryID, paymentMeth
 
 	// 결제수단 존재 여부 확인
 	var paymentMethodExists bool
-	err = db.Conn.QueryRow("SELECT 1 FROM payment_methods WHERE id = ? AND is_active = 1", paymentMethodID).Scan(&paymentMethodExists)
+	err = db.Conn.QueryRow("SELECT 1 FROM payment_methods WHERE id = ? AND ledger_id = ? AND is_active = 1", paymentMethodID, ledgerID).Scan(&paymentMethodExists)
 	if err != nil {
 		utils.Debug("결제수단 검증 실패: paymentMethodID=%d, err=%v", paymentMethodID, err)
 		return fmt.Errorf("존재하지 않거나 비활성화된 결제수단입니다 (ID: %d)", paymentMethodID)
@@ -560,7 +671,7 @@ func (h *OutAccountHandler) GetOutAccountsByPaymentMethodHandler(w http.Response
 	calculatedEndDate := utils.FormatDateKST(end)
 
 	// 지출 내역 조회
-	accounts, err := h.DB.GetOutAccountsByPaymentMethod(paymentMethodID, calculatedStartDate, calculatedEndDate)
+	accounts, err := h.DB.GetOutAccountsByPaymentMethod(utils.LedgerIDFromRequest(r), paymentMethodID, calculatedStartDate, calculatedEndDate)
 	if err != nil {
 		utils.LogError("결제수단별 지출 내역 조회", err)
 		utils.SendErrorResponse(w, http.StatusInternalServerError, "DATABASE_ERROR", "결제수단별 지출 내역 조회 중 오류 발생")
@@ -578,7 +689,7 @@ func (h *OutAccountHandler) GetOutAccountsByPaymentMethodHandler(w http.Response
 	utils.SendSuccessResponse(w, response)
 }
 
-// GetOutAccountsByUserHandler 사용자별 지출 내역 조회 핸들러
+// GetOutAccountsByUserHandler 사용자별 지출 내역 조회 핸들러 (필터/정렬 파라미터, limit 또는 cursor 가 있으면 next_cursor 포함)
 func (h *OutAccountHandler) GetOutAccountsByUserHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		utils.SendError(w, apiErrors.ErrInvalidRequest.WithMessage("지원되지 않는 메소드입니다"))
@@ -679,11 +790,16 @@ func (h *OutAccountHandler) GetOutAccountsByUserHandler(w http.ResponseWriter, r
 	calculatedStartDate := utils.FormatDateKST(start)
 	calculatedEndDate := utils.FormatDateKST(end)
 
+	opts, ok := parseListOptions(w, r)
+	if !ok {
+		return
+	}
+
 	// 지출 내역 조회
-	accounts, err := h.DB.GetOutAccountsByUser(userName, calculatedStartDate, calculatedEndDate)
+	accounts, page, err := h.DB.GetOutAccountsByUser(utils.LedgerIDFromRequest(r), userName, calculatedStartDate, calculatedEndDate, opts)
 	if err != nil {
 		utils.LogError("사용자별 지출 내역 조회", err)
-		utils.SendErrorResponse(w, http.StatusInternalServerError, "DATABASE_ERROR", "사용자별 지출 내역 조회 중 오류 발생")
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("사용자별 지출 내역 조회 중 오류 발생"))
 		return
 	}
 
@@ -692,7 +808,12 @@ func (h *OutAccountHandler) GetOutAccountsByUserHandler(w http.ResponseWriter, r
 		"start_date":  calculatedStartDate,
 		"end_date":    calculatedEndDate,
 		"accounts":    accounts,
-		"total_count": len(accounts),
+		"total_count": page.TotalCount,
+	}
+	if opts.Paginated() {
+		response["limit"] = page.Limit
+		response["has_more"] = page.HasMore
+		response["next_cursor"] = page.NextCursor
 	}
 
 	utils.SendSuccessResponse(w, response)
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"iksoon_account_backend/database"
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+)
+
+// newTestDB 임시 디렉토리에 마이그레이션을 모두 적용한 테스트용 DB 생성 (테스트 종료 시 닫힘)
+func newTestDB(t *testing.T) *database.DB {
+	t.Helper()
+
+	db, err := database.InitDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("테스트 DB 생성 실패: %v", err)
+	}
+	t.Cleanup(func() { db.Conn.Close() })
+	return db
+}
+
+// getOutAccountRange 기간별 지출 목록 요청 (응답 상태 코드와 본문 반환)
+func getOutAccountRange(t *testing.T, handler *OutAccountHandler, params url.Values) (int, []byte) {
+	t.Helper()
+
+	params.Set("start_date", "2024-03-01")
+	params.Set("end_date", "2024-03-31")
+	recorder := httptest.NewRecorder()
+	handler.GetOutAccountsByDateRangeHandler(recorder, httptest.NewRequest(http.MethodGet, "/v2/out-accounts?"+params.Encode(), nil))
+	return recorder.Code, recorder.Body.Bytes()
+}
+
+func TestGetOutAccountsByDateRangePages(t *testing.T) {
+	db := newTestDB(t)
+	handler := &OutAccountHandler{DB: db}
+
+	food, err := db.CreateCategory(database.DefaultLedgerID, "테스트 식비", "out", "")
+	if err != nil {
+		t.Fatalf("카테고리 생성 실패: %v", err)
+	}
+	methodID, err := db.CreatePaymentMethod(database.DefaultLedgerID, "테스트 카드", nil)
+	if err != nil {
+		t.Fatalf("결제수단 생성 실패: %v", err)
+	}
+	for _, row := range []struct {
+		date  string
+		money int
+	}{
+		{"2024-03-01", 5000}, {"2024-03-02", 12000}, {"2024-03-02", 12000},
+		{"2024-03-10", 800}, {"2024-03-15", 30000}, {"2024-04-01", 99000},
+	} {
+		if _, err := db.InsertOutAccount(database.DefaultLedgerID, row.date, "테스트", row.money, int(food), nil, int(methodID), "", "", 0); err != nil {
+			t.Fatalf("지출 등록 실패: %v", err)
+		}
+	}
+
+	// limit/cursor 가 없으면 기존과 같은 배열 응답
+	status, body := getOutAccountRange(t, handler, url.Values{"sort": {"money"}, "order": {"desc"}, "min_amount": {"1000"}})
+	if status != http.StatusOK {
+		t.Fatalf("전체 조회 status = %d (%s)", status, body)
+	}
+	var all []models.OutAccount
+	if err := json.Unmarshal(body, &all); err != nil {
+		t.Fatalf("배열 응답이 아님: %v (%s)", err, body)
+	}
+	if len(all) != 4 {
+		t.Fatalf("전체 조회 %d건, want 4건 (기간 밖·최소 금액 미만 제외)", len(all))
+	}
+
+	// next_cursor 를 따라가면 같은 순서로 빠짐없이 이어짐
+	var paged []models.OutAccount
+	params := url.Values{"sort": {"money"}, "order": {"desc"}, "min_amount": {"1000"}, "limit": {"3"}}
+	for page := 1; ; page++ {
+		if page > 3 {
+			t.Fatal("페이지가 끝나지 않음")
+		}
+		status, body := getOutAccountRange(t, handler, params)
+		if status != http.StatusOK {
+			t.Fatalf("%d페이지 status = %d (%s)", page, status, body)
+		}
+		var response models.OutAccountPage
+		if err := json.Unmarshal(body, &response); err != nil {
+			t.Fatalf("%d페이지 응답 해석 실패: %v", page, err)
+		}
+		if response.TotalCount != 4 || response.Limit != 3 {
+			t.Errorf("%d페이지 total_count/limit = %d/%d, want 4/3", page, response.TotalCount, response.Limit)
+		}
+		paged = append(paged, response.Items...)
+		if !response.HasMore {
+			break
+		}
+		params.Set("cursor", response.NextCursor)
+	}
+	if len(paged) != len(all) {
+		t.Fatalf("페이지 합계 %d건, want %d건", len(paged), len(all))
+	}
+	for i := range all {
+		if paged[i].UUID != all[i].UUID {
+			t.Errorf("%d번째 = %s (%d원), want %s (%d원)", i, paged[i].UUID, paged[i].Money, all[i].UUID, all[i].Money)
+		}
+	}
+
+	// 다른 정렬의 커서, 잘못된 커서와 limit 은 400
+	for _, tt := range []struct {
+		name     string
+		params   url.Values
+		wantCode string
+	}{
+		{"정렬이 다른 커서", url.Values{"sort": {"date"}, "cursor": {params.Get("cursor")}}, apiErrors.ErrInvalidCursor.Code},
+		{"해석할 수 없는 커서", url.Values{"cursor": {"!!!"}}, apiErrors.ErrInvalidCursor.Code},
+		{"범위를 넘는 limit", url.Values{"limit": {"100000"}}, apiErrors.ErrInvalidListOption.Code},
+		{"잘못된 정렬", url.Values{"sort": {"memo"}}, apiErrors.ErrInvalidListOption.Code},
+	} {
+		status, body := getOutAccountRange(t, handler, tt.params)
+		var response apiErrors.ErrorResponse
+		if err := json.Unmarshal(body, &response); err != nil {
+			t.Fatalf("%s 응답 해석 실패: %v (%s)", tt.name, err, body)
+		}
+		if status != http.StatusBadRequest || response.Error.Code != tt.wantCode {
+			t.Errorf("%s: status = %d, code = %s; want 400, %s", tt.name, status, response.Error.Code, tt.wantCode)
+		}
+	}
+}
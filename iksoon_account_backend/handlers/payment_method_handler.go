@@ -16,13 +16,13 @@ type PaymentMethodHandler struct {
 }
 
 type PaymentMethodRepository interface {
-	GetPaymentMethods() ([]models.PaymentMethod, error)
-	CreatePaymentMethod(name string, parentID *int) (int64, error)
-	UpdatePaymentMethod(id int, name string) error
-	DeletePaymentMethod(id int) error
-	ForceDeletePaymentMethod(id int) error
-	CheckPaymentMethodExists(id int) (bool, error)
-	CheckPaymentMethodUsage(paymentMethodID int) (bool, error)
+	GetPaymentMethods(ledgerID int) ([]models.PaymentMethod, error)
+	CreatePaymentMethod(ledgerID int, name string, parentID *int) (int64, error)
+	UpdatePaymentMethod(ledgerID int, id int, name string) error
+	DeletePaymentMethod(ledgerID int, id int) error
+	ForceDeletePaymentMethod(ledgerID int, id int) error
+	CheckPaymentMethodExists(ledgerID int, id int) (bool, error)
+	CheckPaymentMethodUsage(ledgerID int, paymentMethodID int) (bool, error)
 }
 
 // GetPaymentMethodsHandler 결제수단 목록 조회 핸들러
@@ -33,7 +33,7 @@ func (h *PaymentMethodHandler) GetPaymentMethodsHandler(w http.ResponseWriter, r
 
 	utils.Debug("결제수단 목록 조회 요청")
 
-	paymentMethods, err := h.DB.GetPaymentMethods()
+	paymentMethods, err := h.DB.GetPaymentMethods(utils.LedgerIDFromRequest(r))
 	if err != nil {
 		utils.LogDatabaseError("결제수단 목록 조회", err)
 		utils.SendError(w, apiErrors.ErrDatabaseConnection.WithDetails("결제수단 목록 조회 실패"))
@@ -63,7 +63,7 @@ func (h *PaymentMethodHandler) CreatePaymentMethodHandler(w http.ResponseWriter,
 		return
 	}
 
-	paymentMethodID, err := h.DB.CreatePaymentMethod(req.Name, req.ParentID)
+	paymentMethodID, err := h.DB.CreatePaymentMethod(utils.LedgerIDFromRequest(r), req.Name, req.ParentID)
 	if err != nil {
 		if strings.Contains(err.Error(), "이미 존재하는") {
 			utils.SendError(w, apiErrors.ErrAlreadyExists.WithMessage(err.Error()))
@@ -113,7 +113,7 @@ func (h *PaymentMethodHandler) UpdatePaymentMethodHandler(w http.ResponseWriter,
 		return
 	}
 
-	err = h.DB.UpdatePaymentMethod(paymentMethodID, req.Name)
+	err = h.DB.UpdatePaymentMethod(utils.LedgerIDFromRequest(r), paymentMethodID, req.Name)
 	if err != nil {
 		if strings.Contains(err.Error(), "no rows affected") {
 			utils.SendErrorResponse(w, http.StatusNotFound, models.ErrCodeNotFound, "존재하지 않는 결제수단입니다.")
@@ -154,7 +154,7 @@ func (h *PaymentMethodHandler) DeletePaymentMethodHandler(w http.ResponseWriter,
 	}
 
 	// 결제수단을 사용하는 데이터가 있는지 확인
-	hasData, err := h.DB.CheckPaymentMethodUsage(paymentMethodID)
+	hasData, err := h.DB.CheckPaymentMethodUsage(utils.LedgerIDFromRequest(r), paymentMethodID)
 	if err != nil {
 		utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "결제수단 사용 여부 확인 중 오류 발생")
 		return
@@ -165,7 +165,7 @@ func (h *PaymentMethodHandler) DeletePaymentMethodHandler(w http.ResponseWriter,
 		return
 	}
 
-	err = h.DB.DeletePaymentMethod(paymentMethodID)
+	err = h.DB.DeletePaymentMethod(utils.LedgerIDFromRequest(r), paymentMethodID)
 	if err != nil {
 		if strings.Contains(err.Error(), "no rows affected") {
 			utils.SendErrorResponse(w, http.StatusNotFound, models.ErrCodeNotFound, "존재하지 않는 결제수단입니다.")
@@ -201,7 +201,7 @@ func (h *PaymentMethodHandler) ForceDeletePaymentMethodHandler(w http.ResponseWr
 		return
 	}
 
-	err = h.DB.ForceDeletePaymentMethod(paymentMethodID)
+	err = h.DB.ForceDeletePaymentMethod(utils.LedgerIDFromRequest(r), paymentMethodID)
 	if err != nil {
 		if strings.Contains(err.Error(), "no rows affected") {
 			utils.SendErrorResponse(w, http.StatusNotFound, models.ErrCodeNotFound, "존재하지 않는 결제수단입니다.")
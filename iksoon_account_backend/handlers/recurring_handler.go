@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+type RecurringHandler struct {
+	DB        RecurringRepository
+	KeywordDB KeywordRepository
+}
+
+type RecurringRepository interface {
+	GetRecurringRules(ledgerID int, accountType string) ([]models.RecurringRule, error)
+	GetRecurringRuleByID(ledgerID int, id int) (*models.RecurringRule, error)
+	CreateRecurringRule(ledgerID int, req models.RecurringRuleRequest) (int64, error)
+	UpdateRecurringRule(ledgerID int, id int, req models.RecurringRuleRequest) error
+	DeleteRecurringRule(ledgerID int, id int) error
+	GenerateRecurringTransactions(now time.Time) (*models.RecurringGenerationResult, error)
+}
+
+// GetRecurringRulesHandler 정기 거래 규칙 목록 조회 핸들러 (type 파라미터로 out/in 필터링, id 지정 시 단건 조회)
+func (h *RecurringHandler) GetRecurringRulesHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	if r.URL.Query().Get("id") != "" {
+		id, ok := utils.ParseIDFromQuery(w, r, "id")
+		if !ok {
+			return
+		}
+		rule, err := h.DB.GetRecurringRuleByID(utils.LedgerIDFromRequest(r), id)
+		if err != nil {
+			utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("정기 거래 규칙 조회 실패"))
+			return
+		}
+		utils.SendSuccessResponse(w, rule)
+		return
+	}
+
+	accountType := r.URL.Query().Get("type")
+	if accountType != "" && accountType != "out" && accountType != "in" {
+		utils.SendError(w, apiErrors.ErrInvalidRequest.WithMessage("type은 'out' 또는 'in'이어야 합니다"))
+		return
+	}
+
+	rules, err := h.DB.GetRecurringRules(utils.LedgerIDFromRequest(r), accountType)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("정기 거래 규칙 조회 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, rules)
+}
+
+// CreateRecurringRuleHandler 정기 거래 규칙 생성 핸들러
+func (h *RecurringHandler) CreateRecurringRuleHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	var req models.RecurringRuleRequest
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+
+	req.User = utils.ResolveRequestUser(r, req.User)
+	utils.Debug("정기 거래 규칙 생성 요청: %+v", req)
+
+	if !h.resolveKeyword(w, r, &req) {
+		return
+	}
+
+	id, err := h.DB.CreateRecurringRule(utils.LedgerIDFromRequest(r), req)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("정기 거래 규칙 생성 실패"))
+		return
+	}
+
+	response := map[string]interface{}{
+		"id":      id,
+		"message": "정기 거래 규칙이 성공적으로 생성되었습니다.",
+	}
+
+	utils.SendCreatedResponse(w, response)
+}
+
+// UpdateRecurringRuleHandler 정기 거래 규칙 수정 핸들러
+func (h *RecurringHandler) UpdateRecurringRuleHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPut) {
+		return
+	}
+
+	id, ok := utils.ParseIDFromQuery(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.RecurringRuleRequest
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+
+	req.User = utils.ResolveRequestUser(r, req.User)
+	utils.Debug("정기 거래 규칙 수정 요청: ID=%d, %+v", id, req)
+
+	if !h.resolveKeyword(w, r, &req) {
+		return
+	}
+
+	if err := h.DB.UpdateRecurringRule(utils.LedgerIDFromRequest(r), id, req); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("정기 거래 규칙 수정 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, utils.CreateSuccessMessage("정기 거래 규칙이 성공적으로 수정되었습니다."))
+}
+
+// DeleteRecurringRuleHandler 정기 거래 규칙 삭제 핸들러 (이미 생성된 거래는 유지)
+func (h *RecurringHandler) DeleteRecurringRuleHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodDelete) {
+		return
+	}
+
+	id, ok := utils.ParseIDFromQuery(w, r, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.DB.DeleteRecurringRule(utils.LedgerIDFromRequest(r), id); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("정기 거래 규칙 삭제 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, utils.CreateSuccessMessage("정기 거래 규칙이 성공적으로 삭제되었습니다."))
+}
+
+// RunRecurringRulesHandler 정기 거래 즉시 생성 핸들러 (스케줄러를 기다리지 않고 수동 실행)
+func (h *RecurringHandler) RunRecurringRulesHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	result, err := h.DB.GenerateRecurringTransactions(utils.GetCurrentKST())
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("정기 거래 생성 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, result)
+}
+
+// resolveKeyword keyword_name 이 있으면 키워드를 upsert 하여 ID로 변환
+func (h *RecurringHandler) resolveKeyword(w http.ResponseWriter, r *http.Request, req *models.RecurringRuleRequest) bool {
+	if req.KeywordName == "" || req.CategoryID <= 0 {
+		return true
+	}
+
+	id, err := h.KeywordDB.UpsertKeyword(utils.LedgerIDFromRequest(r), req.CategoryID, req.KeywordName)
+	if err != nil {
+		utils.LogError("정기 거래 키워드 처리", err)
+		utils.SendError(w, apiErrors.ErrDatabaseConnection.WithDetails("키워드 처리 실패"))
+		return false
+	}
+
+	keywordID := int(id)
+	req.KeywordID = &keywordID
+	return true
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"net/http"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+type ReimbursementHandler struct {
+	DB ReimbursementRepository
+}
+
+type ReimbursementRepository interface {
+	GetReimbursements(ledgerID int, status, user string) ([]models.Reimbursement, error)
+	GetReimbursement(ledgerID int, outUUID string) (*models.Reimbursement, error)
+	MarkReimbursable(ledgerID int, outUUID string) error
+	UnmarkReimbursable(ledgerID int, outUUID string) error
+	SettleReimbursements(ledgerID int, outUUIDs []string, inUUID string) error
+	UnsettleReimbursement(ledgerID int, outUUID string) error
+	GetOutstandingReimbursements(ledgerID int, user string) (*models.OutstandingReimbursementReport, error)
+}
+
+// GetReimbursementsHandler 환급 대상 지출 목록 조회 핸들러 (status=pending|settled, user 필터)
+func (h *ReimbursementHandler) GetReimbursementsHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	query := r.URL.Query()
+	reimbursements, err := h.DB.GetReimbursements(utils.LedgerIDFromRequest(r), query.Get("status"), query.Get("user"))
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("환급 목록 조회 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, reimbursements)
+}
+
+// MarkReimbursableHandler 지출을 환급 대상으로 지정하는 핸들러
+func (h *ReimbursementHandler) MarkReimbursableHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	var req models.ReimbursementRequest
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+
+	ledgerID := utils.LedgerIDFromRequest(r)
+	if err := h.DB.MarkReimbursable(ledgerID, req.UUID); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("환급 대상 지정 실패"))
+		return
+	}
+
+	reimbursement, err := h.DB.GetReimbursement(ledgerID, req.UUID)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("환급 정보 조회 실패"))
+		return
+	}
+
+	utils.Info("환급 대상 지정: 지출=%s, 사용자=%s, 금액=%d", reimbursement.OutAccountUUID, reimbursement.User, reimbursement.Money)
+	utils.SendCreatedResponse(w, reimbursement)
+}
+
+// UnmarkReimbursableHandler 지출의 환급 대상 지정 해제 핸들러 (uuid 파라미터)
+func (h *ReimbursementHandler) UnmarkReimbursableHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodDelete) {
+		return
+	}
+
+	uuid := r.URL.Query().Get("uuid")
+	if uuid == "" {
+		utils.SendError(w, apiErrors.ErrMissingRequired.WithMessage("uuid는 필수입니다"))
+		return
+	}
+
+	if err := h.DB.UnmarkReimbursable(utils.LedgerIDFromRequest(r), uuid); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("환급 대상 해제 실패"))
+		return
+	}
+
+	utils.Info("환급 대상 해제: 지출=%s", uuid)
+	utils.SendSuccessResponse(w, utils.CreateSuccessMessage("환급 대상에서 해제되었습니다."))
+}
+
+// SettleReimbursementsHandler 환급 수입을 지출들에 연결하는 핸들러 (연결된 지출과 수입은 통계에서 제외)
+func (h *ReimbursementHandler) SettleReimbursementsHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPut) {
+		return
+	}
+
+	var req models.ReimbursementSettleRequest
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+
+	ledgerID := utils.LedgerIDFromRequest(r)
+	if err := h.DB.SettleReimbursements(ledgerID, req.OutUUIDs, req.InUUID); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("환급 완료 처리 실패"))
+		return
+	}
+
+	settled := []models.Reimbursement{}
+	seen := map[string]bool{}
+	for _, outUUID := range req.OutUUIDs {
+		if seen[outUUID] {
+			continue
+		}
+		seen[outUUID] = true
+
+		reimbursement, err := h.DB.GetReimbursement(ledgerID, outUUID)
+		if err != nil {
+			continue
+		}
+		settled = append(settled, *reimbursement)
+	}
+
+	utils.Info("환급 완료 처리: 수입=%s, 지출 %d건", req.InUUID, len(settled))
+	utils.SendSuccessResponse(w, settled)
+}
+
+// UnsettleReimbursementHandler 지출의 환급 수입 연결 해제 핸들러 (uuid 파라미터, 환급 대기로 되돌림)
+func (h *ReimbursementHandler) UnsettleReimbursementHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPut) {
+		return
+	}
+
+	uuid := r.URL.Query().Get("uuid")
+	if uuid == "" {
+		utils.SendError(w, apiErrors.ErrMissingRequired.WithMessage("uuid는 필수입니다"))
+		return
+	}
+
+	ledgerID := utils.LedgerIDFromRequest(r)
+	if err := h.DB.UnsettleReimbursement(ledgerID, uuid); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("환급 연결 해제 실패"))
+		return
+	}
+
+	reimbursement, err := h.DB.GetReimbursement(ledgerID, uuid)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("환급 정보 조회 실패"))
+		return
+	}
+
+	utils.Info("환급 연결 해제: 지출=%s", uuid)
+	utils.SendSuccessResponse(w, reimbursement)
+}
+
+// GetOutstandingReimbursementsHandler 사용자별 미환급 금액 보고서 핸들러 (user 필터)
+func (h *ReimbursementHandler) GetOutstandingReimbursementsHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	report, err := h.DB.GetOutstandingReimbursements(utils.LedgerIDFromRequest(r), r.URL.Query().Get("user"))
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("미환급 금액 조회 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, report)
+}
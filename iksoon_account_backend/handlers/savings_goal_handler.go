@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"net/http"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+type SavingsGoalHandler struct {
+	DB SavingsGoalRepository
+}
+
+type SavingsGoalRepository interface {
+	GetSavingsGoals(ledgerID int) ([]models.SavingsGoal, error)
+	GetSavingsGoalDetail(ledgerID, id int) (*models.SavingsGoalDetail, error)
+	CreateSavingsGoal(ledgerID int, req models.SavingsGoalRequest) (int64, error)
+	UpdateSavingsGoal(ledgerID, id int, req models.SavingsGoalRequest) error
+	DeleteSavingsGoal(ledgerID, id int) error
+}
+
+// GetSavingsGoalsHandler 저축 목표 목록 조회 핸들러 (진행 상황 포함)
+func (h *SavingsGoalHandler) GetSavingsGoalsHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	goals, err := h.DB.GetSavingsGoals(utils.LedgerIDFromRequest(r))
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("저축 목표 목록 조회 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, goals)
+}
+
+// GetSavingsGoalProgressHandler 저축 목표 진행 상황과 월별 적립 내역 조회 핸들러 (id 파라미터)
+func (h *SavingsGoalHandler) GetSavingsGoalProgressHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	id, ok := utils.ParseIDFromQuery(w, r, "id")
+	if !ok {
+		return
+	}
+
+	detail, err := h.DB.GetSavingsGoalDetail(utils.LedgerIDFromRequest(r), id)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("저축 목표 조회 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, detail)
+}
+
+// CreateSavingsGoalHandler 저축 목표 생성 핸들러
+func (h *SavingsGoalHandler) CreateSavingsGoalHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	var req models.SavingsGoalRequest
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+
+	ledgerID := utils.LedgerIDFromRequest(r)
+	id, err := h.DB.CreateSavingsGoal(ledgerID, req)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("저축 목표 생성 실패"))
+		return
+	}
+
+	detail, err := h.DB.GetSavingsGoalDetail(ledgerID, int(id))
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("생성된 저축 목표 조회 실패"))
+		return
+	}
+
+	utils.Info("저축 목표 생성: ID=%d, 이름=%s, 목표=%d원, 목표일=%s", detail.ID, detail.Name, detail.TargetAmount, detail.TargetDate)
+	utils.SendCreatedResponse(w, detail)
+}
+
+// UpdateSavingsGoalHandler 저축 목표 수정 핸들러 (id 파라미터)
+func (h *SavingsGoalHandler) UpdateSavingsGoalHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPut) {
+		return
+	}
+
+	id, ok := utils.ParseIDFromQuery(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.SavingsGoalRequest
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+
+	ledgerID := utils.LedgerIDFromRequest(r)
+	if err := h.DB.UpdateSavingsGoal(ledgerID, id, req); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("저축 목표 수정 실패"))
+		return
+	}
+
+	detail, err := h.DB.GetSavingsGoalDetail(ledgerID, id)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("수정된 저축 목표 조회 실패"))
+		return
+	}
+
+	utils.Info("저축 목표 수정: ID=%d, 이름=%s, 목표=%d원, 목표일=%s", detail.ID, detail.Name, detail.TargetAmount, detail.TargetDate)
+	utils.SendSuccessResponse(w, detail)
+}
+
+// DeleteSavingsGoalHandler 저축 목표 삭제 핸들러 (id 파라미터)
+func (h *SavingsGoalHandler) DeleteSavingsGoalHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodDelete) {
+		return
+	}
+
+	id, ok := utils.ParseIDFromQuery(w, r, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.DB.DeleteSavingsGoal(utils.LedgerIDFromRequest(r), id); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("저축 목표 삭제 실패"))
+		return
+	}
+
+	utils.Info("저축 목표 삭제: ID=%d", id)
+	utils.SendSuccessResponse(w, utils.CreateSuccessMessage("저축 목표가 삭제되었습니다."))
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+type SearchHandler struct {
+	DB SearchRepository
+}
+
+type SearchRepository interface {
+	SearchTransactions(ledgerID int, q models.SearchQuery) (*models.SearchResult, error)
+}
+
+// SearchTransactionsHandler 거래 전문 검색 핸들러 (q, type, start_date, end_date, limit, offset 파라미터)
+func (h *SearchHandler) SearchTransactionsHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	query := r.URL.Query()
+	q := models.SearchQuery{
+		Query:     query.Get("q"),
+		Type:      query.Get("type"),
+		StartDate: query.Get("start_date"),
+		EndDate:   query.Get("end_date"),
+	}
+	for _, param := range []struct {
+		name   string
+		target *int
+	}{{"limit", &q.Limit}, {"offset", &q.Offset}} {
+		value := query.Get(param.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			utils.SendError(w, apiErrors.ErrInvalidSearchQuery.WithMessage(param.name+"가 올바르지 않습니다"))
+			return
+		}
+		*param.target = parsed
+	}
+
+	result, err := h.DB.SearchTransactions(utils.LedgerIDFromRequest(r), q)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("거래 검색 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, result)
+}
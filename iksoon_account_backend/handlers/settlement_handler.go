@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"net/http"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+type SettlementHandler struct {
+	DB SettlementRepository
+}
+
+type SettlementRepository interface {
+	GetSharingRules(ledgerID int) ([]models.SharingRule, error)
+	GetSharingRuleByID(ledgerID, id int) (*models.SharingRule, error)
+	CreateSharingRule(ledgerID int, req models.SharingRuleRequest) (int64, error)
+	UpdateSharingRule(ledgerID, id int, req models.SharingRuleRequest) error
+	DeleteSharingRule(ledgerID, id int) error
+	GetSettlementBalance(ledgerID int, startDate, endDate string) (*models.SettlementBalance, error)
+	GetSettlements(ledgerID int) ([]models.Settlement, error)
+	GetSettlementByID(ledgerID, id int) (*models.Settlement, error)
+	RecordSettlement(ledgerID int, req models.SettlementRequest) (int64, error)
+	DeleteSettlement(ledgerID, id int) error
+}
+
+// GetSharingRulesHandler 공동 지출 분담 규칙 목록 조회 핸들러
+func (h *SettlementHandler) GetSharingRulesHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	rules, err := h.DB.GetSharingRules(utils.LedgerIDFromRequest(r))
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("분담 규칙 조회 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, rules)
+}
+
+// CreateSharingRuleHandler 분담 규칙 생성 핸들러 (category_id 생략 시 기본 규칙)
+func (h *SettlementHandler) CreateSharingRuleHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	var req models.SharingRuleRequest
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+
+	ledgerID := utils.LedgerIDFromRequest(r)
+	id, err := h.DB.CreateSharingRule(ledgerID, req)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("분담 규칙 생성 실패"))
+		return
+	}
+
+	rule, err := h.DB.GetSharingRuleByID(ledgerID, int(id))
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("생성된 분담 규칙 조회 실패"))
+		return
+	}
+
+	utils.Info("분담 규칙 생성: ID=%d, 카테고리=%s, 방식=%s, 참여자 %d명", rule.ID, rule.CategoryName, rule.Method, len(rule.Shares))
+	utils.SendCreatedResponse(w, rule)
+}
+
+// UpdateSharingRuleHandler 분담 규칙 수정 핸들러 (id 파라미터, 참여자 목록 전체 교체)
+func (h *SettlementHandler) UpdateSharingRuleHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPut) {
+		return
+	}
+
+	id, ok := utils.ParseIDFromQuery(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.SharingRuleRequest
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+
+	ledgerID := utils.LedgerIDFromRequest(r)
+	if err := h.DB.UpdateSharingRule(ledgerID, id, req); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("분담 규칙 수정 실패"))
+		return
+	}
+
+	rule, err := h.DB.GetSharingRuleByID(ledgerID, id)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("수정된 분담 규칙 조회 실패"))
+		return
+	}
+
+	utils.Info("분담 규칙 수정: ID=%d, 카테고리=%s, 방식=%s, 참여자 %d명", rule.ID, rule.CategoryName, rule.Method, len(rule.Shares))
+	utils.SendSuccessResponse(w, rule)
+}
+
+// DeleteSharingRuleHandler 분담 규칙 삭제 핸들러 (id 파라미터)
+func (h *SettlementHandler) DeleteSharingRuleHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodDelete) {
+		return
+	}
+
+	id, ok := utils.ParseIDFromQuery(w, r, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.DB.DeleteSharingRule(utils.LedgerIDFromRequest(r), id); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("분담 규칙 삭제 실패"))
+		return
+	}
+
+	utils.Info("분담 규칙 삭제: ID=%d", id)
+	utils.SendSuccessResponse(w, utils.CreateSuccessMessage("분담 규칙이 삭제되었습니다."))
+}
+
+// GetSettlementBalanceHandler 멤버별 정산 잔액과 최소 송금 목록 조회 핸들러
+// start_date 생략 시 마지막 정산 다음 날부터, end_date 생략 시 오늘까지
+func (h *SettlementHandler) GetSettlementBalanceHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	query := r.URL.Query()
+	balance, err := h.DB.GetSettlementBalance(utils.LedgerIDFromRequest(r), query.Get("start_date"), query.Get("end_date"))
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrStatisticsCalculation.WithDetails("정산 잔액 계산 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, balance)
+}
+
+// GetSettlementsHandler 기록된 정산 목록 조회 핸들러
+func (h *SettlementHandler) GetSettlementsHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	settlements, err := h.DB.GetSettlements(utils.LedgerIDFromRequest(r))
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("정산 목록 조회 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, settlements)
+}
+
+// RecordSettlementHandler 정산 기록 핸들러 (마지막 정산 다음 날부터 end_date 까지의 송금 목록을 기록해 잔액 초기화)
+func (h *SettlementHandler) RecordSettlementHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	var req models.SettlementRequest
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+
+	ledgerID := utils.LedgerIDFromRequest(r)
+	id, err := h.DB.RecordSettlement(ledgerID, req)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("정산 기록 실패"))
+		return
+	}
+
+	settlement, err := h.DB.GetSettlementByID(ledgerID, int(id))
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("기록된 정산 조회 실패"))
+		return
+	}
+
+	utils.Info("정산 기록: ID=%d, 기간=%s~%s, 송금 %d건", settlement.ID, settlement.StartDate, settlement.EndDate, len(settlement.Transfers))
+	utils.SendCreatedResponse(w, settlement)
+}
+
+// DeleteSettlementHandler 기록된 정산 삭제 핸들러 (id 파라미터)
+func (h *SettlementHandler) DeleteSettlementHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodDelete) {
+		return
+	}
+
+	id, ok := utils.ParseIDFromQuery(w, r, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.DB.DeleteSettlement(utils.LedgerIDFromRequest(r), id); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("정산 삭제 실패"))
+		return
+	}
+
+	utils.Info("정산 삭제: ID=%d", id)
+	utils.SendSuccessResponse(w, utils.CreateSuccessMessage("정산 기록이 삭제되었습니다."))
+}
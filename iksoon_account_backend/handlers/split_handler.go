@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+type SplitHandler struct {
+	DB        SplitRepository
+	KeywordDB KeywordRepository
+}
+
+type SplitRepository interface {
+	GetOutAccountSplits(ledgerID int, uuid string) ([]models.OutAccountSplit, error)
+	SetOutAccountSplits(ledgerID int, uuid string, splits []models.OutAccountSplitRequest) error
+}
+
+// GetOutAccountSplitsHandler 지출의 분할 항목 조회 핸들러 (분할되지 않은 지출은 빈 목록)
+func (h *SplitHandler) GetOutAccountSplitsHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	uuid := r.URL.Query().Get("uuid")
+	if uuid == "" {
+		utils.SendError(w, apiErrors.ErrMissingRequired.WithMessage("uuid는 필수입니다"))
+		return
+	}
+
+	splits, err := h.DB.GetOutAccountSplits(utils.LedgerIDFromRequest(r), uuid)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("분할 지출 조회 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, splits)
+}
+
+// UpdateOutAccountSplitsHandler 지출의 분할 항목 전체 교체 핸들러 (splits 가 빈 목록이면 분할 해제)
+func (h *SplitHandler) UpdateOutAccountSplitsHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPut) {
+		return
+	}
+
+	uuid := r.URL.Query().Get("uuid")
+	if uuid == "" {
+		utils.SendError(w, apiErrors.ErrMissingRequired.WithMessage("uuid는 필수입니다"))
+		return
+	}
+
+	var req models.OutAccountSplitsRequest
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+
+	ledgerID := utils.LedgerIDFromRequest(r)
+	if err := resolveSplitKeywords(h.KeywordDB, ledgerID, req.Splits); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("키워드 처리 실패"))
+		return
+	}
+	if err := h.DB.SetOutAccountSplits(ledgerID, uuid, req.Splits); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("분할 지출 저장 실패"))
+		return
+	}
+
+	splits, err := h.DB.GetOutAccountSplits(ledgerID, uuid)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("저장된 분할 지출 조회 실패"))
+		return
+	}
+
+	utils.Info("분할 지출 저장: UUID=%s, 항목 %d개", uuid, len(splits))
+	utils.SendSuccessResponse(w, splits)
+}
+
+// resolveSplitKeywords 분할 항목의 키워드 이름으로 키워드를 조회/생성해 KeywordID 채우기
+func resolveSplitKeywords(keywordDB KeywordRepository, ledgerID int, splits []models.OutAccountSplitRequest) error {
+	for i := range splits {
+		name := strings.TrimSpace(splits[i].KeywordName)
+		if name == "" || splits[i].CategoryID <= 0 {
+			continue
+		}
+
+		id, err := keywordDB.UpsertKeyword(ledgerID, splits[i].CategoryID, name)
+		if err != nil {
+			return err
+		}
+		keywordID := int(id)
+		splits[i].KeywordID = &keywordID
+	}
+	return nil
+}
@@ -15,13 +15,13 @@ type StatisticsHandler struct {
 }
 
 type StatisticsRepository interface {
-	GetCategoryStatistics(startDate, endDate, accountType string) ([]models.CategoryStatistics, error)
-	GetKeywordStatistics(categoryID int, startDate, endDate, accountType string) ([]models.KeywordStatistics, error)
-	GetTotalAmount(startDate, endDate, accountType string) (int, int, error) // total, count
-	GetAllBudgetUsages(userName string, currentDate time.Time) ([]models.BudgetUsage, error)
-	GetPaymentMethodStatistics(startDate, endDate string) ([]models.PaymentMethodStatistics, error)
-	GetPaymentMethodCategoryStatistics(paymentMethodID int, startDate, endDate string) ([]models.CategoryStatistics, error)
-	GetUserStatistics(startDate, endDate string) ([]models.UserStatistics, error)
+	GetCategoryStatistics(ledgerID int, startDate, endDate, accountType string) ([]models.CategoryStatistics, error)
+	GetKeywordStatistics(ledgerID int, categoryID int, startDate, endDate, accountType string) ([]models.KeywordStatistics, error)
+	GetTotalAmount(ledgerID int, startDate, endDate, accountType string) (int, int, error) // total, count
+	GetAllBudgetUsages(ledgerID int, userName string, currentDate time.Time) ([]models.BudgetUsage, error)
+	GetPaymentMethodStatistics(ledgerID int, startDate, endDate string) ([]models.PaymentMethodStatistics, error)
+	GetPaymentMethodCategoryStatistics(ledgerID int, paymentMethodID int, startDate, endDate string) ([]models.CategoryStatistics, error)
+	GetUserStatistics(ledgerID int, startDate, endDate string) ([]models.UserStatistics, error)
 }
 
 // 통계 조회 핸들러
@@ -55,14 +55,14 @@ func (h *StatisticsHandler) GetStatisticsHandler(w http.ResponseWriter, r *http.
 	calculatedStartDate, calculatedEndDate, period := h.calculateDateRange(statisticsType, startDate, endDate, yearStr, monthStr, weekStr)
 
 	// 카테고리별 통계 조회
-	categories, err := h.DB.GetCategoryStatistics(calculatedStartDate, calculatedEndDate, accountType)
+	categories, err := h.DB.GetCategoryStatistics(utils.LedgerIDFromRequest(r), calculatedStartDate, calculatedEndDate, accountType)
 	if err != nil {
 		utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "통계 조회 중 오류 발생")
 		return
 	}
 
 	// 총합 계산
-	totalAmount, totalCount, err := h.DB.GetTotalAmount(calculatedStartDate, calculatedEndDate, accountType)
+	totalAmount, totalCount, err := h.DB.GetTotalAmount(utils.LedgerIDFromRequest(r), calculatedStartDate, calculatedEndDate, accountType)
 	if err != nil {
 		utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "총합 계산 중 오류 발생")
 		return
@@ -87,8 +87,12 @@ func (h *StatisticsHandler) GetStatisticsHandler(w http.ResponseWriter, r *http.
 	// 기준치 정보 조회 (지출 통계이고 사용자명이 있는 경우에만)
 	var budgetUsages []models.BudgetUsage
 	if accountType == "out" && userName != "" {
+		// 지난 기간 통계는 기간 마지막 달에 적용되던 기준치로 평가
 		currentDate := time.Now()
-		budgetUsages, err = h.DB.GetAllBudgetUsages(userName, currentDate)
+		if periodEnd, err := utils.ParseDateTimeKST(calculatedEndDate); err == nil && periodEnd.Before(currentDate) {
+			currentDate = periodEnd
+		}
+		budgetUsages, err = h.DB.GetAllBudgetUsages(utils.LedgerIDFromRequest(r), userName, currentDate)
 		if err != nil {
 			utils.LogError("기준치 사용량 조회", err)
 			// 기준치 조회 오류는 무시하고 계속 진행
@@ -99,7 +103,7 @@ func (h *StatisticsHandler) GetStatisticsHandler(w http.ResponseWriter, r *http.
 	// 결제수단별 통계 조회 (지출 통계인 경우에만)
 	var paymentMethods []models.PaymentMethodStatistics
 	if accountType == "out" {
-		paymentMethods, err = h.DB.GetPaymentMethodStatistics(calculatedStartDate, calculatedEndDate)
+		paymentMethods, err = h.DB.GetPaymentMethodStatistics(utils.LedgerIDFromRequest(r), calculatedStartDate, calculatedEndDate)
 		if err != nil {
 			utils.LogError("결제수단 통계 조회", err)
 			// 결제수단 통계 조회 오류는 무시하고 계속 진행
@@ -117,7 +121,7 @@ func (h *StatisticsHandler) GetStatisticsHandler(w http.ResponseWriter, r *http.
 	// 사용자별 통계 조회 (지출 통계인 경우에만)
 	var users []models.UserStatistics
 	if accountType == "out" {
-		users, err = h.DB.GetUserStatistics(calculatedStartDate, calculatedEndDate)
+		users, err = h.DB.GetUserStatistics(utils.LedgerIDFromRequest(r), calculatedStartDate, calculatedEndDate)
 		if err != nil {
 			utils.LogError("사용자별 통계 조회", err)
 			// 사용자 통계 조회 오류는 무시하고 계속 진행
@@ -189,7 +193,7 @@ func (h *StatisticsHandler) GetCategoryKeywordStatisticsHandler(w http.ResponseW
 	calculatedStartDate, calculatedEndDate, period := h.calculateDateRange(statisticsType, startDate, endDate, yearStr, monthStr, weekStr)
 
 	// 키워드별 통계 조회
-	keywords, err := h.DB.GetKeywordStatistics(categoryID, calculatedStartDate, calculatedEndDate, accountType)
+	keywords, err := h.DB.GetKeywordStatistics(utils.LedgerIDFromRequest(r), categoryID, calculatedStartDate, calculatedEndDate, accountType)
 	if err != nil {
 		utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "키워드 통계 조회 중 오류 발생")
 		return
@@ -456,7 +460,7 @@ func (h *StatisticsHandler) GetPaymentMethodCategoryStatisticsHandler(w http.Res
 	calculatedStartDate, calculatedEndDate, period := h.calculateDateRange(statisticsType, startDate, endDate, yearStr, monthStr, weekStr)
 
 	// 카테고리별 통계 조회
-	categories, err := h.DB.GetPaymentMethodCategoryStatistics(paymentMethodID, calculatedStartDate, calculatedEndDate)
+	categories, err := h.DB.GetPaymentMethodCategoryStatistics(utils.LedgerIDFromRequest(r), paymentMethodID, calculatedStartDate, calculatedEndDate)
 	if err != nil {
 		utils.SendErrorResponse(w, http.StatusInternalServerError, models.ErrCodeDatabaseError, "결제수단별 카테고리 통계 조회 중 오류 발생")
 		return
@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+type TagHandler struct {
+	DB TagRepository
+}
+
+type TagRepository interface {
+	GetTags(ledgerID int) ([]models.Tag, error)
+	GetTagByID(ledgerID int, id int) (*models.Tag, error)
+	CreateTag(ledgerID int, name string) (int64, error)
+	UpdateTag(ledgerID int, id int, name string) error
+	DeleteTag(ledgerID int, id int) error
+	SetTransactionTags(ledgerID int, accountType, uuid string, names []string) ([]string, error)
+	GetTagStatistics(ledgerID int, tagID int, startDate, endDate string) (*models.TagStatistics, error)
+}
+
+// GetTagsHandler 태그 목록 조회 핸들러 (사용 건수 포함)
+func (h *TagHandler) GetTagsHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	tags, err := h.DB.GetTags(utils.LedgerIDFromRequest(r))
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("태그 목록 조회 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, tags)
+}
+
+// CreateTagHandler 태그 생성 핸들러
+func (h *TagHandler) CreateTagHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	var req models.TagRequest
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+
+	ledgerID := utils.LedgerIDFromRequest(r)
+	id, err := h.DB.CreateTag(ledgerID, req.Name)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("태그 생성 실패"))
+		return
+	}
+
+	tag, err := h.DB.GetTagByID(ledgerID, int(id))
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("생성된 태그 조회 실패"))
+		return
+	}
+
+	utils.Info("태그 생성: ID=%d, Name=%s", tag.ID, tag.Name)
+	utils.SendCreatedResponse(w, tag)
+}
+
+// UpdateTagHandler 태그 이름 변경 핸들러
+func (h *TagHandler) UpdateTagHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPut) {
+		return
+	}
+
+	id, ok := utils.ParseIDFromQuery(w, r, "id")
+	if !ok {
+		return
+	}
+
+	var req models.TagRequest
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+
+	ledgerID := utils.LedgerIDFromRequest(r)
+	if err := h.DB.UpdateTag(ledgerID, id, req.Name); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("태그 수정 실패"))
+		return
+	}
+
+	tag, err := h.DB.GetTagByID(ledgerID, id)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("수정된 태그 조회 실패"))
+		return
+	}
+
+	utils.Info("태그 수정: ID=%d, Name=%s", tag.ID, tag.Name)
+	utils.SendSuccessResponse(w, tag)
+}
+
+// DeleteTagHandler 태그 삭제 핸들러 (수입/지출은 유지하고 태그 연결만 해제)
+func (h *TagHandler) DeleteTagHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodDelete) {
+		return
+	}
+
+	id, ok := utils.ParseIDFromQuery(w, r, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.DB.DeleteTag(utils.LedgerIDFromRequest(r), id); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("태그 삭제 실패"))
+		return
+	}
+
+	utils.Info("태그 삭제: ID=%d", id)
+	utils.SendSuccessResponse(w, utils.CreateSuccessMessage("태그가 삭제되었습니다."))
+}
+
+// SetTransactionTagsHandler 수입/지출의 태그 전체 교체 핸들러 (없는 태그는 자동 생성, 빈 목록이면 모두 해제)
+func (h *TagHandler) SetTransactionTagsHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPut) {
+		return
+	}
+
+	var req models.TransactionTagsRequest
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+	if req.UUID == "" {
+		utils.SendError(w, apiErrors.ErrMissingRequired.WithMessage("uuid는 필수입니다"))
+		return
+	}
+
+	tags, err := h.DB.SetTransactionTags(utils.LedgerIDFromRequest(r), req.Type, req.UUID, req.Tags)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("태그 저장 실패"))
+		return
+	}
+
+	utils.Info("거래 태그 저장: Type=%s, UUID=%s, 태그 %d개", req.Type, req.UUID, len(tags))
+	utils.SendSuccessResponse(w, map[string]interface{}{
+		"type": req.Type,
+		"uuid": req.UUID,
+		"tags": tags,
+	})
+}
+
+// GetTagStatisticsHandler 태그별 수입/지출 합계 조회 핸들러 (start_date ~ end_date, 생략 시 전체 기간)
+func (h *TagHandler) GetTagStatisticsHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	id, ok := utils.ParseIDFromQuery(w, r, "id")
+	if !ok {
+		return
+	}
+
+	startDate := r.URL.Query().Get("start_date")
+	endDate := r.URL.Query().Get("end_date")
+
+	stats, err := h.DB.GetTagStatistics(utils.LedgerIDFromRequest(r), id, startDate, endDate)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("태그 통계 조회 실패"))
+		return
+	}
+
+	// 지출 합계 대비 비율 계산
+	if stats.TotalExpense > 0 {
+		for i := range stats.Categories {
+			stats.Categories[i].Percentage = float64(stats.Categories[i].TotalAmount) / float64(stats.TotalExpense) * 100
+		}
+		for i := range stats.Users {
+			stats.Users[i].Percentage = float64(stats.Users[i].TotalAmount) / float64(stats.TotalExpense) * 100
+		}
+	}
+
+	utils.SendSuccessResponse(w, stats)
+}
+
+// parseTagFilter 목록/검색 API 의 tag_id 쿼리 파라미터 파싱 (없으면 0)
+func parseTagFilter(w http.ResponseWriter, r *http.Request) (int, bool) {
+	value := r.URL.Query().Get("tag_id")
+	if value == "" {
+		return 0, true
+	}
+
+	tagID, err := strconv.Atoi(value)
+	if err != nil || tagID <= 0 {
+		utils.SendError(w, apiErrors.ErrInvalidRequest.WithMessage("tag_id가 올바르지 않습니다"))
+		return 0, false
+	}
+	return tagID, true
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+type TransactionHandler struct {
+	DB TransactionRepository
+}
+
+type TransactionRepository interface {
+	GetTransactions(ledgerID int, q models.TransactionQuery) ([]models.Transaction, models.PageInfo, error)
+	GetDailyNet(ledgerID int, q models.TransactionQuery) (*models.DailyNetSummary, error)
+}
+
+// parseTransactionQuery 통합 조회 파라미터 파싱 (start_date, end_date, type, keyword 와 목록 공통 파라미터)
+func parseTransactionQuery(w http.ResponseWriter, r *http.Request) (models.TransactionQuery, bool) {
+	query := r.URL.Query()
+	q := models.TransactionQuery{
+		StartDate: query.Get("start_date"),
+		EndDate:   query.Get("end_date"),
+		Type:      query.Get("type"),
+		Keyword:   strings.TrimSpace(query.Get("keyword")),
+	}
+
+	if q.StartDate == "" || q.EndDate == "" {
+		utils.SendError(w, apiErrors.ErrMissingRequired.WithMessage("시작일과 종료일이 필요합니다"))
+		return q, false
+	}
+
+	opts, ok := parseListOptions(w, r)
+	if !ok {
+		return q, false
+	}
+	q.ListOptions = opts
+	return q, true
+}
+
+// GetTransactionsHandler 수입/지출 통합 목록 조회 핸들러 (type 으로 구분된 하나의 정렬된 목록, 항상 페이지 응답 형식)
+func (h *TransactionHandler) GetTransactionsHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	q, ok := parseTransactionQuery(w, r)
+	if !ok {
+		return
+	}
+
+	transactions, page, err := h.DB.GetTransactions(utils.LedgerIDFromRequest(r), q)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("통합 거래 목록 조회 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, models.TransactionPage{Items: transactions, PageInfo: page})
+}
+
+// GetDailyNetHandler 날짜별 수입/지출 합계와 순액 조회 핸들러 (달력 보기용, 통합 목록과 같은 필터)
+func (h *TransactionHandler) GetDailyNetHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	q, ok := parseTransactionQuery(w, r)
+	if !ok {
+		return
+	}
+
+	summary, err := h.DB.GetDailyNet(utils.LedgerIDFromRequest(r), q)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("날짜별 순액 조회 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, summary)
+}
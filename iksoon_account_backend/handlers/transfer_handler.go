@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	apiErrors "iksoon_account_backend/errors"
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+type TransferHandler struct {
+	DB TransferRepository
+}
+
+type TransferRepository interface {
+	GetTransfers(ledgerID int, startDate, endDate string, accountID int) ([]models.Transfer, error)
+	GetTransferByUUID(ledgerID int, uuid string) (*models.Transfer, error)
+	InsertTransfer(ledgerID int, req models.TransferRequest) (string, error)
+	UpdateTransfer(ledgerID int, uuid string, req models.TransferRequest) error
+	DeleteTransfer(ledgerID int, uuid string) error
+}
+
+// GetTransfersHandler 이체 목록 조회 핸들러
+// uuid 지정 시 단건, 아니면 start_date ~ end_date (기본: 이번 달) 기간과 account_id 로 필터링
+func (h *TransferHandler) GetTransfersHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	query := r.URL.Query()
+	ledgerID := utils.LedgerIDFromRequest(r)
+
+	if uuid := query.Get("uuid"); uuid != "" {
+		transfer, err := h.DB.GetTransferByUUID(ledgerID, uuid)
+		if err != nil {
+			utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("이체 조회 실패"))
+			return
+		}
+		utils.SendSuccessResponse(w, transfer)
+		return
+	}
+
+	now := utils.GetCurrentKST()
+	startDate, ok := parseAccountDate(w, query.Get("start_date"), "start_date")
+	if !ok {
+		return
+	}
+	if query.Get("start_date") == "" {
+		startDate = utils.FormatDateKST(utils.StartOfMonthKST(now))
+	}
+	endDate, ok := parseAccountDate(w, query.Get("end_date"), "end_date")
+	if !ok {
+		return
+	}
+	if query.Get("end_date") == "" {
+		endDate = utils.FormatDateKST(utils.EndOfMonthKST(now))
+	}
+	if endDate < startDate {
+		utils.SendError(w, apiErrors.ErrInvalidDateRange.WithMessage("종료일은 시작일 이후여야 합니다"))
+		return
+	}
+
+	accountID := 0
+	if value := query.Get("account_id"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil || id <= 0 {
+			utils.SendError(w, apiErrors.ErrInvalidRequest.WithMessage("account_id가 올바르지 않습니다"))
+			return
+		}
+		accountID = id
+	}
+
+	transfers, err := h.DB.GetTransfers(ledgerID, startDate, endDate, accountID)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("이체 목록 조회 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, transfers)
+}
+
+// InsertTransferHandler 이체 등록 핸들러
+func (h *TransferHandler) InsertTransferHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	var req models.TransferRequest
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+	req.User = utils.ResolveRequestUser(r, req.User)
+
+	ledgerID := utils.LedgerIDFromRequest(r)
+	uuid, err := h.DB.InsertTransfer(ledgerID, req)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("이체 등록 실패"))
+		return
+	}
+
+	created, err := h.DB.GetTransferByUUID(ledgerID, uuid)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("등록된 이체 조회 실패"))
+		return
+	}
+
+	utils.Info("이체 등록: UUID=%s, %s → %s, 금액=%d", uuid, created.FromAccountName, created.ToAccountName, created.Money)
+	utils.SendCreatedResponse(w, created)
+}
+
+// UpdateTransferHandler 이체 수정 핸들러
+func (h *TransferHandler) UpdateTransferHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodPut) {
+		return
+	}
+
+	uuid := r.URL.Query().Get("uuid")
+	if uuid == "" {
+		utils.SendError(w, apiErrors.ErrMissingRequired.WithMessage("uuid는 필수입니다"))
+		return
+	}
+
+	var req models.TransferRequest
+	if !utils.ValidateJSONRequest(w, r, &req) {
+		return
+	}
+	req.User = utils.ResolveRequestUser(r, req.User)
+
+	ledgerID := utils.LedgerIDFromRequest(r)
+	if err := h.DB.UpdateTransfer(ledgerID, uuid, req); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("이체 수정 실패"))
+		return
+	}
+
+	updated, err := h.DB.GetTransferByUUID(ledgerID, uuid)
+	if err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("수정된 이체 조회 실패"))
+		return
+	}
+
+	utils.SendSuccessResponse(w, updated)
+}
+
+// DeleteTransferHandler 이체 삭제 핸들러
+func (h *TransferHandler) DeleteTransferHandler(w http.ResponseWriter, r *http.Request) {
+	if !utils.ValidateHTTPMethod(w, r, http.MethodDelete) {
+		return
+	}
+
+	uuid := r.URL.Query().Get("uuid")
+	if uuid == "" {
+		utils.SendError(w, apiErrors.ErrMissingRequired.WithMessage("uuid는 필수입니다"))
+		return
+	}
+
+	if err := h.DB.DeleteTransfer(utils.LedgerIDFromRequest(r), uuid); err != nil {
+		utils.SendErrorFrom(w, err, apiErrors.ErrDatabaseConnection.WithDetails("이체 삭제 실패"))
+		return
+	}
+
+	utils.Info("이체 삭제: UUID=%s", uuid)
+	utils.SendSuccessResponse(w, utils.CreateSuccessMessage("이체가 삭제되었습니다."))
+}
@@ -15,9 +15,9 @@ type UserHandler struct {
 }
 
 type UserRepository interface {
-	GetUsers() ([]models.User, error)
+	GetUsers(ledgerID int) ([]models.User, error)
 	GetUserByID(id int) (*models.User, error)
-	CreateUser(name, email string) (int64, error)
+	CreateUser(ledgerID int, name, email string) (int64, error)
 	UpdateUser(id int, name, email string) error
 	DeleteUser(id int) error
 	ForceDeleteUser(id int) error
@@ -33,7 +33,7 @@ func (h *UserHandler) GetUsersHandler(w http.ResponseWriter, r *http.Request) {
 
 	utils.Debug("사용자 목록 조회 요청")
 
-	users, err := h.DB.GetUsers()
+	users, err := h.DB.GetUsers(utils.LedgerIDFromRequest(r))
 	if err != nil {
 		utils.LogDatabaseError("사용자 목록 조회", err)
 		utils.SendError(w, apiErrors.ErrDatabaseConnection.WithDetails("사용자 목록 조회 실패"))
@@ -70,7 +70,7 @@ func (h *UserHandler) CreateUserHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	userID, err := h.DB.CreateUser(req.Name, req.Email)
+	userID, err := h.DB.CreateUser(utils.LedgerIDFromRequest(r), req.Name, req.Email)
 	if err != nil {
 		if err.Error() == "이미 존재하는 사용자 이름입니다" {
 			utils.SendError(w, apiErrors.ErrAlreadyExists.WithMessage("이미 존재하는 사용자 이름입니다"))
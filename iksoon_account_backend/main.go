@@ -3,10 +3,13 @@ package main
 import (
 	"log"
 	"net/http"
+	"os"
+	"time"
 
 	"iksoon_account_backend/config"
 	"iksoon_account_backend/database"
 	"iksoon_account_backend/handlers"
+	"iksoon_account_backend/scheduler"
 	"iksoon_account_backend/utils"
 )
 
@@ -37,14 +40,21 @@ func main() {
 		cfg.PrintConfig()
 	}
 
-	// SQLite 데이터베이스 연결 및 테이블 초기화
+	// SQLite 데이터베이스 연결 및 스키마 마이그레이션 적용
 	dbPath := cfg.GetDBPath()
+
+	// 마이그레이션 CLI 명령 (예: ./main migrate status, ./main migrate down 1) 은 서버를 띄우지 않고 종료
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrateCommand(dbPath, os.Args[2:]))
+	}
+
 	db, err := database.InitDB(dbPath)
 	if err != nil {
 		utils.Error("데이터베이스 초기화 오류: %v", err)
 		log.Fatalf("데이터베이스 초기화 실패")
 	}
 	defer db.Conn.Close()
+	db.AttachmentDir = cfg.GetAttachmentDir()
 
 	utils.Info("데이터베이스 연결 성공: %s", dbPath)
 
@@ -54,18 +64,69 @@ func main() {
 	keywordHandler := &handlers.KeywordHandler{DB: db}
 	paymentMethodHandler := &handlers.PaymentMethodHandler{DB: db}
 	depositPathHandler := &handlers.DepositPathHandler{DB: db}
-	outAccountHandler := &handlers.OutAccountHandler{DB: db, KeywordDB: db}
-	inAccountHandler := &handlers.InAccountHandler{DB: db, KeywordDB: db}
+	outAccountHandler := &handlers.OutAccountHandler{DB: db, KeywordDB: db, CurrencyDB: db, Rules: db}
+	inAccountHandler := &handlers.InAccountHandler{DB: db, KeywordDB: db, CurrencyDB: db, Rules: db}
 	statisticsHandler := &handlers.StatisticsHandler{DB: db}
 	categoryBudgetHandler := handlers.NewCategoryBudgetHandler(db)
+	recurringHandler := &handlers.RecurringHandler{DB: db, KeywordDB: db}
+	ledgerHandler := &handlers.LedgerHandler{DB: db}
+	importHandler := &handlers.ImportHandler{DB: db}
+	categorizationRuleHandler := &handlers.CategorizationRuleHandler{DB: db}
+	exportHandler := &handlers.ExportHandler{DB: db}
+	accountHandler := &handlers.AccountHandler{DB: db}
+	transferHandler := &handlers.TransferHandler{DB: db}
+	installmentHandler := &handlers.InstallmentHandler{DB: db, KeywordDB: db}
+	splitHandler := &handlers.SplitHandler{DB: db, KeywordDB: db}
+	tagHandler := &handlers.TagHandler{DB: db}
+	transactionHandler := &handlers.TransactionHandler{DB: db}
+	searchHandler := &handlers.SearchHandler{DB: db}
+	attachmentHandler := &handlers.AttachmentHandler{DB: db, MaxSize: cfg.GetAttachmentMaxSize()}
+	reimbursementHandler := &handlers.ReimbursementHandler{DB: db}
+	settlementHandler := &handlers.SettlementHandler{DB: db}
+	currencyHandler := &handlers.CurrencyHandler{DB: db}
+	savingsGoalHandler := &handlers.SavingsGoalHandler{DB: db}
+	cardBillingHandler := &handlers.CardBillingHandler{DB: db}
+	backupHandler := &handlers.BackupHandler{DB: db, Dir: cfg.GetBackupDir()}
+	authHandler := &handlers.AuthHandler{
+		DB:         db,
+		Enabled:    cfg.AuthEnabled,
+		SessionTTL: time.Duration(cfg.SessionTTLHours) * time.Hour,
+		Throttle: &utils.LoginThrottle{
+			MaxFailuresPerName: 5,
+			MaxFailuresPerIP:   20,
+			Window:             15 * time.Minute,
+		},
+		Proxies: cfg.GetTrustedProxies(),
+	}
+
+	if !cfg.AuthEnabled {
+		utils.Warning("인증이 비활성화되어 있습니다 (AUTH_ENABLED=false). 외부에 노출하는 경우 반드시 활성화하세요 (백업/강제 삭제 등 관리자 기능은 사용 불가)")
+	}
+	if cfg.CorsAllowedOrigin == "*" {
+		utils.Warning("CORS_ALLOWED_ORIGIN=* 로 모든 Origin 의 요청을 허용합니다. 프론트엔드 주소로 제한하세요")
+	}
+
+	// 정기 거래 자동 생성 스케줄러 시작 (중단 기간 동안 놓친 주기 포함)
+	scheduler.StartRecurringScheduler(db, time.Duration(cfg.RecurringIntervalMinutes)*time.Minute)
+
+	// 자동 백업 스케줄러 시작 (보관 개수를 넘는 오래된 자동 백업은 삭제)
+	scheduler.StartBackupScheduler(db, backupHandler.Dir, time.Duration(cfg.BackupIntervalHours)*time.Hour, cfg.BackupRetention)
 
-	// CORS(Cross-Origin Resource Sharing) 및 HTTP 요청 로깅을 위한 미들웨어
+	// 기준치 알림 스케줄러 시작 (임계값 도달 확인, 웹훅/이메일 발송 및 실패 시 재시도)
+	notificationScheduler := scheduler.StartNotificationScheduler(db, cfg.GetSMTPConfig(), time.Duration(cfg.NotificationIntervalSeconds)*time.Second)
+	outAccountHandler.Alerts = notificationScheduler
+	categorizationRuleHandler.Alerts = notificationScheduler
+	notificationHandler := &handlers.NotificationHandler{DB: db, Alerts: notificationScheduler}
+
+	// CORS(Cross-Origin Resource Sharing), HTTP 요청 로깅, 인증 및 가계부 선택을 위한 미들웨어
 	enableCorsAndLogging := func(next http.Handler) http.Handler {
+		authenticated := backupHandler.ConnGuard(authHandler.Middleware(ledgerHandler.Middleware(next)))
 		return utils.LogHTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// 프론트엔드에서의 요청을 허용하기 위한 CORS 헤더 설정
-			w.Header().Set("Access-Control-Allow-Origin", "*")
+			w.Header().Set("Access-Control-Allow-Origin", cfg.CorsAllowedOrigin)
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS, PATCH")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, "+handlers.LedgerHeader)
+			w.Header().Set("Access-Control-Expose-Headers", "Content-Disposition")
 
 			// 브라우저 preflight 요청 처리
 			if r.Method == "OPTIONS" {
@@ -73,20 +134,34 @@ func main() {
 				return
 			}
 
-			// 다음 핸들러로 요청 전달
-			next.ServeHTTP(w, r)
+			// 세션 및 가계부 확인 후 다음 핸들러로 요청 전달
+			authenticated.ServeHTTP(w, r)
 		}))
 	}
 
 	// RESTful API 라우트 설정 - 각 엔드포인트에 미들웨어 적용
 
+	// 인증 API - 로그인/로그아웃 및 비밀번호 설정
+	http.Handle("/auth/login", enableCorsAndLogging(http.HandlerFunc(authHandler.LoginHandler)))          // POST: 로그인 (세션 토큰 발급)
+	http.Handle("/auth/logout", enableCorsAndLogging(http.HandlerFunc(authHandler.LogoutHandler)))        // POST: 로그아웃 (세션 토큰 폐기)
+	http.Handle("/auth/me", enableCorsAndLogging(http.HandlerFunc(authHandler.MeHandler)))                // GET: 현재 로그인 사용자 조회
+	http.Handle("/auth/password", enableCorsAndLogging(http.HandlerFunc(authHandler.SetPasswordHandler))) // POST: 비밀번호 설정/변경
+
+	// 가계부 관리 API - 가족/가구 단위 가계부와 멤버 관리 (X-Ledger-ID 헤더로 대상 가계부 지정)
+	http.Handle("/ledgers", enableCorsAndLogging(http.HandlerFunc(ledgerHandler.GetLedgersHandler)))                        // GET: 소속 가계부 목록 조회
+	http.Handle("/ledgers/create", enableCorsAndLogging(http.HandlerFunc(ledgerHandler.CreateLedgerHandler)))               // POST: 가계부 생성 (기본 카테고리/결제수단/입금경로 포함)
+	http.Handle("/ledgers/update", enableCorsAndLogging(http.HandlerFunc(ledgerHandler.UpdateLedgerHandler)))               // PUT: 현재 가계부 이름 수정 (소유자)
+	http.Handle("/ledgers/members", enableCorsAndLogging(http.HandlerFunc(ledgerHandler.GetLedgerMembersHandler)))          // GET: 현재 가계부 멤버 목록
+	http.Handle("/ledgers/members/add", enableCorsAndLogging(http.HandlerFunc(ledgerHandler.AddLedgerMemberHandler)))       // POST: 멤버 추가/역할 변경 (소유자)
+	http.Handle("/ledgers/members/remove", enableCorsAndLogging(http.HandlerFunc(ledgerHandler.RemoveLedgerMemberHandler))) // DELETE: 멤버 제거 (소유자 또는 본인)
+
 	// 사용자 관리 API - 사용자 CRUD 및 사용 여부 확인
-	http.Handle("/users", enableCorsAndLogging(http.HandlerFunc(userHandler.GetUsersHandler)))                     // GET: 사용자 목록 조회
-	http.Handle("/users/create", enableCorsAndLogging(http.HandlerFunc(userHandler.CreateUserHandler)))            // POST: 신규 사용자 생성
-	http.Handle("/users/update", enableCorsAndLogging(http.HandlerFunc(userHandler.UpdateUserHandler)))            // PUT: 사용자 정보 수정
-	http.Handle("/users/delete", enableCorsAndLogging(http.HandlerFunc(userHandler.DeleteUserHandler)))            // DELETE: 사용자 삭제 (참조 데이터 있으면 실패)
-	http.Handle("/users/force-delete", enableCorsAndLogging(http.HandlerFunc(userHandler.ForceDeleteUserHandler))) // DELETE: 사용자 강제 삭제 (참조 데이터 포함)
-	http.Handle("/users/check-usage", enableCorsAndLogging(http.HandlerFunc(userHandler.CheckUserUsageHandler)))   // GET: 사용자 사용 여부 확인
+	http.Handle("/users", enableCorsAndLogging(http.HandlerFunc(userHandler.GetUsersHandler)))                             // GET: 사용자 목록 조회
+	http.Handle("/users/create", enableCorsAndLogging(authHandler.RequireAdminWhenEnabled(userHandler.CreateUserHandler))) // POST: 신규 사용자 생성
+	http.Handle("/users/update", enableCorsAndLogging(authHandler.RequireAdminWhenEnabled(userHandler.UpdateUserHandler))) // PUT: 사용자 정보 수정
+	http.Handle("/users/delete", enableCorsAndLogging(authHandler.RequireAdminWhenEnabled(userHandler.DeleteUserHandler))) // DELETE: 사용자 삭제 (참조 데이터 있으면 실패)
+	http.Handle("/users/force-delete", enableCorsAndLogging(authHandler.RequireAdmin(userHandler.ForceDeleteUserHandler))) // DELETE: 사용자 강제 삭제 (참조 데이터 포함)
+	http.Handle("/users/check-usage", enableCorsAndLogging(http.HandlerFunc(userHandler.CheckUserUsageHandler)))           // GET: 사용자 사용 여부 확인
 
 	// 카테고리 관리 API - 지출/수입 카테고리 CRUD
 	http.Handle("/categories", enableCorsAndLogging(http.HandlerFunc(categoryHandler.GetCategoriesHandler)))                    // GET: 카테고리 목록 조회 (type 파라미터로 out/in 필터링)
@@ -110,6 +185,10 @@ func main() {
 	http.Handle("/payment-methods/delete", enableCorsAndLogging(http.HandlerFunc(paymentMethodHandler.DeletePaymentMethodHandler)))
 	http.Handle("/payment-methods/force-delete", enableCorsAndLogging(http.HandlerFunc(paymentMethodHandler.ForceDeletePaymentMethodHandler)))
 
+	// 카드 명세서 API - 카드 결제수단별 명세서 마감일/결제일과 결제월별 청구 내역
+	http.Handle("/payment-methods/billing", enableCorsAndLogging(http.HandlerFunc(cardBillingHandler.SetCardBillingHandler))) // PUT: 카드 명세서 마감일/결제일 설정 (id)
+	http.Handle("/card-statements", enableCorsAndLogging(http.HandlerFunc(cardBillingHandler.GetCardStatementsHandler)))      // GET: 결제월별 카드 명세서 (due_month, payment_method_id)
+
 	// 입금경로 관리 API
 	http.Handle("/deposit-paths", enableCorsAndLogging(http.HandlerFunc(depositPathHandler.GetDepositPathsHandler)))
 	http.Handle("/deposit-paths/create", enableCorsAndLogging(http.HandlerFunc(depositPathHandler.CreateDepositPathHandler)))
@@ -127,6 +206,15 @@ func main() {
 	http.Handle("/v2/out-account/update", enableCorsAndLogging(http.HandlerFunc(outAccountHandler.UpdateOutAccountHandler)))
 	http.Handle("/v2/out-account/delete", enableCorsAndLogging(http.HandlerFunc(outAccountHandler.DeleteOutAccountHandler)))
 
+	// 분할 지출 API - 한 번의 결제를 카테고리/키워드별 항목으로 나눔 (통계/예산은 항목 단위 집계)
+	http.Handle("/v2/out-account/splits", enableCorsAndLogging(http.HandlerFunc(splitHandler.GetOutAccountSplitsHandler)))           // GET: 분할 항목 조회 (uuid)
+	http.Handle("/v2/out-account/splits/update", enableCorsAndLogging(http.HandlerFunc(splitHandler.UpdateOutAccountSplitsHandler))) // PUT: 분할 항목 전체 교체 (uuid, 빈 목록이면 분할 해제)
+
+	// 할부 API - 지출 등록 시 installment_months 로 생성된 할부를 묶음 단위로 조회/수정/취소
+	http.Handle("/v2/installments", enableCorsAndLogging(http.HandlerFunc(installmentHandler.GetInstallmentsHandler)))          // GET: 할부 목록 (active=true) 또는 uuid 단건 (회차 포함)
+	http.Handle("/v2/installments/update", enableCorsAndLogging(http.HandlerFunc(installmentHandler.UpdateInstallmentHandler))) // PUT: 할부 수정 (uuid, 회차 재생성)
+	http.Handle("/v2/installments/delete", enableCorsAndLogging(http.HandlerFunc(installmentHandler.DeleteInstallmentHandler))) // DELETE: 할부 취소 (uuid, 회차 함께 삭제)
+
 	// 수입 관리 API (새로운 구조)
 	http.Handle("/v2/in-account/insert", enableCorsAndLogging(http.HandlerFunc(inAccountHandler.InsertInAccountHandler)))
 	http.Handle("/v2/in-account", enableCorsAndLogging(http.HandlerFunc(inAccountHandler.GetInAccountByDateHandler)))
@@ -136,6 +224,52 @@ func main() {
 	http.Handle("/v2/in-account/update", enableCorsAndLogging(http.HandlerFunc(inAccountHandler.UpdateInAccountHandler)))
 	http.Handle("/v2/in-account/delete", enableCorsAndLogging(http.HandlerFunc(inAccountHandler.DeleteInAccountHandler)))
 
+	// 수입/지출 통합 조회 API - 두 거래를 type 으로 구분한 하나의 정렬된 목록과 날짜별 순액 (필터/정렬/커서는 /v2/out-accounts 와 동일)
+	http.Handle("/v2/transactions", enableCorsAndLogging(http.HandlerFunc(transactionHandler.GetTransactionsHandler)))   // GET: 통합 목록 (start_date, end_date, type, keyword, limit, cursor, sort, order, 필터)
+	http.Handle("/v2/transactions/daily", enableCorsAndLogging(http.HandlerFunc(transactionHandler.GetDailyNetHandler))) // GET: 날짜별 수입/지출/순액 (start_date, end_date, 필터)
+
+	// 거래 검색 API - 메모/키워드/카테고리/결제수단·입금경로/사용자 전체 기간 검색 (FTS5 trigram 인덱스, 없으면 부분 일치)
+	http.Handle("/v2/search", enableCorsAndLogging(http.HandlerFunc(searchHandler.SearchTransactionsHandler))) // GET: 거래 검색 (q, type, start_date, end_date, limit, offset)
+
+	// 태그 API - 수입/지출에 여러 개의 자유 태그를 붙여 여행/행사처럼 카테고리를 넘나드는 비용 집계 (목록/검색 API 는 tag_id 로 필터링)
+	http.Handle("/tags", enableCorsAndLogging(http.HandlerFunc(tagHandler.GetTagsHandler)))                      // GET: 태그 목록 (사용 건수 포함)
+	http.Handle("/tags/create", enableCorsAndLogging(http.HandlerFunc(tagHandler.CreateTagHandler)))             // POST: 태그 생성
+	http.Handle("/tags/update", enableCorsAndLogging(http.HandlerFunc(tagHandler.UpdateTagHandler)))             // PUT: 태그 이름 변경 (id)
+	http.Handle("/tags/delete", enableCorsAndLogging(http.HandlerFunc(tagHandler.DeleteTagHandler)))             // DELETE: 태그 삭제 (id, 거래는 유지)
+	http.Handle("/tags/statistics", enableCorsAndLogging(http.HandlerFunc(tagHandler.GetTagStatisticsHandler)))  // GET: 태그별 수입/지출 합계 (id, start_date, end_date)
+	http.Handle("/v2/tags/assign", enableCorsAndLogging(http.HandlerFunc(tagHandler.SetTransactionTagsHandler))) // PUT: 수입/지출 태그 전체 교체 (type, uuid, tags)
+
+	// 첨부파일 API - 수입/지출에 영수증 이미지/PDF 첨부 (파일은 DB 옆 첨부파일 디렉토리에 저장, 백업/내보내기에 포함)
+	http.Handle("/v2/attachments", enableCorsAndLogging(http.HandlerFunc(attachmentHandler.GetAttachmentsHandler)))              // GET: 첨부파일 목록 (type, uuid)
+	http.Handle("/v2/attachments/upload", enableCorsAndLogging(http.HandlerFunc(attachmentHandler.UploadAttachmentHandler)))     // POST: 첨부파일 업로드 (multipart: file, type, uuid)
+	http.Handle("/v2/attachments/download", enableCorsAndLogging(http.HandlerFunc(attachmentHandler.DownloadAttachmentHandler))) // GET: 첨부파일 다운로드 (uuid, inline)
+	http.Handle("/v2/attachments/delete", enableCorsAndLogging(http.HandlerFunc(attachmentHandler.DeleteAttachmentHandler)))     // DELETE: 첨부파일 삭제 (uuid)
+
+	// 환급 API - 개인 카드로 낸 업무 경비처럼 나중에 돌려받는 지출을 환급 수입과 연결 (연결된 지출/수입은 통계에서 제외)
+	http.Handle("/v2/reimbursements", enableCorsAndLogging(http.HandlerFunc(reimbursementHandler.GetReimbursementsHandler)))                        // GET: 환급 대상 목록 (status=pending|settled, user)
+	http.Handle("/v2/reimbursements/mark", enableCorsAndLogging(http.HandlerFunc(reimbursementHandler.MarkReimbursableHandler)))                    // POST: 지출을 환급 대상으로 지정 ({"uuid"})
+	http.Handle("/v2/reimbursements/unmark", enableCorsAndLogging(http.HandlerFunc(reimbursementHandler.UnmarkReimbursableHandler)))                // DELETE: 환급 대상 해제 (uuid)
+	http.Handle("/v2/reimbursements/settle", enableCorsAndLogging(http.HandlerFunc(reimbursementHandler.SettleReimbursementsHandler)))              // PUT: 환급 수입 연결 ({"out_uuids", "in_uuid"})
+	http.Handle("/v2/reimbursements/unsettle", enableCorsAndLogging(http.HandlerFunc(reimbursementHandler.UnsettleReimbursementHandler)))           // PUT: 환급 수입 연결 해제 (uuid, 환급 대기로)
+	http.Handle("/v2/reimbursements/outstanding", enableCorsAndLogging(http.HandlerFunc(reimbursementHandler.GetOutstandingReimbursementsHandler))) // GET: 사용자별 미환급 금액 (user)
+
+	// 공동 지출 정산 API - 분담 규칙(균등/비율, 카테고리별)으로 멤버별 잔액과 최소 송금 목록 계산, 정산 기록 시 잔액 초기화
+	http.Handle("/v2/sharing-rules", enableCorsAndLogging(http.HandlerFunc(settlementHandler.GetSharingRulesHandler)))            // GET: 분담 규칙 목록
+	http.Handle("/v2/sharing-rules/create", enableCorsAndLogging(http.HandlerFunc(settlementHandler.CreateSharingRuleHandler)))   // POST: 분담 규칙 생성 ({"category_id", "method", "shares"})
+	http.Handle("/v2/sharing-rules/update", enableCorsAndLogging(http.HandlerFunc(settlementHandler.UpdateSharingRuleHandler)))   // PUT: 분담 규칙 수정 (id)
+	http.Handle("/v2/sharing-rules/delete", enableCorsAndLogging(http.HandlerFunc(settlementHandler.DeleteSharingRuleHandler)))   // DELETE: 분담 규칙 삭제 (id)
+	http.Handle("/v2/settlements", enableCorsAndLogging(http.HandlerFunc(settlementHandler.GetSettlementsHandler)))               // GET: 기록된 정산 목록
+	http.Handle("/v2/settlements/balance", enableCorsAndLogging(http.HandlerFunc(settlementHandler.GetSettlementBalanceHandler))) // GET: 멤버별 잔액과 송금 목록 (start_date, end_date)
+	http.Handle("/v2/settlements/create", enableCorsAndLogging(http.HandlerFunc(settlementHandler.RecordSettlementHandler)))      // POST: 정산 기록 ({"end_date", "memo"})
+	http.Handle("/v2/settlements/delete", enableCorsAndLogging(http.HandlerFunc(settlementHandler.DeleteSettlementHandler)))      // DELETE: 정산 기록 삭제 (id)
+
+	// 외화/환율 API
+	http.Handle("/v2/exchange-rates", enableCorsAndLogging(http.HandlerFunc(currencyHandler.GetExchangeRatesHandler)))           // GET: 환율 목록 (currency)
+	http.Handle("/v2/exchange-rates/create", enableCorsAndLogging(http.HandlerFunc(currencyHandler.SaveExchangeRateHandler)))    // POST: 환율 등록/덮어쓰기 ({"currency", "date", "rate"})
+	http.Handle("/v2/exchange-rates/delete", enableCorsAndLogging(http.HandlerFunc(currencyHandler.DeleteExchangeRateHandler)))  // DELETE: 환율 삭제 (id)
+	http.Handle("/v2/exchange-rates/import", enableCorsAndLogging(http.HandlerFunc(currencyHandler.ImportExchangeRatesHandler))) // POST: 환율 CSV/XLSX 가져오기 (multipart: file)
+	http.Handle("/v2/currency/assign", enableCorsAndLogging(http.HandlerFunc(currencyHandler.SetTransactionCurrencyHandler)))    // PUT: 수입/지출 외화 금액 지정/해제 ({"type", "uuid", "currency", "original_money"})
+
 	// 통계 API
 	http.Handle("/statistics", enableCorsAndLogging(http.HandlerFunc(statisticsHandler.GetStatisticsHandler)))
 	http.Handle("/statistics/category-keywords", enableCorsAndLogging(http.HandlerFunc(statisticsHandler.GetCategoryKeywordStatisticsHandler)))
@@ -151,6 +285,84 @@ func main() {
 	http.Handle("/category-budgets/delete", enableCorsAndLogging(http.HandlerFunc(categoryBudgetHandler.DeleteCategoryBudgetHandler)))
 	http.Handle("/category-budgets/usage", enableCorsAndLogging(http.HandlerFunc(categoryBudgetHandler.GetBudgetUsageHandler)))
 
+	// 기준치 적용 기간 API - 월별로 적용된 기준치 이력과 기준치 대비 실제 지출
+	http.Handle("/category-budgets/periods", enableCorsAndLogging(http.HandlerFunc(categoryBudgetHandler.GetBudgetPeriodsHandler)))              // GET: 적용 기간 이력 (id)
+	http.Handle("/category-budgets/periods/set", enableCorsAndLogging(http.HandlerFunc(categoryBudgetHandler.SetBudgetPeriodHandler)))           // PUT: 특정 월부터 적용할 기준치 (id, {"effective_from", "monthly_budget", "yearly_budget"})
+	http.Handle("/category-budgets/periods/delete", enableCorsAndLogging(http.HandlerFunc(categoryBudgetHandler.DeleteBudgetPeriodHandler)))     // DELETE: 적용 기간 삭제 (id, effective_from)
+	http.Handle("/category-budgets/monthly-report", enableCorsAndLogging(http.HandlerFunc(categoryBudgetHandler.GetBudgetMonthlyReportHandler))) // GET: 월별 기준치 대비 실제 지출 (user, category_id, start_month, end_month)
+
+	// 기준치 이월 API - 남은 월 기준치(초과 지출)를 다음 달로 이월하는 봉투식 기준치
+	http.Handle("/category-budgets/rollover", enableCorsAndLogging(http.HandlerFunc(categoryBudgetHandler.SetBudgetRolloverHandler))) // PUT: 이월 모드 설정 (id, {"enabled", "cap", "from"})
+
+	// 기준치 알림 API - 사용률 임계값 도달 시 웹훅/이메일 채널로 기간당 한 번 알림
+	http.Handle("/category-budgets/alerts", enableCorsAndLogging(http.HandlerFunc(notificationHandler.GetBudgetAlertsHandler)))                    // GET: 알림 임계값 목록 (budget_id)
+	http.Handle("/category-budgets/alerts/create", enableCorsAndLogging(http.HandlerFunc(notificationHandler.CreateBudgetAlertHandler)))           // POST: 임계값 생성 ({"budget_id", "period", "percent"})
+	http.Handle("/category-budgets/alerts/delete", enableCorsAndLogging(http.HandlerFunc(notificationHandler.DeleteBudgetAlertHandler)))           // DELETE: 임계값 삭제 (id)
+	http.Handle("/v2/notifications/channels", enableCorsAndLogging(http.HandlerFunc(notificationHandler.GetNotificationChannelsHandler)))          // GET: 알림 채널 목록
+	http.Handle("/v2/notifications/channels/create", enableCorsAndLogging(http.HandlerFunc(notificationHandler.CreateNotificationChannelHandler))) // POST: 채널 생성 ({"type", "name", "target", "enabled"})
+	http.Handle("/v2/notifications/channels/update", enableCorsAndLogging(http.HandlerFunc(notificationHandler.UpdateNotificationChannelHandler))) // PUT: 채널 수정 (id)
+	http.Handle("/v2/notifications/channels/delete", enableCorsAndLogging(http.HandlerFunc(notificationHandler.DeleteNotificationChannelHandler))) // DELETE: 채널 삭제 (id)
+	http.Handle("/v2/notifications/channels/test", enableCorsAndLogging(http.HandlerFunc(notificationHandler.TestNotificationChannelHandler)))     // POST: 테스트 알림 발송 (id)
+	http.Handle("/v2/notifications/logs", enableCorsAndLogging(http.HandlerFunc(notificationHandler.GetNotificationLogsHandler)))                  // GET: 발송 기록 (status, limit)
+	http.Handle("/v2/notifications/logs/retry", enableCorsAndLogging(http.HandlerFunc(notificationHandler.RetryNotificationHandler)))              // POST: 실패한 알림 재시도 (id)
+
+	// 저축 목표 API - 입금경로/수입 카테고리의 수입으로 진행률 계산
+	http.Handle("/v2/savings-goals", enableCorsAndLogging(http.HandlerFunc(savingsGoalHandler.GetSavingsGoalsHandler)))                 // GET: 저축 목표 목록 (진행 상황 포함)
+	http.Handle("/v2/savings-goals/progress", enableCorsAndLogging(http.HandlerFunc(savingsGoalHandler.GetSavingsGoalProgressHandler))) // GET: 진행 상황과 월별 적립 내역 (id)
+	http.Handle("/v2/savings-goals/create", enableCorsAndLogging(http.HandlerFunc(savingsGoalHandler.CreateSavingsGoalHandler)))        // POST: 저축 목표 생성
+	http.Handle("/v2/savings-goals/update", enableCorsAndLogging(http.HandlerFunc(savingsGoalHandler.UpdateSavingsGoalHandler)))        // PUT: 저축 목표 수정 (id)
+	http.Handle("/v2/savings-goals/delete", enableCorsAndLogging(http.HandlerFunc(savingsGoalHandler.DeleteSavingsGoalHandler)))        // DELETE: 저축 목표 삭제 (id)
+
+	// 정기 거래 규칙 API - 고정 지출/급여 등 주기적 거래 자동 생성
+	http.Handle("/v2/recurring", enableCorsAndLogging(http.HandlerFunc(recurringHandler.GetRecurringRulesHandler)))          // GET: 정기 거래 규칙 목록 조회 (type, id 파라미터)
+	http.Handle("/v2/recurring/create", enableCorsAndLogging(http.HandlerFunc(recurringHandler.CreateRecurringRuleHandler))) // POST: 정기 거래 규칙 생성
+	http.Handle("/v2/recurring/update", enableCorsAndLogging(http.HandlerFunc(recurringHandler.UpdateRecurringRuleHandler))) // PUT: 정기 거래 규칙 수정
+	http.Handle("/v2/recurring/delete", enableCorsAndLogging(http.HandlerFunc(recurringHandler.DeleteRecurringRuleHandler))) // DELETE: 정기 거래 규칙 삭제
+	http.Handle("/v2/recurring/run", enableCorsAndLogging(http.HandlerFunc(recurringHandler.RunRecurringRulesHandler)))      // POST: 정기 거래 즉시 생성
+
+	// 명세서 가져오기 API - 카드사/은행 CSV·XLSX 명세서를 매핑 프로필로 미리보기 후 일괄 등록
+	http.Handle("/import/profiles", enableCorsAndLogging(http.HandlerFunc(importHandler.GetImportProfilesHandler)))          // GET: 가져오기 프로필 목록
+	http.Handle("/import/profiles/create", enableCorsAndLogging(http.HandlerFunc(importHandler.CreateImportProfileHandler))) // POST: 가져오기 프로필 생성
+	http.Handle("/import/profiles/update", enableCorsAndLogging(http.HandlerFunc(importHandler.UpdateImportProfileHandler))) // PUT: 가져오기 프로필 수정
+	http.Handle("/import/profiles/delete", enableCorsAndLogging(http.HandlerFunc(importHandler.DeleteImportProfileHandler))) // DELETE: 가져오기 프로필 삭제
+	http.Handle("/import/preview", enableCorsAndLogging(http.HandlerFunc(importHandler.PreviewImportHandler)))               // POST: 명세서 미리보기 (multipart: file, profile_id)
+	http.Handle("/import/commit", enableCorsAndLogging(http.HandlerFunc(importHandler.CommitImportHandler)))                 // POST: 승인한 행 일괄 등록 (단일 트랜잭션)
+
+	// 자동 분류 규칙 API - 메모/금액/사용자 조건으로 카테고리·키워드·결제수단/입금경로를 우선순위 순서대로 지정 (지출/수입 등록 시 비워 둔 항목과 명세서 가져오기 미리보기에 적용)
+	http.Handle("/rules", enableCorsAndLogging(http.HandlerFunc(categorizationRuleHandler.GetCategorizationRulesHandler)))          // GET: 규칙 목록 (type, 평가 순서대로)
+	http.Handle("/rules/create", enableCorsAndLogging(http.HandlerFunc(categorizationRuleHandler.CreateCategorizationRuleHandler))) // POST: 규칙 생성
+	http.Handle("/rules/update", enableCorsAndLogging(http.HandlerFunc(categorizationRuleHandler.UpdateCategorizationRuleHandler))) // PUT: 규칙 수정 (id)
+	http.Handle("/rules/delete", enableCorsAndLogging(http.HandlerFunc(categorizationRuleHandler.DeleteCategorizationRuleHandler))) // DELETE: 규칙 삭제 (id)
+	http.Handle("/rules/test", enableCorsAndLogging(http.HandlerFunc(categorizationRuleHandler.TestCategorizationRulesHandler)))    // POST: 예시 거래의 분류 결과 확인 ({"type", "memo", "user", "money"})
+	http.Handle("/rules/apply", enableCorsAndLogging(http.HandlerFunc(categorizationRuleHandler.ApplyCategorizationRulesHandler)))  // POST: 기간 내 기존 거래에 규칙 다시 적용 ({"start_date", "end_date", "type", "rule_id", "dry_run"})
+
+	// 계좌 API - 실제 은행/카드 계좌 잔액 (연결된 결제수단/입금경로 거래로 계산) 및 잔액 대조
+	http.Handle("/accounts", enableCorsAndLogging(http.HandlerFunc(accountHandler.GetAccountsHandler)))                               // GET: 계좌 목록 조회 (현재 잔액 포함, id 지정 시 단건)
+	http.Handle("/accounts/create", enableCorsAndLogging(http.HandlerFunc(accountHandler.CreateAccountHandler)))                      // POST: 계좌 생성 (개시 잔액/개시일)
+	http.Handle("/accounts/update", enableCorsAndLogging(http.HandlerFunc(accountHandler.UpdateAccountHandler)))                      // PUT: 계좌 수정
+	http.Handle("/accounts/delete", enableCorsAndLogging(http.HandlerFunc(accountHandler.DeleteAccountHandler)))                      // DELETE: 계좌 삭제 (결제수단/입금경로 연결 해제)
+	http.Handle("/accounts/link", enableCorsAndLogging(http.HandlerFunc(accountHandler.LinkAccountHandler)))                          // PUT: 결제수단/입금경로를 계좌에 연결 또는 해제
+	http.Handle("/accounts/balance", enableCorsAndLogging(http.HandlerFunc(accountHandler.GetAccountBalanceHandler)))                 // GET: 기준일 잔액 조회 (id, date)
+	http.Handle("/accounts/balance-history", enableCorsAndLogging(http.HandlerFunc(accountHandler.GetAccountBalanceHistoryHandler)))  // GET: 날짜별 잔액 추이 (id, start_date, end_date)
+	http.Handle("/accounts/reconcile", enableCorsAndLogging(http.HandlerFunc(accountHandler.ReconcileAccountHandler)))                // POST: 실제 잔액 입력 후 차이 확인 (기록 저장)
+	http.Handle("/accounts/reconciliations", enableCorsAndLogging(http.HandlerFunc(accountHandler.GetAccountReconciliationsHandler))) // GET: 잔액 대조 기록 조회 (id)
+
+	// 이체 API - 내 계좌 간 이동 (수입/지출 통계에서 제외, 계좌 잔액에는 반영)
+	http.Handle("/v2/transfers", enableCorsAndLogging(http.HandlerFunc(transferHandler.GetTransfersHandler)))          // GET: 이체 목록 (start_date, end_date, account_id) 또는 uuid 단건
+	http.Handle("/v2/transfers/insert", enableCorsAndLogging(http.HandlerFunc(transferHandler.InsertTransferHandler))) // POST: 이체 등록
+	http.Handle("/v2/transfers/update", enableCorsAndLogging(http.HandlerFunc(transferHandler.UpdateTransferHandler))) // PUT: 이체 수정 (uuid)
+	http.Handle("/v2/transfers/delete", enableCorsAndLogging(http.HandlerFunc(transferHandler.DeleteTransferHandler))) // DELETE: 이체 삭제 (uuid)
+
+	// 내보내기 API - 세무 신고/회계사 전달용 전체 거래 다운로드 (스트리밍)
+	http.Handle("/v2/export", enableCorsAndLogging(http.HandlerFunc(exportHandler.ExportHandler))) // GET: 거래 내보내기 (format=csv|json|xlsx, type=out|in|all, 기간/사용자/카테고리/결제수단 필터)
+
+	// 백업 관리 API (관리자 전용) - 실행 중 스냅샷 생성, 다운로드, 무결성 검사 후 복원
+	http.Handle("/admin/backups", enableCorsAndLogging(authHandler.RequireAdmin(backupHandler.GetBackupsHandler)))              // GET: 백업 목록 조회 (최신순)
+	http.Handle("/admin/backups/create", enableCorsAndLogging(authHandler.RequireAdmin(backupHandler.CreateBackupHandler)))     // POST: 수동 백업 생성
+	http.Handle("/admin/backups/download", enableCorsAndLogging(authHandler.RequireAdmin(backupHandler.DownloadBackupHandler))) // GET: 백업 파일 다운로드 (name 파라미터)
+	http.Handle("/admin/backups/delete", enableCorsAndLogging(authHandler.RequireAdmin(backupHandler.DeleteBackupHandler)))     // DELETE: 백업 파일 삭제 (name 파라미터)
+	http.Handle("/admin/backups/restore", enableCorsAndLogging(authHandler.RequireAdmin(backupHandler.RestoreBackupHandler)))   // POST: 백업 복원 (name 파라미터, 복원 직전 스냅샷 생성)
+
 	// 서비스 상태 확인 API - 로드밸런서 및 모니터링 도구에서 사용
 	http.Handle("/health", enableCorsAndLogging(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
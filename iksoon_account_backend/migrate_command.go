@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"iksoon_account_backend/database"
+)
+
+// migrateUsage 마이그레이션 명령 사용법
+const migrateUsage = `사용법: main migrate <명령>
+
+명령:
+  status      전체 마이그레이션과 적용 여부 출력 (기본)
+  up          적용되지 않은 마이그레이션 적용
+  down [N]    최근 적용된 마이그레이션 N개 되돌리기 (기본 1)`
+
+// runMigrateCommand 스키마 마이그레이션 CLI 명령 실행 (프로세스 종료 코드 반환)
+func runMigrateCommand(dbPath string, args []string) int {
+	command := "status"
+	if len(args) > 0 {
+		command = args[0]
+	}
+
+	steps := 1
+	switch command {
+	case "status", "up":
+	case "down":
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				fmt.Fprintf(os.Stderr, "되돌릴 개수가 올바르지 않습니다: %s\n", args[1])
+				return 2
+			}
+			steps = n
+		}
+	default:
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	db, err := database.OpenDB(dbPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "데이터베이스 연결 실패: %v\n", err)
+		return 1
+	}
+	defer db.Conn.Close()
+
+	switch command {
+	case "up":
+		count, err := db.Migrate()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "마이그레이션 실패: %v\n", err)
+			return 1
+		}
+		fmt.Printf("마이그레이션 %d개 적용\n", count)
+	case "down":
+		rolledBack, err := db.RollbackMigrations(steps)
+		for _, m := range rolledBack {
+			fmt.Printf("되돌림: %d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "되돌리기 중단: %v\n", err)
+			return 1
+		}
+	}
+
+	return printMigrationStatus(db)
+}
+
+// printMigrationStatus 마이그레이션 적용 상태를 표 형태로 출력
+func printMigrationStatus(db *database.DB) int {
+	statuses, err := db.MigrationStatus()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "마이그레이션 상태 조회 실패: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("%-7s %-30s %-9s %-21s %s\n", "VERSION", "NAME", "STATUS", "APPLIED_AT", "DOWN")
+	for _, status := range statuses {
+		state := "pending"
+		if status.Unknown {
+			state = "unknown"
+		} else if status.Applied {
+			state = "applied"
+		}
+		down := "no"
+		if status.Reversible {
+			down = "yes"
+		}
+		fmt.Printf("%-7d %-30s %-9s %-21s %s\n", status.Version, status.Name, state, status.AppliedAt, down)
+	}
+	return 0
+}
@@ -0,0 +1,97 @@
+package models
+
+import "time"
+
+// 계좌 유형 상수
+const (
+	AccountTypeBank  = "bank"
+	AccountTypeCard  = "card"
+	AccountTypeCash  = "cash"
+	AccountTypeOther = "other"
+)
+
+// Account 구조체 - 실제 은행/카드 계좌 (결제수단/입금경로를 연결해 잔액 계산)
+type Account struct {
+	ID               int       `json:"id"`
+	LedgerID         int       `json:"ledger_id"`
+	Name             string    `json:"name"`
+	Type             string    `json:"type"`            // 'bank', 'card', 'cash', 'other'
+	OpeningBalance   int       `json:"opening_balance"` // 개시일 시작 시점 잔액 (카드는 음수 가능)
+	OpeningDate      string    `json:"opening_date"`    // YYYY-MM-DD, 이 날짜 이후 거래만 잔액에 반영
+	Balance          int       `json:"balance"`         // 오늘(KST)까지 반영한 현재 잔액
+	PaymentMethodIDs []int     `json:"payment_method_ids"`
+	DepositPathIDs   []int     `json:"deposit_path_ids"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// AccountRequest 구조체 - 계좌 생성/수정 요청
+type AccountRequest struct {
+	Name           string `json:"name"`
+	Type           string `json:"type"`
+	OpeningBalance int    `json:"opening_balance"`
+	OpeningDate    string `json:"opening_date"` // 비어 있으면 오늘(KST)
+}
+
+// AccountLinkRequest 구조체 - 결제수단/입금경로를 계좌에 연결 (account_id 가 null 이면 연결 해제)
+// 하위 결제수단은 자신의 연결이 없으면 상위 결제수단의 계좌를 따름
+type AccountLinkRequest struct {
+	AccountID       *int `json:"account_id"`
+	PaymentMethodID *int `json:"payment_method_id,omitempty"`
+	DepositPathID   *int `json:"deposit_path_id,omitempty"`
+}
+
+// AccountBalance 구조체 - 기준일 잔액
+type AccountBalance struct {
+	AccountID        int    `json:"account_id"`
+	AccountName      string `json:"account_name"`
+	OpeningBalance   int    `json:"opening_balance"`
+	OpeningDate      string `json:"opening_date"`
+	Date             string `json:"date"`               // 기준일 (해당 일자 거래까지 포함)
+	TotalIncome      int    `json:"total_income"`       // 개시일 ~ 기준일 입금 합계
+	TotalExpense     int    `json:"total_expense"`      // 개시일 ~ 기준일 지출 합계
+	TotalTransferIn  int    `json:"total_transfer_in"`  // 개시일 ~ 기준일 다른 계좌에서 받은 이체 합계
+	TotalTransferOut int    `json:"total_transfer_out"` // 개시일 ~ 기준일 다른 계좌로 보낸 이체 합계
+	Balance          int    `json:"balance"`
+}
+
+// AccountBalancePoint 구조체 - 거래/이체가 있었던 날짜별 잔액 변화
+type AccountBalancePoint struct {
+	Date        string `json:"date"`
+	Income      int    `json:"income"`
+	Expense     int    `json:"expense"`
+	TransferIn  int    `json:"transfer_in"`
+	TransferOut int    `json:"transfer_out"`
+	Balance     int    `json:"balance"` // 해당 일자 거래 반영 후 잔액
+}
+
+// AccountBalanceHistory 구조체 - 기간별 잔액 추이
+type AccountBalanceHistory struct {
+	AccountID    int                   `json:"account_id"`
+	AccountName  string                `json:"account_name"`
+	StartDate    string                `json:"start_date"`
+	EndDate      string                `json:"end_date"`
+	StartBalance int                   `json:"start_balance"` // 시작일 거래 반영 전 잔액
+	EndBalance   int                   `json:"end_balance"`
+	Points       []AccountBalancePoint `json:"points"`
+}
+
+// AccountReconciliationRequest 구조체 - 실제 잔액 대조 요청
+type AccountReconciliationRequest struct {
+	AccountID        int    `json:"account_id"`
+	Date             string `json:"date"`              // YYYY-MM-DD
+	StatementBalance int    `json:"statement_balance"` // 은행/카드사에서 확인한 실제 잔액
+	Memo             string `json:"memo"`
+}
+
+// AccountReconciliation 구조체 - 잔액 대조 기록
+type AccountReconciliation struct {
+	ID               int       `json:"id"`
+	AccountID        int       `json:"account_id"`
+	Date             string    `json:"date"`
+	StatementBalance int       `json:"statement_balance"`
+	ComputedBalance  int       `json:"computed_balance"` // 가계부 기록으로 계산한 잔액
+	Difference       int       `json:"difference"`       // 실제 잔액 - 계산 잔액 (0 이면 일치)
+	Memo             string    `json:"memo"`
+	CreatedAt        time.Time `json:"created_at"`
+}
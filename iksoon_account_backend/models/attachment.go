@@ -0,0 +1,14 @@
+package models
+
+// Attachment 구조체 - 수입/지출에 첨부한 영수증 사진·PDF 인보이스 (파일은 첨부파일 디렉토리에 저장)
+type Attachment struct {
+	UUID        string `json:"uuid"`
+	AccountType string `json:"type"` // 'out' 또는 'in'
+	AccountUUID string `json:"account_uuid"`
+	FileName    string `json:"file_name"` // 업로드한 원본 파일 이름
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	SHA256      string `json:"sha256"`
+	StoredName  string `json:"-"` // 첨부파일 디렉토리 기준 저장 경로
+	CreatedAt   string `json:"created_at"`
+}
@@ -0,0 +1,25 @@
+package models
+
+// 백업 종류
+const (
+	BackupKindAuto       = "auto"        // 스케줄러가 만든 백업 (보관 개수 초과 시 자동 삭제)
+	BackupKindManual     = "manual"      // 관리자가 직접 만든 백업
+	BackupKindPreRestore = "pre_restore" // 복원 직전 현재 DB 스냅샷
+)
+
+// BackupInfo 구조체 - 백업 파일 정보
+type BackupInfo struct {
+	Name        string `json:"name"`
+	Kind        string `json:"kind"`
+	Size        int64  `json:"size"`
+	Attachments int    `json:"attachments"` // 함께 보관한 첨부파일 수
+	CreatedAt   string `json:"created_at"`
+}
+
+// BackupRestoreResult 구조체 - 백업 복원 결과
+type BackupRestoreResult struct {
+	Restored            string `json:"restored"`             // 복원한 백업 파일
+	SafetyBackup        string `json:"safety_backup"`        // 복원 직전 DB 스냅샷 (되돌릴 때 사용)
+	MigrationsApplied   int    `json:"migrations_applied"`   // 복원한 DB에 추가로 적용한 마이그레이션 수
+	AttachmentsRestored int    `json:"attachments_restored"` // 백업과 함께 복원한 첨부파일 수
+}
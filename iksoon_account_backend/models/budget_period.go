@@ -0,0 +1,50 @@
+package models
+
+// BudgetPeriod 구조체 - 기준치 적용 기간 (effective_from 월부터 다음 기간 전까지 적용)
+type BudgetPeriod struct {
+	BudgetID      int    `json:"budget_id"`
+	EffectiveFrom string `json:"effective_from"` // YYYY-MM
+	MonthlyBudget int    `json:"monthly_budget"`
+	YearlyBudget  int    `json:"yearly_budget"`
+	CreatedAt     string `json:"created_at"`
+	UpdatedAt     string `json:"updated_at"`
+}
+
+// BudgetPeriodRequest 구조체 - 특정 월부터 적용할 기준치 설정 요청 (생략한 금액은 그 월에 적용 중이던 값 유지)
+type BudgetPeriodRequest struct {
+	EffectiveFrom string `json:"effective_from"` // YYYY-MM, 비어 있으면 이번 달(KST)
+	MonthlyBudget *int   `json:"monthly_budget,omitempty"`
+	YearlyBudget  *int   `json:"yearly_budget,omitempty"`
+}
+
+// BudgetMonth 구조체 - 월별 기준치 대비 실제 지출
+type BudgetMonth struct {
+	Month         string  `json:"month"`          // YYYY-MM
+	HasBudget     bool    `json:"has_budget"`     // 그 달에 적용된 기준치가 있었는지 여부
+	MonthlyBudget int     `json:"monthly_budget"` // 그 달에 적용된 월 기준치
+	Carried       int     `json:"carried"`        // 이월 모드일 때 지난달까지 이월된 금액
+	Available     int     `json:"available"`      // 월 기준치 + 이월 금액
+	Used          int     `json:"used"`
+	Remaining     int     `json:"remaining"`
+	Percent       float64 `json:"percent"`
+	IsOver        bool    `json:"is_over"`
+}
+
+// BudgetMonthlyReport 구조체 - 기준치별 월별 기준치 대비 실제 지출 표
+type BudgetMonthlyReport struct {
+	BudgetID     int           `json:"budget_id"`
+	CategoryID   int           `json:"category_id"`
+	CategoryName string        `json:"category_name"`
+	UserName     string        `json:"user_name"`
+	Months       []BudgetMonth `json:"months"`
+	TotalBudget  int           `json:"total_budget"` // 기준치가 있던 달의 월 기준치 합계
+	TotalUsed    int           `json:"total_used"`
+	OverCount    int           `json:"over_count"` // 월 기준치를 초과한 달 수
+}
+
+// BudgetRolloverRequest 구조체 - 기준치 이월 모드 설정 요청
+type BudgetRolloverRequest struct {
+	Enabled bool   `json:"enabled"`
+	Cap     *int   `json:"cap,omitempty"`  // 이월 금액 상한 (절댓값, 생략 시 제한 없음)
+	From    string `json:"from,omitempty"` // YYYY-MM, 이 달부터 이월 누적 (생략 시 이번 달)
+}
@@ -0,0 +1,29 @@
+package models
+
+// CardBillingRequest 구조체 - 카드 결제수단 명세서 주기 설정 (두 값 모두 null 이면 설정 해제)
+// 하위 결제수단은 자신의 설정이 없으면 상위 결제수단의 주기를 따름
+type CardBillingRequest struct {
+	StatementClosingDay *int `json:"statement_closing_day"` // 명세서 마감일 (1~31, 말일 초과 시 말일)
+	PaymentDueDay       *int `json:"payment_due_day"`       // 결제일 (1~31, 말일 초과 시 말일)
+}
+
+// CardStatement 구조체 - 카드 한 장의 명세서 (이용 기간, 결제일, 포함된 지출)
+type CardStatement struct {
+	PaymentMethodID     int          `json:"payment_method_id"`
+	PaymentMethodName   string       `json:"payment_method_name"`
+	StatementClosingDay int          `json:"statement_closing_day"`
+	PaymentDueDay       int          `json:"payment_due_day"`
+	PeriodStart         string       `json:"period_start"` // 이용 기간 시작일 (YYYY-MM-DD)
+	PeriodEnd           string       `json:"period_end"`   // 이용 기간 종료일 = 명세서 마감일
+	DueDate             string       `json:"due_date"`     // 결제 예정일
+	TotalAmount         int          `json:"total_amount"`
+	Count               int          `json:"count"`
+	Expenses            []OutAccount `json:"expenses"`
+}
+
+// CardStatementSummary 구조체 - 결제월 기준 카드 명세서 목록
+type CardStatementSummary struct {
+	DueMonth    string          `json:"due_month"` // YYYY-MM
+	TotalAmount int             `json:"total_amount"`
+	Statements  []CardStatement `json:"statements"`
+}
@@ -0,0 +1,115 @@
+package models
+
+// 자동 분류 규칙 메모 일치 방식
+const (
+	RuleMatchContains   = "contains"    // 메모에 패턴 포함 (기본값)
+	RuleMatchEquals     = "equals"      // 메모가 패턴과 일치
+	RuleMatchStartsWith = "starts_with" // 메모가 패턴으로 시작
+	RuleMatchRegex      = "regex"       // Go 정규식 (대소문자 무시)
+)
+
+// CategorizationRule 구조체 - 거래 자동 분류 규칙
+// 조건(메모 패턴, 금액 범위, 사용자)이 모두 맞으면 카테고리/키워드/결제수단(지출) 또는 입금경로(수입)를 지정
+type CategorizationRule struct {
+	ID                int    `json:"id"`
+	Name              string `json:"name"`
+	Type              string `json:"type"`     // 'out' 또는 'in'
+	Priority          int    `json:"priority"` // 작을수록 먼저 평가
+	IsActive          bool   `json:"is_active"`
+	Pattern           string `json:"pattern"`    // 메모(가져오기는 가맹점명 포함) 비교 패턴, 비어 있으면 메모 조건 없음
+	MatchType         string `json:"match_type"` // 'contains', 'equals', 'starts_with', 'regex'
+	MinAmount         *int   `json:"min_amount,omitempty"`
+	MaxAmount         *int   `json:"max_amount,omitempty"`
+	User              string `json:"user,omitempty"` // 비어 있으면 모든 사용자
+	CategoryID        *int   `json:"category_id,omitempty"`
+	CategoryName      string `json:"category_name,omitempty"`
+	KeywordName       string `json:"keyword_name,omitempty"` // category_id 가 있을 때만 지정 가능
+	PaymentMethodID   *int   `json:"payment_method_id,omitempty"`
+	PaymentMethodName string `json:"payment_method_name,omitempty"`
+	DepositPathID     *int   `json:"deposit_path_id,omitempty"`
+	DepositPathName   string `json:"deposit_path_name,omitempty"`
+	CreatedAt         string `json:"created_at"`
+	UpdatedAt         string `json:"updated_at"`
+}
+
+// CategorizationRuleRequest 구조체 - 자동 분류 규칙 생성/수정 요청
+type CategorizationRuleRequest struct {
+	Name            string `json:"name"`
+	Type            string `json:"type"`
+	Priority        *int   `json:"priority,omitempty"`  // 생략하면 가장 마지막 순서
+	IsActive        *bool  `json:"is_active,omitempty"` // 생략하면 사용
+	Pattern         string `json:"pattern"`
+	MatchType       string `json:"match_type"` // 생략하면 'contains'
+	MinAmount       *int   `json:"min_amount,omitempty"`
+	MaxAmount       *int   `json:"max_amount,omitempty"`
+	User            string `json:"user,omitempty"`
+	CategoryID      *int   `json:"category_id,omitempty"`
+	KeywordName     string `json:"keyword_name,omitempty"`
+	PaymentMethodID *int   `json:"payment_method_id,omitempty"`
+	DepositPathID   *int   `json:"deposit_path_id,omitempty"`
+}
+
+// RuleSubject 구조체 - 자동 분류 규칙을 평가할 거래 정보 (/rules/test 요청 본문)
+type RuleSubject struct {
+	Type     string `json:"type"` // 'out' 또는 'in'
+	Memo     string `json:"memo"`
+	Merchant string `json:"merchant,omitempty"` // 명세서 가맹점명 (가져오기에서 메모와 함께 비교)
+	User     string `json:"user,omitempty"`
+	Money    int    `json:"money,omitempty"`
+}
+
+// RuleMatchRef 구조체 - 일치한 규칙과 그 규칙에서 가져온 항목
+type RuleMatchRef struct {
+	ID       int      `json:"id"`
+	Name     string   `json:"name"`
+	Priority int      `json:"priority"`
+	Fields   []string `json:"fields"` // 'category', 'payment_method', 'deposit_path' (적용된 항목이 없으면 빈 목록)
+}
+
+// RuleResult 구조체 - 자동 분류 결과
+// 항목별로 우선순위가 가장 높은 일치 규칙의 값을 사용하며, 키워드는 카테고리를 지정한 규칙의 것을 함께 사용
+type RuleResult struct {
+	Matched           bool           `json:"matched"`
+	CategoryID        *int           `json:"category_id,omitempty"`
+	CategoryName      string         `json:"category_name,omitempty"`
+	KeywordName       string         `json:"keyword_name,omitempty"`
+	PaymentMethodID   *int           `json:"payment_method_id,omitempty"`
+	PaymentMethodName string         `json:"payment_method_name,omitempty"`
+	DepositPathID     *int           `json:"deposit_path_id,omitempty"`
+	DepositPathName   string         `json:"deposit_path_name,omitempty"`
+	MatchedRules      []RuleMatchRef `json:"matched_rules"`
+}
+
+// RuleApplyRequest 구조체 - 기간 내 기존 거래에 자동 분류 규칙 다시 적용 요청
+type RuleApplyRequest struct {
+	StartDate string `json:"start_date"`        // YYYY-MM-DD
+	EndDate   string `json:"end_date"`          // YYYY-MM-DD
+	Type      string `json:"type"`              // 'out', 'in' (비어 있으면 둘 다)
+	RuleID    *int   `json:"rule_id,omitempty"` // 지정하면 이 규칙만 적용
+	DryRun    bool   `json:"dry_run"`           // true 면 변경 내용만 반환하고 저장하지 않음
+}
+
+// RuleChange 구조체 - 규칙 적용으로 바뀌는(바뀐) 거래 한 건
+type RuleChange struct {
+	Type           string `json:"type"`
+	UUID           string `json:"uuid"`
+	Date           string `json:"date"`
+	Money          int    `json:"money"`
+	Memo           string `json:"memo"`
+	OldCategoryID  int    `json:"old_category_id"`
+	NewCategoryID  int    `json:"new_category_id"`
+	OldKeywordName string `json:"old_keyword_name"`
+	NewKeywordName string `json:"new_keyword_name"`
+	OldMethodID    int    `json:"old_method_id"` // 지출은 결제수단, 수입은 입금경로 ID
+	NewMethodID    int    `json:"new_method_id"`
+	RuleIDs        []int  `json:"rule_ids"`
+}
+
+// RuleApplyResult 구조체 - 기존 거래 규칙 적용 결과
+type RuleApplyResult struct {
+	DryRun  bool         `json:"dry_run"`
+	Scanned int          `json:"scanned"` // 검사한 거래 수 (분할 지출, 할부 회차 제외)
+	Matched int          `json:"matched"` // 규칙이 하나 이상 일치한 거래 수
+	Changed int          `json:"changed"` // 값이 바뀌는(바뀐) 거래 수
+	Changes []RuleChange `json:"changes"`
+}
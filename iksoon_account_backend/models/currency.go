@@ -0,0 +1,36 @@
+package models
+
+// BaseCurrency 기준 통화 (모든 수입/지출의 money 와 통계/예산은 원화 기준)
+const BaseCurrency = "KRW"
+
+// ExchangeRate 구조체 - 날짜별 환율 (외화 1단위당 원화, 해당 날짜부터 다음 환율 날짜 전까지 적용)
+type ExchangeRate struct {
+	ID        int     `json:"id"`
+	Currency  string  `json:"currency"`
+	Date      string  `json:"date"` // YYYY-MM-DD
+	Rate      float64 `json:"rate"`
+	CreatedAt string  `json:"created_at"`
+	UpdatedAt string  `json:"updated_at"`
+}
+
+// ExchangeRateRequest 구조체 - 환율 등록/수정 요청 (같은 통화/날짜가 있으면 덮어씀)
+type ExchangeRateRequest struct {
+	Currency string  `json:"currency"`
+	Date     string  `json:"date"`
+	Rate     float64 `json:"rate"`
+}
+
+// ExchangeRateImportResult 구조체 - 환율 CSV 가져오기 결과
+type ExchangeRateImportResult struct {
+	Imported    int      `json:"imported"`    // 저장된 환율 수 (덮어쓴 환율 포함)
+	Currencies  []string `json:"currencies"`  // 가져온 통화 목록
+	Reconverted int      `json:"reconverted"` // 새 환율로 원화 금액이 바뀐 거래 수
+}
+
+// TransactionCurrencyRequest 구조체 - 수입/지출의 외화 금액 지정 요청 (currency 가 비어 있거나 KRW 이면 외화 정보 해제)
+type TransactionCurrencyRequest struct {
+	Type          string  `json:"type"` // 'out' 또는 'in'
+	UUID          string  `json:"uuid"`
+	Currency      string  `json:"currency"`
+	OriginalMoney float64 `json:"original_money"`
+}
@@ -0,0 +1,35 @@
+package models
+
+// ExportFilter 구조체 - 거래 내보내기 조건
+type ExportFilter struct {
+	Type            string // 'out', 'in', 'all'
+	StartDate       string // YYYY-MM-DD (비어있으면 처음부터)
+	EndDate         string // YYYY-MM-DD (비어있으면 끝까지)
+	User            string
+	CategoryID      *int
+	PaymentMethodID *int // 결제수단은 지출에만 있으므로 지정 시 수입은 제외
+}
+
+// ExportRow 구조체 - 내보내기 행 (지출/수입 공통)
+type ExportRow struct {
+	Type              string   `json:"type"` // 'out' 또는 'in'
+	UUID              string   `json:"uuid"`
+	Date              string   `json:"date"`
+	User              string   `json:"user"`
+	Money             int      `json:"money"`
+	CategoryID        int      `json:"category_id"`
+	CategoryName      string   `json:"category_name"`
+	KeywordName       string   `json:"keyword_name"`
+	PaymentMethodID   *int     `json:"payment_method_id,omitempty"`
+	PaymentMethodName string   `json:"payment_method_name,omitempty"`
+	DepositPathID     *int     `json:"deposit_path_id,omitempty"`
+	DepositPathName   string   `json:"deposit_path_name,omitempty"`
+	Memo              string   `json:"memo"`
+	CreatedAt         string   `json:"created_at"`
+	UpdatedAt         string   `json:"updated_at"`
+	Attachments       []string `json:"attachments,omitempty"`    // 첨부파일 이름 목록
+	Tags              []string `json:"tags,omitempty"`           // 태그 이름 목록 (이름순)
+	Currency          string   `json:"currency,omitempty"`       // 외화 거래인 경우 통화 코드 (money 는 환산된 원화 금액)
+	OriginalMoney     float64  `json:"original_money,omitempty"` // 외화 거래인 경우 외화 금액
+	ExchangeRate      float64  `json:"exchange_rate,omitempty"`  // 외화 거래에 적용된 환율
+}
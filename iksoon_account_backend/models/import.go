@@ -0,0 +1,80 @@
+package models
+
+import "time"
+
+// ImportProfile 구조체 - 카드사/은행 명세서 컬럼 매핑 프로필
+// 컬럼은 헤더 이름 또는 1부터 시작하는 열 번호로 지정
+type ImportProfile struct {
+	ID              int       `json:"id"`
+	LedgerID        int       `json:"ledger_id"`
+	Name            string    `json:"name"`
+	Type            string    `json:"type"`        // 'out' 또는 'in'
+	HasHeader       bool      `json:"has_header"`  // 첫 행(skip_rows 이후)이 헤더인지 여부
+	SkipRows        int       `json:"skip_rows"`   // 헤더 앞에서 건너뛸 행 수 (명세서 상단 안내 문구 등)
+	Delimiter       string    `json:"delimiter"`   // CSV 구분자 (기본 ",")
+	DateColumn      string    `json:"date_column"` // 거래일 컬럼
+	DateFormat      string    `json:"date_format"` // Go 날짜 레이아웃 (비어있으면 자동 인식)
+	AmountColumn    string    `json:"amount_column"`
+	MerchantColumn  string    `json:"merchant_column"`       // 가맹점/적요 컬럼
+	MemoColumn      string    `json:"memo_column,omitempty"` // 비어있으면 가맹점명을 메모로 사용
+	NegateAmount    bool      `json:"negate_amount"`         // 출금이 음수로 표시되는 명세서용 (부호 반전)
+	PaymentMethodID *int      `json:"payment_method_id,omitempty"`
+	DepositPathID   *int      `json:"deposit_path_id,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// ImportPreviewRow 구조체 - 가져오기 미리보기 행 (카테고리/키워드/결제수단 자동 매핑 결과)
+type ImportPreviewRow struct {
+	RowNumber       int    `json:"row_number"` // 원본 파일의 행 번호 (1부터)
+	Date            string `json:"date"`
+	Money           int    `json:"money"`
+	Merchant        string `json:"merchant"`
+	Memo            string `json:"memo"`
+	User            string `json:"user"`
+	CategoryID      *int   `json:"category_id,omitempty"`
+	CategoryName    string `json:"category_name,omitempty"`
+	KeywordID       *int   `json:"keyword_id,omitempty"`
+	KeywordName     string `json:"keyword_name,omitempty"`
+	PaymentMethodID *int   `json:"payment_method_id,omitempty"`
+	DepositPathID   *int   `json:"deposit_path_id,omitempty"`
+	RuleIDs         []int  `json:"rule_ids,omitempty"`       // 적용된 자동 분류 규칙 ID (우선순위 순)
+	IsDuplicate     bool   `json:"is_duplicate"`             // 날짜/금액/메모가 같은 거래가 이미 있거나 파일 내 중복
+	DuplicateUUID   string `json:"duplicate_uuid,omitempty"` // 이미 등록된 거래의 UUID
+	Error           string `json:"error,omitempty"`          // 파싱 실패 사유 (있으면 가져올 수 없음)
+}
+
+// ImportPreview 구조체 - 가져오기 미리보기 결과
+type ImportPreview struct {
+	Profile         ImportProfile      `json:"profile"`
+	Rows            []ImportPreviewRow `json:"rows"`
+	TotalRows       int                `json:"total_rows"`
+	MatchedRows     int                `json:"matched_rows"`      // 카테고리가 자동 매핑된 행 수
+	RuleMatchedRows int                `json:"rule_matched_rows"` // 자동 분류 규칙이 적용된 행 수
+	DuplicateRows   int                `json:"duplicate_rows"`    // 중복으로 표시된 행 수
+	ErrorRows       int                `json:"error_rows"`        // 파싱 오류 행 수
+}
+
+// ImportCommitRow 구조체 - 가져오기 확정 요청의 개별 거래
+type ImportCommitRow struct {
+	Date            string `json:"date"`
+	Money           int    `json:"money"`
+	User            string `json:"user"`
+	CategoryID      int    `json:"category_id"`
+	KeywordName     string `json:"keyword_name,omitempty"`
+	PaymentMethodID int    `json:"payment_method_id,omitempty"`
+	DepositPathID   int    `json:"deposit_path_id,omitempty"`
+	Memo            string `json:"memo"`
+}
+
+// ImportCommitRequest 구조체 - 미리보기에서 승인한 행을 한 번에 등록하는 요청
+type ImportCommitRequest struct {
+	Type string            `json:"type"` // 'out' 또는 'in'
+	Rows []ImportCommitRow `json:"rows"`
+}
+
+// ImportCommitResult 구조체 - 가져오기 확정 결과
+type ImportCommitResult struct {
+	Inserted int      `json:"inserted"`
+	UUIDs    []string `json:"uuids"`
+}
@@ -0,0 +1,39 @@
+package models
+
+// Installment 구조체 - 할부 구매 (원금과 조건만 보관하고, 실제 지출은 월별 회차로 기록)
+// 예산/통계에는 각 달의 회차 금액만 반영됨
+type Installment struct {
+	UUID              string       `json:"uuid"`
+	PurchaseDate      string       `json:"purchase_date"`
+	Principal         int          `json:"principal"`     // 할부 원금 (구매 금액)
+	Months            int          `json:"months"`        // 할부 개월 수
+	InterestRate      float64      `json:"interest_rate"` // 연 이자율 (%), 무이자 할부는 0
+	TotalInterest     int          `json:"total_interest"`
+	TotalAmount       int          `json:"total_amount"`     // 원금 + 이자
+	PaidAmount        int          `json:"paid_amount"`      // 오늘(KST)까지 도래한 회차 합계
+	RemainingAmount   int          `json:"remaining_amount"` // 아직 도래하지 않은 회차 합계
+	User              string       `json:"user"`
+	CategoryID        int          `json:"category_id"`
+	CategoryName      string       `json:"category_name,omitempty"`
+	KeywordID         *int         `json:"keyword_id,omitempty"`
+	KeywordName       string       `json:"keyword_name,omitempty"`
+	PaymentMethodID   int          `json:"payment_method_id"`
+	PaymentMethodName string       `json:"payment_method_name,omitempty"`
+	Memo              string       `json:"memo"`
+	Portions          []OutAccount `json:"portions,omitempty"` // 단건 조회 시 월별 회차 지출
+	CreatedAt         string       `json:"created_at"`
+	UpdatedAt         string       `json:"updated_at"`
+}
+
+// InstallmentRequest 구조체 - 할부 구매 등록/수정 요청
+type InstallmentRequest struct {
+	Date            string  `json:"date"`
+	User            string  `json:"user"`
+	Money           int     `json:"money"` // 할부 원금
+	CategoryID      int     `json:"category_id"`
+	KeywordName     string  `json:"keyword_name,omitempty"`
+	PaymentMethodID int     `json:"payment_method_id"`
+	Memo            string  `json:"memo"`
+	Months          int     `json:"installment_months"`
+	InterestRate    float64 `json:"installment_interest_rate"`
+}
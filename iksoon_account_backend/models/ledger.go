@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// 가계부 멤버 역할
+const (
+	LedgerRoleOwner  = "owner"  // 가계부 소유자 (멤버 관리 가능)
+	LedgerRoleMember = "member" // 일반 멤버
+)
+
+// Ledger 가계부 구조체 - 가족/가구 단위로 데이터를 분리
+type Ledger struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	Role        string    `json:"role,omitempty"` // 조회한 사용자의 역할 (owner/member)
+	MemberCount int       `json:"member_count"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// LedgerMember 가계부 멤버 구조체
+type LedgerMember struct {
+	LedgerID  int       `json:"ledger_id"`
+	UserID    int       `json:"user_id"`
+	UserName  string    `json:"user_name"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
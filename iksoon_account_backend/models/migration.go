@@ -0,0 +1,11 @@
+package models
+
+// MigrationStatus 구조체 - 스키마 마이그레이션 적용 상태
+type MigrationStatus struct {
+	Version    int    `json:"version"`
+	Name       string `json:"name"`
+	Applied    bool   `json:"applied"`
+	AppliedAt  string `json:"applied_at,omitempty"`
+	Reversible bool   `json:"reversible"` // down 마이그레이션 제공 여부
+	Unknown    bool   `json:"unknown"`    // DB에는 기록되어 있지만 현재 버전에 정의되지 않은 마이그레이션
+}
@@ -0,0 +1,78 @@
+package models
+
+// 알림 채널 종류
+const (
+	NotificationChannelWebhook = "webhook" // Slack/Discord 호환 JSON 을 POST 하는 웹훅 URL
+	NotificationChannelEmail   = "email"   // SMTP 로 보내는 이메일 (쉼표로 구분한 여러 주소 가능)
+)
+
+// 알림 발송 상태
+const (
+	NotificationPending = "pending" // 발송 대기 또는 재시도 대기
+	NotificationSent    = "sent"    // 발송 완료
+	NotificationFailed  = "failed"  // 최대 재시도 횟수를 넘겨 발송 실패
+)
+
+// 기준치 알림 기간
+const (
+	BudgetAlertMonthly = "monthly" // 월 기준치 사용률 기준, 달마다 한 번
+	BudgetAlertYearly  = "yearly"  // 연 기준치 사용률 기준, 해마다 한 번
+)
+
+// NotificationChannel 구조체 - 가계부의 알림 수신 채널
+type NotificationChannel struct {
+	ID        int    `json:"id"`
+	Type      string `json:"type"` // 'webhook', 'email'
+	Name      string `json:"name"`
+	Target    string `json:"target"` // 웹훅 URL 또는 이메일 주소
+	Enabled   bool   `json:"enabled"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// NotificationChannelRequest 구조체 - 알림 채널 생성/수정 요청
+type NotificationChannelRequest struct {
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Target  string `json:"target"`
+	Enabled *bool  `json:"enabled,omitempty"` // 생략 시 사용
+}
+
+// BudgetAlertThreshold 구조체 - 기준치 사용률 알림 임계값
+type BudgetAlertThreshold struct {
+	ID              int    `json:"id"`
+	BudgetID        int    `json:"budget_id"`
+	CategoryID      int    `json:"category_id"`
+	CategoryName    string `json:"category_name,omitempty"`
+	UserName        string `json:"user_name"`
+	Period          string `json:"period"`  // 'monthly', 'yearly'
+	Percent         int    `json:"percent"` // 사용률이 이 값(%) 이상이 되면 알림
+	LastFiredPeriod string `json:"last_fired_period,omitempty"`
+	CreatedAt       string `json:"created_at"`
+}
+
+// BudgetAlertThresholdRequest 구조체 - 기준치 알림 임계값 생성 요청
+type BudgetAlertThresholdRequest struct {
+	BudgetID int    `json:"budget_id"`
+	Period   string `json:"period,omitempty"` // 생략 시 'monthly'
+	Percent  int    `json:"percent"`
+}
+
+// NotificationDelivery 구조체 - 알림 발송 기록 (채널별 1건, 재시도 상태 포함)
+type NotificationDelivery struct {
+	ID            int    `json:"id"`
+	ChannelID     int    `json:"channel_id"`
+	ChannelName   string `json:"channel_name"`
+	ChannelType   string `json:"channel_type"`
+	Target        string `json:"-"`
+	ThresholdID   *int   `json:"threshold_id,omitempty"` // 테스트 알림은 null
+	PeriodKey     string `json:"period_key,omitempty"`   // YYYY-MM 또는 YYYY
+	Subject       string `json:"subject"`
+	Message       string `json:"message"`
+	Status        string `json:"status"` // 'pending', 'sent', 'failed'
+	Attempts      int    `json:"attempts"`
+	LastError     string `json:"last_error,omitempty"`
+	NextAttemptAt string `json:"next_attempt_at,omitempty"`
+	SentAt        string `json:"sent_at,omitempty"`
+	CreatedAt     string `json:"created_at"`
+}
@@ -0,0 +1,61 @@
+package models
+
+// 목록 정렬 기준
+const (
+	ListSortDate      = "date"       // 거래 일시 (기본값)
+	ListSortMoney     = "money"      // 금액
+	ListSortCreatedAt = "created_at" // 등록 시각
+)
+
+// 목록 정렬 방향
+const (
+	ListOrderAsc  = "asc"
+	ListOrderDesc = "desc" // 기본값
+)
+
+// 목록 조회 개수 제한
+const (
+	DefaultListLimit = 50   // cursor 만 주고 limit 을 생략했을 때의 페이지 크기
+	MaxListLimit     = 1000 // 한 번에 조회할 수 있는 최대 개수
+)
+
+// ListOptions 구조체 - 수입/지출 목록 조회의 필터, 정렬, 커서 페이지네이션 옵션
+// Limit 이 0 이면 페이지를 나누지 않고 조건에 맞는 전체 목록을 반환 (기존 배열 응답 호환)
+type ListOptions struct {
+	Limit           int
+	Cursor          string
+	Sort            string // 'date', 'money', 'created_at'
+	Order           string // 'asc', 'desc'
+	TagID           int
+	MinAmount       *int
+	MaxAmount       *int
+	CategoryID      *int
+	PaymentMethodID *int // 지출 목록에만 적용
+	DepositPathID   *int // 수입 목록에만 적용
+	User            string
+}
+
+// Paginated 목록을 페이지 단위로 조회하는지 여부
+func (o ListOptions) Paginated() bool {
+	return o.Limit > 0
+}
+
+// PageInfo 구조체 - 커서 페이지네이션 메타데이터
+type PageInfo struct {
+	TotalCount int    `json:"total_count"`           // 필터 조건에 맞는 전체 건수
+	Limit      int    `json:"limit"`                 // 페이지 크기 (전체 조회면 0)
+	NextCursor string `json:"next_cursor,omitempty"` // 다음 페이지 조회용 커서 (마지막 페이지면 생략)
+	HasMore    bool   `json:"has_more"`
+}
+
+// OutAccountPage 구조체 - 지출 목록 페이지 응답
+type OutAccountPage struct {
+	Items []OutAccount `json:"items"`
+	PageInfo
+}
+
+// InAccountPage 구조체 - 수입 목록 페이지 응답
+type InAccountPage struct {
+	Items []InAccount `json:"items"`
+	PageInfo
+}
@@ -0,0 +1,67 @@
+package models
+
+import "time"
+
+// 정기 거래 주기 상수
+const (
+	RecurringFrequencyMonthly = "monthly"
+	RecurringFrequencyWeekly  = "weekly"
+	RecurringFrequencyYearly  = "yearly"
+)
+
+// RecurringRule 구조체 - 정기 거래 규칙 (월세, 보험료, 급여 등)
+type RecurringRule struct {
+	ID                int       `json:"id"`
+	LedgerID          int       `json:"ledger_id"`
+	Type              string    `json:"type"`          // 'out' 또는 'in'
+	Frequency         string    `json:"frequency"`     // 'monthly', 'weekly', 'yearly'
+	DayOfMonth        int       `json:"day_of_month"`  // monthly/yearly: 생성일 (말일 초과 시 말일로 보정)
+	DayOfWeek         int       `json:"day_of_week"`   // weekly: 0(일) ~ 6(토)
+	MonthOfYear       int       `json:"month_of_year"` // yearly: 1 ~ 12
+	Money             int       `json:"money"`
+	User              string    `json:"user"`
+	CategoryID        int       `json:"category_id"`
+	CategoryName      string    `json:"category_name,omitempty"`
+	KeywordID         *int      `json:"keyword_id,omitempty"`
+	KeywordName       string    `json:"keyword_name,omitempty"`
+	PaymentMethodID   *int      `json:"payment_method_id,omitempty"`
+	PaymentMethodName string    `json:"payment_method_name,omitempty"`
+	DepositPathID     *int      `json:"deposit_path_id,omitempty"`
+	DepositPathName   string    `json:"deposit_path_name,omitempty"`
+	Memo              string    `json:"memo"`
+	StartDate         string    `json:"start_date"`
+	EndDate           *string   `json:"end_date,omitempty"`
+	LastGeneratedDate *string   `json:"last_generated_date,omitempty"`
+	IsActive          bool      `json:"is_active"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// RecurringRuleRequest 구조체 - 정기 거래 규칙 생성/수정 요청
+type RecurringRuleRequest struct {
+	Type            string  `json:"type"`
+	Frequency       string  `json:"frequency"`
+	DayOfMonth      int     `json:"day_of_month"`
+	DayOfWeek       int     `json:"day_of_week"`
+	MonthOfYear     int     `json:"month_of_year"`
+	Money           int     `json:"money"`
+	User            string  `json:"user"`
+	CategoryID      int     `json:"category_id"`
+	KeywordName     string  `json:"keyword_name,omitempty"`
+	KeywordID       *int    `json:"-"` // 핸들러에서 keyword_name 으로 upsert 후 설정
+	PaymentMethodID *int    `json:"payment_method_id,omitempty"`
+	DepositPathID   *int    `json:"deposit_path_id,omitempty"`
+	Memo            string  `json:"memo"`
+	StartDate       string  `json:"start_date"`
+	EndDate         *string `json:"end_date,omitempty"`
+	IsActive        *bool   `json:"is_active,omitempty"`
+}
+
+// RecurringGenerationResult 구조체 - 정기 거래 생성 실행 결과
+type RecurringGenerationResult struct {
+	RulesChecked int `json:"rules_checked"`
+	Created      int `json:"created"`     // 새로 생성된 거래 수
+	Skipped      int `json:"skipped"`     // 이미 해당 주기에 거래가 있어 건너뛴 수
+	Failed       int `json:"failed"`      // 생성에 실패한 규칙 수
+	Deactivated  int `json:"deactivated"` // 결제수단/입금경로가 없어 비활성화한 규칙 수
+}
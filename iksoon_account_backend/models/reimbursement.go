@@ -0,0 +1,49 @@
+package models
+
+// 환급 상태
+const (
+	ReimbursementPending = "pending" // 환급 대기 (아직 환급 수입이 연결되지 않음)
+	ReimbursementSettled = "settled" // 환급 완료 (환급 수입과 연결되어 통계에서 제외)
+)
+
+// Reimbursement 구조체 - 환급 대상 지출과 연결된 환급 수입
+type Reimbursement struct {
+	OutAccountUUID string  `json:"out_uuid"`
+	Status         string  `json:"status"` // 'pending' 또는 'settled'
+	Date           string  `json:"date"`   // 지출 일시
+	User           string  `json:"user"`
+	Money          int     `json:"money"` // 지출 금액
+	CategoryName   string  `json:"category_name"`
+	Memo           string  `json:"memo"`
+	InAccountUUID  *string `json:"in_uuid,omitempty"`  // 환급 수입 UUID
+	InDate         string  `json:"in_date,omitempty"`  // 환급 수입 일시
+	InMoney        int     `json:"in_money,omitempty"` // 환급 수입 금액 (여러 지출을 한 번에 환급받은 경우 합계 금액)
+	CreatedAt      string  `json:"created_at"`
+	SettledAt      *string `json:"settled_at,omitempty"`
+}
+
+// ReimbursementRequest 구조체 - 환급 대상 지정 요청
+type ReimbursementRequest struct {
+	UUID string `json:"uuid"` // 지출 UUID
+}
+
+// ReimbursementSettleRequest 구조체 - 환급 수입 연결 요청 (한 번의 환급으로 여러 지출을 정산할 수 있음)
+type ReimbursementSettleRequest struct {
+	OutUUIDs []string `json:"out_uuids"`
+	InUUID   string   `json:"in_uuid"`
+}
+
+// OutstandingReimbursement 구조체 - 사용자별 미환급 금액
+type OutstandingReimbursement struct {
+	User       string `json:"user"`
+	Count      int    `json:"count"`
+	Amount     int    `json:"amount"`
+	OldestDate string `json:"oldest_date"` // 가장 오래된 미환급 지출 일시
+}
+
+// OutstandingReimbursementReport 구조체 - 미환급 금액 보고서
+type OutstandingReimbursementReport struct {
+	TotalCount  int                        `json:"total_count"`
+	TotalAmount int                        `json:"total_amount"`
+	Users       []OutstandingReimbursement `json:"users"`
+}
@@ -0,0 +1,64 @@
+package models
+
+// 저축 목표 진행 상태
+const (
+	SavingsGoalAchieved = "achieved" // 목표 금액 달성
+	SavingsGoalOnTrack  = "on_track" // 목표일까지 균등하게 모았을 때의 예상 금액 이상
+	SavingsGoalBehind   = "behind"   // 예상 금액보다 부족
+	SavingsGoalOverdue  = "overdue"  // 목표일이 지났지만 미달성
+)
+
+// SavingsGoal 구조체 - 저축 목표 (입금경로 또는 수입 카테고리의 수입으로 진행률 계산)
+type SavingsGoal struct {
+	ID              int                  `json:"id"`
+	Name            string               `json:"name"`
+	TargetAmount    int                  `json:"target_amount"`
+	StartDate       string               `json:"start_date"`  // YYYY-MM-DD, 이 날짜부터의 수입만 반영
+	TargetDate      string               `json:"target_date"` // YYYY-MM-DD
+	DepositPathID   *int                 `json:"deposit_path_id,omitempty"`
+	DepositPathName string               `json:"deposit_path_name,omitempty"`
+	CategoryID      *int                 `json:"category_id,omitempty"`
+	CategoryName    string               `json:"category_name,omitempty"`
+	InitialAmount   int                  `json:"initial_amount"` // 시작일 이전에 이미 모아 둔 금액
+	Memo            string               `json:"memo"`
+	Progress        *SavingsGoalProgress `json:"progress,omitempty"`
+	CreatedAt       string               `json:"created_at"`
+	UpdatedAt       string               `json:"updated_at"`
+}
+
+// SavingsGoalProgress 구조체 - 오늘(KST) 기준 저축 목표 진행 상황
+type SavingsGoalProgress struct {
+	SavedAmount     int     `json:"saved_amount"` // 시작 금액 + 시작일 이후 적립액
+	RemainingAmount int     `json:"remaining_amount"`
+	ProgressPercent float64 `json:"progress_percent"`
+	ExpectedAmount  int     `json:"expected_amount"`  // 시작일부터 목표일까지 균등하게 모았을 때 오늘까지의 예상 금액
+	MonthsRemaining int     `json:"months_remaining"` // 이번 달을 포함해 목표 월까지 남은 개월 수
+	MonthlyNeeded   int     `json:"monthly_needed"`   // 남은 금액을 남은 개월 수로 나눈 월 필요 금액
+	OnTrack         bool    `json:"on_track"`
+	Status          string  `json:"status"` // 'achieved', 'on_track', 'behind', 'overdue'
+}
+
+// SavingsGoalMonth 구조체 - 저축 목표의 월별 적립액
+type SavingsGoalMonth struct {
+	Month      string `json:"month"` // YYYY-MM
+	Amount     int    `json:"amount"`
+	Cumulative int    `json:"cumulative"` // 시작 금액 포함 누적 금액
+}
+
+// SavingsGoalDetail 구조체 - 월별 적립 내역을 포함한 저축 목표
+type SavingsGoalDetail struct {
+	SavingsGoal
+	Months []SavingsGoalMonth `json:"months"`
+}
+
+// SavingsGoalRequest 구조체 - 저축 목표 생성/수정 요청 (deposit_path_id 와 category_id 중 하나만 지정)
+type SavingsGoalRequest struct {
+	Name          string `json:"name"`
+	TargetAmount  int    `json:"target_amount"`
+	TargetDate    string `json:"target_date"`          // YYYY-MM(해당 월 말일) 또는 YYYY-MM-DD
+	StartDate     string `json:"start_date,omitempty"` // 비어 있으면 오늘(KST)
+	DepositPathID *int   `json:"deposit_path_id,omitempty"`
+	CategoryID    *int   `json:"category_id,omitempty"`
+	InitialAmount int    `json:"initial_amount,omitempty"`
+	Memo          string `json:"memo"`
+}
@@ -0,0 +1,42 @@
+package models
+
+// 검색 결과 개수 제한
+const (
+	DefaultSearchLimit = 50
+	MaxSearchLimit     = 200
+)
+
+// SearchQuery 구조체 - 거래 전문 검색 조건
+type SearchQuery struct {
+	Query     string // 검색어 (공백은 AND, OR, -제외어, "구문", 접두어* 지원)
+	Type      string // 'out', 'in' (비어 있으면 둘 다)
+	StartDate string // YYYY-MM-DD (선택)
+	EndDate   string // YYYY-MM-DD (선택)
+	Limit     int
+	Offset    int
+}
+
+// SearchHit 구조체 - 검색된 거래 한 건
+type SearchHit struct {
+	Type         string  `json:"type"` // 'out', 'in'
+	UUID         string  `json:"uuid"`
+	Date         string  `json:"date"`
+	User         string  `json:"user"`
+	Money        int     `json:"money"`
+	CategoryName string  `json:"category_name"`
+	KeywordName  string  `json:"keyword_name,omitempty"`
+	MethodName   string  `json:"method_name"` // 지출은 결제수단, 수입은 입금경로 이름
+	Memo         string  `json:"memo"`
+	Snippet      string  `json:"snippet"`        // 일치한 부분을 <mark></mark> 로 감싼 발췌 (HTML 이스케이프되지 않음)
+	Rank         float64 `json:"rank,omitempty"` // 관련도 점수 (작을수록 관련도 높음, 전문 검색 인덱스가 없으면 0)
+}
+
+// SearchResult 구조체 - 거래 전문 검색 응답
+type SearchResult struct {
+	Query      string      `json:"query"`
+	Mode       string      `json:"mode"` // 'fts' (FTS5 인덱스 사용) 또는 'like' (부분 일치 대체 검색)
+	TotalCount int         `json:"total_count"`
+	Limit      int         `json:"limit"`
+	Offset     int         `json:"offset"`
+	Items      []SearchHit `json:"items"`
+}
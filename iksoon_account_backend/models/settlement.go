@@ -0,0 +1,75 @@
+package models
+
+// 공유 규칙 분담 방식
+const (
+	SharingMethodEqual      = "equal"      // 참여자끼리 균등 분담
+	SharingMethodPercentage = "percentage" // 사용자별 비율(%)로 분담
+)
+
+// SharingRule 구조체 - 공동 지출 분담 규칙 (카테고리별 규칙이 없으면 기본 규칙, 기본 규칙도 없으면 전체 멤버 균등 분담)
+type SharingRule struct {
+	ID           int            `json:"id"`
+	CategoryID   *int           `json:"category_id"` // nil 이면 기본 규칙
+	CategoryName string         `json:"category_name,omitempty"`
+	Method       string         `json:"method"` // 'equal' 또는 'percentage'
+	Shares       []SharingShare `json:"shares"`
+	CreatedAt    string         `json:"created_at"`
+	UpdatedAt    string         `json:"updated_at"`
+}
+
+// SharingShare 구조체 - 분담 규칙의 참여자별 비율
+type SharingShare struct {
+	UserID     int     `json:"user_id"`
+	UserName   string  `json:"user_name,omitempty"`
+	Percentage float64 `json:"percentage,omitempty"` // percentage 방식에서만 사용 (합계 100)
+}
+
+// SharingRuleRequest 구조체 - 분담 규칙 생성/수정 요청
+// equal 방식에서 shares 를 비우면 가계부의 모든 멤버가 참여
+type SharingRuleRequest struct {
+	CategoryID *int           `json:"category_id"`
+	Method     string         `json:"method"`
+	Shares     []SharingShare `json:"shares"`
+}
+
+// SettlementMember 구조체 - 기간 내 멤버별 정산 잔액
+type SettlementMember struct {
+	UserName string `json:"user_name"`
+	Paid     int    `json:"paid"`    // 실제로 낸 지출 금액
+	Share    int    `json:"share"`   // 분담 규칙에 따라 부담해야 하는 금액
+	Settled  int    `json:"settled"` // 기록된 정산으로 보낸 금액 - 받은 금액
+	Balance  int    `json:"balance"` // 양수면 받을 돈, 음수면 보낼 돈
+}
+
+// SettlementTransfer 구조체 - 정산 송금 (from_user 가 to_user 에게 amount 송금)
+type SettlementTransfer struct {
+	FromUser string `json:"from_user"`
+	ToUser   string `json:"to_user"`
+	Amount   int    `json:"amount"`
+}
+
+// SettlementBalance 구조체 - 기간별 정산 잔액과 잔액을 맞추기 위한 최소 송금 목록
+type SettlementBalance struct {
+	StartDate    string               `json:"start_date"`
+	EndDate      string               `json:"end_date"`
+	TotalExpense int                  `json:"total_expense"` // 분담 대상 지출 합계 (환급 대상 지출 제외)
+	Members      []SettlementMember   `json:"members"`
+	Transfers    []SettlementTransfer `json:"transfers"`
+}
+
+// Settlement 구조체 - 기록된 정산 (정산 이후 잔액은 end_date 다음 날부터 다시 계산)
+type Settlement struct {
+	ID           int                  `json:"id"`
+	StartDate    string               `json:"start_date"`
+	EndDate      string               `json:"end_date"`
+	TotalExpense int                  `json:"total_expense"`
+	Memo         string               `json:"memo"`
+	Transfers    []SettlementTransfer `json:"transfers"`
+	CreatedAt    string               `json:"created_at"`
+}
+
+// SettlementRequest 구조체 - 정산 기록 요청 (마지막 정산 다음 날부터 end_date 까지를 정산, end_date 생략 시 오늘)
+type SettlementRequest struct {
+	EndDate string `json:"end_date"`
+	Memo    string `json:"memo"`
+}
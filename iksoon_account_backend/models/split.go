@@ -0,0 +1,28 @@
+package models
+
+// OutAccountSplit 구조체 - 분할 지출 항목 (한 번의 결제를 카테고리/키워드별로 나눈 금액)
+// 분할된 지출의 카테고리/키워드 통계와 예산 사용량은 항목 단위로 집계됨
+type OutAccountSplit struct {
+	ID           int    `json:"id"`
+	Seq          int    `json:"seq"`
+	CategoryID   int    `json:"category_id"`
+	CategoryName string `json:"category_name,omitempty"`
+	KeywordID    *int   `json:"keyword_id,omitempty"`
+	KeywordName  string `json:"keyword_name,omitempty"`
+	Money        int    `json:"money"`
+	Memo         string `json:"memo"`
+}
+
+// OutAccountSplitRequest 구조체 - 분할 지출 항목 요청
+type OutAccountSplitRequest struct {
+	CategoryID  int    `json:"category_id"`
+	KeywordName string `json:"keyword_name,omitempty"`
+	Money       int    `json:"money"`
+	Memo        string `json:"memo"`
+	KeywordID   *int   `json:"-"` // 핸들러에서 keyword_name 으로 조회/생성한 키워드
+}
+
+// OutAccountSplitsRequest 구조체 - 분할 항목 전체 교체 요청 (빈 목록이면 분할 해제)
+type OutAccountSplitsRequest struct {
+	Splits []OutAccountSplitRequest `json:"splits"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// Tag 구조체 - 수입/지출에 여러 개 붙일 수 있는 자유 태그 (여행, 행사, 회사경비 등)
+type Tag struct {
+	ID         int       `json:"id"`
+	LedgerID   int       `json:"ledger_id"`
+	Name       string    `json:"name"`
+	UsageCount int       `json:"usage_count"` // 태그가 붙은 수입/지출 건수
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// TagRequest 구조체 - 태그 생성/수정 요청
+type TagRequest struct {
+	Name string `json:"name"`
+}
+
+// TransactionTagsRequest 구조체 - 수입/지출의 태그 전체 교체 요청 (없는 태그는 생성, 빈 목록이면 모두 해제)
+type TransactionTagsRequest struct {
+	Type string   `json:"type"` // 'out' 또는 'in'
+	UUID string   `json:"uuid"`
+	Tags []string `json:"tags"`
+}
+
+// TagStatistics 구조체 - 태그별 수입/지출 합계 (여행/행사처럼 여러 카테고리에 걸친 비용 확인용)
+type TagStatistics struct {
+	TagID        int                  `json:"tag_id"`
+	TagName      string               `json:"tag_name"`
+	StartDate    string               `json:"start_date,omitempty"`
+	EndDate      string               `json:"end_date,omitempty"`
+	TotalExpense int                  `json:"total_expense"`
+	ExpenseCount int                  `json:"expense_count"`
+	TotalIncome  int                  `json:"total_income"`
+	IncomeCount  int                  `json:"income_count"`
+	Net          int                  `json:"net"`        // 수입 - 지출
+	FirstDate    string               `json:"first_date"` // 태그가 붙은 첫 거래일
+	LastDate     string               `json:"last_date"`  // 태그가 붙은 마지막 거래일
+	Categories   []CategoryStatistics `json:"categories"` // 지출 카테고리별 합계 (분할 지출은 항목 단위)
+	Users        []UserStatistics     `json:"users"`      // 사용자별 지출 합계
+}
@@ -0,0 +1,65 @@
+package models
+
+// Transaction 구조체 - 수입/지출 통합 목록의 거래 한 건 (type 으로 구분)
+// 지출이면 결제수단, 수입이면 입금경로 필드만 채워짐
+type Transaction struct {
+	Type              string            `json:"type"` // 'out', 'in'
+	UUID              string            `json:"uuid"`
+	Date              string            `json:"date"`
+	User              string            `json:"user"`
+	Money             int               `json:"money"`
+	CategoryID        int               `json:"category_id"`
+	CategoryName      string            `json:"category_name,omitempty"`
+	KeywordID         *int              `json:"keyword_id,omitempty"`
+	KeywordName       string            `json:"keyword_name,omitempty"`
+	PaymentMethodID   *int              `json:"payment_method_id,omitempty"`
+	PaymentMethodName string            `json:"payment_method_name,omitempty"`
+	DepositPathID     *int              `json:"deposit_path_id,omitempty"`
+	DepositPathName   string            `json:"deposit_path_name,omitempty"`
+	Memo              string            `json:"memo"`
+	InstallmentID     *string           `json:"installment_id,omitempty"`
+	InstallmentSeq    *int              `json:"installment_seq,omitempty"`
+	Splits            []OutAccountSplit `json:"splits,omitempty"`
+	Tags              []string          `json:"tags,omitempty"`
+	Reimbursement     string            `json:"reimbursement,omitempty"`
+	Currency          string            `json:"currency,omitempty"`
+	OriginalMoney     float64           `json:"original_money,omitempty"`
+	ExchangeRate      float64           `json:"exchange_rate,omitempty"`
+	CreatedAt         string            `json:"created_at"`
+	UpdatedAt         string            `json:"updated_at"`
+}
+
+// TransactionQuery 구조체 - 수입/지출 통합 조회 조건
+type TransactionQuery struct {
+	StartDate string
+	EndDate   string
+	Type      string // 'out', 'in' (비어 있으면 둘 다)
+	Keyword   string // 키워드 이름 또는 메모 부분 일치
+	ListOptions
+}
+
+// TransactionPage 구조체 - 수입/지출 통합 목록 페이지 응답
+type TransactionPage struct {
+	Items []Transaction `json:"items"`
+	PageInfo
+}
+
+// DailyNet 구조체 - 날짜별 수입/지출 합계와 순액 (달력 보기용)
+type DailyNet struct {
+	Date         string `json:"date"` // YYYY-MM-DD
+	Income       int    `json:"income"`
+	Expense      int    `json:"expense"`
+	Net          int    `json:"net"` // 수입 - 지출
+	IncomeCount  int    `json:"income_count"`
+	ExpenseCount int    `json:"expense_count"`
+}
+
+// DailyNetSummary 구조체 - 기간의 날짜별 순액 응답
+type DailyNetSummary struct {
+	StartDate    string     `json:"start_date"`
+	EndDate      string     `json:"end_date"`
+	Days         []DailyNet `json:"days"` // 거래가 있는 날짜만, 날짜 오름차순
+	TotalIncome  int        `json:"total_income"`
+	TotalExpense int        `json:"total_expense"`
+	TotalNet     int        `json:"total_net"`
+}
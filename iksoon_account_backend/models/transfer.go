@@ -0,0 +1,27 @@
+package models
+
+// Transfer 구조체 - 내 계좌 간 이체 (월급통장 → 적금, 카드 대금 납부 등)
+// 수입/지출 통계에는 포함되지 않고 계좌 잔액에만 반영됨
+type Transfer struct {
+	UUID            string `json:"uuid"`
+	Date            string `json:"date"`
+	Money           int    `json:"money"`
+	FromAccountID   int    `json:"from_account_id"`
+	FromAccountName string `json:"from_account_name,omitempty"`
+	ToAccountID     int    `json:"to_account_id"`
+	ToAccountName   string `json:"to_account_name,omitempty"`
+	User            string `json:"user"`
+	Memo            string `json:"memo"`
+	CreatedAt       string `json:"created_at"`
+	UpdatedAt       string `json:"updated_at"`
+}
+
+// TransferRequest 구조체 - 이체 등록/수정 요청
+type TransferRequest struct {
+	Date          string `json:"date"`
+	Money         int    `json:"money"`
+	FromAccountID int    `json:"from_account_id"`
+	ToAccountID   int    `json:"to_account_id"`
+	User          string `json:"user"`
+	Memo          string `json:"memo"`
+}
@@ -4,12 +4,14 @@ import "time"
 
 // User 구조체 - 사용자 관리
 type User struct {
-	ID        int       `json:"id"`
-	Name      string    `json:"name"`
-	Email     string    `json:"email,omitempty"`
-	IsActive  bool      `json:"is_active"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	Email       string    `json:"email,omitempty"`
+	IsActive    bool      `json:"is_active"`
+	IsAdmin     bool      `json:"is_admin"`     // 관리자 여부 (다른 사용자 비밀번호 설정 가능)
+	HasPassword bool      `json:"has_password"` // 로그인 비밀번호 설정 여부
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
 }
 
 // Category 구조체 - 카테고리 관리
@@ -35,13 +37,15 @@ type Keyword struct {
 
 // PaymentMethod 구조체 - 결제수단 관리
 type PaymentMethod struct {
-	ID        int             `json:"id"`
-	Name      string          `json:"name"`
-	ParentID  *int            `json:"parent_id"`
-	IsActive  bool            `json:"is_active"`
-	CreatedAt time.Time       `json:"created_at"`
-	UpdatedAt time.Time       `json:"updated_at"`
-	Children  []PaymentMethod `json:"children,omitempty"`
+	ID                  int             `json:"id"`
+	Name                string          `json:"name"`
+	ParentID            *int            `json:"parent_id"`
+	IsActive            bool            `json:"is_active"`
+	StatementClosingDay *int            `json:"statement_closing_day,omitempty"` // 카드 명세서 마감일 (1~31)
+	PaymentDueDay       *int            `json:"payment_due_day,omitempty"`       // 카드 결제일 (1~31)
+	CreatedAt           time.Time       `json:"created_at"`
+	UpdatedAt           time.Time       `json:"updated_at"`
+	Children            []PaymentMethod `json:"children,omitempty"`
 }
 
 // DepositPath 구조체 - 입금 경로 관리
@@ -55,36 +59,54 @@ type DepositPath struct {
 
 // OutAccount 구조체 - 지출 데이터
 type OutAccount struct {
-	UUID              string `json:"uuid"`
-	Date              string `json:"date"`
-	User              string `json:"user"`
-	Money             int    `json:"money"`
-	CategoryID        int    `json:"category_id"`
-	CategoryName      string `json:"category_name,omitempty"`
-	KeywordID         *int   `json:"keyword_id,omitempty"`
-	KeywordName       string `json:"keyword_name,omitempty"`
-	PaymentMethodID   int    `json:"payment_method_id"`
-	PaymentMethodName string `json:"payment_method_name,omitempty"`
-	Memo              string `json:"memo"`
-	CreatedAt         string `json:"created_at"`
-	UpdatedAt         string `json:"updated_at"`
+	UUID              string            `json:"uuid"`
+	Date              string            `json:"date"`
+	User              string            `json:"user"`
+	Money             int               `json:"money"`
+	CategoryID        int               `json:"category_id"`
+	CategoryName      string            `json:"category_name,omitempty"`
+	KeywordID         *int              `json:"keyword_id,omitempty"`
+	KeywordName       string            `json:"keyword_name,omitempty"`
+	PaymentMethodID   int               `json:"payment_method_id"`
+	PaymentMethodName string            `json:"payment_method_name,omitempty"`
+	Memo              string            `json:"memo"`
+	InstallmentID     *string           `json:"installment_id,omitempty"`  // 할부 회차인 경우 할부 UUID
+	InstallmentSeq    *int              `json:"installment_seq,omitempty"` // 할부 회차 (1부터)
+	Splits            []OutAccountSplit `json:"splits,omitempty"`          // 분할 지출인 경우 카테고리별 항목
+	Tags              []string          `json:"tags,omitempty"`
+	Reimbursement     string            `json:"reimbursement,omitempty"`  // 환급 대상인 경우 'pending' 또는 'settled'
+	Currency          string            `json:"currency,omitempty"`       // 외화 거래인 경우 통화 코드 (money 는 환산된 원화 금액)
+	OriginalMoney     float64           `json:"original_money,omitempty"` // 외화 거래인 경우 외화 금액
+	ExchangeRate      float64           `json:"exchange_rate,omitempty"`  // 외화 거래에 적용된 환율
+	CreatedAt         string            `json:"created_at"`
+	UpdatedAt         string            `json:"updated_at"`
 }
 
+// 거래 유형 (태그/첨부파일처럼 수입·지출 양쪽에 붙는 데이터의 account_type)
+const (
+	AccountTypeOut = "out"
+	AccountTypeIn  = "in"
+)
+
 // InAccount 구조체 - 수입 데이터
 type InAccount struct {
-	UUID            string `json:"uuid"`
-	Date            string `json:"date"`
-	User            string `json:"user"`
-	Money           int    `json:"money"`
-	CategoryID      int    `json:"category_id"`
-	CategoryName    string `json:"category_name,omitempty"`
-	KeywordID       *int   `json:"keyword_id,omitempty"`
-	KeywordName     string `json:"keyword_name,omitempty"`
-	DepositPathID   int    `json:"deposit_path_id"`
-	DepositPathName string `json:"deposit_path_name,omitempty"`
-	Memo            string `json:"memo"`
-	CreatedAt       string `json:"created_at"`
-	UpdatedAt       string `json:"updated_at"`
+	UUID            string   `json:"uuid"`
+	Date            string   `json:"date"`
+	User            string   `json:"user"`
+	Money           int      `json:"money"`
+	CategoryID      int      `json:"category_id"`
+	CategoryName    string   `json:"category_name,omitempty"`
+	KeywordID       *int     `json:"keyword_id,omitempty"`
+	KeywordName     string   `json:"keyword_name,omitempty"`
+	DepositPathID   int      `json:"deposit_path_id"`
+	DepositPathName string   `json:"deposit_path_name,omitempty"`
+	Memo            string   `json:"memo"`
+	Tags            []string `json:"tags,omitempty"`
+	Currency        string   `json:"currency,omitempty"`       // 외화 거래인 경우 통화 코드 (money 는 환산된 원화 금액)
+	OriginalMoney   float64  `json:"original_money,omitempty"` // 외화 거래인 경우 외화 금액
+	ExchangeRate    float64  `json:"exchange_rate,omitempty"`  // 외화 거래에 적용된 환율
+	CreatedAt       string   `json:"created_at"`
+	UpdatedAt       string   `json:"updated_at"`
 }
 
 // KeywordSuggestion 구조체 - 키워드 자동완성용
@@ -188,14 +210,18 @@ type ErrorResponse struct {
 
 // CategoryBudget 구조체 - 카테고리별 기준치 관리
 type CategoryBudget struct {
-	ID            int       `json:"id"`
-	CategoryID    int       `json:"category_id"`
-	CategoryName  string    `json:"category_name,omitempty"`
-	UserName      string    `json:"user_name"`
-	MonthlyBudget int       `json:"monthly_budget"` // 월 기준치
-	YearlyBudget  int       `json:"yearly_budget"`  // 연 기준치
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID            int    `json:"id"`
+	CategoryID    int    `json:"category_id"`
+	CategoryName  string `json:"category_name,omitempty"`
+	UserName      string `json:"user_name"`
+	MonthlyBudget int    `json:"monthly_budget"` // 월 기준치
+	YearlyBudget  int    `json:"yearly_budget"`  // 연 기준치
+	// 이월 모드: 남은(초과한) 월 기준치를 다음 달로 이월
+	RolloverEnabled bool      `json:"rollover_enabled"`
+	RolloverCap     *int      `json:"rollover_cap,omitempty"`  // 이월 금액 상한 (절댓값, null 이면 제한 없음)
+	RolloverFrom    string    `json:"rollover_from,omitempty"` // YYYY-MM, 이 달부터 이월 누적
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
 }
 
 // BudgetUsage 구조체 - 기준치 사용량 정보
@@ -212,6 +238,10 @@ type BudgetUsage struct {
 	YearlyPercent    float64 `json:"yearly_percent"`    // 연 사용 퍼센티지
 	IsMonthlyOver    bool    `json:"is_monthly_over"`   // 월 기준치 초과 여부
 	IsYearlyOver     bool    `json:"is_yearly_over"`    // 연 기준치 초과 여부
+	// 이월 모드일 때 월 잔여/사용률/초과 여부는 사용 가능 금액 기준
+	RolloverEnabled bool `json:"rollover_enabled"`
+	CarriedAmount   int  `json:"carried_amount"`   // 지난달까지 이월된 금액 (초과 지출은 음수)
+	AvailableAmount int  `json:"available_amount"` // 이번 달 사용 가능 금액 (월 기준치 + 이월 금액)
 }
 
 // CategoryBudgetRequest 구조체 - 기준치 요청
@@ -220,20 +250,23 @@ type CategoryBudgetRequest struct {
 	UserName      string `json:"user_name"`
 	MonthlyBudget int    `json:"monthly_budget"`
 	YearlyBudget  int    `json:"yearly_budget"`
+	EffectiveFrom string `json:"effective_from,omitempty"` // YYYY-MM, 비어 있으면 이번 달부터 적용
 }
 
 // MonthlyBudgetRequest 구조체 - 월별 기준치 요청
 type MonthlyBudgetRequest struct {
-	CategoryID int    `json:"category_id"`
-	UserName   string `json:"user_name"`
-	Amount     int    `json:"amount"`
+	CategoryID    int    `json:"category_id"`
+	UserName      string `json:"user_name"`
+	Amount        int    `json:"amount"`
+	EffectiveFrom string `json:"effective_from,omitempty"` // YYYY-MM, 비어 있으면 이번 달부터 적용
 }
 
 // YearlyBudgetRequest 구조체 - 연별 기준치 요청
 type YearlyBudgetRequest struct {
-	CategoryID int    `json:"category_id"`
-	UserName   string `json:"user_name"`
-	Amount     int    `json:"amount"`
+	CategoryID    int    `json:"category_id"`
+	UserName      string `json:"user_name"`
+	Amount        int    `json:"amount"`
+	EffectiveFrom string `json:"effective_from,omitempty"` // YYYY-MM, 비어 있으면 이번 달부터 적용
 }
 
 // OutAccountWithBudget 구조체 - 기준치 정보 포함 지출 응답
@@ -0,0 +1,79 @@
+package scheduler
+
+import (
+	"time"
+
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// BackupCreator 백업 생성/정리 기능을 제공하는 저장소 인터페이스
+type BackupCreator interface {
+	AcquireConn() func()
+	ListBackups(dir string) ([]models.BackupInfo, error)
+	CreateBackup(dir, kind string) (*models.BackupInfo, error)
+	PruneBackups(dir string, keep int) (int, error)
+}
+
+// StartBackupScheduler interval 간격으로 자동 백업을 만들고 최신 retention 개만 보관하는 백그라운드 작업 시작
+// 서버를 재시작해도 백업이 몰리지 않도록 마지막 자동 백업 시각 기준으로 첫 실행을 예약
+func StartBackupScheduler(creator BackupCreator, dir string, interval time.Duration, retention int) {
+	if interval <= 0 {
+		utils.Info("자동 백업 스케줄러 비활성화됨")
+		return
+	}
+
+	run := func() {
+		release := creator.AcquireConn()
+		defer release()
+
+		if _, err := creator.CreateBackup(dir, models.BackupKindAuto); err != nil {
+			utils.LogError("자동 백업", err)
+			return
+		}
+		if _, err := creator.PruneBackups(dir, retention); err != nil {
+			utils.LogError("오래된 백업 정리", err)
+		}
+	}
+
+	firstDelay := time.Duration(0)
+	if last := lastAutoBackupTime(creator, dir); !last.IsZero() {
+		if elapsed := time.Since(last); elapsed < interval {
+			firstDelay = interval - elapsed
+		}
+	}
+
+	go func() {
+		time.Sleep(firstDelay)
+		run()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			run()
+		}
+	}()
+
+	utils.Info("자동 백업 스케줄러 시작: %v 간격, %d개 보관, 디렉토리=%s (첫 백업까지 %v)", interval, retention, dir, firstDelay.Round(time.Second))
+}
+
+// lastAutoBackupTime 가장 최근 자동 백업의 생성 시각 (없으면 zero time)
+func lastAutoBackupTime(creator BackupCreator, dir string) time.Time {
+	backups, err := creator.ListBackups(dir)
+	if err != nil {
+		utils.LogError("백업 목록 조회", err)
+		return time.Time{}
+	}
+
+	for _, backup := range backups {
+		if backup.Kind != models.BackupKindAuto {
+			continue
+		}
+		createdAt, err := utils.ParseDateTimeKST(backup.CreatedAt)
+		if err != nil {
+			return time.Time{}
+		}
+		return createdAt
+	}
+	return time.Time{}
+}
@@ -0,0 +1,107 @@
+package scheduler
+
+import (
+	"fmt"
+	"time"
+
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// notificationBatchSize 한 번에 발송하는 최대 알림 수
+const notificationBatchSize = 50
+
+// NotificationStore 기준치 알림 확인과 알림 발송 대기열 기능을 제공하는 저장소 인터페이스
+type NotificationStore interface {
+	AcquireConn() func()
+	EvaluateBudgetAlerts(now time.Time) (int, error)
+	GetDueNotificationDeliveries(now time.Time, limit int) ([]models.NotificationDelivery, error)
+	CompleteNotificationDelivery(id int, sendErr error, now time.Time) error
+}
+
+// NotificationScheduler 기준치 알림 확인과 알림 발송을 처리하는 백그라운드 작업
+type NotificationScheduler struct {
+	store NotificationStore
+	smtp  utils.SMTPConfig
+	wake  chan struct{}
+}
+
+// StartNotificationScheduler interval 간격으로 기준치 사용률을 확인하고 발송 시각이 된 알림을 보내는 백그라운드 작업 시작
+// 지출 등록 등으로 Trigger 가 호출되면 다음 주기를 기다리지 않고 바로 실행
+func StartNotificationScheduler(store NotificationStore, smtpConfig utils.SMTPConfig, interval time.Duration) *NotificationScheduler {
+	if interval <= 0 {
+		utils.Info("알림 스케줄러 비활성화됨")
+		return nil
+	}
+
+	s := &NotificationScheduler{store: store, smtp: smtpConfig, wake: make(chan struct{}, 1)}
+	go func() {
+		s.run()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+			case <-s.wake:
+			}
+			s.run()
+		}
+	}()
+
+	utils.Info("알림 스케줄러 시작: %v 간격, SMTP=%s", interval, smtpConfig.Host)
+	return s
+}
+
+// Trigger 기준치 알림 확인과 대기 중인 알림 발송을 곧바로 실행하도록 요청 (스케줄러가 꺼져 있으면 무시)
+func (s *NotificationScheduler) Trigger() {
+	if s == nil {
+		return
+	}
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run 기준치 알림을 확인해 대기열에 넣고 발송 시각이 된 알림 발송
+// 웹훅/SMTP 응답을 기다리는 동안 백업 복원을 막지 않도록 발송 중에는 DB 잠금을 풀어 둠
+func (s *NotificationScheduler) run() {
+	release := s.store.AcquireConn()
+	if _, err := s.store.EvaluateBudgetAlerts(utils.GetCurrentKST()); err != nil {
+		utils.LogError("기준치 알림 확인", err)
+	}
+	deliveries, err := s.store.GetDueNotificationDeliveries(utils.GetCurrentKST(), notificationBatchSize)
+	release()
+	if err != nil {
+		utils.LogError("발송 대기 알림 조회", err)
+		return
+	}
+
+	for _, delivery := range deliveries {
+		sendErr := s.send(delivery)
+		if sendErr != nil {
+			utils.Warning("알림 발송 실패: ID=%d, 채널=%s, %d번째 시도: %v", delivery.ID, delivery.ChannelName, delivery.Attempts+1, sendErr)
+		} else {
+			utils.Info("알림 발송: ID=%d, 채널=%s, 제목=%s", delivery.ID, delivery.ChannelName, delivery.Subject)
+		}
+
+		release := s.store.AcquireConn()
+		if err := s.store.CompleteNotificationDelivery(delivery.ID, sendErr, utils.GetCurrentKST()); err != nil {
+			utils.LogError("알림 발송 결과 저장", err)
+		}
+		release()
+	}
+}
+
+// send 채널 종류에 맞게 알림 1건 발송
+func (s *NotificationScheduler) send(delivery models.NotificationDelivery) error {
+	switch delivery.ChannelType {
+	case models.NotificationChannelWebhook:
+		return utils.SendWebhook(delivery.Target, delivery.Subject, delivery.Message)
+	case models.NotificationChannelEmail:
+		return utils.SendEmail(s.smtp, delivery.Target, delivery.Subject, delivery.Message)
+	default:
+		return fmt.Errorf("지원하지 않는 알림 채널 종류: %s", delivery.ChannelType)
+	}
+}
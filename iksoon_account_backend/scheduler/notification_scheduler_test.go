@@ -0,0 +1,97 @@
+package scheduler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// fakeNotificationStore 발송 대기 알림을 돌려주고 발송 결과를 기록하는 테스트용 저장소
+type fakeNotificationStore struct {
+	mu         sync.Mutex
+	evaluated  int
+	due        []models.NotificationDelivery
+	completed  map[int]error
+	connLocked bool
+}
+
+func (f *fakeNotificationStore) AcquireConn() func() {
+	f.mu.Lock()
+	f.connLocked = true
+	return func() {
+		f.connLocked = false
+		f.mu.Unlock()
+	}
+}
+
+func (f *fakeNotificationStore) EvaluateBudgetAlerts(now time.Time) (int, error) {
+	f.evaluated++
+	return 0, nil
+}
+
+func (f *fakeNotificationStore) GetDueNotificationDeliveries(now time.Time, limit int) ([]models.NotificationDelivery, error) {
+	return f.due, nil
+}
+
+func (f *fakeNotificationStore) CompleteNotificationDelivery(id int, sendErr error, now time.Time) error {
+	f.completed[id] = sendErr
+	return nil
+}
+
+func TestNotificationSchedulerRun(t *testing.T) {
+	var store *fakeNotificationStore
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		// 발송 중에는 DB 잠금을 잡고 있지 않아야 함
+		if !store.mu.TryLock() {
+			t.Error("웹훅 발송 중 DB 잠금을 잡고 있음")
+		} else {
+			store.mu.Unlock()
+		}
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	store = &fakeNotificationStore{
+		due: []models.NotificationDelivery{
+			{ID: 1, ChannelType: models.NotificationChannelWebhook, Target: server.URL + "/ok", Subject: "성공"},
+			{ID: 2, ChannelType: models.NotificationChannelWebhook, Target: server.URL + "/fail", Subject: "실패"},
+			{ID: 3, ChannelType: models.NotificationChannelEmail, Target: "a@example.com", Subject: "SMTP 미설정"},
+			{ID: 4, ChannelType: "sms", Target: "010", Subject: "지원 안 함"},
+		},
+		completed: map[int]error{},
+	}
+	s := &NotificationScheduler{store: store, smtp: utils.SMTPConfig{}, wake: make(chan struct{}, 1)}
+	s.run()
+
+	if store.evaluated != 1 {
+		t.Errorf("기준치 알림 확인 횟수 = %d, want 1", store.evaluated)
+	}
+	if requests != 2 {
+		t.Errorf("웹훅 요청 수 = %d, want 2", requests)
+	}
+	if len(store.completed) != 4 {
+		t.Fatalf("발송 결과 기록 수 = %d, want 4", len(store.completed))
+	}
+	if err := store.completed[1]; err != nil {
+		t.Errorf("ID=1 발송 결과 = %v, want 성공", err)
+	}
+	for _, id := range []int{2, 3, 4} {
+		if store.completed[id] == nil {
+			t.Errorf("ID=%d 발송 결과가 실패로 기록되지 않음", id)
+		}
+	}
+	if store.connLocked {
+		t.Error("실행 후 DB 잠금이 해제되지 않음")
+	}
+}
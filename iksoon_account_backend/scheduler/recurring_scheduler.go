@@ -0,0 +1,44 @@
+package scheduler
+
+import (
+	"time"
+
+	"iksoon_account_backend/models"
+	"iksoon_account_backend/utils"
+)
+
+// RecurringGenerator 정기 거래 생성 기능을 제공하는 저장소 인터페이스
+type RecurringGenerator interface {
+	AcquireConn() func()
+	GenerateRecurringTransactions(now time.Time) (*models.RecurringGenerationResult, error)
+}
+
+// StartRecurringScheduler 서버 시작 시 한 번, 이후 interval 간격으로 정기 거래를 생성하는 백그라운드 작업 시작
+// 서버가 중단되었던 기간의 거래는 첫 실행에서 따라잡음
+func StartRecurringScheduler(generator RecurringGenerator, interval time.Duration) {
+	if interval <= 0 {
+		utils.Info("정기 거래 스케줄러 비활성화됨")
+		return
+	}
+
+	run := func() {
+		release := generator.AcquireConn()
+		defer release()
+
+		if _, err := generator.GenerateRecurringTransactions(utils.GetCurrentKST()); err != nil {
+			utils.LogError("정기 거래 자동 생성", err)
+		}
+	}
+
+	go func() {
+		run()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			run()
+		}
+	}()
+
+	utils.Info("정기 거래 스케줄러 시작: %v 간격", interval)
+}
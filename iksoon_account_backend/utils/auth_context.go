@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+
+	"iksoon_account_backend/models"
+)
+
+// authContextKey 요청 컨텍스트에 인증 사용자를 저장하기 위한 키 타입
+type authContextKey struct{}
+
+// WithAuthUser 인증된 사용자를 요청 컨텍스트에 저장
+func WithAuthUser(r *http.Request, user *models.User) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), authContextKey{}, user))
+}
+
+// AuthUserFromRequest 요청 컨텍스트에서 인증된 사용자 조회 (인증되지 않았으면 nil)
+func AuthUserFromRequest(r *http.Request) *models.User {
+	user, _ := r.Context().Value(authContextKey{}).(*models.User)
+	return user
+}
+
+// ResolveRequestUser 인증된 사용자가 있으면 그 이름을, 없으면 요청 본문의 사용자명을 반환
+func ResolveRequestUser(r *http.Request, bodyUser string) string {
+	if user := AuthUserFromRequest(r); user != nil {
+		return user.Name
+	}
+	return bodyUser
+}
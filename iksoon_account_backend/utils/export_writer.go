@@ -0,0 +1,215 @@
+package utils
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// NewExcelCSVWriter 엑셀에서 한글이 깨지지 않도록 UTF-8 BOM을 먼저 쓰는 CSV 작성기 생성
+func NewExcelCSVWriter(w io.Writer) (*csv.Writer, error) {
+	if _, err := w.Write([]byte("\xef\xbb\xbf")); err != nil {
+		return nil, err
+	}
+	return csv.NewWriter(w), nil
+}
+
+// EscapeCSVFormula 스프레드시트가 수식으로 해석하는 문자(=, +, -, @, 탭, CR)로 시작하는 값 앞에 ' 를 붙임 (CSV 수식 주입 방지)
+func EscapeCSVFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// JSONArrayWriter 항목을 하나씩 JSON 배열로 스트리밍하는 작성기
+type JSONArrayWriter struct {
+	w     io.Writer
+	count int
+}
+
+// NewJSONArrayWriter JSON 배열 작성기 생성
+func NewJSONArrayWriter(w io.Writer) *JSONArrayWriter {
+	return &JSONArrayWriter{w: w}
+}
+
+// Write 배열 항목 하나를 기록
+func (j *JSONArrayWriter) Write(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	prefix := ",\n"
+	if j.count == 0 {
+		prefix = "[\n"
+	}
+	if _, err := io.WriteString(j.w, prefix); err != nil {
+		return err
+	}
+	if _, err := j.w.Write(data); err != nil {
+		return err
+	}
+	j.count++
+	return nil
+}
+
+// Close 배열을 닫음 (항목이 없으면 빈 배열)
+func (j *JSONArrayWriter) Close() error {
+	closing := "\n]\n"
+	if j.count == 0 {
+		closing = "[]\n"
+	}
+	_, err := io.WriteString(j.w, closing)
+	return err
+}
+
+// XLSXStreamWriter 시트 하나짜리 XLSX 파일을 행 단위로 스트리밍하는 작성기
+// 공유 문자열 없이 inlineStr 셀을 사용하므로 전체 데이터를 메모리에 올리지 않음
+type XLSXStreamWriter struct {
+	archive *zip.Writer
+	sheet   io.Writer
+	rowNum  int
+}
+
+// xlsx 패키지 고정 파일 (시트 데이터 앞에 먼저 기록)
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`
+	xlsxWorkbookFormat = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetEnd = `</sheetData></worksheet>`
+)
+
+// NewXLSXStreamWriter XLSX 작성기 생성 (패키지 구성 파일을 먼저 기록하고 시트 데이터를 열어둠)
+func NewXLSXStreamWriter(w io.Writer, sheetName string) (*XLSXStreamWriter, error) {
+	archive := zip.NewWriter(w)
+
+	var escapedName strings.Builder
+	if err := xml.EscapeText(&escapedName, []byte(sheetName)); err != nil {
+		return nil, err
+	}
+
+	fixedFiles := []struct{ name, content string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbookFormat, escapedName.String())},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	}
+	for _, file := range fixedFiles {
+		fw, err := archive.Create(file.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(fw, file.content); err != nil {
+			return nil, err
+		}
+	}
+
+	sheet, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(sheet, xlsxSheetStart); err != nil {
+		return nil, err
+	}
+
+	return &XLSXStreamWriter{archive: archive, sheet: sheet}, nil
+}
+
+// WriteRow 행 하나를 기록 (정수는 숫자 셀, 그 외는 문자열 셀, nil 은 빈 셀)
+func (x *XLSXStreamWriter) WriteRow(values []interface{}) error {
+	x.rowNum++
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<row r="%d">`, x.rowNum)
+	for i, value := range values {
+		ref := columnName(i) + strconv.Itoa(x.rowNum)
+		switch v := value.(type) {
+		case nil:
+			continue
+		case int:
+			fmt.Fprintf(&sb, `<c r="%s"><v>%d</v></c>`, ref, v)
+		case *int:
+			if v == nil {
+				continue
+			}
+			fmt.Fprintf(&sb, `<c r="%s"><v>%d</v></c>`, ref, *v)
+		case float64:
+			fmt.Fprintf(&sb, `<c r="%s"><v>%s</v></c>`, ref, strconv.FormatFloat(v, 'f', -1, 64))
+		default:
+			text := fmt.Sprint(v)
+			if text == "" {
+				continue
+			}
+			fmt.Fprintf(&sb, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+			if err := xml.EscapeText(&sb, []byte(text)); err != nil {
+				return err
+			}
+			sb.WriteString(`</t></is></c>`)
+		}
+	}
+	sb.WriteString(`</row>`)
+
+	_, err := io.WriteString(x.sheet, sb.String())
+	return err
+}
+
+// Close 시트를 닫고 zip 목차를 기록
+func (x *XLSXStreamWriter) Close() error {
+	if _, err := io.WriteString(x.sheet, xlsxSheetEnd); err != nil {
+		return err
+	}
+	return x.archive.Close()
+}
+
+// columnName 0부터 시작하는 열 번호를 엑셀 열 이름(A, B, ..., AA)으로 변환
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// WriteZipFile 디스크의 파일을 zip 항목으로 기록 (이름은 UTF-8 로 표시되도록 플래그 설정)
+func WriteZipFile(archive *zip.Writer, name, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	header, err := zip.FileInfoHeader(stat)
+	if err != nil {
+		return err
+	}
+	header.Name = name
+	header.Method = zip.Deflate
+	header.Flags |= 0x800
+
+	entry, err := archive.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(entry, file)
+	return err
+}
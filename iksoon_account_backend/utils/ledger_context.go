@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+)
+
+// DefaultLedgerID 기본 가계부 ID (기존 단일 가계부 데이터가 속한 가계부)
+const DefaultLedgerID = 1
+
+// ledgerContextKey 요청 컨텍스트에 현재 가계부 ID를 저장하기 위한 키 타입
+type ledgerContextKey struct{}
+
+// WithLedgerID 현재 요청의 가계부 ID를 요청 컨텍스트에 저장
+func WithLedgerID(r *http.Request, ledgerID int) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), ledgerContextKey{}, ledgerID))
+}
+
+// LedgerIDFromRequest 요청 컨텍스트에서 가계부 ID 조회 (없으면 기본 가계부)
+func LedgerIDFromRequest(r *http.Request) int {
+	if ledgerID, ok := r.Context().Value(ledgerContextKey{}).(int); ok && ledgerID > 0 {
+		return ledgerID
+	}
+	return DefaultLedgerID
+}
@@ -0,0 +1,177 @@
+package utils
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxLoginThrottleKeys 실패 기록을 보관하는 최대 키(사용자 이름/IP) 수
+// 임의의 이름/IP 로 계속 시도해도 메모리가 한없이 늘지 않도록, 넘으면 가장 오래전에 실패한 키부터 삭제
+const maxLoginThrottleKeys = 10000
+
+// LoginThrottle 로그인 실패 횟수 제한 (메모리 보관, 서버 재시작 시 초기화)
+// 사용자 이름별, 접속 IP별로 Window 안의 실패 횟수를 세고 한도를 넘으면 Window 가 지날 때까지 로그인 시도를 막음
+type LoginThrottle struct {
+	MaxFailuresPerName int           // 같은 사용자 이름으로 허용하는 실패 횟수
+	MaxFailuresPerIP   int           // 같은 IP 에서 허용하는 실패 횟수 (여러 사용자 이름을 번갈아 시도하는 경우)
+	Window             time.Duration // 실패 횟수를 세는 기간이자 차단 기간
+
+	mu        sync.Mutex
+	failures  map[string][]time.Time
+	lastPrune time.Time
+}
+
+// Blocked 로그인 시도가 차단 중이면 남은 차단 시간과 true 반환
+func (t *LoginThrottle) Blocked(name, ip string, now time.Time) (time.Duration, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var retryAfter time.Duration
+	for _, key := range []struct {
+		key   string
+		limit int
+	}{{loginNameKey(name), t.MaxFailuresPerName}, {"ip:" + ip, t.MaxFailuresPerIP}} {
+		recent := t.recentFailures(key.key, now)
+		if key.limit <= 0 || len(recent) < key.limit {
+			continue
+		}
+		// 한도에 도달한 실패 중 가장 오래된 기록이 Window 를 벗어나면 다시 시도 가능
+		if wait := recent[len(recent)-key.limit].Add(t.Window).Sub(now); wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+	return retryAfter, retryAfter > 0
+}
+
+// RecordFailure 로그인 실패 기록
+func (t *LoginThrottle) RecordFailure(name, ip string, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.failures == nil {
+		t.failures = make(map[string][]time.Time)
+	}
+	t.prune(now)
+	for _, key := range []string{loginNameKey(name), "ip:" + ip} {
+		records := t.recentFailures(key, now)
+		if records == nil && len(t.failures) >= maxLoginThrottleKeys {
+			t.evictOldest()
+		}
+		t.failures[key] = append(records, now)
+	}
+}
+
+// Reset 로그인 성공 시 해당 사용자 이름의 실패 기록 삭제 (IP 기록은 유지)
+func (t *LoginThrottle) Reset(name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.failures, loginNameKey(name))
+}
+
+// recentFailures Window 안의 실패 기록만 남기고 반환 (잠금을 잡은 상태에서 호출)
+func (t *LoginThrottle) recentFailures(key string, now time.Time) []time.Time {
+	records := t.failures[key]
+	start := 0
+	for start < len(records) && !records[start].Add(t.Window).After(now) {
+		start++
+	}
+	if start == len(records) {
+		delete(t.failures, key)
+		return nil
+	}
+	records = records[start:]
+	t.failures[key] = records
+	return records
+}
+
+// prune Window 가 지난 실패 기록을 모든 키에서 정리 (Window 마다 한 번, 잠금을 잡은 상태에서 호출)
+// 다시 시도하지 않는 사용자 이름/IP 의 기록도 남지 않도록 함
+func (t *LoginThrottle) prune(now time.Time) {
+	if now.Sub(t.lastPrune) < t.Window {
+		return
+	}
+	t.lastPrune = now
+	for key := range t.failures {
+		t.recentFailures(key, now)
+	}
+}
+
+// evictOldest 마지막 실패가 가장 오래된 키의 기록 삭제 (잠금을 잡은 상태에서 호출)
+func (t *LoginThrottle) evictOldest() {
+	oldestKey := ""
+	var oldest time.Time
+	for key, records := range t.failures {
+		if last := records[len(records)-1]; oldestKey == "" || last.Before(oldest) {
+			oldestKey, oldest = key, last
+		}
+	}
+	delete(t.failures, oldestKey)
+}
+
+// loginNameKey 사용자 이름 기준 키 (대소문자/앞뒤 공백 차이로 한도를 우회하지 못하도록 정규화)
+func loginNameKey(name string) string {
+	return "name:" + strings.ToLower(strings.TrimSpace(name))
+}
+
+// TrustedProxies X-Real-IP 헤더를 믿을 수 있는 프록시(Nginx 등) 주소 목록
+type TrustedProxies []*net.IPNet
+
+// ParseTrustedProxies 쉼표로 구분한 IP 또는 CIDR 목록 파싱 (예: "127.0.0.1, 172.20.0.0/16")
+func ParseTrustedProxies(value string) (TrustedProxies, error) {
+	var proxies TrustedProxies
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("올바르지 않은 프록시 주소: %s", entry)
+			}
+			bits := 128
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("올바르지 않은 프록시 주소: %s", entry)
+		}
+		proxies = append(proxies, network)
+	}
+	return proxies, nil
+}
+
+// contains 주소가 신뢰하는 프록시 범위에 있는지 확인
+func (p TrustedProxies) contains(ip net.IP) bool {
+	for _, network := range p {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP 요청한 클라이언트 IP
+// 신뢰하는 프록시에서 온 요청만 X-Real-IP 헤더를 사용하고, 그 밖에는 헤더를 무시하고 접속 주소 사용
+// (클라이언트가 헤더를 바꿔 보내며 IP별 로그인 제한을 우회하지 못하도록)
+func (p TrustedProxies) ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	if remote := net.ParseIP(host); remote != nil && p.contains(remote) {
+		if realIP := net.ParseIP(strings.TrimSpace(r.Header.Get("X-Real-IP"))); realIP != nil {
+			return realIP.String()
+		}
+	}
+	return host
+}
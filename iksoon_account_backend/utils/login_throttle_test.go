@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTrustedProxiesClientIP(t *testing.T) {
+	proxies, err := ParseTrustedProxies("127.0.0.1, 172.20.0.0/16")
+	if err != nil {
+		t.Fatalf("ParseTrustedProxies() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		proxies    TrustedProxies
+		remoteAddr string
+		realIP     string
+		want       string
+	}{
+		{"프록시 미설정 시 헤더 무시", nil, "203.0.113.7:51234", "198.51.100.1", "203.0.113.7"},
+		{"신뢰하지 않는 주소의 헤더 무시", proxies, "203.0.113.7:51234", "198.51.100.1", "203.0.113.7"},
+		{"신뢰하는 단일 주소", proxies, "127.0.0.1:40000", "198.51.100.1", "198.51.100.1"},
+		{"신뢰하는 대역", proxies, "172.20.0.3:40000", "198.51.100.1", "198.51.100.1"},
+		{"프록시의 헤더가 비어 있으면 접속 주소", proxies, "172.20.0.3:40000", "", "172.20.0.3"},
+		{"프록시의 헤더가 IP 가 아니면 접속 주소", proxies, "172.20.0.3:40000", "unknown", "172.20.0.3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/auth/login", nil)
+			r.RemoteAddr = tt.remoteAddr
+			if tt.realIP != "" {
+				r.Header.Set("X-Real-IP", tt.realIP)
+			}
+			if got := tt.proxies.ClientIP(r); got != tt.want {
+				t.Errorf("ClientIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTrustedProxiesInvalid(t *testing.T) {
+	for _, value := range []string{"localhost", "10.0.0.0/33", "127.0.0.1, nginx"} {
+		if _, err := ParseTrustedProxies(value); err == nil {
+			t.Errorf("ParseTrustedProxies(%q) 오류가 반환되지 않음", value)
+		}
+	}
+	if proxies, err := ParseTrustedProxies(" "); err != nil || len(proxies) != 0 {
+		t.Errorf("빈 값: proxies = %v, err = %v; want 빈 목록", proxies, err)
+	}
+}
+
+func TestLoginThrottleSpoofedHeaderSharesLimit(t *testing.T) {
+	throttle := &LoginThrottle{MaxFailuresPerIP: 3, Window: 15 * time.Minute}
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	// 신뢰하지 않는 클라이언트가 X-Real-IP 를 바꿔 보내도 같은 IP 한도로 셈
+	var proxies TrustedProxies
+	for i := 0; i < 3; i++ {
+		r := httptest.NewRequest("POST", "/auth/login", nil)
+		r.RemoteAddr = "203.0.113.7:51234"
+		r.Header.Set("X-Real-IP", fmt.Sprintf("198.51.100.%d", i))
+		throttle.RecordFailure(fmt.Sprintf("user%d", i), proxies.ClientIP(r), now)
+	}
+	if _, blocked := throttle.Blocked("other", "203.0.113.7", now); !blocked {
+		t.Error("헤더를 바꿔 보낸 시도가 IP 한도를 우회함")
+	}
+}
+
+func TestLoginThrottlePrunesExpiredRecords(t *testing.T) {
+	throttle := &LoginThrottle{MaxFailuresPerName: 5, MaxFailuresPerIP: 20, Window: 15 * time.Minute}
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 50; i++ {
+		throttle.RecordFailure(fmt.Sprintf("user%d", i), fmt.Sprintf("203.0.113.%d", i), now)
+	}
+	if len(throttle.failures) != 100 {
+		t.Fatalf("기록된 키 = %d, want 100", len(throttle.failures))
+	}
+
+	// Window 가 지난 뒤 다른 실패가 기록되면 다시 시도하지 않은 키의 기록도 정리
+	throttle.RecordFailure("admin", "198.51.100.1", now.Add(16*time.Minute))
+	if len(throttle.failures) != 2 {
+		t.Errorf("정리 후 키 = %d, want 2", len(throttle.failures))
+	}
+}
+
+func TestLoginThrottleCapsKeys(t *testing.T) {
+	throttle := &LoginThrottle{MaxFailuresPerName: 5, MaxFailuresPerIP: 20, Window: time.Hour}
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	// Window 안에서 서로 다른 이름/IP 로 계속 실패해도 키 수는 한도 이하
+	for i := 0; i < maxLoginThrottleKeys; i++ {
+		throttle.RecordFailure(fmt.Sprintf("user%d", i), fmt.Sprintf("ip%d", i), start.Add(time.Duration(i)*time.Millisecond))
+	}
+	if len(throttle.failures) > maxLoginThrottleKeys {
+		t.Fatalf("키 수 = %d, want <= %d", len(throttle.failures), maxLoginThrottleKeys)
+	}
+
+	// 가장 최근 실패는 남고 가장 오래된 실패부터 삭제
+	last := maxLoginThrottleKeys - 1
+	if _, ok := throttle.failures[loginNameKey(fmt.Sprintf("user%d", last))]; !ok {
+		t.Error("가장 최근 실패 기록이 삭제됨")
+	}
+	if _, ok := throttle.failures[loginNameKey("user0")]; ok {
+		t.Error("가장 오래된 실패 기록이 남아 있음")
+	}
+}
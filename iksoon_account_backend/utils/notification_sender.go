@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// webhookTimeout 웹훅 요청 1건의 최대 대기 시간
+const webhookTimeout = 10 * time.Second
+
+// webhookClient 웹훅 발송용 HTTP 클라이언트
+var webhookClient = &http.Client{Timeout: webhookTimeout}
+
+// SMTPConfig 이메일 알림 발송용 SMTP 서버 설정 (Host 가 비어 있으면 이메일 발송 불가)
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// SendWebhook 웹훅 URL 로 알림 POST (Slack 의 text, Discord 의 content 필드를 함께 채워 양쪽과 호환)
+// 2xx 가 아닌 응답은 오류로 처리
+func SendWebhook(url, subject, message string) error {
+	text := subject + "\n" + message
+	body, err := json.Marshal(map[string]string{
+		"text":    text,
+		"content": text,
+		"subject": subject,
+		"message": message,
+	})
+	if err != nil {
+		return fmt.Errorf("웹훅 본문 생성 오류: %v", err)
+	}
+
+	resp, err := webhookClient.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("웹훅 요청 오류: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return fmt.Errorf("웹훅 응답 오류: HTTP %d %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+// SendEmail SMTP 로 이메일 알림 발송 (to 는 쉼표로 구분한 주소 목록, 서버가 지원하면 STARTTLS 사용)
+func SendEmail(cfg SMTPConfig, to, subject, message string) error {
+	if cfg.Host == "" {
+		return fmt.Errorf("SMTP_HOST가 설정되지 않아 이메일을 보낼 수 없습니다")
+	}
+
+	addresses, err := mail.ParseAddressList(to)
+	if err != nil {
+		return fmt.Errorf("수신 주소 오류: %v", err)
+	}
+	recipients := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		recipients = append(recipients, address.Address)
+	}
+
+	from := cfg.From
+	if from == "" {
+		from = cfg.Username
+	}
+	sender, err := mail.ParseAddress(from)
+	if err != nil {
+		return fmt.Errorf("발신 주소(SMTP_FROM) 오류: %v", err)
+	}
+
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "From: %s\r\n", sender.String())
+	fmt.Fprintf(&body, "To: %s\r\n", strings.Join(recipients, ", "))
+	fmt.Fprintf(&body, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", subject))
+	fmt.Fprintf(&body, "Date: %s\r\n", GetCurrentKST().Format(time.RFC1123Z))
+	body.WriteString("MIME-Version: 1.0\r\n")
+	body.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	body.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	body.WriteString(strings.ReplaceAll(message, "\n", "\r\n"))
+	body.WriteString("\r\n")
+
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+
+	port := cfg.Port
+	if port == "" {
+		port = "587"
+	}
+	if err := smtp.SendMail(net.JoinHostPort(cfg.Host, port), auth, sender.Address, recipients, body.Bytes()); err != nil {
+		return fmt.Errorf("SMTP 발송 오류: %v", err)
+	}
+	return nil
+}
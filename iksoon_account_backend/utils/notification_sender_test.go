@@ -0,0 +1,205 @@
+package utils
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+)
+
+func TestSendWebhook(t *testing.T) {
+	var received map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		if contentType := r.Header.Get("Content-Type"); contentType != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", contentType)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("본문 디코딩 실패: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	if err := SendWebhook(server.URL, "기준치 알림", "식비 80% 사용"); err != nil {
+		t.Fatalf("SendWebhook() error = %v", err)
+	}
+
+	want := map[string]string{
+		"text":    "기준치 알림\n식비 80% 사용",
+		"content": "기준치 알림\n식비 80% 사용",
+		"subject": "기준치 알림",
+		"message": "식비 80% 사용",
+	}
+	for key, value := range want {
+		if received[key] != value {
+			t.Errorf("본문 %s = %q, want %q", key, received[key], value)
+		}
+	}
+}
+
+func TestSendWebhookErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	err := SendWebhook(server.URL, "제목", "내용")
+	if err == nil {
+		t.Fatal("2xx 가 아닌 응답에서 오류가 반환되지 않음")
+	}
+	if !strings.Contains(err.Error(), "HTTP 403") || !strings.Contains(err.Error(), "invalid_token") {
+		t.Errorf("오류 메시지에 상태 코드/응답 본문이 없음: %v", err)
+	}
+}
+
+func TestSendWebhookConnectionError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	if err := SendWebhook(url, "제목", "내용"); err == nil {
+		t.Fatal("연결할 수 없는 URL 에서 오류가 반환되지 않음")
+	}
+}
+
+// smtpMessage 가짜 SMTP 서버가 받은 메일 1건
+type smtpMessage struct {
+	from       string
+	recipients []string
+	data       string
+}
+
+// startFakeSMTPServer 메일 1건을 받아 채널로 넘기는 가짜 SMTP 서버 (STARTTLS/AUTH 미지원)
+func startFakeSMTPServer(t *testing.T) (host, port string, messages <-chan smtpMessage) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("SMTP 리스너 생성 실패: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan smtpMessage, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		text := textproto.NewConn(conn)
+		var message smtpMessage
+		text.PrintfLine("220 localhost ESMTP test")
+		for {
+			line, err := text.ReadLine()
+			if err != nil {
+				return
+			}
+			command := strings.ToUpper(line)
+			switch {
+			case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+				text.PrintfLine("250-localhost")
+				text.PrintfLine("250 8BITMIME")
+			case strings.HasPrefix(command, "MAIL FROM:"):
+				message.from = angleAddress(line)
+				text.PrintfLine("250 OK")
+			case strings.HasPrefix(command, "RCPT TO:"):
+				message.recipients = append(message.recipients, angleAddress(line))
+				text.PrintfLine("250 OK")
+			case command == "DATA":
+				text.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+				data, err := io.ReadAll(text.DotReader())
+				if err != nil {
+					return
+				}
+				message.data = string(data)
+				text.PrintfLine("250 OK")
+			case command == "QUIT":
+				text.PrintfLine("221 Bye")
+				received <- message
+				return
+			default:
+				text.PrintfLine("250 OK")
+			}
+		}
+	}()
+
+	host, port, _ = net.SplitHostPort(listener.Addr().String())
+	return host, port, received
+}
+
+// angleAddress SMTP 명령의 <...> 안 주소 추출 (MAIL FROM 뒤의 BODY=8BITMIME 같은 인자 무시)
+func angleAddress(line string) string {
+	start, end := strings.Index(line, "<"), strings.Index(line, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+	return line[start+1 : end]
+}
+
+func TestSendEmail(t *testing.T) {
+	host, port, messages := startFakeSMTPServer(t)
+	cfg := SMTPConfig{Host: host, Port: port, From: "가계부 <noreply@example.com>"}
+
+	if err := SendEmail(cfg, "a@example.com, B <b@example.com>", "기준치 알림", "첫 줄\n둘째 줄"); err != nil {
+		t.Fatalf("SendEmail() error = %v", err)
+	}
+	message := <-messages
+
+	if message.from != "noreply@example.com" {
+		t.Errorf("MAIL FROM = %q, want noreply@example.com", message.from)
+	}
+	if strings.Join(message.recipients, ",") != "a@example.com,b@example.com" {
+		t.Errorf("RCPT TO = %v, want [a@example.com b@example.com]", message.recipients)
+	}
+
+	header, body, found := strings.Cut(message.data, "\n\n")
+	if !found {
+		t.Fatalf("헤더와 본문 구분이 없음: %q", message.data)
+	}
+	if !strings.Contains(header, "To: a@example.com, b@example.com") {
+		t.Errorf("To 헤더가 없음: %q", header)
+	}
+	if !strings.Contains(header, "Content-Type: text/plain; charset=UTF-8") {
+		t.Errorf("Content-Type 헤더가 없음: %q", header)
+	}
+	var subject string
+	for _, line := range strings.Split(header, "\n") {
+		if strings.HasPrefix(line, "Subject: ") {
+			subject, _ = new(mime.WordDecoder).DecodeHeader(strings.TrimPrefix(line, "Subject: "))
+		}
+	}
+	if subject != "기준치 알림" {
+		t.Errorf("Subject = %q, want 기준치 알림", subject)
+	}
+	if body != "첫 줄\n둘째 줄\n" {
+		t.Errorf("본문 = %q, want 첫 줄/둘째 줄", body)
+	}
+}
+
+func TestSendEmailValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  SMTPConfig
+		to   string
+	}{
+		{"SMTP 미설정", SMTPConfig{}, "a@example.com"},
+		{"잘못된 수신 주소", SMTPConfig{Host: "127.0.0.1", From: "noreply@example.com"}, "not-an-address"},
+		{"발신 주소 없음", SMTPConfig{Host: "127.0.0.1"}, "a@example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := SendEmail(tt.cfg, tt.to, "제목", "내용"); err == nil {
+				t.Fatal("오류가 반환되지 않음")
+			}
+		})
+	}
+}
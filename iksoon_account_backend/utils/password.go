@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	passwordHashScheme     = "pbkdf2-sha256"
+	passwordHashIterations = 210000
+	passwordSaltLength     = 16
+	passwordKeyLength      = 32
+)
+
+// HashPassword PBKDF2-SHA256으로 비밀번호 해시 생성 ("pbkdf2-sha256$반복횟수$salt$hash" 형식)
+func HashPassword(password string) (string, error) {
+	salt := make([]byte, passwordSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("salt 생성 오류: %v", err)
+	}
+
+	key := pbkdf2SHA256([]byte(password), salt, passwordHashIterations, passwordKeyLength)
+	return fmt.Sprintf("%s$%d$%s$%s", passwordHashScheme, passwordHashIterations,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// VerifyPassword 저장된 해시와 비밀번호 일치 여부 확인 (상수 시간 비교)
+func VerifyPassword(password, encoded string) bool {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 4 || parts[0] != passwordHashScheme {
+		return false
+	}
+
+	iterations, err := strconv.Atoi(parts[1])
+	if err != nil || iterations <= 0 {
+		return false
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+	expected, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return false
+	}
+
+	key := pbkdf2SHA256([]byte(password), salt, iterations, len(expected))
+	return hmac.Equal(key, expected)
+}
+
+// GenerateSessionToken 세션 토큰 생성 (클라이언트 전달용 원본 토큰과 DB 저장용 해시 반환)
+func GenerateSessionToken() (token string, tokenHash string, err error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", fmt.Errorf("세션 토큰 생성 오류: %v", err)
+	}
+	token = base64.RawURLEncoding.EncodeToString(raw)
+	return token, HashSessionToken(token), nil
+}
+
+// HashSessionToken 세션 토큰을 DB 조회용 해시로 변환 (원본 토큰은 저장하지 않음)
+func HashSessionToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// pbkdf2SHA256 RFC 8018 PBKDF2 (HMAC-SHA256) 구현
+func pbkdf2SHA256(password, salt []byte, iterations, keyLength int) []byte {
+	prf := hmac.New(sha256.New, password)
+	hashLength := prf.Size()
+	blocks := (keyLength + hashLength - 1) / hashLength
+
+	derived := make([]byte, 0, blocks*hashLength)
+	counter := make([]byte, 4)
+	for block := 1; block <= blocks; block++ {
+		binary.BigEndian.PutUint32(counter, uint32(block))
+
+		prf.Reset()
+		prf.Write(salt)
+		prf.Write(counter)
+		u := prf.Sum(nil)
+
+		t := make([]byte, len(u))
+		copy(t, u)
+		for i := 1; i < iterations; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+		derived = append(derived, t...)
+	}
+
+	return derived[:keyLength]
+}
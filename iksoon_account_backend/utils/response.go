@@ -2,6 +2,7 @@ package utils
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
@@ -34,6 +35,17 @@ func SendError(w http.ResponseWriter, err apiErrors.ErrorCode) {
 	json.NewEncoder(w).Encode(errorResponse)
 }
 
+// SendErrorFrom 저장소 오류가 ErrorCode이면 그대로, 아니면 fallback 에러 코드로 응답 전송
+func SendErrorFrom(w http.ResponseWriter, err error, fallback apiErrors.ErrorCode) {
+	var code apiErrors.ErrorCode
+	if errors.As(err, &code) {
+		SendError(w, code)
+		return
+	}
+	LogError(fallback.Message, err)
+	SendError(w, fallback)
+}
+
 // SendSuccessResponse 성공 응답 전송 헬퍼 함수
 func SendSuccessResponse(w http.ResponseWriter, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
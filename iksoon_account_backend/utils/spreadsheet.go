@@ -0,0 +1,258 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ReadSpreadsheet 업로드된 명세서 파일을 행 단위 문자열 배열로 읽기
+// 확장자가 .xlsx 이면 첫 번째 시트를, 그 외에는 CSV(UTF-8)로 읽음
+func ReadSpreadsheet(filename string, data []byte, delimiter string) ([][]string, error) {
+	if strings.EqualFold(path.Ext(filename), ".xlsx") {
+		return ReadXLSX(data)
+	}
+	return ReadCSV(data, delimiter)
+}
+
+// ReadCSV CSV 데이터를 읽기 (UTF-8 BOM 제거, 행마다 컬럼 수가 달라도 허용)
+func ReadCSV(data []byte, delimiter string) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if !utf8.Valid(data) {
+		return nil, fmt.Errorf("UTF-8 형식의 CSV 파일만 지원합니다 (EUC-KR 파일은 UTF-8로 다시 저장해주세요)")
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	if delimiter != "" {
+		r, size := utf8.DecodeRuneInString(delimiter)
+		if size != len(delimiter) {
+			return nil, fmt.Errorf("구분자는 한 글자여야 합니다: %q", delimiter)
+		}
+		reader.Comma = r
+	}
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("CSV 읽기 오류: %v", err)
+	}
+	return rows, nil
+}
+
+// xlsx 파일 내부 XML 구조 (필요한 부분만 정의)
+type xlsxWorkbook struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxRichText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+// String 일반 텍스트와 서식 있는 텍스트(run)를 합친 문자열
+func (t xlsxRichText) String() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+	var sb strings.Builder
+	sb.WriteString(t.Text)
+	for _, run := range t.Runs {
+		sb.WriteString(run.Text)
+	}
+	return sb.String()
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxRichText `xml:"si"`
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		Cells []struct {
+			Ref          string       `xml:"r,attr"`
+			Type         string       `xml:"t,attr"`
+			Value        string       `xml:"v"`
+			InlineString xlsxRichText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// ReadXLSX XLSX 파일의 첫 번째 시트를 읽기
+// 숫자 셀은 원본 값 그대로 반환하므로 날짜 셀은 엑셀 일련번호(예: 45567)로 읽힘 (ParseExcelSerialDate 로 변환)
+func ReadXLSX(data []byte) ([][]string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("XLSX 파일을 열 수 없습니다: %v", err)
+	}
+
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	var sharedStrings xlsxSharedStrings
+	if file, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := decodeZipXML(file, &sharedStrings); err != nil {
+			return nil, err
+		}
+	}
+
+	sheetPath, err := firstSheetPath(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var sheet xlsxSheet
+	if err := decodeZipXML(files[sheetPath], &sheet); err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(sheet.Rows))
+	for _, sheetRow := range sheet.Rows {
+		var row []string
+		for i, cell := range sheetRow.Cells {
+			col := i
+			if cell.Ref != "" {
+				if parsed, ok := columnIndexFromRef(cell.Ref); ok {
+					col = parsed
+				}
+			}
+			for len(row) <= col {
+				row = append(row, "")
+			}
+
+			switch cell.Type {
+			case "s":
+				index, err := strconv.Atoi(cell.Value)
+				if err != nil || index < 0 || index >= len(sharedStrings.Items) {
+					return nil, fmt.Errorf("XLSX 공유 문자열 참조 오류: %s", cell.Ref)
+				}
+				row[col] = sharedStrings.Items[index].String()
+			case "inlineStr":
+				row[col] = cell.InlineString.String()
+			case "b":
+				row[col] = map[string]string{"1": "TRUE", "0": "FALSE"}[cell.Value]
+			default:
+				row[col] = cell.Value
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// firstSheetPath 통합 문서에서 첫 번째 시트의 파일 경로 찾기
+func firstSheetPath(files map[string]*zip.File) (string, error) {
+	const fallback = "xl/worksheets/sheet1.xml"
+
+	workbookFile, ok := files["xl/workbook.xml"]
+	relsFile, relsOK := files["xl/_rels/workbook.xml.rels"]
+	if !ok || !relsOK {
+		if _, exists := files[fallback]; exists {
+			return fallback, nil
+		}
+		return "", fmt.Errorf("XLSX 파일에서 시트를 찾을 수 없습니다")
+	}
+
+	var workbook xlsxWorkbook
+	if err := decodeZipXML(workbookFile, &workbook); err != nil {
+		return "", err
+	}
+	var rels xlsxRelationships
+	if err := decodeZipXML(relsFile, &rels); err != nil {
+		return "", err
+	}
+
+	if len(workbook.Sheets) > 0 {
+		for _, rel := range rels.Relationships {
+			if rel.ID != workbook.Sheets[0].RID {
+				continue
+			}
+			target := strings.TrimPrefix(rel.Target, "/")
+			if !strings.HasPrefix(target, "xl/") {
+				target = path.Join("xl", target)
+			}
+			if _, exists := files[target]; exists {
+				return target, nil
+			}
+		}
+	}
+
+	if _, exists := files[fallback]; exists {
+		return fallback, nil
+	}
+	return "", fmt.Errorf("XLSX 파일에서 시트를 찾을 수 없습니다")
+}
+
+// decodeZipXML zip 내부 XML 파일 디코드
+func decodeZipXML(file *zip.File, v interface{}) error {
+	reader, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("XLSX 내부 파일 열기 오류 (%s): %v", file.Name, err)
+	}
+	defer reader.Close()
+
+	if err := xml.NewDecoder(io.LimitReader(reader, 64<<20)).Decode(v); err != nil {
+		return fmt.Errorf("XLSX 내부 파일 해석 오류 (%s): %v", file.Name, err)
+	}
+	return nil
+}
+
+// columnIndexFromRef 셀 참조(예: "C12")에서 0부터 시작하는 열 번호 계산
+func columnIndexFromRef(ref string) (int, bool) {
+	col := 0
+	letters := 0
+	for _, ch := range ref {
+		if ch >= 'A' && ch <= 'Z' {
+			col = col*26 + int(ch-'A'+1)
+			letters++
+			continue
+		}
+		break
+	}
+	if letters == 0 {
+		return 0, false
+	}
+	return col - 1, true
+}
+
+// ParseExcelSerialDate 엑셀 날짜 일련번호(1900 날짜 체계)를 KST 날짜로 변환
+func ParseExcelSerialDate(value string) (time.Time, bool) {
+	serial, err := strconv.ParseFloat(value, 64)
+	// 1 (1900-01-01) ~ 2958465 (9999-12-31) 범위만 날짜로 인정
+	if err != nil || serial < 1 || serial > 2958465 {
+		return time.Time{}, false
+	}
+
+	location, err := time.LoadLocation("Asia/Seoul")
+	if err != nil {
+		// Docker 환경에서 시간대 정보가 없는 경우 UTC+9 오프셋 사용
+		location = time.FixedZone("KST", 9*60*60) // UTC+9
+	}
+
+	// 엑셀 일련번호는 시간대 없는 날짜이므로 KST 벽시계 시각으로 해석
+	days := int(serial)
+	seconds := int((serial-float64(days))*86400 + 0.5)
+	return time.Date(1899, 12, 30, 0, 0, 0, 0, location).AddDate(0, 0, days).Add(time.Duration(seconds) * time.Second), true
+}
@@ -113,3 +113,20 @@ func EndOfMonthKST(t time.Time) time.Time {
 	firstOfNextMonth := time.Date(year, month+1, 1, 0, 0, 0, 0, location)
 	return firstOfNextMonth.AddDate(0, 0, -1).Add(23*time.Hour + 59*time.Minute + 59*time.Second)
 }
+
+// ParseDateInLayoutsKST 주어진 레이아웃 중 처음 일치하는 형식으로 KST 날짜 파싱 (명세서 가져오기용)
+func ParseDateInLayoutsKST(value string, layouts ...string) (time.Time, error) {
+	location, err := time.LoadLocation("Asia/Seoul")
+	if err != nil {
+		// Docker 환경에서 시간대 정보가 없는 경우 UTC+9 오프셋 사용
+		location = time.FixedZone("KST", 9*60*60) // UTC+9
+	}
+
+	for _, layout := range layouts {
+		if parsedDate, err := time.ParseInLocation(layout, value, location); err == nil {
+			return parsedDate, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("지원되지 않는 날짜 형식: %s", value)
+}
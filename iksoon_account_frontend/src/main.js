@@ -5,10 +5,50 @@ import 'element-plus/dist/index.css';
 import './assets/styles.css';
 import App from "./App.vue";
 import router from './router';
+import axios from 'axios';
+import { useAuthStore } from './stores/authStore';
+import { useLedgerStore } from './stores/ledgerStore';
 
 const app = createApp(App);
 const pinia = createPinia();
 app.use(pinia);
 app.use(ElementPlus);
 app.use(router);
+
+// 로그인 세션 토큰과 선택한 가계부 ID를 모든 API 요청에 첨부
+const authStore = useAuthStore(pinia);
+const ledgerStore = useLedgerStore(pinia);
+axios.interceptors.request.use((config) => {
+  if (authStore.token) {
+    config.headers.Authorization = `Bearer ${authStore.token}`;
+  }
+  if (ledgerStore.currentLedgerId) {
+    config.headers['X-Ledger-ID'] = ledgerStore.currentLedgerId;
+  }
+  return config;
+});
+
+// 서버가 인증을 요구하면(401) 세션을 지우고 로그인 페이지로 이동
+axios.interceptors.response.use(
+  (response) => response,
+  (error) => {
+    const isLoginRequest = error.config?.url?.endsWith('/auth/login');
+    if (error.response?.status === 401 && !isLoginRequest) {
+      authStore.clearSession();
+      ledgerStore.clearLedger();
+      if (router.currentRoute.value.name !== 'Login') {
+        router.push({ name: 'Login', query: { redirect: router.currentRoute.value.fullPath } });
+      }
+    }
+    // 선택한 가계부가 삭제되었으면 선택을 초기화하고 기본 가계부로 다시 시도
+    const isLedgerError = error.response?.data?.error?.code === 'LEDGER_NOT_FOUND';
+    if (isLedgerError && error.config?.headers?.['X-Ledger-ID'] && !error.config._ledgerRetried) {
+      ledgerStore.clearLedger();
+      delete error.config.headers['X-Ledger-ID'];
+      return axios({ ...error.config, _ledgerRetried: true });
+    }
+    return Promise.reject(error);
+  }
+);
+
 app.mount("#app");
\ No newline at end of file
@@ -5,6 +5,7 @@ import KeywordSearchPage from '../components/KeywordSearchPage.vue'
 import ExportDataPage from '../components/ExportDataPage.vue'
 import DetailPage from '../components/DetailPage.vue'
 import ManagementPage from '../components/ManagementPage.vue'
+import LoginPage from '../components/LoginPage.vue'
 
 const routes = [
   {
@@ -43,6 +44,11 @@ const routes = [
     path: '/management',
     name: 'Management',
     component: ManagementPage
+  },
+  {
+    path: '/login',
+    name: 'Login',
+    component: LoginPage
   }
 ]
 
@@ -0,0 +1,71 @@
+import { defineStore } from 'pinia';
+import axios from 'axios';
+import { getApiBaseUrl } from '../config';
+
+const BACKEND_API_BASE_URL = getApiBaseUrl();
+const TOKEN_STORAGE_KEY = 'auth_token';
+
+/**
+ * 로그인 세션 관리를 위한 Pinia 스토어
+ * 세션 토큰은 localStorage에 저장되며 axios 인터셉터에서 Authorization 헤더로 전송됩니다.
+ */
+export const useAuthStore = defineStore('auth', {
+  state: () => ({
+    token: localStorage.getItem(TOKEN_STORAGE_KEY) || '',
+    user: null,
+    authEnabled: false,
+  }),
+
+  getters: {
+    /**
+     * 로그인 여부
+     * @returns {boolean}
+     */
+    isLoggedIn: (state) => !!state.token && !!state.user,
+  },
+
+  actions: {
+    /**
+     * 사용자 이름과 비밀번호로 로그인합니다.
+     * @param {string} name - 사용자 이름
+     * @param {string} password - 비밀번호
+     */
+    async login(name, password) {
+      const response = await axios.post(`${BACKEND_API_BASE_URL}/auth/login`, { name, password });
+      this.token = response.data.token;
+      this.user = response.data.user;
+      localStorage.setItem(TOKEN_STORAGE_KEY, this.token);
+    },
+
+    /**
+     * 현재 세션을 폐기하고 로그아웃합니다.
+     */
+    async logout() {
+      try {
+        await axios.post(`${BACKEND_API_BASE_URL}/auth/logout`);
+      } catch (error) {
+        console.error('로그아웃 오류:', error);
+      } finally {
+        this.clearSession();
+      }
+    },
+
+    /**
+     * 현재 로그인 사용자와 인증 활성화 여부를 서버에서 가져옵니다.
+     */
+    async fetchMe() {
+      const response = await axios.get(`${BACKEND_API_BASE_URL}/auth/me`);
+      this.authEnabled = response.data.auth_enabled;
+      this.user = response.data.user;
+    },
+
+    /**
+     * 로컬에 저장된 세션 정보를 제거합니다.
+     */
+    clearSession() {
+      this.token = '';
+      this.user = null;
+      localStorage.removeItem(TOKEN_STORAGE_KEY);
+    },
+  },
+});
@@ -0,0 +1,67 @@
+import { defineStore } from 'pinia';
+import axios from 'axios';
+import { getApiBaseUrl } from '../config';
+
+const BACKEND_API_BASE_URL = getApiBaseUrl();
+const LEDGER_STORAGE_KEY = 'ledger_id';
+
+/**
+ * 가계부 선택을 위한 Pinia 스토어
+ * 선택한 가계부 ID는 localStorage에 저장되며 axios 인터셉터에서 X-Ledger-ID 헤더로 전송됩니다.
+ */
+export const useLedgerStore = defineStore('ledger', {
+  state: () => ({
+    ledgers: [],
+    currentLedgerId: Number(localStorage.getItem(LEDGER_STORAGE_KEY)) || null,
+  }),
+
+  getters: {
+    /**
+     * 현재 선택된 가계부
+     * @returns {Object|null}
+     */
+    currentLedger: (state) => state.ledgers.find((ledger) => ledger.id === state.currentLedgerId) || null,
+  },
+
+  actions: {
+    /**
+     * 접근 가능한 가계부 목록을 조회합니다.
+     * 저장된 가계부가 목록에 없으면 첫 번째 가계부를 선택합니다.
+     */
+    async fetchLedgers() {
+      const response = await axios.get(`${BACKEND_API_BASE_URL}/ledgers`);
+      this.ledgers = response.data || [];
+      if (this.ledgers.length > 0 && !this.ledgers.some((ledger) => ledger.id === this.currentLedgerId)) {
+        this.selectLedger(this.ledgers[0].id);
+      }
+    },
+
+    /**
+     * 작업할 가계부를 선택합니다.
+     * @param {number} ledgerId - 가계부 ID
+     */
+    selectLedger(ledgerId) {
+      this.currentLedgerId = ledgerId;
+      localStorage.setItem(LEDGER_STORAGE_KEY, String(ledgerId));
+    },
+
+    /**
+     * 새 가계부를 생성합니다.
+     * @param {string} name - 가계부 이름
+     */
+    async createLedger(name) {
+      const response = await axios.post(`${BACKEND_API_BASE_URL}/ledgers/create`, { name });
+      await this.fetchLedgers();
+      return response.data;
+    },
+
+    /**
+     * 선택된 가계부 정보를 초기화합니다. (로그아웃 시 사용)
+     */
+    clearLedger() {
+      this.ledgers = [];
+      this.currentLedgerId = null;
+      localStorage.removeItem(LEDGER_STORAGE_KEY);
+    },
+  },
+});
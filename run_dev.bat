@@ -21,6 +21,6 @@ if exist "config.env" (
 )
 
 echo [INFO] Go 백엔드 서버 시작...
-go run main.go
+go run -tags sqlite_fts5 .
 
 pause
\ No newline at end of file
@@ -31,4 +31,4 @@ else
 fi
 
 echo -e "${BLUE}[INFO]${NC} Go 백엔드 서버 시작..."
-go run main.go
+go run -tags sqlite_fts5 .